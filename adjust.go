@@ -14,7 +14,11 @@ package excelize
 import (
 	"bytes"
 	"encoding/xml"
+	"fmt"
 	"io"
+	"path/filepath"
+	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 	"unicode"
@@ -30,7 +34,7 @@ const (
 )
 
 // adjustHelperFunc defines functions to adjust helper.
-var adjustHelperFunc = [9]func(*File, *xlsxWorksheet, string, adjustDirection, int, int, int) error{
+var adjustHelperFunc = [11]func(*File, *xlsxWorksheet, string, adjustDirection, int, int, int) error{
 	func(f *File, ws *xlsxWorksheet, sheet string, dir adjustDirection, num, offset, sheetID int) error {
 		return f.adjustConditionalFormats(ws, sheet, dir, num, offset, sheetID)
 	},
@@ -43,6 +47,9 @@ var adjustHelperFunc = [9]func(*File, *xlsxWorksheet, string, adjustDirection, i
 	func(f *File, ws *xlsxWorksheet, sheet string, dir adjustDirection, num, offset, sheetID int) error {
 		return f.adjustDrawings(ws, sheet, dir, num, offset)
 	},
+	func(f *File, ws *xlsxWorksheet, sheet string, dir adjustDirection, num, offset, sheetID int) error {
+		return f.adjustComments(ws, sheet, dir, num, offset)
+	},
 	func(f *File, ws *xlsxWorksheet, sheet string, dir adjustDirection, num, offset, sheetID int) error {
 		return f.adjustMergeCells(ws, sheet, dir, num, offset, sheetID)
 	},
@@ -58,18 +65,31 @@ var adjustHelperFunc = [9]func(*File, *xlsxWorksheet, string, adjustDirection, i
 	func(f *File, ws *xlsxWorksheet, sheet string, dir adjustDirection, num, offset, sheetID int) error {
 		return f.adjustVolatileDeps(ws, sheet, dir, num, offset, sheetID)
 	},
+	func(f *File, ws *xlsxWorksheet, sheet string, dir adjustDirection, num, offset, sheetID int) error {
+		return f.adjustExtLst(ws, sheet, dir, num, offset, sheetID)
+	},
 }
 
+var (
+	// formulaCellRefExp matches the column and row parts of the cell reference
+	// in the formula range operand.
+	formulaCellRefExp = regexp.MustCompile(`^(\$?)([A-Za-z]{1,3})(\$?[0-9]*)$`)
+	// formulaRowRefExp matches the column and row parts of the cell reference
+	// or the row reference in the formula range operand.
+	formulaRowRefExp = regexp.MustCompile(`^((?:\$?[A-Za-z]{1,3})?)(\$?)([0-9]*)$`)
+)
+
 // adjustHelper provides a function to adjust rows and columns dimensions,
-// hyperlinks, merged cells and auto filter when inserting or deleting rows or
-// columns.
+// hyperlinks, merged cells, auto filter, drawings and comments when inserting
+// or deleting rows or columns, and update the used range of the worksheet if
+// the worksheet has the dimension.
 //
 // sheet: Worksheet name that we're editing
 // column: Index number of the column we're inserting/deleting before
 // row: Index number of the row we're inserting/deleting before
 // offset: Number of rows/column to insert/delete negative values indicate deletion
 //
-// TODO: adjustComments, adjustPageBreaks, adjustProtectedCells
+// TODO: adjustPageBreaks, adjustProtectedCells
 func (f *File) adjustHelper(sheet string, dir adjustDirection, num, offset int) error {
 	ws, err := f.workSheetReader(sheet)
 	if err != nil {
@@ -95,6 +115,9 @@ func (f *File) adjustHelper(sheet string, dir adjustDirection, num, offset int)
 	if ws.MergeCells != nil && len(ws.MergeCells.Cells) == 0 {
 		ws.MergeCells = nil
 	}
+	if ws.Dimension != nil {
+		return ws.updateDimension()
+	}
 	return nil
 }
 
@@ -120,16 +143,20 @@ func (f *File) adjustCols(ws *xlsxWorksheet, col, offset int) error {
 			}
 			continue
 		}
-		if ws.Cols.Col[i].Min == col && ws.Cols.Col[i].Max == col {
-			ws.Cols.Col = append(ws.Cols.Col[:i], ws.Cols.Col[i+1:]...)
-			i--
-			continue
-		}
-		if ws.Cols.Col[i].Min > col {
+		// The removed columns range from col to col-offset-1
+		if lastCol := col - offset - 1; ws.Cols.Col[i].Min > lastCol {
 			ws.Cols.Col[i].Min += offset
+		} else if ws.Cols.Col[i].Min > col {
+			ws.Cols.Col[i].Min = col
 		}
 		if ws.Cols.Col[i].Max >= col {
-			ws.Cols.Col[i].Max += offset
+			if ws.Cols.Col[i].Max += offset; ws.Cols.Col[i].Max < col {
+				ws.Cols.Col[i].Max = col - 1
+			}
+		}
+		if ws.Cols.Col[i].Min > ws.Cols.Col[i].Max {
+			ws.Cols.Col = append(ws.Cols.Col[:i], ws.Cols.Col[i+1:]...)
+			i--
 		}
 	}
 	if len(ws.Cols.Col) == 0 {
@@ -241,12 +268,17 @@ func (f *File) adjustCellRef(cellRef string, dir adjustDirection, num, offset in
 	var SQRef []string
 	applyOffset := func(coordinates []int, idx1, idx2, maxVal int) []int {
 		if coordinates[idx1] >= num {
-			coordinates[idx1] += offset
+			if coordinates[idx1] += offset; coordinates[idx1] < num {
+				coordinates[idx1] = num
+			}
 		}
 		if coordinates[idx2] >= num {
 			if coordinates[idx2] += offset; coordinates[idx2] > maxVal {
 				coordinates[idx2] = maxVal
 			}
+			if coordinates[idx2] < num {
+				coordinates[idx2] = num - 1
+			}
 		}
 		return coordinates
 	}
@@ -254,22 +286,23 @@ func (f *File) adjustCellRef(cellRef string, dir adjustDirection, num, offset in
 		if !strings.Contains(ref, ":") {
 			ref += ":" + ref
 		}
+		cells := strings.Split(ref, ":")
 		coordinates, err := rangeRefToCoordinates(ref)
 		if err != nil {
 			return "", err
 		}
 		if dir == columns {
-			if offset < 0 && coordinates[0] == coordinates[2] && num == coordinates[0] {
+			if offset < 0 && coordinates[0] >= num && coordinates[2] < num-offset {
 				continue
 			}
 			coordinates = applyOffset(coordinates, 0, 2, MaxColumns)
 		} else {
-			if offset < 0 && coordinates[1] == coordinates[3] && num == coordinates[1] {
+			if offset < 0 && coordinates[1] >= num && coordinates[3] < num-offset {
 				continue
 			}
 			coordinates = applyOffset(coordinates, 1, 3, TotalRows)
 		}
-		if ref, err = coordinatesToRangeRef(coordinates); err != nil {
+		if ref, err = joinAdjustedRangeRef(coordinates, cells); err != nil {
 			return "", err
 		}
 		SQRef = append(SQRef, ref)
@@ -277,6 +310,32 @@ func (f *File) adjustCellRef(cellRef string, dir adjustDirection, num, offset in
 	return strings.Join(SQRef, " "), nil
 }
 
+// joinAdjustedRangeRef provides a function to build the adjusted range
+// reference by given coordinates and the cell references of the original
+// range, the absolute column and row of the original cell references will be
+// kept.
+func joinAdjustedRangeRef(coordinates []int, cells []string) (string, error) {
+	refs := make([]string, 2)
+	for i := range refs {
+		colName, err := ColumnNumberToName(coordinates[i*2])
+		if err != nil {
+			return "", err
+		}
+		if coordinates[i*2+1] < 1 || coordinates[i*2+1] > TotalRows {
+			return "", ErrMaxRows
+		}
+		rowNum := strconv.Itoa(coordinates[i*2+1])
+		if strings.HasPrefix(cells[i], "$") {
+			colName = "$" + colName
+		}
+		if strings.Contains(strings.TrimPrefix(cells[i], "$"), "$") {
+			rowNum = "$" + rowNum
+		}
+		refs[i] = colName + rowNum
+	}
+	return refs[0] + ":" + refs[1], nil
+}
+
 // adjustFormula provides a function to adjust formula reference and shared
 // formula reference.
 func (f *File) adjustFormula(sheet, sheetN string, cell *xlsxC, dir adjustDirection, num, offset int, si bool) error {
@@ -326,7 +385,10 @@ func adjustFormulaColumnName(name, operand string, abs, keepRelative bool, dir a
 		return "", operand, false, err
 	}
 	if dir == columns && col >= num {
-		if col += offset; col < 1 {
+		if col += offset; col < num {
+			col = num - 1
+		}
+		if col < 1 {
 			col = 1
 		}
 		colName, err := ColumnNumberToName(col)
@@ -464,7 +526,7 @@ func adjustRangeSheetName(rng, source, target string) string {
 		for j, rangeRef := range rangeRefs {
 			parts := strings.Split(rangeRef, "!")
 			for k, part := range parts {
-				if strings.TrimPrefix(strings.TrimSuffix(part, "'"), "'") == source {
+				if strings.EqualFold(strings.TrimPrefix(strings.TrimSuffix(part, "'"), "'"), source) {
 					part = escapeSheetName(target)
 				}
 				parts[k] = part
@@ -593,9 +655,21 @@ func (f *File) adjustHyperlinks(ws *xlsxWorksheet, sheet string, dir adjustDirec
 	if offset < 0 {
 		for i := len(ws.Hyperlinks.Hyperlink) - 1; i >= 0; i-- {
 			linkData := ws.Hyperlinks.Hyperlink[i]
-			colNum, rowNum, _ := CellNameToCoordinates(linkData.Ref)
-
-			if (dir == rows && num == rowNum) || (dir == columns && num == colNum) {
+			ref := linkData.Ref
+			if !strings.Contains(ref, ":") {
+				ref += ":" + ref
+			}
+			coordinates, err := rangeRefToCoordinates(ref)
+			if err != nil {
+				continue
+			}
+			// Delete the hyperlink which anchored in the deleted rows or
+			// columns entirely
+			p1, p2 := coordinates[0], coordinates[2]
+			if dir == rows {
+				p1, p2 = coordinates[1], coordinates[3]
+			}
+			if num <= p1 && p2 < num-offset {
 				f.deleteSheetRelationships(sheet, linkData.RID)
 				if len(ws.Hyperlinks.Hyperlink) > 1 {
 					ws.Hyperlinks.Hyperlink = append(ws.Hyperlinks.Hyperlink[:i],
@@ -618,6 +692,20 @@ func (f *File) adjustHyperlinks(ws *xlsxWorksheet, sheet string, dir adjustDirec
 // adjustTable provides a function to update the table when inserting or
 // deleting rows or columns.
 func (f *File) adjustTable(ws *xlsxWorksheet, sheet string, dir adjustDirection, num, offset, sheetID int) error {
+	return f.updateTables(ws, sheet, dir, func(coordinates []int) bool {
+		// Remove the table when deleting the header row of the table
+		if dir == rows && num == coordinates[0] && offset == -1 {
+			return false
+		}
+		f.adjustAutoFilterHelper(dir, coordinates, num, offset)
+		return true
+	})
+}
+
+// updateTables provides a function to update the range of the tables by
+// given function, which updates the coordinates of the table range in place
+// and returns false if the table should be removed.
+func (f *File) updateTables(ws *xlsxWorksheet, sheet string, dir adjustDirection, fn func(coordinates []int) bool) error {
 	if ws.TableParts == nil || len(ws.TableParts.TableParts) == 0 {
 		return nil
 	}
@@ -638,16 +726,9 @@ func (f *File) adjustTable(ws *xlsxWorksheet, sheet string, dir adjustDirection,
 		if err != nil {
 			return err
 		}
-		// Remove the table when deleting the header row of the table
-		if dir == rows && num == coordinates[0] && offset == -1 {
-			ws.TableParts.TableParts = append(ws.TableParts.TableParts[:idx], ws.TableParts.TableParts[idx+1:]...)
-			ws.TableParts.Count = len(ws.TableParts.TableParts)
-			idx--
-			continue
-		}
-		coordinates = f.adjustAutoFilterHelper(dir, coordinates, num, offset)
+		keep := fn(coordinates)
 		x1, y1, x2, y2 := coordinates[0], coordinates[1], coordinates[2], coordinates[3]
-		if y2-y1 < 1 || x2-x1 < 0 {
+		if !keep || y2-y1 < 1 || x2-x1 < 0 {
 			ws.TableParts.TableParts = append(ws.TableParts.TableParts[:idx], ws.TableParts.TableParts[idx+1:]...)
 			ws.TableParts.Count = len(ws.TableParts.TableParts)
 			idx--
@@ -657,9 +738,13 @@ func (f *File) adjustTable(ws *xlsxWorksheet, sheet string, dir adjustDirection,
 		if t.AutoFilter != nil {
 			t.AutoFilter.Ref = t.Ref
 		}
-		_ = f.setTableColumns(sheet, true, x1, y1, x2, &t)
-		// Currently doesn't support query table
-		t.TableType, t.TotalsRowCount, t.ConnectionID = "", 0, 0
+		// Keep the query table linked with the connection when the columns of
+		// the table are unchanged
+		if t.TableType != "queryTable" || dir != rows {
+			_ = f.setTableColumns(sheet, true, x1, y1, x2, &t)
+			// Currently doesn't support adjust columns of query table
+			t.TableType, t.TotalsRowCount, t.ConnectionID = "", 0, 0
+		}
 		table, _ := xml.Marshal(t)
 		f.saveFileList(tableXML, table)
 	}
@@ -679,7 +764,7 @@ func (f *File) adjustAutoFilter(ws *xlsxWorksheet, sheet string, dir adjustDirec
 	}
 	x1, y1, x2, y2 := coordinates[0], coordinates[1], coordinates[2], coordinates[3]
 
-	if (dir == rows && y1 == num && offset < 0) || (dir == columns && x1 == num && x2 == num) {
+	if (dir == rows && y1 == num && offset < 0) || (dir == columns && ((x1 == num && x2 == num) || (offset < 0 && x1 >= num && x2 < num-offset))) {
 		ws.AutoFilter = nil
 		for rowIdx := range ws.SheetData.Row {
 			rowData := &ws.SheetData.Row[rowIdx]
@@ -710,11 +795,12 @@ func (f *File) adjustAutoFilterHelper(dir adjustDirection, coordinates []int, nu
 		}
 		return coordinates
 	}
-	if coordinates[0] >= num {
-		coordinates[0] += offset
-	}
-	if coordinates[2] >= num {
-		coordinates[2] += offset
+	for _, idx := range []int{0, 2} {
+		if coordinates[idx] >= num {
+			if coordinates[idx] += offset; coordinates[idx] < num {
+				coordinates[idx] = num - 1
+			}
+		}
 	}
 	return coordinates
 }
@@ -746,7 +832,7 @@ func (f *File) adjustMergeCells(ws *xlsxWorksheet, sheet string, dir adjustDirec
 
 			y1, y2 = f.adjustMergeCellsHelper(y1, y2, num, offset)
 		} else {
-			if x1 == num && x2 == num && offset < 0 {
+			if x1 >= num && x2 < num-offset && offset < 0 {
 				f.deleteMergeCell(ws, i)
 				i--
 				continue
@@ -784,11 +870,16 @@ func (f *File) adjustMergeCellsHelper(p1, p2, num, offset int) (int, int) {
 		}
 		return p1, p2
 	}
-	if num < p1 || (num == p1 && num == p2) {
+	// The removed range is from num to num-offset-1
+	if lastNum := num - offset - 1; p1 > lastNum {
 		p1 += offset
-		p2 += offset
-	} else if num <= p2 {
-		p2 += offset
+	} else if p1 >= num {
+		p1 = num
+	}
+	if p2 >= num {
+		if p2 += offset; p2 < num {
+			p2 = num - 1
+		}
 	}
 	return p1, p2
 }
@@ -846,7 +937,7 @@ func (f *File) adjustCalcChain(ws *xlsxWorksheet, sheet string, dir adjustDirect
 			f.CalcChain.C[i].R, _ = adjustCellName(c.R, dir, colNum, rowNum, offset)
 		}
 		if dir == columns && num <= colNum {
-			if num == colNum && offset == -1 {
+			if colNum < num-offset {
 				_ = f.deleteCalcChain(c.I, c.R)
 				i--
 				continue
@@ -874,7 +965,7 @@ func (vt *xlsxVolTypes) adjustVolatileDepsTopic(cell string, dir adjustDirection
 		vt.VolType[i1].Main[i2].Tp[i3].Tr[i4].R, _ = adjustCellName(cell, dir, colNum, rowNum, offset)
 	}
 	if dir == columns && num <= colNum {
-		if num == colNum && offset == -1 {
+		if colNum < num-offset {
 			vt.deleteVolTopicRef(i1, i2, i3, i4)
 			i4--
 			return i4, err
@@ -930,10 +1021,227 @@ func (f *File) adjustConditionalFormats(ws *xlsxWorksheet, sheet string, dir adj
 			continue
 		}
 		ws.ConditionalFormatting[i].SQRef = ref
+		for _, rule := range cf.CfRule {
+			if rule == nil {
+				continue
+			}
+			for j, formula := range rule.Formula {
+				if rule.Formula[j], err = f.adjustFormulaRef(sheet, sheet, formula, false, dir, num, offset); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// adjustExtLst updates the cell reference of the sparklines and the
+// conditional formatting in the worksheet extension list when inserting or
+// deleting rows or columns. The sparklines and the conditional formatting
+// in the deleted range will be removed.
+func (f *File) adjustExtLst(ws *xlsxWorksheet, sheet string, dir adjustDirection, num, offset, sheetID int) error {
+	return f.updateExtLst(sheet, func(ref string) (string, error) {
+		ref, err := f.adjustCellRef(ref, dir, num, offset)
+		if err != nil || ref == "" {
+			return ref, err
+		}
+		refs := strings.Split(ref, " ")
+		for i, r := range refs {
+			if cells := strings.Split(r, ":"); len(cells) == 2 && cells[0] == cells[1] {
+				refs[i] = cells[0]
+			}
+		}
+		return strings.Join(refs, " "), err
+	}, func(sheetN, formula string) (string, error) {
+		return f.adjustFormulaRef(sheet, sheetN, formula, false, dir, num, offset)
+	})
+}
+
+// updateExtLst provides a function to update the cell reference of the
+// sparklines and the conditional formatting in the extension list of each
+// worksheet by given functions. The reference sequences of the given
+// worksheet will be updated by the adjustRef function, which returns an empty
+// string if the whole reference sequence is deleted, and the formulas
+// referring to the given worksheet will be updated by the adjustFormula
+// function.
+func (f *File) updateExtLst(sheet string, adjustRef func(ref string) (string, error), adjustFormula func(sheetN, formula string) (string, error)) error {
+	for _, sheetN := range f.GetSheetList() {
+		worksheet, err := f.workSheetReader(sheetN)
+		if err != nil {
+			if err.Error() == newNotWorksheetError(sheetN).Error() {
+				continue
+			}
+			return err
+		}
+		if worksheet.ExtLst == nil {
+			continue
+		}
+		decodeExtLst := new(decodeExtLst)
+		if err = f.xmlNewDecoder(strings.NewReader("<extLst>" + worksheet.ExtLst.Ext + "</extLst>")).
+			Decode(decodeExtLst); err != nil && err != io.EOF {
+			return err
+		}
+		var exts []*xlsxExt
+		for _, ext := range decodeExtLst.Ext {
+			if ext.URI == ExtURISparklineGroups || ext.URI == ExtURIConditionalFormattings {
+				refFn := adjustRef
+				if sheet != sheetN {
+					refFn = nil
+				}
+				if ext.Content, err = f.updateExtElements(ext, refFn, func(formula string) (string, error) {
+					return adjustFormula(sheetN, formula)
+				}); err != nil {
+					return err
+				}
+			}
+			if ext.Content != "" {
+				exts = append(exts, ext)
+			}
+		}
+		if decodeExtLst.Ext = exts; len(exts) == 0 {
+			worksheet.ExtLst = nil
+			continue
+		}
+		extLstBytes, err := xml.Marshal(decodeExtLst)
+		if err != nil {
+			return err
+		}
+		worksheet.ExtLst = &xlsxExtLst{Ext: strings.TrimSuffix(strings.TrimPrefix(string(extLstBytes), "<extLst>"), "</extLst>")}
 	}
 	return nil
 }
 
+// extElementEdit defines the replacement of the bytes range from start to end
+// in the extension content.
+type extElementEdit struct {
+	start, end int
+	text       string
+}
+
+// extElementFrame defines the state of the sparkline group, sparkline or
+// conditional formatting element when walking the extension content.
+type extElementFrame struct {
+	name              string
+	start             int
+	deleted, hasSQRef bool
+	children          int
+	edits             []extElementEdit
+}
+
+// updateExtElements provides a function to update the sqref and formula
+// references of the sparklines or conditional formatting in the given
+// extension by given functions. The elements are matched by the namespace
+// instead of the prefix, and the unchanged parts of the content will be kept
+// as is. The reference sequence will be kept if the adjustRef function is
+// nil. The sparkline or conditional formatting whose references are all
+// deleted will be removed, and the empty content will be returned if no
+// element remaining.
+func (f *File) updateExtElements(ext *xlsxExt, adjustRef, adjustFormula func(string) (string, error)) (string, error) {
+	var decl strings.Builder
+	declared := map[string]bool{}
+	decl.WriteString("<ext")
+	for _, attr := range ext.xmlns {
+		if prefix, ok := strings.CutPrefix(attr.Name.Local, "xmlns:"); ok && attr.Name.Space == "" {
+			declared[prefix] = true
+			decl.WriteString(" " + attr.Name.Local + `="`)
+			_ = xml.EscapeText(&decl, []byte(attr.Value))
+			decl.WriteString(`"`)
+		}
+	}
+	for _, attr := range []xml.Attr{NameSpaceSpreadSheetX14, NameSpaceSpreadSheetExcel2006Main} {
+		if !declared[attr.Name.Local] {
+			decl.WriteString(" xmlns:" + attr.Name.Local + `="` + attr.Value + `"`)
+		}
+	}
+	decl.WriteString(">")
+	prefix, x14, xm := decl.String(), NameSpaceSpreadSheetX14.Value, NameSpaceSpreadSheetExcel2006Main.Value
+	d := f.xmlNewDecoder(strings.NewReader(prefix + ext.Content + "</ext>"))
+	var (
+		stack   []*extElementFrame
+		edits   []extElementEdit
+		text    *extElementEdit
+		name    string
+		matched bool
+	)
+	for {
+		start := int(d.InputOffset()) - len(prefix)
+		token, err := d.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return ext.Content, err
+		}
+		switch t := token.(type) {
+		case xml.StartElement:
+			if t.Name.Space == x14 && inStrSlice([]string{"sparklineGroup", "sparkline", "conditionalFormatting"}, t.Name.Local, true) != -1 {
+				stack = append(stack, &extElementFrame{name: t.Name.Local, start: start})
+			}
+			if t.Name.Space == xm && (t.Name.Local == "f" || t.Name.Local == "sqref") && len(stack) > 0 {
+				text, name = &extElementEdit{start: int(d.InputOffset()) - len(prefix)}, t.Name.Local
+			}
+		case xml.CharData:
+			if text != nil {
+				text.text += string(t)
+			}
+		case xml.EndElement:
+			if text != nil && t.Name.Space == xm && t.Name.Local == name {
+				frame := stack[len(stack)-1]
+				text.end = start
+				if name == "sqref" {
+					frame.hasSQRef = true
+					if adjustRef != nil {
+						if text.text, err = adjustRef(text.text); err != nil {
+							return ext.Content, err
+						}
+						frame.deleted = text.text == ""
+						frame.edits = append(frame.edits, *text)
+					}
+				} else if formula, err := adjustFormula(text.text); err == nil {
+					text.text = formulaEscaper.Replace(formula)
+					frame.edits = append(frame.edits, *text)
+				}
+				text = nil
+			}
+			if len(stack) == 0 || t.Name.Space != x14 || t.Name.Local != stack[len(stack)-1].name {
+				continue
+			}
+			frame, parent := stack[len(stack)-1], &edits
+			if stack = stack[:len(stack)-1]; len(stack) > 0 {
+				parent = &stack[len(stack)-1].edits
+			}
+			deleted := frame.deleted
+			switch frame.name {
+			case "sparkline":
+				deleted = deleted || !frame.hasSQRef
+			case "sparklineGroup":
+				deleted = frame.children == 0
+			}
+			if deleted {
+				*parent = append(*parent, extElementEdit{start: frame.start, end: int(d.InputOffset()) - len(prefix)})
+				continue
+			}
+			*parent = append(*parent, frame.edits...)
+			if len(stack) > 0 {
+				stack[len(stack)-1].children++
+				continue
+			}
+			matched = true
+		}
+	}
+	if !matched {
+		return "", nil
+	}
+	var content strings.Builder
+	last := 0
+	for _, edit := range edits {
+		content.WriteString(ext.Content[last:edit.start] + edit.text)
+		last = edit.end
+	}
+	content.WriteString(ext.Content[last:])
+	return content.String(), nil
+}
+
 // adjustDataValidations updates the range of data validations for the worksheet
 // when inserting or deleting rows or columns.
 func (f *File) adjustDataValidations(ws *xlsxWorksheet, sheet string, dir adjustDirection, num, offset, sheetID int) error {
@@ -992,11 +1300,11 @@ func (f *File) adjustDataValidations(ws *xlsxWorksheet, sheet string, dir adjust
 // and charts object when inserting or deleting rows or columns.
 func (from *xlsxFrom) adjustDrawings(dir adjustDirection, num, offset int, editAs string) (bool, error) {
 	var ok bool
-	if dir == columns && from.Col+1 >= num && from.Col+offset >= 0 {
-		if from.Col+offset >= MaxColumns {
+	if col := max(from.Col+offset, num-2); dir == columns && from.Col+1 >= num && col >= 0 {
+		if col >= MaxColumns {
 			return false, ErrColumnNumber
 		}
-		from.Col += offset
+		from.Col = col
 		ok = editAs == "oneCell"
 	}
 	if dir == rows && from.Row+1 >= num && from.Row+offset >= 0 {
@@ -1012,11 +1320,11 @@ func (from *xlsxFrom) adjustDrawings(dir adjustDirection, num, offset int, editA
 // adjustDrawings updates the ending anchor of the two cell anchor pictures
 // and charts object when inserting or deleting rows or columns.
 func (to *xlsxTo) adjustDrawings(dir adjustDirection, num, offset int, ok bool) error {
-	if dir == columns && to.Col+1 >= num && to.Col+offset >= 0 && ok {
-		if to.Col+offset >= MaxColumns {
+	if col := max(to.Col+offset, num-2); dir == columns && to.Col+1 >= num && col >= 0 && ok {
+		if col >= MaxColumns {
 			return ErrColumnNumber
 		}
-		to.Col += offset
+		to.Col = col
 	}
 	if dir == rows && to.Row+1 >= num && to.Row+offset >= 0 && ok {
 		if to.Row+offset >= TotalRows {
@@ -1027,35 +1335,15 @@ func (to *xlsxTo) adjustDrawings(dir adjustDirection, num, offset int, ok bool)
 	return nil
 }
 
-// adjustDrawings updates the two cell anchor pictures and charts object when
-// inserting or deleting rows or columns.
-func (a *xdrCellAnchor) adjustDrawings(dir adjustDirection, num, offset int) error {
-	editAs := a.EditAs
-	if (a.From == nil && (a.To == nil || a.Ext == nil)) || editAs == "absolute" {
-		return nil
-	}
-	ok, err := a.From.adjustDrawings(dir, num, offset, editAs)
-	if err != nil {
-		return err
-	}
-	if a.To != nil {
-		return a.To.adjustDrawings(dir, num, offset, ok || editAs == "")
-	}
-	return err
-}
-
-// adjustDrawings updates the existing two cell anchor pictures and charts
-// object when inserting or deleting rows or columns.
-func (a *xlsxCellAnchorPos) adjustDrawings(dir adjustDirection, num, offset int, editAs string) error {
-	if (a.From == nil && (a.To == nil || a.Ext == nil)) || editAs == "absolute" {
-		return nil
-	}
-	ok, err := a.From.adjustDrawings(dir, num, offset, editAs)
+// adjustCellAnchor updates the two cell anchor pictures and charts object
+// when inserting or deleting rows or columns.
+func adjustCellAnchor(from *xlsxFrom, to *xlsxTo, editAs string, dir adjustDirection, num, offset int) error {
+	ok, err := from.adjustDrawings(dir, num, offset, editAs)
 	if err != nil {
 		return err
 	}
-	if a.To != nil {
-		return a.To.adjustDrawings(dir, num, offset, ok || editAs == "")
+	if to != nil {
+		return to.adjustDrawings(dir, num, offset, ok || editAs == "")
 	}
 	return err
 }
@@ -1063,6 +1351,15 @@ func (a *xlsxCellAnchorPos) adjustDrawings(dir adjustDirection, num, offset int,
 // adjustDrawings updates the pictures and charts object when inserting or
 // deleting rows or columns.
 func (f *File) adjustDrawings(ws *xlsxWorksheet, sheet string, dir adjustDirection, num, offset int) error {
+	return f.updateDrawingAnchors(ws, sheet, func(from *xlsxFrom, to *xlsxTo, editAs string) error {
+		return adjustCellAnchor(from, to, editAs, dir, num, offset)
+	})
+}
+
+// updateDrawingAnchors provides a function to update the starting and ending
+// anchors of the pictures and charts object in the worksheet drawing by given
+// update function. The objects with absolute positioning will stay put.
+func (f *File) updateDrawingAnchors(ws *xlsxWorksheet, sheet string, fn func(from *xlsxFrom, to *xlsxTo, editAs string) error) error {
 	if ws.Drawing == nil {
 		return nil
 	}
@@ -1077,7 +1374,10 @@ func (f *File) adjustDrawings(ws *xlsxWorksheet, sheet string, dir adjustDirecti
 	}
 	anchorCb := func(a *xdrCellAnchor) error {
 		if a.GraphicFrame == "" {
-			return a.adjustDrawings(dir, num, offset)
+			if a.From == nil || a.EditAs == "absolute" {
+				return nil
+			}
+			return fn(a.From, a.To, a.EditAs)
 		}
 		deCellAnchor := decodeCellAnchor{}
 		deCellAnchorPos := decodeCellAnchorPos{}
@@ -1099,7 +1399,10 @@ func (f *File) adjustDrawings(ws *xlsxWorksheet, sheet string, dir adjustDirecti
 				Row: deCellAnchor.To.Row, RowOff: deCellAnchor.To.RowOff,
 			}
 		}
-		if err = xlsxCellAnchorPos.adjustDrawings(dir, num, offset, a.EditAs); err != nil {
+		if xlsxCellAnchorPos.From == nil || a.EditAs == "absolute" {
+			return nil
+		}
+		if err = fn(xlsxCellAnchorPos.From, xlsxCellAnchorPos.To, a.EditAs); err != nil {
 			return err
 		}
 		cellAnchor, _ := xml.Marshal(xlsxCellAnchorPos)
@@ -1119,6 +1422,211 @@ func (f *File) adjustDrawings(ws *xlsxWorksheet, sheet string, dir adjustDirecti
 	return nil
 }
 
+// adjustComments updates the cell reference of the comments and the anchor of
+// the VML shapes when inserting or deleting rows or columns. The comments in
+// the deleted rows or columns will be removed, and the form controls with
+// absolute positioning will stay put.
+func (f *File) adjustComments(ws *xlsxWorksheet, sheet string, dir adjustDirection, num, offset int) error {
+	return f.updateComments(ws, sheet, dir, func(idx int) (int, bool) {
+		if idx < num {
+			return idx, false
+		}
+		return max(idx+offset, num-1, 1), offset < 0 && idx < num-offset
+	})
+}
+
+// updateComments provides a function to update the cell reference of the
+// comments and the anchor of the VML shapes by given function which maps the
+// row or column number before updating to the number after that, and reports
+// whether the row or column is deleted.
+func (f *File) updateComments(ws *xlsxWorksheet, sheet string, dir adjustDirection, remap func(idx int) (int, bool)) error {
+	if ws.LegacyDrawing == nil {
+		return nil
+	}
+	sheetXMLPath, _ := f.getSheetXMLPath(sheet)
+	if commentsXML := f.getSheetComments(filepath.Base(sheetXMLPath)); commentsXML != "" {
+		if !strings.HasPrefix(commentsXML, "/") {
+			commentsXML = "xl" + strings.TrimPrefix(commentsXML, "..")
+		}
+		commentsXML = strings.TrimPrefix(commentsXML, "/")
+		cmts, err := f.commentsReader(commentsXML)
+		if err != nil {
+			return err
+		}
+		if cmts != nil {
+			var list []xlsxComment
+			for _, cmt := range cmts.CommentList.Comment {
+				col, row, err := CellNameToCoordinates(cmt.Ref)
+				if err != nil {
+					return err
+				}
+				idx := &col
+				if dir == rows {
+					idx = &row
+				}
+				var deleted bool
+				if *idx, deleted = remap(*idx); deleted {
+					continue
+				}
+				if cmt.Ref, err = CoordinatesToCellName(col, row); err != nil {
+					return err
+				}
+				list = append(list, cmt)
+			}
+			cmts.CommentList.Comment = list
+		}
+	}
+	drawingVML, vml, err := f.vmlDrawingReader(f.getSheetRelationshipsTargetByID(sheet, ws.LegacyDrawing.RID))
+	if err != nil {
+		return err
+	}
+	var shapes []xlsxShape
+	for _, sp := range vml.Shape {
+		var shapeVal decodeShapeVal
+		if err = xml.Unmarshal([]byte(fmt.Sprintf("<shape>%s</shape>", sp.Val)), &shapeVal); err != nil ||
+			shapeVal.ClientData.Anchor == "" {
+			shapes = append(shapes, sp)
+			continue
+		}
+		isComment := shapeVal.ClientData.ObjectType == "Note"
+		if !isComment && shapeVal.ClientData.MoveWithCells != nil {
+			shapes = append(shapes, sp)
+			continue
+		}
+		if isComment {
+			idx, ref := shapeVal.ClientData.Column, 0
+			if dir == rows {
+				idx, ref = shapeVal.ClientData.Row, 2
+			}
+			if idx == nil {
+				cell, _ := strconv.Atoi(strings.TrimSpace(strings.Split(shapeVal.ClientData.Anchor, ",")[ref]))
+				idx = &cell
+			}
+			if _, deleted := remap(*idx + 1); deleted {
+				continue
+			}
+		}
+		if sp.Val, err = updateVMLShape(sp.Val, dir, func(idx int) int {
+			idx, _ = remap(idx)
+			return idx
+		}); err != nil {
+			return err
+		}
+		shapes = append(shapes, sp)
+	}
+	vml.Shape = shapes
+	f.VMLDrawing[drawingVML] = vml
+	return nil
+}
+
+// adjustVMLShape updates the anchor, row and column of the VML shape client
+// data when inserting or deleting rows or columns.
+func adjustVMLShape(val string, dir adjustDirection, num, offset int) (string, error) {
+	return updateVMLShape(val, dir, func(idx int) int {
+		if idx < num {
+			return idx
+		}
+		return max(idx+offset, num-1, 1)
+	})
+}
+
+// updateVMLShape updates the anchor, row and column of the VML shape client
+// data by given function which maps the row or column number before updating
+// to the number after that. The elements of the client data will be matched by
+// the local name, regardless of the namespace prefix used in the shape.
+func updateVMLShape(val string, dir adjustDirection, remap func(idx int) int) (string, error) {
+	adjust := func(idx int) (int, error) {
+		idx = remap(idx+1) - 1
+		if dir == columns && idx >= MaxColumns {
+			return idx, ErrColumnNumber
+		}
+		if dir == rows && idx >= TotalRows {
+			return idx, ErrMaxRows
+		}
+		return idx, nil
+	}
+	name, pos := "Column", []int{0, 4}
+	if dir == rows {
+		name, pos = "Row", []int{2, 6}
+	}
+	prefix := "<shape>"
+	d := xml.NewDecoder(strings.NewReader(prefix + val + "</shape>"))
+	d.Strict = false
+	var (
+		edits []extElementEdit
+		text  *extElementEdit
+	)
+	for {
+		start := int(d.InputOffset()) - len(prefix)
+		token, err := d.RawToken()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return val, err
+		}
+		switch t := token.(type) {
+		case xml.StartElement:
+			if t.Name.Local == "Anchor" || t.Name.Local == name {
+				text = &extElementEdit{start: int(d.InputOffset()) - len(prefix)}
+			}
+		case xml.CharData:
+			if text != nil {
+				text.text += string(t)
+			}
+		case xml.EndElement:
+			if text == nil || (t.Name.Local != "Anchor" && t.Name.Local != name) {
+				continue
+			}
+			text.end = start
+			if t.Name.Local == name {
+				idx, e := strconv.Atoi(strings.TrimSpace(text.text))
+				if e != nil {
+					text = nil
+					continue
+				}
+				if idx, err = adjust(idx); err != nil {
+					return val, err
+				}
+				text.text = strconv.Itoa(idx)
+				edits, text = append(edits, *text), nil
+				continue
+			}
+			anchor := strings.Split(text.text, ",")
+			if len(anchor) != 8 {
+				text = nil
+				continue
+			}
+			valid := true
+			for _, i := range pos {
+				idx, e := strconv.Atoi(strings.TrimSpace(anchor[i]))
+				if e != nil {
+					valid = false
+					break
+				}
+				if idx, err = adjust(idx); err != nil {
+					return val, err
+				}
+				anchor[i] = " " + strconv.Itoa(idx)
+			}
+			if valid {
+				anchor[0] = strings.TrimSpace(anchor[0])
+				text.text = strings.Join(anchor, ",")
+				edits = append(edits, *text)
+			}
+			text = nil
+		}
+	}
+	var content strings.Builder
+	last := 0
+	for _, edit := range edits {
+		content.WriteString(val[last:edit.start] + edit.text)
+		last = edit.end
+	}
+	content.WriteString(val[last:])
+	return content.String(), nil
+}
+
 // adjustDefinedNames updates the cell reference of the defined names when
 // inserting or deleting rows or columns.
 func (f *File) adjustDefinedNames(sheet string, dir adjustDirection, num, offset int) error {
@@ -1128,11 +1636,908 @@ func (f *File) adjustDefinedNames(sheet string, dir adjustDirection, num, offset
 	}
 	if wb.DefinedNames != nil {
 		for i := 0; i < len(wb.DefinedNames.DefinedName); i++ {
+			if wb.DefinedNames.DefinedName[i].isStringConstant() {
+				continue
+			}
 			data := wb.DefinedNames.DefinedName[i].Data
-			if data, err = f.adjustFormulaRef(sheet, "", data, true, dir, num, offset); err == nil {
+			if data, err = adjustDefinedNameRef(sheet, data, dir, num, offset); err == nil {
 				wb.DefinedNames.DefinedName[i].Data = data
 			}
 		}
 	}
 	return nil
 }
+
+// definedNameAreaPattern matches the part of the area reference in the defined
+// name, such as $A$1, $A, or $1.
+var definedNameAreaPattern = regexp.MustCompile(`^(\$?)([A-Za-z]*)(\$?)([0-9]*)$`)
+
+// adjustDefinedNameRef returns the adjusted reference of the defined name by
+// giving the worksheet name, adjusting direction, the base number of column
+// or row, and offset. The references to other worksheets and the relative
+// references will be kept, the areas which are entirely removed will be
+// replaced with the #REF! error, and the reference will be kept if it can't
+// be parsed.
+func adjustDefinedNameRef(sheet, data string, dir adjustDirection, num, offset int) (string, error) {
+	ps := efp.ExcelParser()
+	tokens := ps.Parse(data)
+	for i, token := range tokens {
+		if token.TType == efp.TokenTypeUnknown {
+			if strings.HasSuffix(token.TValue, "!") && i+1 < len(tokens) && tokens[i+1].TSubType == efp.TokenSubTypeError {
+				tokens[i].TValue = escapeSheetName(strings.TrimSuffix(token.TValue, "!")) + "!"
+				continue
+			}
+			return data, nil
+		}
+		if token.TType != efp.TokenTypeOperand || token.TSubType != efp.TokenSubTypeRange {
+			continue
+		}
+		parts := strings.Split(token.TValue, "!")
+		if len(parts) != 2 || strings.ContainsAny(token.TValue, "[]") {
+			continue
+		}
+		area := parts[1]
+		if parts[0] == sheet {
+			var err error
+			if area, err = adjustDefinedNameArea(area, dir, num, offset); err != nil {
+				return data, err
+			}
+		}
+		tokens[i].TValue = escapeSheetName(parts[0]) + "!" + area
+	}
+	return ps.Render(), nil
+}
+
+// adjustDefinedNameArea returns the adjusted area reference of the defined name
+// on the worksheet by giving adjusting direction, the base number of column or
+// row, and offset. The start of the area in the removed columns or rows will
+// be moved to the first column or row after them, and the end of the area
+// will be moved to the last column or row before them.
+func adjustDefinedNameArea(area string, dir adjustDirection, num, offset int) (string, error) {
+	var (
+		err     error
+		refs    = strings.Split(area, ":")
+		matches = make([][]string, len(refs))
+		numbers = make([]int, len(refs))
+		idx     = 4
+		abs     = true
+	)
+	if dir == columns {
+		idx = 2
+	}
+	for i, ref := range refs {
+		if matches[i] = definedNameAreaPattern.FindStringSubmatch(ref); matches[i] == nil || matches[i][2]+matches[i][4] == "" {
+			return area, err
+		}
+		if matches[i][2] == "" {
+			matches[i][1], matches[i][3] = "", matches[i][1]+matches[i][3]
+		}
+		if matches[i][idx] == "" {
+			return area, err
+		}
+		abs = abs && matches[i][idx-1] != ""
+		if numbers[i], err = strconv.Atoi(matches[i][4]); dir == columns {
+			numbers[i], err = ColumnNameToNumber(matches[i][2])
+		}
+		if err != nil {
+			return area, nil
+		}
+	}
+	start, end := 0, len(refs)-1
+	if numbers[start] > numbers[end] {
+		start, end = end, start
+	}
+	from, to, last := numbers[start], numbers[end], num-offset-1
+	if offset < 0 && from >= num && to <= last {
+		if abs {
+			return "#REF!", err
+		}
+		return area, err
+	}
+	for i, n := range []int{from, to} {
+		if (n >= num && offset > 0) || (n > last && offset < 0) {
+			n += offset
+		} else if n >= num && offset < 0 {
+			if n = num; i == 1 {
+				n = num - 1
+			}
+		}
+		if i == 0 {
+			from = n
+		} else {
+			to = n
+		}
+	}
+	numbers[start], numbers[end] = from, to
+	for i := range refs {
+		if matches[i][idx-1] == "" {
+			continue
+		}
+		if dir == rows {
+			if numbers[i] > TotalRows {
+				return area, ErrMaxRows
+			}
+			matches[i][4] = strconv.Itoa(numbers[i])
+		} else if matches[i][2], err = ColumnNumberToName(numbers[i]); err != nil {
+			return area, err
+		}
+		refs[i] = strings.Join(matches[i][1:], "")
+	}
+	return strings.Join(refs, ":"), err
+}
+
+// relocateCols provides a function to move the cells, column definitions,
+// merged cells, hyperlinks, conditional formats, data validations and
+// formula references of the columns from start to end on the worksheet to the
+// empty columns beginning with the target column.
+func (f *File) relocateCols(sheet string, start, end, target int) error {
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return err
+	}
+	colMap := func(col int) int {
+		if start <= col && col <= end {
+			return col - start + target
+		}
+		return col
+	}
+	for _, sheetN := range f.GetSheetList() {
+		worksheet, err := f.workSheetReader(sheetN)
+		if err != nil {
+			if err.Error() == newNotWorksheetError(sheetN).Error() {
+				continue
+			}
+			return err
+		}
+		for rowIdx := range worksheet.SheetData.Row {
+			row := &worksheet.SheetData.Row[rowIdx]
+			for i := range row.C {
+				if err = f.relocateFormula(sheet, sheetN, &row.C[i], colMap); err != nil {
+					return err
+				}
+			}
+			if sheetN == sheet {
+				if err = row.relocateCells(start, end, target); err != nil {
+					return err
+				}
+			}
+		}
+		if err = f.relocateDataValidations(worksheet, sheet, sheetN, colMap); err != nil {
+			return err
+		}
+	}
+	ws.formulaSI.Clear()
+	ws.relocateColDefinitions(start, end, target)
+	if err = ws.relocateMergeCells(colMap); err != nil {
+		return err
+	}
+	if ws.Hyperlinks != nil {
+		for i := range ws.Hyperlinks.Hyperlink {
+			link := &ws.Hyperlinks.Hyperlink[i]
+			if link.Ref, err = relocateCellRef(link.Ref, colMap); err != nil {
+				return err
+			}
+		}
+	}
+	for _, cf := range ws.ConditionalFormatting {
+		if cf.SQRef, err = relocateCellRef(cf.SQRef, colMap); err != nil {
+			return err
+		}
+		for _, rule := range cf.CfRule {
+			for i := range rule.Formula {
+				if rule.Formula[i], err = f.relocateFormulaRef(sheet, sheet, rule.Formula[i], colMap); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return f.relocateDefinedNames(sheet, colMap)
+}
+
+// relocateCells provides a function to move the cells of the row in the
+// columns from start to end to the columns beginning with the target column,
+// the placeholder cells in the target columns will be replaced.
+func (r *xlsxRow) relocateCells(start, end, target int) error {
+	var cells, moved []xlsxC
+	for _, c := range r.C {
+		col, row, err := CellNameToCoordinates(c.R)
+		if err != nil {
+			return err
+		}
+		if start <= col && col <= end {
+			c.R, _ = CoordinatesToCellName(col-start+target, row)
+			moved = append(moved, c)
+			continue
+		}
+		if target <= col && col <= target+end-start {
+			continue
+		}
+		cells = append(cells, c)
+	}
+	if len(moved) == 0 {
+		return nil
+	}
+	idx := sort.Search(len(cells), func(i int) bool {
+		col, _, _ := CellNameToCoordinates(cells[i].R)
+		return col > target
+	})
+	r.C = append(cells[:idx], append(moved, cells[idx:]...)...)
+	return nil
+}
+
+// relocateFormula provides a function to update the formula and shared
+// formula reference of the cell by given column mapping function.
+func (f *File) relocateFormula(sheet, sheetN string, cell *xlsxC, colMap func(col int) int) error {
+	var err error
+	if cell.f != "" {
+		if cell.f, err = f.relocateFormulaRef(sheet, sheetN, cell.f, colMap); err != nil {
+			return err
+		}
+	}
+	if cell.F == nil {
+		return nil
+	}
+	if cell.F.Ref != "" && sheet == sheetN {
+		if cell.F.Ref, err = relocateCellRef(cell.F.Ref, colMap); err != nil {
+			return err
+		}
+	}
+	if cell.F.Content != "" {
+		cell.F.Content, err = f.relocateFormulaRef(sheet, sheetN, cell.F.Content, colMap)
+	}
+	return err
+}
+
+// relocateFormulaRef returns the formula with updated cell references of the
+// given worksheet by given column mapping function.
+func (f *File) relocateFormulaRef(sheet, sheetN, formula string, colMap func(col int) int) (string, error) {
+	var (
+		definedNames []string
+		changed      bool
+	)
+	for _, definedName := range f.GetDefinedName() {
+		if definedName.Scope == "Workbook" || definedName.Scope == sheet {
+			definedNames = append(definedNames, definedName.Name)
+		}
+	}
+	ps := efp.ExcelParser()
+	tokens := ps.Parse(formula)
+	for i, token := range tokens {
+		if token.TType == efp.TokenTypeUnknown {
+			return formula, nil
+		}
+		if token.TType == efp.TokenTypeOperand && token.TSubType == efp.TokenSubTypeRange {
+			if inStrSlice(definedNames, token.TValue, true) != -1 || strings.ContainsAny(token.TValue, "[]") {
+				continue
+			}
+			if operand := relocateFormulaOperand(sheet, sheetN, token.TValue, colMap); operand != token.TValue {
+				tokens[i].TValue, changed = operand, true
+			}
+		}
+	}
+	if !changed {
+		return formula, nil
+	}
+	return ps.Render(), nil
+}
+
+// relocateFormulaOperand returns the range operand with updated columns by
+// given column mapping function, the columns of the range will be kept in
+// ascending order.
+func relocateFormulaOperand(sheet, sheetN, operand string, colMap func(col int) int) string {
+	prefix, sheetName, ref := "", sheetN, operand
+	if idx := strings.LastIndex(operand, "!"); idx != -1 {
+		prefix, ref = operand[:idx+1], operand[idx+1:]
+		sheetName = strings.ReplaceAll(strings.Trim(operand[:idx], "'"), "''", "'")
+	}
+	if sheetName == "" || !strings.EqualFold(sheetName, sheet) {
+		return operand
+	}
+	parts := strings.Split(ref, ":")
+	if len(parts) > 2 {
+		return operand
+	}
+	matches, cols := make([][]string, len(parts)), make([]int, len(parts))
+	for i, part := range parts {
+		if matches[i] = formulaCellRefExp.FindStringSubmatch(part); matches[i] == nil ||
+			(len(parts) == 1 && matches[i][3] == "") {
+			return operand
+		}
+		col, err := ColumnNameToNumber(matches[i][2])
+		if err != nil {
+			return operand
+		}
+		cols[i] = colMap(col)
+	}
+	if len(cols) == 2 && cols[0] > cols[1] {
+		cols[0], cols[1] = cols[1], cols[0]
+		matches[0][1], matches[1][1] = matches[1][1], matches[0][1]
+	}
+	for i := range parts {
+		colName, _ := ColumnNumberToName(cols[i])
+		parts[i] = matches[i][1] + colName + matches[i][3]
+	}
+	return prefix + strings.Join(parts, ":")
+}
+
+// relocateCellRef returns the reference sequence with updated cell ranges by
+// given column mapping function, only the ranges which columns are all moved
+// will be updated.
+func relocateCellRef(cellRef string, colMap func(col int) int) (string, error) {
+	refs := strings.Split(cellRef, " ")
+	for i, ref := range refs {
+		if ref == "" {
+			continue
+		}
+		rng := ref
+		if !strings.Contains(ref, ":") {
+			rng += ":" + ref
+		}
+		coordinates, err := rangeRefToCoordinates(rng)
+		if err != nil {
+			return cellRef, err
+		}
+		col1, col2 := colMap(coordinates[0]), colMap(coordinates[2])
+		if col1 == coordinates[0] || col2 == coordinates[2] || col2-col1 != coordinates[2]-coordinates[0] {
+			continue
+		}
+		coordinates[0], coordinates[2] = col1, col2
+		if refs[i], err = joinAdjustedRangeRef(coordinates, strings.Split(rng, ":")); err != nil {
+			return cellRef, err
+		}
+		if !strings.Contains(ref, ":") {
+			refs[i] = strings.Split(refs[i], ":")[0]
+		}
+	}
+	return strings.Join(refs, " "), nil
+}
+
+// relocateColDefinitions provides a function to move the column definitions
+// of the columns from start to end to the columns beginning with the target
+// column, the existing definitions of the target columns will be replaced.
+func (ws *xlsxWorksheet) relocateColDefinitions(start, end, target int) {
+	if ws.Cols == nil {
+		return
+	}
+	n, cols := end-start+1, make([]xlsxCol, 0, len(ws.Cols.Col))
+	for _, c := range ws.Cols.Col {
+		bounds := []int{c.Min}
+		for _, bound := range []int{start, end + 1, target, target + n} {
+			if c.Min < bound && bound <= c.Max {
+				bounds = append(bounds, bound)
+			}
+		}
+		sort.Ints(bounds)
+		bounds = append(bounds, c.Max+1)
+		for i := 0; i < len(bounds)-1; i++ {
+			lo, hi := bounds[i], bounds[i+1]-1
+			if lo > hi || (target <= lo && hi < target+n) {
+				continue
+			}
+			col := c
+			col.Min, col.Max = lo, hi
+			if start <= lo && hi <= end {
+				col.Min, col.Max = lo-start+target, hi-start+target
+			}
+			cols = append(cols, col)
+		}
+	}
+	sort.Slice(cols, func(i, j int) bool { return cols[i].Min < cols[j].Min })
+	if ws.Cols.Col = cols; len(cols) == 0 {
+		ws.Cols = nil
+	}
+}
+
+// relocateMergeCells provides a function to move the merged cells which are
+// fully contained in the moved columns by given column mapping function.
+func (ws *xlsxWorksheet) relocateMergeCells(colMap func(col int) int) error {
+	if ws.MergeCells == nil {
+		return nil
+	}
+	for _, mergedCells := range ws.MergeCells.Cells {
+		ref, err := relocateCellRef(mergedCells.Ref, colMap)
+		if err != nil {
+			return err
+		}
+		if ref != mergedCells.Ref {
+			mergedCells.Ref, mergedCells.rect = ref, nil
+		}
+	}
+	return nil
+}
+
+// relocateDataValidations provides a function to update the range and
+// formulas of data validations on the worksheet by given column mapping
+// function.
+func (f *File) relocateDataValidations(ws *xlsxWorksheet, sheet, sheetN string, colMap func(col int) int) error {
+	if ws.DataValidations == nil {
+		return nil
+	}
+	var err error
+	for _, dv := range ws.DataValidations.DataValidation {
+		if dv == nil {
+			continue
+		}
+		if sheet == sheetN {
+			if dv.Sqref, err = relocateCellRef(dv.Sqref, colMap); err != nil {
+				return err
+			}
+		}
+		for _, formula := range []*xlsxInnerXML{dv.Formula1, dv.Formula2} {
+			if !formula.isFormula() {
+				continue
+			}
+			content := formulaUnescaper.Replace(formula.Content)
+			if content, err = f.relocateFormulaRef(sheet, sheetN, content, colMap); err != nil {
+				return err
+			}
+			formula.Content = formulaEscaper.Replace(content)
+		}
+	}
+	return nil
+}
+
+// relocateDefinedNames provides a function to update the references of the
+// defined names by given column mapping function.
+func (f *File) relocateDefinedNames(sheet string, colMap func(col int) int) error {
+	wb, err := f.workbookReader()
+	if err != nil {
+		return err
+	}
+	if wb.DefinedNames != nil {
+		for i := range wb.DefinedNames.DefinedName {
+			definedName := &wb.DefinedNames.DefinedName[i]
+			if definedName.isStringConstant() {
+				continue
+			}
+			if definedName.Data, err = f.relocateFormulaRef(sheet, "", definedName.Data, colMap); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// rowsRemap is the sorted and deduplicated row numbers to be removed, which
+// maps the row numbers before removing the rows to the row numbers after
+// that.
+type rowsRemap []int
+
+// row returns the row number after removing the rows by given row number,
+// and reports whether the row is removed. The removed row will be mapped to
+// the nearest remaining row above it.
+func (m rowsRemap) row(row int) (int, bool) {
+	idx := sort.SearchInts(m, row+1)
+	return max(row-idx, 1), idx > 0 && m[idx-1] == row
+}
+
+// span returns the first and last row numbers of the rows range after
+// removing the rows by given first and last row numbers of the range, and
+// reports whether any row of the range remains.
+func (m rowsRemap) span(first, last int) (int, int, bool) {
+	i1, i2 := sort.SearchInts(m, first), sort.SearchInts(m, last+1)
+	return first - i1, last - i2, i2-i1 <= last-first
+}
+
+// cellRef returns the reference sequence with updated rows after removing
+// the rows, the ranges which rows are all removed will be dropped.
+func (m rowsRemap) cellRef(cellRef string) (string, error) {
+	var refs []string
+	for _, ref := range strings.Split(cellRef, " ") {
+		if ref == "" {
+			continue
+		}
+		rng := ref
+		if !strings.Contains(ref, ":") {
+			rng += ":" + ref
+		}
+		coordinates, err := rangeRefToCoordinates(rng)
+		if err != nil {
+			return cellRef, err
+		}
+		var ok bool
+		if coordinates[1], coordinates[3], ok = m.span(coordinates[1], coordinates[3]); !ok {
+			continue
+		}
+		adjusted, err := joinAdjustedRangeRef(coordinates, strings.Split(rng, ":"))
+		if err != nil {
+			return cellRef, err
+		}
+		if !strings.Contains(ref, ":") {
+			adjusted = strings.Split(adjusted, ":")[0]
+		}
+		refs = append(refs, adjusted)
+	}
+	return strings.Join(refs, " "), nil
+}
+
+// remapFormula provides a function to update the formula and shared formula
+// reference of the cell after removing the rows of the given worksheet.
+func (f *File) remapFormula(sheet, sheetN string, cell *xlsxC, m rowsRemap) error {
+	var err error
+	if cell.f != "" {
+		if cell.f, err = f.remapFormulaRef(sheet, sheetN, cell.f, false, m); err != nil {
+			return err
+		}
+	}
+	if cell.F == nil {
+		return nil
+	}
+	if cell.F.Ref != "" && sheet == sheetN {
+		if cell.F.Ref, err = m.cellRef(cell.F.Ref); err != nil {
+			return err
+		}
+	}
+	if cell.F.Content != "" {
+		cell.F.Content, err = f.remapFormulaRef(sheet, sheetN, cell.F.Content, false, m)
+	}
+	return err
+}
+
+// remapFormulaRef returns the formula with updated row references of the
+// given worksheet after removing the rows, the relative references will be
+// kept if keepRelative is true.
+func (f *File) remapFormulaRef(sheet, sheetN, formula string, keepRelative bool, m rowsRemap) (string, error) {
+	var (
+		definedNames []string
+		changed      bool
+	)
+	for _, definedName := range f.GetDefinedName() {
+		if definedName.Scope == "Workbook" || definedName.Scope == sheet {
+			definedNames = append(definedNames, definedName.Name)
+		}
+	}
+	ps := efp.ExcelParser()
+	tokens := ps.Parse(formula)
+	for i, token := range tokens {
+		if token.TType == efp.TokenTypeUnknown {
+			return formula, nil
+		}
+		if token.TType == efp.TokenTypeOperand && token.TSubType == efp.TokenSubTypeRange {
+			if inStrSlice(definedNames, token.TValue, true) != -1 || strings.ContainsAny(token.TValue, "[]") {
+				continue
+			}
+			if operand := remapFormulaOperand(sheet, sheetN, token.TValue, keepRelative, m); operand != token.TValue {
+				tokens[i].TValue, changed = operand, true
+			}
+		}
+	}
+	if !changed {
+		return formula, nil
+	}
+	return ps.Render(), nil
+}
+
+// remapFormulaOperand returns the range operand with updated rows after
+// removing the rows of the given worksheet.
+func remapFormulaOperand(sheet, sheetN, operand string, keepRelative bool, m rowsRemap) string {
+	prefix, sheetName, ref := "", sheetN, operand
+	if idx := strings.LastIndex(operand, "!"); idx != -1 {
+		prefix, ref = operand[:idx+1], operand[idx+1:]
+		sheetName = strings.ReplaceAll(strings.Trim(operand[:idx], "'"), "''", "'")
+	}
+	if sheetName == "" || !strings.EqualFold(sheetName, sheet) {
+		return operand
+	}
+	parts := strings.Split(ref, ":")
+	if len(parts) > 2 {
+		return operand
+	}
+	for i, part := range parts {
+		matches := formulaRowRefExp.FindStringSubmatch(part)
+		if matches == nil {
+			return operand
+		}
+		if matches[3] == "" || (keepRelative && matches[2] == "") {
+			continue
+		}
+		row, _ := strconv.Atoi(matches[3])
+		row, _ = m.row(row)
+		parts[i] = matches[1] + matches[2] + strconv.Itoa(row)
+	}
+	return prefix + strings.Join(parts, ":")
+}
+
+// remapHyperlinks provides a function to update the hyperlinks after
+// removing the rows, the hyperlinks in the removed rows will be deleted.
+func (f *File) remapHyperlinks(ws *xlsxWorksheet, sheet string, m rowsRemap) error {
+	if ws.Hyperlinks == nil {
+		return nil
+	}
+	var links []xlsxHyperlink
+	for _, link := range ws.Hyperlinks.Hyperlink {
+		ref, err := m.cellRef(link.Ref)
+		if err != nil {
+			return err
+		}
+		if ref == "" {
+			f.deleteSheetRelationships(sheet, link.RID)
+			continue
+		}
+		link.Ref = ref
+		links = append(links, link)
+	}
+	if ws.Hyperlinks.Hyperlink = links; len(links) == 0 {
+		ws.Hyperlinks = nil
+	}
+	return nil
+}
+
+// remapMergeCells provides a function to update the merged cells after
+// removing the rows, the merged cells which rows are all removed or become a
+// single cell will be deleted.
+func (ws *xlsxWorksheet) remapMergeCells(m rowsRemap) error {
+	if ws.MergeCells == nil {
+		return nil
+	}
+	var cells []*xlsxMergeCell
+	for _, mergedCells := range ws.MergeCells.Cells {
+		ref := mergedCells.Ref
+		if !strings.Contains(ref, ":") {
+			ref += ":" + ref
+		}
+		coordinates, err := rangeRefToCoordinates(ref)
+		if err != nil {
+			return err
+		}
+		_ = sortCoordinates(coordinates)
+		x1, x2 := coordinates[0], coordinates[2]
+		y1, y2, ok := m.span(coordinates[1], coordinates[3])
+		if !ok || (x1 == x2 && y1 == y2) {
+			continue
+		}
+		mergedCells.rect = []int{x1, y1, x2, y2}
+		if mergedCells.Ref, err = coordinatesToRangeRef(mergedCells.rect); err != nil {
+			return err
+		}
+		cells = append(cells, mergedCells)
+	}
+	ws.MergeCells.Cells, ws.MergeCells.Count = cells, len(cells)
+	if len(cells) == 0 {
+		ws.MergeCells = nil
+	}
+	return nil
+}
+
+// remapConditionalFormats provides a function to update the range and
+// formulas of the conditional formats after removing the rows, the
+// conditional formats which rows are all removed will be deleted.
+func (f *File) remapConditionalFormats(ws *xlsxWorksheet, sheet string, m rowsRemap) error {
+	var cfs []*xlsxConditionalFormatting
+	for _, cf := range ws.ConditionalFormatting {
+		if cf == nil {
+			cfs = append(cfs, cf)
+			continue
+		}
+		ref, err := m.cellRef(cf.SQRef)
+		if err != nil {
+			return err
+		}
+		if ref == "" {
+			continue
+		}
+		cf.SQRef = ref
+		for _, rule := range cf.CfRule {
+			if rule == nil {
+				continue
+			}
+			for i := range rule.Formula {
+				if rule.Formula[i], err = f.remapFormulaRef(sheet, sheet, rule.Formula[i], false, m); err != nil {
+					return err
+				}
+			}
+		}
+		cfs = append(cfs, cf)
+	}
+	ws.ConditionalFormatting = cfs
+	return nil
+}
+
+// remapDataValidations provides a function to update the range and formulas
+// of the data validations on the worksheet after removing the rows of the
+// given worksheet, the data validations which rows are all removed will be
+// deleted.
+func (f *File) remapDataValidations(ws *xlsxWorksheet, sheet, sheetN string, m rowsRemap) error {
+	if ws.DataValidations == nil {
+		return nil
+	}
+	var dvs []*xlsxDataValidation
+	for _, dv := range ws.DataValidations.DataValidation {
+		if dv == nil {
+			dvs = append(dvs, dv)
+			continue
+		}
+		if sheet == sheetN {
+			ref, err := m.cellRef(dv.Sqref)
+			if err != nil {
+				return err
+			}
+			if ref == "" {
+				continue
+			}
+			dv.Sqref = ref
+		}
+		for _, formula := range []*xlsxInnerXML{dv.Formula1, dv.Formula2} {
+			if !formula.isFormula() {
+				continue
+			}
+			content, err := f.remapFormulaRef(sheet, sheetN, formulaUnescaper.Replace(formula.Content), false, m)
+			if err != nil {
+				return err
+			}
+			formula.Content = formulaEscaper.Replace(content)
+		}
+		dvs = append(dvs, dv)
+	}
+	if ws.DataValidations.DataValidation, ws.DataValidations.Count = dvs, len(dvs); len(dvs) == 0 {
+		ws.DataValidations = nil
+	}
+	return nil
+}
+
+// remapDefinedNames provides a function to update the references of the
+// defined names after removing the rows of the given worksheet.
+func (f *File) remapDefinedNames(sheet string, m rowsRemap) error {
+	wb, err := f.workbookReader()
+	if err != nil {
+		return err
+	}
+	if wb.DefinedNames != nil {
+		for i := range wb.DefinedNames.DefinedName {
+			definedName := &wb.DefinedNames.DefinedName[i]
+			if definedName.isStringConstant() {
+				continue
+			}
+			if definedName.Data, err = f.remapFormulaRef(sheet, "", definedName.Data, true, m); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// remapAutoFilter provides a function to update the auto filter after
+// removing the rows, the auto filter will be removed if the header row of
+// it is removed.
+func (ws *xlsxWorksheet) remapAutoFilter(m rowsRemap) error {
+	if ws.AutoFilter == nil {
+		return nil
+	}
+	coordinates, err := rangeRefToCoordinates(ws.AutoFilter.Ref)
+	if err != nil {
+		return err
+	}
+	y1, y2, _ := m.span(coordinates[1], coordinates[3])
+	if _, removed := m.row(coordinates[1]); removed {
+		ws.AutoFilter = nil
+		for rowIdx := range ws.SheetData.Row {
+			if rowData := &ws.SheetData.Row[rowIdx]; rowData.R >= y1 && rowData.R <= y2 {
+				rowData.Hidden = false
+			}
+		}
+		return nil
+	}
+	coordinates[1], coordinates[3] = y1, y2
+	ws.AutoFilter.Ref, err = coordinatesToRangeRef(coordinates)
+	return err
+}
+
+// remapTables provides a function to update the range of the tables after
+// removing the rows, the table will be removed if the header row of it is
+// removed.
+func (f *File) remapTables(ws *xlsxWorksheet, sheet string, m rowsRemap) error {
+	return f.updateTables(ws, sheet, rows, func(coordinates []int) bool {
+		if _, removed := m.row(coordinates[1]); removed {
+			return false
+		}
+		coordinates[1], coordinates[3], _ = m.span(coordinates[1], coordinates[3])
+		return true
+	})
+}
+
+// remapCalcChain provides a function to update the calculation chain after
+// removing the rows of the worksheet by given sheet ID, the cells in the
+// removed rows will be deleted from the calculation chain.
+func (f *File) remapCalcChain(sheetID int, m rowsRemap) error {
+	if f.CalcChain == nil {
+		return nil
+	}
+	// If sheet ID is omitted, it is assumed to be the same as the i value of
+	// the previous cell.
+	var (
+		prevSheetID, keptSheetID int
+		chain                    []xlsxCalcChainC
+	)
+	for _, c := range f.CalcChain.C {
+		omitted := c.I == 0
+		if omitted {
+			c.I = prevSheetID
+		}
+		prevSheetID = c.I
+		if c.I == sheetID {
+			col, row, err := CellNameToCoordinates(c.R)
+			if err != nil {
+				return err
+			}
+			newRow, removed := m.row(row)
+			if removed {
+				continue
+			}
+			c.R, _ = CoordinatesToCellName(col, newRow)
+		}
+		if omitted && c.I == keptSheetID {
+			c.I = 0
+		}
+		keptSheetID = prevSheetID
+		chain = append(chain, c)
+	}
+	if f.CalcChain.C = chain; len(chain) == 0 {
+		return f.deleteCalcChain(sheetID, "")
+	}
+	return nil
+}
+
+// remapVolatileDeps provides a function to update the volatile dependencies
+// after removing the rows of the worksheet by given sheet ID, the references
+// to the cells in the removed rows will be deleted.
+func (f *File) remapVolatileDeps(sheetID int, m rowsRemap) error {
+	volTypes, err := f.volatileDepsReader()
+	if err != nil || volTypes == nil {
+		return err
+	}
+	for i1 := range volTypes.VolType {
+		for i2 := range volTypes.VolType[i1].Main {
+			for i3 := range volTypes.VolType[i1].Main[i2].Tp {
+				tp := &volTypes.VolType[i1].Main[i2].Tp[i3]
+				var refs []xlsxVolTopicRef
+				for _, ref := range tp.Tr {
+					if ref.S == sheetID {
+						col, row, err := CellNameToCoordinates(ref.R)
+						if err != nil {
+							return err
+						}
+						newRow, removed := m.row(row)
+						if removed {
+							continue
+						}
+						ref.R, _ = CoordinatesToCellName(col, newRow)
+					}
+					refs = append(refs, ref)
+				}
+				tp.Tr = refs
+			}
+		}
+	}
+	return nil
+}
+
+// remapDrawings provides a function to update the pictures and charts object
+// after removing the rows. The starting anchor will be moved with the cells,
+// and the ending anchor will be moved with the cells for the object without
+// the editAs attribute, or moved with the starting anchor for the object
+// with "oneCell" positioning.
+func (f *File) remapDrawings(ws *xlsxWorksheet, sheet string, m rowsRemap) error {
+	return f.updateDrawingAnchors(ws, sheet, func(from *xlsxFrom, to *xlsxTo, editAs string) error {
+		row, _ := m.row(from.Row + 1)
+		shift := from.Row + 1 - row
+		from.Row = row - 1
+		if to == nil {
+			return nil
+		}
+		if editAs == "" {
+			row, _ = m.row(to.Row + 1)
+			to.Row = row - 1
+		}
+		if editAs == "oneCell" {
+			to.Row = max(to.Row-shift, 0)
+		}
+		return nil
+	})
+}
+
+// remapComments provides a function to update the comments and the anchor
+// of the VML shapes after removing the rows, the comments in the removed
+// rows will be deleted.
+func (f *File) remapComments(ws *xlsxWorksheet, sheet string, m rowsRemap) error {
+	return f.updateComments(ws, sheet, rows, m.row)
+}
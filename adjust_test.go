@@ -551,6 +551,32 @@ func TestAdjustHyperlinks(t *testing.T) {
 	assert.NoError(t, f.Close())
 }
 
+func TestAdjustWholeColumnAndRowReference(t *testing.T) {
+	for _, c := range []struct {
+		formula, cell, expected string
+		adjust                  func(f *File) error
+	}{
+		{"SUM(D:D)", "A1", "SUM(E:E)", func(f *File) error { return f.InsertCols("Sheet1", "C", 1) }},
+		{"SUM($D:$F)", "A1", "SUM($F:$H)", func(f *File) error { return f.InsertCols("Sheet1", "D", 2) }},
+		{"SUM(Sheet1!D:D)", "A1", "SUM(Sheet1!C:C)", func(f *File) error { return f.RemoveCol("Sheet1", "C") }},
+		{"SUM(D:F)", "A1", "SUM(D:E)", func(f *File) error { return f.RemoveCol("Sheet1", "E") }},
+		{"SUM(D:D)", "A1", "SUM(D:D)", func(f *File) error { return f.InsertRows("Sheet1", 3, 1) }},
+		{"SUM(7:7)", "A1", "SUM(8:8)", func(f *File) error { return f.InsertRows("Sheet1", 3, 1) }},
+		{"SUM($7:$9)", "A1", "SUM($6:$8)", func(f *File) error { return f.RemoveRow("Sheet1", 3) }},
+		{"SUM(7:9)", "A1", "SUM(5:7)", func(f *File) error { return f.RemoveRows("Sheet1", []int{2, 3}) }},
+		{"SUM(7:7)", "B1", "SUM(7:7)", func(f *File) error { return f.InsertCols("Sheet1", "A", 1) }},
+		{"SUM(D:F)", "A1", "SUM(C:E)", func(f *File) error { return f.MoveCols("Sheet1", "B:B", "H") }},
+	} {
+		f := NewFile()
+		assert.NoError(t, f.SetCellFormula("Sheet1", "A1", c.formula))
+		assert.NoError(t, c.adjust(f))
+		formula, err := f.GetCellFormula("Sheet1", c.cell)
+		assert.NoError(t, err)
+		assert.Equal(t, c.expected, formula, c.formula)
+		assert.NoError(t, f.Close())
+	}
+}
+
 func TestAdjustFormula(t *testing.T) {
 	f := NewFile()
 	formulaType, ref := STCellFormulaTypeShared, "C1:C5"
@@ -1217,6 +1243,15 @@ func TestAdjustDrawings(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, []string{"C2", "B21", "C12"}, cells)
 
+	// Test get pictures on the shifted cell after inserting columns
+	assert.NoError(t, f.InsertCols("Sheet1", "A", 2))
+	pics, err := f.GetPictures("Sheet1", "E2")
+	assert.NoError(t, err)
+	assert.Len(t, pics, 1)
+	pics, err = f.GetPictures("Sheet1", "C2")
+	assert.NoError(t, err)
+	assert.Empty(t, pics)
+
 	// Test adjust existing pictures on inserting columns and rows
 	f, err = OpenFile(wb)
 	assert.NoError(t, err)
@@ -1237,9 +1272,10 @@ func TestAdjustDrawings(t *testing.T) {
 
 	errors := []error{ErrColumnNumber, ErrColumnNumber, ErrMaxRows, ErrMaxRows}
 	cells = []string{"XFD1", "XFB1"}
+	opts := []*GraphicOptions{{ScaleX: 0.4}, nil}
 	for i, cell := range cells {
 		f = NewFile()
-		assert.NoError(t, f.AddPicture("Sheet1", cell, filepath.Join("test", "images", "excel.jpg"), nil))
+		assert.NoError(t, f.AddPicture("Sheet1", cell, filepath.Join("test", "images", "excel.jpg"), opts[i]))
 		assert.Equal(t, errors[i], f.InsertCols("Sheet1", "A", 1))
 		assert.NoError(t, f.SaveAs(wb))
 		f, err = OpenFile(wb)
@@ -1248,9 +1284,10 @@ func TestAdjustDrawings(t *testing.T) {
 	}
 	errors = []error{ErrMaxRows, ErrMaxRows}
 	cells = []string{"A1048576", "A1048570"}
+	opts = []*GraphicOptions{{ScaleY: 0.1}, nil}
 	for i, cell := range cells {
 		f = NewFile()
-		assert.NoError(t, f.AddPicture("Sheet1", cell, filepath.Join("test", "images", "excel.jpg"), nil))
+		assert.NoError(t, f.AddPicture("Sheet1", cell, filepath.Join("test", "images", "excel.jpg"), opts[i]))
 		assert.Equal(t, errors[i], f.InsertRows("Sheet1", 1, 1))
 		assert.NoError(t, f.SaveAs(wb))
 		f, err = OpenFile(wb)
@@ -1258,10 +1295,7 @@ func TestAdjustDrawings(t *testing.T) {
 		assert.Equal(t, errors[i], f.InsertRows("Sheet1", 1, 1))
 	}
 
-	a := xdrCellAnchor{}
-	assert.NoError(t, a.adjustDrawings(columns, 0, 0))
-	p := xlsxCellAnchorPos{}
-	assert.NoError(t, p.adjustDrawings(columns, 0, 0, ""))
+	assert.NoError(t, adjustCellAnchor(&xlsxFrom{}, nil, "", columns, 0, 0))
 
 	f, err = OpenFile(wb)
 	assert.NoError(t, err)
@@ -1274,6 +1308,113 @@ func TestAdjustDrawings(t *testing.T) {
 	assert.EqualError(t, f.InsertCols("Sheet1", "A", 1), "the column number must be greater than or equal to 1 and less than or equal to 16384")
 }
 
+func TestAdjustComments(t *testing.T) {
+	f := NewFile()
+	for _, cell := range []string{"B2", "C3", "D4"} {
+		assert.NoError(t, f.AddComment("Sheet1", Comment{Cell: cell, Author: "Excelize", Text: cell}))
+	}
+	assert.NoError(t, f.AddFormControl("Sheet1", FormControl{Cell: "C6", Type: FormControlButton, Text: "Button"}))
+	assert.NoError(t, f.AddFormControl("Sheet1", FormControl{
+		Cell: "C8", Type: FormControlButton, Text: "Absolute",
+		Format: GraphicOptions{Positioning: "absolute"},
+	}))
+	getCells := func() ([]string, []string) {
+		comments, err := f.GetComments("Sheet1")
+		assert.NoError(t, err)
+		var commentCells, formControlCells []string
+		for _, comment := range comments {
+			commentCells = append(commentCells, comment.Cell)
+		}
+		formControls, err := f.GetFormControls("Sheet1")
+		assert.NoError(t, err)
+		for _, formControl := range formControls {
+			formControlCells = append(formControlCells, formControl.Cell)
+		}
+		return commentCells, formControlCells
+	}
+	// Test adjust comments and form controls on inserting columns and rows
+	assert.NoError(t, f.InsertCols("Sheet1", "C", 2))
+	assert.NoError(t, f.InsertRows("Sheet1", 3, 1))
+	comments, formControls := getCells()
+	assert.Equal(t, []string{"B2", "E4", "F5"}, comments)
+	assert.Equal(t, []string{"E7", "C8"}, formControls)
+	// Test delete comment on the shifted cell
+	assert.NoError(t, f.DeleteComment("Sheet1", "E4"))
+	comments, _ = getCells()
+	assert.Equal(t, []string{"B2", "F5"}, comments)
+	assert.NoError(t, f.AddComment("Sheet1", Comment{Cell: "E4", Author: "Excelize", Text: "E4"}))
+	wb := filepath.Join("test", "TestAdjustComments.xlsx")
+	assert.NoError(t, f.SaveAs(wb))
+
+	// Test adjust comments and form controls on deleting columns and rows
+	assert.NoError(t, f.RemoveCol("Sheet1", "E"))
+	assert.NoError(t, f.RemoveRow("Sheet1", 2))
+	comments, formControls = getCells()
+	assert.Equal(t, []string{"E4"}, comments)
+	assert.Equal(t, []string{"D6", "C8"}, formControls)
+
+	// Test adjust existing comments on inserting rows
+	f, err := OpenFile(wb)
+	assert.NoError(t, err)
+	assert.NoError(t, f.InsertRows("Sheet1", 1, 2))
+	comments, formControls = getCells()
+	assert.Equal(t, []string{"B4", "F7", "E6"}, comments)
+	assert.Equal(t, []string{"E9", "C8"}, formControls)
+	assert.NoError(t, f.Close())
+
+	// Test adjust comments with unsupported charset
+	f, err = OpenFile(wb)
+	assert.NoError(t, err)
+	f.Pkg.Store("xl/comments1.xml", MacintoshCyrillicCharset)
+	assert.EqualError(t, f.InsertCols("Sheet1", "A", 1), "XML syntax error on line 1: invalid UTF-8")
+	f, err = OpenFile(wb)
+	assert.NoError(t, err)
+	f.Pkg.Store("xl/drawings/vmlDrawing1.vml", MacintoshCyrillicCharset)
+	assert.EqualError(t, f.InsertCols("Sheet1", "A", 1), "XML syntax error on line 1: invalid UTF-8")
+	// Test adjust comments with invalid cell reference
+	f, err = OpenFile(wb)
+	assert.NoError(t, err)
+	cmts, err := f.commentsReader("xl/comments1.xml")
+	assert.NoError(t, err)
+	cmts.CommentList.Comment[0].Ref = "A"
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), f.InsertCols("Sheet1", "A", 1))
+	cmts.CommentList.Comment[0].Ref = "XFD1"
+	assert.Equal(t, ErrColumnNumber, f.InsertCols("Sheet1", "A", 1))
+
+	// Test adjust VML shape with exceeded anchor
+	anchor := "<x:Anchor>16383, 0, 1048575, 0, 16383, 0, 1048575, 0</x:Anchor>"
+	for dir, expected := range map[adjustDirection]error{columns: ErrColumnNumber, rows: ErrMaxRows} {
+		val, err := adjustVMLShape(anchor, dir, 1, 1)
+		assert.Equal(t, expected, err)
+		assert.Equal(t, anchor, val)
+		_, err = adjustVMLShape("<x:Row>1048575</x:Row><x:Column>16383</x:Column>", dir, 1, 1)
+		assert.Equal(t, expected, err)
+	}
+	// Test adjust VML shape with invalid anchor
+	for _, anchor := range []string{"<x:Anchor>1, 0</x:Anchor>", "<x:Anchor>A, 0, 1, 0, 2, 0, 3, 0</x:Anchor>", "<x:Row/><x:Column>A</x:Column>"} {
+		val, err := adjustVMLShape(anchor, columns, 1, 1)
+		assert.NoError(t, err)
+		assert.Equal(t, anchor, val)
+	}
+	// Test adjust VML shape with custom namespace prefix and default namespace
+	for _, shape := range []string{
+		`<v:textbox><div>A1, 1</div></v:textbox><xl:ClientData xmlns:xl="urn:schemas-microsoft-com:office:excel" ObjectType="Note"><xl:Anchor>1, 15, 0, 2, 3, 15, 3, 16</xl:Anchor><xl:Row>0</xl:Row><xl:Column>1</xl:Column></xl:ClientData>`,
+		`<v:textbox><div>A1, 1</div></v:textbox><ClientData xmlns="urn:schemas-microsoft-com:office:excel" ObjectType="Note"><Anchor>1, 15, 0, 2, 3, 15, 3, 16</Anchor><Row>0</Row><Column>1</Column></ClientData>`,
+	} {
+		val, err := adjustVMLShape(shape, columns, 1, 1)
+		assert.NoError(t, err)
+		expected := strings.NewReplacer("1, 15, 0, 2, 3, 15, 3, 16", "2, 15, 0, 2, 4, 15, 3, 16", "Column>1<", "Column>2<").Replace(shape)
+		assert.Equal(t, expected, val)
+		val, err = adjustVMLShape(shape, rows, 1, 1)
+		assert.NoError(t, err)
+		expected = strings.NewReplacer("1, 15, 0, 2, 3, 15, 3, 16", "1, 15, 1, 2, 3, 15, 4, 16", "Row>0<", "Row>1<").Replace(shape)
+		assert.Equal(t, expected, val)
+	}
+	// Test adjust VML shape with malformed content
+	_, err = adjustVMLShape("<x:Anchor 1, 0/>", columns, 1, 1)
+	assert.Error(t, err)
+}
+
 func TestAdjustDefinedNames(t *testing.T) {
 	f := NewFile()
 	_, err := f.NewSheet("Sheet2")
@@ -1320,7 +1461,7 @@ func TestAdjustDefinedNames(t *testing.T) {
 	}))
 	assert.NoError(t, f.RemoveCol("Sheet1", "A"))
 	definedNames = f.GetDefinedName()
-	assert.Equal(t, "Sheet1!$A$1", definedNames[0].RefersTo)
+	assert.Equal(t, "Sheet1!#REF!", definedNames[0].RefersTo)
 
 	f = NewFile()
 	assert.NoError(t, f.SetDefinedName(&DefinedName{
@@ -1337,3 +1478,196 @@ func TestAdjustDefinedNames(t *testing.T) {
 	f.Pkg.Store(defaultXMLPathWorkbook, MacintoshCyrillicCharset)
 	assert.EqualError(t, f.adjustDefinedNames("Sheet1", columns, 0, 0), "XML syntax error on line 1: invalid UTF-8")
 }
+
+func TestAdjustDefinedNamesColumns(t *testing.T) {
+	newFile := func() *File {
+		f := NewFile()
+		_, err := f.NewSheet("My Sheet")
+		assert.NoError(t, err)
+		for _, dn := range []*DefinedName{
+			{Name: "Prices", RefersTo: "Sheet1!$D:$D"},
+			{Name: "Bounded", RefersTo: "Sheet1!$D$2:$D$100"},
+			{Name: "Multi", RefersTo: "Sheet1!$B:$B,Sheet1!$F$1:$F$5"},
+			{Name: "Other", RefersTo: "'My Sheet'!$D:$D"},
+			{Name: "Removed", RefersTo: "Sheet1!$C:$C"},
+			{Name: "RemovedArea", RefersTo: "Sheet1!$C$1:$C$9"},
+			{Name: "PartlyRemoved", RefersTo: "Sheet1!$C:$C,Sheet1!$F$1:$F$5"},
+			{Name: "Span", RefersTo: "Sheet1!$C:$E"},
+			{Name: "Rows", RefersTo: "Sheet1!$2:$3"},
+			{Name: "Total", RefersTo: "SUM(Sheet1!$D:$D,'My Sheet'!$C:$C)"},
+		} {
+			assert.NoError(t, f.SetDefinedName(dn))
+		}
+		return f
+	}
+	getRefersTo := func(f *File) map[string]string {
+		refersTo := make(map[string]string)
+		for _, dn := range f.GetDefinedName() {
+			refersTo[dn.Name] = dn.RefersTo
+		}
+		return refersTo
+	}
+	f := newFile()
+	assert.NoError(t, f.InsertCols("Sheet1", "C", 2))
+	assert.Equal(t, map[string]string{
+		"Prices":        "Sheet1!$F:$F",
+		"Bounded":       "Sheet1!$F$2:$F$100",
+		"Multi":         "Sheet1!$B:$B,Sheet1!$H$1:$H$5",
+		"Other":         "'My Sheet'!$D:$D",
+		"Removed":       "Sheet1!$E:$E",
+		"RemovedArea":   "Sheet1!$E$1:$E$9",
+		"PartlyRemoved": "Sheet1!$E:$E,Sheet1!$H$1:$H$5",
+		"Span":          "Sheet1!$E:$G",
+		"Rows":          "Sheet1!$2:$3",
+		"Total":         "SUM(Sheet1!$F:$F,'My Sheet'!$C:$C)",
+	}, getRefersTo(f))
+	assert.NoError(t, f.Close())
+
+	f = newFile()
+	assert.NoError(t, f.RemoveCol("Sheet1", "C"))
+	expected := map[string]string{
+		"Prices":        "Sheet1!$C:$C",
+		"Bounded":       "Sheet1!$C$2:$C$100",
+		"Multi":         "Sheet1!$B:$B,Sheet1!$E$1:$E$5",
+		"Other":         "'My Sheet'!$D:$D",
+		"Removed":       "Sheet1!#REF!",
+		"RemovedArea":   "Sheet1!#REF!",
+		"PartlyRemoved": "Sheet1!#REF!,Sheet1!$E$1:$E$5",
+		"Span":          "Sheet1!$C:$D",
+		"Rows":          "Sheet1!$2:$3",
+		"Total":         "SUM(Sheet1!$C:$C,'My Sheet'!$C:$C)",
+	}
+	assert.Equal(t, expected, getRefersTo(f))
+	// Test adjust the defined names after save and reopen the workbook
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAdjustDefinedNamesColumns.xlsx")))
+	assert.NoError(t, f.Close())
+	f, err := OpenFile(filepath.Join("test", "TestAdjustDefinedNamesColumns.xlsx"))
+	assert.NoError(t, err)
+	assert.Equal(t, expected, getRefersTo(f))
+	assert.NoError(t, f.RemoveCols("Sheet1", "B", "C"))
+	assert.Equal(t, map[string]string{
+		"Prices":        "Sheet1!#REF!",
+		"Bounded":       "Sheet1!#REF!",
+		"Multi":         "Sheet1!#REF!,Sheet1!$C$1:$C$5",
+		"Other":         "'My Sheet'!$D:$D",
+		"Removed":       "Sheet1!#REF!",
+		"RemovedArea":   "Sheet1!#REF!",
+		"PartlyRemoved": "Sheet1!#REF!,Sheet1!$C$1:$C$5",
+		"Span":          "Sheet1!$B:$B",
+		"Rows":          "Sheet1!$2:$3",
+		"Total":         "SUM(Sheet1!#REF!,'My Sheet'!$C:$C)",
+	}, getRefersTo(f))
+	assert.NoError(t, f.Close())
+
+	// Test adjust the area of the defined name exceeds the maximum columns
+	_, err = adjustDefinedNameRef("Sheet1", "Sheet1!$XFD:$XFD", columns, 1, 1)
+	assert.Equal(t, ErrColumnNumber, err)
+	_, err = adjustDefinedNameRef("Sheet1", "Sheet1!$1048576:$1048576", rows, 1, 1)
+	assert.Equal(t, ErrMaxRows, err)
+	// Test adjust the area of the defined name with relative references
+	for _, ref := range []string{"Sheet1!C:C", "Sheet1!C1", "Sheet1!$A1:B1", "Sheet1!XFE1"} {
+		data, err := adjustDefinedNameRef("Sheet1", ref, columns, 1, -1)
+		assert.NoError(t, err)
+		assert.Equal(t, ref, data)
+	}
+}
+
+func TestAdjustExtLst(t *testing.T) {
+	f := NewFile()
+	for r := 1; r <= 6; r++ {
+		assert.NoError(t, f.SetSheetRow("Sheet1", fmt.Sprintf("A%d", r), &[]interface{}{1, 2, 3, 4, 5, 6}))
+	}
+	formatID, err := f.NewConditionalStyle(&Style{Font: &Font{Color: "9A0511"}})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetConditionalFormat("Sheet1", "C1:D5 F1:F3 B2", []ConditionalFormatOptions{
+		{Type: "cell", Criteria: ">", Format: &formatID, Value: "$C$1"},
+	}))
+	assert.NoError(t, f.SetConditionalFormat("Sheet1", "A1:A5", []ConditionalFormatOptions{
+		{Type: "data_bar", Criteria: "=", MinType: "min", MaxType: "max", BarColor: "#638EC6", BarSolid: true},
+	}))
+	dv := NewDataValidation(true)
+	dv.Sqref = "C2:C10 E1"
+	dv.SetSqrefDropList("$C$1:$D$1")
+	assert.NoError(t, f.AddDataValidation("Sheet1", dv))
+	assert.NoError(t, f.AddTable("Sheet1", &Table{Range: "B1:E5", Name: "Table1"}))
+	assert.NoError(t, f.AddSparkline("Sheet1", &SparklineOptions{
+		Location: []string{"G2", "G3"},
+		Range:    []string{"Sheet1!A2:F2", "Sheet1!A3:F3"},
+	}))
+	assert.NoError(t, f.InsertCols("Sheet1", "C", 2))
+
+	buf, err := f.WriteToBuffer()
+	assert.NoError(t, err)
+	f, err = OpenReader(buf)
+	assert.NoError(t, err)
+	opts, err := f.GetConditionalFormats("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, opts, 2)
+	assert.Equal(t, "$E$1", opts["E1:F5 H1:H3 B2:B2"][0].Value)
+	assert.Contains(t, opts, "A1:A5")
+	dvs, err := f.GetDataValidations("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, dvs, 1)
+	assert.Equal(t, "E2:E10 G1:G1", dvs[0].Sqref)
+	assert.Equal(t, "$E$1:$F$1", dvs[0].Formula1)
+	tables, err := f.GetTables("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, "B1:G5", tables[0].Range)
+	ws, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	assert.Contains(t, ws.ExtLst.Ext, "<xm:f>Sheet1!A2:H2</xm:f><xm:sqref>I2</xm:sqref>")
+	assert.Contains(t, ws.ExtLst.Ext, "<xm:f>Sheet1!A3:H3</xm:f><xm:sqref>I3</xm:sqref>")
+	assert.Contains(t, ws.ExtLst.Ext, ExtURIConditionalFormattings)
+
+	// Test remove columns with ranges entirely inside the removed columns
+	assert.NoError(t, f.RemoveCol("Sheet1", "I"))
+	assert.NoError(t, f.RemoveCol("Sheet1", "A"))
+	buf, err = f.WriteToBuffer()
+	assert.NoError(t, err)
+	f, err = OpenReader(buf)
+	assert.NoError(t, err)
+	opts, err = f.GetConditionalFormats("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, opts, 1)
+	assert.Equal(t, "$D$1", opts["D1:E5 G1:G3 A2:A2"][0].Value)
+	ws, err = f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	assert.NotContains(t, ws.ExtLst.Ext, ExtURISparklineGroups)
+	assert.NoError(t, f.Close())
+
+	// Test adjust conditional formatting with reference sequence in the
+	// extension list
+	f = NewFile()
+	ws, err = f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	ws.ExtLst = &xlsxExtLst{Ext: `<ext uri="` + ExtURIConditionalFormattings + `"><x14:conditionalFormattings><x14:conditionalFormatting xmlns:xm="` + NameSpaceSpreadSheetExcel2006Main.Value + `"><x14:cfRule type="dataBar" id="{00000000-0000-0000-0000-000000000001}"></x14:cfRule><xm:sqref>$B$1:$C$5</xm:sqref></x14:conditionalFormatting><x14:conditionalFormatting xmlns:xm="` + NameSpaceSpreadSheetExcel2006Main.Value + `"><x14:cfRule type="dataBar" id="{00000000-0000-0000-0000-000000000002}"></x14:cfRule><xm:sqref>D1:D5</xm:sqref></x14:conditionalFormatting></x14:conditionalFormattings></ext>`}
+	assert.NoError(t, f.InsertCols("Sheet1", "C", 1))
+	assert.Contains(t, ws.ExtLst.Ext, "<xm:sqref>$B$1:$D$5</xm:sqref>")
+	assert.Contains(t, ws.ExtLst.Ext, "<xm:sqref>E1:E5</xm:sqref>")
+	assert.NoError(t, f.RemoveCol("Sheet1", "E"))
+	assert.Contains(t, ws.ExtLst.Ext, "<xm:sqref>$B$1:$D$5</xm:sqref>")
+	assert.NotContains(t, ws.ExtLst.Ext, "00000000-0000-0000-0000-000000000002")
+	assert.NoError(t, f.RemoveCols("Sheet1", "B", "D"))
+	assert.Nil(t, ws.ExtLst)
+
+	// Test adjust extension list with custom namespace prefixes
+	f = NewFile()
+	ws, err = f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	ws.ExtLst = &xlsxExtLst{Ext: `<ext xmlns:s="` + NameSpaceSpreadSheetX14.Value + `" uri="` + ExtURISparklineGroups + `"><s:sparklineGroups xmlns:m="` + NameSpaceSpreadSheetExcel2006Main.Value + `"><s:sparklineGroup displayEmptyCellsAs="gap"><s:colorSeries rgb="FF376092"/><s:sparklines><s:sparkline><m:f>Sheet1!A2:F2</m:f><m:sqref>G2</m:sqref></s:sparkline><s:sparkline><m:f>Sheet1!A3:F3</m:f><m:sqref>G3</m:sqref></s:sparkline></s:sparklines></s:sparklineGroup></s:sparklineGroups></ext>`}
+	assert.NoError(t, f.InsertCols("Sheet1", "C", 1))
+	assert.Contains(t, ws.ExtLst.Ext, `<s:colorSeries rgb="FF376092"/>`)
+	assert.Contains(t, ws.ExtLst.Ext, "<s:sparkline><m:f>Sheet1!A2:G2</m:f><m:sqref>H2</m:sqref></s:sparkline>")
+	assert.NoError(t, f.RemoveRows("Sheet1", []int{1, 2}))
+	assert.NotContains(t, ws.ExtLst.Ext, "H2")
+	assert.Contains(t, ws.ExtLst.Ext, "<s:sparkline><m:f>Sheet1!A1:G1</m:f><m:sqref>H1</m:sqref></s:sparkline>")
+	assert.NoError(t, f.RemoveRows("Sheet1", []int{1}))
+	assert.Nil(t, ws.ExtLst)
+
+	// Test adjust extension list with unsupported charset
+	f = NewFile()
+	ws, err = f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	ws.ExtLst = &xlsxExtLst{Ext: string(MacintoshCyrillicCharset)}
+	assert.EqualError(t, f.InsertCols("Sheet1", "A", 1), "XML syntax error on line 1: invalid UTF-8")
+}
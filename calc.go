@@ -14,6 +14,7 @@ package excelize
 import (
 	"bytes"
 	"container/list"
+	"context"
 	"errors"
 	"fmt"
 	"math"
@@ -49,6 +50,11 @@ const (
 	formulaErrorSPILL       = "#SPILL!"
 	formulaErrorCALC        = "#CALC!"
 	formulaErrorGETTINGDATA = "#GETTING_DATA"
+	// Default limits of the formula calculation
+	defaultMaxCalcSteps      = 1 << 24
+	defaultMaxCalcRangeCells = 1 << 22
+	defaultMaxCalcDepth      = 1 << 14
+	defaultCalcTimeout       = time.Minute
 	// Formula criteria condition enumeration
 	_ byte = iota
 	criteriaEq
@@ -220,6 +226,107 @@ type calcContext struct {
 	maxCalcIterations uint
 	iterations        map[string]uint
 	iterationsCache   map[string]formulaArg
+	unknownFuncAsName bool
+	unknownFunc       bool
+	maxCalcSteps      int
+	maxCalcRangeCells int
+	maxCalcDepth      int
+	calcTimeout       time.Duration
+	deadline          time.Time
+	done              <-chan struct{}
+	cancelErr         func() error
+	steps             int
+	rangeCells        int
+	depth             int
+	limitErr          error
+}
+
+// newCalcContext provides a function to create the formula execution context
+// by given worksheet name, cell reference and options, the limits of the
+// formula calculation will be set as the default value if not specified.
+func newCalcContext(sheet, cell string, options *Options) *calcContext {
+	ctx := &calcContext{
+		entry:             fmt.Sprintf("%s!%s", sheet, cell),
+		maxCalcIterations: options.MaxCalcIterations,
+		iterations:        make(map[string]uint),
+		iterationsCache:   make(map[string]formulaArg),
+		maxCalcSteps:      options.MaxCalcSteps,
+		maxCalcRangeCells: options.MaxCalcRangeCells,
+		maxCalcDepth:      options.MaxCalcDepth,
+		calcTimeout:       options.CalcTimeout,
+	}
+	if ctx.maxCalcSteps <= 0 {
+		ctx.maxCalcSteps = defaultMaxCalcSteps
+	}
+	if ctx.maxCalcRangeCells <= 0 {
+		ctx.maxCalcRangeCells = defaultMaxCalcRangeCells
+	}
+	if ctx.maxCalcDepth <= 0 {
+		ctx.maxCalcDepth = defaultMaxCalcDepth
+	}
+	if ctx.calcTimeout <= 0 {
+		ctx.calcTimeout = defaultCalcTimeout
+	}
+	ctx.deadline = time.Now().Add(ctx.calcTimeout)
+	return ctx
+}
+
+// exceedLimit provides a function to record the limit of the formula
+// calculation which has been exceeded, the calculation will be stopped and
+// the error will be returned by the CalcCellValue function.
+func (ctx *calcContext) exceedLimit(limit string, value interface{}) error {
+	if ctx.limitErr == nil {
+		ctx.limitErr = ErrCalcLimitExceeded{Limit: limit, Value: value}
+	}
+	return ctx.limitErr
+}
+
+// step provides a function to count an evaluation step, and check the steps
+// and time limits of the formula calculation. The clock will be checked every
+// 1024 steps to reduce the overhead.
+func (ctx *calcContext) step() error {
+	if ctx.limitErr != nil {
+		return ctx.limitErr
+	}
+	if ctx.steps++; ctx.steps > ctx.maxCalcSteps {
+		return ctx.exceedLimit("MaxCalcSteps", ctx.maxCalcSteps)
+	}
+	if ctx.done != nil {
+		select {
+		case <-ctx.done:
+			ctx.limitErr = ctx.cancelErr()
+			return ctx.limitErr
+		default:
+		}
+	}
+	if ctx.steps%1024 == 0 && time.Now().After(ctx.deadline) {
+		return ctx.exceedLimit("CalcTimeout", ctx.calcTimeout)
+	}
+	return nil
+}
+
+// expandRange provides a function to count the cells expanded from the cell
+// range, and check the range cells limit of the formula calculation before
+// allocating the values of the range.
+func (ctx *calcContext) expandRange(rows, cols int) error {
+	if ctx.limitErr != nil {
+		return ctx.limitErr
+	}
+	if rows > 0 && cols > 0 {
+		if ctx.rangeCells += rows * cols; ctx.rangeCells > ctx.maxCalcRangeCells {
+			return ctx.exceedLimit("MaxCalcRangeCells", ctx.maxCalcRangeCells)
+		}
+	}
+	return nil
+}
+
+// checkDepth provides a function to check the recursion depth limit of the
+// formula calculation by given nested functions count of the current formula.
+func (ctx *calcContext) checkDepth(nested int) error {
+	if ctx.depth+nested > ctx.maxCalcDepth {
+		return ctx.exceedLimit("MaxCalcDepth", ctx.maxCalcDepth)
+	}
+	return nil
 }
 
 // cellRef defines the structure of a cell reference.
@@ -268,6 +375,13 @@ type formulaArg struct {
 	cellRefs, cellRanges *list.List
 }
 
+// FormulaArg is the argument and the result of the custom formula function
+// registered by the RegisterCalcFunction function. The Type field specifies
+// the data type of the argument, an error result should be specified with the
+// ArgError type, the formula error value in the String field, such as
+// "#VALUE!", and the error message in the Error field.
+type FormulaArg = formulaArg
+
 // Value returns a string data type of the formula argument.
 func (fa formulaArg) Value() (value string) {
 	switch fa.Type {
@@ -814,24 +928,70 @@ type formulaFuncs struct {
 //	Z.TEST
 //	ZTEST
 func (f *File) CalcCellValue(sheet, cell string, opts ...Options) (result string, err error) {
+	return f.CalcCellValueContext(context.Background(), sheet, cell, opts...)
+}
+
+// CalcCellValueContext provides a function to get calculated cell value the
+// same as the CalcCellValue function, and the calculation will be stopped
+// when the given context is canceled or its deadline exceeded, the error of
+// the context will be returned in that case. The context is checked on every
+// evaluation step together with the MaxCalcSteps limit. For example,
+// calculate the cell A1 on Sheet1 and cancel it after 10 seconds:
+//
+//	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+//	defer cancel()
+//	result, err := f.CalcCellValueContext(ctx, "Sheet1", "A1")
+//	if errors.Is(err, context.DeadlineExceeded) {
+//	    fmt.Println("calculation canceled")
+//	}
+func (f *File) CalcCellValueContext(cancelCtx context.Context, sheet, cell string, opts ...Options) (result string, err error) {
 	options := f.getOptions(opts...)
 	var (
 		rawCellValue = options.RawCellValue
 		styleIdx     int
 		token        formulaArg
 	)
-	if token, err = f.calcCellValue(&calcContext{
-		entry:             fmt.Sprintf("%s!%s", sheet, cell),
-		maxCalcIterations: options.MaxCalcIterations,
-		iterations:        make(map[string]uint),
-		iterationsCache:   make(map[string]formulaArg),
-	}, sheet, cell); err != nil {
+	ctx := newCalcContext(sheet, cell, options)
+	ctx.done, ctx.cancelErr = cancelCtx.Done(), cancelCtx.Err
+	if err = cancelCtx.Err(); err != nil {
+		return
+	}
+	ctx.unknownFuncAsName = getCallOptions(opts...).UnknownFunctionAsNameError
+	if token, err = f.calcCellValue(ctx, sheet, cell); ctx.limitErr != nil {
+		return "", ctx.limitErr
+	}
+	if err != nil {
 		result = token.String
 		return
 	}
 	if !rawCellValue {
 		styleIdx, _ = f.GetCellStyle(sheet, cell)
 	}
+	return f.formatCalcResult(token, styleIdx, rawCellValue)
+}
+
+// calcFormulaValue provides a function to calculate the given formula of the
+// cell by given worksheet name, cell reference, formula, style index of the
+// cell and if get the raw value. It is used by the rows and columns iterators
+// to calculate the formula which has been decoded, so the worksheet will be
+// only loaded when the formula referencing other cells.
+func (f *File) calcFormulaValue(sheet, cell, formula string, styleIdx int, rawCellValue bool) (string, error) {
+	ctx := newCalcContext(sheet, cell, f.options)
+	ps := efp.ExcelParser()
+	token, err := f.evalInfixExp(ctx, sheet, cell, ps.Parse(formula))
+	if ctx.limitErr != nil {
+		return "", ctx.limitErr
+	}
+	if err != nil {
+		return token.String, err
+	}
+	return f.formatCalcResult(token, styleIdx, rawCellValue)
+}
+
+// formatCalcResult provides a function to format the result of the formula
+// calculation by given result, style index of the cell and if get the raw
+// value.
+func (f *File) formatCalcResult(token formulaArg, styleIdx int, rawCellValue bool) (result string, err error) {
 	if token.Type == ArgNumber && !token.Boolean {
 		_, precision, decimal := isNumeric(token.Value())
 		if precision > 15 {
@@ -941,6 +1101,9 @@ func (f *File) evalInfixExp(ctx *calcContext, sheet, cell string, tokens []efp.T
 	)
 	for i := 0; i < len(tokens); i++ {
 		token := tokens[i]
+		if err = ctx.step(); err != nil {
+			return newEmptyFormulaArg(), err
+		}
 
 		// out of function stack
 		if opfStack.Len() == 0 {
@@ -960,6 +1123,9 @@ func (f *File) evalInfixExp(ctx *calcContext, sheet, cell string, tokens []efp.T
 				continue
 			}
 			opfStack.Push(token)
+			if err = ctx.checkDepth(opfStack.Len()); err != nil {
+				return newEmptyFormulaArg(), err
+			}
 			argsStack.Push(list.New().Init())
 			opftStack.Push(token) // to know which operators belong to a function use the function as a separator
 			continue
@@ -1068,10 +1234,12 @@ func (f *File) evalInfixExpFunc(ctx *calcContext, sheet, cell string, token, nex
 	}
 	prepareEvalInfixExp(opfStack, opftStack, opfdStack, argsStack)
 	// call formula function to evaluate
-	arg := callFuncByName(&formulaFuncs{f: f, sheet: sheet, cell: cell, ctx: ctx}, strings.NewReplacer(
-		"_xlfn.", "", ".", "dot").Replace(opfStack.Peek().(efp.Token).TValue),
-		[]reflect.Value{reflect.ValueOf(argsStack.Peek().(*list.List))})
-	if arg.Type == ArgError && opfStack.Len() == 1 {
+	arg, defined := f.callCalcFunction(ctx, sheet, cell, opfStack.Peek().(efp.Token).TValue, argsStack.Peek().(*list.List))
+	if !defined && ctx.unknownFuncAsName {
+		// the #NAME? error of the unknown function is a formula value, which
+		// should be propagated as the spreadsheet applications
+		arg, ctx.unknownFunc = newErrorFormulaArg(formulaErrorNAME, formulaErrorNAME), true
+	} else if arg.Type == ArgError && opfStack.Len() == 1 && !ctx.unknownFunc {
 		return arg
 	}
 	argsStack.Pop()
@@ -1531,6 +1699,35 @@ func (cr *cellRange) prepareCellRange(col, row bool, cellRef cellRef) error {
 	return nil
 }
 
+// isWholeCols returns if the cell range is the whole column reference, such
+// as A:C.
+func (cr *cellRange) isWholeCols() bool {
+	return cr.From.Row == 1 && cr.To.Row == TotalRows
+}
+
+// isWholeRows returns if the cell range is the whole row reference, such as
+// 1:3.
+func (cr *cellRange) isWholeRows() bool {
+	return cr.From.Col == 1 && cr.To.Col == MaxColumns
+}
+
+// clipValueRange provides a function to clip the value range of the whole
+// column or whole row reference by the used extent of the worksheet, so that
+// only the populated part of the range will be expanded. At least one row and
+// one column of the range will be kept.
+func (ws *xlsxWorksheet) clipValueRange(cr cellRange, valueRange []int) {
+	if cr.isWholeCols() {
+		valueRange[1] = max(valueRange[0], min(valueRange[1], len(ws.SheetData.Row)))
+	}
+	if cr.isWholeRows() {
+		var maxCol int
+		for _, row := range ws.SheetData.Row {
+			maxCol = max(maxCol, len(row.C))
+		}
+		valueRange[3] = max(valueRange[2], min(valueRange[3], maxCol))
+	}
+}
+
 // parseReference parse reference and extract values by given reference
 // characters and default sheet name.
 func (f *File) parseReference(ctx *calcContext, sheet, reference string) (formulaArg, error) {
@@ -1613,14 +1810,28 @@ func (f *File) cellResolver(ctx *calcContext, sheet, cell string) (formulaArg, e
 		value string
 		err   error
 	)
+	if err = ctx.step(); err != nil {
+		return arg, err
+	}
 	ref := fmt.Sprintf("%s!%s", sheet, cell)
 	if formula, _ := f.getCellFormula(sheet, cell, true); len(formula) != 0 {
 		ctx.mu.Lock()
 		if ctx.entry != ref {
 			if ctx.iterations[ref] <= f.options.MaxCalcIterations {
+				if err = ctx.checkDepth(1); err != nil {
+					ctx.mu.Unlock()
+					return arg, err
+				}
 				ctx.iterations[ref]++
+				ctx.depth++
 				ctx.mu.Unlock()
 				arg, _ = f.calcCellValue(ctx, sheet, cell)
+				ctx.mu.Lock()
+				ctx.depth--
+				ctx.mu.Unlock()
+				if ctx.limitErr != nil {
+					return arg, ctx.limitErr
+				}
 				ctx.iterationsCache[ref] = arg
 				return arg, nil
 			}
@@ -1663,7 +1874,8 @@ func (f *File) cellResolver(ctx *calcContext, sheet, cell string) (formulaArg, e
 
 // rangeResolver extract value as string from given reference and range list.
 // This function will not ignore the empty cell. For example, A1:A2:A2:B3 will
-// be reference A1:B3.
+// be reference A1:B3. The whole column and whole row references will be
+// expanded within the used extent of the worksheet only.
 func (f *File) rangeResolver(ctx *calcContext, cellRefs, cellRanges *list.List) (arg formulaArg, err error) {
 	arg.cellRefs, arg.cellRanges = cellRefs, cellRanges
 	// value range order: from row, to row, from column, to column
@@ -1696,6 +1908,12 @@ func (f *File) rangeResolver(ctx *calcContext, cellRefs, cellRanges *list.List)
 		if err != nil {
 			return
 		}
+		if cellRanges.Len() == 1 && cellRefs.Len() == 0 {
+			ws.clipValueRange(cellRanges.Front().Value.(cellRange), valueRange)
+		}
+		if err = ctx.expandRange(valueRange[1]-valueRange[0]+1, valueRange[3]-valueRange[2]+1); err != nil {
+			return
+		}
 
 		for row := valueRange[0]; row <= valueRange[1]; row++ {
 			colMax := 0
@@ -1737,19 +1955,84 @@ func (f *File) rangeResolver(ctx *calcContext, cellRefs, cellRanges *list.List)
 	return
 }
 
-// callFuncByName calls the no error or only error return function with
-// reflect by given receiver, name and parameters.
-func callFuncByName(receiver interface{}, name string, params []reflect.Value) (arg formulaArg) {
-	function := reflect.ValueOf(receiver).MethodByName(name)
-	if function.IsValid() {
-		rt := function.Call(params)
-		if len(rt) == 0 {
-			return
+// RegisterCalcFunction provides a function to register a custom formula
+// function for the CalcCellValue function by given function name, such as the
+// user-defined functions provided by the add-ins. The function name is case
+// insensitive, and the "_xll." or "_xludf." prefix of the function name in
+// the formula will be ignored. The registered function will receive all the
+// arguments in the formula, so it should check the number of arguments and
+// return an error result if necessary. The error result of the function will
+// be propagated as the built-in functions. It returns an error if the
+// function name conflicts with a built-in function, and the function
+// registered with the same name will be replaced. For example, register a
+// function named RATECARD to double the given number:
+//
+//	err := f.RegisterCalcFunction("RATECARD", func(args []excelize.FormulaArg) excelize.FormulaArg {
+//	    if len(args) != 1 {
+//	        return excelize.FormulaArg{Type: excelize.ArgError, String: "#VALUE!", Error: "RATECARD requires 1 argument"}
+//	    }
+//	    if num := args[0].ToNumber(); num.Type == excelize.ArgNumber {
+//	        return excelize.FormulaArg{Type: excelize.ArgNumber, Number: num.Number * 2}
+//	    }
+//	    return excelize.FormulaArg{Type: excelize.ArgError, String: "#VALUE!", Error: "#VALUE!"}
+//	})
+func (f *File) RegisterCalcFunction(name string, fn func(args []FormulaArg) FormulaArg) error {
+	name = strings.ToUpper(trimCalcFunctionPrefix(name))
+	if name == "" || fn == nil {
+		return ErrParameterInvalid
+	}
+	if _, ok := getCalcFunctions()[strings.NewReplacer(
+		"_xlfn.", "", ".", "dot").Replace(name)]; ok {
+		return newCalcFunctionConflictError(name)
+	}
+	f.calcFuncs.Store(name, fn)
+	return nil
+}
+
+// trimCalcFunctionPrefix provides a function to remove the add-in and
+// user-defined function prefix of the formula function name.
+func trimCalcFunctionPrefix(name string) string {
+	for _, prefix := range []string{"_xll.", "_xludf."} {
+		if len(name) > len(prefix) && strings.EqualFold(name[:len(prefix)], prefix) {
+			return name[len(prefix):]
 		}
-		arg = rt[0].Interface().(formulaArg)
-		return
 	}
-	return newErrorFormulaArg(formulaErrorVALUE, fmt.Sprintf("not support %s function", name))
+	return name
+}
+
+// getCalcFunctions provides a function to get the mapping of the built-in
+// formula function name and the method index of the formula functions
+// receiver, the mapping will be built only once on the first call.
+var getCalcFunctions = sync.OnceValue(func() map[string]int {
+	fnType := reflect.TypeOf(&formulaFuncs{})
+	fns := make(map[string]int, fnType.NumMethod())
+	for i := 0; i < fnType.NumMethod(); i++ {
+		fns[fnType.Method(i).Name] = i
+	}
+	return fns
+})
+
+// callCalcFunction calls the built-in or registered custom formula function
+// by given function name and arguments list, and returns if the function has
+// been defined.
+func (f *File) callCalcFunction(ctx *calcContext, sheet, cell, name string, args *list.List) (formulaArg, bool) {
+	if fn, ok := f.calcFuncs.Load(strings.ToUpper(trimCalcFunctionPrefix(name))); ok {
+		params := make([]formulaArg, 0, args.Len())
+		for arg := args.Front(); arg != nil; arg = arg.Next() {
+			params = append(params, arg.Value.(formulaArg))
+		}
+		return fn.(func(args []FormulaArg) FormulaArg)(params), true
+	}
+	fnName := strings.NewReplacer("_xlfn.", "", ".", "dot").Replace(name)
+	idx, ok := getCalcFunctions()[fnName]
+	if !ok {
+		return newErrorFormulaArg(formulaErrorVALUE, fmt.Sprintf("not support %s function", fnName)), false
+	}
+	receiver := &formulaFuncs{f: f, sheet: sheet, cell: cell, ctx: ctx}
+	if rt := reflect.ValueOf(receiver).Method(idx).Call([]reflect.Value{reflect.ValueOf(args)}); len(rt) > 0 {
+		return rt[0].Interface().(formulaArg), true
+	}
+	return formulaArg{}, true
 }
 
 // formulaCriteriaParser parse formula criteria.
@@ -7883,7 +8166,8 @@ func (fn *formulaFuncs) COUNT(argsList *list.List) formulaArg {
 }
 
 // COUNTA function returns the number of non-blanks within a supplied set of
-// cells or values. The syntax of the function is:
+// cells or values, the cells with an empty string value will be counted. The
+// syntax of the function is:
 //
 //	COUNTA(value1,[value2],...)
 func (fn *formulaFuncs) COUNTA(argsList *list.List) formulaArg {
@@ -7891,20 +8175,12 @@ func (fn *formulaFuncs) COUNTA(argsList *list.List) formulaArg {
 	for token := argsList.Front(); token != nil; token = token.Next() {
 		arg := token.Value.(formulaArg)
 		switch arg.Type {
-		case ArgString:
-			if arg.String != "" {
-				count++
-			}
-		case ArgNumber:
+		case ArgString, ArgNumber:
 			count++
 		case ArgMatrix:
 			for _, row := range arg.ToList() {
 				switch row.Type {
-				case ArgString:
-					if row.String != "" {
-						count++
-					}
-				case ArgNumber:
+				case ArgString, ArgNumber:
 					count++
 				}
 			}
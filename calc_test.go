@@ -2,10 +2,13 @@ package excelize
 
 import (
 	"container/list"
+	"context"
 	"math"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/xuri/efp"
@@ -1063,6 +1066,7 @@ func TestCalcCellValue(t *testing.T) {
 		"=COUNTA(A1:A5,B2:B5,\"text\",1,INT(2))": "8",
 		"=COUNTA(COUNTA(1),MUNIT(1))":            "2",
 		"=COUNTA(D1:D2)":                         "2",
+		"=COUNTA(\"\",\"\")":                     "2",
 		// COUNTBLANK
 		"=COUNTBLANK(MUNIT(1))": "0",
 		"=COUNTBLANK(1)":        "0",
@@ -6313,7 +6317,7 @@ func TestCalcRangeResolver(t *testing.T) {
 		From: cellRef{Col: 1, Row: 1, Sheet: "SheetN"},
 		To:   cellRef{Col: 1, Row: TotalRows, Sheet: "SheetN"},
 	})
-	_, err := f.rangeResolver(&calcContext{}, cellRefs, cellRanges)
+	_, err := f.rangeResolver(newCalcContext("Sheet1", "A1", &Options{}), cellRefs, cellRanges)
 	assert.EqualError(t, err, "sheet SheetN does not exist")
 
 	ws, err := f.workSheetReader("Sheet1")
@@ -6325,18 +6329,18 @@ func TestCalcRangeResolver(t *testing.T) {
 		From: cellRef{Col: 3, Row: TotalRows, Sheet: "Sheet1"},
 		To:   cellRef{Col: 3, Row: TotalRows + 1, Sheet: "Sheet1"},
 	})
-	_, err = f.rangeResolver(&calcContext{}, cellRefs, cellRanges)
+	_, err = f.rangeResolver(newCalcContext("Sheet1", "A1", &Options{}), cellRefs, cellRanges)
 	assert.Equal(t, ErrMaxRows, err)
 
 	// Test extract value from references with invalid references
 	cellRanges.Init()
 	cellRefs.PushBack(cellRef{Col: 1, Row: 1, Sheet: "SheetN"})
-	_, err = f.rangeResolver(&calcContext{}, cellRefs, cellRanges)
+	_, err = f.rangeResolver(newCalcContext("Sheet1", "A1", &Options{}), cellRefs, cellRanges)
 	assert.EqualError(t, err, "sheet SheetN does not exist")
 
 	cellRefs.Init()
 	cellRefs.PushBack(cellRef{Col: 1, Row: TotalRows + 1, Sheet: "SheetN"})
-	_, err = f.rangeResolver(&calcContext{}, cellRefs, cellRanges)
+	_, err = f.rangeResolver(newCalcContext("Sheet1", "A1", &Options{}), cellRefs, cellRanges)
 	assert.Equal(t, ErrMaxRows, err)
 }
 
@@ -6489,7 +6493,7 @@ func TestCalcCellResolver(t *testing.T) {
 
 func TestEvalInfixExp(t *testing.T) {
 	f := NewFile()
-	arg, err := f.evalInfixExp(nil, "Sheet1", "A1", []efp.Token{
+	arg, err := f.evalInfixExp(newCalcContext("Sheet1", "A1", &Options{}), "Sheet1", "A1", []efp.Token{
 		{TSubType: efp.TokenSubTypeRange, TValue: "1A"},
 	})
 	assert.Equal(t, arg, newEmptyFormulaArg())
@@ -6502,3 +6506,207 @@ func TestParseToken(t *testing.T) {
 		efp.Token{TSubType: efp.TokenSubTypeRange, TValue: "1A"}, nil, nil,
 	).Error())
 }
+
+func TestRegisterCalcFunction(t *testing.T) {
+	f := NewFile()
+	rateCard := func(args []FormulaArg) FormulaArg {
+		if len(args) != 1 {
+			return FormulaArg{Type: ArgError, String: formulaErrorVALUE, Error: "RATECARD requires 1 argument"}
+		}
+		if num := args[0].ToNumber(); num.Type == ArgNumber {
+			return FormulaArg{Type: ArgNumber, Number: num.Number * 2}
+		}
+		return FormulaArg{Type: ArgError, String: formulaErrorVALUE, Error: formulaErrorVALUE}
+	}
+	assert.NoError(t, f.RegisterCalcFunction("rateCard", rateCard))
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A1", &[]interface{}{3, 1}))
+	for _, tbl := range [][]string{
+		{"=SUM(RATECARD(A1),B1)", "7"},
+		{"=SUM(_xll.RATECARD(A1),RATECARD(B1))", "8"},
+		{"=IF(RATECARD(A1)>5,\"high\",\"low\")", "high"},
+		{"=IF(RATECARD(B1)>5,\"high\",\"low\")", "low"},
+		{"=IFERROR(RATECARD(A1,B1),\"error\")", "error"},
+	} {
+		assert.NoError(t, f.SetCellFormula("Sheet1", "C1", tbl[0]))
+		result, err := f.CalcCellValue("Sheet1", "C1")
+		assert.NoError(t, err, tbl[0])
+		assert.Equal(t, tbl[1], result, tbl[0])
+	}
+	// Test calculate the custom function with error result
+	assert.NoError(t, f.SetCellFormula("Sheet1", "C1", "=RATECARD(A1,B1)"))
+	result, err := f.CalcCellValue("Sheet1", "C1")
+	assert.EqualError(t, err, "RATECARD requires 1 argument")
+	assert.Equal(t, formulaErrorVALUE, result)
+	// Test register custom function which conflicts with the built-in function
+	assert.EqualError(t, f.RegisterCalcFunction("sum", rateCard), "formula function SUM conflicts with the built-in function")
+	assert.EqualError(t, f.RegisterCalcFunction("Norm.Dist", rateCard), "formula function NORM.DIST conflicts with the built-in function")
+	// Test register custom function with invalid parameters
+	assert.Equal(t, ErrParameterInvalid, f.RegisterCalcFunction("", rateCard))
+	assert.Equal(t, ErrParameterInvalid, f.RegisterCalcFunction("BLOOMBERG", nil))
+	// Test calculate unregistered function
+	assert.NoError(t, f.SetCellFormula("Sheet1", "C1", "=SUM(BLOOMBERG(A1),1)"))
+	_, err = f.CalcCellValue("Sheet1", "C1")
+	assert.EqualError(t, err, "not support BLOOMBERG function")
+	for _, formula := range []string{"=SUM(BLOOMBERG(A1),1)", "=BLOOMBERG(A1)", "=SUM(1,SUM(BLOOMBERG(A1),1))", "=MAX(_xll.BLOOMBERG(A1),1)"} {
+		assert.NoError(t, f.SetCellFormula("Sheet1", "C1", formula))
+		result, err = f.CalcCellValue("Sheet1", "C1", Options{UnknownFunctionAsNameError: true})
+		assert.NoError(t, err, formula)
+		assert.Equal(t, formulaErrorNAME, result, formula)
+	}
+	// Test calculate the error result of the defined function with unknown function as name error
+	assert.NoError(t, f.SetCellFormula("Sheet1", "C1", "=SUM(RATECARD(A1,B1),1)"))
+	result, err = f.CalcCellValue("Sheet1", "C1", Options{UnknownFunctionAsNameError: true})
+	assert.EqualError(t, err, "RATECARD requires 1 argument")
+	assert.Equal(t, formulaErrorVALUE, result)
+	// Test the unknown function as name error option of the workbook be ignored
+	f.options.UnknownFunctionAsNameError = true
+	assert.NoError(t, f.SetCellFormula("Sheet1", "C1", "=BLOOMBERG(A1)"))
+	_, err = f.CalcCellValue("Sheet1", "C1")
+	assert.EqualError(t, err, "not support BLOOMBERG function")
+	f.options.UnknownFunctionAsNameError = false
+	assert.NoError(t, f.SetCellFormula("Sheet1", "C1", "=IFERROR(BLOOMBERG(A1),\"n/a\")"))
+	result, err = f.CalcCellValue("Sheet1", "C1", Options{UnknownFunctionAsNameError: true})
+	assert.NoError(t, err)
+	assert.Equal(t, "n/a", result)
+	assert.NoError(t, f.SetCellFormula("Sheet1", "C1", "=BLOOMBERG(A1)"))
+	result, err = f.CalcCellValue("Sheet1", "C1", Options{UnknownFunctionAsNameError: true})
+	assert.NoError(t, err)
+	assert.Equal(t, formulaErrorNAME, result)
+}
+
+func TestCalcLimits(t *testing.T) {
+	f := NewFile()
+	for row := 1; row <= 2000; row++ {
+		assert.NoError(t, f.SetCellValue("Sheet1", "A"+strconv.Itoa(row), row))
+		assert.NoError(t, f.SetCellValue("Sheet1", "B"+strconv.Itoa(row), 2))
+	}
+	assertLimit := func(err error, limit string) {
+		var limitErr ErrCalcLimitExceeded
+		if assert.ErrorAs(t, err, &limitErr) {
+			assert.Equal(t, limit, limitErr.Limit)
+		}
+	}
+	// Test calculate the formula with full column ranges within the default limits
+	assert.NoError(t, f.SetCellFormula("Sheet1", "C1", "=SUMPRODUCT(A:A,B:B)"))
+	result, err := f.CalcCellValue("Sheet1", "C1")
+	assert.NoError(t, err)
+	assert.Equal(t, "4002000", result)
+	// Test the range cells limit
+	_, err = f.CalcCellValue("Sheet1", "C1", Options{MaxCalcRangeCells: 1000})
+	assertLimit(err, "MaxCalcRangeCells")
+	assert.EqualError(t, err, "formula calculation exceeded the MaxCalcRangeCells limit 1000")
+	start := time.Now()
+	assert.NoError(t, f.SetCellFormula("Sheet1", "C1", "=SUMPRODUCT(B2:XFD1048576,B2:XFD1048576)"))
+	_, err = f.CalcCellValue("Sheet1", "C1")
+	assertLimit(err, "MaxCalcRangeCells")
+	assert.Less(t, time.Since(start), 10*time.Second)
+	// Test the steps limit
+	assert.NoError(t, f.SetCellFormula("Sheet1", "C1", "=SUM(A1:A2000)"))
+	_, err = f.CalcCellValue("Sheet1", "C1", Options{MaxCalcSteps: 100})
+	assertLimit(err, "MaxCalcSteps")
+	// Test the time limit
+	_, err = f.CalcCellValue("Sheet1", "C1", Options{CalcTimeout: time.Nanosecond})
+	assertLimit(err, "CalcTimeout")
+	// Test cancel the calculation by the context
+	cancelCtx, cancel := context.WithCancel(context.Background())
+	result, err = f.CalcCellValueContext(cancelCtx, "Sheet1", "C1")
+	assert.NoError(t, err)
+	assert.Equal(t, "2001000", result)
+	cancel()
+	_, err = f.CalcCellValueContext(cancelCtx, "Sheet1", "C1")
+	assert.ErrorIs(t, err, context.Canceled)
+	deadlineCtx, cancel := context.WithDeadline(context.Background(), time.Now())
+	defer cancel()
+	_, err = f.CalcCellValueContext(deadlineCtx, "Sheet1", "C1")
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	ctx := newCalcContext("Sheet1", "C1", f.options)
+	ctx.done, ctx.cancelErr = cancelCtx.Done(), cancelCtx.Err
+	_, err = f.calcCellValue(ctx, "Sheet1", "C1")
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.ErrorIs(t, ctx.step(), context.Canceled)
+	// Test the recursion depth limit with nested functions
+	formula := strings.Repeat("IF(TRUE,", 500) + "1" + strings.Repeat(")", 500)
+	assert.NoError(t, f.SetCellFormula("Sheet1", "C1", formula))
+	result, err = f.CalcCellValue("Sheet1", "C1")
+	assert.NoError(t, err)
+	assert.Equal(t, "1", result)
+	_, err = f.CalcCellValue("Sheet1", "C1", Options{MaxCalcDepth: 64})
+	assertLimit(err, "MaxCalcDepth")
+	// Test the recursion depth limit with the untrusted formula exceeding the
+	// formula length limit
+	ws, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	ws.SheetData.Row[0].C[2].F.Content = strings.Repeat("IF(TRUE,", 20000) + "1" + strings.Repeat(")", 20000)
+	_, err = f.CalcCellValue("Sheet1", "C1")
+	assertLimit(err, "MaxCalcDepth")
+	// Test the recursion depth limit with the chain of cell references
+	for row := 1; row < 100; row++ {
+		assert.NoError(t, f.SetCellFormula("Sheet1", "D"+strconv.Itoa(row), "=D"+strconv.Itoa(row+1)+"+1"))
+	}
+	result, err = f.CalcCellValue("Sheet1", "D1")
+	assert.NoError(t, err)
+	assert.Equal(t, "99", result)
+	_, err = f.CalcCellValue("Sheet1", "D1", Options{MaxCalcDepth: 50})
+	assertLimit(err, "MaxCalcDepth")
+	// Test the limits of the workbook options for calculating on read
+	f.options.MaxCalcDepth = 50
+	_, err = f.calcFormulaValue("Sheet1", "E1", "=D1", 0, true)
+	assertLimit(err, "MaxCalcDepth")
+	f.options.MaxCalcDepth = 0
+	assert.NoError(t, f.Close())
+}
+
+func TestCalcWholeColumnAndRowReference(t *testing.T) {
+	f := NewFile()
+	for row := 1; row <= 5; row++ {
+		assert.NoError(t, f.SetSheetRow("Sheet1", "A"+strconv.Itoa(row), &[]int{row, row * 2, row * 3}))
+	}
+	ws, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	// Test clip the whole column and whole row references by the used extent
+	valueRange := []int{1, TotalRows, 2, 3}
+	ws.clipValueRange(cellRange{From: cellRef{Col: 2, Row: 1}, To: cellRef{Col: 3, Row: TotalRows}}, valueRange)
+	assert.Equal(t, []int{1, 5, 2, 3}, valueRange)
+	valueRange = []int{2, 4, 1, MaxColumns}
+	ws.clipValueRange(cellRange{From: cellRef{Col: 1, Row: 2}, To: cellRef{Col: MaxColumns, Row: 4}}, valueRange)
+	assert.Equal(t, []int{2, 4, 1, 3}, valueRange)
+	valueRange = []int{7, TotalRows, 1, 1}
+	ws.clipValueRange(cellRange{From: cellRef{Col: 1, Row: 7}, To: cellRef{Col: 1, Row: TotalRows}}, valueRange)
+	assert.Equal(t, []int{7, TotalRows, 1, 1}, valueRange)
+	for formula, expected := range map[string]string{
+		"=SUM(A:A)":               "15",
+		"=SUM(B:C)":               "75",
+		"=SUM(2:3)":               "30",
+		"=SUMPRODUCT(A:A,B:B)":    "110",
+		"=COUNT(Sheet1!A:C)":      "15",
+		"=ROWS(A:A)":              "1048576",
+		"=COLUMNS(2:2)":           "16384",
+		"=MATCH(4,A:A,0)":         "4",
+		"=VLOOKUP(3,A:C,3,FALSE)": "9",
+	} {
+		assert.NoError(t, f.SetCellFormula("Sheet1", "E1", formula))
+		result, err := f.CalcCellValue("Sheet1", "E1")
+		assert.NoError(t, err, formula)
+		assert.Equal(t, expected, result, formula)
+	}
+	assert.NoError(t, f.Close())
+}
+
+func BenchmarkCalcSumWholeColumn(b *testing.B) {
+	f := NewFile()
+	for row := 1; row <= 10000; row++ {
+		if err := f.SetCellValue("Sheet1", "A"+strconv.Itoa(row), row); err != nil {
+			b.Fatal(err)
+		}
+	}
+	if err := f.SetCellFormula("Sheet1", "B1", "=SUM(A:A)"); err != nil {
+		b.Fatal(err)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := f.CalcCellValue("Sheet1", "B1"); err != nil {
+			b.Error(err)
+		}
+	}
+}
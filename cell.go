@@ -13,12 +13,14 @@ package excelize
 
 import (
 	"encoding/xml"
+	"errors"
 	"fmt"
 	"math"
 	"os"
 	"reflect"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 	"unicode/utf8"
 
@@ -62,6 +64,22 @@ var cellTypes = map[string]CellType{
 	"inlineStr": CellTypeInlineString,
 }
 
+// TypedCell directly maps the value, data type and style ID of a cell.
+type TypedCell struct {
+	Value   string
+	Type    CellType
+	StyleID int
+}
+
+// CellMeta directly maps the sheet name, cell reference, data type and number
+// format ID of a cell passed to the CellValueTransformer function.
+type CellMeta struct {
+	Sheet    string
+	Cell     string
+	Type     CellType
+	NumFmtID int
+}
+
 // GetCellValue provides a function to get formatted value from cell by given
 // worksheet name and cell reference in spreadsheet. The return value is
 // converted to the 'string' data type. This function is concurrency safe. If
@@ -96,6 +114,44 @@ func (f *File) GetCellType(sheet, cell string) (CellType, error) {
 	return cellType, err
 }
 
+// CellExists provides a function to check if the cell exists by given
+// worksheet name and cell reference. The cell with an empty string value or
+// only with a style exists, and the cell that has never been set or has been
+// deleted by the DeleteCell function doesn't exist. Use this function to
+// distinguish a missing cell from a cell with an empty string value, which
+// can't be distinguished by the GetCellValue function. For example, check if
+// the cell A1 exists on Sheet1:
+//
+//	exists, err := f.CellExists("Sheet1", "A1")
+func (f *File) CellExists(sheet, cell string) (bool, error) {
+	col, row, err := CellNameToCoordinates(cell)
+	if err != nil {
+		return false, err
+	}
+	f.mu.Lock()
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		f.mu.Unlock()
+		return false, err
+	}
+	f.mu.Unlock()
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	if row > len(ws.SheetData.Row) || col > len(ws.SheetData.Row[row-1].C) {
+		return false, err
+	}
+	return ws.SheetData.Row[row-1].C[col-1].hasValue(), err
+}
+
+// getCellType returns the data type of the cell, the cell without data type
+// attribute that has a value will be treated as a number.
+func (c *xlsxC) getCellType() CellType {
+	if c.T == "" && c.V != "" {
+		return CellTypeNumber
+	}
+	return cellTypes[c.T]
+}
+
 // SetCellValue provides a function to set the value of a cell. This function
 // is concurrency safe. The specified coordinates should not be in the first
 // row of the table, a complex number can be set with string text. The
@@ -120,6 +176,12 @@ func (f *File) GetCellType(sheet, cell string) (CellType, error) {
 //	bool
 //	nil
 //
+// Set the value as nil to remove the value and formula of the cell and keep
+// the cell style, the cell without style will be removed when saving the
+// workbook. Set the value as an empty string to keep an empty string value in
+// the cell, which will be counted as a non-empty cell by the COUNTA formula
+// function. Use the DeleteCell function to remove the cell entirely.
+//
 // Note that default date format is m/d/yy h:mm of time.Time type value. You
 // can set numbers format by the SetCellStyle function. If you need to set the
 // specialized date in Excel like January 0, 1900 or February 29, 1900, these
@@ -180,6 +242,12 @@ func (c *xlsxC) hasValue() bool {
 	return c.S != 0 || c.V != "" || c.F != nil || c.T != ""
 }
 
+// hasStringValue determine if the cell contains a string value, including
+// the empty string which is different from the blank cell without any value.
+func (c *xlsxC) hasStringValue() bool {
+	return c.T == "str" || c.T == "inlineStr" || (c.T == "s" && c.V != "")
+}
+
 // removeFormula delete formula for the cell.
 func (f *File) removeFormula(c *xlsxC, ws *xlsxWorksheet, sheet string) error {
 	if c.F != nil && c.Vm == nil {
@@ -243,6 +311,7 @@ func (f *File) setCellTimeFunc(sheet, cell string, value time.Time) error {
 	if err != nil {
 		return err
 	}
+	track := f.trackCellChange(sheet, ws, col, row)
 	ws.mu.Lock()
 	c.S = ws.prepareCellStyle(col, row, c.S)
 	ws.mu.Unlock()
@@ -260,7 +329,7 @@ func (f *File) setCellTimeFunc(sheet, cell string, value time.Time) error {
 	if isNum {
 		_ = f.setDefaultTimeStyle(sheet, cell, getTimeNumFmt(value))
 	}
-	return err
+	return track(err)
 }
 
 // setCellTime prepares cell type and Excel time by given Go time.Time type
@@ -304,10 +373,11 @@ func (f *File) SetCellInt(sheet, cell string, value int64) error {
 	if err != nil {
 		return err
 	}
+	track := f.trackCellChange(sheet, ws, col, row)
 	c.S = ws.prepareCellStyle(col, row, c.S)
 	c.T, c.V = setCellInt(value)
 	c.IS = nil
-	return f.removeFormula(c, ws, sheet)
+	return track(f.removeFormula(c, ws, sheet))
 }
 
 // setCellInt prepares cell type and string type cell value by a given integer.
@@ -332,10 +402,11 @@ func (f *File) SetCellUint(sheet, cell string, value uint64) error {
 	if err != nil {
 		return err
 	}
+	track := f.trackCellChange(sheet, ws, col, row)
 	c.S = ws.prepareCellStyle(col, row, c.S)
 	c.T, c.V = setCellUint(value)
 	c.IS = nil
-	return f.removeFormula(c, ws, sheet)
+	return track(f.removeFormula(c, ws, sheet))
 }
 
 // setCellUint prepares cell type and string type cell value by a given unsigned
@@ -361,10 +432,11 @@ func (f *File) SetCellBool(sheet, cell string, value bool) error {
 	if err != nil {
 		return err
 	}
+	track := f.trackCellChange(sheet, ws, col, row)
 	c.S = ws.prepareCellStyle(col, row, c.S)
 	c.T, c.V = setCellBool(value)
 	c.IS = nil
-	return f.removeFormula(c, ws, sheet)
+	return track(f.removeFormula(c, ws, sheet))
 }
 
 // setCellBool prepares cell type and string type cell value by a given boolean
@@ -404,9 +476,10 @@ func (f *File) SetCellFloat(sheet, cell string, value float64, precision, bitSiz
 	if err != nil {
 		return err
 	}
+	track := f.trackCellChange(sheet, ws, col, row)
 	c.S = ws.prepareCellStyle(col, row, c.S)
 	c.setCellFloat(value, precision, bitSize)
-	return f.removeFormula(c, ws, sheet)
+	return track(f.removeFormula(c, ws, sheet))
 }
 
 // setCellFloat prepares cell type and string type cell value by a given float
@@ -421,8 +494,14 @@ func (c *xlsxC) setCellFloat(value float64, precision, bitSize int) {
 }
 
 // SetCellStr provides a function to set string type value of a cell. Total
-// number of characters that a cell can contain 32767 characters.
+// number of characters that a cell can contain 32767 characters, this
+// function returns ErrCellCharsLength error if the value exceeds the limit,
+// unless the TruncateCellValue option enabled.
 func (f *File) SetCellStr(sheet, cell, value string) error {
+	value, err := f.checkCellLength(sheet, cell, value, TotalCellChars, ErrCellCharsLength)
+	if err != nil {
+		return err
+	}
 	f.mu.Lock()
 	ws, err := f.workSheetReader(sheet)
 	if err != nil {
@@ -436,12 +515,13 @@ func (f *File) SetCellStr(sheet, cell, value string) error {
 	if err != nil {
 		return err
 	}
+	track := f.trackCellChange(sheet, ws, col, row)
 	c.S = ws.prepareCellStyle(col, row, c.S)
 	if c.T, c.V, err = f.setCellString(value); err != nil {
 		return err
 	}
 	c.IS = nil
-	return f.removeFormula(c, ws, sheet)
+	return track(f.removeFormula(c, ws, sheet))
 }
 
 // setCellString provides a function to set string type to shared string table.
@@ -491,20 +571,27 @@ func (f *File) setSharedString(val string) (int, error) {
 	if err != nil {
 		return 0, err
 	}
+	return f.addSharedString(sst, val), err
+}
+
+// addSharedString provides a function to add string to the given loaded share
+// string table, and returns the index of the string in the table.
+func (f *File) addSharedString(sst *xlsxSST, val string) int {
 	f.mu.Lock()
 	defer f.mu.Unlock()
-	if i, ok := f.sharedStringsMap[val]; ok {
-		return i, nil
+	t := xlsxT{}
+	t.Val, t.Space = trimCellValue(val, false)
+	if i, ok := f.sharedStringsMap[t.Val]; ok {
+		return i
 	}
 	sst.mu.Lock()
 	defer sst.mu.Unlock()
-	t := xlsxT{Val: val}
-	val, t.Space = trimCellValue(val, false)
+	val = t.Val
 	sst.SI = append(sst.SI, xlsxSI{T: &t})
 	sst.Count = len(sst.SI)
 	sst.UniqueCount = sst.Count
 	f.sharedStringsMap[val] = sst.UniqueCount - 1
-	return sst.UniqueCount - 1, nil
+	return sst.UniqueCount - 1
 }
 
 // trimCellValue provides a function to set string type to cell.
@@ -524,13 +611,14 @@ func trimCellValue(value string, escape bool) (v string, ns xml.Attr) {
 			}
 		}
 
+		value = bstrMarshal(value)
 		if escape {
 			var buf strings.Builder
 			_ = xml.EscapeText(&buf, []byte(value))
 			value = strings.ReplaceAll(buf.String(), "&#xA;", "\n")
 		}
 	}
-	v = bstrMarshal(value)
+	v = value
 	return
 }
 
@@ -588,23 +676,41 @@ func (c *xlsxC) setCellDefault(value string) {
 // getCellDate parse cell value which contains a date in the ISO 8601 format.
 func (c *xlsxC) getCellDate(f *File, raw bool) (string, error) {
 	if !raw {
-		layout := "20060102T150405.999"
-		if strings.HasSuffix(c.V, "Z") {
-			layout = "20060102T150405Z"
-			if strings.Contains(c.V, "-") {
-				layout = "2006-01-02T15:04:05Z"
-			}
-		} else if strings.Contains(c.V, "-") {
-			layout = "2006-01-02 15:04:05Z"
+		var date1904 bool
+		wb, err := f.workbookReader()
+		if err != nil {
+			return c.V, err
+		}
+		if wb != nil && wb.WorkbookPr != nil {
+			date1904 = wb.WorkbookPr.Date1904
 		}
-		if timestamp, err := time.Parse(layout, strings.ReplaceAll(c.V, ",", ".")); err == nil {
-			excelTime, _ := timeToExcelTime(timestamp, false)
+		if excelTime, ok := parseCellDate(c.V, date1904); ok {
 			c.V = strconv.FormatFloat(excelTime, 'G', 15, 64)
 		}
 	}
 	return f.formattedValue(c, raw, CellTypeDate)
 }
 
+// parseCellDate parse the date cell value in the ISO 8601 format, and returns
+// the Excel serial number of the date in the given date system.
+func parseCellDate(val string, date1904 bool) (float64, bool) {
+	layout := "20060102T150405.999"
+	if strings.HasSuffix(val, "Z") {
+		layout = "20060102T150405Z"
+		if strings.Contains(val, "-") {
+			layout = "2006-01-02T15:04:05Z"
+		}
+	} else if strings.Contains(val, "-") {
+		layout = "2006-01-02 15:04:05Z"
+	}
+	timestamp, err := time.Parse(layout, strings.ReplaceAll(val, ",", "."))
+	if err != nil {
+		return 0, false
+	}
+	excelTime, _ := timeToExcelTime(timestamp, date1904)
+	return excelTime, true
+}
+
 // getValueFrom return a value from a column/row cell, this function is
 // intended to be used with for range on rows an argument with the spreadsheet
 // opened file.
@@ -622,13 +728,13 @@ func (c *xlsxC) getValueFrom(f *File, d *xlsxSST, raw bool) (string, error) {
 			}
 			d.mu.Lock()
 			defer d.mu.Unlock()
-			if len(d.SI) > xlsxSI {
+			if xlsxSI >= 0 && len(d.SI) > xlsxSI {
 				return f.formattedValue(&xlsxC{S: c.S, V: d.SI[xlsxSI].String()}, raw, CellTypeSharedString)
 			}
 		}
 		return f.formattedValue(c, raw, CellTypeSharedString)
 	case "str":
-		return c.V, nil
+		return bstrUnmarshal(c.V), nil
 	case "inlineStr":
 		if c.IS != nil {
 			return f.formattedValue(&xlsxC{S: c.S, V: c.IS.String()}, raw, CellTypeInlineString)
@@ -646,9 +752,45 @@ func (c *xlsxC) getValueFrom(f *File, d *xlsxSST, raw bool) (string, error) {
 	}
 }
 
+// transformCellValue provides a function to transform the value of the cell
+// by given cell value transformer, worksheet name and cell reference.
+func (f *File) transformCellValue(fn func(string, CellMeta, string) (string, error), sheet, cell string, c *xlsxC, val string) (string, error) {
+	res, err := fn(cell, CellMeta{Sheet: sheet, Cell: cell, Type: c.getCellType(), NumFmtID: f.getCellNumFmtID(c.S)}, val)
+	if err != nil {
+		return val, ErrTransformCellValue{Sheet: sheet, Cell: cell, Err: err}
+	}
+	return res, err
+}
+
+// getCellNumFmtID provides a function to get the number format ID of the cell
+// by given style ID.
+func (f *File) getCellNumFmtID(styleID int) int {
+	styleSheet, err := f.stylesReader()
+	if err != nil {
+		return 0
+	}
+	styleSheet.mu.Lock()
+	defer styleSheet.mu.Unlock()
+	if styleSheet.CellXfs == nil || styleID >= len(styleSheet.CellXfs.Xf) || styleID < 0 || styleSheet.CellXfs.Xf[styleID].NumFmtID == nil {
+		return 0
+	}
+	return *styleSheet.CellXfs.Xf[styleID].NumFmtID
+}
+
+// isTransformCellValueError provides a function to check if the error is
+// returned by the cell value transformer.
+func isTransformCellValueError(err error) bool {
+	var transformErr ErrTransformCellValue
+	return errors.As(err, &transformErr)
+}
+
 // SetCellDefault provides a function to set string type value of a cell as
 // default format without escaping the cell.
 func (f *File) SetCellDefault(sheet, cell, value string) error {
+	value, err := f.checkCellLength(sheet, cell, value, TotalCellChars, ErrCellCharsLength)
+	if err != nil {
+		return err
+	}
 	f.mu.Lock()
 	ws, err := f.workSheetReader(sheet)
 	if err != nil {
@@ -662,9 +804,46 @@ func (f *File) SetCellDefault(sheet, cell, value string) error {
 	if err != nil {
 		return err
 	}
+	track := f.trackCellChange(sheet, ws, col, row)
 	c.S = ws.prepareCellStyle(col, row, c.S)
 	c.setCellDefault(value)
-	return f.removeFormula(c, ws, sheet)
+	return track(f.removeFormula(c, ws, sheet))
+}
+
+// DeleteCell provides a function to remove the cell entirely by given
+// worksheet name and cell reference, including the value, formula and style
+// of the cell, without shifting the other cells. The hyperlinks, comments and
+// merged cells which contain the cell will be kept. For example, delete the
+// cell A1 on Sheet1:
+//
+//	err := f.DeleteCell("Sheet1", "A1")
+func (f *File) DeleteCell(sheet, cell string) error {
+	col, row, err := CellNameToCoordinates(cell)
+	if err != nil {
+		return err
+	}
+	f.mu.Lock()
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		f.mu.Unlock()
+		return err
+	}
+	f.mu.Unlock()
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	if row > len(ws.SheetData.Row) || col > len(ws.SheetData.Row[row-1].C) {
+		return err
+	}
+	c := &ws.SheetData.Row[row-1].C[col-1]
+	if !c.hasValue() {
+		return err
+	}
+	track := f.trackCellChange(sheet, ws, col, row)
+	if err = f.removeFormula(c, ws, sheet); err != nil {
+		return track(err)
+	}
+	*c = xlsxC{R: c.R}
+	return track(err)
 }
 
 // GetCellFormula provides a function to get formula from cell by given
@@ -786,18 +965,23 @@ type FormulaOpts struct {
 //	    }
 //	}
 func (f *File) SetCellFormula(sheet, cell, formula string, opts ...FormulaOpts) error {
+	formula, err := f.checkCellLength(sheet, cell, formula, TotalFormulaChars, ErrCellFormulaLength)
+	if err != nil {
+		return err
+	}
 	ws, err := f.workSheetReader(sheet)
 	if err != nil {
 		return err
 	}
-	c, _, _, err := ws.prepareCell(cell)
+	c, col, row, err := ws.prepareCell(cell)
 	if err != nil {
 		return err
 	}
+	track := f.trackCellChange(sheet, ws, col, row)
 	if formula == "" {
 		ws.deleteSharedFormula(c)
 		c.F = nil
-		return f.deleteCalcChain(f.getSheetID(sheet), cell)
+		return track(f.deleteCalcChain(f.getSheetID(sheet), cell))
 	}
 
 	if c.F != nil {
@@ -809,7 +993,7 @@ func (f *File) SetCellFormula(sheet, cell, formula string, opts ...FormulaOpts)
 	for _, opt := range opts {
 		if opt.Type != nil {
 			if *opt.Type == STCellFormulaTypeDataTable {
-				return err
+				return track(err)
 			}
 			c.F.T = *opt.Type
 			if c.F.T == STCellFormulaTypeArray && opt.Ref != nil {
@@ -829,7 +1013,7 @@ func (f *File) SetCellFormula(sheet, cell, formula string, opts ...FormulaOpts)
 		}
 	}
 	c.T, c.IS = "str", nil
-	return err
+	return track(err)
 }
 
 // setArrayFormula transform the array formula in an array formula range to the
@@ -959,29 +1143,55 @@ func (ws *xlsxWorksheet) deleteSharedFormula(c *xlsxC) {
 //
 //	link, target, err := f.GetCellHyperLink("Sheet1", "H6")
 func (f *File) GetCellHyperLink(sheet, cell string) (bool, string, error) {
+	ok, link, err := f.GetCellHyperLinkDetails(sheet, cell)
+	if link.External {
+		return ok, link.Target, err
+	}
+	return ok, link.Location, err
+}
+
+// GetCellHyperLinkDetails provides a function to get all attributes of the
+// cell hyperlink by given worksheet name and cell reference. The hyperlink
+// which covers a range containing the cell will also be matched. If the cell
+// has a hyperlink, it will return 'true' and the hyperlink attributes,
+// otherwise it will return 'false' and empty attributes. For example, get
+// the hyperlink of cell C2 on Sheet1:
+//
+//	ok, link, err := f.GetCellHyperLinkDetails("Sheet1", "C2")
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	if ok {
+//	    fmt.Println(link.Ref, link.Target, link.Location, link.Display, link.Tooltip)
+//	}
+func (f *File) GetCellHyperLinkDetails(sheet, cell string) (bool, HyperlinkDetails, error) {
+	var details HyperlinkDetails
 	// Check for correct cell name
 	if _, _, err := SplitCellName(cell); err != nil {
-		return false, "", err
+		return false, details, err
 	}
 	ws, err := f.workSheetReader(sheet)
 	if err != nil {
-		return false, "", err
+		return false, details, err
 	}
-	if ws.Hyperlinks != nil {
-		for _, link := range ws.Hyperlinks.Hyperlink {
-			ok, err := f.checkCellInRangeRef(cell, link.Ref)
-			if err != nil {
-				return false, "", err
-			}
-			if link.Ref == cell || ok {
-				if link.RID != "" {
-					return true, f.getSheetRelationshipsTargetByID(sheet, link.RID), err
-				}
-				return true, link.Location, err
+	if ws.Hyperlinks == nil {
+		return false, details, err
+	}
+	for _, link := range ws.Hyperlinks.Hyperlink {
+		ok, err := f.checkCellInRangeRef(cell, link.Ref)
+		if err != nil {
+			return false, details, err
+		}
+		if link.Ref == cell || ok {
+			details = HyperlinkDetails{Ref: link.Ref, Location: link.Location, Display: link.Display, Tooltip: link.Tooltip}
+			if link.RID != "" {
+				details.Target, details.External = f.getSheetRelationshipsTargetByID(sheet, link.RID), true
 			}
+			return true, details, err
 		}
 	}
-	return false, "", err
+	return false, details, err
 }
 
 // HyperlinkOpts can be passed to SetCellHyperlink to set optional hyperlink
@@ -991,10 +1201,63 @@ type HyperlinkOpts struct {
 	Tooltip *string
 }
 
+// HyperlinkDetails directly maps the attributes of the hyperlink returned by
+// the GetCellHyperLinkDetails function. The Ref is the cell reference or the
+// range reference covered by the hyperlink, the Target is the address of the
+// external hyperlink, and the Location is the location anchor in the workbook
+// or in the target of the external hyperlink.
+type HyperlinkDetails struct {
+	Ref      string
+	Target   string
+	Location string
+	Display  string
+	Tooltip  string
+	External bool
+}
+
+// removeRangeHyperLinks remove the hyperlinks overlapping with the given
+// range coordinates for worksheet and delete relationships for the worksheet
+// by given sheet name. If within is true, only the hyperlinks within the range
+// will be deleted, except the hyperlink with the given range reference.
+func (f *File) removeRangeHyperLinks(ws *xlsxWorksheet, sheet, ref string, rect []int, within bool) error {
+	if ws.Hyperlinks == nil {
+		return nil
+	}
+	for idx := 0; idx < len(ws.Hyperlinks.Hyperlink); idx++ {
+		link := ws.Hyperlinks.Hyperlink[idx]
+		linkRef := link.Ref
+		if !strings.Contains(linkRef, ":") {
+			linkRef += ":" + linkRef
+		}
+		coordinates, err := rangeRefToCoordinates(linkRef)
+		if err != nil {
+			return err
+		}
+		_ = sortCoordinates(coordinates)
+		if within {
+			if link.Ref == ref || !cellInRange(coordinates[:2], rect) || !cellInRange(coordinates[2:], rect) {
+				continue
+			}
+		} else if !isOverlap(coordinates, rect) {
+			continue
+		}
+		ws.Hyperlinks.Hyperlink = append(ws.Hyperlinks.Hyperlink[:idx], ws.Hyperlinks.Hyperlink[idx+1:]...)
+		idx--
+		f.deleteSheetRelationships(sheet, link.RID)
+	}
+	if len(ws.Hyperlinks.Hyperlink) == 0 {
+		ws.Hyperlinks = nil
+	}
+	return nil
+}
+
 // removeHyperLink remove hyperlink for worksheet and delete relationships for
 // the worksheet by given sheet name and cell reference. Note that if the cell
 // in a range reference, the whole hyperlinks will be deleted.
 func (f *File) removeHyperLink(ws *xlsxWorksheet, sheet, cell string) error {
+	if ws.Hyperlinks == nil {
+		return nil
+	}
 	for idx := 0; idx < len(ws.Hyperlinks.Hyperlink); idx++ {
 		link := ws.Hyperlinks.Hyperlink[idx]
 		ok, err := f.checkCellInRangeRef(cell, link.Ref)
@@ -1042,6 +1305,16 @@ func (f *File) removeHyperLink(ws *xlsxWorksheet, sheet, cell string) error {
 // This is another example for "Location":
 //
 //	err := f.SetCellHyperLink("Sheet1", "A3", "Sheet1!A40", "Location")
+//
+// The relative path of the "External" link, such as "reports/2024.xlsx", will
+// be resolved by the spreadsheet application against the hyperlink base of
+// the workbook set by the SetHyperlinkBase function, or against the folder of
+// the workbook when the hyperlink base is empty:
+//
+//	if err := f.SetHyperlinkBase("https://example.com/share/"); err != nil {
+//	    fmt.Println(err)
+//	}
+//	err := f.SetCellHyperLink("Sheet1", "A3", "reports/2024.xlsx", "External")
 func (f *File) SetCellHyperLink(sheet, cell, link, linkType string, opts ...HyperlinkOpts) error {
 	// Check for correct cell name
 	if _, _, err := SplitCellName(cell); err != nil {
@@ -1055,9 +1328,64 @@ func (f *File) SetCellHyperLink(sheet, cell, link, linkType string, opts ...Hype
 	if cell, err = ws.mergeCellsParser(cell); err != nil {
 		return err
 	}
+	track := f.trackChange(sheet, cell, CellChangeHyperLink, func() string {
+		_, target, _ := f.GetCellHyperLink(sheet, cell)
+		return target
+	})
+	if linkType == "None" {
+		return track(f.removeHyperLink(ws, sheet, cell))
+	}
+	return track(f.setHyperLink(ws, sheet, cell, link, linkType, opts...))
+}
+
+// SetRangeHyperLink provides a function to set a hyperlink covering the range
+// by given worksheet name, range reference, link URL address and link type.
+// The link types are the same as the SetCellHyperLink function, a single
+// hyperlink will be set for the whole range instead of duplicating the
+// hyperlink for each cell, and the existing hyperlinks within the range will
+// be replaced. The hyperlinks overlapping with the range will be removed when
+// the link type is "None". This function is only used to set the hyperlink of
+// the cells and doesn't affect the value of the cells. For example, set an
+// external hyperlink covering the range B2:D2 on Sheet1:
+//
+//	err := f.SetRangeHyperLink("Sheet1", "B2:D2", "https://github.com/xuri/excelize", "External")
+func (f *File) SetRangeHyperLink(sheet, rangeRef, link, linkType string, opts ...HyperlinkOpts) error {
+	coordinates, err := rangeRefToCoordinates(rangeRef)
+	if err != nil {
+		return err
+	}
+	_ = sortCoordinates(coordinates)
+	if rangeRef, err = coordinatesToRangeRef(coordinates); err != nil {
+		return err
+	}
+	if coordinates[0] == coordinates[2] && coordinates[1] == coordinates[3] {
+		rangeRef = strings.Split(rangeRef, ":")[0]
+	}
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return err
+	}
+	if linkType == "None" {
+		return f.removeRangeHyperLinks(ws, sheet, rangeRef, coordinates, false)
+	}
+	if linkType != "External" && linkType != "Location" {
+		return newInvalidLinkTypeError(linkType)
+	}
+	if err = f.removeRangeHyperLinks(ws, sheet, rangeRef, coordinates, true); err != nil {
+		return err
+	}
+	return f.setHyperLink(ws, sheet, rangeRef, link, linkType, opts...)
+}
 
-	var linkData xlsxHyperlink
-	idx := -1
+// setHyperLink provides a function to set the hyperlink of the cell or range
+// by given worksheet, worksheet name, cell or range reference, link URL
+// address and link type.
+func (f *File) setHyperLink(ws *xlsxWorksheet, sheet, cell, link, linkType string, opts ...HyperlinkOpts) error {
+	var (
+		err      error
+		linkData xlsxHyperlink
+		idx      = -1
+	)
 	if ws.Hyperlinks == nil {
 		ws.Hyperlinks = new(xlsxHyperlinks)
 	}
@@ -1088,8 +1416,6 @@ func (f *File) SetCellHyperLink(sheet, cell, link, linkType string, opts ...Hype
 			Ref:      cell,
 			Location: link,
 		}
-	case "None":
-		return f.removeHyperLink(ws, sheet, cell)
 	default:
 		return newInvalidLinkTypeError(linkType)
 	}
@@ -1113,11 +1439,11 @@ func (f *File) SetCellHyperLink(sheet, cell, link, linkType string, opts ...Hype
 // getCellRichText returns rich text of cell by given string item.
 func getCellRichText(si *xlsxSI) (runs []RichTextRun) {
 	if si.T != nil {
-		runs = append(runs, RichTextRun{Text: si.T.Val})
+		runs = append(runs, RichTextRun{Text: bstrUnmarshal(si.T.Val)})
 	}
 	for _, v := range si.R {
 		run := RichTextRun{
-			Text: v.T.Val,
+			Text: bstrUnmarshal(v.T.Val),
 		}
 		if v.RPr != nil {
 			run.Font = newFont(v.RPr)
@@ -1228,7 +1554,7 @@ func setRichText(runs []RichTextRun) ([]xlsxR, error) {
 		totalCellChars int
 	)
 	for _, textRun := range runs {
-		totalCellChars += len(textRun.Text)
+		totalCellChars += utf8.RuneCountInString(textRun.Text)
 		if totalCellChars > TotalCellChars {
 			return textRuns, ErrCellCharsLength
 		}
@@ -1367,6 +1693,10 @@ func setRichText(runs []RichTextRun) ([]xlsxR, error) {
 //	    }
 //	}
 func (f *File) SetCellRichText(sheet, cell string, runs []RichTextRun) error {
+	runs, err := f.checkRichTextLength(sheet, cell, runs)
+	if err != nil {
+		return err
+	}
 	ws, err := f.workSheetReader(sheet)
 	if err != nil {
 		return err
@@ -1375,6 +1705,7 @@ func (f *File) SetCellRichText(sheet, cell string, runs []RichTextRun) error {
 	if err != nil {
 		return err
 	}
+	track := f.trackCellChange(sheet, ws, col, row)
 	if err := f.sharedStringsLoader(); err != nil {
 		return err
 	}
@@ -1390,37 +1721,66 @@ func (f *File) SetCellRichText(sheet, cell string, runs []RichTextRun) error {
 	for idx, strItem := range sst.SI {
 		if reflect.DeepEqual(strItem, si) {
 			c.T, c.V = "s", strconv.Itoa(idx)
-			return err
+			return track(err)
 		}
 	}
 	sst.SI = append(sst.SI, si)
 	sst.Count++
 	sst.UniqueCount++
 	c.T, c.V = "s", strconv.Itoa(len(sst.SI)-1)
-	return err
+	return track(err)
 }
 
 // SetSheetRow writes an array to row by given worksheet name, starting
-// cell reference and a pointer to array type 'slice'. This function is
+// cell reference and a pointer to array type 'slice'. Each value is set by
+// the SetCellValue function, so the cells for the nil values will be cleared,
+// which is different from the SetSheetCol function. This function is
 // concurrency safe. For example, writes an array to row 6 start with the cell
 // B6 on Sheet1:
 //
 //	err := f.SetSheetRow("Sheet1", "B6", &[]interface{}{"1", nil, 2})
 func (f *File) SetSheetRow(sheet, cell string, slice interface{}) error {
-	return f.setSheetCells(sheet, cell, slice, rows)
+	return f.setSheetCells(sheet, cell, slice)
+}
+
+// setSheetCells provides a function to set worksheet cells value of a row.
+func (f *File) setSheetCells(sheet, cell string, slice interface{}) error {
+	col, row, err := CellNameToCoordinates(cell)
+	if err != nil {
+		return err
+	}
+	// Make sure 'slice' is a Ptr to Slice
+	v := reflect.ValueOf(slice)
+	if v.Kind() != reflect.Ptr || v.Elem().Kind() != reflect.Slice {
+		return ErrParameterInvalid
+	}
+	if v = v.Elem(); col+v.Len()-1 > MaxColumns {
+		return ErrColumnNumber
+	}
+	for i := 0; i < v.Len(); i++ {
+		cell, err := CoordinatesToCellName(col+i, row)
+		// Error should never happen here. But keep checking to early detect regressions
+		// if it will be introduced in the future.
+		if err != nil {
+			return err
+		}
+		if err := f.SetCellValue(sheet, cell, v.Index(i).Interface()); err != nil {
+			return err
+		}
+	}
+	return err
 }
 
 // SetSheetCol writes an array to column by given worksheet name, starting
-// cell reference and a pointer to array type 'slice'. For example, writes an
-// array to column B start with the cell B6 on Sheet1:
+// cell reference and a pointer to array type 'slice'. The values are written
+// downward from the starting cell with the same data types supported by the
+// SetCellValue function. Unlike the SetSheetRow function, which clears the
+// cells for the nil values, the nil values will be skipped without changing
+// the cells. This function is concurrency safe. For example, writes an array
+// to column B start with the cell B6 on Sheet1:
 //
 //	err := f.SetSheetCol("Sheet1", "B6", &[]interface{}{"1", nil, 2})
 func (f *File) SetSheetCol(sheet, cell string, slice interface{}) error {
-	return f.setSheetCells(sheet, cell, slice, columns)
-}
-
-// setSheetCells provides a function to set worksheet cells value.
-func (f *File) setSheetCells(sheet, cell string, slice interface{}, dir adjustDirection) error {
 	col, row, err := CellNameToCoordinates(cell)
 	if err != nil {
 		return err
@@ -1430,21 +1790,27 @@ func (f *File) setSheetCells(sheet, cell string, slice interface{}, dir adjustDi
 	if v.Kind() != reflect.Ptr || v.Elem().Kind() != reflect.Slice {
 		return ErrParameterInvalid
 	}
-	v = v.Elem()
+	if v = v.Elem(); row+v.Len()-1 > TotalRows {
+		return ErrMaxRows
+	}
+	f.mu.Lock()
+	_, err = f.workSheetReader(sheet)
+	f.mu.Unlock()
+	if err != nil {
+		return err
+	}
 	for i := 0; i < v.Len(); i++ {
-		var cell string
-		var err error
-		if dir == rows {
-			cell, err = CoordinatesToCellName(col+i, row)
-		} else {
-			cell, err = CoordinatesToCellName(col, row+i)
+		value := v.Index(i).Interface()
+		if value == nil {
+			continue
 		}
+		cell, err := CoordinatesToCellName(col, row+i)
 		// Error should never happen here. But keep checking to early detect regressions
 		// if it will be introduced in the future.
 		if err != nil {
 			return err
 		}
-		if err := f.SetCellValue(sheet, cell, v.Index(i).Interface()); err != nil {
+		if err := f.SetCellValue(sheet, cell, value); err != nil {
 			return err
 		}
 	}
@@ -1498,7 +1864,13 @@ func (f *File) getCellStringFunc(sheet, cell string, fn func(x *xlsxWorksheet, c
 		return "", nil
 	}
 
-	for rowIdx := range ws.SheetData.Row {
+	rowIdx := 0
+	if row <= len(ws.SheetData.Row) && ws.SheetData.Row[row-1].R == row {
+		// the rows are stored in order of the row number generally, look up
+		// the row by index directly to avoid scanning the preceding rows
+		rowIdx = row - 1
+	}
+	for ; rowIdx < len(ws.SheetData.Row); rowIdx++ {
 		rowData := &ws.SheetData.Row[rowIdx]
 		if rowData.R != row {
 			continue
@@ -1516,6 +1888,7 @@ func (f *File) getCellStringFunc(sheet, cell string, fn func(x *xlsxWorksheet, c
 				return val, nil
 			}
 		}
+		break
 	}
 	return "", nil
 }
@@ -1531,16 +1904,17 @@ func (f *File) formattedValue(c *xlsxC, raw bool, cellType CellType) (string, er
 	if err != nil {
 		return c.V, err
 	}
-	if styleSheet.CellXfs == nil {
-		return c.V, err
-	}
-	if c.S >= len(styleSheet.CellXfs.Xf) || c.S < 0 {
+	styleSheet.mu.Lock()
+	if styleSheet.CellXfs == nil || c.S >= len(styleSheet.CellXfs.Xf) || c.S < 0 {
+		styleSheet.mu.Unlock()
 		return c.V, err
 	}
 	var numFmtID int
 	if styleSheet.CellXfs.Xf[c.S].NumFmtID != nil {
 		numFmtID = *styleSheet.CellXfs.Xf[c.S].NumFmtID
 	}
+	fmtCode, custom := styleSheet.getCustomNumFmtCode(numFmtID)
+	styleSheet.mu.Unlock()
 	date1904 := false
 	wb, err := f.workbookReader()
 	if err != nil {
@@ -1549,7 +1923,7 @@ func (f *File) formattedValue(c *xlsxC, raw bool, cellType CellType) (string, er
 	if wb != nil && wb.WorkbookPr != nil {
 		date1904 = wb.WorkbookPr.Date1904
 	}
-	if fmtCode, ok := styleSheet.getCustomNumFmtCode(numFmtID); ok {
+	if custom {
 		return format(c.V, fmtCode, date1904, cellType, f.options), err
 	}
 	if fmtCode, ok := f.getBuiltInNumFmtCode(numFmtID); ok {
@@ -1575,7 +1949,9 @@ func (ss *xlsxStyleSheet) getCustomNumFmtCode(numFmtID int) (string, bool) {
 }
 
 // prepareCellStyle provides a function to prepare style index of cell in
-// worksheet by given column index and style index.
+// worksheet by given column index and style index. The explicit cell style
+// takes precedence over the row style, and the row style takes precedence
+// over the column style.
 func (ws *xlsxWorksheet) prepareCellStyle(col, row, style int) int {
 	if style != 0 {
 		return style
@@ -1621,7 +1997,7 @@ func (ws *xlsxWorksheet) mergeCellsParser(cell string) (string, error) {
 				_ = sortCoordinates(rect)
 				ws.MergeCells.Cells[i].rect = rect
 			}
-			if cellInRange([]int{col, row}, ws.MergeCells.Cells[i].rect) {
+			if rect := ws.MergeCells.Cells[i].rect; len(rect) == 4 && cellInRange([]int{col, row}, rect) {
 				cell = strings.Split(ws.MergeCells.Cells[i].Ref, ":")[0]
 				break
 			}
@@ -1678,16 +2054,21 @@ func (c *xlsxC) convertSharedFormula(cell string) (string, error) {
 	if err != nil {
 		return "", err
 	}
-	dCol, dRow := col-sharedCol, row-sharedRow
+	return shiftFormula(c.F.Content, col-sharedCol, row-sharedRow), nil
+}
+
+// shiftFormula returns the formula with the relative cell references shifted
+// according to dCol and dRow.
+func shiftFormula(formula string, dCol, dRow int) string {
 	ps := efp.ExcelParser()
-	tokens := ps.Parse(c.F.Content)
+	tokens := ps.Parse(formula)
 	for i := range tokens {
 		token := tokens[i]
 		if token.TType == efp.TokenTypeOperand && token.TSubType == efp.TokenSubTypeRange {
 			tokens[i].TValue = shiftCell(token.TValue, dCol, dRow)
 		}
 	}
-	return ps.Render(), nil
+	return ps.Render()
 }
 
 // getSharedFormula find a cell contains the same formula as another cell,
@@ -1755,3 +2136,199 @@ func shiftCell(val string, dCol, dRow int) string {
 	}
 	return strings.Join(parts, ":")
 }
+
+// CellChangeType is the type of the cell property recorded in the change
+// journal.
+type CellChangeType byte
+
+// This section defines the currently supported cell change types enumeration.
+const (
+	CellChangeValue CellChangeType = iota
+	CellChangeStyle
+	CellChangeHyperLink
+)
+
+// CellChange directly maps the entry of the change journal, which records the
+// cell value, style or hyperlink written by a mutating cell function. The
+// Label is the change label set by the SetChangeLabel function at the time of
+// the change. For the style changes, the OldValue and NewValue are the style
+// index, and for the hyperlink changes, they are the link target.
+type CellChange struct {
+	Sheet    string
+	Cell     string
+	Type     CellChangeType
+	Label    string
+	OldValue string
+	NewValue string
+}
+
+// changeJournal directly maps the in-memory journal of the cell changes, the
+// entries will be stored in a ring buffer if the size greater than 0.
+type changeJournal struct {
+	mu      sync.Mutex
+	label   string
+	size    int
+	start   int
+	entries []CellChange
+}
+
+// add provides a function to append the cell change entry into the journal.
+func (j *changeJournal) add(change CellChange) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	change.Label = j.label
+	if j.size > 0 && len(j.entries) == j.size {
+		j.entries[j.start] = change
+		j.start = (j.start + 1) % j.size
+		return
+	}
+	j.entries = append(j.entries, change)
+}
+
+// getCellChangeValue provides a function to get the raw value or the formula
+// of the cell for the change journal.
+func (f *File) getCellChangeValue(c *xlsxC) string {
+	if c.F != nil && c.F.Content != "" {
+		return "=" + c.F.Content
+	}
+	sst, _ := f.sharedStringsReader()
+	val, _ := c.getValueFrom(f, sst, true)
+	return val
+}
+
+// untrackedChange returns the error of the cell change as is, it will be used
+// when the change journal is disabled to avoid building the cell reference
+// and the value getter for each change.
+func untrackedChange(err error) error { return err }
+
+// trackChange provides a function to get the property of the cell before
+// change by given getter, and returns a function to record the cell change
+// into the journal if the change succeeded.
+func (f *File) trackChange(sheet, cell string, typ CellChangeType, get func() string) func(err error) error {
+	if f.journal == nil {
+		return untrackedChange
+	}
+	oldValue := get()
+	return func(err error) error {
+		if err == nil {
+			f.journal.add(CellChange{
+				Sheet: sheet, Cell: cell, Type: typ, OldValue: oldValue, NewValue: get(),
+			})
+		}
+		return err
+	}
+}
+
+// trackCellChange provides a function to get the value of the cell before
+// change, and returns a function to record the cell change into the journal
+// if the change succeeded.
+func (f *File) trackCellChange(sheet string, ws *xlsxWorksheet, col, row int) func(err error) error {
+	if f.journal == nil {
+		return untrackedChange
+	}
+	cell, _ := CoordinatesToCellName(col, row)
+	return f.trackChange(sheet, cell, CellChangeValue, func() string {
+		return f.getCellChangeValue(&ws.SheetData.Row[row-1].C[col-1])
+	})
+}
+
+// SetChangeLabel provides a function to set the label for the subsequent cell
+// changes recorded in the change journal, used to attribute which stage wrote
+// each cell. This function takes no effect unless the TrackChanges option
+// enabled. For example:
+//
+//	f := excelize.NewFile(excelize.Options{TrackChanges: true})
+//	f.SetChangeLabel("import")
+func (f *File) SetChangeLabel(label string) {
+	if f.journal != nil {
+		f.journal.mu.Lock()
+		f.journal.label = label
+		f.journal.mu.Unlock()
+	}
+}
+
+// GetChangeJournal provides a function to get the ordered cell changes
+// recorded since the TrackChanges option enabled, the oldest entries will be
+// discarded when the number of entries exceeds the ChangeJournalSize option.
+func (f *File) GetChangeJournal() ([]CellChange, error) {
+	if f.journal == nil {
+		return nil, ErrTrackChangesDisabled
+	}
+	f.journal.mu.Lock()
+	defer f.journal.mu.Unlock()
+	changes := make([]CellChange, 0, len(f.journal.entries))
+	changes = append(changes, f.journal.entries[f.journal.start:]...)
+	return append(changes, f.journal.entries[:f.journal.start]...), nil
+}
+
+// TruncatedCell directly maps the cell which value or formula has been
+// truncated to the limit of characters when the TruncateCellValue option
+// enabled. The Length is the number of characters of the original value.
+type TruncatedCell struct {
+	Sheet  string
+	Cell   string
+	Length int
+}
+
+// truncatedCells directly maps the in-memory report of the truncated cells.
+type truncatedCells struct {
+	mu    sync.Mutex
+	cells []TruncatedCell
+}
+
+// checkCellLength provides a function to check the number of characters of the
+// cell value or formula by given limit. The value will be truncated and
+// recorded into the truncated cells report if the TruncateCellValue option
+// enabled, otherwise returns the given error.
+func (f *File) checkCellLength(sheet, cell, value string, limit int, err error) (string, error) {
+	length := utf8.RuneCountInString(value)
+	if length <= limit {
+		return value, nil
+	}
+	if !f.options.TruncateCellValue {
+		return value, err
+	}
+	f.truncated.mu.Lock()
+	defer f.truncated.mu.Unlock()
+	f.truncated.cells = append(f.truncated.cells, TruncatedCell{Sheet: sheet, Cell: cell, Length: length})
+	return string([]rune(value)[:limit]), nil
+}
+
+// checkRichTextLength provides a function to check the total number of
+// characters of the rich text runs, the exceeded text runs will be truncated
+// if the TruncateCellValue option enabled.
+func (f *File) checkRichTextLength(sheet, cell string, runs []RichTextRun) ([]RichTextRun, error) {
+	var text strings.Builder
+	for _, run := range runs {
+		text.WriteString(run.Text)
+	}
+	value, err := f.checkCellLength(sheet, cell, text.String(), TotalCellChars, ErrCellCharsLength)
+	if err != nil || len(value) == text.Len() {
+		return runs, err
+	}
+	var truncated []RichTextRun
+	for _, run := range runs {
+		if len(value) == 0 {
+			break
+		}
+		run.Text = value[:min(len(run.Text), len(value))]
+		value = value[len(run.Text):]
+		truncated = append(truncated, run)
+	}
+	return truncated, err
+}
+
+// GetTruncatedCells provides a function to get the cells which value or
+// formula has been truncated since the TruncateCellValue option enabled. For
+// example:
+//
+//	f := excelize.NewFile(excelize.Options{TruncateCellValue: true})
+//	// Set cells value...
+//	for _, cell := range f.GetTruncatedCells() {
+//	    fmt.Println(cell.Sheet, cell.Cell, cell.Length)
+//	}
+func (f *File) GetTruncatedCells() []TruncatedCell {
+	f.truncated.mu.Lock()
+	defer f.truncated.mu.Unlock()
+	return append([]TruncatedCell{}, f.truncated.cells...)
+}
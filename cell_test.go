@@ -73,6 +73,10 @@ func TestConcurrency(t *testing.T) {
 				_, err := cols.Rows()
 				assert.NoError(t, err)
 			}
+			// Concurrency walk columns
+			assert.NoError(t, f.WalkCols("Sheet1", func(colName string, cells []string) error {
+				return ErrStopWalk
+			}))
 			// Concurrency set columns style
 			assert.NoError(t, f.SetColStyle("Sheet1", "C:E", style))
 			// Concurrency get columns style
@@ -213,7 +217,7 @@ func TestSetCellUint(t *testing.T) {
 }
 
 func TestSetCellValuesMultiByte(t *testing.T) {
-	f := NewFile()
+	f := NewFile(Options{TruncateCellValue: true})
 	row := []interface{}{
 		// Test set cell value with multi byte characters value
 		strings.Repeat("\u4E00", TotalCellChars+1),
@@ -242,9 +246,133 @@ func TestSetCellValuesMultiByte(t *testing.T) {
 			assert.Len(t, []rune(result), expected)
 		}
 	}
+	assert.Equal(t, []TruncatedCell{
+		{Sheet: "Sheet1", Cell: "A1", Length: TotalCellChars + 1},
+		{Sheet: "Sheet1", Cell: "D1", Length: TotalCellChars + 1},
+	}, f.GetTruncatedCells())
 	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestSetCellValuesMultiByte.xlsx")))
 }
 
+func TestCellLengthLimits(t *testing.T) {
+	f := NewFile()
+	value, formula := strings.Repeat("\u4E00", TotalCellChars+1), "="+strings.Repeat("A", TotalFormulaChars)
+	runs := []RichTextRun{{Text: strings.Repeat("a", TotalCellChars-1)}, {Text: "\u4E00\u4E00", Font: &Font{Bold: true}}}
+	// Test set cells with the value exceeds the limit through each entry point
+	assert.Equal(t, ErrCellCharsLength, f.SetCellValue("Sheet1", "A1", value))
+	assert.Equal(t, ErrCellCharsLength, f.SetCellValue("Sheet1", "A1", []byte(value)))
+	assert.Equal(t, ErrCellCharsLength, f.SetCellStr("Sheet1", "A1", value))
+	assert.Equal(t, ErrCellCharsLength, f.SetCellDefault("Sheet1", "A1", value))
+	assert.Equal(t, ErrCellFormulaLength, f.SetCellFormula("Sheet1", "A1", formula))
+	assert.Equal(t, ErrCellCharsLength, f.SetCellRichText("Sheet1", "A1", runs))
+	assert.Equal(t, ErrCellCharsLength, f.SetSheetRow("Sheet1", "A1", &[]interface{}{"a", value}))
+	assert.Equal(t, ErrCellCharsLength, f.SetSheetCol("Sheet1", "A1", &[]interface{}{"a", value}))
+	assert.Empty(t, f.GetTruncatedCells())
+	// Test set cells with the value at the limit, the multi-byte characters
+	// should be counted as one character
+	assert.NoError(t, f.SetCellStr("Sheet1", "A1", value[3:]))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "A2", formula[1:]))
+	assert.NoError(t, f.SetCellRichText("Sheet1", "A3", runs[:1]))
+	assert.NoError(t, f.SetCellRichText("Sheet1", "A4", []RichTextRun{{Text: value[3:]}}))
+	assert.NoError(t, f.Close())
+
+	// Test set cells with the value exceeds the limit and truncate
+	f = NewFile(Options{TruncateCellValue: true})
+	assert.NoError(t, f.SetCellStr("Sheet1", "A1", value))
+	assert.NoError(t, f.SetCellDefault("Sheet1", "A2", value))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "A3", formula))
+	assert.NoError(t, f.SetCellRichText("Sheet1", "A4", runs))
+	assert.NoError(t, f.SetCellRichText("Sheet1", "A5", append([]RichTextRun{{Text: value}}, runs...)))
+	assert.NoError(t, f.SetSheetRow("Sheet1", "B1", &[]interface{}{"a", value}))
+	assert.NoError(t, f.SetSheetCol("Sheet1", "D1", &[]interface{}{"a", []byte(value)}))
+	for _, cell := range []string{"A1", "A2", "C1", "D2"} {
+		result, err := f.GetCellValue("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Len(t, []rune(result), TotalCellChars, cell)
+	}
+	result, err := f.GetCellFormula("Sheet1", "A3")
+	assert.NoError(t, err)
+	assert.Equal(t, formula[:TotalFormulaChars], result)
+	richText, err := f.GetCellRichText("Sheet1", "A4")
+	assert.NoError(t, err)
+	assert.Len(t, richText, 2)
+	assert.Equal(t, "\u4E00", richText[1].Text)
+	richText, err = f.GetCellRichText("Sheet1", "A5")
+	assert.NoError(t, err)
+	assert.Len(t, richText, 1)
+	assert.Equal(t, value[3:], richText[0].Text)
+	assert.Equal(t, []TruncatedCell{
+		{Sheet: "Sheet1", Cell: "A1", Length: TotalCellChars + 1},
+		{Sheet: "Sheet1", Cell: "A2", Length: TotalCellChars + 1},
+		{Sheet: "Sheet1", Cell: "A3", Length: TotalFormulaChars + 1},
+		{Sheet: "Sheet1", Cell: "A4", Length: TotalCellChars + 1},
+		{Sheet: "Sheet1", Cell: "A5", Length: TotalCellChars*2 + 2},
+		{Sheet: "Sheet1", Cell: "C1", Length: TotalCellChars + 1},
+		{Sheet: "Sheet1", Cell: "D2", Length: TotalCellChars + 1},
+	}, f.GetTruncatedCells())
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestCellLengthLimits.xlsx")))
+	assert.NoError(t, f.Close())
+}
+
+func TestCellBoundaryLimits(t *testing.T) {
+	lastCell, err := CoordinatesToCellName(MaxColumns, TotalRows)
+	assert.NoError(t, err)
+	for _, cell := range []string{"XFD1", fmt.Sprintf("A%d", TotalRows), lastCell} {
+		f := NewFile()
+		assert.NoError(t, f.SetCellValue("Sheet1", cell, 1))
+		assert.NoError(t, f.SetCellStr("Sheet1", cell, "a"))
+		assert.NoError(t, f.SetCellInt("Sheet1", cell, 1))
+		assert.NoError(t, f.SetCellBool("Sheet1", cell, true))
+		assert.NoError(t, f.SetCellFloat("Sheet1", cell, 1.5, -1, 64))
+		assert.NoError(t, f.SetCellFormula("Sheet1", cell, "1+1"))
+		assert.NoError(t, f.SetCellStyle("Sheet1", cell, cell, 0))
+		assert.NoError(t, f.SetCellRichText("Sheet1", cell, []RichTextRun{{Text: "a"}}))
+		assert.NoError(t, f.SetCellHyperLink("Sheet1", cell, "Sheet1!A1", "Location"))
+		assert.NoError(t, f.Close())
+	}
+	f := NewFile()
+	assert.NoError(t, f.SetSheetCol("Sheet1", fmt.Sprintf("A%d", TotalRows-1), &[]interface{}{1, 2}))
+	assert.NoError(t, f.SetSheetRow("Sheet1", "XFC1", &[]interface{}{1, 2}))
+	assert.NoError(t, f.SetRowHeight("Sheet1", TotalRows, 20))
+	assert.NoError(t, f.SetRowVisible("Sheet1", TotalRows, false))
+	assert.NoError(t, f.SetRowOutlineLevel("Sheet1", TotalRows, 1))
+	height, err := f.GetRowHeight("Sheet1", TotalRows)
+	assert.NoError(t, err)
+	assert.Equal(t, 20.0, height)
+	// Test write one past the maximum number of columns and rows
+	for _, cell := range []string{"XFE1", fmt.Sprintf("A%d", TotalRows+1)} {
+		expected := ErrColumnNumber
+		if cell != "XFE1" {
+			expected = ErrMaxRows
+		}
+		assert.Equal(t, expected, f.SetCellValue("Sheet1", cell, 1))
+		assert.Equal(t, expected, f.SetCellStr("Sheet1", cell, "a"))
+		assert.Equal(t, expected, f.SetCellInt("Sheet1", cell, 1))
+		assert.Equal(t, expected, f.SetCellBool("Sheet1", cell, true))
+		assert.Equal(t, expected, f.SetCellFloat("Sheet1", cell, 1.5, -1, 64))
+		assert.Equal(t, expected, f.SetCellFormula("Sheet1", cell, "1+1"))
+		assert.Equal(t, expected, f.SetCellStyle("Sheet1", cell, cell, 0))
+		assert.Equal(t, expected, f.SetCellRichText("Sheet1", cell, []RichTextRun{{Text: "a"}}))
+		assert.Equal(t, expected, f.SetCellHyperLink("Sheet1", cell, "Sheet1!A1", "Location"))
+		assert.Equal(t, expected, f.SetSheetRow("Sheet1", cell, &[]interface{}{1}))
+		assert.Equal(t, expected, f.SetSheetCol("Sheet1", cell, &[]interface{}{1}))
+	}
+	assert.Equal(t, ErrColumnNumber, f.SetSheetRow("Sheet1", "XFD1", &[]interface{}{1, 2}))
+	assert.Equal(t, ErrMaxRows, f.SetSheetCol("Sheet1", fmt.Sprintf("A%d", TotalRows), &[]interface{}{1, 2}))
+	assert.Equal(t, ErrMaxRows, f.SetRowHeight("Sheet1", TotalRows+1, 20))
+	assert.Equal(t, ErrMaxRows, f.SetRowVisible("Sheet1", TotalRows+1, false))
+	assert.Equal(t, ErrMaxRows, f.SetRowOutlineLevel("Sheet1", TotalRows+1, 1))
+	assert.Equal(t, ErrMaxRows, f.RemoveRow("Sheet1", TotalRows+1))
+	assert.Equal(t, ErrMaxRows, f.RemoveRows("Sheet1", []int{1, TotalRows + 1}))
+	assert.Equal(t, ErrMaxRows, f.DuplicateRowTo("Sheet1", 1, TotalRows+1))
+	_, err = f.GetRowHeight("Sheet1", TotalRows+1)
+	assert.Equal(t, ErrMaxRows, err)
+	_, err = f.GetRowVisible("Sheet1", TotalRows+1)
+	assert.Equal(t, ErrMaxRows, err)
+	_, err = f.GetRowOutlineLevel("Sheet1", TotalRows+1)
+	assert.Equal(t, ErrMaxRows, err)
+	assert.NoError(t, f.Close())
+}
+
 func TestSetCellValue(t *testing.T) {
 	f := NewFile()
 	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), f.SetCellValue("Sheet1", "A", time.Now().UTC()))
@@ -348,6 +476,60 @@ func TestSetCellValues(t *testing.T) {
 	assert.Equal(t, v, "1600-12-31T00:00:00Z")
 }
 
+func TestCellValueEscapeRoundTrip(t *testing.T) {
+	values := []string{"\tleading tab", "trailing CR\r", "bell\x07", "_x0041_", " padded ", "line\r\nbreak", "_x005F_x0041_"}
+	f := NewFile()
+	sw, err := f.NewStreamWriter("Sheet1")
+	assert.NoError(t, err)
+	_, err = f.NewSheet("Sheet2")
+	assert.NoError(t, err)
+	for i, value := range values {
+		cell, err := CoordinatesToCellName(1, i+1)
+		assert.NoError(t, err)
+		assert.NoError(t, sw.SetRow(cell, []interface{}{value, Cell{Formula: "A1", Value: value}}))
+		assert.NoError(t, f.SetCellValue("Sheet2", cell, value))
+		cell, err = CoordinatesToCellName(2, i+1)
+		assert.NoError(t, err)
+		assert.NoError(t, f.SetCellRichText("Sheet2", cell, []RichTextRun{{Text: value}, {Text: value, Font: &Font{Bold: true}}}))
+	}
+	assert.NoError(t, sw.Flush())
+	assert.NoError(t, f.SetDefinedName(&DefinedName{Name: "Escaped", RefersTo: "\"_x0041_\x07\"", Comment: "\tcomment"}))
+	// Test setting the same shared string value reuses the string item
+	assert.NoError(t, f.SetCellValue("Sheet2", "C1", values[2]))
+	assert.Len(t, f.SharedStrings.SI, len(values)*2)
+	buf, err := f.WriteToBuffer()
+	assert.NoError(t, err)
+	assert.NoError(t, f.Close())
+
+	for _, expected := range []string{`<t xml:space="preserve">&#x9;leading tab</t>`, `<t>bell_x0007_</t>`, `<t>_x005F_x0041_</t>`, `<t xml:space="preserve"> padded </t>`, `<t>line_x000D_&#xA;break</t>`} {
+		assert.Contains(t, string(f.readBytes(defaultXMLPathSharedStrings)), expected)
+	}
+	f, err = OpenReader(buf)
+	assert.NoError(t, err)
+	for i, value := range values {
+		for _, sheet := range []string{"Sheet1", "Sheet2"} {
+			rows, err := f.GetRows(sheet)
+			assert.NoError(t, err)
+			assert.Equal(t, value, rows[i][0], sheet)
+			if sheet == "Sheet1" {
+				assert.Equal(t, value, rows[i][1], sheet)
+			}
+		}
+		cell, err := CoordinatesToCellName(2, i+1)
+		assert.NoError(t, err)
+		runs, err := f.GetCellRichText("Sheet2", cell)
+		assert.NoError(t, err)
+		assert.Len(t, runs, 2)
+		assert.Equal(t, value, runs[0].Text)
+		assert.Equal(t, value, runs[1].Text)
+	}
+	definedNames := f.GetDefinedName()
+	assert.Len(t, definedNames, 1)
+	assert.Equal(t, "\"_x0041_\x07\"", definedNames[0].RefersTo)
+	assert.Equal(t, "\tcomment", definedNames[0].Comment)
+	assert.NoError(t, f.Close())
+}
+
 func TestSetCellBool(t *testing.T) {
 	f := NewFile()
 	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), f.SetCellBool("Sheet1", "A", true))
@@ -556,6 +738,100 @@ func TestGetCellType(t *testing.T) {
 	assert.Equal(t, ErrSheetNameInvalid, err)
 }
 
+func TestCellExists(t *testing.T) {
+	f := NewFile()
+	styleID, err := f.NewStyle(&Style{Font: &Font{Bold: true}})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellValue("Sheet1", "A1", ""))
+	assert.NoError(t, f.SetCellStyle("Sheet1", "A3", "A3", styleID))
+	assert.NoError(t, f.SetCellValue("Sheet1", "A3", nil))
+	assert.NoError(t, f.SetCellValue("Sheet1", "A4", nil))
+	assert.NoError(t, f.SetCellValue("Sheet1", "C4", "C4"))
+	for cell, expected := range map[string]bool{
+		"A1": true, "A2": false, "A3": true, "A4": false, "B4": false, "C4": true, "D4": false, "A5": false,
+	} {
+		exists, err := f.CellExists("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, exists, cell)
+	}
+	// Test the value and style of the cell after set the cell value as nil
+	val, err := f.GetCellValue("Sheet1", "A3")
+	assert.NoError(t, err)
+	assert.Empty(t, val)
+	cellStyleID, err := f.GetCellStyle("Sheet1", "A3")
+	assert.NoError(t, err)
+	assert.Equal(t, styleID, cellStyleID)
+	// Test check cell exists with invalid cell reference
+	_, err = f.CellExists("Sheet1", "A")
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), err)
+	// Test check cell exists with not exists worksheet
+	_, err = f.CellExists("SheetN", "A1")
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+	// Test check cell exists with invalid sheet name
+	_, err = f.CellExists("Sheet:1", "A1")
+	assert.Equal(t, ErrSheetNameInvalid, err)
+}
+
+func TestDeleteCell(t *testing.T) {
+	f := NewFile(Options{TrackChanges: true})
+	styleID, err := f.NewStyle(&Style{Font: &Font{Bold: true}})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellValue("Sheet1", "A1", ""))
+	assert.NoError(t, f.SetCellValue("Sheet1", "A2", "A2"))
+	assert.NoError(t, f.SetCellStyle("Sheet1", "A3", "A3", styleID))
+	assert.NoError(t, f.SetCellValue("Sheet1", "A3", nil))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "B1", "COUNTA(A1:A4)"))
+	// Test the empty string cell is counted and the blank cells are not
+	result, err := f.CalcCellValue("Sheet1", "B1")
+	assert.NoError(t, err)
+	assert.Equal(t, "2", result)
+	assert.NoError(t, f.DeleteCell("Sheet1", "A1"))
+	exists, err := f.CellExists("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.False(t, exists)
+	result, err = f.CalcCellValue("Sheet1", "B1")
+	assert.NoError(t, err)
+	assert.Equal(t, "1", result)
+	// Test delete the cell with style and formula
+	assert.NoError(t, f.DeleteCell("Sheet1", "A3"))
+	assert.NoError(t, f.DeleteCell("Sheet1", "B1"))
+	for _, cell := range []string{"A3", "B1"} {
+		exists, err = f.CellExists("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.False(t, exists)
+	}
+	formula, err := f.GetCellFormula("Sheet1", "B1")
+	assert.NoError(t, err)
+	assert.Empty(t, formula)
+	changes, err := f.GetChangeJournal()
+	assert.NoError(t, err)
+	assert.Equal(t, CellChange{Sheet: "Sheet1", Cell: "A1", Type: CellChangeValue}, changes[5])
+	// Test delete not exists cells
+	assert.NoError(t, f.DeleteCell("Sheet1", "A4"))
+	assert.NoError(t, f.DeleteCell("Sheet1", "Z100"))
+	changes, err = f.GetChangeJournal()
+	assert.NoError(t, err)
+	assert.Len(t, changes, 8)
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	assert.Equal(t, "A2", ws.(*xlsxWorksheet).SheetData.Row[1].C[0].R)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestDeleteCell.xlsx")))
+	// Test the deleted cells are not counted after reopen the workbook
+	f, err = OpenFile(filepath.Join("test", "TestDeleteCell.xlsx"))
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellFormula("Sheet1", "B1", "COUNTA(A1:A4)"))
+	result, err = f.CalcCellValue("Sheet1", "B1")
+	assert.NoError(t, err)
+	assert.Equal(t, "1", result)
+	// Test delete cell with invalid cell reference
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), f.DeleteCell("Sheet1", "A"))
+	// Test delete cell with not exists worksheet
+	assert.EqualError(t, f.DeleteCell("SheetN", "A1"), "sheet SheetN does not exist")
+	// Test delete cell with invalid sheet name
+	assert.Equal(t, ErrSheetNameInvalid, f.DeleteCell("Sheet:1", "A1"))
+	assert.NoError(t, f.Close())
+}
+
 func TestGetValueFrom(t *testing.T) {
 	f := NewFile()
 	c := xlsxC{T: "s"}
@@ -569,6 +845,14 @@ func TestGetValueFrom(t *testing.T) {
 	value, err = c.getValueFrom(f, &xlsxSST{Count: 1, SI: []xlsxSI{{}, {T: &xlsxT{Val: "s"}}}}, false)
 	assert.NoError(t, err)
 	assert.Equal(t, "s", value)
+
+	// Test get shared string value with out of range index
+	for _, idx := range []string{"-1", "2"} {
+		c = xlsxC{T: "s", V: idx}
+		value, err = c.getValueFrom(f, &xlsxSST{Count: 1, SI: []xlsxSI{{}, {T: &xlsxT{Val: "s"}}}}, false)
+		assert.NoError(t, err)
+		assert.Equal(t, idx, value)
+	}
 }
 
 func TestGetCellFormula(t *testing.T) {
@@ -743,6 +1027,35 @@ func BenchmarkSetCellValue(b *testing.B) {
 	}
 }
 
+func BenchmarkSetSheetCol(b *testing.B) {
+	values := make([]interface{}, 10000)
+	for i := range values {
+		values[i] = []interface{}{i, "Value" + strconv.Itoa(i%100), float64(i) / 3, i%2 == 0}[i%4]
+	}
+	b.Run("SetCellValue", func(b *testing.B) {
+		for n := 0; n < b.N; n++ {
+			b.StopTimer()
+			f := NewFile()
+			b.StartTimer()
+			for i, value := range values {
+				if err := f.SetCellValue("Sheet1", "B"+strconv.Itoa(i+1), value); err != nil {
+					b.Error(err)
+				}
+			}
+		}
+	})
+	b.Run("SetSheetCol", func(b *testing.B) {
+		for n := 0; n < b.N; n++ {
+			b.StopTimer()
+			f := NewFile()
+			b.StartTimer()
+			if err := f.SetSheetCol("Sheet1", "B1", &values); err != nil {
+				b.Error(err)
+			}
+		}
+	})
+}
+
 func TestOverflowNumericCell(t *testing.T) {
 	f, err := OpenFile(filepath.Join("test", "OverflowNumericCell.xlsx"))
 	if !assert.NoError(t, err) {
@@ -1265,3 +1578,88 @@ func TestSetCellIntFunc(t *testing.T) {
 func TestSIString(t *testing.T) {
 	assert.Empty(t, xlsxSI{}.String())
 }
+
+func TestChangeJournal(t *testing.T) {
+	// Test get change journal without track changes enabled
+	f := NewFile()
+	f.SetChangeLabel("stage")
+	assert.NoError(t, f.SetCellValue("Sheet1", "A1", 1))
+	_, err := f.GetChangeJournal()
+	assert.Equal(t, ErrTrackChangesDisabled, err)
+	// Test set cell value without allocations for the change journal
+	assert.Zero(t, testing.AllocsPerRun(100, func() {
+		assert.NoError(t, f.SetCellInt("Sheet1", "A1", 7))
+	}))
+
+	f = NewFile(Options{TrackChanges: true})
+	assert.NoError(t, f.SetCellValue("Sheet1", "A1", "foo"))
+	f.SetChangeLabel("transform")
+	assert.NoError(t, f.SetCellValue("Sheet1", "A1", 1.5))
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A2", &[]interface{}{true, nil, uint8(2)}))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "B1", "SUM(A1,A2)"))
+	f.SetChangeLabel("copy")
+	assert.NoError(t, f.DuplicateRowTo("Sheet1", 1, 5))
+	changes, err := f.GetChangeJournal()
+	assert.NoError(t, err)
+	assert.Equal(t, []CellChange{
+		{Sheet: "Sheet1", Cell: "A1", NewValue: "foo"},
+		{Sheet: "Sheet1", Cell: "A1", Label: "transform", OldValue: "foo", NewValue: "1.5"},
+		{Sheet: "Sheet1", Cell: "A2", Label: "transform", NewValue: "1"},
+		{Sheet: "Sheet1", Cell: "B2", Label: "transform"},
+		{Sheet: "Sheet1", Cell: "C2", Label: "transform", NewValue: "2"},
+		{Sheet: "Sheet1", Cell: "B1", Label: "transform", NewValue: "=SUM(A1,A2)"},
+		{Sheet: "Sheet1", Cell: "A5", Label: "copy", NewValue: "1.5"},
+		{Sheet: "Sheet1", Cell: "B5", Label: "copy", NewValue: "=SUM(A5,A6)"},
+	}, changes)
+
+	// Test change journal with ring buffer size
+	f = NewFile(Options{TrackChanges: true, ChangeJournalSize: 2})
+	for i, cell := range []string{"A1", "A2", "A3"} {
+		assert.NoError(t, f.SetCellValue("Sheet1", cell, i))
+	}
+	changes, err = f.GetChangeJournal()
+	assert.NoError(t, err)
+	assert.Equal(t, []CellChange{
+		{Sheet: "Sheet1", Cell: "A2", NewValue: "1"},
+		{Sheet: "Sheet1", Cell: "A3", NewValue: "2"},
+	}, changes)
+
+	// Test change journal on opened workbook
+	buf, err := f.WriteToBuffer()
+	assert.NoError(t, err)
+	f, err = OpenReader(buf, Options{TrackChanges: true})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellRichText("Sheet1", "A1", []RichTextRun{{Text: "bar"}}))
+	changes, err = f.GetChangeJournal()
+	assert.NoError(t, err)
+	assert.Equal(t, []CellChange{{Sheet: "Sheet1", Cell: "A1", OldValue: "0", NewValue: "bar"}}, changes)
+	assert.NoError(t, f.Close())
+
+	// Test change journal only records the succeeded changes
+	f = NewFile(Options{TrackChanges: true})
+	assert.Equal(t, ErrCellCharsLength, f.SetCellStr("Sheet1", "A1", strings.Repeat("c", TotalCellChars+1)))
+	ws, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	ws.Hyperlinks = &xlsxHyperlinks{Hyperlink: make([]xlsxHyperlink, TotalSheetHyperlinks+1)}
+	assert.Equal(t, ErrTotalSheetHyperlinks, f.SetCellHyperLink("Sheet1", "A1", "Sheet1!A2", "Location"))
+	ws.Hyperlinks = nil
+	assert.Equal(t, newInvalidStyleID(10), f.SetCellStyle("Sheet1", "A1", "A1", 10))
+	changes, err = f.GetChangeJournal()
+	assert.NoError(t, err)
+	assert.Empty(t, changes)
+
+	// Test change journal with style and hyperlink changes
+	styleID, err := f.NewStyle(&Style{Font: &Font{Bold: true}})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellStyle("Sheet1", "A1", "B1", styleID))
+	assert.NoError(t, f.SetCellHyperLink("Sheet1", "A1", "Sheet1!A2", "Location"))
+	assert.NoError(t, f.SetCellHyperLink("Sheet1", "A1", "", "None"))
+	changes, err = f.GetChangeJournal()
+	assert.NoError(t, err)
+	assert.Equal(t, []CellChange{
+		{Sheet: "Sheet1", Cell: "A1", Type: CellChangeStyle, OldValue: "0", NewValue: "1"},
+		{Sheet: "Sheet1", Cell: "B1", Type: CellChangeStyle, OldValue: "0", NewValue: "1"},
+		{Sheet: "Sheet1", Cell: "A1", Type: CellChangeHyperLink, NewValue: "Sheet1!A2"},
+		{Sheet: "Sheet1", Cell: "A1", Type: CellChangeHyperLink, OldValue: "Sheet1!A2"},
+	}, changes)
+}
@@ -12,6 +12,7 @@
 package excelize
 
 import (
+	"bytes"
 	"encoding/xml"
 	"fmt"
 	"strconv"
@@ -552,6 +553,16 @@ func parseChartOptions(opts *Chart) (*Chart, error) {
 	if opts == nil {
 		return nil, ErrParameterInvalid
 	}
+	if opts.Overlap != nil && (*opts.Overlap < -100 || *opts.Overlap > 100) {
+		return nil, ErrChartOverlap
+	}
+	for _, ser := range opts.Series {
+		for _, dp := range ser.DataPoints {
+			if dp.Index < 0 {
+				return nil, ErrParameterInvalid
+			}
+		}
+	}
 	if opts.Dimension.Width == 0 {
 		opts.Dimension.Width = defaultChartDimensionWidth
 	}
@@ -570,6 +581,9 @@ func parseChartOptions(opts *Chart) (*Chart, error) {
 	if opts.Format.ScaleY == 0 {
 		opts.Format.ScaleY = defaultDrawingScale
 	}
+	if err := opts.Format.validate(); err != nil {
+		return nil, err
+	}
 	if opts.Legend.Position == "" {
 		opts.Legend.Position = defaultChartLegendPosition
 	}
@@ -750,6 +764,7 @@ func (opts *Chart) parseTitle() {
 //	Marker
 //	DataLabel
 //	DataLabelPosition
+//	DataPoints
 //
 // Name: Set the name for the series. The name is displayed in the chart legend
 // and in the formula bar. The 'Name' property is optional and if it isn't
@@ -796,6 +811,14 @@ func (opts *Chart) parseTitle() {
 //
 // DataLabelPosition: This sets the position of the chart series data label.
 //
+// DataPoints: This sets the fill and border format of the single data points
+// in the series by the zero-based 'Index' of the data point, for example,
+// highlight the second column in red:
+//
+//	DataPoints: []excelize.ChartDataPoint{
+//	    {Index: 1, Fill: excelize.Fill{Type: "pattern", Color: []string{"FF0000"}, Pattern: 1}},
+//	}
+//
 // Set properties of the chart legend. The options that can be set are:
 //
 //	Position
@@ -1002,7 +1025,8 @@ func (opts *Chart) parseTitle() {
 //
 // Set series overlap of the column and bar series chart by 'Overlap' property.
 // The 'Overlap' property is optional. The default width is 0, and the value
-// should be great or equal than -100 and less or equal than 100.
+// should be great or equal than -100 and less or equal than 100, otherwise
+// an error will be returned.
 //
 // combo: Specifies the create a chart that combines two or more chart types in
 // a single chart. For example, create a clustered column - line chart with
@@ -1140,6 +1164,75 @@ func (f *File) AddChart(sheet, cell string, chart *Chart, combo ...*Chart) error
 	return err
 }
 
+// AddChartSeriesFromCols provides a function to get a chart series with the
+// categories and values referenced the data rows of the given columns. The
+// given number of header rows will be skipped, and the last header row will be
+// used as the series name. The ranges of categories and values are computed
+// from the populated extent of each column when saving the workbook rather
+// than calling this function, so the rows appended after the call are
+// included and the trailing blank cells are excluded. For example, create a
+// line chart with categories in the column A and values in the column B with
+// one header row of the worksheet named 'Sheet1':
+//
+//	series, err := f.AddChartSeriesFromCols("Sheet1", "A", "B", 1)
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	err = f.AddChart("Sheet1", "D1", &excelize.Chart{
+//	    Type:   excelize.Line,
+//	    Series: []excelize.ChartSeries{series},
+//	})
+func (f *File) AddChartSeriesFromCols(sheet, categoriesCol, valuesCol string, skipHeader int) (ChartSeries, error) {
+	var series ChartSeries
+	if skipHeader < 0 || skipHeader >= TotalRows {
+		return series, newInvalidRowNumberError(skipHeader)
+	}
+	var cols [2]int
+	for i, name := range []string{categoriesCol, valuesCol} {
+		col, err := ColumnNameToNumber(name)
+		if err != nil {
+			return series, err
+		}
+		cols[i] = col
+	}
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return series, err
+	}
+	sheetXMLPath, _ := f.getSheetXMLPath(sheet)
+	var refs [2]string
+	for i, col := range cols {
+		colName, _ := ColumnNumberToName(col)
+		ref := fmt.Sprintf("%s!$%s$%d:$%s$%d", escapeSheetName(sheet), colName, skipHeader+1, colName, TotalRows)
+		refs[i] = ref
+		if i == 1 && skipHeader > 0 {
+			series.Name = fmt.Sprintf("%s!$%s$%d", escapeSheetName(sheet), colName, skipHeader)
+		}
+		f.addColRange(ws, &colRange{
+			chart:       true,
+			sheetXML:    sheetXMLPath,
+			placeholder: chartFormulaXML(ref),
+			resolve: func(ws *xlsxWorksheet) string {
+				_, last := ws.getColExtent(col, skipHeader+1, func(c *xlsxC) bool { return true })
+				return chartFormulaXML(fmt.Sprintf("%s!$%s$%d:$%s$%d", escapeSheetName(sheet), colName, skipHeader+1, colName, max(last, skipHeader+1)))
+			},
+		})
+	}
+	series.Categories, series.Values = refs[0], refs[1]
+	return series, err
+}
+
+// chartFormulaXML provides a function to get the XML content of the formula
+// element in the chart by given reference.
+func chartFormulaXML(ref string) string {
+	var buf bytes.Buffer
+	buf.WriteString("<f>")
+	_ = xml.EscapeText(&buf, []byte(ref))
+	buf.WriteString("</f>")
+	return buf.String()
+}
+
 // AddChartSheet provides the method to create a chartsheet by given chart
 // format set (such as offset, scale, aspect ratio setting and print settings)
 // and properties set. In Excel a chartsheet is a worksheet that only contains
@@ -1163,16 +1256,15 @@ func (f *File) AddChartSheet(sheet string, chart *Chart, combo ...*Chart) error
 		},
 	}
 	f.SheetCount++
-	wb, _ := f.workbookReader()
 	sheetID := 0
-	for _, v := range wb.Sheets.Sheet {
+	for _, v := range f.getSheets() {
 		if v.SheetID > sheetID {
 			sheetID = v.SheetID
 		}
 	}
 	sheetID++
 	path := "xl/chartsheets/sheet" + strconv.Itoa(sheetID) + ".xml"
-	f.sheetMap[sheet] = path
+	f.setSheetXMLPath(sheet, path)
 	f.Sheet.Store(path, nil)
 	drawingID := f.countDrawings() + 1
 	chartID := f.countCharts() + 1
@@ -5,6 +5,8 @@ import (
 	"encoding/xml"
 	"fmt"
 	"path/filepath"
+	"sort"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -321,6 +323,67 @@ func TestAddChart(t *testing.T) {
 	assert.EqualError(t, f.AddChart("Sheet1", "P1", &Chart{Type: Col, Series: []ChartSeries{{Name: "Sheet1!$A$30", Categories: "Sheet1!$B$29:$D$29", Values: "Sheet1!$B$30:$D$30"}}, Title: []RichTextRun{{Text: "2D Column Chart"}}}), "XML syntax error on line 1: invalid UTF-8")
 }
 
+func TestAddChartSeriesFromCols(t *testing.T) {
+	f := NewFile()
+	for idx, row := range [][]interface{}{{"Month", "Sales"}, {"Jan", 10}, {"Feb", 20}} {
+		cell, err := CoordinatesToCellName(1, idx+1)
+		assert.NoError(t, err)
+		assert.NoError(t, f.SetSheetRow("Sheet1", cell, &row))
+	}
+	series, err := f.AddChartSeriesFromCols("Sheet1", "A", "B", 1)
+	assert.NoError(t, err)
+	assert.Equal(t, ChartSeries{
+		Name:       "Sheet1!$B$1",
+		Categories: "Sheet1!$A$2:$A$1048576",
+		Values:     "Sheet1!$B$2:$B$1048576",
+	}, series)
+	assert.NoError(t, f.AddChart("Sheet1", "D1", &Chart{Type: Line, Series: []ChartSeries{series}}))
+	// Test the ranges track the rows appended after adding the chart
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A4", &[]interface{}{"Mar", 30}))
+	assert.NoError(t, f.SetCellValue("Sheet1", "A5", "Apr"))
+	assert.NoError(t, f.SetCellStyle("Sheet1", "A6", "B8", 0))
+	getChart := func(f *File) string {
+		buf, err := f.WriteToBuffer()
+		assert.NoError(t, err)
+		newFile, err := OpenReader(buf)
+		assert.NoError(t, err)
+		content, ok := newFile.Pkg.Load("xl/charts/chart1.xml")
+		assert.True(t, ok)
+		assert.NoError(t, newFile.Close())
+		return string(content.([]byte))
+	}
+	chart := getChart(f)
+	assert.Contains(t, chart, "<f>Sheet1!$B$1</f>")
+	assert.Contains(t, chart, "<f>Sheet1!$A$2:$A$5</f>")
+	assert.Contains(t, chart, "<f>Sheet1!$B$2:$B$4</f>")
+	// Test save the workbook again after append rows
+	assert.NoError(t, f.SetCellValue("Sheet1", "B5", 40))
+	assert.Contains(t, getChart(f), "<f>Sheet1!$B$2:$B$5</f>")
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAddChartSeriesFromCols.xlsx")))
+
+	// Test get chart series without header rows on empty columns
+	_, err = f.NewSheet("Sheet 2")
+	assert.NoError(t, err)
+	series, err = f.AddChartSeriesFromCols("Sheet 2", "A", "B", 0)
+	assert.NoError(t, err)
+	assert.Equal(t, ChartSeries{Categories: "'Sheet 2'!$A$1:$A$1048576", Values: "'Sheet 2'!$B$1:$B$1048576"}, series)
+	assert.Equal(t, "<f>&#39;Sheet 2&#39;!$B$1:$B$1</f>", string(f.replaceColRanges("xl/charts/chart2.xml", []byte(chartFormulaXML(series.Values)))))
+
+	// Test get chart series with invalid header rows
+	_, err = f.AddChartSeriesFromCols("Sheet1", "A", "B", -1)
+	assert.Equal(t, newInvalidRowNumberError(-1), err)
+	_, err = f.AddChartSeriesFromCols("Sheet1", "A", "B", TotalRows)
+	assert.Equal(t, newInvalidRowNumberError(TotalRows), err)
+	// Test get chart series with invalid column names
+	_, err = f.AddChartSeriesFromCols("Sheet1", "-", "B", 1)
+	assert.Equal(t, newInvalidColumnNameError("-"), err)
+	_, err = f.AddChartSeriesFromCols("Sheet1", "A", "-", 1)
+	assert.Equal(t, newInvalidColumnNameError("-"), err)
+	// Test get chart series on not exists worksheet
+	_, err = f.AddChartSeriesFromCols("SheetN", "A", "B", 1)
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+}
+
 func TestAddChartSheet(t *testing.T) {
 	categories := map[string]string{"A2": "Small", "A3": "Normal", "A4": "Large", "B1": "Apple", "C1": "Orange", "D1": "Pear"}
 	values := map[string]int{"B2": 2, "C2": 3, "D2": 3, "B3": 5, "C3": 2, "D3": 4, "B4": 6, "C4": 7, "D4": 8}
@@ -514,3 +577,60 @@ func TestChartWithLogarithmicBase(t *testing.T) {
 		}
 	}
 }
+
+func TestChartDataPoints(t *testing.T) {
+	f := NewFile()
+	for idx, row := range [][]interface{}{{"A", 1}, {"B", 3}, {"C", 2}, {"D", 4}} {
+		assert.NoError(t, f.SetSheetRow("Sheet1", fmt.Sprintf("A%d", idx+1), &row))
+	}
+	series := []ChartSeries{{
+		Name: "Sheet1!$A$1", Categories: "Sheet1!$A$1:$A$4", Values: "Sheet1!$B$1:$B$4",
+		DataPoints: []ChartDataPoint{
+			{Index: 3, Fill: Fill{Type: "pattern", Color: []string{"FF0000"}, Pattern: 1}},
+			{Index: 1, Border: ChartLine{Type: ChartLineSolid, Width: 2}},
+		},
+	}}
+	assert.NoError(t, f.AddChart("Sheet1", "D1", &Chart{Type: Col, Series: series, GapWidth: uintPtr(50), Overlap: intPtr(-20), PlotArea: ChartPlotArea{ShowVal: true}}))
+	assert.NoError(t, f.AddChart("Sheet1", "D16", &Chart{Type: Pie, Series: []ChartSeries{{
+		Categories: "Sheet1!$A$1:$A$4", Values: "Sheet1!$B$1:$B$4",
+		DataPoints: []ChartDataPoint{{Index: 2, Fill: Fill{Type: "pattern", Color: []string{"00FF00"}, Pattern: 1}}},
+	}}, VaryColors: boolPtr(false)}))
+	assert.NoError(t, f.AddChart("Sheet1", "M1", &Chart{Type: Col, Series: []ChartSeries{{Values: "Sheet1!$B$1:$B$4"}}}))
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestChartDataPoints.xlsx")))
+
+	content, ok := f.Pkg.Load("xl/charts/chart1.xml")
+	assert.True(t, ok)
+	chart := string(content.([]byte))
+	assert.Contains(t, chart, `<gapWidth val="50"></gapWidth><overlap val="-20"></overlap>`)
+	// Test the data points are placed after the invertIfNegative element and
+	// before the data labels in ascending order
+	idx := []int{
+		strings.Index(chart, "<invertIfNegative"),
+		strings.Index(chart, `<dPt><idx val="1">`),
+		strings.Index(chart, `<dPt><idx val="3">`),
+		strings.Index(chart, "<dLbls>"),
+	}
+	assert.True(t, sort.IntsAreSorted(idx), idx)
+	assert.Contains(t, chart, `<dPt><idx val="3"></idx><bubble3D val="0"></bubble3D><spPr><a:solidFill><a:srgbClr val="FF0000"></a:srgbClr></a:solidFill>`)
+
+	var chartSpace xlsxChartSpace
+	content, ok = f.Pkg.Load("xl/charts/chart2.xml")
+	assert.True(t, ok)
+	assert.NoError(t, xml.Unmarshal(content.([]byte), &chartSpace))
+	dPt := (*chartSpace.Chart.PlotArea.PieChart[0].Ser)[0].DPt
+	assert.Len(t, dPt, 2)
+	assert.Equal(t, 0, *dPt[0].IDx.Val)
+	assert.Equal(t, 2, *dPt[1].IDx.Val)
+	assert.False(t, *chartSpace.Chart.PlotArea.PieChart[0].VaryColors.Val)
+
+	// Test the chart without data points is unaffected
+	content, ok = f.Pkg.Load("xl/charts/chart3.xml")
+	assert.True(t, ok)
+	assert.NotContains(t, string(content.([]byte)), "<dPt>")
+
+	// Test add chart with invalid overlap and data point index
+	for _, overlap := range []int{-101, 101} {
+		assert.Equal(t, ErrChartOverlap, f.AddChart("Sheet1", "M16", &Chart{Type: Col, Series: series, Overlap: intPtr(overlap)}))
+	}
+	assert.Equal(t, ErrParameterInvalid, f.AddChart("Sheet1", "M16", &Chart{Type: Col, Series: []ChartSeries{{Values: "Sheet1!$B$1:$B$4", DataPoints: []ChartDataPoint{{Index: -1}}}}}))
+}
@@ -14,8 +14,15 @@ package excelize
 import (
 	"bytes"
 	"encoding/xml"
+	"io"
+	"math"
+	"regexp"
+	"sort"
 	"strconv"
 	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
 
 	"github.com/tiendc/go-deepcopy"
 )
@@ -26,6 +33,8 @@ const (
 	defaultColWidthPixels  float64 = 84.0
 	defaultRowHeight       float64 = 15.6
 	defaultRowHeightPixels float64 = 20.8
+	defaultMaxDigitWidth   float64 = 8
+	defaultFontSize        float64 = 11
 	EMU                    int     = 9525
 )
 
@@ -33,11 +42,21 @@ const (
 type Cols struct {
 	err                                    error
 	curCol, totalCols, totalRows, stashCol int
-	rawCellValue                           bool
+	startCol, endCol, startRow, endRow     int
+	rawCellValue, calcFormula, formulaText bool
+	skipHiddenCols, skipFilteredRows       bool
 	sheet                                  string
 	f                                      *File
-	sheetXML                               []byte
+	sheetReader                            io.ReaderAt
 	sst                                    *xlsxSST
+	offsets                                [][]cellOffset
+	cellRows                               []int
+	hiddenCols                             [][]int
+	hiddenRows, filteredRows               []int
+	sharedFormulas                         map[int]xlsxC
+	ignoreInvalidCellRef                   bool
+	warnings                               []error
+	transformer                            func(string, CellMeta, string) (string, error)
 }
 
 // GetCols gets the value of all cells by columns on the worksheet based on the
@@ -61,295 +80,2626 @@ type Cols struct {
 //	    }
 //	    fmt.Println()
 //	}
+//
+// The columns will be padded with empty cells to the number of rows of the
+// worksheet, use the TrimTrailingEmptyCells option to truncate the trailing
+// empty cells of each column:
+//
+//	cols, err := f.GetCols("Sheet1", excelize.Options{TrimTrailingEmptyCells: true})
+//
+// Use the SkipHiddenCols option to omit the hidden columns from the result,
+// and the SkipFilteredRows option to omit the rows hidden by the AutoFilter.
+// The errors of calculating the formula cells with the CalcFormulaOnRead
+// option will be dropped, use the columns iterator to get them by the Error
+// function.
 func (f *File) GetCols(sheet string, opts ...Options) ([][]string, error) {
-	cols, err := f.Cols(sheet)
+	cols, err := f.Cols(sheet, opts...)
 	if err != nil {
 		return nil, err
 	}
 	results := make([][]string, 0, 64)
 	for cols.Next() {
-		col, _ := cols.Rows(opts...)
+		col, err := cols.Rows(opts...)
+		if isTransformCellValueError(err) {
+			return nil, err
+		}
 		results = append(results, col)
 	}
 	return results, nil
 }
 
-// Next will return true if the next column is found.
-func (cols *Cols) Next() bool {
-	cols.curCol++
-	return cols.curCol <= cols.totalCols
-}
-
-// Error will return an error when the error occurs.
-func (cols *Cols) Error() error {
-	return cols.err
+// GetColsBoth gets the value of all cells by columns on the worksheet based on
+// the given worksheet name in both the formatted and the raw representation
+// with a single pass of parsing, returned as two index-aligned
+// two-dimensional arrays. The formatted values are the same as the GetCols
+// function returns, and the raw values are the same as the GetCols function
+// returns with the RawCellValue option enabled. For example:
+//
+//	formatted, raw, err := f.GetColsBoth("Sheet1")
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	for colIdx, col := range formatted {
+//	    for rowIdx, cell := range col {
+//	        fmt.Print(cell, raw[colIdx][rowIdx], "\t")
+//	    }
+//	    fmt.Println()
+//	}
+func (f *File) GetColsBoth(sheet string, opts ...Options) ([][]string, [][]string, error) {
+	cols, err := f.Cols(sheet, opts...)
+	if err != nil {
+		return nil, nil, err
+	}
+	formatted, raw := make([][]string, 0, 64), make([][]string, 0, 64)
+	for cols.Next() {
+		col, rawCol, err := cols.RowsBoth(opts...)
+		if isTransformCellValueError(err) {
+			return nil, nil, err
+		}
+		formatted, raw = append(formatted, col), append(raw, rawCol)
+	}
+	return formatted, raw, nil
 }
 
-// Rows return the current column's row values.
-func (cols *Cols) Rows(opts ...Options) ([]string, error) {
-	var rowIterator rowXMLIterator
-	if cols.stashCol >= cols.curCol {
-		return rowIterator.cells, rowIterator.err
-	}
-	cols.rawCellValue = cols.f.getOptions(opts...).RawCellValue
-	if cols.sst, rowIterator.err = cols.f.sharedStringsReader(); rowIterator.err != nil {
-		return rowIterator.cells, rowIterator.err
+// GetColsWithTypes gets the value, data type and style ID of all cells by
+// columns on the worksheet based on the given worksheet name, returned as a
+// two-dimensional array. The blank cells are reported with the CellTypeUnset
+// type. For example, get the cells by columns on a worksheet named 'Sheet1':
+//
+//	cols, err := f.GetColsWithTypes("Sheet1")
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	for _, col := range cols {
+//	    for _, cell := range col {
+//	        fmt.Print(cell.Value, cell.Type, "\t")
+//	    }
+//	    fmt.Println()
+//	}
+func (f *File) GetColsWithTypes(sheet string, opts ...Options) ([][]TypedCell, error) {
+	cols, err := f.Cols(sheet, opts...)
+	if err != nil {
+		return nil, err
 	}
-	decoder := cols.f.xmlNewDecoder(bytes.NewReader(cols.sheetXML))
-	for {
-		token, _ := decoder.Token()
-		if token == nil {
-			break
-		}
-		switch xmlElement := token.(type) {
-		case xml.StartElement:
-			rowIterator.inElement = xmlElement.Name.Local
-			if rowIterator.inElement == "row" {
-				rowIterator.cellCol = 0
-				rowIterator.cellRow++
-				attrR, _ := attrValToInt("r", xmlElement.Attr)
-				if attrR != 0 {
-					rowIterator.cellRow = attrR
-				}
-			}
-			if cols.rowXMLHandler(&rowIterator, &xmlElement, decoder); rowIterator.err != nil {
-				return rowIterator.cells, rowIterator.err
-			}
-		case xml.EndElement:
-			if xmlElement.Name.Local == "sheetData" {
-				return rowIterator.cells, rowIterator.err
-			}
+	results := make([][]TypedCell, 0, 64)
+	for cols.Next() {
+		col, err := cols.RowsWithTypes(opts...)
+		if isTransformCellValueError(err) {
+			return nil, err
 		}
+		results = append(results, col)
 	}
-	return rowIterator.cells, rowIterator.err
+	return results, nil
 }
 
-// columnXMLIterator defined runtime use field for the worksheet column SAX parser.
-type columnXMLIterator struct {
-	err                  error
-	cols                 Cols
-	cellCol, curRow, row int
+// ColumnData directly maps the cells value of a column with the original
+// position of the column in the worksheet. The Name and Index are the column
+// name and the column number starting from 1, the Cells are the cells value
+// aligned to the row numbers, the index 0 of the Cells corresponds to row 1.
+// The Refs are the cell references of the cells with data in ascending row
+// order, which only be set when the CellRefs option enabled.
+type ColumnData struct {
+	Name  string
+	Index int
+	Cells []string
+	Refs  []string
 }
 
-// columnXMLHandler parse the column XML element of the worksheet.
-func columnXMLHandler(colIterator *columnXMLIterator, xmlElement *xml.StartElement) {
-	colIterator.err = nil
-	inElement := xmlElement.Name.Local
-	if inElement == "row" {
-		colIterator.row++
-		for _, attr := range xmlElement.Attr {
-			if attr.Name.Local == "r" {
-				if colIterator.curRow, colIterator.err = strconv.Atoi(attr.Value); colIterator.err != nil {
-					return
-				}
-				colIterator.row = colIterator.curRow
-			}
-		}
-		colIterator.cols.totalRows = colIterator.row
-		colIterator.cellCol = 0
+// ColumnDataOptions directly maps the settings of getting the columns data
+// by the GetColsData and Cols.Data functions. The RawCellValue specifies if
+// get the raw value of the cells without applying the number format, the
+// TrimTrailingEmptyCells specifies if truncate the trailing empty cells of
+// each column, and the CellRefs specifies if carry the cell references of the
+// cells with data in each column, which is useful for accessing the sparse
+// columns without scanning the empty cells. The SkipHiddenCols specifies if
+// omit the hidden columns, which only works with the GetColsData function.
+type ColumnDataOptions struct {
+	RawCellValue           bool
+	TrimTrailingEmptyCells bool
+	CellRefs               bool
+	SkipHiddenCols         bool
+}
+
+// GetColsData gets the value of all cells by columns on the worksheet based
+// on the given worksheet name, returned as a ColumnData slice. The columns
+// without any cell with data will be skipped, and each of the returned
+// columns carries the column name and number in the worksheet, so the
+// results can be mapped back to the original cell references. Enable the
+// CellRefs option to get the cell references of the cells with data in each
+// column. For example, get the columns with data on Sheet1:
+//
+//	cols, err := f.GetColsData("Sheet1", excelize.ColumnDataOptions{CellRefs: true})
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	for _, col := range cols {
+//	    fmt.Println(col.Name, col.Index, col.Cells, col.Refs)
+//	}
+func (f *File) GetColsData(sheet string, opts ...ColumnDataOptions) ([]ColumnData, error) {
+	cols, err := f.Cols(sheet)
+	if err != nil {
+		return nil, err
 	}
-	if inElement == "c" {
-		colIterator.cellCol++
-		for _, attr := range xmlElement.Attr {
-			if attr.Name.Local == "r" {
-				if colIterator.cellCol, _, colIterator.err = CellNameToCoordinates(attr.Value); colIterator.err != nil {
-					return
-				}
-			}
+	for _, opt := range opts {
+		cols.skipHiddenCols = opt.SkipHiddenCols
+	}
+	var results []ColumnData
+	for cols.Next() {
+		col, err := cols.Data(opts...)
+		if err != nil {
+			return results, err
 		}
-		if colIterator.cellCol > colIterator.cols.totalCols {
-			colIterator.cols.totalCols = colIterator.cellCol
+		if len(trimTrailingEmptyCells(col.Cells, "")) == 0 {
+			continue
 		}
+		results = append(results, col)
 	}
+	return results, nil
 }
 
-// rowXMLHandler parse the row XML element of the worksheet.
-func (cols *Cols) rowXMLHandler(rowIterator *rowXMLIterator, xmlElement *xml.StartElement, decoder *xml.Decoder) {
-	if rowIterator.inElement == "c" {
-		rowIterator.cellCol++
-		for _, attr := range xmlElement.Attr {
-			if attr.Name.Local == "r" {
-				if rowIterator.cellCol, rowIterator.cellRow, rowIterator.err = CellNameToCoordinates(attr.Value); rowIterator.err != nil {
-					return
-				}
-			}
-		}
-		blank := rowIterator.cellRow - len(rowIterator.cells)
-		for i := 1; i < blank; i++ {
-			rowIterator.cells = append(rowIterator.cells, "")
-		}
-		if rowIterator.cellCol == cols.curCol {
-			colCell := xlsxC{}
-			_ = decoder.DecodeElement(&colCell, xmlElement)
-			val, _ := colCell.getValueFrom(cols.f, cols.sst, cols.rawCellValue)
-			rowIterator.cells = append(rowIterator.cells, val)
-		}
-	}
+// CompareOptions directly maps the settings of comparing two worksheets by
+// the CompareSheets function. The RawCellValue specifies if compare the raw
+// value of the cells without applying the number format. The Tolerance
+// specifies the maximum absolute difference of two numeric values to be
+// treated as equal, and the values will be compared as strings if it is
+// zero. The IgnoreTrailingEmpty specifies if ignore the empty cells out of
+// the dimension of the other worksheet, otherwise the empty cells with style
+// in the trailing rows and columns will be reported. The WithRawValues and
+// WithStyles specify if carry the raw values and style IDs of the cells in
+// the differences, the style IDs will not be compared since each workbook
+// has its own styles.
+type CompareOptions struct {
+	RawCellValue        bool
+	Tolerance           float64
+	IgnoreTrailingEmpty bool
+	WithRawValues       bool
+	WithStyles          bool
 }
 
-// Cols returns a columns iterator, used for streaming reading data for a
-// worksheet with a large data. This function is concurrency safe. For
-// example:
+// CellDiff directly maps a different cell found by the CompareSheets
+// function. The Value and OtherValue are the formatted values of the cell in
+// the two worksheets, the RawValue and OtherRawValue will be set only if the
+// WithRawValues option is enabled, and the StyleID and OtherStyleID will be
+// set only if the WithStyles option is enabled.
+type CellDiff struct {
+	Cell          string
+	Value         string
+	OtherValue    string
+	RawValue      string
+	OtherRawValue string
+	StyleID       int
+	OtherStyleID  int
+}
+
+// compareCells directly maps the cells of a column for comparing worksheets.
+type compareCells struct {
+	values, rawValues []string
+	styleIDs          []int
+}
+
+// CompareSheets provides a function to compare the worksheet with the
+// worksheet of another workbook column by column, and returns the
+// differences of the cells. Both worksheets will be read by the columns
+// iterator, and the cells out of the dimension of one worksheet will be
+// reported with an empty value on that side. For example, compare Sheet1 of
+// the workbook with the golden file, the numeric values which differ by no
+// more than 0.001 will be treated as equal:
 //
-//	cols, err := f.Cols("Sheet1")
+//	diffs, err := f.CompareSheets(golden, "Sheet1", "Sheet1", excelize.CompareOptions{Tolerance: 0.001})
 //	if err != nil {
 //	    fmt.Println(err)
 //	    return
 //	}
-//	for cols.Next() {
-//	    col, err := cols.Rows()
-//	    if err != nil {
-//	        fmt.Println(err)
-//	    }
-//	    for _, rowCell := range col {
-//	        fmt.Print(rowCell, "\t")
-//	    }
-//	    fmt.Println()
+//	for _, diff := range diffs {
+//	    fmt.Println(diff.Cell, diff.Value, diff.OtherValue)
 //	}
-func (f *File) Cols(sheet string) (*Cols, error) {
-	if err := checkSheetName(sheet); err != nil {
-		return nil, err
+func (f *File) CompareSheets(other *File, sheet, otherSheet string, opts ...CompareOptions) ([]CellDiff, error) {
+	var options CompareOptions
+	for _, opt := range opts {
+		options = opt
 	}
-	name, ok := f.getSheetXMLPath(sheet)
-	if !ok {
-		return nil, ErrSheetNotExist{sheet}
+	if other == nil {
+		return nil, ErrParameterInvalid
 	}
-	if worksheet, ok := f.Sheet.Load(name); ok && worksheet != nil {
-		ws := worksheet.(*xlsxWorksheet)
-		ws.mu.Lock()
-		defer ws.mu.Unlock()
-		output, _ := xml.Marshal(ws)
-		f.saveFileList(name, f.replaceNameSpaceBytes(name, output))
+	cols, err := f.Cols(sheet)
+	if err != nil {
+		return nil, err
 	}
-	var colIterator columnXMLIterator
-	colIterator.cols.sheetXML = f.readBytes(name)
-	decoder := f.xmlNewDecoder(bytes.NewReader(colIterator.cols.sheetXML))
-	for {
-		token, _ := decoder.Token()
-		if token == nil {
-			break
+	otherCols, err := other.Cols(otherSheet)
+	if err != nil {
+		return nil, err
+	}
+	var diffs []CellDiff
+	for col := 1; col <= max(cols.totalCols, otherCols.totalCols); col++ {
+		cells, err := cols.compareCells(options)
+		if err != nil {
+			return diffs, err
 		}
-		switch xmlElement := token.(type) {
-		case xml.StartElement:
-			columnXMLHandler(&colIterator, &xmlElement)
-			if colIterator.err != nil {
-				return &colIterator.cols, colIterator.err
+		otherCells, err := otherCols.compareCells(options)
+		if err != nil {
+			return diffs, err
+		}
+		for idx := 0; idx < max(len(cells.values), len(otherCells.values)); idx++ {
+			diff := CellDiff{}
+			diff.Value, diff.RawValue, diff.StyleID = cells.get(idx)
+			diff.OtherValue, diff.OtherRawValue, diff.OtherStyleID = otherCells.get(idx)
+			value, otherValue := diff.Value, diff.OtherValue
+			if options.RawCellValue {
+				value, otherValue = diff.RawValue, diff.OtherRawValue
 			}
-		case xml.EndElement:
-			if xmlElement.Name.Local == "sheetData" {
-				colIterator.cols.f = f
-				colIterator.cols.sheet = sheet
-				return &colIterator.cols, nil
+			equal := compareCellValue(value, otherValue, options.Tolerance)
+			if equal && !options.IgnoreTrailingEmpty {
+				equal = !(diff.StyleID != 0 && isTrailingCell(otherCols, col, idx+1)) &&
+					!(diff.OtherStyleID != 0 && isTrailingCell(cols, col, idx+1))
+			}
+			if equal {
+				continue
+			}
+			if diff.Cell, err = CoordinatesToCellName(col, idx+1); err != nil {
+				return diffs, err
+			}
+			if !options.WithRawValues {
+				diff.RawValue, diff.OtherRawValue = "", ""
+			}
+			if !options.WithStyles {
+				diff.StyleID, diff.OtherStyleID = 0, 0
 			}
+			diffs = append(diffs, diff)
 		}
 	}
-	return &colIterator.cols, nil
+	return diffs, nil
 }
 
-// GetColVisible provides a function to get visible of a single column by given
-// worksheet name and column name. This function is concurrency safe. For
-// example, get visible state of column D in Sheet1:
-//
-//	visible, err := f.GetColVisible("Sheet1", "D")
-func (f *File) GetColVisible(sheet, col string) (bool, error) {
-	colNum, err := ColumnNameToNumber(col)
-	if err != nil {
-		return true, err
-	}
-	f.mu.Lock()
-	ws, err := f.workSheetReader(sheet)
-	if err != nil {
-		f.mu.Unlock()
-		return false, err
+// compareCells provides a function to move the columns iterator to the next
+// column and read the cells of it for comparing worksheets, the style IDs of
+// the cells will be read only if they are needed by given compare options.
+func (cols *Cols) compareCells(opts CompareOptions) (compareCells, error) {
+	var (
+		cells compareCells
+		err   error
+	)
+	if !cols.Next() {
+		return cells, err
 	}
-	f.mu.Unlock()
-	ws.mu.Lock()
-	defer ws.mu.Unlock()
-	if ws.Cols == nil {
-		return true, err
+	if cells.values, cells.rawValues, err = cols.RowsBoth(); err != nil {
+		return cells, err
 	}
-	visible := true
-	for c := range ws.Cols.Col {
-		colData := &ws.Cols.Col[c]
-		if colData.Min <= colNum && colNum <= colData.Max {
-			visible = !colData.Hidden
+	if opts.WithStyles || !opts.IgnoreTrailingEmpty {
+		typedCells, err := cols.RowsWithTypes()
+		if err != nil {
+			return cells, err
+		}
+		for _, cell := range typedCells {
+			cells.styleIDs = append(cells.styleIDs, cell.StyleID)
 		}
 	}
-	return visible, err
+	return cells, err
 }
 
-// SetColVisible provides a function to set visible columns by given worksheet
-// name, columns range and visibility. This function is concurrency safe.
-//
-// For example hide column D on Sheet1:
-//
-//	err := f.SetColVisible("Sheet1", "D", false)
-//
-// Hide the columns from D to F (included):
-//
-//	err := f.SetColVisible("Sheet1", "D:F", false)
-func (f *File) SetColVisible(sheet, columns string, visible bool) error {
-	minVal, maxVal, err := f.parseColRange(columns)
-	if err != nil {
-		return err
-	}
-	ws, err := f.workSheetReader(sheet)
-	if err != nil {
-		return err
+// get provides a function to get the formatted value, raw value and style ID
+// of the cell by given index in the column, the blank values will be
+// returned if the index is out of the range of the column.
+func (cells compareCells) get(idx int) (string, string, int) {
+	var (
+		value, rawValue string
+		styleID         int
+	)
+	if idx < len(cells.values) {
+		value = cells.values[idx]
 	}
-	ws.mu.Lock()
-	defer ws.mu.Unlock()
-	colData := xlsxCol{
-		Min:         minVal,
-		Max:         maxVal,
-		Width:       float64Ptr(defaultColWidth),
-		Hidden:      !visible,
-		CustomWidth: true,
+	if idx < len(cells.rawValues) {
+		rawValue = cells.rawValues[idx]
 	}
-	if ws.Cols == nil {
-		cols := xlsxCols{}
-		cols.Col = append(cols.Col, colData)
-		ws.Cols = &cols
-		return nil
+	if idx < len(cells.styleIDs) {
+		styleID = cells.styleIDs[idx]
 	}
-	ws.Cols.Col = flatCols(colData, ws.Cols.Col, func(fc, c xlsxCol) xlsxCol {
-		fc.BestFit = c.BestFit
-		fc.Collapsed = c.Collapsed
-		fc.CustomWidth = c.CustomWidth
-		fc.OutlineLevel = c.OutlineLevel
-		fc.Phonetic = c.Phonetic
-		fc.Style = c.Style
-		fc.Width = c.Width
-		return fc
-	})
-	return nil
+	return value, rawValue, styleID
 }
 
-// GetColOutlineLevel provides a function to get outline level of a single
-// column by given worksheet name and column name. For example, get outline
-// level of column D in Sheet1:
-//
-//	level, err := f.GetColOutlineLevel("Sheet1", "D")
-func (f *File) GetColOutlineLevel(sheet, col string) (uint8, error) {
-	level := uint8(0)
-	colNum, err := ColumnNameToNumber(col)
-	if err != nil {
-		return level, err
-	}
-	ws, err := f.workSheetReader(sheet)
-	if err != nil {
-		return 0, err
+// isTrailingCell returns true if the cell by given column and row number is
+// out of the dimension of the worksheet read by the columns iterator.
+func isTrailingCell(cols *Cols, col, row int) bool {
+	return col > cols.totalCols || row > cols.totalRows
+}
+
+// compareCellValue returns true if the two cell values are equal, the values
+// will be compared as numbers if the tolerance is positive and both values
+// are numeric.
+func compareCellValue(value, otherValue string, tolerance float64) bool {
+	if value == otherValue {
+		return true
 	}
-	if ws.Cols == nil {
-		return level, err
+	if tolerance <= 0 {
+		return false
 	}
-	for c := range ws.Cols.Col {
-		colData := &ws.Cols.Col[c]
-		if colData.Min <= colNum && colNum <= colData.Max {
-			level = colData.OutlineLevel
-		}
+	x, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+	if err != nil {
+		return false
 	}
-	return level, err
+	y, err := strconv.ParseFloat(strings.TrimSpace(otherValue), 64)
+	return err == nil && math.Abs(x-y) <= tolerance
+}
+
+// HeaderOptions directly maps the settings of mapping the header text and
+// the column names by the GetColIndexByHeader and GetColsIndexByHeader
+// functions. The LowerCase specifies if lower-case the header text before
+// building the mapping, and the RawCellValue specifies if use the raw value
+// of the header cells without applying the number format.
+type HeaderOptions struct {
+	LowerCase    bool
+	RawCellValue bool
+}
+
+// GetColIndexByHeader provides a function to get the mapping of the header
+// text and the column name by given worksheet name and header row number. The
+// rows iterator will be used to read the worksheet, and only the header row
+// will be parsed. The header text will be trimmed whitespace, and the blank
+// header cells will be skipped. The duplicate header text will be suffixed
+// with an underscore and the smallest sequence number starting from 2 which
+// doesn't conflict with any header text in the row, such as "Name", "Name_2"
+// and "Name_3" in column order, so a literal "Name_2" header will be kept
+// and the duplicate "Name" header will be mapped as "Name_3". Use the
+// GetColsIndexByHeader function to get all columns of the duplicate header
+// text without suffixing. For example, get the column name of the header
+// "Revenue" in the first row on Sheet1:
+//
+//	index, err := f.GetColIndexByHeader("Sheet1", 1)
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	fmt.Println(index["Revenue"])
+func (f *File) GetColIndexByHeader(sheet string, headerRow int, opts ...HeaderOptions) (map[string]string, error) {
+	headers, err := f.getHeaderRow(sheet, headerRow, opts...)
+	if err != nil {
+		return nil, err
+	}
+	literal, index := make(map[string]bool, len(headers)), make(map[string]string, len(headers))
+	for _, header := range headers {
+		literal[header] = true
+	}
+	for colIdx, header := range headers {
+		if header == "" {
+			continue
+		}
+		key := header
+		for seq := 2; index[key] != ""; seq++ {
+			if key = header + "_" + strconv.Itoa(seq); literal[key] {
+				key = header
+			}
+		}
+		index[key], _ = ColumnNumberToName(colIdx + 1)
+	}
+	return index, err
+}
+
+// GetColsIndexByHeader provides a function to get the mapping of the header
+// text and the column names by given worksheet name and header row number,
+// which is the multi-valued variant of the GetColIndexByHeader function. All
+// the column names with the same header text will be returned in column order
+// without suffixing the duplicate header text. For example, get the columns
+// of the header "Name" in the first row on Sheet1:
+//
+//	index, err := f.GetColsIndexByHeader("Sheet1", 1)
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	fmt.Println(index["Name"])
+func (f *File) GetColsIndexByHeader(sheet string, headerRow int, opts ...HeaderOptions) (map[string][]string, error) {
+	headers, err := f.getHeaderRow(sheet, headerRow, opts...)
+	if err != nil {
+		return nil, err
+	}
+	index := make(map[string][]string, len(headers))
+	for colIdx, header := range headers {
+		if header == "" {
+			continue
+		}
+		colName, _ := ColumnNumberToName(colIdx + 1)
+		index[header] = append(index[header], colName)
+	}
+	return index, err
+}
+
+// getHeaderRow provides a function to get the trimmed header text of each
+// column by given worksheet name, header row number and header options. The
+// rows iterator will be created with the explicit options, so the header row
+// will be read even if it is hidden.
+func (f *File) getHeaderRow(sheet string, headerRow int, opts ...HeaderOptions) ([]string, error) {
+	var options HeaderOptions
+	for _, opt := range opts {
+		options = opt
+	}
+	if headerRow < 1 {
+		return nil, newInvalidRowNumberError(headerRow)
+	}
+	rows, err := f.Rows(sheet, Options{})
+	if err != nil {
+		return nil, err
+	}
+	var headers []string
+	for rows.Next() {
+		if rows.CurrentRow() < headerRow {
+			continue
+		}
+		if headers, err = rows.Columns(Options{RawCellValue: options.RawCellValue}); err != nil {
+			_ = rows.Close()
+			return nil, err
+		}
+		break
+	}
+	for colIdx, header := range headers {
+		if header = strings.TrimSpace(header); options.LowerCase {
+			header = strings.ToLower(header)
+		}
+		headers[colIdx] = header
+	}
+	return headers, rows.Close()
+}
+
+// BlankCellPolicy is the type of the policy for handling the blank cells when
+// parsing the cells value of a column.
+type BlankCellPolicy byte
+
+// Blank cell policies enumeration.
+const (
+	BlankCellSkip BlankCellPolicy = iota
+	BlankCellZero
+	BlankCellError
+)
+
+// SearchMatchType is the type of the matching mode for searching the cells.
+type SearchMatchType byte
+
+// Search matching modes enumeration.
+const (
+	SearchMatchExact SearchMatchType = iota
+	SearchMatchCaseInsensitive
+	SearchMatchRegex
+)
+
+// SearchOptions directly maps the settings of searching the cells. The Match
+// specifies the matching mode, the cells value will be matched exactly by
+// default, set to SearchMatchCaseInsensitive to match the cells value case
+// insensitively, or set to SearchMatchRegex to match the cells value by the
+// regular expression. The cells value will be matched with the number format
+// applied unless the RawCellValue is true.
+type SearchOptions struct {
+	Match        SearchMatchType
+	RawCellValue bool
+}
+
+// SearchResult directly maps the cell matched by searching, includes the cell
+// reference, the matched cell value and the row number.
+type SearchResult struct {
+	Cell  string
+	Value string
+	Row   int
+}
+
+// ColStats directly maps the statistics of the cells value of a column. The
+// Count is the number of the non-blank cells, the CountNumeric, CountBool and
+// CountError are the number of the numeric, boolean and error cells, and the
+// CountBlank is the number of the blank cells above the last row of the
+// worksheet. The Sum, Min, Max and Mean are calculated on the numeric cells
+// only, and will be zero if there are no numeric cells.
+type ColStats struct {
+	Count        int
+	CountNumeric int
+	CountBlank   int
+	CountBool    int
+	CountError   int
+	Sum          float64
+	Min          float64
+	Max          float64
+	Mean         float64
+}
+
+// ColParseOptions directly maps the settings of parsing the cells value of a
+// column. The Blank specifies the policy for handling the blank cells, the
+// blank cells will be skipped by default, set to BlankCellZero to get the
+// zero value for the blank cells, or set to BlankCellError to report the
+// blank cells as parse errors. The blank cells after the last cell of the
+// column up to the last row of the worksheet are also handled by the policy.
+type ColParseOptions struct {
+	Blank BlankCellPolicy
+}
+
+// CopyColOptions directly maps the settings of copying a column. The Values
+// specifies whether to copy the cell values, formulas, hyperlinks and merged
+// cells, the Styles specifies whether to copy the cell styles, conditional
+// formats and data validations, and the Properties specifies whether to copy
+// the column width, visibility, outline level and column style, all of them
+// will be copied by default. Set the Insert to true to insert a new column
+// before the destination column for the copied column, otherwise the
+// destination column will be overwritten.
+type CopyColOptions struct {
+	Values     *bool
+	Styles     *bool
+	Properties *bool
+	Insert     bool
+}
+
+// ColProps directly maps the settings of the columns. The Width specifies the
+// column width in characters, the Hidden specifies if the column is hidden,
+// the BestFit specifies if the column width has been fitted to the contents,
+// the Collapsed specifies if the outline of the column is collapsed, the
+// OutlineLevel specifies the outline level of the column in the range 0-7,
+// the Style specifies the style ID of the column, and the Phonetic specifies
+// if the phonetic information should be displayed for the column. The nil
+// fields will be left unchanged when setting the column settings.
+type ColProps struct {
+	Width        *float64
+	Hidden       *bool
+	BestFit      *bool
+	Collapsed    *bool
+	OutlineLevel *uint8
+	Style        *int
+	Phonetic     *bool
+}
+
+// GetColFloats provides a function to get the cells value of a column as
+// float64 by given worksheet name and column name. The numeric text values
+// will be parsed as numbers, and the cells which can't be parsed will be
+// reported in the returned parse errors and excluded from the values. For
+// example, get the values of column C on Sheet1, and treat blank cells as
+// zero:
+//
+//	values, parseErrs, err := f.GetColFloats("Sheet1", "C", excelize.ColParseOptions{
+//	    Blank: excelize.BlankCellZero,
+//	})
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	for _, parseErr := range parseErrs {
+//	    fmt.Println(parseErr.Cell, parseErr.Value, parseErr.Reason)
+//	}
+func (f *File) GetColFloats(sheet, col string, opts ...ColParseOptions) ([]float64, []CellParseError, error) {
+	var values []float64
+	parseErrs, err := f.parseColCells(sheet, col, opts, func(c TypedCell) string {
+		if c.Value == "" {
+			values = append(values, 0)
+			return ""
+		}
+		if c.Type == CellTypeBool || c.Type == CellTypeError {
+			return "not a number"
+		}
+		val, err := strconv.ParseFloat(strings.TrimSpace(c.Value), 64)
+		if err != nil {
+			return "not a number"
+		}
+		values = append(values, val)
+		return ""
+	})
+	return values, parseErrs, err
+}
+
+// GetColTimes provides a function to get the cells value of a column as
+// time.Time by given worksheet name and column name. The numeric cells will
+// be converted by the 1900 or 1904 date system of the workbook only if a date
+// or time number format applied on the cells, and the ISO 8601 date values
+// will be parsed. The cells which can't be parsed will be reported in the
+// returned parse errors and excluded from the values. For example:
+//
+//	values, parseErrs, err := f.GetColTimes("Sheet1", "A")
+func (f *File) GetColTimes(sheet, col string, opts ...ColParseOptions) ([]time.Time, []CellParseError, error) {
+	var (
+		values   []time.Time
+		date1904 bool
+		dateFmts = map[int]bool{}
+	)
+	wb, err := f.workbookReader()
+	if err != nil {
+		return values, nil, err
+	}
+	if wb != nil && wb.WorkbookPr != nil {
+		date1904 = wb.WorkbookPr.Date1904
+	}
+	parseErrs, err := f.parseColCells(sheet, col, opts, func(c TypedCell) string {
+		if c.Value == "" {
+			values = append(values, time.Time{})
+			return ""
+		}
+		if c.Type == CellTypeNumber {
+			isDateFmt, ok := dateFmts[c.StyleID]
+			if !ok {
+				isDateFmt = f.isDateTimeNumFmt(c.StyleID)
+				dateFmts[c.StyleID] = isDateFmt
+			}
+			val, err := strconv.ParseFloat(c.Value, 64)
+			if err != nil || !isDateFmt {
+				return "not a date or time number format"
+			}
+			values = append(values, timeFromExcelTime(val, date1904))
+			return ""
+		}
+		if c.Type != CellTypeBool && c.Type != CellTypeError {
+			for _, layout := range []string{time.RFC3339Nano, "2006-01-02T15:04:05.999999999", "2006-01-02"} {
+				if val, err := time.Parse(layout, strings.TrimSpace(c.Value)); err == nil {
+					values = append(values, val)
+					return ""
+				}
+			}
+		}
+		return "not a date or time"
+	})
+	return values, parseErrs, err
+}
+
+// GetColBools provides a function to get the cells value of a column as bool
+// by given worksheet name and column name. The text values TRUE and FALSE
+// will be parsed case-insensitively, and the cells which can't be parsed will
+// be reported in the returned parse errors and excluded from the values. For
+// example:
+//
+//	values, parseErrs, err := f.GetColBools("Sheet1", "B")
+func (f *File) GetColBools(sheet, col string, opts ...ColParseOptions) ([]bool, []CellParseError, error) {
+	var values []bool
+	parseErrs, err := f.parseColCells(sheet, col, opts, func(c TypedCell) string {
+		switch {
+		case c.Value == "":
+			values = append(values, false)
+		case c.Type == CellTypeBool:
+			values = append(values, c.Value == "1")
+		case c.Type != CellTypeNumber && c.Type != CellTypeError && strings.EqualFold(strings.TrimSpace(c.Value), "TRUE"):
+			values = append(values, true)
+		case c.Type != CellTypeNumber && c.Type != CellTypeError && strings.EqualFold(strings.TrimSpace(c.Value), "FALSE"):
+			values = append(values, false)
+		default:
+			return "not a boolean"
+		}
+		return ""
+	})
+	return values, parseErrs, err
+}
+
+// GetColStats provides a function to get the statistics of the cells value of
+// a column by given worksheet name and column name by the columns iterator,
+// only the cells of the given column will be loaded into memory. Only the
+// numeric cells will be counted as numeric, the text cells such as "42" will
+// not be parsed as numbers. The cells value will be parsed with the number
+// format applied, so the formatted value such as "1,234.00" will not be
+// counted as numeric unless the RawCellValue option is enabled. For example,
+// get the sum and mean of the raw values in column C on Sheet1:
+//
+//	stats, err := f.GetColStats("Sheet1", "C", excelize.Options{RawCellValue: true})
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	fmt.Println(stats.Sum, stats.Mean)
+func (f *File) GetColStats(sheet, col string, opts ...Options) (ColStats, error) {
+	var stats ColStats
+	colNum, err := ColumnNameToNumber(col)
+	if err != nil {
+		return stats, err
+	}
+	cols, err := f.Cols(sheet)
+	if err != nil {
+		return stats, err
+	}
+	stats.CountBlank = cols.TotalRows()
+	for cols.Next() {
+		if cols.curCol != colNum {
+			continue
+		}
+		cells, err := cols.RowsWithTypes(opts...)
+		if err != nil {
+			return stats, err
+		}
+		for _, c := range cells {
+			if c.Value == "" {
+				continue
+			}
+			stats.Count++
+			switch c.Type {
+			case CellTypeBool:
+				stats.CountBool++
+				continue
+			case CellTypeError:
+				stats.CountError++
+				continue
+			case CellTypeNumber:
+			default:
+				continue
+			}
+			val, err := strconv.ParseFloat(strings.TrimSpace(c.Value), 64)
+			if err != nil || math.IsNaN(val) || math.IsInf(val, 0) {
+				continue
+			}
+			if stats.CountNumeric == 0 || val < stats.Min {
+				stats.Min = val
+			}
+			if stats.CountNumeric == 0 || val > stats.Max {
+				stats.Max = val
+			}
+			stats.CountNumeric++
+			stats.Sum += val
+		}
+		break
+	}
+	stats.CountBlank -= stats.Count
+	if stats.CountNumeric > 0 {
+		stats.Mean = stats.Sum / float64(stats.CountNumeric)
+	}
+	return stats, err
+}
+
+// SearchCols provides a function to search the cells in the given columns
+// by given worksheet name, columns range, cell value and optional settings.
+// The columns are read by the columns iterator, so it could be used on the
+// huge worksheets, and the number format will be applied to the cells value
+// in the same way as the GetCellValue function unless the RawCellValue is
+// true. The results are ordered by column and then by row, and the blank
+// cells will never be matched. For example, search the cells in column C on
+// Sheet1 which value start with "INV-" case insensitively:
+//
+//	results, err := f.SearchCols("Sheet1", "C", "(?i)^INV-", excelize.SearchOptions{
+//	    Match: excelize.SearchMatchRegex,
+//	})
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	for _, result := range results {
+//	    fmt.Println(result.Cell, result.Value, result.Row)
+//	}
+//
+// Search the cells in columns C to F on Sheet1 which raw value is "100":
+//
+//	results, err := f.SearchCols("Sheet1", "C:F", "100", excelize.SearchOptions{
+//	    RawCellValue: true,
+//	})
+func (f *File) SearchCols(sheet, columns, value string, opts ...SearchOptions) ([]SearchResult, error) {
+	var (
+		options SearchOptions
+		results []SearchResult
+		regex   *regexp.Regexp
+	)
+	for _, opt := range opts {
+		options = opt
+	}
+	if options.Match > SearchMatchRegex {
+		return results, ErrParameterInvalid
+	}
+	minVal, maxVal, err := f.parseColRange(columns)
+	if err != nil {
+		return results, err
+	}
+	if options.Match == SearchMatchRegex {
+		if regex, err = regexp.Compile(value); err != nil {
+			return results, err
+		}
+	}
+	match := func(val string) bool {
+		switch options.Match {
+		case SearchMatchCaseInsensitive:
+			return strings.EqualFold(val, value)
+		case SearchMatchRegex:
+			return regex.MatchString(val)
+		}
+		return val == value
+	}
+	cols, err := f.Cols(sheet)
+	if err != nil {
+		return results, err
+	}
+	for cols.Next() && cols.curCol <= maxVal {
+		if cols.curCol < minVal {
+			continue
+		}
+		cells, err := cols.Rows(Options{RawCellValue: options.RawCellValue})
+		if err != nil {
+			return results, err
+		}
+		for idx, val := range cells {
+			if val == "" || !match(val) {
+				continue
+			}
+			cell, _ := CoordinatesToCellName(cols.curCol, idx+1)
+			results = append(results, SearchResult{Cell: cell, Value: val, Row: idx + 1})
+		}
+	}
+	return results, nil
+}
+
+// parseColCells provides a function to parse the raw value of the cells in
+// the column by the given parser with the columns iterator. The parser
+// returns the reason if the cell value can't be parsed, and the blank cells up
+// to the last row of the worksheet will be passed to the parser with an empty
+// value only if the blank cell policy is BlankCellZero.
+func (f *File) parseColCells(sheet, col string, opts []ColParseOptions, parser func(c TypedCell) string) ([]CellParseError, error) {
+	var (
+		options   ColParseOptions
+		parseErrs []CellParseError
+	)
+	for _, opt := range opts {
+		options = opt
+	}
+	colNum, err := ColumnNameToNumber(col)
+	if err != nil {
+		return parseErrs, err
+	}
+	cols, err := f.Cols(sheet)
+	if err != nil {
+		return parseErrs, err
+	}
+	var cells []TypedCell
+	for cols.Next() {
+		if cols.curCol != colNum {
+			continue
+		}
+		if cells, err = cols.RowsWithTypes(Options{RawCellValue: true}); err != nil {
+			return parseErrs, err
+		}
+		break
+	}
+	for idx := 0; idx < max(len(cells), cols.TotalRows()); idx++ {
+		var c TypedCell
+		if idx < len(cells) {
+			c = cells[idx]
+		}
+		cell, _ := CoordinatesToCellName(colNum, idx+1)
+		if c.Value == "" {
+			if options.Blank == BlankCellError {
+				parseErrs = append(parseErrs, CellParseError{Cell: cell, Reason: "blank cell"})
+			}
+			if options.Blank != BlankCellZero {
+				continue
+			}
+		}
+		if reason := parser(c); reason != "" {
+			parseErrs = append(parseErrs, CellParseError{Cell: cell, Value: c.Value, Reason: reason})
+		}
+	}
+	return parseErrs, err
+}
+
+// Next will return true if the next column is found.
+func (cols *Cols) Next() bool {
+	cols.curCol++
+	for cols.skipHiddenCols && cols.curCol <= cols.totalCols && cols.isHiddenCol(cols.curCol) {
+		cols.curCol++
+	}
+	return cols.curCol <= cols.totalCols
+}
+
+// isHiddenCol returns true if the given column number is in the hidden
+// columns of the worksheet.
+func (cols *Cols) isHiddenCol(col int) bool {
+	for _, hidden := range cols.hiddenCols {
+		if hidden[0] <= col && col <= hidden[1] {
+			return true
+		}
+	}
+	return false
+}
+
+// Error will return an error when the error occurs.
+func (cols *Cols) Error() error {
+	return cols.err
+}
+
+// Warnings returns the problems of the cells which have been skipped by the
+// columns iterator with the IgnoreInvalidCellRef option, each of them is an
+// ErrInvalidCellRef error.
+func (cols *Cols) Warnings() []error {
+	return cols.warnings
+}
+
+// CurrentCol returns the column number of the current column, the column
+// number starts from 1, and 0 will be returned before the first call of the
+// Next function. The column number before the seeked column will be returned
+// after calling the Seek function.
+func (cols *Cols) CurrentCol() int {
+	return cols.curCol
+}
+
+// CurrentColName returns the column name of the current column, an error will
+// be returned before the first call of the Next function.
+func (cols *Cols) CurrentColName() (string, error) {
+	return ColumnNumberToName(cols.curCol)
+}
+
+// TotalCols returns the total number of columns in the worksheet, which is
+// the maximum column number of the cells. The columns after the end column
+// will not be counted if the iterator was created by the ColsRange function.
+func (cols *Cols) TotalCols() int {
+	return cols.totalCols
+}
+
+// TotalRows returns the total number of rows in the worksheet, which is the
+// maximum row number of the rows.
+func (cols *Cols) TotalRows() int {
+	return cols.totalRows
+}
+
+// Seek provides a function to position the columns iterator by given column
+// name, so that the next call of the Next function will yield the given
+// column. Seeking backwards to a column that has been read is supported. The
+// Next function will return false if the given column is beyond the last
+// column with cells, and an error will be returned if the given column is out
+// of the column range of the iterator created by the ColsRange function. For
+// example, read the columns from H on Sheet1:
+//
+//	cols, err := f.Cols("Sheet1")
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	if err = cols.Seek("H"); err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	for cols.Next() {
+//	    col, err := cols.Rows()
+//	    if err != nil {
+//	        fmt.Println(err)
+//	    }
+//	    fmt.Println(col)
+//	}
+func (cols *Cols) Seek(col string) error {
+	num, err := ColumnNameToNumber(col)
+	if err != nil {
+		return err
+	}
+	if cols.startCol > 0 && (num < cols.startCol || num > cols.endCol) {
+		return ErrColumnNumber
+	}
+	if num < cols.curCol {
+		cols.offsets = nil
+	}
+	cols.curCol, cols.stashCol = num-1, num-1
+	return err
+}
+
+// SetRowRange provides a function to limit the Rows function to read only the
+// cells within the given start and end row number (both inclusive), and stop
+// parsing the worksheet as soon as the end row is passed. The returned values
+// are indexed relative to the start row. For example, read the first 100 rows
+// of column A on Sheet1:
+//
+//	cols, err := f.Cols("Sheet1")
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	if err = cols.SetRowRange(1, 100); err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	if cols.Next() {
+//	    col, err := cols.Rows()
+//	    if err != nil {
+//	        fmt.Println(err)
+//	    }
+//	    fmt.Println(col)
+//	}
+func (cols *Cols) SetRowRange(start, end int) error {
+	if start < 1 {
+		return newInvalidRowNumberError(start)
+	}
+	if end < start {
+		return newInvalidRowNumberError(end)
+	}
+	if end > TotalRows {
+		return ErrMaxRows
+	}
+	cols.startRow, cols.endRow = start, end
+	return nil
+}
+
+// Rows return the current column's row values. The trailing empty cells of
+// the column will be truncated if the TrimTrailingEmptyCells option enabled.
+func (cols *Cols) Rows(opts ...Options) ([]string, error) {
+	options := cols.f.getOptions(opts...)
+	rowIterator := cols.rows(&rowXMLIterator{blankValue: options.BlankCellValue}, opts...)
+	if options.TrimTrailingEmptyCells {
+		rowIterator.cells = trimTrailingEmptyCells(rowIterator.cells, options.BlankCellValue)
+	}
+	return rowIterator.cells, rowIterator.err
+}
+
+// Data return the current column's cells value with the column name and
+// number. The cells value are aligned to the row numbers even if a row range
+// was set by the SetRowRange function, and the cell references of the cells
+// with data will be returned if the CellRefs option enabled. For example:
+//
+//	cols, err := f.Cols("Sheet1")
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	for cols.Next() {
+//	    col, err := cols.Data()
+//	    if err != nil {
+//	        fmt.Println(err)
+//	    }
+//	    fmt.Println(col.Name, col.Index, col.Cells)
+//	}
+func (cols *Cols) Data(opts ...ColumnDataOptions) (ColumnData, error) {
+	var (
+		err     error
+		options ColumnDataOptions
+		rowOpts []Options
+		data    = ColumnData{Index: cols.curCol}
+	)
+	for _, opt := range opts {
+		options = opt
+		rowOpts = []Options{{RawCellValue: opt.RawCellValue, TrimTrailingEmptyCells: opt.TrimTrailingEmptyCells}}
+	}
+	if data.Name, err = ColumnNumberToName(cols.curCol); err != nil {
+		return data, err
+	}
+	cells, err := cols.Rows(rowOpts...)
+	if err != nil {
+		return data, err
+	}
+	if len(cells) > 0 && cols.startRow > 1 {
+		cells = append(make([]string, cols.startRow-1, cols.startRow-1+len(cells)), cells...)
+	}
+	data.Cells = cells
+	if options.CellRefs {
+		for idx, val := range cells {
+			if val == "" {
+				continue
+			}
+			cell, _ := CoordinatesToCellName(cols.curCol, idx+1)
+			data.Refs = append(data.Refs, cell)
+		}
+	}
+	return data, err
+}
+
+// trimTrailingEmptyCells provides a function to truncate the trailing empty
+// cells of the given cells by the value of the blank cells.
+func trimTrailingEmptyCells(cells []string, blank string) []string {
+	for len(cells) > 0 && cells[len(cells)-1] == blank {
+		cells = cells[:len(cells)-1]
+	}
+	return cells
+}
+
+// WalkCols provides a function to traverse the value of all cells by columns
+// on the worksheet based on the given worksheet name and callback function,
+// the callback function will be called with the column name and the cells
+// value of each column in ascending order. The cells value are the same as
+// the GetCols function returns, including the columns without any cell
+// before the last column with cells, and the hidden columns will be skipped
+// if the SkipHiddenCols option is enabled. The positions of the cells are
+// indexed in a streaming pass, then the cells of each column are read by the
+// positions, so only the positions and the cells value of one column will be
+// kept in memory at a time. The worksheet XML which has been extracted to
+// the temporary file by the UnzipXMLSizeLimit option will be read from the
+// file without being loaded into memory, this is useful for reading the
+// worksheet with a large data. Return ErrStopWalk
+// in the callback function to stop the traversal early without any error,
+// and any other error returned by the callback function will abort the
+// traversal and be returned. This function is concurrency safe. For example,
+// print the cells value of the columns on Sheet1 until the column D:
+//
+//	err := f.WalkCols("Sheet1", func(colName string, cells []string) error {
+//	    fmt.Println(colName, cells)
+//	    if colName == "D" {
+//	        return excelize.ErrStopWalk
+//	    }
+//	    return nil
+//	})
+func (f *File) WalkCols(sheet string, fn func(colName string, cells []string) error, opts ...Options) error {
+	name, err := f.flushColsSheetXML(sheet)
+	if err != nil {
+		return err
+	}
+	content := f.readXML(name)
+	var r io.ReaderAt = bytes.NewReader(content)
+	if len(content) == 0 {
+		tempFile, err := f.readTemp(name)
+		if err != nil {
+			return err
+		}
+		if tempFile != nil {
+			defer func() {
+				_ = tempFile.Close()
+			}()
+			r = tempFile
+		}
+	}
+	cols, err := f.newCols(sheet, r, 0, 0, opts...)
+	if err != nil {
+		return err
+	}
+	options, callOpts := f.getOptions(opts...), getCallOptions(opts...)
+	cols.rawCellValue, cols.calcFormula, cols.formulaText = options.RawCellValue, callOpts.CalcFormulaOnRead, callOpts.FormulaTextOnRead
+	cols.transformer = options.CellValueTransformer
+	if cols.sst, err = f.sharedStringsReader(); err != nil {
+		return err
+	}
+	if cols.offsets, cols.cellRows, err = cols.cellOffsets(); err != nil {
+		return err
+	}
+	for col := range cols.offsets {
+		if cols.curCol = col + 1; cols.skipHiddenCols && cols.isHiddenCol(cols.curCol) {
+			cols.offsets[col] = nil
+			continue
+		}
+		rowIterator := &rowXMLIterator{blankValue: options.BlankCellValue}
+		if cols.decodeCells(rowIterator); rowIterator.err != nil {
+			return rowIterator.err
+		}
+		if options.TrimTrailingEmptyCells {
+			rowIterator.cells = trimTrailingEmptyCells(rowIterator.cells, options.BlankCellValue)
+		}
+		cols.offsets[col] = nil
+		colName, _ := ColumnNumberToName(col + 1)
+		if err = fn(colName, rowIterator.cells); err != nil {
+			if err == ErrStopWalk {
+				return nil
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// cellOffset directly maps the row number and the position of the cell
+// element in the worksheet XML.
+type cellOffset struct {
+	start int64
+	row   int32
+	size  uint32
+}
+
+// cellOffsets provides a function to get the row number and the position of
+// all cell elements in the worksheet XML grouped by the column number in a
+// single pass, used for decoding the cells of a column without parsing the
+// whole worksheet again. The sorted row numbers of the rows with cells will
+// be returned for padding the columns to the same length as the GetCols
+// function.
+func (cols *Cols) cellOffsets() ([][]cellOffset, []int, error) {
+	var (
+		err      error
+		row, col int
+		rows     []int
+		offsets  = make([][]cellOffset, cols.totalCols)
+		decoder  = cols.f.xmlNewDecoder(io.NewSectionReader(cols.sheetReader, 0, math.MaxInt64))
+	)
+	for {
+		start := decoder.InputOffset()
+		token, _ := decoder.Token()
+		if token == nil {
+			break
+		}
+		switch xmlElement := token.(type) {
+		case xml.StartElement:
+			if xmlElement.Name.Local == "row" {
+				row, col = row+1, 0
+				if attrR, _ := attrValToInt("r", xmlElement.Attr); attrR != 0 {
+					row = attrR
+				}
+			}
+			if xmlElement.Name.Local != "c" {
+				continue
+			}
+			col++
+			for _, attr := range xmlElement.Attr {
+				if attr.Name.Local == "r" {
+					cellCol, cellRow, err := CellNameToCoordinates(attr.Value)
+					if err != nil {
+						if !cols.ignoreInvalidCellRef {
+							return offsets, rows, err
+						}
+						continue
+					}
+					col, row = cellCol, cellRow
+				}
+			}
+			if err = decoder.Skip(); err != nil {
+				return offsets, rows, err
+			}
+			if n := len(rows); n == 0 || rows[n-1] != row {
+				rows = append(rows, row)
+			}
+			if col >= cols.startCol && col <= len(offsets) {
+				offsets[col-1] = append(offsets[col-1], cellOffset{start: start, row: int32(row), size: uint32(decoder.InputOffset() - start)})
+			}
+		case xml.EndElement:
+			if xmlElement.Name.Local == "sheetData" {
+				return offsets, sortedUniqueInts(rows), err
+			}
+		}
+	}
+	return offsets, sortedUniqueInts(rows), err
+}
+
+// sortedUniqueInts provides a function to sort the given integers in
+// ascending order and remove the duplicate integers.
+func sortedUniqueInts(nums []int) []int {
+	if sort.IntsAreSorted(nums) {
+		unique := nums[:0]
+		for i, num := range nums {
+			if i == 0 || num != nums[i-1] {
+				unique = append(unique, num)
+			}
+		}
+		return unique
+	}
+	sort.Ints(nums)
+	return sortedUniqueInts(nums)
+}
+
+// decodeCells provides a function to decode the cells of the current column
+// by the cell offsets of the column, and pad the column with empty cells to
+// the last row with cells in the row range, which is the same as parsing the
+// whole worksheet.
+func (cols *Cols) decodeCells(rowIterator *rowXMLIterator) {
+	var (
+		buf      bytes.Buffer
+		cells    = cols.offsets[cols.curCol-1]
+		startRow = max(cols.startRow-1, 0)
+	)
+	for _, cell := range cells {
+		if _, rowIterator.err = buf.ReadFrom(io.NewSectionReader(cols.sheetReader, cell.start, int64(cell.size))); rowIterator.err != nil {
+			return
+		}
+	}
+	decoder := cols.f.xmlNewDecoder(&buf)
+	for idx := 0; idx < len(cells); {
+		token, _ := decoder.Token()
+		if token == nil {
+			break
+		}
+		xmlElement, ok := token.(xml.StartElement)
+		if !ok {
+			continue
+		}
+		row := int(cells[idx].row)
+		if idx++; row < cols.startRow || (cols.endRow > 0 && row > cols.endRow) {
+			_ = decoder.Skip()
+			continue
+		}
+		rowIterator.inElement, rowIterator.cellCol, rowIterator.cellRow = "c", cols.curCol-1, row
+		if cols.rowXMLHandler(rowIterator, &xmlElement, decoder); rowIterator.err != nil {
+			return
+		}
+	}
+	lastRow := len(cols.cellRows)
+	if cols.endRow > 0 {
+		lastRow = sort.SearchInts(cols.cellRows, cols.endRow+1)
+	}
+	if lastRow == 0 || cols.cellRows[lastRow-1] < cols.startRow {
+		return
+	}
+	for length := cols.cellRows[lastRow-1] - startRow - 1; len(rowIterator.cells)+len(rowIterator.typedCells) < length; {
+		if rowIterator.withTypes {
+			rowIterator.typedCells = append(rowIterator.typedCells, TypedCell{})
+			continue
+		}
+		rowIterator.cells = append(rowIterator.cells, rowIterator.blankValue)
+		if rowIterator.withRaw {
+			rowIterator.rawCells = append(rowIterator.rawCells, "")
+		}
+	}
+	cols.dropFilteredRows(rowIterator)
+}
+
+// RowsWithTypes return the current column's row values with the data type and
+// style ID of each cell. The blank cells are reported with the CellTypeUnset
+// type, and the cells without data type attribute that have a value are
+// reported with the CellTypeNumber type. For example:
+//
+//	cols, err := f.Cols("Sheet1")
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	for cols.Next() {
+//	    col, err := cols.RowsWithTypes()
+//	    if err != nil {
+//	        fmt.Println(err)
+//	    }
+//	    for _, cell := range col {
+//	        fmt.Print(cell.Value, cell.Type, "\t")
+//	    }
+//	    fmt.Println()
+//	}
+func (cols *Cols) RowsWithTypes(opts ...Options) ([]TypedCell, error) {
+	rowIterator := cols.rows(&rowXMLIterator{withTypes: true}, opts...)
+	return rowIterator.typedCells, rowIterator.err
+}
+
+// RowsBoth return the current column's row values in both the formatted and
+// the raw representation by decoding each cell only once. The two slices are
+// index-aligned including the blank cells, and the RawCellValue option will be
+// ignored. The trailing cells which are empty in both representations will be
+// truncated if the TrimTrailingEmptyCells option enabled. For example:
+//
+//	cols, err := f.Cols("Sheet1")
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	for cols.Next() {
+//	    formatted, raw, err := cols.RowsBoth()
+//	    if err != nil {
+//	        fmt.Println(err)
+//	    }
+//	    for idx := range formatted {
+//	        fmt.Print(formatted[idx], raw[idx], "\t")
+//	    }
+//	    fmt.Println()
+//	}
+func (cols *Cols) RowsBoth(opts ...Options) ([]string, []string, error) {
+	rowIterator := cols.rows(&rowXMLIterator{withRaw: true}, opts...)
+	if cols.f.getOptions(opts...).TrimTrailingEmptyCells {
+		n := len(rowIterator.cells)
+		for n > 0 && rowIterator.cells[n-1] == "" && rowIterator.rawCells[n-1] == "" {
+			n--
+		}
+		rowIterator.cells, rowIterator.rawCells = rowIterator.cells[:n], rowIterator.rawCells[:n]
+	}
+	return rowIterator.cells, rowIterator.rawCells, rowIterator.err
+}
+
+// rows parse the cells of the current column in the worksheet by given row
+// iterator.
+func (cols *Cols) rows(rowIterator *rowXMLIterator, opts ...Options) *rowXMLIterator {
+	if cols.stashCol >= cols.curCol {
+		return rowIterator
+	}
+	callOpts, options := getCallOptions(opts...), cols.f.getOptions(opts...)
+	cols.rawCellValue, cols.transformer = options.RawCellValue, options.CellValueTransformer
+	cols.calcFormula, cols.formulaText = callOpts.CalcFormulaOnRead, callOpts.FormulaTextOnRead
+	if cols.sst, rowIterator.err = cols.f.sharedStringsReader(); rowIterator.err != nil {
+		return rowIterator
+	}
+	if cols.offsets == nil {
+		if cols.offsets, cols.cellRows, rowIterator.err = cols.cellOffsets(); rowIterator.err != nil {
+			cols.offsets = nil
+			return rowIterator
+		}
+	}
+	if cols.curCol < 1 || cols.curCol > len(cols.offsets) {
+		return rowIterator
+	}
+	for col := 0; col < cols.curCol-1; col++ {
+		cols.offsets[col] = nil
+	}
+	cols.decodeCells(rowIterator)
+	return rowIterator
+}
+
+// columnXMLIterator defined runtime use field for the worksheet column SAX parser.
+type columnXMLIterator struct {
+	err                  error
+	cols                 Cols
+	cellCol, curRow, row int
+}
+
+// columnXMLHandler parse the column XML element of the worksheet.
+func columnXMLHandler(colIterator *columnXMLIterator, xmlElement *xml.StartElement) {
+	colIterator.err = nil
+	inElement := xmlElement.Name.Local
+	if inElement == "col" {
+		if hidden, _ := attrValToBool("hidden", xmlElement.Attr); hidden {
+			minVal, _ := attrValToInt("min", xmlElement.Attr)
+			maxVal, _ := attrValToInt("max", xmlElement.Attr)
+			colIterator.cols.hiddenCols = append(colIterator.cols.hiddenCols, []int{minVal, maxVal})
+		}
+	}
+	if inElement == "row" {
+		colIterator.row++
+		for _, attr := range xmlElement.Attr {
+			if attr.Name.Local == "r" {
+				if colIterator.curRow, colIterator.err = strconv.Atoi(attr.Value); colIterator.err != nil {
+					return
+				}
+				colIterator.row = colIterator.curRow
+			}
+		}
+		colIterator.cols.totalRows = colIterator.row
+		colIterator.cellCol = 0
+		if colIterator.cols.skipFilteredRows {
+			if hidden, _ := attrValToBool("hidden", xmlElement.Attr); hidden {
+				colIterator.cols.hiddenRows = append(colIterator.cols.hiddenRows, colIterator.row)
+			}
+		}
+	}
+	if inElement == "c" {
+		colIterator.cellCol++
+		for _, attr := range xmlElement.Attr {
+			if attr.Name.Local == "r" {
+				col, _, err := CellNameToCoordinates(attr.Value)
+				if err != nil {
+					if !colIterator.cols.ignoreInvalidCellRef {
+						colIterator.err = err
+						return
+					}
+					colIterator.cols.warnings = append(colIterator.cols.warnings, ErrInvalidCellRef{
+						Sheet: colIterator.cols.sheet, Row: colIterator.row, Ref: attr.Value, Err: err,
+					})
+					continue
+				}
+				colIterator.cellCol = col
+			}
+		}
+		if colIterator.cellCol > colIterator.cols.totalCols &&
+			(colIterator.cols.endCol == 0 || colIterator.cellCol <= colIterator.cols.endCol) {
+			colIterator.cols.totalCols = colIterator.cellCol
+		}
+	}
+}
+
+// rowXMLHandler parse the row XML element of the worksheet.
+func (cols *Cols) rowXMLHandler(rowIterator *rowXMLIterator, xmlElement *xml.StartElement, decoder *xml.Decoder) {
+	if rowIterator.inElement == "c" {
+		rowIterator.cellCol++
+		for _, attr := range xmlElement.Attr {
+			if attr.Name.Local == "r" {
+				col, row, err := CellNameToCoordinates(attr.Value)
+				if err != nil {
+					if !cols.ignoreInvalidCellRef {
+						rowIterator.err = err
+						return
+					}
+					continue
+				}
+				rowIterator.cellCol, rowIterator.cellRow = col, row
+			}
+		}
+		if rowIterator.cellRow < cols.startRow || (cols.endRow > 0 && rowIterator.cellRow > cols.endRow) {
+			return
+		}
+		blank := rowIterator.cellRow - max(cols.startRow-1, 0) - len(rowIterator.cells) - len(rowIterator.typedCells)
+		for i := 1; i < blank; i++ {
+			if rowIterator.withTypes {
+				rowIterator.typedCells = append(rowIterator.typedCells, TypedCell{})
+				continue
+			}
+			rowIterator.cells = append(rowIterator.cells, rowIterator.blankValue)
+			if rowIterator.withRaw {
+				rowIterator.rawCells = append(rowIterator.rawCells, "")
+			}
+		}
+		if rowIterator.cellCol == cols.curCol {
+			colCell := xlsxC{}
+			_ = decoder.DecodeElement(&colCell, xmlElement)
+			if rowIterator.withRaw {
+				val, _ := colCell.getValueFrom(cols.f, cols.sst, false)
+				raw, _ := colCell.getValueFrom(cols.f, cols.sst, true)
+				val, raw = cols.formulaCellValue(rowIterator, &colCell, val, false), cols.formulaCellValue(rowIterator, &colCell, raw, true)
+				if cols.transformer != nil {
+					if val, rowIterator.err = cols.transformCellValue(rowIterator, &colCell, val); rowIterator.err != nil {
+						return
+					}
+					if raw, rowIterator.err = cols.transformCellValue(rowIterator, &colCell, raw); rowIterator.err != nil {
+						return
+					}
+				}
+				rowIterator.cells, rowIterator.rawCells = append(rowIterator.cells, val), append(rowIterator.rawCells, raw)
+				return
+			}
+			val, _ := colCell.getValueFrom(cols.f, cols.sst, cols.rawCellValue)
+			val = cols.formulaCellValue(rowIterator, &colCell, val, cols.rawCellValue)
+			if cols.transformer != nil {
+				if val, rowIterator.err = cols.transformCellValue(rowIterator, &colCell, val); rowIterator.err != nil {
+					return
+				}
+			}
+			if rowIterator.withTypes {
+				rowIterator.typedCells = append(rowIterator.typedCells, TypedCell{Value: val, Type: colCell.getCellType(), StyleID: colCell.S})
+				return
+			}
+			if val == "" && colCell.F == nil && !colCell.hasStringValue() {
+				val = rowIterator.blankValue
+			}
+			rowIterator.cells = append(rowIterator.cells, val)
+		}
+	}
+}
+
+// formulaCellValue provides a function to get the value of the formula cell
+// in the current column by the CalcFormulaOnRead and FormulaTextOnRead
+// options. The error of calculating the formula will be kept in the iterator
+// instead of aborting the iteration.
+func (cols *Cols) formulaCellValue(rowIterator *rowXMLIterator, c *xlsxC, val string, raw bool) string {
+	if c.F == nil || !(cols.calcFormula || cols.formulaText) {
+		return val
+	}
+	cell, _ := CoordinatesToCellName(rowIterator.cellCol, rowIterator.cellRow)
+	if cols.sharedFormulas == nil {
+		cols.sharedFormulas = make(map[int]xlsxC)
+	}
+	val, err := cols.f.getFormulaCellValue(cols.sheet, cell, c, val, raw, cols.formulaText, cols.sharedFormulas)
+	if err != nil {
+		cols.err = err
+	}
+	return val
+}
+
+// transformCellValue provides a function to transform the value of the cell
+// in the current column by the CellValueTransformer option, the blank cells
+// will not be transformed.
+func (cols *Cols) transformCellValue(rowIterator *rowXMLIterator, c *xlsxC, val string) (string, error) {
+	if val == "" && c.F == nil && !c.hasStringValue() {
+		return val, nil
+	}
+	cell, _ := CoordinatesToCellName(rowIterator.cellCol, rowIterator.cellRow)
+	return cols.f.transformCellValue(cols.transformer, cols.sheet, cell, c, val)
+}
+
+// Cols returns a columns iterator, used for streaming reading data for a
+// worksheet with a large data. The positions of the cells in the worksheet
+// will be indexed on the first read of the column cells, and the cells of
+// each column will be decoded by the index without parsing the whole
+// worksheet again. The hidden columns will be skipped by the Next function if
+// the SkipHiddenCols option is enabled. This function is concurrency safe.
+// For example:
+//
+//	cols, err := f.Cols("Sheet1")
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	for cols.Next() {
+//	    col, err := cols.Rows()
+//	    if err != nil {
+//	        fmt.Println(err)
+//	    }
+//	    for _, rowCell := range col {
+//	        fmt.Print(rowCell, "\t")
+//	    }
+//	    fmt.Println()
+//	}
+func (f *File) Cols(sheet string, opts ...Options) (*Cols, error) {
+	return f.colsIterator(sheet, 0, 0, opts...)
+}
+
+// ColsRange returns a columns iterator bounded by given worksheet name, start
+// and end column name (both inclusive), the first call of the Next function
+// will yield the start column, and the cells out of the column range will not
+// be indexed. This is useful for reading a few columns on a very wide
+// worksheet. This function is concurrency safe. For example, read the
+// columns from H to Z on Sheet1:
+//
+//	cols, err := f.ColsRange("Sheet1", "H", "Z")
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	for cols.Next() {
+//	    col, err := cols.Rows()
+//	    if err != nil {
+//	        fmt.Println(err)
+//	    }
+//	    fmt.Println(col)
+//	}
+func (f *File) ColsRange(sheet, startCol, endCol string) (*Cols, error) {
+	start, end, err := f.parseColRange(startCol + ":" + endCol)
+	if err != nil {
+		return nil, err
+	}
+	return f.colsIterator(sheet, start, end)
+}
+
+// colsIterator provides a function to create the columns iterator by given
+// worksheet name, start and end column number, the columns iterator will be
+// not bounded if the start and end column number are 0.
+func (f *File) colsIterator(sheet string, startCol, endCol int, opts ...Options) (*Cols, error) {
+	name, err := f.flushColsSheetXML(sheet)
+	if err != nil {
+		return nil, err
+	}
+	return f.newCols(sheet, bytes.NewReader(f.readBytes(name)), startCol, endCol, opts...)
+}
+
+// flushColsSheetXML provides a function to check the worksheet name and save
+// the worksheet which has been loaded into the package before reading the
+// columns of the worksheet XML, and returns the path of the worksheet XML.
+func (f *File) flushColsSheetXML(sheet string) (string, error) {
+	if err := checkSheetName(sheet); err != nil {
+		return "", err
+	}
+	name, ok := f.getSheetXMLPath(sheet)
+	if !ok {
+		return name, ErrSheetNotExist{sheet}
+	}
+	f.flushWorksheet(name)
+	return name, nil
+}
+
+// newCols provides a function to create the columns iterator by given
+// worksheet name, the reader of the worksheet XML, start and end column
+// number, the worksheet XML will be read in a streaming pass for getting the
+// number of the columns and rows, and the rows hidden by the AutoFilter if
+// the SkipFilteredRows option is enabled.
+func (f *File) newCols(sheet string, r io.ReaderAt, startCol, endCol int, opts ...Options) (*Cols, error) {
+	var colIterator columnXMLIterator
+	callOpts := getCallOptions(opts...)
+	colIterator.cols.f, colIterator.cols.sheet = f, sheet
+	colIterator.cols.startCol, colIterator.cols.endCol = startCol, endCol
+	colIterator.cols.skipHiddenCols, colIterator.cols.skipFilteredRows = callOpts.SkipHiddenCols, callOpts.SkipFilteredRows
+	colIterator.cols.ignoreInvalidCellRef = callOpts.IgnoreInvalidCellRef
+	colIterator.cols.curCol, colIterator.cols.stashCol = max(startCol-1, 0), max(startCol-1, 0)
+	colIterator.cols.sheetReader = r
+	decoder := f.xmlNewDecoder(io.NewSectionReader(r, 0, math.MaxInt64))
+	for {
+		token, _ := decoder.Token()
+		if token == nil {
+			break
+		}
+		switch xmlElement := token.(type) {
+		case xml.StartElement:
+			columnXMLHandler(&colIterator, &xmlElement)
+			if colIterator.err != nil {
+				return &colIterator.cols, colIterator.err
+			}
+		case xml.EndElement:
+			if xmlElement.Name.Local == "sheetData" {
+				return &colIterator.cols, colIterator.cols.setFilteredRows(decoder)
+			}
+		}
+	}
+	return &colIterator.cols, nil
+}
+
+// setFilteredRows provides a function to get the rows hidden by the
+// AutoFilter from the hidden rows by reading the AutoFilter range after the
+// sheet data, if the SkipFilteredRows option is enabled.
+func (cols *Cols) setFilteredRows(decoder *xml.Decoder) error {
+	if !cols.skipFilteredRows || len(cols.hiddenRows) == 0 {
+		return nil
+	}
+	start, end, err := autoFilterRows(decoder)
+	for _, row := range cols.hiddenRows {
+		if start <= row && row <= end {
+			cols.filteredRows = append(cols.filteredRows, row)
+		}
+	}
+	cols.hiddenRows = nil
+	return err
+}
+
+// dropFilteredRows provides a function to remove the cells of the rows hidden
+// by the AutoFilter from the cells of the current column.
+func (cols *Cols) dropFilteredRows(rowIterator *rowXMLIterator) {
+	startRow := max(cols.startRow-1, 0)
+	for i := len(cols.filteredRows) - 1; i >= 0; i-- {
+		idx := cols.filteredRows[i] - startRow - 1
+		if idx < 0 {
+			break
+		}
+		if idx < len(rowIterator.cells) {
+			rowIterator.cells = append(rowIterator.cells[:idx], rowIterator.cells[idx+1:]...)
+		}
+		if idx < len(rowIterator.rawCells) {
+			rowIterator.rawCells = append(rowIterator.rawCells[:idx], rowIterator.rawCells[idx+1:]...)
+		}
+		if idx < len(rowIterator.typedCells) {
+			rowIterator.typedCells = append(rowIterator.typedCells[:idx], rowIterator.typedCells[idx+1:]...)
+		}
+	}
+}
+
+// ColExtent directly maps the populated extent of a column in the worksheet.
+// The FirstRow and LastRow specifies the row number of the first and last cell
+// of the column, and the CellCount specifies the number of cells in the
+// column.
+type ColExtent struct {
+	FirstRow  int
+	LastRow   int
+	CellCount int
+}
+
+// GetColsUsedRange provides a function to get the populated extent of each
+// column by given worksheet name, the result is keyed by the column name, and
+// the columns without any cell will be omitted. The worksheet will be read in
+// a single streaming pass by the cell references only without decoding the
+// cells value unless it has been loaded, and the cells with only a style are
+// also counted. This function is
+// concurrency safe. For example, get the populated extent of the columns on
+// Sheet1:
+//
+//	extents, err := f.GetColsUsedRange("Sheet1")
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	for col, extent := range extents {
+//	    fmt.Println(col, extent.FirstRow, extent.LastRow, extent.CellCount)
+//	}
+func (f *File) GetColsUsedRange(sheet string) (map[string]ColExtent, error) {
+	extents, err := f.getColExtents(sheet)
+	if err != nil {
+		return nil, err
+	}
+	results := make(map[string]ColExtent, len(extents))
+	for col, extent := range extents {
+		name, _ := ColumnNumberToName(col)
+		results[name] = extent
+	}
+	return results, err
+}
+
+// GetUsedRange provides a function to get the bounding range reference of
+// all cells in the worksheet by given worksheet name, it will return an empty
+// string if the worksheet has no cell. Unlike the GetSheetDimension function,
+// the used range is computed by the cells of the worksheet in a single
+// streaming pass instead of reading the dimension of the worksheet. This
+// function is concurrency safe. For example, get the used range of Sheet1:
+//
+//	ref, err := f.GetUsedRange("Sheet1")
+func (f *File) GetUsedRange(sheet string) (string, error) {
+	extents, err := f.getColExtents(sheet)
+	if err != nil || len(extents) == 0 {
+		return "", err
+	}
+	coordinates := []int{MaxColumns, TotalRows, 0, 0}
+	for col, extent := range extents {
+		coordinates[0], coordinates[2] = min(coordinates[0], col), max(coordinates[2], col)
+		coordinates[1], coordinates[3] = min(coordinates[1], extent.FirstRow), max(coordinates[3], extent.LastRow)
+	}
+	if coordinates[0] == coordinates[2] && coordinates[1] == coordinates[3] {
+		return CoordinatesToCellName(coordinates[0], coordinates[1])
+	}
+	return coordinatesToRangeRef(coordinates)
+}
+
+// ComputedColOptions directly maps the settings of adding a computed column
+// by the AddComputedCol function, exactly one of the FormulaTemplate and
+// ValueFunc should be specified. The FormulaTemplate specifies the formula of
+// the cells, and the {row} in the template will be replaced with the row
+// number of each cell. The ValueFunc specifies the function to compute the
+// value of each cell by the formatted values of the row keyed by the column
+// name, the destination column is not included. The HeaderRow specifies the
+// row number of the header row, the rows before and at the header row will be
+// skipped, and the Header will be written to the destination column of the
+// header row if it is not empty. The Overwrite specifies if replace the data
+// in the destination column.
+type ComputedColOptions struct {
+	FormulaTemplate string
+	ValueFunc       func(rowValues map[string]string) (interface{}, error)
+	HeaderRow       int
+	Header          string
+	Overwrite       bool
+}
+
+// AddComputedCol provides a function to add a column computed from the other
+// columns by given worksheet name, destination column name and options. The
+// rows of the computed column are the populated extent of the other columns
+// detected in the same way as the GetUsedRange function, excluding the header
+// rows. The formula will be set as a shared formula when the formula of each
+// row can be derived from the first one, otherwise set to each cell. The
+// ErrComputedColExists error will be returned if the destination column
+// already contains data after the header row unless the Overwrite option
+// enabled. For example, set the amount in the column D by the price in the
+// column B and the quantity in the column C with a header row on Sheet1:
+//
+//	err := f.AddComputedCol("Sheet1", "D", excelize.ComputedColOptions{
+//	    FormulaTemplate: "B{row}*C{row}",
+//	    HeaderRow:       1,
+//	    Header:          "Amount",
+//	})
+//
+// Compute the full name in the column C by the first name in the column A and
+// the last name in the column B:
+//
+//	err := f.AddComputedCol("Sheet1", "C", excelize.ComputedColOptions{
+//	    ValueFunc: func(rowValues map[string]string) (interface{}, error) {
+//	        return rowValues["A"] + " " + rowValues["B"], nil
+//	    },
+//	    HeaderRow: 1,
+//	    Header:    "Full Name",
+//	})
+func (f *File) AddComputedCol(sheet, destCol string, opts ComputedColOptions) error {
+	if (opts.FormulaTemplate == "") == (opts.ValueFunc == nil) {
+		return ErrComputedColOptions
+	}
+	if opts.HeaderRow < 0 || opts.HeaderRow >= TotalRows {
+		return newInvalidRowNumberError(opts.HeaderRow)
+	}
+	col, err := ColumnNameToNumber(destCol)
+	if err != nil {
+		return err
+	}
+	extents, err := f.getColExtents(sheet)
+	if err != nil {
+		return err
+	}
+	firstRow, lastRow := TotalRows, 0
+	for num, extent := range extents {
+		if num != col {
+			firstRow, lastRow = min(firstRow, extent.FirstRow), max(lastRow, extent.LastRow)
+		}
+	}
+	firstRow = max(firstRow, opts.HeaderRow+1)
+	f.mu.Lock()
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		f.mu.Unlock()
+		return err
+	}
+	f.mu.Unlock()
+	ws.mu.Lock()
+	if _, last := ws.getColExtent(col, opts.HeaderRow+1, func(c *xlsxC) bool { return true }); last > 0 {
+		if !opts.Overwrite {
+			ws.mu.Unlock()
+			return ErrComputedColExists
+		}
+		ws.clearColCells(col, opts.HeaderRow+1)
+	}
+	ws.mu.Unlock()
+	colName, _ := ColumnNumberToName(col)
+	if opts.HeaderRow > 0 && opts.Header != "" {
+		if err = f.SetCellStr(sheet, colName+strconv.Itoa(opts.HeaderRow), opts.Header); err != nil {
+			return err
+		}
+	}
+	if firstRow > lastRow {
+		return err
+	}
+	if opts.ValueFunc != nil {
+		return f.setComputedColValues(sheet, col, firstRow, lastRow, opts.ValueFunc)
+	}
+	return f.setComputedColFormulas(sheet, col, firstRow, lastRow, opts.FormulaTemplate)
+}
+
+// clearColCells provides a function to clear the value and formula of the
+// cells in the given column start from the given row number, and keep the
+// style of the cells.
+func (ws *xlsxWorksheet) clearColCells(col, fromRow int) {
+	for i := range ws.SheetData.Row {
+		row := &ws.SheetData.Row[i]
+		if row.R < fromRow {
+			continue
+		}
+		for j := range row.C {
+			c := &row.C[j]
+			if cellCol, _, err := CellNameToCoordinates(c.R); err == nil && cellCol == col {
+				ws.deleteSharedFormula(c)
+				*c = xlsxC{R: c.R, S: c.S}
+				break
+			}
+		}
+	}
+}
+
+// setComputedColFormulas provides a function to set the formula of the
+// computed column by given formula template, the formula will be set as a
+// shared formula if the formula of the second row equals to the formula of
+// the first row with the relative cell references shifted.
+func (f *File) setComputedColFormulas(sheet string, col, firstRow, lastRow int, template string) error {
+	formula := func(row int) string {
+		return strings.ReplaceAll(template, "{row}", strconv.Itoa(row))
+	}
+	cell, _ := CoordinatesToCellName(col, firstRow)
+	if firstRow < lastRow && shiftFormula(formula(firstRow), 0, 1) == shiftFormula(formula(firstRow+1), 0, 0) {
+		lastCell, _ := CoordinatesToCellName(col, lastRow)
+		formulaType, ref := STCellFormulaTypeShared, cell+":"+lastCell
+		return f.SetCellFormula(sheet, cell, formula(firstRow), FormulaOpts{Type: &formulaType, Ref: &ref})
+	}
+	for row := firstRow; row <= lastRow; row++ {
+		cell, _ = CoordinatesToCellName(col, row)
+		if err := f.SetCellFormula(sheet, cell, formula(row)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// setComputedColValues provides a function to set the value of the computed
+// column by the value computed from the formatted values of each row.
+func (f *File) setComputedColValues(sheet string, col, firstRow, lastRow int, fn func(rowValues map[string]string) (interface{}, error)) error {
+	rows, err := f.GetRows(sheet)
+	if err != nil {
+		return err
+	}
+	for row := firstRow; row <= lastRow; row++ {
+		rowValues := make(map[string]string)
+		if row <= len(rows) {
+			for idx, val := range rows[row-1] {
+				if idx+1 != col && val != "" {
+					name, _ := ColumnNumberToName(idx + 1)
+					rowValues[name] = val
+				}
+			}
+		}
+		val, err := fn(rowValues)
+		if err != nil {
+			return err
+		}
+		cell, _ := CoordinatesToCellName(col, row)
+		if err = f.SetCellValue(sheet, cell, val); err != nil {
+			return err
+		}
+	}
+	return err
+}
+
+// getColExtents provides a function to get the populated extent of each
+// column by given worksheet name, the result is keyed by the column number.
+// The cells of the loaded worksheet will be read from the memory, otherwise
+// the worksheet will be read by the XML tokens without decoding the elements,
+// and the row and column number of the cells without the reference will be
+// inferred from the previous row and cell.
+func (f *File) getColExtents(sheet string) (map[int]ColExtent, error) {
+	if err := checkSheetName(sheet); err != nil {
+		return nil, err
+	}
+	name, ok := f.getSheetXMLPath(sheet)
+	if !ok {
+		return nil, ErrSheetNotExist{sheet}
+	}
+	extents := make(map[int]ColExtent)
+	addCell := func(col, row int) {
+		extent, ok := extents[col]
+		if !ok {
+			extent.FirstRow = row
+		}
+		extent.FirstRow, extent.LastRow = min(extent.FirstRow, row), max(extent.LastRow, row)
+		extent.CellCount++
+		extents[col] = extent
+	}
+	if worksheet, ok := f.Sheet.Load(name); ok && worksheet != nil {
+		ws := worksheet.(*xlsxWorksheet)
+		ws.mu.Lock()
+		defer ws.mu.Unlock()
+		for _, row := range ws.SheetData.Row {
+			for _, c := range row.C {
+				if !c.hasValue() {
+					continue
+				}
+				col, cellRow, err := CellNameToCoordinates(c.R)
+				if err != nil {
+					return nil, err
+				}
+				addCell(col, cellRow)
+			}
+		}
+		return extents, nil
+	}
+	needClose, decoder, tempFile, err := f.xmlDecoder(name)
+	if needClose && err == nil {
+		defer func() {
+			_ = tempFile.Close()
+		}()
+	}
+	if err != nil {
+		return nil, err
+	}
+	var row, cellRow, cellCol int
+	for {
+		token, _ := decoder.Token()
+		if token == nil {
+			break
+		}
+		switch xmlElement := token.(type) {
+		case xml.StartElement:
+			if xmlElement.Name.Local == "row" {
+				row++
+				for _, attr := range xmlElement.Attr {
+					if attr.Name.Local == "r" {
+						if row, err = strconv.Atoi(attr.Value); err != nil {
+							return nil, err
+						}
+					}
+				}
+				cellCol = 0
+			}
+			if xmlElement.Name.Local == "c" {
+				cellRow, cellCol = row, cellCol+1
+				for _, attr := range xmlElement.Attr {
+					if attr.Name.Local == "r" {
+						if cellCol, cellRow, err = CellNameToCoordinates(attr.Value); err != nil {
+							return nil, err
+						}
+					}
+				}
+				addCell(cellCol, cellRow)
+			}
+		case xml.EndElement:
+			if xmlElement.Name.Local == "sheetData" {
+				return extents, err
+			}
+		}
+	}
+	return extents, err
+}
+
+// AppendColsOptions directly maps the settings of appending columns by the
+// AppendCols function. The StartRow specifies the row number of the first
+// cell of the appended columns, the default value is 1. The Headers specifies
+// the header cells written at the start row of each appended column, and the
+// values will be written from the next row if it is specified. The Widths and
+// Styles specifies the column width and style ID of each appended column, the
+// zero value will be skipped.
+type AppendColsOptions struct {
+	StartRow int
+	Headers  []string
+	Widths   []float64
+	Styles   []int
+}
+
+// AppendCols provides a function to append columns to the right of the
+// rightmost column with a value, formula or style by given worksheet name,
+// columns values and optional settings. Each slice of the values will be
+// written downward, and the nil values will be skipped. The cells are written
+// in a single pass by rows, so it's faster than setting the cells one by one.
+// The tables and auto filter ending at the previous rightmost column will be
+// extended to include the appended columns, and the header cells of the
+// appended table columns will be used as the names of the table columns. For
+// example, append the columns with headers to the right of the data on
+// Sheet1:
+//
+//	err := f.AppendCols("Sheet1", [][]interface{}{
+//	    {100, 200, 300},
+//	    {true, false, true},
+//	}, excelize.AppendColsOptions{
+//	    Headers: []string{"Total", "Checked"},
+//	    Widths:  []float64{12, 10},
+//	})
+func (f *File) AppendCols(sheet string, cols [][]interface{}, opts ...AppendColsOptions) error {
+	var options AppendColsOptions
+	for _, opt := range opts {
+		options = opt
+	}
+	if options.StartRow == 0 {
+		options.StartRow = 1
+	}
+	if err := checkRowNumber(options.StartRow); err != nil {
+		return err
+	}
+	num, height := max(len(cols), len(options.Headers)), 0
+	for _, col := range cols {
+		height = max(height, len(col))
+	}
+	valueRow := options.StartRow
+	if len(options.Headers) > 0 {
+		valueRow++
+	}
+	if num == 0 {
+		return nil
+	}
+	if valueRow+height-1 > TotalRows {
+		return ErrMaxRows
+	}
+	f.mu.Lock()
+	ws, err := f.workSheetReader(sheet)
+	f.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	ws.mu.Lock()
+	lastCol := ws.getLastCol()
+	ws.mu.Unlock()
+	if lastCol+num > MaxColumns {
+		return ErrColumnNumber
+	}
+	if err = f.setAppendedColsFormat(sheet, lastCol, options); err != nil {
+		return err
+	}
+	fallback, err := f.setAppendedCells(ws, lastCol, valueRow, valueRow+height-1, cols, options)
+	if err != nil {
+		return err
+	}
+	for _, c := range fallback {
+		if err = f.SetCellValue(sheet, c.cell, c.value); err != nil {
+			return err
+		}
+	}
+	if lastCol == 0 {
+		return err
+	}
+	if err = f.updateTables(ws, sheet, columns, func(coordinates []int) bool {
+		if coordinates[2] == lastCol {
+			coordinates[2] += num
+		}
+		return true
+	}); err != nil {
+		return err
+	}
+	return f.extendAutoFilter(ws, sheet, lastCol, num)
+}
+
+// getLastCol provides a function to get the column number of the rightmost
+// cell with a value, formula or style in the worksheet, it will return 0 if
+// the worksheet has no such cell.
+func (ws *xlsxWorksheet) getLastCol() int {
+	var lastCol int
+	for _, row := range ws.SheetData.Row {
+		for i := len(row.C) - 1; i >= 0; i-- {
+			if row.C[i].hasValue() {
+				col, _, err := CellNameToCoordinates(row.C[i].R)
+				if err != nil {
+					col = i + 1
+				}
+				lastCol = max(lastCol, col)
+				break
+			}
+		}
+	}
+	return lastCol
+}
+
+// setAppendedColsFormat provides a function to set the width and style of the
+// appended columns by given worksheet name, the column number of previous
+// rightmost column and the settings of appending columns.
+func (f *File) setAppendedColsFormat(sheet string, lastCol int, opts AppendColsOptions) error {
+	for i := 0; i < max(len(opts.Widths), len(opts.Styles)); i++ {
+		name, err := ColumnNumberToName(lastCol + i + 1)
+		if err != nil {
+			return err
+		}
+		if i < len(opts.Widths) && opts.Widths[i] != 0 {
+			if err = f.SetColWidth(sheet, name, name, opts.Widths[i]); err != nil {
+				return err
+			}
+		}
+		if i < len(opts.Styles) && opts.Styles[i] != 0 {
+			if err = f.SetColStyle(sheet, name, opts.Styles[i]); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// appendedCell defined the cell reference and value of the appended cell,
+// which should be set by the SetCellValue function.
+type appendedCell struct {
+	cell  string
+	value interface{}
+}
+
+// setAppendedCells provides a function to set the cells value of the
+// appended columns in a single pass by rows. The strings, booleans and
+// numbers will be set in place, and the other values will be returned with
+// the cell references to be set by the SetCellValue function. All values will
+// be returned if the cells change journal is enabled or the worksheet has
+// merged cells.
+func (f *File) setAppendedCells(ws *xlsxWorksheet, lastCol, valueRow, lastRow int, cols [][]interface{}, opts AppendColsOptions) ([]appendedCell, error) {
+	var fallback []appendedCell
+	num := max(len(cols), len(opts.Headers))
+	inPlace := f.journal == nil && (ws.MergeCells == nil || len(ws.MergeCells.Cells) == 0)
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	for row := opts.StartRow; row <= lastRow; row++ {
+		var prepared bool
+		for i := 0; i < num; i++ {
+			var value interface{}
+			if row < valueRow {
+				if i < len(opts.Headers) && opts.Headers[i] != "" {
+					value = opts.Headers[i]
+				}
+			} else if i < len(cols) && row-valueRow < len(cols[i]) {
+				value = cols[i][row-valueRow]
+			}
+			if value == nil {
+				continue
+			}
+			if !prepared {
+				ws.prepareSheetXML(lastCol+num, row)
+				prepared = true
+			}
+			col := lastCol + i + 1
+			c := &ws.SheetData.Row[row-1].C[col-1]
+			ok, err := f.setAppendedCell(c, value, inPlace)
+			if err != nil {
+				return fallback, err
+			}
+			if !ok {
+				fallback = append(fallback, appendedCell{cell: c.R, value: value})
+				continue
+			}
+			c.S = ws.prepareCellStyle(col, row, c.S)
+		}
+	}
+	return fallback, nil
+}
+
+// setAppendedCell provides a function to set the value of the appended cell
+// in place if the value is a string, boolean or number, it will return false
+// if the value should be set by the SetCellValue function.
+func (f *File) setAppendedCell(c *xlsxC, value interface{}, inPlace bool) (bool, error) {
+	if !inPlace {
+		return false, nil
+	}
+	var err error
+	switch v := value.(type) {
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		setCellIntFunc(c, v)
+	case float32:
+		if math.IsNaN(float64(v)) || math.IsInf(float64(v), 0) {
+			return false, err
+		}
+		c.setCellFloat(float64(v), -1, 32)
+	case float64:
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			return false, err
+		}
+		c.setCellFloat(v, -1, 64)
+	case string:
+		if utf8.RuneCountInString(v) > TotalCellChars {
+			return false, err
+		}
+		c.T, c.V, err = f.setCellString(v)
+	case bool:
+		c.T, c.V = setCellBool(v)
+	default:
+		return false, err
+	}
+	c.IS = nil
+	return true, err
+}
+
+// extendAutoFilter provides a function to extend the auto filter of the
+// worksheet which ending at the given column by the number of appended
+// columns, and update the filter database defined name of the worksheet.
+func (f *File) extendAutoFilter(ws *xlsxWorksheet, sheet string, lastCol, num int) error {
+	if ws.AutoFilter == nil {
+		return nil
+	}
+	coordinates, err := rangeRefToCoordinates(ws.AutoFilter.Ref)
+	if err != nil {
+		return err
+	}
+	_ = sortCoordinates(coordinates)
+	if coordinates[2] != lastCol {
+		return err
+	}
+	coordinates[2] += num
+	if ws.AutoFilter.Ref, err = coordinatesToRangeRef(coordinates); err != nil {
+		return err
+	}
+	ref, _ := coordinatesToRangeRef(coordinates, true)
+	return f.setFilterDatabase(sheet, ref)
+}
+
+// GetColVisible provides a function to get visible of a single column by given
+// worksheet name and column name. This function is concurrency safe. For
+// example, get visible state of column D in Sheet1:
+//
+//	visible, err := f.GetColVisible("Sheet1", "D")
+func (f *File) GetColVisible(sheet, col string) (bool, error) {
+	colNum, err := ColumnNameToNumber(col)
+	if err != nil {
+		return true, err
+	}
+	f.mu.Lock()
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		f.mu.Unlock()
+		return false, err
+	}
+	f.mu.Unlock()
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	if ws.Cols == nil {
+		return true, err
+	}
+	visible := true
+	for c := range ws.Cols.Col {
+		colData := &ws.Cols.Col[c]
+		if colData.Min <= colNum && colNum <= colData.Max {
+			visible = !colData.Hidden
+		}
+	}
+	return visible, err
+}
+
+// GetHiddenCols provides a function to get the names of all hidden columns in
+// ascending order by given worksheet name. This function is concurrency safe.
+// For example, get the hidden columns in Sheet1:
+//
+//	cols, err := f.GetHiddenCols("Sheet1")
+func (f *File) GetHiddenCols(sheet string) ([]string, error) {
+	f.mu.Lock()
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		f.mu.Unlock()
+		return nil, err
+	}
+	f.mu.Unlock()
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	var cols []string
+	if ws.Cols == nil {
+		return cols, err
+	}
+	hidden := make(map[int]bool)
+	for _, c := range ws.Cols.Col {
+		for col := max(c.Min, MinColumns); col <= min(c.Max, MaxColumns); col++ {
+			hidden[col] = c.Hidden
+		}
+	}
+	for col := MinColumns; col <= MaxColumns; col++ {
+		if hidden[col] {
+			name, _ := ColumnNumberToName(col)
+			cols = append(cols, name)
+		}
+	}
+	return cols, err
+}
+
+// SetColVisible provides a function to set visible columns by given worksheet
+// name, columns range and visibility. This function is concurrency safe.
+//
+// For example hide column D on Sheet1:
+//
+//	err := f.SetColVisible("Sheet1", "D", false)
+//
+// Hide the columns from D to F (included):
+//
+//	err := f.SetColVisible("Sheet1", "D:F", false)
+func (f *File) SetColVisible(sheet, columns string, visible bool) error {
+	minVal, maxVal, err := f.parseColRange(columns)
+	if err != nil {
+		return err
+	}
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return err
+	}
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	ws.setColVisible(minVal, maxVal, visible)
+	return nil
+}
+
+// setColVisible provides a function to set visible of the columns by given
+// column range and visibility.
+func (ws *xlsxWorksheet) setColVisible(minVal, maxVal int, visible bool) {
+	colData := xlsxCol{
+		Min:         minVal,
+		Max:         maxVal,
+		Width:       float64Ptr(defaultColWidth),
+		CustomWidth: true,
+	}
+	ws.updateCols(colData, func(c *xlsxCol) { c.Hidden = !visible })
+}
+
+// GetColsVisible provides a function to get visible of the columns by given
+// worksheet name and columns range in a single pass, returned as a map with
+// the column name as key. This function is concurrency safe. For example, get
+// visible state of the columns from A to KZ in Sheet1:
+//
+//	visible, err := f.GetColsVisible("Sheet1", "A:KZ")
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	fmt.Println(visible["D"])
+func (f *File) GetColsVisible(sheet, columns string) (map[string]bool, error) {
+	minVal, maxVal, err := f.parseColRange(columns)
+	if err != nil {
+		return nil, err
+	}
+	f.mu.Lock()
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		f.mu.Unlock()
+		return nil, err
+	}
+	f.mu.Unlock()
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	visible := make([]bool, maxVal-minVal+1)
+	for i := range visible {
+		visible[i] = true
+	}
+	if ws.Cols != nil {
+		for _, colData := range ws.Cols.Col {
+			for colNum := max(colData.Min, minVal); colNum <= min(colData.Max, maxVal); colNum++ {
+				visible[colNum-minVal] = !colData.Hidden
+			}
+		}
+	}
+	result := make(map[string]bool, len(visible))
+	for i, v := range visible {
+		colName, _ := ColumnNumberToName(minVal + i)
+		result[colName] = v
+	}
+	return result, err
+}
+
+// SetColsVisible provides a function to set visible of multiple columns by
+// given worksheet name and a map of the columns range and visibility in a
+// single pass. The columns ranges should not overlap with each other. This
+// function is concurrency safe. For example, hide column D and the columns
+// from F to H, and show column B in Sheet1:
+//
+//	err := f.SetColsVisible("Sheet1", map[string]bool{
+//	    "B": true, "D": false, "F:H": false,
+//	})
+func (f *File) SetColsVisible(sheet string, visibility map[string]bool) error {
+	type colVisibleRange struct {
+		min, max int
+		visible  bool
+	}
+	ranges := make([]colVisibleRange, 0, len(visibility))
+	for columns, visible := range visibility {
+		minVal, maxVal, err := f.parseColRange(columns)
+		if err != nil {
+			return err
+		}
+		ranges = append(ranges, colVisibleRange{minVal, maxVal, visible})
+	}
+	sort.Slice(ranges, func(i, j int) bool { return ranges[i].min < ranges[j].min })
+	for i := 1; i < len(ranges); i++ {
+		if ranges[i].min <= ranges[i-1].max {
+			return ErrParameterInvalid
+		}
+	}
+	f.mu.Lock()
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		f.mu.Unlock()
+		return err
+	}
+	f.mu.Unlock()
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	bounds := make([][2]int, len(ranges))
+	for i, r := range ranges {
+		bounds[i] = [2]int{r.min, r.max}
+	}
+	ws.updateColRanges(bounds, xlsxCol{Width: float64Ptr(defaultColWidth), CustomWidth: true}, func(c *xlsxCol, idx int) {
+		c.Hidden = !ranges[idx].visible
+	})
+	return err
+}
+
+// GetColOutlineLevel provides a function to get outline level of a single
+// column by given worksheet name and column name. For example, get outline
+// level of column D in Sheet1:
+//
+//	level, err := f.GetColOutlineLevel("Sheet1", "D")
+func (f *File) GetColOutlineLevel(sheet, col string) (uint8, error) {
+	level := uint8(0)
+	colNum, err := ColumnNameToNumber(col)
+	if err != nil {
+		return level, err
+	}
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return 0, err
+	}
+	if ws.Cols == nil {
+		return level, err
+	}
+	for c := range ws.Cols.Col {
+		colData := &ws.Cols.Col[c]
+		if colData.Min <= colNum && colNum <= colData.Max {
+			level = colData.OutlineLevel
+		}
+	}
+	return level, err
+}
+
+// ColPropsRange directly maps the settings of a range of columns in the
+// column definitions of the worksheet. The Min and Max specify the first and
+// last column number of the range, the Width specifies the column width in
+// characters and nil for the default width, the Style specifies the style ID
+// of the columns, and the OutlineLevel specifies the outline level of the
+// columns in the range 0-7.
+type ColPropsRange struct {
+	Min          int
+	Max          int
+	Width        *float64
+	Hidden       bool
+	Style        int
+	OutlineLevel uint8
+	BestFit      bool
+	Collapsed    bool
+	CustomWidth  bool
+	Phonetic     bool
+}
+
+// GetColsProps provides a function to get the column definitions of the
+// worksheet by given worksheet name in document order, the column ranges
+// will be returned as they are without being expanded to each column. This
+// function is concurrency safe. For example, copy the column definitions of
+// Sheet1 to Sheet2:
+//
+//	props, err := f.GetColsProps("Sheet1")
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	err = f.SetColsProps("Sheet2", props)
+func (f *File) GetColsProps(sheet string) ([]ColPropsRange, error) {
+	f.mu.Lock()
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		f.mu.Unlock()
+		return nil, err
+	}
+	f.mu.Unlock()
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	if ws.Cols == nil {
+		return nil, err
+	}
+	props := make([]ColPropsRange, 0, len(ws.Cols.Col))
+	for _, col := range ws.Cols.Col {
+		prop := ColPropsRange{
+			Min: col.Min, Max: col.Max, Hidden: col.Hidden, Style: col.Style,
+			OutlineLevel: col.OutlineLevel, BestFit: col.BestFit, Collapsed: col.Collapsed,
+			CustomWidth: col.CustomWidth, Phonetic: col.Phonetic,
+		}
+		if col.Width != nil {
+			prop.Width = float64Ptr(*col.Width)
+		}
+		props = append(props, prop)
+	}
+	return props, err
+}
+
+// SetColsProps provides a function to replace the column definitions of the
+// worksheet by given worksheet name and column ranges, the column definitions
+// will be removed if the column ranges are empty. The column ranges must not
+// overlap, and the style IDs must exist in the workbook. This function is
+// concurrency safe.
+func (f *File) SetColsProps(sheet string, props []ColPropsRange) error {
+	cols := make([]xlsxCol, 0, len(props))
+	for _, prop := range props {
+		if prop.Min < MinColumns || prop.Max > MaxColumns || prop.Min > prop.Max {
+			return ErrColumnNumber
+		}
+		if prop.OutlineLevel > 7 {
+			return ErrOutlineLevel
+		}
+		col := xlsxCol{
+			Min: prop.Min, Max: prop.Max, Hidden: prop.Hidden, Style: prop.Style,
+			OutlineLevel: prop.OutlineLevel, BestFit: prop.BestFit, Collapsed: prop.Collapsed,
+			CustomWidth: prop.CustomWidth, Phonetic: prop.Phonetic,
+		}
+		if prop.Width != nil {
+			if err := checkColWidth(*prop.Width); err != nil {
+				return err
+			}
+			col.Width = float64Ptr(*prop.Width)
+		}
+		cols = append(cols, col)
+	}
+	sorted := make([]xlsxCol, len(cols))
+	copy(sorted, cols)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Min < sorted[j].Min })
+	for i := 1; i < len(sorted); i++ {
+		if sorted[i].Min <= sorted[i-1].Max {
+			return ErrColumnRangeOverlap
+		}
+	}
+	f.mu.Lock()
+	s, err := f.stylesReader()
+	if err != nil {
+		f.mu.Unlock()
+		return err
+	}
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		f.mu.Unlock()
+		return err
+	}
+	f.mu.Unlock()
+	s.mu.Lock()
+	for _, col := range cols {
+		if col.Style < 0 || (col.Style > 0 && (s.CellXfs == nil || len(s.CellXfs.Xf) <= col.Style)) {
+			s.mu.Unlock()
+			return newInvalidStyleID(col.Style)
+		}
+	}
+	s.mu.Unlock()
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	if ws.Cols = nil; len(cols) > 0 {
+		ws.Cols = &xlsxCols{Col: cols}
+	}
+	return err
 }
 
 // parseColRange parse and convert column range with column name to the column number.
@@ -371,52 +2721,154 @@ func (f *File) parseColRange(columns string) (minVal, maxVal int, err error) {
 	return
 }
 
-// SetColOutlineLevel provides a function to set outline level of a single
-// column by given worksheet name and column name. The value of parameter
-// 'level' is 1-7. For example, set outline level of column D in Sheet1 to 2:
+// SetColOutlineLevel provides a function to set outline level of a single
+// column by given worksheet name and column name. The value of parameter
+// 'level' is 1-7. For example, set outline level of column D in Sheet1 to 2:
+//
+//	err := f.SetColOutlineLevel("Sheet1", "D", 2)
+func (f *File) SetColOutlineLevel(sheet, col string, level uint8) error {
+	if level > 7 || level < 1 {
+		return ErrOutlineLevel
+	}
+	colNum, err := ColumnNameToNumber(col)
+	if err != nil {
+		return err
+	}
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return err
+	}
+	ws.updateCols(xlsxCol{Min: colNum, Max: colNum, CustomWidth: true}, func(c *xlsxCol) {
+		c.OutlineLevel = level
+	})
+	return err
+}
+
+// GroupCols provides a function to group the columns by given worksheet name,
+// start and end column name and group options. The outline level of each
+// column in the range will be increased by one, nesting groups up to the
+// maximum outline level 7. The summary column is the adjacent column on the
+// right of the group by default, set SummaryLeft to place it on the left, and
+// set Collapsed to hide the columns of the group and mark the summary column
+// as collapsed. This function is concurrency safe. For example, group the
+// columns from B to D in Sheet1 with the summary column E in collapsed state:
+//
+//	err := f.GroupCols("Sheet1", "B", "D", excelize.GroupOptions{Collapsed: true})
+func (f *File) GroupCols(sheet, startCol, endCol string, opts GroupOptions) error {
+	minVal, maxVal, err := f.parseColRange(startCol + ":" + endCol)
+	if err != nil {
+		return err
+	}
+	f.mu.Lock()
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		f.mu.Unlock()
+		return err
+	}
+	f.mu.Unlock()
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	if ws.Cols != nil {
+		for _, c := range ws.Cols.Col {
+			if c.Min <= maxVal && c.Max >= minVal && c.OutlineLevel >= 7 {
+				return ErrOutlineLevel
+			}
+		}
+	}
+	summary := maxVal + 1
+	if opts.SummaryLeft {
+		summary = minVal - 1
+	}
+	cols := ws.flatColRange(minVal, maxVal, summary)
+	for colNum := minVal; colNum <= maxVal; colNum++ {
+		cols[colNum].OutlineLevel++
+		if opts.Collapsed {
+			cols[colNum].Hidden = true
+		}
+	}
+	if c, ok := cols[summary]; ok && opts.Collapsed {
+		c.Collapsed = true
+	}
+	ws.setSheetOutlineProps(&SheetPropsOptions{OutlineSummaryRight: boolPtr(!opts.SummaryLeft)})
+	return err
+}
+
+// UngroupCols provides a function to ungroup the columns by given worksheet
+// name, start and end column name. The outline level of each grouped column
+// in the range will be decreased by one. If the group was collapsed, the
+// columns of the group will be shown and the collapsed state of the summary
+// column will be cleared. This function is concurrency safe. For example,
+// ungroup the columns from B to D in Sheet1:
 //
-//	err := f.SetColOutlineLevel("Sheet1", "D", 2)
-func (f *File) SetColOutlineLevel(sheet, col string, level uint8) error {
-	if level > 7 || level < 1 {
-		return ErrOutlineLevel
-	}
-	colNum, err := ColumnNameToNumber(col)
+//	err := f.UngroupCols("Sheet1", "B", "D")
+func (f *File) UngroupCols(sheet, startCol, endCol string) error {
+	minVal, maxVal, err := f.parseColRange(startCol + ":" + endCol)
 	if err != nil {
 		return err
 	}
-	colData := xlsxCol{
-		Min:          colNum,
-		Max:          colNum,
-		OutlineLevel: level,
-		CustomWidth:  true,
-	}
+	f.mu.Lock()
 	ws, err := f.workSheetReader(sheet)
 	if err != nil {
+		f.mu.Unlock()
 		return err
 	}
+	f.mu.Unlock()
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
 	if ws.Cols == nil {
-		cols := xlsxCols{}
-		cols.Col = append(cols.Col, colData)
-		ws.Cols = &cols
 		return err
 	}
+	summary := maxVal + 1
+	if ws.SheetPr != nil && ws.SheetPr.OutlinePr != nil &&
+		ws.SheetPr.OutlinePr.SummaryRight != nil && !*ws.SheetPr.OutlinePr.SummaryRight {
+		summary = minVal - 1
+	}
+	cols := ws.flatColRange(minVal, maxVal, summary)
+	collapsed := false
+	if c, ok := cols[summary]; ok && c.Collapsed {
+		c.Collapsed, collapsed = false, true
+	}
+	for colNum := minVal; colNum <= maxVal; colNum++ {
+		if cols[colNum].OutlineLevel > 0 {
+			cols[colNum].OutlineLevel--
+		}
+		if collapsed {
+			cols[colNum].Hidden = false
+		}
+	}
+	return err
+}
+
+// flatColRange provides a function to flatten the column definitions of the
+// worksheet for the given columns range and the summary column, and returns
+// the flattened column definitions of them by column number. The summary
+// column out of the valid columns range will be ignored.
+func (ws *xlsxWorksheet) flatColRange(minVal, maxVal, summary int) map[int]*xlsxCol {
+	if ws.Cols == nil {
+		ws.Cols = &xlsxCols{}
+	}
+	colData := xlsxCol{Min: minVal, Max: maxVal}
+	if summary >= MinColumns && summary <= MaxColumns {
+		colData.Min, colData.Max = min(minVal, summary), max(maxVal, summary)
+	}
 	ws.Cols.Col = flatCols(colData, ws.Cols.Col, func(fc, c xlsxCol) xlsxCol {
-		fc.BestFit = c.BestFit
-		fc.Collapsed = c.Collapsed
-		fc.CustomWidth = c.CustomWidth
-		fc.Hidden = c.Hidden
-		fc.Phonetic = c.Phonetic
-		fc.Style = c.Style
-		fc.Width = c.Width
-		return fc
+		c.Min, c.Max = fc.Min, fc.Max
+		return c
 	})
-	return err
+	cols := make(map[int]*xlsxCol, colData.Max-colData.Min+1)
+	for i := range ws.Cols.Col {
+		if c := &ws.Cols.Col[i]; c.Min >= colData.Min && c.Max <= colData.Max {
+			cols[c.Min] = c
+		}
+	}
+	return cols
 }
 
 // SetColStyle provides a function to set style of columns by given worksheet
 // name, columns range and style ID. This function is concurrency safe. Note
 // that this will overwrite the existing styles for the columns, it won't
-// append or merge style with existing styles.
+// append or merge style with existing styles, use the MergeColStyle function
+// to combine the column style with the existing cell styles.
 //
 // For example set style of column H on Sheet1:
 //
@@ -448,6 +2900,14 @@ func (f *File) SetColStyle(sheet, columns string, styleID int) error {
 		return newInvalidStyleID(styleID)
 	}
 	s.mu.Unlock()
+	return f.applyColStyle(sheet, ws, minVal, maxVal, styleID)
+}
+
+// applyColStyle provides a function to set the style of the columns and the
+// cells in the columns by given worksheet name, worksheet, columns range and
+// style ID.
+func (f *File) applyColStyle(sheet string, ws *xlsxWorksheet, minVal, maxVal, styleID int) error {
+	var err error
 	ws.mu.Lock()
 	ws.setColStyle(minVal, maxVal, styleID)
 	ws.mu.Unlock()
@@ -461,44 +2921,515 @@ func (f *File) SetColStyle(sheet, columns string, styleID int) error {
 	return err
 }
 
+// SetColStyleAll provides a function to set style of columns on multiple
+// worksheets by given worksheet names, columns range and style ID, all
+// worksheets will be used if no worksheet specified. The style ID will be
+// validated once, and the columns of each worksheet will be set like the
+// SetColStyle function. The errors of each worksheet will be joined instead
+// of stopping at the first failure. This function is concurrency safe. For
+// example, set style of columns A:C on all worksheets:
+//
+//	err = f.SetColStyleAll(nil, "A:C", style)
+func (f *File) SetColStyleAll(sheets []string, columns string, styleID int) error {
+	minVal, maxVal, err := f.parseColRange(columns)
+	if err != nil {
+		return err
+	}
+	s, err := f.stylesReader()
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	if styleID < 0 || s.CellXfs == nil || len(s.CellXfs.Xf) <= styleID {
+		s.mu.Unlock()
+		return newInvalidStyleID(styleID)
+	}
+	s.mu.Unlock()
+	return f.forEachWorksheet(sheets, func(sheet string, ws *xlsxWorksheet) error {
+		return f.applyColStyle(sheet, ws, minVal, maxVal, styleID)
+	})
+}
+
+// MergeColStyle provides a function to set style of columns by given worksheet
+// name, columns range and style ID, and combine it with the existing styles
+// of the cells in the columns. This function is concurrency safe. Unlike the
+// SetColStyle function, only the attribute groups (number format, font, fill,
+// border, alignment and protection) explicitly set in the given style will
+// override the corresponding groups of each existing cell style, and the
+// cells without an explicit style will get the column style. The merged
+// styles will be reused for the cells with the same existing style.
+//
+// For example, apply a date number format to column D on Sheet1 and keep the
+// bold font of the header cell D1:
+//
+//	style, err := f.NewStyle(&excelize.Style{NumFmt: 14})
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	err = f.MergeColStyle("Sheet1", "D", style)
+func (f *File) MergeColStyle(sheet, columns string, styleID int) error {
+	minVal, maxVal, err := f.parseColRange(columns)
+	if err != nil {
+		return err
+	}
+	f.mu.Lock()
+	s, err := f.stylesReader()
+	if err != nil {
+		f.mu.Unlock()
+		return err
+	}
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		f.mu.Unlock()
+		return err
+	}
+	f.mu.Unlock()
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if styleID < 0 || s.CellXfs == nil || len(s.CellXfs.Xf) <= styleID {
+		return newInvalidStyleID(styleID)
+	}
+	merged := map[int]int{0: styleID, styleID: styleID}
+	mergeStyle := func(baseID int) (int, error) {
+		if ID, ok := merged[baseID]; ok {
+			return ID, nil
+		}
+		if baseID < 0 || len(s.CellXfs.Xf) <= baseID {
+			return styleID, nil
+		}
+		ID, err := s.mergeCellXfs(baseID, styleID)
+		merged[baseID] = ID
+		return ID, err
+	}
+	colStyles := make([]int, 0, maxVal-minVal+1)
+	for col := minVal; col <= maxVal; col++ {
+		var baseID int
+		if ws.Cols != nil {
+			for _, c := range ws.Cols.Col {
+				if c.Min <= col && col <= c.Max {
+					baseID = c.Style
+				}
+			}
+		}
+		ID, err := mergeStyle(baseID)
+		if err != nil {
+			return err
+		}
+		colStyles = append(colStyles, ID)
+	}
+	for start, idx := minVal, 1; idx <= len(colStyles); idx++ {
+		if idx == len(colStyles) || colStyles[idx] != colStyles[idx-1] {
+			ws.setColStyle(start, minVal+idx-1, colStyles[idx-1])
+			start = minVal + idx
+		}
+	}
+	rows := len(ws.SheetData.Row)
+	if rows == 0 {
+		return err
+	}
+	ws.prepareSheetXML(maxVal, rows)
+	ws.makeContiguousColumns(1, rows, maxVal)
+	for r := 0; r < rows; r++ {
+		for col := minVal; col <= maxVal; col++ {
+			c := &ws.SheetData.Row[r].C[col-1]
+			if c.S, err = mergeStyle(c.S); err != nil {
+				return err
+			}
+		}
+	}
+	return err
+}
+
+// SetColNumFmt provides a function to set the number format of columns by
+// given worksheet name, columns range and number format code. This function
+// is concurrency safe. The number format will be combined with the existing
+// styles of the columns and cells like the MergeColStyle function, and the
+// cells set later in the columns by the SetCellValue, SetSheetRow and
+// StreamWriter.SetRow functions will inherit it unless an explicit style was
+// specified for the cell or the row. For example, set the number format of
+// columns D:E on Sheet1:
+//
+//	err := f.SetColNumFmt("Sheet1", "D:E", "yyyy-mm-dd")
+func (f *File) SetColNumFmt(sheet, columns, numFmtCode string) error {
+	if _, _, err := f.parseColRange(columns); err != nil {
+		return err
+	}
+	styleID, err := f.NewStyle(&Style{CustomNumFmt: &numFmtCode})
+	if err != nil {
+		return err
+	}
+	return f.MergeColStyle(sheet, columns, styleID)
+}
+
+// SetColLocked provides a function to lock or unlock the cells of columns by
+// given worksheet name, columns range and locked state. This function is
+// concurrency safe. Only the protection settings of the existing styles of
+// the columns and cells will be changed, the number formats, fonts, fills,
+// borders and alignments will be kept, and the cells set later in the columns
+// will inherit the column style. The locked state takes effect after the
+// worksheet is protected. For example, leave the columns B:C editable on the
+// protected Sheet1:
+//
+//	if err := f.SetColLocked("Sheet1", "B:C", false); err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	err := f.ProtectSheet("Sheet1", &excelize.SheetProtectionOptions{})
+func (f *File) SetColLocked(sheet, columns string, locked bool) error {
+	return f.setColProtection(sheet, columns, func(p *xlsxProtection) {
+		p.Locked = boolPtr(locked)
+	})
+}
+
+// SetColFormulaHidden provides a function to hide or show the formulas of the
+// cells of columns in the formula bar by given worksheet name, columns range
+// and hidden state. This function is concurrency safe. Like the SetColLocked
+// function, only the protection settings of the existing styles will be
+// changed, and the hidden state takes effect after the worksheet is
+// protected. For example, hide the formulas in column D on Sheet1:
+//
+//	err := f.SetColFormulaHidden("Sheet1", "D", true)
+func (f *File) SetColFormulaHidden(sheet, columns string, hidden bool) error {
+	return f.setColProtection(sheet, columns, func(p *xlsxProtection) {
+		p.Hidden = boolPtr(hidden)
+	})
+}
+
+// setColProtection provides a function to modify the protection settings of
+// the column styles and the existing cell styles in the columns by given
+// worksheet name, columns range and the function to modify the protection
+// settings. The derived styles will be reused for the same base style.
+func (f *File) setColProtection(sheet, columns string, fn func(p *xlsxProtection)) error {
+	minVal, maxVal, err := f.parseColRange(columns)
+	if err != nil {
+		return err
+	}
+	f.mu.Lock()
+	s, err := f.stylesReader()
+	if err != nil {
+		f.mu.Unlock()
+		return err
+	}
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		f.mu.Unlock()
+		return err
+	}
+	f.mu.Unlock()
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.CellXfs == nil || len(s.CellXfs.Xf) == 0 {
+		return newInvalidStyleID(0)
+	}
+	derived := map[int]int{}
+	deriveStyle := func(baseID int) (int, error) {
+		if ID, ok := derived[baseID]; ok {
+			return ID, nil
+		}
+		ID, err := s.setCellXfsProtection(baseID, fn)
+		derived[baseID] = ID
+		return ID, err
+	}
+	colStyles := make([]int, 0, maxVal-minVal+1)
+	for col := minVal; col <= maxVal; col++ {
+		var baseID int
+		if ws.Cols != nil {
+			for _, c := range ws.Cols.Col {
+				if c.Min <= col && col <= c.Max {
+					baseID = c.Style
+				}
+			}
+		}
+		ID, err := deriveStyle(baseID)
+		if err != nil {
+			return err
+		}
+		colStyles = append(colStyles, ID)
+	}
+	for start, idx := minVal, 1; idx <= len(colStyles); idx++ {
+		if idx == len(colStyles) || colStyles[idx] != colStyles[idx-1] {
+			ws.setColStyle(start, minVal+idx-1, colStyles[idx-1])
+			start = minVal + idx
+		}
+	}
+	for r := range ws.SheetData.Row {
+		for i := range ws.SheetData.Row[r].C {
+			c := &ws.SheetData.Row[r].C[i]
+			col, _, err := CellNameToCoordinates(c.R)
+			if err != nil {
+				return err
+			}
+			if col < minVal || col > maxVal {
+				continue
+			}
+			if c.S, err = deriveStyle(c.S); err != nil {
+				return err
+			}
+		}
+	}
+	return err
+}
+
 // setColStyle provides a function to set the style of a single column or
 // multiple columns.
 func (ws *xlsxWorksheet) setColStyle(minVal, maxVal, styleID int) {
-	if ws.Cols == nil {
-		ws.Cols = &xlsxCols{}
+	width := defaultColWidth
+	if ws.SheetFormatPr != nil && ws.SheetFormatPr.DefaultColWidth > 0 {
+		width = ws.SheetFormatPr.DefaultColWidth
+	}
+	ws.updateCols(xlsxCol{Min: minVal, Max: maxVal, Width: float64Ptr(width)}, func(c *xlsxCol) {
+		c.Style = styleID
+	})
+}
+
+// SetColWidth provides a function to set the width of a single column or
+// multiple columns. The width 0 will hide the columns like the spreadsheet
+// applications, and setting a positive width on the zero-width hidden columns
+// will unhide them. This function is concurrency safe. For example:
+//
+//	err := f.SetColWidth("Sheet1", "A", "H", 20)
+func (f *File) SetColWidth(sheet, startCol, endCol string, width float64) error {
+	minVal, maxVal, err := f.parseColRange(startCol + ":" + endCol)
+	if err != nil {
+		return err
+	}
+	if err = checkColWidth(width); err != nil {
+		return err
+	}
+	f.mu.Lock()
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		f.mu.Unlock()
+		return err
+	}
+	f.mu.Unlock()
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	ws.setColWidth(minVal, maxVal, width)
+	return err
+}
+
+// SetColWidthAll provides a function to set the width of a single column or
+// multiple columns on multiple worksheets by given worksheet names, columns
+// range and width, all worksheets will be used if no worksheet specified. The
+// width will be validated once, and the columns of each worksheet will be set
+// like the SetColWidth function. The errors of each worksheet will be joined
+// instead of stopping at the first failure. This function is concurrency
+// safe. For example, set the width of columns A to H on Sheet1 and Sheet2:
+//
+//	err := f.SetColWidthAll([]string{"Sheet1", "Sheet2"}, "A", "H", 20)
+func (f *File) SetColWidthAll(sheets []string, startCol, endCol string, width float64) error {
+	minVal, maxVal, err := f.parseColRange(startCol + ":" + endCol)
+	if err != nil {
+		return err
+	}
+	if err = checkColWidth(width); err != nil {
+		return err
+	}
+	return f.forEachWorksheet(sheets, func(sheet string, ws *xlsxWorksheet) error {
+		ws.mu.Lock()
+		defer ws.mu.Unlock()
+		ws.setColWidth(minVal, maxVal, width)
+		return nil
+	})
+}
+
+// setColWidth provides a function to set the width of a single column or
+// multiple columns, the columns with width 0 will be hidden.
+func (ws *xlsxWorksheet) setColWidth(minVal, maxVal int, width float64) {
+	ws.updateCols(xlsxCol{Min: minVal, Max: maxVal}, func(c *xlsxCol) {
+		if width == 0 {
+			c.Hidden = true
+		} else if c.Hidden && c.Width != nil && *c.Width == 0 {
+			c.Hidden = false
+		}
+		c.Width, c.CustomWidth = float64Ptr(width), true
+	})
+}
+
+// checkColWidth provides a function to validate the column width, the
+// negative, NaN and infinite width is invalid.
+func checkColWidth(width float64) error {
+	if width < 0 || math.IsNaN(width) || math.IsInf(width, 0) {
+		return ErrColumnWidthValue
+	}
+	if width > MaxColumnWidth {
+		return ErrColumnWidth
+	}
+	return nil
+}
+
+// AutoFitColWidth provides a function to set the width of a single column or
+// multiple columns to fit the cell contents. The cells are read by the
+// columns iterator, and the width is measured by the formatted cell values
+// with the font size of the cells, the East Asian wide characters will be
+// counted as two characters, and each indent level of the cell alignment will
+// be counted as three characters. The columns without any content will be
+// skipped. The registered font metrics by the RegisterFontMetrics function
+// will be used for measuring the cells with the font, and the maximum digit
+// width of the default font metrics will be used for the column width. For
+// example, auto fit the width of columns A to H on Sheet1, with a width
+// between 8 and 50 characters:
+//
+//	err := f.AutoFitColWidth("Sheet1", "A", "H", excelize.AutoFitOptions{
+//	    MinWidth:     8,
+//	    MaxWidth:     50,
+//	    ConsiderBold: true,
+//	})
+func (f *File) AutoFitColWidth(sheet, startCol, endCol string, opts ...AutoFitOptions) error {
+	minVal, maxVal, err := f.parseColRange(startCol + ":" + endCol)
+	if err != nil {
+		return err
+	}
+	var options AutoFitOptions
+	for _, opt := range opts {
+		options = opt
+	}
+	if options.MaxWidth == 0 || options.MaxWidth > MaxColumnWidth {
+		options.MaxWidth = MaxColumnWidth
+	}
+	if options.MinWidth < 0 || options.MinWidth > options.MaxWidth {
+		return ErrParameterInvalid
+	}
+	cols, err := f.Cols(sheet)
+	if err != nil {
+		return err
+	}
+	defaultStyle, err := f.GetStyle(0)
+	if err != nil {
+		return err
+	}
+	defaultFontSize, defaultFontFamily := 11.0, ""
+	if defaultStyle.Font != nil && defaultStyle.Font.Size > 0 {
+		defaultFontSize = defaultStyle.Font.Size
+	}
+	if defaultStyle.Font != nil {
+		defaultFontFamily = defaultStyle.Font.Family
+	}
+	maxDigitWidth, err := f.getMaxDigitWidth()
+	if err != nil {
+		return err
+	}
+	fontScales, indents := map[int]float64{}, map[int]float64{}
+	fontMetrics := map[int]*FontMetrics{}
+	widths := map[int]float64{}
+	for cols.Next() && cols.curCol <= maxVal {
+		if cols.curCol < minVal {
+			continue
+		}
+		cells, err := cols.RowsWithTypes()
+		if err != nil {
+			return err
+		}
+		for _, cell := range cells {
+			if cell.Value == "" {
+				continue
+			}
+			scale, ok := fontScales[cell.StyleID]
+			if !ok {
+				style, err := f.GetStyle(cell.StyleID)
+				if err != nil {
+					return err
+				}
+				fontFamily, fontSize, bold := defaultFontFamily, defaultFontSize, 1.0
+				if style.Font != nil {
+					if style.Font.Size > 0 {
+						fontSize = style.Font.Size
+					}
+					if style.Font.Family != "" {
+						fontFamily = style.Font.Family
+					}
+					if options.ConsiderBold && style.Font.Bold {
+						bold = 1.1
+					}
+				}
+				scale = fontSize / defaultFontSize * bold
+				if metrics, ok := f.getFontMetrics(fontFamily); ok {
+					fontMetrics[cell.StyleID] = &metrics
+					scale = fontSize / 11 / maxDigitWidth * bold
+				}
+				if style.Alignment != nil {
+					indents[cell.StyleID] = float64(style.Alignment.Indent * 3)
+				}
+				fontScales[cell.StyleID] = scale
+			}
+			textWidth := getTextWidth(cell.Value)
+			if metrics := fontMetrics[cell.StyleID]; metrics != nil {
+				textWidth = metrics.getTextWidth(cell.Value)
+			}
+			if width := textWidth*scale + indents[cell.StyleID]; width > widths[cols.curCol] {
+				widths[cols.curCol] = width
+			}
+		}
+	}
+	f.mu.Lock()
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		f.mu.Unlock()
+		return err
+	}
+	f.mu.Unlock()
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	for col, chars := range widths {
+		width := math.Trunc((chars*maxDigitWidth+5)/maxDigitWidth*256) / 256
+		ws.setColWidth(col, col, math.Min(math.Max(width, options.MinWidth), options.MaxWidth))
 	}
-	width := defaultColWidth
-	if ws.SheetFormatPr != nil && ws.SheetFormatPr.DefaultColWidth > 0 {
-		width = ws.SheetFormatPr.DefaultColWidth
+	return err
+}
+
+// getTextWidth provides a function to get the width of the text in characters,
+// the width of the longest line will be returned for the multi-line text, and
+// the East Asian wide characters will be counted as two characters.
+func getTextWidth(text string) float64 {
+	var maxWidth float64
+	for _, line := range strings.Split(text, "\n") {
+		var width float64
+		for _, r := range line {
+			if unicode.In(r, unicode.Han, unicode.Hangul, unicode.Hiragana, unicode.Katakana) ||
+				(r >= 0xFF01 && r <= 0xFF60) || (r >= 0xFFE0 && r <= 0xFFE6) {
+				width += 2
+				continue
+			}
+			width++
+		}
+		maxWidth = math.Max(maxWidth, width)
 	}
-	ws.Cols.Col = flatCols(xlsxCol{
-		Min:   minVal,
-		Max:   maxVal,
-		Width: float64Ptr(width),
-		Style: styleID,
-	}, ws.Cols.Col, func(fc, c xlsxCol) xlsxCol {
-		fc.BestFit = c.BestFit
-		fc.Collapsed = c.Collapsed
-		fc.CustomWidth = c.CustomWidth
-		fc.Hidden = c.Hidden
-		fc.OutlineLevel = c.OutlineLevel
-		fc.Phonetic = c.Phonetic
-		fc.Width = c.Width
-		return fc
-	})
+	return maxWidth
 }
 
-// SetColWidth provides a function to set the width of a single column or
-// multiple columns. This function is concurrency safe. For example:
+// SetColWidths provides a function to set the width of multiple columns or
+// column ranges in a single pass by given worksheet name and a map of the
+// column name or column range and the width. The ranges should not overlap
+// each other and the width should not be negative, and the adjacent columns
+// with identical settings will be coalesced into ranges. The settings of the
+// later column definition take precedence if the existing column definitions
+// of the worksheet overlap. This function is concurrency safe. For example, set
+// the width of column A, and columns C to F on Sheet1:
 //
-//	err := f.SetColWidth("Sheet1", "A", "H", 20)
-func (f *File) SetColWidth(sheet, startCol, endCol string, width float64) error {
-	minVal, maxVal, err := f.parseColRange(startCol + ":" + endCol)
-	if err != nil {
-		return err
+//	err := f.SetColWidths("Sheet1", map[string]float64{"A": 12, "C:F": 20})
+func (f *File) SetColWidths(sheet string, widths map[string]float64) error {
+	ranges := make([]colWidthRange, 0, len(widths))
+	for columns, width := range widths {
+		minVal, maxVal, err := f.parseColRange(columns)
+		if err != nil {
+			return err
+		}
+		if err = checkColWidth(width); err != nil {
+			return err
+		}
+		ranges = append(ranges, colWidthRange{minVal, maxVal, width})
 	}
-	if width > MaxColumnWidth {
-		return ErrColumnWidth
+	sort.Slice(ranges, func(i, j int) bool { return ranges[i].min < ranges[j].min })
+	for i := 1; i < len(ranges); i++ {
+		if ranges[i].min <= ranges[i-1].max {
+			return ErrParameterInvalid
+		}
 	}
 	f.mu.Lock()
 	ws, err := f.workSheetReader(sheet)
@@ -509,34 +3440,119 @@ func (f *File) SetColWidth(sheet, startCol, endCol string, width float64) error
 	f.mu.Unlock()
 	ws.mu.Lock()
 	defer ws.mu.Unlock()
-	ws.setColWidth(minVal, maxVal, width)
+	bounds := make([][2]int, len(ranges))
+	for i, r := range ranges {
+		bounds[i] = [2]int{r.min, r.max}
+	}
+	ws.updateColRanges(bounds, xlsxCol{}, func(c *xlsxCol, idx int) {
+		c.Width, c.CustomWidth = float64Ptr(ranges[idx].width), true
+	})
 	return err
 }
 
-// setColWidth provides a function to set the width of a single column or
-// multiple columns.
-func (ws *xlsxWorksheet) setColWidth(minVal, maxVal int, width float64) {
-	col := xlsxCol{
-		Min:         minVal,
-		Max:         maxVal,
-		Width:       float64Ptr(width),
-		CustomWidth: true,
+// colWidthRange directly maps the width of a column range.
+type colWidthRange struct {
+	min, max int
+	width    float64
+}
+
+// updateColRanges provides a function to update the settings of the sorted
+// and non-overlapping column ranges in a single pass by given initial settings
+// of the columns which have not been defined in the worksheet, and the update
+// function which will be called with the index of the range for each part of
+// the columns in the range. The existing column ranges are split at the
+// boundaries of the given ranges instead of flattening into single columns,
+// and the adjacent columns with identical settings are coalesced. The later
+// one of the overlapping existing column ranges takes precedence.
+func (ws *xlsxWorksheet) updateColRanges(ranges [][2]int, colData xlsxCol, update func(c *xlsxCol, idx int)) {
+	var existing []xlsxCol
+	if ws.Cols != nil {
+		existing = ws.Cols.Col
+	}
+	var bounds []int
+	for _, c := range existing {
+		bounds = append(bounds, c.Min, c.Max+1)
+	}
+	for _, r := range ranges {
+		bounds = append(bounds, r[0], r[1]+1)
+	}
+	sort.Ints(bounds)
+	var cols []xlsxCol
+	for i, r := 0, 0; i < len(bounds)-1; i++ {
+		start, end := bounds[i], bounds[i+1]-1
+		if end < start {
+			continue
+		}
+		for r < len(ranges) && ranges[r][1] < start {
+			r++
+		}
+		c := -1
+		for idx := len(existing) - 1; idx >= 0 && c == -1; idx-- {
+			if existing[idx].Min <= start && start <= existing[idx].Max {
+				c = idx
+			}
+		}
+		var col xlsxCol
+		inRange := r < len(ranges) && ranges[r][0] <= start
+		if c == -1 && !inRange {
+			continue
+		}
+		if c != -1 {
+			deepcopy.Copy(&col, existing[c])
+		} else {
+			deepcopy.Copy(&col, colData)
+		}
+		if inRange {
+			update(&col, r)
+		}
+		col.Min, col.Max = start, end
+		cols = append(cols, col)
+	}
+	if ws.Cols = nil; len(cols) > 0 {
+		ws.Cols = &xlsxCols{Col: coalesceCols(cols)}
+	}
+}
+
+// coalesceCols provides a function to merge the adjacent sorted columns with
+// identical settings into column ranges.
+func coalesceCols(cols []xlsxCol) []xlsxCol {
+	var merged []xlsxCol
+	for _, c := range cols {
+		if n := len(merged); n > 0 {
+			prev := &merged[n-1]
+			if prev.Max+1 == c.Min && prev.BestFit == c.BestFit && prev.Collapsed == c.Collapsed &&
+				prev.CustomWidth == c.CustomWidth && prev.Hidden == c.Hidden &&
+				prev.OutlineLevel == c.OutlineLevel && prev.Phonetic == c.Phonetic && prev.Style == c.Style &&
+				((prev.Width == nil && c.Width == nil) || (prev.Width != nil && c.Width != nil && *prev.Width == *c.Width)) {
+				prev.Max = c.Max
+				continue
+			}
+		}
+		merged = append(merged, c)
 	}
+	return merged
+}
+
+// updateCols provides a function to update the settings of the columns by
+// given column definition and update function. The given column definition
+// specifies the columns range and the initial settings of the columns which
+// have not been defined in the worksheet, and the update function will be
+// called for each of the columns in the range.
+func (ws *xlsxWorksheet) updateCols(colData xlsxCol, update func(c *xlsxCol)) {
 	if ws.Cols == nil {
-		cols := xlsxCols{}
-		cols.Col = append(cols.Col, col)
-		ws.Cols = &cols
+		update(&colData)
+		ws.Cols = &xlsxCols{Col: []xlsxCol{colData}}
 		return
 	}
-	ws.Cols.Col = flatCols(col, ws.Cols.Col, func(fc, c xlsxCol) xlsxCol {
-		fc.BestFit = c.BestFit
-		fc.Collapsed = c.Collapsed
-		fc.Hidden = c.Hidden
-		fc.OutlineLevel = c.OutlineLevel
-		fc.Phonetic = c.Phonetic
-		fc.Style = c.Style
-		return fc
+	ws.Cols.Col = flatCols(colData, ws.Cols.Col, func(fc, c xlsxCol) xlsxCol {
+		c.Min, c.Max = fc.Min, fc.Max
+		return c
 	})
+	for i := range ws.Cols.Col {
+		if c := &ws.Cols.Col[i]; c.Min >= colData.Min && c.Max <= colData.Max {
+			update(c)
+		}
+	}
 }
 
 // flatCols provides a method for the column's operation functions to flatten
@@ -620,7 +3636,11 @@ func flatCols(col xlsxCol, cols []xlsxCol, replacer func(fc, c xlsxCol) xlsxCol)
 //
 //	width           # Width of object frame.
 //	height          # Height of object frame.
-func (f *File) positionObjectPixels(sheet string, col, row, width, height int, opts *GraphicOptions) (int, int, int, int, int, int, int, int) {
+func (f *File) positionObjectPixels(sheet string, col, row, width, height int, opts *GraphicOptions) (int, int, int, int, int, int, int, int, error) {
+	maxDigitWidth, err := f.getMaxDigitWidth()
+	if err != nil {
+		return 0, 0, 0, 0, 0, 0, 0, 0, err
+	}
 	colIdx, rowIdx := col-1, row-1
 	// Initialized end cell to the same as the start cell.
 	colEnd, rowEnd := colIdx, rowIdx
@@ -630,15 +3650,15 @@ func (f *File) positionObjectPixels(sheet string, col, row, width, height int, o
 		// "from" cell dimensions. If these were to be exceeded the "toPoint" would
 		// be calculated incorrectly, since the requested "fromPoint" is not possible
 
-		x1 = min(x1, f.getColWidth(sheet, col))
+		x1 = min(x1, f.getColWidth(sheet, col, maxDigitWidth))
 		y1 = min(y1, f.getRowHeight(sheet, row))
 
 		x2 += x1
 		y2 += y1
 		// Subtract the underlying cell widths to find end cell of the object.
-		for x2 >= f.getColWidth(sheet, colEnd+1) {
+		for x2 >= f.getColWidth(sheet, colEnd+1, maxDigitWidth) {
 			colEnd++
-			x2 -= f.getColWidth(sheet, colEnd)
+			x2 -= f.getColWidth(sheet, colEnd, maxDigitWidth)
 		}
 
 		// Subtract the underlying cell heights to find end cell of the object.
@@ -648,29 +3668,39 @@ func (f *File) positionObjectPixels(sheet string, col, row, width, height int, o
 		}
 	}
 	// The end vertices are whatever is left from the width and height.
-	return colIdx, rowIdx, colEnd, rowEnd, x1, y1, x2, y2
+	return colIdx, rowIdx, colEnd, rowEnd, x1, y1, x2, y2, err
 }
 
 // getColWidth provides a function to get column width in pixels by given
-// sheet name and column number.
-func (f *File) getColWidth(sheet string, col int) int {
+// sheet name, column number and the maximum digit width of the default font.
+// The width of the hidden column is zero, the same as Excel positions the
+// objects.
+func (f *File) getColWidth(sheet string, col int, maxDigitWidth float64) int {
 	ws, _ := f.workSheetReader(sheet)
 	ws.mu.Lock()
 	defer ws.mu.Unlock()
 	if ws.Cols != nil {
 		width := -1.0
 		for _, v := range ws.Cols.Col {
-			if v.Min <= col && col <= v.Max && v.Width != nil {
-				width = *v.Width
-				break
+			if v.Min <= col && col <= v.Max {
+				if v.Hidden {
+					return 0
+				}
+				if v.Width != nil {
+					width = *v.Width
+					break
+				}
 			}
 		}
 		if width != -1.0 {
-			return int(convertColWidthToPixels(width))
+			return int(colWidthToPixels(width, maxDigitWidth))
 		}
 	}
 	if ws.SheetFormatPr != nil && ws.SheetFormatPr.DefaultColWidth > 0 {
-		return int(convertColWidthToPixels(ws.SheetFormatPr.DefaultColWidth))
+		return int(colWidthToPixels(ws.SheetFormatPr.DefaultColWidth, maxDigitWidth))
+	}
+	if maxDigitWidth != defaultMaxDigitWidth {
+		return int(colWidthToPixels(defaultColWidth, maxDigitWidth))
 	}
 	// Optimization for when the column widths haven't changed.
 	return int(defaultColWidthPixels)
@@ -703,92 +3733,942 @@ func (f *File) GetColStyle(sheet, col string) (int, error) {
 	return styleID, err
 }
 
+// GetColNumFmt provides a function to get the number format code of the
+// column by given worksheet name and column name. This function is
+// concurrency safe. An empty string will be returned if the column has no
+// number format.
+func (f *File) GetColNumFmt(sheet, col string) (string, error) {
+	styleID, err := f.GetColStyle(sheet, col)
+	if err != nil || styleID == 0 {
+		return "", err
+	}
+	s, err := f.stylesReader()
+	if err != nil {
+		return "", err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.CellXfs == nil || len(s.CellXfs.Xf) <= styleID || s.CellXfs.Xf[styleID].NumFmtID == nil || *s.CellXfs.Xf[styleID].NumFmtID == 0 {
+		return "", err
+	}
+	numFmtID := *s.CellXfs.Xf[styleID].NumFmtID
+	if fmtCode, ok := s.getCustomNumFmtCode(numFmtID); ok {
+		return fmtCode, err
+	}
+	fmtCode, _ := f.getBuiltInNumFmtCode(numFmtID)
+	return fmtCode, err
+}
+
+// SetColProps provides a function to set the settings of a single column or
+// multiple columns by given worksheet name, columns range and column
+// settings. Only the non-nil fields of the settings will be changed, and the
+// other settings of the columns will be kept. Note that the Style only set
+// the column style, use the SetColStyle function to also apply the style on
+// the existing cells in the columns. This function is concurrency safe. For
+// example, collapse the columns from D to F on Sheet1 and mark them as best
+// fit:
+//
+//	enable := true
+//	err := f.SetColProps("Sheet1", "D:F", excelize.ColProps{
+//	    BestFit:   &enable,
+//	    Collapsed: &enable,
+//	})
+func (f *File) SetColProps(sheet, columns string, props ColProps) error {
+	minVal, maxVal, err := f.parseColRange(columns)
+	if err != nil {
+		return err
+	}
+	if props.Width != nil {
+		if err = checkColWidth(*props.Width); err != nil {
+			return err
+		}
+	}
+	if props.OutlineLevel != nil && *props.OutlineLevel > 7 {
+		return ErrOutlineLevel
+	}
+	f.mu.Lock()
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		f.mu.Unlock()
+		return err
+	}
+	if props.Style != nil {
+		s, err := f.stylesReader()
+		if err != nil {
+			f.mu.Unlock()
+			return err
+		}
+		s.mu.Lock()
+		if styleID := *props.Style; styleID < 0 || s.CellXfs == nil || len(s.CellXfs.Xf) <= styleID {
+			s.mu.Unlock()
+			f.mu.Unlock()
+			return newInvalidStyleID(styleID)
+		}
+		s.mu.Unlock()
+	}
+	f.mu.Unlock()
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	ws.updateCols(xlsxCol{Min: minVal, Max: maxVal}, func(c *xlsxCol) {
+		if props.Width != nil {
+			c.Width, c.CustomWidth = float64Ptr(*props.Width), true
+		}
+		if props.Hidden != nil {
+			c.Hidden = *props.Hidden
+		}
+		if props.BestFit != nil {
+			c.BestFit = *props.BestFit
+		}
+		if props.Collapsed != nil {
+			c.Collapsed = *props.Collapsed
+		}
+		if props.OutlineLevel != nil {
+			c.OutlineLevel = *props.OutlineLevel
+		}
+		if props.Style != nil {
+			c.Style = *props.Style
+		}
+		if props.Phonetic != nil {
+			c.Phonetic = *props.Phonetic
+		}
+	})
+	return err
+}
+
+// GetColProps provides a function to get the settings of the column by given
+// worksheet name and column name. All fields of the returned settings will be
+// set, and the default column width of the worksheet will be returned if the
+// column width has not been set. This function is concurrency safe.
+func (f *File) GetColProps(sheet, col string) (ColProps, error) {
+	var props ColProps
+	colNum, err := ColumnNameToNumber(col)
+	if err != nil {
+		return props, err
+	}
+	f.mu.Lock()
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		f.mu.Unlock()
+		return props, err
+	}
+	f.mu.Unlock()
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	var colData xlsxCol
+	if ws.Cols != nil {
+		for _, c := range ws.Cols.Col {
+			if c.Min <= colNum && colNum <= c.Max {
+				colData = c
+			}
+		}
+	}
+	width := defaultColWidth
+	if ws.SheetFormatPr != nil && ws.SheetFormatPr.DefaultColWidth > 0 {
+		width = ws.SheetFormatPr.DefaultColWidth
+	}
+	if colData.Width != nil && *colData.Width != 0 {
+		width = *colData.Width
+	}
+	props = ColProps{
+		Width:        float64Ptr(width),
+		Hidden:       boolPtr(colData.Hidden),
+		BestFit:      boolPtr(colData.BestFit),
+		Collapsed:    boolPtr(colData.Collapsed),
+		OutlineLevel: uint8Ptr(colData.OutlineLevel),
+		Style:        intPtr(colData.Style),
+		Phonetic:     boolPtr(colData.Phonetic),
+	}
+	return props, err
+}
+
 // GetColWidth provides a function to get column width by given worksheet name
-// and column name. This function is concurrency safe.
+// and column name, the zero-width hidden column will be reported as 0. This
+// function is concurrency safe.
 func (f *File) GetColWidth(sheet, col string) (float64, error) {
 	colNum, err := ColumnNameToNumber(col)
 	if err != nil {
-		return defaultColWidth, err
+		return defaultColWidth, err
+	}
+	f.mu.Lock()
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		f.mu.Unlock()
+		return defaultColWidth, err
+	}
+	f.mu.Unlock()
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	if ws.Cols != nil {
+		var (
+			width  float64
+			hidden bool
+		)
+		for _, v := range ws.Cols.Col {
+			if v.Min <= colNum && colNum <= v.Max && v.Width != nil {
+				width, hidden = *v.Width, v.Hidden
+			}
+		}
+		if width != 0 {
+			return width, err
+		}
+		if hidden {
+			// the zero-width hidden column
+			return 0, err
+		}
+	}
+	if ws.SheetFormatPr != nil && ws.SheetFormatPr.DefaultColWidth > 0 {
+		return ws.SheetFormatPr.DefaultColWidth, err
+	}
+	// Optimization for when the column widths haven't changed.
+	return defaultColWidth, err
+}
+
+// GetColWidthPixels provides a function to get the width of the column in
+// pixels by given worksheet name and column name, the hidden column will be
+// reported as 0 pixels. The maximum digit width of the registered metrics of
+// the default font will be used for the conversion if exists. For example,
+// get the width of column A on Sheet1:
+//
+//	pixels, err := f.GetColWidthPixels("Sheet1", "A")
+func (f *File) GetColWidthPixels(sheet, col string) (int, error) {
+	visible, err := f.GetColVisible(sheet, col)
+	if err != nil || !visible {
+		return 0, err
+	}
+	width, err := f.GetColWidth(sheet, col)
+	if err != nil {
+		return 0, err
+	}
+	maxDigitWidth, err := f.getMaxDigitWidth()
+	return int(colWidthToPixels(width, maxDigitWidth)), err
+}
+
+// SetColWidthPixels provides a function to set the width of a single column
+// or multiple columns in pixels, the width will be converted to the number of
+// characters by the PixelsToColWidth function, or by the maximum digit width
+// of the registered metrics of the default font if exists. For example, set
+// the width of columns A to H on Sheet1 to 100 pixels:
+//
+//	err := f.SetColWidthPixels("Sheet1", "A", "H", 100)
+func (f *File) SetColWidthPixels(sheet, startCol, endCol string, pixels int) error {
+	if pixels < 0 {
+		return ErrParameterInvalid
+	}
+	maxDigitWidth, err := f.getMaxDigitWidth()
+	if err != nil {
+		return err
+	}
+	return f.SetColWidth(sheet, startCol, endCol, pixelsToColWidth(pixels, maxDigitWidth))
+}
+
+// SetColWidthUnit provides a function to set the width of a single column or
+// multiple columns in the given physical measurement unit. The width will be
+// converted to pixels by the 96 DPI mapping and rounded to the nearest pixel,
+// so the value read back may differ within one pixel. For example, set the
+// width of columns A to C on Sheet1 to 2.5 centimeters:
+//
+//	err := f.SetColWidthUnit("Sheet1", "A:C", 2.5, excelize.UnitCentimeters)
+func (f *File) SetColWidthUnit(sheet, columns string, value float64, unit MeasurementUnit) error {
+	pixels, err := unit.toPixels(value)
+	if err != nil {
+		return err
+	}
+	minVal, maxVal, err := f.parseColRange(columns)
+	if err != nil {
+		return err
+	}
+	startCol, _ := ColumnNumberToName(minVal)
+	endCol, _ := ColumnNumberToName(maxVal)
+	return f.SetColWidthPixels(sheet, startCol, endCol, int(math.Round(pixels)))
+}
+
+// GetColWidthUnit provides a function to get the width of the column in the
+// given physical measurement unit by given worksheet name and column name,
+// the hidden column will be reported as 0. For example, get the width of
+// column A on Sheet1 in inches:
+//
+//	width, err := f.GetColWidthUnit("Sheet1", "A", excelize.UnitInches)
+func (f *File) GetColWidthUnit(sheet, col string, unit MeasurementUnit) (float64, error) {
+	if _, err := unit.toPixels(0); err != nil {
+		return 0, err
+	}
+	pixels, err := f.GetColWidthPixels(sheet, col)
+	if err != nil {
+		return 0, err
+	}
+	return unit.fromPixels(float64(pixels)), err
+}
+
+// InsertCols provides a function to insert new columns before the given column
+// name and number of columns. For example, create two columns before column
+// C in Sheet1:
+//
+//	err := f.InsertCols("Sheet1", "C", 2)
+//
+// The pictures, charts, shapes, form controls and comments anchored at or
+// beyond the inserted columns will be moved with the cells, except the objects
+// with "absolute" positioning.
+//
+// Use this method with caution, which will affect changes in references such
+// as formulas, charts, and so on. If there is any referenced value of the
+// worksheet, it will cause a file error when you open it. The excelize only
+// partially updates these references currently.
+func (f *File) InsertCols(sheet, col string, n int) error {
+	num, err := ColumnNameToNumber(col)
+	if err != nil {
+		return err
+	}
+	if n < 1 || n > MaxColumns {
+		return ErrColumnNumber
+	}
+	return f.adjustHelper(sheet, columns, num, n)
+}
+
+// RemoveCol provides a function to remove single column by given worksheet
+// name and column index. For example, remove column C in Sheet1:
+//
+//	err := f.RemoveCol("Sheet1", "C")
+//
+// The absolute references of the defined names on the worksheet will be
+// shifted, and the areas which are entirely removed will be replaced with the
+// #REF! error, such as Sheet1!#REF!.
+//
+// Use this method with caution, which will affect changes in references such
+// as formulas, charts, and so on. If there is any referenced value of the
+// worksheet, it will cause a file error when you open it. The excelize only
+// partially updates these references currently.
+func (f *File) RemoveCol(sheet, col string) error {
+	return f.RemoveCols(sheet, col, col)
+}
+
+// RemoveCols provides a function to remove a contiguous range of columns by
+// given worksheet name, start and end column name. The cells, formulas,
+// merged cells, data validations and column definitions are adjusted in a
+// single pass. The merged cells, hyperlinks and comments which entirely in the
+// removed columns will be deleted, and the merged cells straddle the removed
+// columns will be shrunk. For example, remove the columns from C to F in
+// Sheet1:
+//
+//	err := f.RemoveCols("Sheet1", "C", "F")
+//
+// Use this method with caution, which will affect changes in references such
+// as formulas, charts, and so on. If there is any referenced value of the
+// worksheet, it will cause a file error when you open it. The excelize only
+// partially updates these references currently.
+func (f *File) RemoveCols(sheet, startCol, endCol string) error {
+	start, err := ColumnNameToNumber(startCol)
+	if err != nil {
+		return err
+	}
+	end, err := ColumnNameToNumber(endCol)
+	if err != nil {
+		return err
+	}
+	if end < start {
+		return ErrParameterInvalid
+	}
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return err
+	}
+	ws.formulaSI.Clear()
+	for rowIdx := range ws.SheetData.Row {
+		rowData := &ws.SheetData.Row[rowIdx]
+		cells := rowData.C[:0]
+		for _, c := range rowData.C {
+			if col, _, _ := CellNameToCoordinates(c.R); col < start || col > end {
+				cells = append(cells, c)
+			}
+		}
+		rowData.C = cells
+	}
+	return f.adjustHelper(sheet, columns, start, start-end-1)
+}
+
+// MoveCols provides a function to move a contiguous range of columns before
+// the given destination column by given worksheet name, source columns range
+// and destination column name, just like cut the columns and insert the cut
+// cells in Excel. The cell values, styles, column widths, visibility, outline
+// levels, merged cells fully contained in the moved columns, hyperlinks,
+// comments, conditional formats and data validations will be moved together,
+// and the formula references to the moved columns in the workbook will be
+// updated. An error will be returned if the destination column is within the
+// moved columns or next to the last moved column. For example, move the
+// columns from D to F before column B in Sheet1:
+//
+//	err := f.MoveCols("Sheet1", "D:F", "B")
+//
+// Use this method with caution, which will affect changes in references such
+// as formulas, charts, and so on. If there is any referenced value of the
+// worksheet, it will cause a file error when you open it. The excelize only
+// partially updates these references currently.
+func (f *File) MoveCols(sheet, srcRange, destCol string) error {
+	start, end, err := f.parseColRange(srcRange)
+	if err != nil {
+		return err
 	}
-	f.mu.Lock()
-	ws, err := f.workSheetReader(sheet)
+	dest, err := ColumnNameToNumber(destCol)
 	if err != nil {
-		f.mu.Unlock()
-		return defaultColWidth, err
+		return err
 	}
-	f.mu.Unlock()
-	ws.mu.Lock()
-	defer ws.mu.Unlock()
-	if ws.Cols != nil {
-		var width float64
-		for _, v := range ws.Cols.Col {
-			if v.Min <= colNum && colNum <= v.Max && v.Width != nil {
-				width = *v.Width
-			}
+	if start <= dest && dest <= end+1 {
+		return newMoveColsError(srcRange, destCol)
+	}
+	comments, err := f.GetComments(sheet)
+	if err != nil {
+		return err
+	}
+	var moved []Comment
+	for _, comment := range comments {
+		col, _, err := CellNameToCoordinates(comment.Cell)
+		if err != nil {
+			return err
 		}
-		if width != 0 {
-			return width, err
+		if start <= col && col <= end {
+			moved = append(moved, comment)
 		}
 	}
-	if ws.SheetFormatPr != nil && ws.SheetFormatPr.DefaultColWidth > 0 {
-		return ws.SheetFormatPr.DefaultColWidth, err
+	n := end - start + 1
+	if err = f.InsertCols(sheet, destCol, n); err != nil {
+		return err
 	}
-	// Optimization for when the column widths haven't changed.
-	return defaultColWidth, err
+	from := start
+	if dest < start {
+		from += n
+	}
+	if err = f.relocateCols(sheet, from, from+n-1, dest); err != nil {
+		return err
+	}
+	startCol, _ := ColumnNumberToName(from)
+	endCol, _ := ColumnNumberToName(from + n - 1)
+	if err = f.RemoveCols(sheet, startCol, endCol); err != nil {
+		return err
+	}
+	return f.moveComments(sheet, moved, func(col int) int {
+		if dest < start {
+			return col - start + dest
+		}
+		return col - start + dest - n
+	})
 }
 
-// InsertCols provides a function to insert new columns before the given column
-// name and number of columns. For example, create two columns before column
-// C in Sheet1:
+// DuplicateCol inserts a copy of specified column by given worksheet name and
+// column name after it. The cell values, styles, formulas, column width,
+// visibility, outline level, merged cells, hyperlinks, conditional formats,
+// data validations and comments of the column will be duplicated, and the
+// relative references of the formulas in the duplicated cells will be
+// re-based to the new column. The merged cells spanning the column will be
+// expanded, and the formula references to the columns after it will be
+// shifted. For example, duplicate column B on Sheet1:
 //
-//	err := f.InsertCols("Sheet1", "C", 2)
+//	err := f.DuplicateCol("Sheet1", "B")
 //
 // Use this method with caution, which will affect changes in references such
 // as formulas, charts, and so on. If there is any referenced value of the
 // worksheet, it will cause a file error when you open it. The excelize only
 // partially updates these references currently.
-func (f *File) InsertCols(sheet, col string, n int) error {
+func (f *File) DuplicateCol(sheet, col string) error {
 	num, err := ColumnNameToNumber(col)
 	if err != nil {
 		return err
 	}
-	if n < 1 || n > MaxColumns {
-		return ErrColumnNumber
-	}
-	return f.adjustHelper(sheet, columns, num, n)
+	return f.DuplicateColTo(sheet, col, num+1)
 }
 
-// RemoveCol provides a function to remove single column by given worksheet
-// name and column index. For example, remove column C in Sheet1:
+// DuplicateColTo inserts a copy of specified column by given worksheet name,
+// column name and the destination column number, the existing columns at and
+// after the destination column will be moved right. The column will be
+// duplicated in the same way as the DuplicateCol function. For example,
+// insert a copy of column B on Sheet1 before column E:
 //
-//	err := f.RemoveCol("Sheet1", "C")
+//	err := f.DuplicateColTo("Sheet1", "B", 5)
 //
 // Use this method with caution, which will affect changes in references such
 // as formulas, charts, and so on. If there is any referenced value of the
 // worksheet, it will cause a file error when you open it. The excelize only
 // partially updates these references currently.
-func (f *File) RemoveCol(sheet, col string) error {
-	num, err := ColumnNameToNumber(col)
+func (f *File) DuplicateColTo(sheet, col string, dstCol int) error {
+	src, err := ColumnNameToNumber(col)
+	if err != nil {
+		return err
+	}
+	dst, err := ColumnNumberToName(dstCol)
 	if err != nil {
 		return err
 	}
+	comments, err := f.GetComments(sheet)
+	if err != nil {
+		return err
+	}
+	var duplicated []Comment
+	for _, comment := range comments {
+		col, _, err := CellNameToCoordinates(comment.Cell)
+		if err != nil {
+			return err
+		}
+		if col == src {
+			duplicated = append(duplicated, comment)
+		}
+	}
+	if err = f.CopyCol(sheet, col, sheet, dst, CopyColOptions{Insert: true}); err != nil {
+		return err
+	}
+	return f.moveComments(sheet, duplicated, func(int) int { return dstCol })
+}
 
-	ws, err := f.workSheetReader(sheet)
+// CopyCol provides a function to copy a column to another position of the
+// same or another worksheet by given source worksheet name, source column
+// name, destination worksheet name, destination column name and optional
+// settings. The relative references of the formulas in the copied cells will
+// be re-based to the destination column, and the shared formulas will be kept
+// shared when all the cells of the shared formula are in the source column,
+// otherwise they will be converted to normal formulas. For example, copy
+// column B on Sheet1 to column D on Sheet2 and keep the column width of the
+// destination column:
+//
+//	properties := false
+//	err := f.CopyCol("Sheet1", "B", "Sheet2", "D", excelize.CopyColOptions{
+//	    Properties: &properties,
+//	})
+//
+// Insert a copy of column B before column D on Sheet1:
+//
+//	err := f.CopyCol("Sheet1", "B", "Sheet1", "D", excelize.CopyColOptions{
+//	    Insert: true,
+//	})
+func (f *File) CopyCol(srcSheet, srcCol, dstSheet, dstCol string, opts ...CopyColOptions) error {
+	src, err := ColumnNameToNumber(srcCol)
 	if err != nil {
 		return err
 	}
-	ws.formulaSI.Clear()
-	for rowIdx := range ws.SheetData.Row {
-		rowData := &ws.SheetData.Row[rowIdx]
-		for colIdx := range rowData.C {
-			colName, _, _ := SplitCellName(rowData.C[colIdx].R)
-			if colName == col {
-				rowData.C = append(rowData.C[:colIdx], rowData.C[colIdx+1:]...)[:len(rowData.C)-1]
+	dst, err := ColumnNameToNumber(dstCol)
+	if err != nil {
+		return err
+	}
+	var options CopyColOptions
+	for _, opt := range opts {
+		options = opt
+	}
+	srcWs, err := f.workSheetReader(srcSheet)
+	if err != nil {
+		return err
+	}
+	dstWs, err := f.workSheetReader(dstSheet)
+	if err != nil {
+		return err
+	}
+	if options.Insert {
+		if err = f.InsertCols(dstSheet, dstCol, 1); err != nil {
+			return err
+		}
+		if srcWs == dstWs && src >= dst {
+			src++
+		}
+	} else if srcWs == dstWs && src == dst {
+		return err
+	}
+	srcWs.formulaSI.Clear()
+	values, styles := options.Values == nil || *options.Values, options.Styles == nil || *options.Styles
+	if values || styles {
+		if err = f.copyColCells(srcWs, dstWs, dstSheet, src, dst, values, styles); err != nil {
+			return err
+		}
+	}
+	if values {
+		if err = f.copyColMergeCells(srcWs, dstSheet, src, dst); err != nil {
+			return err
+		}
+		if err = f.copyColHyperlinks(srcWs, srcSheet, dstSheet, src, dst); err != nil {
+			return err
+		}
+	}
+	if styles {
+		if err = copyColStyleRanges(srcWs, dstWs, src, dst); err != nil {
+			return err
+		}
+	}
+	if options.Properties == nil || *options.Properties {
+		dstWs.copyColDefinition(srcWs, src, dst)
+	}
+	return err
+}
+
+// copyColCells provides a function to copy the cell values, formulas or
+// styles of the source column to the destination column. The cells in the
+// destination column without the corresponding source cell will be cleared.
+func (f *File) copyColCells(srcWs, dstWs *xlsxWorksheet, dstSheet string, src, dst int, values, styles bool) error {
+	var cells []xlsxC
+	shared, srcRows := map[int]bool{}, map[int]bool{}
+	for _, row := range srcWs.SheetData.Row {
+		for _, c := range row.C {
+			col, rowNum, err := CellNameToCoordinates(c.R)
+			if err != nil {
+				return err
+			}
+			if col != src {
+				continue
+			}
+			var cell xlsxC
+			deepcopy.Copy(&cell, c)
+			cells, srcRows[rowNum] = append(cells, cell), true
+			if c.F != nil && c.F.T == STCellFormulaTypeShared && c.F.Si != nil && c.F.Ref != "" {
+				if coordinates, err := rangeRefToCoordinates(c.F.Ref); err == nil && coordinates[0] == src && coordinates[2] == src {
+					shared[*c.F.Si] = true
+				}
+			}
+		}
+	}
+	if err := f.clearColCells(dstWs, dstSheet, dst, srcRows, values, styles); err != nil {
+		return err
+	}
+	nextSI, sharedSI := dstWs.countSharedFormula(), map[int]int{}
+	for i := range cells {
+		cell := &cells[i]
+		_, row, _ := CellNameToCoordinates(cell.R)
+		if cell.f = ""; cell.F != nil {
+			if err := cell.copyColFormula(srcWs, shared, sharedSI, &nextSI, src, dst); err != nil {
+				return err
+			}
+		}
+		dstWs.prepareSheetXML(dst, row)
+		c := &dstWs.SheetData.Row[row-1].C[dst-1]
+		if styles {
+			c.S = cell.S
+		}
+		if !values {
+			continue
+		}
+		dstWs.deleteSharedFormula(c)
+		c.XMLSpace, c.T, c.Cm, c.Vm, c.Ph, c.F, c.V, c.IS, c.f = cell.XMLSpace, cell.T, cell.Cm, cell.Vm, cell.Ph, cell.F, cell.V, cell.IS, ""
+		if f.journal != nil && c.hasValue() {
+			f.journal.add(CellChange{Sheet: dstSheet, Cell: c.R, NewValue: f.getCellChangeValue(c)})
+		}
+	}
+	return nil
+}
+
+// clearColCells provides a function to clear the values or styles of the
+// cells in the destination column which rows not exist in the given source
+// rows.
+func (f *File) clearColCells(dstWs *xlsxWorksheet, dstSheet string, dst int, srcRows map[int]bool, values, styles bool) error {
+	for i := range dstWs.SheetData.Row {
+		row := &dstWs.SheetData.Row[i]
+		for j := range row.C {
+			c := &row.C[j]
+			col, rowNum, err := CellNameToCoordinates(c.R)
+			if err != nil {
+				return err
+			}
+			if col != dst || srcRows[rowNum] {
+				continue
+			}
+			if styles {
+				c.S = 0
+			}
+			if !values {
+				continue
+			}
+			if f.journal != nil && c.hasValue() {
+				f.journal.add(CellChange{Sheet: dstSheet, Cell: c.R, OldValue: f.getCellChangeValue(c)})
+			}
+			dstWs.deleteSharedFormula(c)
+			c.XMLSpace, c.T, c.Cm, c.Vm, c.Ph, c.F, c.V, c.IS, c.f = xml.Attr{}, "", nil, nil, nil, nil, "", nil, ""
+		}
+	}
+	return nil
+}
+
+// copyColFormula provides a function to re-base the formula of the copied
+// cell from the source column to the destination column.
+func (c *xlsxC) copyColFormula(srcWs *xlsxWorksheet, shared map[int]bool, sharedSI map[int]int, nextSI *int, src, dst int) error {
+	if c.F.T == STCellFormulaTypeShared && c.F.Si != nil {
+		if !shared[*c.F.Si] {
+			formula, err := getSharedFormula(srcWs, *c.F.Si, c.R)
+			if err != nil {
+				return err
+			}
+			c.F = &xlsxF{Content: formula}
+		} else {
+			si, ok := sharedSI[*c.F.Si]
+			if !ok {
+				si, *nextSI = *nextSI, *nextSI+1
+				sharedSI[*c.F.Si] = si
+			}
+			c.F.Si = intPtr(si)
+		}
+	}
+	if c.F.Ref != "" {
+		coordinates, err := rangeRefToCoordinates(c.F.Ref)
+		if err != nil {
+			return err
+		}
+		if coordinates[0] == src && coordinates[2] == src {
+			coordinates[0], coordinates[2] = dst, dst
+			if c.F.Ref, err = coordinatesToRangeRef(coordinates); err != nil {
+				return err
+			}
+		} else {
+			c.F.T, c.F.Ref = "", ""
+		}
+	}
+	c.F.Content = shiftFormula(c.F.Content, dst-src, 0)
+	return nil
+}
+
+// copyColMergeCells provides a function to copy the merged cells which are
+// fully contained in the source column to the destination column.
+func (f *File) copyColMergeCells(srcWs *xlsxWorksheet, dstSheet string, src, dst int) error {
+	if srcWs.MergeCells == nil {
+		return nil
+	}
+	var refs [][]int
+	for _, mergedCells := range srcWs.MergeCells.Cells {
+		coordinates, err := mergedCells.Rect()
+		if err != nil {
+			return err
+		}
+		if coordinates[0] == src && coordinates[2] == src {
+			refs = append(refs, []int{dst, coordinates[1], dst, coordinates[3]})
+		}
+	}
+	for _, coordinates := range refs {
+		topLeftCell, _ := CoordinatesToCellName(coordinates[0], coordinates[1])
+		bottomRightCell, _ := CoordinatesToCellName(coordinates[2], coordinates[3])
+		if err := f.MergeCell(dstSheet, topLeftCell, bottomRightCell); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// copyColHyperlinks provides a function to copy the hyperlinks of the cells in
+// the source column to the destination column.
+func (f *File) copyColHyperlinks(srcWs *xlsxWorksheet, srcSheet, dstSheet string, src, dst int) error {
+	if srcWs.Hyperlinks == nil {
+		return nil
+	}
+	var links []xlsxHyperlink
+	for _, link := range srcWs.Hyperlinks.Hyperlink {
+		if col, _, err := CellNameToCoordinates(link.Ref); err == nil && col == src {
+			links = append(links, link)
+		}
+	}
+	for _, link := range links {
+		_, row, _ := CellNameToCoordinates(link.Ref)
+		cell, _ := CoordinatesToCellName(dst, row)
+		target, linkType := link.Location, "Location"
+		if link.RID != "" {
+			target, linkType = f.getSheetRelationshipsTargetByID(srcSheet, link.RID), "External"
+		}
+		var opts HyperlinkOpts
+		if link.Display != "" {
+			opts.Display = stringPtr(link.Display)
+		}
+		if link.Tooltip != "" {
+			opts.Tooltip = stringPtr(link.Tooltip)
+		}
+		if err := f.SetCellHyperLink(dstSheet, cell, target, linkType, opts); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// copyColStyleRanges provides a function to copy the conditional formats and
+// data validations in the source column to the destination column.
+func copyColStyleRanges(srcWs, dstWs *xlsxWorksheet, src, dst int) error {
+	var cfs []*xlsxConditionalFormatting
+	for _, cf := range srcWs.ConditionalFormatting {
+		if cf == nil {
+			continue
+		}
+		sqref, dCol, dRow, err := copyColSQRef(cf.SQRef, src, dst)
+		if err != nil {
+			return err
+		}
+		if sqref != "" {
+			var cfCopy xlsxConditionalFormatting
+			deepcopy.Copy(&cfCopy, *cf)
+			cfCopy.SQRef = sqref
+			for _, rule := range cfCopy.CfRule {
+				for i := range rule.Formula {
+					rule.Formula[i] = shiftFormula(rule.Formula[i], dCol, dRow)
+				}
+			}
+			cfs = append(cfs, &cfCopy)
+		}
+	}
+	dstWs.ConditionalFormatting = append(dstWs.ConditionalFormatting, cfs...)
+	if srcWs.DataValidations == nil {
+		return nil
+	}
+	var dvs []*xlsxDataValidation
+	for _, dv := range srcWs.DataValidations.DataValidation {
+		if dv == nil {
+			continue
+		}
+		sqref, dCol, dRow, err := copyColSQRef(dv.Sqref, src, dst)
+		if err != nil {
+			return err
+		}
+		if sqref != "" {
+			var dvCopy xlsxDataValidation
+			deepcopy.Copy(&dvCopy, *dv)
+			dvCopy.Sqref = sqref
+			for _, formula := range []*xlsxInnerXML{dvCopy.Formula1, dvCopy.Formula2} {
+				if formula.isFormula() {
+					formula.Content = formulaEscaper.Replace(shiftFormula(formulaUnescaper.Replace(formula.Content), dCol, dRow))
+				}
+			}
+			dvs = append(dvs, &dvCopy)
+		}
+	}
+	if len(dvs) == 0 {
+		return nil
+	}
+	if dstWs.DataValidations == nil {
+		dstWs.DataValidations = &xlsxDataValidations{}
+	}
+	dstWs.DataValidations.DataValidation = append(dstWs.DataValidations.DataValidation, dvs...)
+	dstWs.DataValidations.Count = len(dstWs.DataValidations.DataValidation)
+	return nil
+}
+
+// copyColSQRef returns the reference sequence in the destination column by
+// given reference sequence and the source column, and the column and row
+// offsets between the top-left cells of the source and destination reference
+// sequence, which the relative references in the formulas are based on.
+func copyColSQRef(sqref string, src, dst int) (string, int, int, error) {
+	var (
+		refs                 []string
+		dCol, dRow, col, row int
+	)
+	for _, ref := range strings.Fields(sqref) {
+		if !strings.Contains(ref, ":") {
+			ref += ":" + ref
+		}
+		coordinates, err := rangeRefToCoordinates(ref)
+		if err != nil {
+			return "", dCol, dRow, err
+		}
+		_ = sortCoordinates(coordinates)
+		if col == 0 {
+			col, row = coordinates[0], coordinates[1]
+		}
+		if coordinates[0] > src || coordinates[2] < src {
+			continue
+		}
+		if len(refs) == 0 {
+			dCol, dRow = dst-col, coordinates[1]-row
+		}
+		coordinates[0], coordinates[2] = dst, dst
+		if ref, err = coordinatesToRangeRef(coordinates); err != nil {
+			return "", dCol, dRow, err
+		}
+		refs = append(refs, ref)
+	}
+	return strings.Join(refs, " "), dCol, dRow, nil
+}
+
+// copyColDefinition provides a function to copy the column definition of the
+// source column to the destination column, the definition of the
+// destination column will be removed if the source column has no definition.
+func (ws *xlsxWorksheet) copyColDefinition(srcWs *xlsxWorksheet, src, dst int) {
+	colData, ok := xlsxCol{Min: dst, Max: dst}, false
+	if srcWs.Cols != nil {
+		for _, c := range srcWs.Cols.Col {
+			if c.Min <= src && src <= c.Max {
+				colData, ok = c, true
+				colData.Min, colData.Max = dst, dst
+				break
+			}
+		}
+	}
+	if ws.Cols == nil {
+		if ok {
+			ws.Cols = &xlsxCols{Col: []xlsxCol{colData}}
+		}
+		return
+	}
+	cols := flatCols(colData, ws.Cols.Col, func(fc, c xlsxCol) xlsxCol {
+		return fc
+	})
+	if !ok {
+		for i := 0; i < len(cols); i++ {
+			if cols[i].Min == dst && cols[i].Max == dst {
+				cols = append(cols[:i], cols[i+1:]...)
 				break
 			}
 		}
 	}
-	return f.adjustHelper(sheet, columns, num, -1)
+	if ws.Cols.Col = cols; len(cols) == 0 {
+		ws.Cols = nil
+	}
+}
+
+// moveComments provides a function to add the comments of the moved columns
+// to the destination by given column mapping function, the comments of the
+// other columns have been adjusted on inserting and removing columns.
+func (f *File) moveComments(sheet string, comments []Comment, colMap func(col int) int) error {
+	for _, comment := range comments {
+		col, row, _ := CellNameToCoordinates(comment.Cell)
+		comment.Cell, _ = CoordinatesToCellName(colMap(col), row)
+		if err := f.AddComment(sheet, comment); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ColWidthToPixels provides a function to convert the width of a column from
+// the number of characters to pixels, Excel rounds the column width to the
+// nearest pixel.
+func ColWidthToPixels(width float64) int {
+	return int(convertColWidthToPixels(width))
+}
+
+// PixelsToColWidth provides a function to convert the width of a column from
+// pixels to the number of characters, which is the inverse of the
+// ColWidthToPixels function.
+func PixelsToColWidth(pixels int) float64 {
+	return pixelsToColWidth(pixels, defaultMaxDigitWidth)
+}
+
+// MeasurementUnit is the type of the physical measurement unit used to set
+// and get the column width and row height.
+type MeasurementUnit byte
+
+// This section defines the currently supported physical measurement units.
+const (
+	UnitCentimeters MeasurementUnit = iota
+	UnitInches
+	UnitMillimeters
+	UnitPoints
+)
+
+// pixelsPerUnit defined the number of pixels per physical measurement unit
+// with the standard 96 DPI mapping.
+var pixelsPerUnit = map[MeasurementUnit]float64{
+	UnitCentimeters: 96 / 2.54,
+	UnitInches:      96,
+	UnitMillimeters: 96 / 25.4,
+	UnitPoints:      96.0 / 72,
+}
+
+// toPixels provides a function to convert the given value in the physical
+// measurement unit to pixels.
+func (unit MeasurementUnit) toPixels(value float64) (float64, error) {
+	ppu, ok := pixelsPerUnit[unit]
+	if !ok || value < 0 {
+		return 0, ErrParameterInvalid
+	}
+	return value * ppu, nil
+}
+
+// fromPixels provides a function to convert the given pixels to the value in
+// the physical measurement unit.
+func (unit MeasurementUnit) fromPixels(pixels float64) float64 {
+	return pixels / pixelsPerUnit[unit]
 }
 
 // convertColWidthToPixels provides function to convert the width of a cell
@@ -796,15 +4676,132 @@ func (f *File) RemoveCol(sheet, col string) error {
 // pixel. If the width hasn't been set by the user we use the default value.
 // If the column is hidden it has a value of zero.
 func convertColWidthToPixels(width float64) float64 {
+	return colWidthToPixels(width, defaultMaxDigitWidth)
+}
+
+// ColWidthToEMU provides a function to convert the width of a column from the
+// number of characters to EMUs (English Metric Units), the width will be
+// rounded to the whole pixels in the same way as the library positions the
+// objects. For example, get the width of the column with 12 characters in
+// EMUs:
+//
+//	emu := excelize.ColWidthToEMU(12)
+func ColWidthToEMU(width float64) int {
+	return PixelsToEMU(int(convertColWidthToPixels(width)))
+}
+
+// PixelsToEMU provides a function to convert pixels to EMUs (English Metric
+// Units), one pixel is 9525 EMUs.
+func PixelsToEMU(pixels int) int {
+	return pixels * EMU
+}
+
+// EMUToPixels provides a function to convert EMUs (English Metric Units) to
+// pixels, the result will be rounded to the nearest pixel.
+func EMUToPixels(emu int) int {
+	return int(math.Round(float64(emu) / float64(EMU)))
+}
+
+// colWidthToPixels provides a function to convert the width of a column from
+// the number of characters to pixels by given maximum digit width of the
+// default font in pixels.
+func colWidthToPixels(width, maxDigitWidth float64) float64 {
 	var pixels float64
-	var maxDigitWidth float64 = 8
 	if width == 0 {
 		return pixels
 	}
 	if width < 1 {
-		pixels = (width * 12) + 0.5
+		pixels = (width * (maxDigitWidth + 4)) + 0.5
 		return float64(int(pixels))
 	}
 	pixels = (width*maxDigitWidth + 0.5)
 	return float64(int(pixels))
 }
+
+// pixelsToColWidth provides a function to convert the width of a column from
+// pixels to the number of characters by given maximum digit width of the
+// default font in pixels.
+func pixelsToColWidth(pixels int, maxDigitWidth float64) float64 {
+	if float64(pixels) < maxDigitWidth {
+		return float64(pixels) / (maxDigitWidth + 4)
+	}
+	return float64(pixels) / maxDigitWidth
+}
+
+// colRange directly maps a column-anchored range registered by the
+// AddColSparkline and AddChartSeriesFromCols functions. The placeholder is
+// written into the worksheet or chart parts, and replaced with the value
+// resolved from the populated extent of the column when saving the workbook.
+type colRange struct {
+	chart       bool
+	sheetXML    string
+	placeholder string
+	value       string
+	resolve     func(ws *xlsxWorksheet) string
+}
+
+// addColRange provides a function to register a column-anchored range and
+// resolve it by the current worksheet data.
+func (f *File) addColRange(ws *xlsxWorksheet, r *colRange) {
+	r.value = r.resolve(ws)
+	f.mu.Lock()
+	f.colRanges = append(f.colRanges, r)
+	f.mu.Unlock()
+}
+
+// resolveColRanges provides a function to resolve the column-anchored ranges
+// which referenced the columns in the given worksheet.
+func (f *File) resolveColRanges(sheetXML string, ws *xlsxWorksheet) {
+	for _, r := range f.colRanges {
+		if r.sheetXML == sheetXML {
+			r.value = r.resolve(ws)
+		}
+	}
+}
+
+// replaceColRanges provides a function to replace the placeholders of the
+// column-anchored ranges in the worksheet or chart part with the resolved
+// values, the content in the package will not be changed.
+func (f *File) replaceColRanges(path string, content []byte) []byte {
+	for _, r := range f.colRanges {
+		if (r.chart && strings.HasPrefix(path, "xl/charts/chart")) || (!r.chart && path == r.sheetXML) {
+			content = bytes.ReplaceAll(content, []byte(r.placeholder), []byte(r.value))
+		}
+	}
+	return content
+}
+
+// getColExtent provides a function to get the first row number of the cells
+// accepted by the given function and the last row number of the populated
+// cells in the given column, start from the given row number. The row numbers
+// will be 0 if there are no such cells. The blank cells with style only are
+// not populated.
+func (ws *xlsxWorksheet) getColExtent(col, fromRow int, accept func(c *xlsxC) bool) (int, int) {
+	var first, last int
+	for i := range ws.SheetData.Row {
+		row := &ws.SheetData.Row[i]
+		if row.R < fromRow {
+			continue
+		}
+		for j := range row.C {
+			c := &row.C[j]
+			if cellCol, _, err := CellNameToCoordinates(c.R); err != nil || cellCol != col {
+				continue
+			}
+			if c.V == "" && c.F == nil && c.IS == nil {
+				break
+			}
+			if first == 0 && accept(c) {
+				first = row.R
+			}
+			last = row.R
+			break
+		}
+	}
+	return first, last
+}
+
+// isTextCell provides a function to check if the cell contains a text value.
+func isTextCell(c *xlsxC) bool {
+	return c.T == "s" || c.T == "str" || c.T == "inlineStr"
+}
@@ -1,10 +1,15 @@
 package excelize
 
 import (
+	"bytes"
+	"encoding/xml"
 	"fmt"
+	"math"
 	"path/filepath"
+	"strings"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -86,6 +91,415 @@ func TestCols(t *testing.T) {
 	assert.EqualError(t, err, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")).Error())
 }
 
+func TestColsCurrentCol(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetCellValue("Sheet1", "B2", "B2"))
+	assert.NoError(t, f.SetCellValue("Sheet1", "D5", "D5"))
+	cols, err := f.Cols("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, 4, cols.TotalCols())
+	assert.Equal(t, 5, cols.TotalRows())
+	// Test get current column before the first iteration
+	assert.Equal(t, 0, cols.CurrentCol())
+	_, err = cols.CurrentColName()
+	assert.Equal(t, ErrColumnNumber, err)
+	var colNames []string
+	for cols.Next() {
+		if cols.CurrentCol()%2 == 1 {
+			continue
+		}
+		colName, err := cols.CurrentColName()
+		assert.NoError(t, err)
+		colNames = append(colNames, colName)
+	}
+	assert.Equal(t, []string{"B", "D"}, colNames)
+	assert.NoError(t, f.Close())
+}
+
+func TestColsSeek(t *testing.T) {
+	f := NewFile()
+	for _, cell := range []string{"A1", "C2", "E3", "H1", "H4"} {
+		assert.NoError(t, f.SetCellValue("Sheet1", cell, cell))
+	}
+	cols, err := f.Cols("Sheet1")
+	assert.NoError(t, err)
+	assert.NoError(t, cols.Seek("E"))
+	assert.Equal(t, 4, cols.CurrentCol())
+	// Test read the cells before the first iteration after seek
+	col, err := cols.Rows()
+	assert.NoError(t, err)
+	assert.Empty(t, col)
+	assert.True(t, cols.Next())
+	col, err = cols.Rows()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"", "", "E3", ""}, col)
+	assert.True(t, cols.Next())
+	assert.True(t, cols.Next())
+	assert.True(t, cols.Next())
+	col, err = cols.Rows()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"H1", "", "", "H4"}, col)
+	// Test seek backwards to the columns that have been read
+	assert.NoError(t, cols.Seek("A"))
+	assert.True(t, cols.Next())
+	col, err = cols.Rows()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"A1", "", "", ""}, col)
+	assert.True(t, cols.Next())
+	assert.True(t, cols.Next())
+	col, err = cols.Rows()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"", "C2", "", ""}, col)
+	// Test seek beyond the extent of the columns
+	assert.NoError(t, cols.Seek("I"))
+	assert.False(t, cols.Next())
+	col, err = cols.Rows()
+	assert.NoError(t, err)
+	assert.Empty(t, col)
+	// Test seek with invalid column name
+	assert.Equal(t, newInvalidColumnNameError("*"), cols.Seek("*"))
+	assert.NoError(t, f.Close())
+}
+
+func TestColsRange(t *testing.T) {
+	f := NewFile()
+	for _, cell := range []string{"A1", "C2", "E3", "H1", "H4", "Z5"} {
+		assert.NoError(t, f.SetCellValue("Sheet1", cell, cell))
+	}
+	cols, err := f.ColsRange("Sheet1", "E", "C")
+	assert.NoError(t, err)
+	assert.Equal(t, 5, cols.TotalCols())
+	assert.Equal(t, 5, cols.TotalRows())
+	assert.Equal(t, 2, cols.CurrentCol())
+	// Test read the cells before the first iteration
+	col, err := cols.Rows()
+	assert.NoError(t, err)
+	assert.Empty(t, col)
+	var data []ColumnData
+	for cols.Next() {
+		col, err := cols.Data(ColumnDataOptions{TrimTrailingEmptyCells: true})
+		assert.NoError(t, err)
+		data = append(data, col)
+	}
+	assert.Equal(t, []ColumnData{
+		{Name: "C", Index: 3, Cells: []string{"", "C2"}},
+		{Name: "D", Index: 4, Cells: []string{}},
+		{Name: "E", Index: 5, Cells: []string{"", "", "E3"}},
+	}, data)
+	// Test seek within and out of the column range
+	assert.NoError(t, cols.Seek("D"))
+	assert.True(t, cols.Next())
+	assert.Equal(t, 4, cols.CurrentCol())
+	assert.Equal(t, ErrColumnNumber, cols.Seek("B"))
+	assert.Equal(t, ErrColumnNumber, cols.Seek("F"))
+	// Test bounded column range beyond the extent of the columns
+	cols, err = f.ColsRange("Sheet1", "AA", "AZ")
+	assert.NoError(t, err)
+	assert.Equal(t, 26, cols.TotalCols())
+	assert.False(t, cols.Next())
+	// Test bounded column range with the row range
+	cols, err = f.ColsRange("Sheet1", "H", "Z")
+	assert.NoError(t, err)
+	assert.NoError(t, cols.SetRowRange(2, 4))
+	assert.True(t, cols.Next())
+	col, err = cols.Rows()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"", "", "H4"}, col)
+	// Test get columns iterator with invalid column name
+	_, err = f.ColsRange("Sheet1", "*", "Z")
+	assert.Equal(t, newInvalidColumnNameError("*"), err)
+	// Test get columns iterator on not exists worksheet
+	_, err = f.ColsRange("SheetN", "A", "Z")
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+	assert.NoError(t, f.Close())
+}
+
+func TestGetColsBoth(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A1", &[]interface{}{"Date", "Rate", "Hidden"}))
+	assert.NoError(t, f.SetCellValue("Sheet1", "A2", 45000))
+	assert.NoError(t, f.SetCellValue("Sheet1", "B4", 0.125))
+	assert.NoError(t, f.SetCellValue("Sheet1", "C5", 7))
+	dateStyle, err := f.NewStyle(&Style{NumFmt: 14})
+	assert.NoError(t, err)
+	percentStyle, err := f.NewStyle(&Style{NumFmt: 10})
+	assert.NoError(t, err)
+	hiddenFmt := ";;;"
+	hiddenStyle, err := f.NewStyle(&Style{CustomNumFmt: &hiddenFmt})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellStyle("Sheet1", "A2", "A2", dateStyle))
+	assert.NoError(t, f.SetCellStyle("Sheet1", "B4", "B4", percentStyle))
+	assert.NoError(t, f.SetCellStyle("Sheet1", "C5", "C5", hiddenStyle))
+	formatted, raw, err := f.GetColsBoth("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{{"Date", "03-15-23", "", "", ""}, {"Rate", "", "", "12.50%", ""}, {"Hidden", "", "", "", ""}}, formatted)
+	assert.Equal(t, [][]string{{"Date", "45000", "", "", ""}, {"Rate", "", "", "0.125", ""}, {"Hidden", "", "", "", "7"}}, raw)
+	// Test the values are the same with the GetCols function
+	cols, err := f.GetCols("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, cols, formatted)
+	cols, err = f.GetCols("Sheet1", Options{RawCellValue: true})
+	assert.NoError(t, err)
+	assert.Equal(t, cols, raw)
+	// Test get columns values with trim trailing empty cells
+	formatted, raw, err = f.GetColsBoth("Sheet1", Options{TrimTrailingEmptyCells: true})
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{{"Date", "03-15-23"}, {"Rate", "", "", "12.50%"}, {"Hidden", "", "", "", ""}}, formatted)
+	assert.Equal(t, [][]string{{"Date", "45000"}, {"Rate", "", "", "0.125"}, {"Hidden", "", "", "", "7"}}, raw)
+	// Test get columns values before the first iteration and with row range
+	itr, err := f.Cols("Sheet1")
+	assert.NoError(t, err)
+	col, rawCol, err := itr.RowsBoth()
+	assert.NoError(t, err)
+	assert.Empty(t, col)
+	assert.Empty(t, rawCol)
+	assert.NoError(t, itr.SetRowRange(2, 4))
+	assert.True(t, itr.Next())
+	assert.True(t, itr.Next())
+	col, rawCol, err = itr.RowsBoth()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"", "", "12.50%"}, col)
+	assert.Equal(t, []string{"", "", "0.125"}, rawCol)
+	// Test get columns values on not exists worksheet
+	_, _, err = f.GetColsBoth("SheetN")
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+	assert.NoError(t, f.Close())
+}
+
+func TestGetColsWithTypes(t *testing.T) {
+	f := NewFile()
+	f.Pkg.Store("xl/worksheets/sheet1.xml", []byte(xml.Header+`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData><row r="1"><c r="A1" t="inlineStr"><is><t>0042</t></is></c><c r="B1" s="1"><v>42</v></c></row><row r="2"><c r="A2" t="inlineStr"><is><t></t></is></c><c r="B2" t="b"><v>1</v></c></row><row r="4"><c r="A4" s="1"/><c r="B4" t="str"><f>B1</f><v>42</v></c></row></sheetData></worksheet>`))
+	f.Sheet.Delete("xl/worksheets/sheet1.xml")
+	f.checked.Delete("xl/worksheets/sheet1.xml")
+	cols, err := f.GetColsWithTypes("Sheet1", Options{RawCellValue: true})
+	assert.NoError(t, err)
+	assert.Equal(t, [][]TypedCell{
+		{{Value: "0042", Type: CellTypeInlineString}, {Type: CellTypeInlineString}, {}, {StyleID: 1}},
+		{{Value: "42", Type: CellTypeNumber, StyleID: 1}, {Value: "1", Type: CellTypeBool}, {}, {Value: "42", Type: CellTypeFormula}},
+	}, cols)
+	// Test the values are the same with the GetCols function
+	values, err := f.GetCols("Sheet1", Options{RawCellValue: true})
+	assert.NoError(t, err)
+	for i, col := range cols {
+		for j, cell := range col {
+			assert.Equal(t, values[i][j], cell.Value)
+		}
+	}
+	// Test get columns with types on not exists worksheet
+	_, err = f.GetColsWithTypes("SheetN")
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+}
+
+func TestGetColTypedValues(t *testing.T) {
+	f := NewFile()
+	dateStyle, err := f.NewStyle(&Style{NumFmt: 14})
+	assert.NoError(t, err)
+	date := time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC)
+	assert.NoError(t, f.SetSheetCol("Sheet1", "A1", &[]interface{}{date, 45000, 45000, "2024-01-02", "text", "2024-01-02T10:30:00Z"}))
+	assert.NoError(t, f.SetCellStyle("Sheet1", "A3", "A3", dateStyle))
+	assert.NoError(t, f.SetSheetCol("Sheet1", "B1", &[]interface{}{true, "false", 1, nil, "yes", " TRUE "}))
+	assert.NoError(t, f.SetSheetCol("Sheet1", "C1", &[]interface{}{"Amount", 12.5, "42", nil, "abc", true, 7}))
+
+	values, parseErrs, err := f.GetColFloats("Sheet1", "C")
+	assert.NoError(t, err)
+	assert.Equal(t, []float64{12.5, 42, 7}, values)
+	assert.Equal(t, []CellParseError{
+		{Cell: "C1", Value: "Amount", Reason: "not a number"},
+		{Cell: "C5", Value: "abc", Reason: "not a number"},
+		{Cell: "C6", Value: "1", Reason: "not a number"},
+	}, parseErrs)
+	assert.EqualError(t, parseErrs[0], `cannot parse cell C1 value "Amount": not a number`)
+	values, parseErrs, err = f.GetColFloats("Sheet1", "C", ColParseOptions{Blank: BlankCellZero})
+	assert.NoError(t, err)
+	assert.Equal(t, []float64{12.5, 42, 0, 7}, values)
+	assert.Len(t, parseErrs, 3)
+	values, parseErrs, err = f.GetColFloats("Sheet1", "C", ColParseOptions{Blank: BlankCellError})
+	assert.NoError(t, err)
+	assert.Equal(t, []float64{12.5, 42, 7}, values)
+	assert.Len(t, parseErrs, 4)
+	assert.Equal(t, CellParseError{Cell: "C4", Reason: "blank cell"}, parseErrs[1])
+
+	times, parseErrs, err := f.GetColTimes("Sheet1", "A")
+	assert.NoError(t, err)
+	assert.Equal(t, []time.Time{
+		date, time.Date(2023, 3, 15, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 2, 10, 30, 0, 0, time.UTC),
+	}, times)
+	assert.Equal(t, []CellParseError{
+		{Cell: "A2", Value: "45000", Reason: "not a date or time number format"},
+		{Cell: "A5", Value: "text", Reason: "not a date or time"},
+	}, parseErrs)
+
+	bools, parseErrs, err := f.GetColBools("Sheet1", "B", ColParseOptions{Blank: BlankCellZero})
+	assert.NoError(t, err)
+	assert.Equal(t, []bool{true, false, false, true, false}, bools)
+	assert.Equal(t, []CellParseError{
+		{Cell: "B3", Value: "1", Reason: "not a boolean"},
+		{Cell: "B5", Value: "yes", Reason: "not a boolean"},
+	}, parseErrs)
+
+	// Test get column values with the blank cells after the last cell
+	assert.NoError(t, f.SetCellValue("Sheet1", "E1", 1))
+	values, parseErrs, err = f.GetColFloats("Sheet1", "E", ColParseOptions{Blank: BlankCellZero})
+	assert.NoError(t, err)
+	assert.Equal(t, []float64{1, 0, 0, 0, 0, 0, 0}, values)
+	assert.Empty(t, parseErrs)
+	values, parseErrs, err = f.GetColFloats("Sheet1", "F", ColParseOptions{Blank: BlankCellError})
+	assert.NoError(t, err)
+	assert.Empty(t, values)
+	assert.Len(t, parseErrs, 7)
+	assert.Equal(t, CellParseError{Cell: "F7", Reason: "blank cell"}, parseErrs[6])
+
+	// Test get column times with the 1904 date system
+	assert.NoError(t, f.SetWorkbookProps(&WorkbookPropsOptions{Date1904: boolPtr(true)}))
+	assert.NoError(t, f.SetCellValue("Sheet1", "D1", 0))
+	assert.NoError(t, f.SetCellStyle("Sheet1", "D1", "D1", dateStyle))
+	times, parseErrs, err = f.GetColTimes("Sheet1", "D")
+	assert.NoError(t, err)
+	assert.Empty(t, parseErrs)
+	assert.Equal(t, []time.Time{time.Date(1904, 1, 1, 0, 0, 0, 0, time.UTC)}, times)
+	// Test get column values in the column without cells
+	values, parseErrs, err = f.GetColFloats("Sheet1", "Z")
+	assert.NoError(t, err)
+	assert.Empty(t, values)
+	assert.Empty(t, parseErrs)
+	// Test get column values with invalid column name
+	_, _, err = f.GetColFloats("Sheet1", "*")
+	assert.EqualError(t, err, newInvalidColumnNameError("*").Error())
+	// Test get column values on not exists worksheet
+	_, _, err = f.GetColBools("SheetN", "A")
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+	// Test get column values with unsupported charset shared strings table
+	f.SharedStrings = nil
+	f.Pkg.Store(defaultXMLPathSharedStrings, MacintoshCyrillicCharset)
+	_, _, err = f.GetColFloats("Sheet1", "C")
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	// Test get column times with unsupported charset workbook
+	f.WorkBook = nil
+	f.Pkg.Store(defaultXMLPathWorkbook, MacintoshCyrillicCharset)
+	_, _, err = f.GetColTimes("Sheet1", "A")
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	assert.False(t, f.isDateTimeNumFmt(-1))
+	assert.NoError(t, f.Close())
+}
+
+func TestGetColStats(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetSheetCol("Sheet1", "C1", &[]interface{}{"Amount", 1234, -5.5, nil, true, "42", "text", 10}))
+	assert.NoError(t, f.SetCellValue("Sheet1", "A10", "last"))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "C9", "1/0"))
+	ws, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	ws.SheetData.Row[8].C[2].T, ws.SheetData.Row[8].C[2].V = "e", "#DIV/0!"
+	style, err := f.NewStyle(&Style{NumFmt: 4})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellStyle("Sheet1", "C2", "C2", style))
+
+	// Test get column statistics with the formatted values
+	stats, err := f.GetColStats("Sheet1", "C")
+	assert.NoError(t, err)
+	assert.Equal(t, ColStats{
+		Count: 8, CountNumeric: 2, CountBlank: 2, CountBool: 1, CountError: 1,
+		Sum: 4.5, Min: -5.5, Max: 10, Mean: 2.25,
+	}, stats)
+	// Test get column statistics with the raw values
+	stats, err = f.GetColStats("Sheet1", "C", Options{RawCellValue: true})
+	assert.NoError(t, err)
+	assert.Equal(t, ColStats{
+		Count: 8, CountNumeric: 3, CountBlank: 2, CountBool: 1, CountError: 1,
+		Sum: 1238.5, Min: -5.5, Max: 1234, Mean: 1238.5 / 3,
+	}, stats)
+	// Test get column statistics with non-finite text and numeric values
+	assert.NoError(t, f.SetSheetCol("Sheet1", "D1", &[]interface{}{"NaN", "Inf", "-Infinity", 2}))
+	ws.SheetData.Row[2].C[3].T, ws.SheetData.Row[2].C[3].V = "", "NaN"
+	stats, err = f.GetColStats("Sheet1", "D", Options{RawCellValue: true})
+	assert.NoError(t, err)
+	assert.Equal(t, ColStats{Count: 4, CountNumeric: 1, CountBlank: 6, Sum: 2, Min: 2, Max: 2, Mean: 2}, stats)
+	// Test get column statistics in the column without cells
+	stats, err = f.GetColStats("Sheet1", "Z")
+	assert.NoError(t, err)
+	assert.Equal(t, ColStats{CountBlank: 10}, stats)
+	// Test get column statistics with invalid column name
+	_, err = f.GetColStats("Sheet1", "*")
+	assert.EqualError(t, err, newInvalidColumnNameError("*").Error())
+	// Test get column statistics on not exists worksheet
+	_, err = f.GetColStats("SheetN", "C")
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+	// Test get column statistics with unsupported charset shared strings table
+	f.SharedStrings = nil
+	f.Pkg.Store(defaultXMLPathSharedStrings, MacintoshCyrillicCharset)
+	_, err = f.GetColStats("Sheet1", "C")
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
+
+func TestSearchCols(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetSheetCol("Sheet1", "B1", &[]interface{}{"inv-001", "Total", 100}))
+	assert.NoError(t, f.SetSheetCol("Sheet1", "C1", &[]interface{}{"INV-001", nil, "INV-002", 100, "memo"}))
+	assert.NoError(t, f.SetSheetCol("Sheet1", "E1", &[]interface{}{"inv-003", 100}))
+	assert.NoError(t, f.SetCellValue("Sheet1", "F1", "INV-004"))
+	style, err := f.NewStyle(&Style{NumFmt: 2})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellStyle("Sheet1", "E2", "E2", style))
+
+	// Test search cells in a single column exactly
+	results, err := f.SearchCols("Sheet1", "C", "INV-001")
+	assert.NoError(t, err)
+	assert.Equal(t, []SearchResult{{Cell: "C1", Value: "INV-001", Row: 1}}, results)
+	// Test search cells case insensitively
+	results, err = f.SearchCols("Sheet1", "B:C", "inv-001", SearchOptions{Match: SearchMatchCaseInsensitive})
+	assert.NoError(t, err)
+	assert.Equal(t, []SearchResult{
+		{Cell: "B1", Value: "inv-001", Row: 1},
+		{Cell: "C1", Value: "INV-001", Row: 1},
+	}, results)
+	// Test search cells by regular expression in the reversed columns range
+	results, err = f.SearchCols("Sheet1", "E:C", "(?i)^inv-", SearchOptions{Match: SearchMatchRegex})
+	assert.NoError(t, err)
+	assert.Equal(t, []SearchResult{
+		{Cell: "C1", Value: "INV-001", Row: 1},
+		{Cell: "C3", Value: "INV-002", Row: 3},
+		{Cell: "E1", Value: "inv-003", Row: 1},
+	}, results)
+	// Test search cells by the formatted and raw value
+	results, err = f.SearchCols("Sheet1", "A:F", "100")
+	assert.NoError(t, err)
+	assert.Equal(t, []SearchResult{
+		{Cell: "B3", Value: "100", Row: 3},
+		{Cell: "C4", Value: "100", Row: 4},
+	}, results)
+	results, err = f.SearchCols("Sheet1", "E", "100.00")
+	assert.NoError(t, err)
+	assert.Equal(t, []SearchResult{{Cell: "E2", Value: "100.00", Row: 2}}, results)
+	results, err = f.SearchCols("Sheet1", "E", "100", SearchOptions{RawCellValue: true})
+	assert.NoError(t, err)
+	assert.Equal(t, []SearchResult{{Cell: "E2", Value: "100", Row: 2}}, results)
+	// Test search blank cells and cells in the columns without cells
+	results, err = f.SearchCols("Sheet1", "C", "")
+	assert.NoError(t, err)
+	assert.Empty(t, results)
+	results, err = f.SearchCols("Sheet1", "X:Z", ".*", SearchOptions{Match: SearchMatchRegex})
+	assert.NoError(t, err)
+	assert.Empty(t, results)
+	// Test search cells with invalid options
+	_, err = f.SearchCols("Sheet1", "C", "INV", SearchOptions{Match: 3})
+	assert.Equal(t, ErrParameterInvalid, err)
+	_, err = f.SearchCols("Sheet1", "C", "[", SearchOptions{Match: SearchMatchRegex})
+	assert.EqualError(t, err, "error parsing regexp: missing closing ]: `[`")
+	_, err = f.SearchCols("Sheet1", "*", "INV")
+	assert.EqualError(t, err, newInvalidColumnNameError("*").Error())
+	// Test search cells on not exists worksheet
+	_, err = f.SearchCols("SheetN", "C", "INV")
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+	// Test search cells with unsupported charset shared strings table
+	f.SharedStrings = nil
+	f.Pkg.Store(defaultXMLPathSharedStrings, MacintoshCyrillicCharset)
+	_, err = f.SearchCols("Sheet1", "C", "INV")
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
+
 func TestColumnsIterator(t *testing.T) {
 	sheetName, colCount, expectedNumCol := "Sheet2", 0, 9
 	f, err := OpenFile(filepath.Join("test", "Book1.xlsx"))
@@ -152,7 +566,7 @@ func TestGetColsError(t *testing.T) {
 	cols.totalRows = 2
 	cols.totalCols = 2
 	cols.curCol = 1
-	cols.sheetXML = []byte(fmt.Sprintf(`<worksheet xmlns="%s"><sheetData><row r="1"><c r="A" t="inlineStr"><is><t>A</t></is></c></row></sheetData></worksheet>`, NameSpaceSpreadSheet.Value))
+	cols.sheetReader = bytes.NewReader([]byte(fmt.Sprintf(`<worksheet xmlns="%s"><sheetData><row r="1"><c r="A" t="inlineStr"><is><t>A</t></is></c></row></sheetData></worksheet>`, NameSpaceSpreadSheet.Value)))
 	_, err = cols.Rows()
 	assert.EqualError(t, err, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")).Error())
 
@@ -162,6 +576,230 @@ func TestGetColsError(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestColsProps(t *testing.T) {
+	f := NewFile()
+	style, err := f.NewStyle(&Style{Font: &Font{Bold: true}})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetColWidth("Sheet1", "B", "D", 20))
+	assert.NoError(t, f.SetColVisible("Sheet1", "C", false))
+	assert.NoError(t, f.SetColStyle("Sheet1", "F:G", style))
+	assert.NoError(t, f.SetColOutlineLevel("Sheet1", "H", 2))
+	props, err := f.GetColsProps("Sheet1")
+	assert.NoError(t, err)
+	ws, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, props, len(ws.Cols.Col))
+	for idx, col := range ws.Cols.Col {
+		assert.Equal(t, col.Min, props[idx].Min)
+		assert.Equal(t, col.Max, props[idx].Max)
+		assert.Equal(t, col.Width, props[idx].Width)
+		assert.Equal(t, col.Hidden, props[idx].Hidden)
+		assert.Equal(t, col.Style, props[idx].Style)
+		assert.Equal(t, col.OutlineLevel, props[idx].OutlineLevel)
+	}
+	// Test the column definitions round trip to another worksheet
+	_, err = f.NewSheet("Sheet2")
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetColsProps("Sheet2", props))
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestColsProps.xlsx")))
+	getCols := func(path string) string {
+		content, ok := f.Pkg.Load(path)
+		assert.True(t, ok)
+		xml := string(content.([]byte))
+		return xml[strings.Index(xml, "<cols>"):strings.Index(xml, "</cols>")]
+	}
+	assert.Equal(t, getCols("xl/worksheets/sheet1.xml"), getCols("xl/worksheets/sheet2.xml"))
+
+	// Test get column definitions in document order
+	f.Sheet.Delete("xl/worksheets/sheet1.xml")
+	f.Pkg.Store("xl/worksheets/sheet1.xml", []byte(fmt.Sprintf(`<worksheet xmlns="%s"><cols><col min="4" max="6" width="9.5" customWidth="1" bestFit="1"/><col min="1" max="2" hidden="1" collapsed="1" phonetic="1"/></cols><sheetData/></worksheet>`, NameSpaceSpreadSheet.Value)))
+	f.checked.Delete("xl/worksheets/sheet1.xml")
+	props, err = f.GetColsProps("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, []ColPropsRange{
+		{Min: 4, Max: 6, Width: float64Ptr(9.5), CustomWidth: true, BestFit: true},
+		{Min: 1, Max: 2, Hidden: true, Collapsed: true, Phonetic: true},
+	}, props)
+	// Test remove the column definitions
+	assert.NoError(t, f.SetColsProps("Sheet1", nil))
+	props, err = f.GetColsProps("Sheet1")
+	assert.NoError(t, err)
+	assert.Empty(t, props)
+
+	// Test set column definitions with invalid column ranges
+	for _, props := range [][]ColPropsRange{{{Min: 0, Max: 1}}, {{Min: 1, Max: MaxColumns + 1}}, {{Min: 3, Max: 2}}} {
+		assert.Equal(t, ErrColumnNumber, f.SetColsProps("Sheet1", props))
+	}
+	assert.Equal(t, ErrColumnRangeOverlap, f.SetColsProps("Sheet1", []ColPropsRange{{Min: 5, Max: 8}, {Min: 1, Max: 5}}))
+	// Test set column definitions with invalid settings
+	assert.Equal(t, ErrOutlineLevel, f.SetColsProps("Sheet1", []ColPropsRange{{Min: 1, Max: 1, OutlineLevel: 8}}))
+	assert.Equal(t, ErrColumnWidthValue, f.SetColsProps("Sheet1", []ColPropsRange{{Min: 1, Max: 1, Width: float64Ptr(-1)}}))
+	assert.Equal(t, newInvalidStyleID(100), f.SetColsProps("Sheet1", []ColPropsRange{{Min: 1, Max: 1, Style: 100}}))
+	assert.Equal(t, newInvalidStyleID(-1), f.SetColsProps("Sheet1", []ColPropsRange{{Min: 1, Max: 1, Style: -1}}))
+	// Test get and set column definitions on not exists worksheet
+	_, err = f.GetColsProps("SheetN")
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+	assert.EqualError(t, f.SetColsProps("SheetN", nil), "sheet SheetN does not exist")
+	// Test set column definitions with unsupported charset style sheet
+	f.Styles = nil
+	f.Pkg.Store(defaultXMLPathStyles, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.SetColsProps("Sheet1", nil), "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
+
+func TestAddComputedCol(t *testing.T) {
+	f := NewFile()
+	for idx, row := range [][]interface{}{{"Price", "Qty"}, {2, 3}, {4, 5}, {6, 7}} {
+		cell, err := CoordinatesToCellName(1, idx+1)
+		assert.NoError(t, err)
+		assert.NoError(t, f.SetSheetRow("Sheet1", cell, &row))
+	}
+	assert.NoError(t, f.AddComputedCol("Sheet1", "C", ComputedColOptions{
+		FormulaTemplate: "A{row}*B{row}", HeaderRow: 1, Header: "Amount",
+	}))
+	val, err := f.GetCellValue("Sheet1", "C1")
+	assert.NoError(t, err)
+	assert.Equal(t, "Amount", val)
+	ws, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, STCellFormulaTypeShared, ws.SheetData.Row[1].C[2].F.T)
+	assert.Equal(t, "C2:C4", ws.SheetData.Row[1].C[2].F.Ref)
+	for cell, expected := range map[string]string{"C2": "6", "C3": "20", "C4": "42"} {
+		val, err = f.CalcCellValue("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, val, cell)
+	}
+	// Test add computed column on the column with data
+	assert.Equal(t, ErrComputedColExists, f.AddComputedCol("Sheet1", "C", ComputedColOptions{FormulaTemplate: "A{row}"}))
+	// Test add computed column with the formula can't be shared
+	assert.NoError(t, f.AddComputedCol("Sheet1", "C", ComputedColOptions{
+		FormulaTemplate: "A{row}*$B${row}", HeaderRow: 1, Overwrite: true,
+	}))
+	val, err = f.GetCellValue("Sheet1", "C1")
+	assert.NoError(t, err)
+	assert.Equal(t, "Amount", val)
+	for row := 2; row <= 4; row++ {
+		cell := fmt.Sprintf("C%d", row)
+		formula, err := f.GetCellFormula("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, fmt.Sprintf("A%d*$B$%d", row, row), formula)
+		assert.Empty(t, ws.SheetData.Row[row-1].C[2].F.T)
+	}
+	// Test add computed column by the function
+	assert.NoError(t, f.AddComputedCol("Sheet1", "D", ComputedColOptions{
+		ValueFunc: func(rowValues map[string]string) (interface{}, error) {
+			return rowValues["A"] + "x" + rowValues["B"], nil
+		},
+		HeaderRow: 1,
+	}))
+	cols, err := f.GetCols("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"", "2x3", "4x5", "6x7"}, cols[3])
+	assert.Equal(t, ErrParameterInvalid, f.AddComputedCol("Sheet1", "E", ComputedColOptions{
+		ValueFunc: func(rowValues map[string]string) (interface{}, error) {
+			return nil, ErrParameterInvalid
+		},
+	}))
+	// Test add computed column on the worksheet without data rows
+	_, err = f.NewSheet("Sheet2")
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellValue("Sheet2", "A1", "Price"))
+	assert.NoError(t, f.AddComputedCol("Sheet2", "B", ComputedColOptions{FormulaTemplate: "A{row}", HeaderRow: 1, Header: "Copy"}))
+	rows, err := f.GetRows("Sheet2")
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{{"Price", "Copy"}}, rows)
+	// Test add computed column with invalid options
+	assert.Equal(t, ErrComputedColOptions, f.AddComputedCol("Sheet1", "E", ComputedColOptions{}))
+	assert.Equal(t, ErrComputedColOptions, f.AddComputedCol("Sheet1", "E", ComputedColOptions{
+		FormulaTemplate: "A{row}",
+		ValueFunc:       func(rowValues map[string]string) (interface{}, error) { return nil, nil },
+	}))
+	assert.Equal(t, newInvalidRowNumberError(-1), f.AddComputedCol("Sheet1", "E", ComputedColOptions{FormulaTemplate: "A{row}", HeaderRow: -1}))
+	assert.Equal(t, newInvalidColumnNameError("-"), f.AddComputedCol("Sheet1", "-", ComputedColOptions{FormulaTemplate: "A{row}"}))
+	assert.EqualError(t, f.AddComputedCol("SheetN", "E", ComputedColOptions{FormulaTemplate: "A{row}"}), "sheet SheetN does not exist")
+	assert.NoError(t, f.Close())
+}
+
+func TestColsCellValueTransformer(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetSheetCol("Sheet1", "A1", &[]interface{}{"1,5", nil, "2,5"}))
+	assert.NoError(t, f.SetCellValue("Sheet1", "B2", "x"))
+	style, err := f.NewStyle(&Style{NumFmt: 2})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellStyle("Sheet1", "A2", "A2", style))
+	var metas []CellMeta
+	transformer := func(cell string, meta CellMeta, value string) (string, error) {
+		metas = append(metas, meta)
+		if value == "x" {
+			return value, ErrParameterInvalid
+		}
+		return strings.ReplaceAll(value, ",", "."), nil
+	}
+	cols, err := f.Cols("Sheet1")
+	assert.NoError(t, err)
+	assert.True(t, cols.Next())
+	cells, err := cols.Rows(Options{CellValueTransformer: transformer})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"1.5", "", "2.5"}, cells)
+	assert.Equal(t, []CellMeta{
+		{Sheet: "Sheet1", Cell: "A1", Type: CellTypeSharedString},
+		{Sheet: "Sheet1", Cell: "A3", Type: CellTypeSharedString},
+	}, metas)
+	formatted, raw, err := cols.RowsBoth(Options{CellValueTransformer: transformer})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"1.5", "", "2.5"}, formatted)
+	assert.Equal(t, []string{"1.5", "", "2.5"}, raw)
+	assert.True(t, cols.Next())
+	_, err = cols.Rows(Options{CellValueTransformer: transformer})
+	assert.Equal(t, ErrTransformCellValue{Sheet: "Sheet1", Cell: "B2", Err: ErrParameterInvalid}, err)
+	// Test get columns with the cell value transformer
+	_, err = f.GetCols("Sheet1", Options{CellValueTransformer: transformer})
+	assert.Equal(t, ErrTransformCellValue{Sheet: "Sheet1", Cell: "B2", Err: ErrParameterInvalid}, err)
+	_, _, err = f.GetColsBoth("Sheet1", Options{CellValueTransformer: transformer})
+	assert.Equal(t, ErrTransformCellValue{Sheet: "Sheet1", Cell: "B2", Err: ErrParameterInvalid}, err)
+	_, err = f.GetColsWithTypes("Sheet1", Options{CellValueTransformer: transformer})
+	assert.Equal(t, ErrTransformCellValue{Sheet: "Sheet1", Cell: "B2", Err: ErrParameterInvalid}, err)
+	assert.Equal(t, ErrTransformCellValue{Sheet: "Sheet1", Cell: "B2", Err: ErrParameterInvalid}, f.WalkCols("Sheet1", func(colName string, cells []string) error {
+		return nil
+	}, Options{CellValueTransformer: transformer}))
+	// Test the cell value transformer specified on opening the workbook
+	assert.NoError(t, f.SetCellValue("Sheet1", "B2", "y"))
+	f.options.CellValueTransformer = transformer
+	results, err := f.GetCols("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{{"1.5", "", "2.5"}, {"", "y"}}, results)
+	typed, err := f.GetColsWithTypes("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, TypedCell{Value: "1.5", Type: CellTypeSharedString}, typed[0][0])
+	assert.NoError(t, f.Close())
+}
+
+func TestColsIgnoreInvalidCellRef(t *testing.T) {
+	f := NewFile()
+	f.Sheet.Delete("xl/worksheets/sheet1.xml")
+	f.Pkg.Store("xl/worksheets/sheet1.xml", []byte(fmt.Sprintf(`<worksheet xmlns="%s"><sheetData><row r="1"><c r="A1" t="str"><v>a</v></c><c r="1B" t="str"><v>b</v></c><c r="C1" t="str"><v>c</v></c></row><row r="2"><c r="A2" t="str"><v>d</v></c><c t="str"><v>e</v></c></row></sheetData></worksheet>`, NameSpaceSpreadSheet.Value)))
+	f.checked = sync.Map{}
+	_, err := f.GetCols("Sheet1")
+	assert.Equal(t, newCellNameToCoordinatesError("1B", newInvalidCellNameError("1B")), err)
+	// Test read columns with the invalid cell reference skipped
+	cols, err := f.Cols("Sheet1", Options{IgnoreInvalidCellRef: true})
+	assert.NoError(t, err)
+	var results [][]string
+	for cols.Next() {
+		col, err := cols.Rows()
+		assert.NoError(t, err)
+		results = append(results, col)
+	}
+	assert.Equal(t, [][]string{{"a", "d"}, {"b", "e"}, {"c"}}, results)
+	assert.Equal(t, []error{ErrInvalidCellRef{
+		Sheet: "Sheet1", Row: 1, Ref: "1B", Err: newCellNameToCoordinatesError("1B", newInvalidCellNameError("1B")),
+	}}, cols.Warnings())
+	results, err = f.GetCols("Sheet1", Options{IgnoreInvalidCellRef: true})
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{{"a", "d"}, {"b", "e"}, {"c"}}, results)
+	assert.NoError(t, f.Close())
+}
+
 func TestColsRows(t *testing.T) {
 	f := NewFile()
 
@@ -190,85 +828,928 @@ func TestColsRows(t *testing.T) {
 	assert.NoError(t, err)
 	cols.stashCol, cols.curCol = 0, 1
 	// Test if token is nil
-	cols.sheetXML = nil
+	cols.sheetReader = bytes.NewReader(nil)
 	_, err = cols.Rows()
 	assert.NoError(t, err)
 }
 
-func TestColumnVisibility(t *testing.T) {
-	t.Run("TestBook1", func(t *testing.T) {
-		f, err := prepareTestBook1()
+func TestGetColsTrimTrailingEmptyCells(t *testing.T) {
+	f := NewFile()
+	styleID, err := f.NewStyle(&Style{Font: &Font{Bold: true}})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellValue("Sheet1", "A1", "A1"))
+	assert.NoError(t, f.SetCellValue("Sheet1", "A3", "A3"))
+	assert.NoError(t, f.SetCellStyle("Sheet1", "A5", "A5", styleID))
+	assert.NoError(t, f.SetCellValue("Sheet1", "B1000", "B1000"))
+	assert.NoError(t, f.SetCellValue("Sheet1", "C2", ""))
+	for _, f := range []*File{f, func() *File {
+		buf, err := f.WriteToBuffer()
 		assert.NoError(t, err)
-
-		// Hide/display a column with SetColVisible
-		assert.NoError(t, f.SetColVisible("Sheet1", "F", false))
-		assert.NoError(t, f.SetColVisible("Sheet1", "F", true))
-		visible, err := f.GetColVisible("Sheet1", "F")
-		assert.Equal(t, true, visible)
+		f, err := OpenReader(buf)
 		assert.NoError(t, err)
-
-		// Test hiding a few columns SetColVisible(...false)...
-		assert.NoError(t, f.SetColVisible("Sheet1", "F:V", false))
-		visible, err = f.GetColVisible("Sheet1", "F")
-		assert.Equal(t, false, visible)
+		return f
+	}()} {
+		cols, err := f.GetCols("Sheet1")
 		assert.NoError(t, err)
-		visible, err = f.GetColVisible("Sheet1", "U")
-		assert.Equal(t, false, visible)
+		assert.Len(t, cols, 3)
+		assert.Greater(t, len(cols[0]), 3)
+		cols, err = f.GetCols("Sheet1", Options{TrimTrailingEmptyCells: true})
 		assert.NoError(t, err)
-		visible, err = f.GetColVisible("Sheet1", "V")
-		assert.Equal(t, false, visible)
+		assert.Equal(t, []string{"A1", "", "A3"}, cols[0])
+		assert.Len(t, cols[1], 1000)
+		assert.Equal(t, "B1000", cols[1][999])
+		assert.Empty(t, cols[2])
+		// Test trim trailing empty cells with the columns iterator
+		iter, err := f.Cols("Sheet1")
 		assert.NoError(t, err)
-		// ...and displaying them back SetColVisible(...true)
-		assert.NoError(t, f.SetColVisible("Sheet1", "V:F", true))
-		visible, err = f.GetColVisible("Sheet1", "F")
-		assert.Equal(t, true, visible)
+		assert.True(t, iter.Next())
+		col, err := iter.Rows(Options{TrimTrailingEmptyCells: true})
 		assert.NoError(t, err)
-		visible, err = f.GetColVisible("Sheet1", "U")
-		assert.Equal(t, true, visible)
+		assert.Equal(t, []string{"A1", "", "A3"}, col)
+		assert.NoError(t, f.Close())
+	}
+	// Test trim trailing empty cells with the option on open the spreadsheet
+	f = NewFile(Options{TrimTrailingEmptyCells: true})
+	assert.NoError(t, f.SetCellValue("Sheet1", "A1", "A1"))
+	assert.NoError(t, f.SetCellValue("Sheet1", "B10", "B10"))
+	cols, err := f.GetCols("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{{"A1"}, {"", "", "", "", "", "", "", "", "", "B10"}}, cols)
+	assert.NoError(t, f.Close())
+}
+
+func TestGetColsBlankCellValue(t *testing.T) {
+	f := NewFile()
+	styleID, err := f.NewStyle(&Style{Font: &Font{Bold: true}})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellValue("Sheet1", "A1", "A1"))
+	assert.NoError(t, f.SetCellStyle("Sheet1", "A2", "A2", styleID))
+	assert.NoError(t, f.SetCellValue("Sheet1", "A3", ""))
+	assert.NoError(t, f.SetCellValue("Sheet1", "A5", "A5"))
+	assert.NoError(t, f.SetCellValue("Sheet1", "B2", ""))
+	for _, f := range []*File{f, func() *File {
+		buf, err := f.WriteToBuffer()
 		assert.NoError(t, err)
-		visible, err = f.GetColVisible("Sheet1", "G")
-		assert.Equal(t, true, visible)
+		f, err := OpenReader(buf)
 		assert.NoError(t, err)
-
-		// Test get column visible on not exists worksheet
-		_, err = f.GetColVisible("SheetN", "F")
-		assert.EqualError(t, err, "sheet SheetN does not exist")
-		// Test get column visible with invalid sheet name
-		_, err = f.GetColVisible("Sheet:1", "F")
-		assert.EqualError(t, err, ErrSheetNameInvalid.Error())
-		// Test get column visible with illegal cell reference
-		_, err = f.GetColVisible("Sheet1", "*")
-		assert.EqualError(t, err, newInvalidColumnNameError("*").Error())
-		assert.EqualError(t, f.SetColVisible("Sheet1", "*", false), newInvalidColumnNameError("*").Error())
-		// Test set column visible with invalid sheet name
-		assert.EqualError(t, f.SetColVisible("Sheet:1", "A", false), ErrSheetNameInvalid.Error())
-
-		_, err = f.NewSheet("Sheet3")
+		return f
+	}()} {
+		cols, err := f.GetCols("Sheet1")
 		assert.NoError(t, err)
-		assert.NoError(t, f.SetColVisible("Sheet3", "E", false))
-		assert.EqualError(t, f.SetColVisible("Sheet1", "A:-1", true), newInvalidColumnNameError("-1").Error())
-		assert.EqualError(t, f.SetColVisible("SheetN", "E", false), "sheet SheetN does not exist")
-		assert.NoError(t, f.SaveAs(filepath.Join("test", "TestColumnVisibility.xlsx")))
-	})
-
-	t.Run("TestBook3", func(t *testing.T) {
-		f, err := prepareTestBook3()
+		assert.Equal(t, [][]string{{"A1", "", "", "", "A5"}, {"", "", "", ""}}, cols)
+		cols, err = f.GetCols("Sheet1", Options{BlankCellValue: "<blank>"})
 		assert.NoError(t, err)
-		visible, err := f.GetColVisible("Sheet1", "B")
-		assert.Equal(t, true, visible)
+		assert.Equal(t, [][]string{{"A1", "<blank>", "", "<blank>", "A5"}, {"<blank>", "", "<blank>", "<blank>"}}, cols)
+		cols, err = f.GetCols("Sheet1", Options{BlankCellValue: "<blank>", TrimTrailingEmptyCells: true})
 		assert.NoError(t, err)
-	})
+		assert.Equal(t, [][]string{{"A1", "<blank>", "", "<blank>", "A5"}, {"<blank>", ""}}, cols)
+		// Test walk columns with blank cell value
+		var walked [][]string
+		assert.NoError(t, f.WalkCols("Sheet1", func(colName string, cells []string) error {
+			walked = append(walked, cells)
+			return nil
+		}, Options{BlankCellValue: "<blank>", TrimTrailingEmptyCells: true}))
+		assert.Equal(t, cols, walked)
+		assert.NoError(t, f.Close())
+	}
 }
 
-func TestOutlineLevel(t *testing.T) {
+func TestGetColsData(t *testing.T) {
 	f := NewFile()
-	level, err := f.GetColOutlineLevel("Sheet1", "D")
-	assert.Equal(t, uint8(0), level)
+	assert.NoError(t, f.SetCellValue("Sheet1", "G50", "G50"))
+	assert.NoError(t, f.SetCellValue("Sheet1", "G52", 52))
+	assert.NoError(t, f.SetCellValue("Sheet1", "I51", "I51"))
+	for _, f := range []*File{f, func() *File {
+		buf, err := f.WriteToBuffer()
+		assert.NoError(t, err)
+		f, err := OpenReader(buf)
+		assert.NoError(t, err)
+		return f
+	}()} {
+		cols, err := f.GetColsData("Sheet1")
+		assert.NoError(t, err)
+		assert.Len(t, cols, 2)
+		assert.Equal(t, "G", cols[0].Name)
+		assert.Equal(t, 7, cols[0].Index)
+		assert.Len(t, cols[0].Cells, 52)
+		assert.Equal(t, "G50", cols[0].Cells[49])
+		assert.Equal(t, "52", cols[0].Cells[51])
+		assert.Nil(t, cols[0].Refs)
+		assert.Equal(t, "I", cols[1].Name)
+		assert.Equal(t, 9, cols[1].Index)
+		assert.Equal(t, "I51", cols[1].Cells[50])
+		// Test get columns data with the cell references
+		cols, err = f.GetColsData("Sheet1", ColumnDataOptions{CellRefs: true, TrimTrailingEmptyCells: true})
+		assert.NoError(t, err)
+		assert.Len(t, cols[0].Cells, 52)
+		assert.Equal(t, []string{"G50", "G52"}, cols[0].Refs)
+		assert.Len(t, cols[1].Cells, 51)
+		assert.Equal(t, []string{"I51"}, cols[1].Refs)
+		for _, col := range cols {
+			for _, ref := range col.Refs {
+				_, row, err := CellNameToCoordinates(ref)
+				assert.NoError(t, err)
+				val, err := f.GetCellValue("Sheet1", ref)
+				assert.NoError(t, err)
+				assert.Equal(t, val, col.Cells[row-1])
+			}
+		}
+		// Test get column data with the columns iterator and row range
+		iter, err := f.Cols("Sheet1")
+		assert.NoError(t, err)
+		assert.NoError(t, iter.SetRowRange(51, 52))
+		for iter.Next() {
+			if iter.CurrentCol() != 7 {
+				continue
+			}
+			col, err := iter.Data(ColumnDataOptions{CellRefs: true})
+			assert.NoError(t, err)
+			assert.Equal(t, ColumnData{Name: "G", Index: 7, Cells: append(make([]string, 51), "52"), Refs: []string{"G52"}}, col)
+		}
+		assert.NoError(t, f.Close())
+	}
+	// Test get column data before the first call of the Next function
+	cols, err := f.Cols("Sheet1")
 	assert.NoError(t, err)
+	_, err = cols.Data()
+	assert.Equal(t, ErrColumnNumber, err)
+	// Test get columns data on not exists worksheet
+	_, err = f.GetColsData("SheetN")
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+	// Test get columns data with invalid cell reference
+	f = NewFile()
+	f.Sheet.Delete("xl/worksheets/sheet1.xml")
+	f.Pkg.Store("xl/worksheets/sheet1.xml", []byte(`<worksheet><sheetData><row r="1"><c r="A1" t="s"><v>0</v></c><c r="B1" t="s"><v>0</v></c></row><row r="2"><c r="A" t="s"><v>0</v></c></row></sheetData></worksheet>`))
+	_, err = f.GetColsData("Sheet1")
+	assert.Error(t, err)
+}
 
-	_, err = f.NewSheet("Sheet2")
+func TestCompareSheets(t *testing.T) {
+	f, other := NewFile(), NewFile()
+	numFmt, err := f.NewStyle(&Style{NumFmt: 2})
 	assert.NoError(t, err)
-	assert.NoError(t, f.SetColOutlineLevel("Sheet1", "D", 4))
+	for cell, value := range map[string]interface{}{"A1": "a", "B1": 1.0001, "A2": "same", "C3": 100} {
+		assert.NoError(t, f.SetCellValue("Sheet1", cell, value))
+	}
+	assert.NoError(t, f.SetCellStyle("Sheet1", "C3", "C3", numFmt))
+	for cell, value := range map[string]interface{}{"A1": "b", "B1": 1.0002, "A2": "same", "C3": 100, "D5": "x"} {
+		assert.NoError(t, other.SetCellValue("Sheet1", cell, value))
+	}
+	fill, err := other.NewStyle(&Style{Fill: Fill{Type: "pattern", Color: []string{"FFFF00"}, Pattern: 1}})
+	assert.NoError(t, err)
+	assert.NoError(t, other.SetCellStyle("Sheet1", "E1", "E1", fill))
+
+	diffs, err := f.CompareSheets(other, "Sheet1", "Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, []CellDiff{
+		{Cell: "A1", Value: "a", OtherValue: "b"},
+		{Cell: "B1", Value: "1.0001", OtherValue: "1.0002"},
+		{Cell: "C3", Value: "100.00", OtherValue: "100"},
+		{Cell: "D5", OtherValue: "x"},
+		{Cell: "E1"},
+	}, diffs)
+	// Test compare worksheets with raw values, numeric tolerance and ignore the
+	// trailing empty cells
+	diffs, err = f.CompareSheets(other, "Sheet1", "Sheet1", CompareOptions{
+		RawCellValue: true, Tolerance: 0.001, IgnoreTrailingEmpty: true, WithRawValues: true, WithStyles: true,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []CellDiff{
+		{Cell: "A1", Value: "a", OtherValue: "b", RawValue: "a", OtherRawValue: "b"},
+		{Cell: "D5", OtherValue: "x", OtherRawValue: "x"},
+	}, diffs)
+	// Test compare worksheets in reverse order with styles
+	diffs, err = other.CompareSheets(f, "Sheet1", "Sheet1", CompareOptions{WithStyles: true})
+	assert.NoError(t, err)
+	assert.Equal(t, []CellDiff{
+		{Cell: "A1", Value: "b", OtherValue: "a"},
+		{Cell: "B1", Value: "1.0002", OtherValue: "1.0001"},
+		{Cell: "C3", Value: "100", OtherValue: "100.00", OtherStyleID: numFmt},
+		{Cell: "D5", Value: "x"},
+		{Cell: "E1", StyleID: fill},
+	}, diffs)
+	// Test compare worksheets with invalid parameters
+	_, err = f.CompareSheets(nil, "Sheet1", "Sheet1")
+	assert.Equal(t, ErrParameterInvalid, err)
+	_, err = f.CompareSheets(other, "SheetN", "Sheet1")
+	assert.Equal(t, ErrSheetNotExist{"SheetN"}, err)
+	_, err = f.CompareSheets(other, "Sheet1", "SheetN")
+	assert.Equal(t, ErrSheetNotExist{"SheetN"}, err)
+	assert.NoError(t, f.Close())
+	assert.NoError(t, other.Close())
+}
+
+func TestCompareCellValue(t *testing.T) {
+	assert.True(t, compareCellValue("1", "1", 0))
+	assert.False(t, compareCellValue("1", "1.0", 0))
+	assert.True(t, compareCellValue("1", " 1.0", 0.1))
+	assert.False(t, compareCellValue("1", "1.2", 0.1))
+	assert.False(t, compareCellValue("a", "1", 0.1))
+	assert.False(t, compareCellValue("1", "a", 0.1))
+}
+
+func TestGetColsDate1904(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetWorkbookProps(&WorkbookPropsOptions{Date1904: boolPtr(true)}))
+	for cell, numFmt := range map[string]string{
+		"A1": "yyyy-mm-dd", "A2": "[h]:mm:ss", "A3": "yyyy-mm-dd hh:mm", "A5": "[$-F800]dddd, mmmm dd, yyyy",
+	} {
+		styleID, err := f.NewStyle(&Style{CustomNumFmt: &numFmt})
+		assert.NoError(t, err)
+		assert.NoError(t, f.SetCellStyle("Sheet1", cell, cell, styleID))
+	}
+	styleID, err := f.NewStyle(&Style{NumFmt: 14})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellStyle("Sheet1", "A4", "A4", styleID))
+	for cell, value := range map[string]float64{"A1": 43831, "A2": 1.5, "A4": 43831, "A5": 43831} {
+		assert.NoError(t, f.SetCellFloat("Sheet1", cell, value, -1, 64))
+	}
+	// Test get the value of the date cell in the ISO 8601 format
+	ws, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	ws.SheetData.Row[2].C[0].T, ws.SheetData.Row[2].C[0].V = "d", "2024-01-02T12:30:00Z"
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestGetColsDate1904.xlsx")))
+	assert.NoError(t, f.Close())
+
+	f, err = OpenFile(filepath.Join("test", "TestGetColsDate1904.xlsx"))
+	assert.NoError(t, err)
+	expected := []string{"2024-01-02", "36:00:00", "2024-01-02 12:30", "01-02-24", "Tuesday, January 02, 2024"}
+	var values []string
+	for row := 1; row <= len(expected); row++ {
+		val, err := f.GetCellValue("Sheet1", fmt.Sprintf("A%d", row))
+		assert.NoError(t, err)
+		values = append(values, val)
+	}
+	assert.Equal(t, expected, values)
+	rows, err := f.GetRows("Sheet1")
+	assert.NoError(t, err)
+	values = values[:0]
+	for _, row := range rows {
+		values = append(values, row[0])
+	}
+	assert.Equal(t, expected, values)
+	cols, err := f.GetCols("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{expected}, cols)
+	assert.NoError(t, f.Close())
+}
+
+func TestGetColIndexByHeader(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A1", &[]interface{}{"Report"}))
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A2", &[]interface{}{" Name ", "", "Revenue", "name", "Name", "Name_2"}))
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A3", &[]interface{}{"Foo", "", 100}))
+	index, err := f.GetColIndexByHeader("Sheet1", 2)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"Name": "A", "Revenue": "C", "name": "D", "Name_3": "E", "Name_2": "F"}, index)
+	index, err = f.GetColIndexByHeader("Sheet1", 2, HeaderOptions{LowerCase: true})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"name": "A", "revenue": "C", "name_3": "D", "name_4": "E", "name_2": "F"}, index)
+	indexes, err := f.GetColsIndexByHeader("Sheet1", 2)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string][]string{"Name": {"A", "E"}, "Revenue": {"C"}, "name": {"D"}, "Name_2": {"F"}}, indexes)
+	indexes, err = f.GetColsIndexByHeader("Sheet1", 2, HeaderOptions{LowerCase: true})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string][]string{"name": {"A", "D", "E"}, "revenue": {"C"}, "name_2": {"F"}}, indexes)
+	index, err = f.GetColIndexByHeader("Sheet1", 1)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"Report": "A"}, index)
+	// Test get column index by the hidden header row
+	assert.NoError(t, f.SetRowVisible("Sheet1", 2, false))
+	f.options.SkipHiddenRows = true
+	index, err = f.GetColIndexByHeader("Sheet1", 2)
+	assert.NoError(t, err)
+	assert.Equal(t, "C", index["Revenue"])
+	f.options.SkipHiddenRows = false
+	// Test get column index by header with the raw cell value
+	style, err := f.NewStyle(&Style{NumFmt: 2})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellStyle("Sheet1", "C3", "C3", style))
+	index, err = f.GetColIndexByHeader("Sheet1", 3)
+	assert.NoError(t, err)
+	assert.Equal(t, "C", index["100.00"])
+	index, err = f.GetColIndexByHeader("Sheet1", 3, HeaderOptions{RawCellValue: true})
+	assert.NoError(t, err)
+	assert.Equal(t, "C", index["100"])
+	// Test get column index by header row without cells
+	index, err = f.GetColIndexByHeader("Sheet1", 5)
+	assert.NoError(t, err)
+	assert.Empty(t, index)
+	// Test get column index by header with invalid header row number
+	_, err = f.GetColIndexByHeader("Sheet1", 0)
+	assert.EqualError(t, err, newInvalidRowNumberError(0).Error())
+	// Test get column index by header on not exists worksheet
+	_, err = f.GetColIndexByHeader("SheetN", 1)
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+	// Test get column index by header with unsupported charset shared strings table
+	f.SharedStrings = nil
+	f.Pkg.Store(defaultXMLPathSharedStrings, MacintoshCyrillicCharset)
+	_, err = f.GetColIndexByHeader("Sheet1", 1)
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	_, err = f.GetColsIndexByHeader("Sheet1", 1)
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+}
+
+func TestWalkCols(t *testing.T) {
+	f, err := OpenFile(filepath.Join("test", "Book1.xlsx"))
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	for _, opts := range []Options{{}, {RawCellValue: true}, {TrimTrailingEmptyCells: true}} {
+		expected, err := f.GetCols("Sheet2", opts)
+		assert.NoError(t, err)
+		var colNames []string
+		assert.NoError(t, f.WalkCols("Sheet2", func(colName string, cells []string) error {
+			col, err := ColumnNameToNumber(colName)
+			assert.NoError(t, err)
+			assert.Equal(t, expected[col-1], cells, colName)
+			colNames = append(colNames, colName)
+			return nil
+		}, opts))
+		assert.Equal(t, []string{"A", "B", "C", "D", "E", "F", "G", "H", "I"}, colNames)
+	}
+	// Test walk columns with stop early
+	var colNames []string
+	assert.NoError(t, f.WalkCols("Sheet2", func(colName string, cells []string) error {
+		if colNames = append(colNames, colName); colName == "C" {
+			return ErrStopWalk
+		}
+		return nil
+	}))
+	assert.Equal(t, []string{"A", "B", "C"}, colNames)
+	// Test walk columns with error returned by the callback function
+	assert.Equal(t, ErrParameterInvalid, f.WalkCols("Sheet2", func(colName string, cells []string) error {
+		return ErrParameterInvalid
+	}))
+	// Test walk columns on not exists worksheet
+	assert.EqualError(t, f.WalkCols("SheetN", nil), "sheet SheetN does not exist")
+	assert.NoError(t, f.Close())
+
+	// Test walk columns with sparse cells
+	f = NewFile()
+	assert.NoError(t, f.SetCellValue("Sheet1", "B2", "B2"))
+	assert.NoError(t, f.SetCellValue("Sheet1", "D1", "D1"))
+	assert.NoError(t, f.SetCellValue("Sheet1", "D3", "D3"))
+	buf, err := f.WriteToBuffer()
+	assert.NoError(t, err)
+	f, err = OpenReader(buf)
+	assert.NoError(t, err)
+	walked := map[string][]string{}
+	assert.NoError(t, f.WalkCols("Sheet1", func(colName string, cells []string) error {
+		walked[colName] = cells
+		return nil
+	}))
+	assert.Equal(t, map[string][]string{"A": {"", ""}, "B": {"", "B2"}, "C": {"", ""}, "D": {"D1", "", "D3"}}, walked)
+	expected, err := f.GetCols("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, expected, 4)
+	for col, cells := range expected {
+		colName, err := ColumnNumberToName(col + 1)
+		assert.NoError(t, err)
+		assert.Equal(t, cells, walked[colName], colName)
+	}
+	// Test walk columns with skip hidden columns
+	assert.NoError(t, f.SetColVisible("Sheet1", "B", false))
+	walked = map[string][]string{}
+	assert.NoError(t, f.WalkCols("Sheet1", func(colName string, cells []string) error {
+		walked[colName] = cells
+		return nil
+	}, Options{SkipHiddenCols: true}))
+	assert.Len(t, walked, 3)
+	assert.NotContains(t, walked, "B")
+	hidden, err := f.GetCols("Sheet1", Options{SkipHiddenCols: true})
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{walked["A"], walked["C"], walked["D"]}, hidden)
+	// Test walk columns on the worksheet extracted to the temporary file
+	// without loading the worksheet XML into memory
+	f2, err := OpenFile(filepath.Join("test", "Book1.xlsx"), Options{UnzipXMLSizeLimit: 128})
+	assert.NoError(t, err)
+	expected, err = f2.GetCols("Sheet2")
+	assert.NoError(t, err)
+	f2.Pkg.Delete("xl/worksheets/sheet2.xml")
+	var walkedCols [][]string
+	assert.NoError(t, f2.WalkCols("Sheet2", func(colName string, cells []string) error {
+		walkedCols = append(walkedCols, cells)
+		return nil
+	}))
+	assert.Equal(t, expected, walkedCols)
+	_, ok := f2.Pkg.Load("xl/worksheets/sheet2.xml")
+	assert.False(t, ok)
+	assert.NoError(t, f2.Close())
+	// Test walk columns with the temporary file which doesn't exist
+	f2, err = OpenFile(filepath.Join("test", "Book1.xlsx"), Options{UnzipXMLSizeLimit: 128})
+	assert.NoError(t, err)
+	f2.tempFiles.Store("xl/worksheets/sheet2.xml", filepath.Join("test", "TestWalkColsNotExist.xml"))
+	assert.Error(t, f2.WalkCols("Sheet2", nil))
+	f2.tempFiles.Delete("xl/worksheets/sheet2.xml")
+	assert.NoError(t, f2.Close())
+	// Test walk columns with unsupported charset shared strings table
+	f.SharedStrings = nil
+	f.Pkg.Store(defaultXMLPathSharedStrings, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.WalkCols("Sheet1", nil), "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+
+	// Test walk columns with invalid cell reference
+	f = NewFile()
+	cols, err := f.Cols("Sheet1")
+	assert.NoError(t, err)
+	cols.totalCols = 1
+	cols.sheetReader = bytes.NewReader([]byte(fmt.Sprintf(`<worksheet xmlns="%s"><sheetData><row r="1"><c r="A" t="inlineStr"><is><t>A</t></is></c></row></sheetData></worksheet>`, NameSpaceSpreadSheet.Value)))
+	_, _, err = cols.cellOffsets()
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), err)
+	cols.sheetReader = bytes.NewReader([]byte(fmt.Sprintf(`<worksheet xmlns="%s"><sheetData><row r="1"><c r="A1"><v>1</v>`, NameSpaceSpreadSheet.Value)))
+	_, _, err = cols.cellOffsets()
+	assert.EqualError(t, err, "XML syntax error on line 1: unexpected EOF")
+	f.Sheet.Delete("xl/worksheets/sheet1.xml")
+	f.Pkg.Store("xl/worksheets/sheet1.xml", []byte(fmt.Sprintf(`<worksheet xmlns="%s"><sheetData><row r="1"><c r="A1"><v>1</v>`, NameSpaceSpreadSheet.Value)))
+	f.checked = sync.Map{}
+	assert.EqualError(t, f.WalkCols("Sheet1", nil), "XML syntax error on line 1: unexpected EOF")
+	assert.NoError(t, f.Close())
+}
+
+func BenchmarkGetCols(b *testing.B) {
+	f := prepareBenchmarkCols(b, 20, 1000)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := f.GetCols("Sheet1"); err != nil {
+			b.Error(err)
+		}
+	}
+}
+
+func BenchmarkGetColsWide(b *testing.B) {
+	f := prepareBenchmarkCols(b, 200, 200)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := f.GetCols("Sheet1"); err != nil {
+			b.Error(err)
+		}
+	}
+}
+
+func BenchmarkColsRowsWide(b *testing.B) {
+	f := prepareBenchmarkCols(b, 200, 200)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		cols, err := f.Cols("Sheet1")
+		if err != nil {
+			b.Error(err)
+		}
+		for cols.Next() {
+			if _, err := cols.RowsWithTypes(); err != nil {
+				b.Error(err)
+			}
+		}
+	}
+}
+
+func BenchmarkColsRepeatedReads(b *testing.B) {
+	for _, modified := range []bool{false, true} {
+		b.Run(fmt.Sprintf("modified=%t", modified), func(b *testing.B) {
+			f := prepareBenchmarkCols(b, 20, 1000)
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if modified {
+					if err := f.SetCellValue("Sheet1", "A1", i); err != nil {
+						b.Error(err)
+					}
+				}
+				if _, err := f.Cols("Sheet1"); err != nil {
+					b.Error(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkWalkCols(b *testing.B) {
+	f := prepareBenchmarkCols(b, 20, 1000)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := f.WalkCols("Sheet1", func(colName string, cells []string) error {
+			return nil
+		}); err != nil {
+			b.Error(err)
+		}
+	}
+}
+
+// prepareBenchmarkCols prepares a workbook with the given number of columns
+// and rows for the columns reading benchmarks.
+func prepareBenchmarkCols(b *testing.B, cols, rows int) *File {
+	f := NewFile()
+	row := make([]interface{}, cols)
+	for c := range row {
+		row[c] = c
+	}
+	for r := 1; r <= rows; r++ {
+		if err := f.SetSheetRow("Sheet1", fmt.Sprintf("A%d", r), &row); err != nil {
+			b.Fatal(err)
+		}
+	}
+	buf, err := f.WriteToBuffer()
+	if err != nil {
+		b.Fatal(err)
+	}
+	if f, err = OpenReader(buf); err != nil {
+		b.Fatal(err)
+	}
+	return f
+}
+
+func TestColsCellOffsets(t *testing.T) {
+	f := NewFile()
+	f.Sheet.Delete("xl/worksheets/sheet1.xml")
+	f.Pkg.Store("xl/worksheets/sheet1.xml", []byte(fmt.Sprintf(`<worksheet xmlns="%s"><sheetData><row r="3"><c r="B3" t="inlineStr"><is><t>B3</t></is></c></row><row r="1"><c r="A1" t="inlineStr"><is><t>A1</t></is></c><c r="C1" t="inlineStr"><is><t>C1</t></is></c></row><row r="4"><c r="A4" t="inlineStr"><is><t>A4</t></is></c></row></sheetData></worksheet>`, NameSpaceSpreadSheet.Value)))
+	f.checked = sync.Map{}
+	cols, err := f.Cols("Sheet1")
+	assert.NoError(t, err)
+	var results [][]string
+	for cols.Next() {
+		col, err := cols.Rows()
+		assert.NoError(t, err)
+		results = append(results, col)
+		// Test the cell offsets of the previous columns have been released
+		for idx := 0; idx < cols.CurrentCol()-1; idx++ {
+			assert.Nil(t, cols.offsets[idx])
+		}
+	}
+	assert.Equal(t, []int{1, 3, 4}, cols.cellRows)
+	assert.Equal(t, [][]string{{"A1", "", "", "A4"}, {"", "", "B3"}, {"C1", "", ""}}, results)
+	// Test read the cells in the row range with the cell offsets
+	cols, err = f.Cols("Sheet1")
+	assert.NoError(t, err)
+	assert.NoError(t, cols.SetRowRange(2, 3))
+	results = results[:0]
+	for cols.Next() {
+		col, err := cols.RowsWithTypes()
+		assert.NoError(t, err)
+		var cells []string
+		for _, cell := range col {
+			cells = append(cells, cell.Value)
+		}
+		results = append(results, cells)
+	}
+	assert.Equal(t, [][]string{{""}, {"", "B3"}, {""}}, results)
+	assert.NoError(t, cols.SetRowRange(5, 6))
+	cols.curCol = 1
+	col, err := cols.Rows()
+	assert.NoError(t, err)
+	assert.Empty(t, col)
+	assert.Equal(t, []int{1, 2, 3}, sortedUniqueInts([]int{3, 1, 2, 3, 1}))
+	assert.NoError(t, f.Close())
+}
+
+func TestColsSetRowRange(t *testing.T) {
+	f := NewFile()
+	for row := 1; row <= 20; row++ {
+		if row == 6 {
+			continue
+		}
+		assert.NoError(t, f.SetSheetRow("Sheet1", fmt.Sprintf("A%d", row), &[]interface{}{row, row * 10}))
+	}
+	cols, err := f.Cols("Sheet1")
+	assert.NoError(t, err)
+	assert.NoError(t, cols.SetRowRange(4, 8))
+	var results [][]string
+	for cols.Next() {
+		col, err := cols.Rows()
+		assert.NoError(t, err)
+		results = append(results, col)
+	}
+	assert.Equal(t, [][]string{{"4", "5", "", "7", "8"}, {"40", "50", "", "70", "80"}}, results)
+	// Test the end row is beyond the used range
+	cols, err = f.Cols("Sheet1")
+	assert.NoError(t, err)
+	assert.NoError(t, cols.SetRowRange(19, 100))
+	assert.True(t, cols.Next())
+	col, err := cols.Rows()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"19", "20"}, col)
+	// Test set row range with invalid row numbers
+	assert.EqualError(t, cols.SetRowRange(0, 10), newInvalidRowNumberError(0).Error())
+	assert.EqualError(t, cols.SetRowRange(5, 4), newInvalidRowNumberError(4).Error())
+	assert.Equal(t, ErrMaxRows, cols.SetRowRange(1, TotalRows+1))
+}
+
+func TestColumnVisibility(t *testing.T) {
+	t.Run("TestBook1", func(t *testing.T) {
+		f, err := prepareTestBook1()
+		assert.NoError(t, err)
+
+		// Hide/display a column with SetColVisible
+		assert.NoError(t, f.SetColVisible("Sheet1", "F", false))
+		assert.NoError(t, f.SetColVisible("Sheet1", "F", true))
+		visible, err := f.GetColVisible("Sheet1", "F")
+		assert.Equal(t, true, visible)
+		assert.NoError(t, err)
+
+		// Test hiding a few columns SetColVisible(...false)...
+		assert.NoError(t, f.SetColVisible("Sheet1", "F:V", false))
+		visible, err = f.GetColVisible("Sheet1", "F")
+		assert.Equal(t, false, visible)
+		assert.NoError(t, err)
+		visible, err = f.GetColVisible("Sheet1", "U")
+		assert.Equal(t, false, visible)
+		assert.NoError(t, err)
+		visible, err = f.GetColVisible("Sheet1", "V")
+		assert.Equal(t, false, visible)
+		assert.NoError(t, err)
+		// ...and displaying them back SetColVisible(...true)
+		assert.NoError(t, f.SetColVisible("Sheet1", "V:F", true))
+		visible, err = f.GetColVisible("Sheet1", "F")
+		assert.Equal(t, true, visible)
+		assert.NoError(t, err)
+		visible, err = f.GetColVisible("Sheet1", "U")
+		assert.Equal(t, true, visible)
+		assert.NoError(t, err)
+		visible, err = f.GetColVisible("Sheet1", "G")
+		assert.Equal(t, true, visible)
+		assert.NoError(t, err)
+
+		// Test get column visible on not exists worksheet
+		_, err = f.GetColVisible("SheetN", "F")
+		assert.EqualError(t, err, "sheet SheetN does not exist")
+		// Test get column visible with invalid sheet name
+		_, err = f.GetColVisible("Sheet:1", "F")
+		assert.EqualError(t, err, ErrSheetNameInvalid.Error())
+		// Test get column visible with illegal cell reference
+		_, err = f.GetColVisible("Sheet1", "*")
+		assert.EqualError(t, err, newInvalidColumnNameError("*").Error())
+		assert.EqualError(t, f.SetColVisible("Sheet1", "*", false), newInvalidColumnNameError("*").Error())
+		// Test set column visible with invalid sheet name
+		assert.EqualError(t, f.SetColVisible("Sheet:1", "A", false), ErrSheetNameInvalid.Error())
+
+		_, err = f.NewSheet("Sheet3")
+		assert.NoError(t, err)
+		assert.NoError(t, f.SetColVisible("Sheet3", "E", false))
+		assert.EqualError(t, f.SetColVisible("Sheet1", "A:-1", true), newInvalidColumnNameError("-1").Error())
+		assert.EqualError(t, f.SetColVisible("SheetN", "E", false), "sheet SheetN does not exist")
+		assert.NoError(t, f.SaveAs(filepath.Join("test", "TestColumnVisibility.xlsx")))
+	})
+
+	t.Run("TestBook3", func(t *testing.T) {
+		f, err := prepareTestBook3()
+		assert.NoError(t, err)
+		visible, err := f.GetColVisible("Sheet1", "B")
+		assert.Equal(t, true, visible)
+		assert.NoError(t, err)
+	})
+}
+
+func TestColsFormulaOnRead(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetSheetCol("Sheet1", "A1", &[]interface{}{1, 2}))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "A3", "SUM(A1:A2)"))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "B1", "BLOOMBERG(A1)"))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "B2", "A1*10"))
+	// Test read the cached value of the formula cells
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	ws.(*xlsxWorksheet).SheetData.Row[1].C[1].V = "20"
+	cols, err := f.GetCols("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{{"1", "2", ""}, {"", "20"}}, cols)
+	// Test calculate the formula cells without cached value
+	cols, err = f.GetCols("Sheet1", Options{CalcFormulaOnRead: true})
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{{"1", "2", "3"}, {"", "20"}}, cols)
+	iter, err := f.Cols("Sheet1")
+	assert.NoError(t, err)
+	var results [][]string
+	for iter.Next() {
+		formatted, raw, err := iter.RowsBoth(Options{CalcFormulaOnRead: true})
+		assert.NoError(t, err)
+		assert.Equal(t, formatted, raw)
+		results = append(results, formatted)
+	}
+	assert.Equal(t, [][]string{{"1", "2", "3"}, {"", "20"}}, results)
+	assert.EqualError(t, iter.Error(), "not support BLOOMBERG function")
+	iter, err = f.Cols("Sheet1")
+	assert.NoError(t, err)
+	assert.True(t, iter.Next())
+	typed, err := iter.RowsWithTypes(Options{CalcFormulaOnRead: true})
+	assert.NoError(t, err)
+	assert.Equal(t, "3", typed[2].Value)
+	assert.NoError(t, iter.Error())
+	// Test read the formula text of the formula cells
+	cols, err = f.GetCols("Sheet1", Options{CalcFormulaOnRead: true, FormulaTextOnRead: true})
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{{"1", "2", "SUM(A1:A2)"}, {"BLOOMBERG(A1)", "A1*10"}}, cols)
+	// Test read formula cells by the rows iterator
+	rows, err := f.GetRows("Sheet1", Options{CalcFormulaOnRead: true})
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{{"1", ""}, {"2", "20"}, {"3"}}, rows)
+	rows, err = f.GetRows("Sheet1", Options{FormulaTextOnRead: true})
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{{"1", "BLOOMBERG(A1)"}, {"2", "A1*10"}, {"SUM(A1:A2)"}}, rows)
+	rowsIter, err := f.Rows("Sheet1")
+	assert.NoError(t, err)
+	for rowsIter.Next() {
+		_, err = rowsIter.Columns(Options{CalcFormulaOnRead: true})
+		assert.NoError(t, err)
+	}
+	assert.EqualError(t, rowsIter.Error(), "not support BLOOMBERG function")
+	assert.NoError(t, rowsIter.Close())
+	// Test the formula options of the workbook be ignored
+	f.options.CalcFormulaOnRead, f.options.FormulaTextOnRead = true, true
+	cols, err = f.GetCols("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{{"1", "2", ""}, {"", "20"}}, cols)
+	assert.NoError(t, f.Close())
+	// Test read the shared formula cells without loading the worksheet
+	f = NewFile()
+	f.Sheet.Delete("xl/worksheets/sheet1.xml")
+	f.Pkg.Store("xl/worksheets/sheet1.xml", []byte(`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData><row r="1"><c r="A1"><v>1</v></c><c r="B1"><f t="shared" ref="B1:B3" si="0">A1*2</f><v>2</v></c><c r="C1"><f t="shared" ref="C1:C2" si="1">ROW()+1</f></c></row><row r="2"><c r="A2"><v>2</v></c><c r="B2"><f t="shared" si="0"/></c><c r="C2"><f t="shared" si="1"/></c></row><row r="3"><c r="A3"><v>3</v></c><c r="B3"><f t="shared" si="0"/></c></row></sheetData></worksheet>`))
+	f.checked = sync.Map{}
+	rows, err = f.GetRows("Sheet1", Options{FormulaTextOnRead: true})
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{{"1", "A1*2", "ROW()+1"}, {"2", "A2*2", "ROW()+1"}, {"3", "A3*2"}}, rows)
+	cols, err = f.GetCols("Sheet1", Options{FormulaTextOnRead: true})
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{{"1", "2", "3"}, {"A1*2", "A2*2", "A3*2"}, {"ROW()+1", "ROW()+1"}}, cols)
+	_, ok = f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.False(t, ok)
+	cols, err = f.GetCols("Sheet1", Options{CalcFormulaOnRead: true})
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{{"1", "2", "3"}, {"2", "4", "6"}, {"2", "3"}}, cols)
+	// Test read the shared formula cells out of the master cell range
+	iter, err = f.ColsRange("Sheet1", "B", "B")
+	assert.NoError(t, err)
+	assert.NoError(t, iter.SetRowRange(2, 3))
+	assert.True(t, iter.Next())
+	col, err := iter.Rows(Options{FormulaTextOnRead: true})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"A2*2", "A3*2"}, col)
+	rowsIter, err = f.Rows("Sheet1")
+	assert.NoError(t, err)
+	assert.True(t, rowsIter.Next())
+	assert.True(t, rowsIter.Next())
+	row, err := rowsIter.Columns(Options{CalcFormulaOnRead: true})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"2", "4", "3"}, row)
+	assert.NoError(t, rowsIter.Close())
+	assert.NoError(t, f.Close())
+}
+
+func TestColsVisible(t *testing.T) {
+	f := NewFile()
+	// Test set columns visible with overlapping columns ranges
+	assert.Equal(t, ErrParameterInvalid, f.SetColsVisible("Sheet1", map[string]bool{"B": false, "D:F": false, "E": true}))
+	assert.NoError(t, f.SetColsVisible("Sheet1", map[string]bool{"B": false, "D:F": false, "H": true}))
+	ws, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, []xlsxCol{
+		{Min: 2, Max: 2, Width: float64Ptr(defaultColWidth), CustomWidth: true, Hidden: true},
+		{Min: 4, Max: 6, Width: float64Ptr(defaultColWidth), CustomWidth: true, Hidden: true},
+		{Min: 8, Max: 8, Width: float64Ptr(defaultColWidth), CustomWidth: true},
+	}, ws.Cols.Col)
+	// Test later column definitions override the earlier overlapping ranges
+	ws.Cols.Col = append(ws.Cols.Col, xlsxCol{Min: 5, Max: 7, Hidden: true})
+	visible, err := f.GetColsVisible("Sheet1", "H:A")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]bool{
+		"A": true, "B": false, "C": true, "D": false,
+		"E": false, "F": false, "G": false, "H": true,
+	}, visible)
+	for col, expected := range visible {
+		v, err := f.GetColVisible("Sheet1", col)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, v, col)
+	}
+	visible, err = f.GetColsVisible("Sheet1", "A:KZ")
+	assert.NoError(t, err)
+	assert.Len(t, visible, 312)
+	assert.True(t, visible["KZ"])
+	// Test set visible of all columns keeps the columns range
+	f2 := NewFile()
+	assert.NoError(t, f2.SetColsVisible("Sheet1", map[string]bool{"A:XFD": false}))
+	ws, err = f2.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, []xlsxCol{{Min: 1, Max: MaxColumns, Width: float64Ptr(defaultColWidth), CustomWidth: true, Hidden: true}}, ws.Cols.Col)
+
+	// Test get and set columns visible on not exists worksheet
+	_, err = f.GetColsVisible("SheetN", "A")
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+	assert.EqualError(t, f.SetColsVisible("SheetN", map[string]bool{"A": false}), "sheet SheetN does not exist")
+	// Test get and set columns visible with illegal column name
+	_, err = f.GetColsVisible("Sheet1", "A:*")
+	assert.EqualError(t, err, newInvalidColumnNameError("*").Error())
+	assert.EqualError(t, f.SetColsVisible("Sheet1", map[string]bool{"*": false}), newInvalidColumnNameError("*").Error())
+}
+
+func TestGetHiddenCols(t *testing.T) {
+	f := NewFile()
+	cols, err := f.GetHiddenCols("Sheet1")
+	assert.NoError(t, err)
+	assert.Empty(t, cols)
+	assert.NoError(t, f.SetColsVisible("Sheet1", map[string]bool{"B": false, "D:F": false, "H": true}))
+	ws, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	ws.Cols.Col = append(ws.Cols.Col, xlsxCol{Min: 5, Max: 5}, xlsxCol{Min: 16383, Max: 16385, Hidden: true})
+	cols, err = f.GetHiddenCols("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"B", "D", "F", "XFC", "XFD"}, cols)
+	// Test get hidden columns on not exists worksheet
+	_, err = f.GetHiddenCols("SheetN")
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+}
+
+func TestGetColsSkipFilteredRows(t *testing.T) {
+	f := NewFile()
+	for row := 1; row <= 8; row++ {
+		if row == 7 {
+			continue
+		}
+		assert.NoError(t, f.SetSheetRow("Sheet1", fmt.Sprintf("A%d", row), &[]interface{}{fmt.Sprintf("A%d", row), row}))
+	}
+	assert.NoError(t, f.AutoFilter("Sheet1", "A1:B6", nil))
+	for _, row := range []int{3, 5, 8} {
+		assert.NoError(t, f.SetRowVisible("Sheet1", row, false))
+	}
+	cols, err := f.GetCols("Sheet1", Options{SkipFilteredRows: true})
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{{"A1", "A2", "A4", "A6", "", "A8"}, {"1", "2", "4", "6", "", "8"}}, cols)
+	cols, err = f.GetCols("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, cols[0], 8)
+	// Test walk columns skip the rows hidden by the AutoFilter
+	var walked [][]string
+	assert.NoError(t, f.WalkCols("Sheet1", func(colName string, cells []string) error {
+		walked = append(walked, cells)
+		return nil
+	}, Options{SkipFilteredRows: true}))
+	assert.Equal(t, [][]string{{"A1", "A2", "A4", "A6", "", "A8"}, {"1", "2", "4", "6", "", "8"}}, walked)
+	// Test columns iterator skip the filtered rows with the row range and types
+	iter, err := f.Cols("Sheet1", Options{SkipFilteredRows: true})
+	assert.NoError(t, err)
+	assert.NoError(t, iter.SetRowRange(4, 6))
+	assert.True(t, iter.Next())
+	col, err := iter.Rows()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"A4", "A6"}, col)
+	assert.True(t, iter.Next())
+	typed, err := iter.RowsWithTypes()
+	assert.NoError(t, err)
+	assert.Len(t, typed, 2)
+	// Test columns iterator skip the filtered rows with invalid AutoFilter range
+	ws, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	ws.AutoFilter.Ref = "A1"
+	_, err = f.GetCols("Sheet1", Options{SkipFilteredRows: true})
+	assert.Equal(t, ErrParameterInvalid, err)
+	assert.NoError(t, f.Close())
+}
+
+func TestGetColsSkipHiddenCols(t *testing.T) {
+	f := NewFile()
+	for _, cell := range []string{"A1", "B1", "C1", "D1", "E1", "F1"} {
+		assert.NoError(t, f.SetCellValue("Sheet1", cell, cell))
+	}
+	assert.NoError(t, f.SetColVisible("Sheet1", "B", false))
+	assert.NoError(t, f.SetColVisible("Sheet1", "D:F", false))
+	cols, err := f.GetCols("Sheet1", Options{SkipHiddenCols: true})
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{{"A1"}, {"C1"}}, cols)
+	cols, err = f.GetCols("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, cols, 6)
+	data, err := f.GetColsData("Sheet1", ColumnDataOptions{SkipHiddenCols: true})
+	assert.NoError(t, err)
+	assert.Len(t, data, 2)
+	assert.Equal(t, "C", data[1].Name)
+	// Test columns iterator skip hidden columns
+	iter, err := f.Cols("Sheet1", Options{SkipHiddenCols: true})
+	assert.NoError(t, err)
+	var names []string
+	for iter.Next() {
+		name, err := iter.CurrentColName()
+		assert.NoError(t, err)
+		names = append(names, name)
+	}
+	assert.Equal(t, []string{"A", "C"}, names)
+	// Test the skip hidden columns option of the workbook be ignored
+	f.options.SkipHiddenCols = true
+	cols, err = f.GetCols("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, cols, 6)
+	assert.NoError(t, f.Close())
+}
+
+func TestOutlineLevel(t *testing.T) {
+	f := NewFile()
+	level, err := f.GetColOutlineLevel("Sheet1", "D")
+	assert.Equal(t, uint8(0), level)
+	assert.NoError(t, err)
+
+	_, err = f.NewSheet("Sheet2")
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetColOutlineLevel("Sheet1", "D", 4))
 
 	level, err = f.GetColOutlineLevel("Sheet1", "D")
 	assert.Equal(t, uint8(4), level)
@@ -329,6 +1810,82 @@ func TestOutlineLevel(t *testing.T) {
 	assert.NoError(t, f.Close())
 }
 
+func TestGroupCols(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetColWidth("Sheet1", "C", "C", 20))
+	assert.NoError(t, f.GroupCols("Sheet1", "B", "D", GroupOptions{Collapsed: true}))
+	assert.NoError(t, f.GroupCols("Sheet1", "C", "C", GroupOptions{}))
+	for col, expected := range map[string]uint8{"A": 0, "B": 1, "C": 2, "D": 1, "E": 0} {
+		level, err := f.GetColOutlineLevel("Sheet1", col)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, level, col)
+	}
+	visible, err := f.GetColsVisible("Sheet1", "A:E")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]bool{"A": true, "B": false, "C": false, "D": false, "E": true}, visible)
+	width, err := f.GetColWidth("Sheet1", "C")
+	assert.NoError(t, err)
+	assert.Equal(t, 20.0, width)
+	ws, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	assert.True(t, ws.flatColRange(5, 5, 0)[5].Collapsed)
+	assert.True(t, *ws.SheetPr.OutlinePr.SummaryRight)
+
+	// Test ungroup the collapsed columns
+	assert.NoError(t, f.UngroupCols("Sheet1", "B", "D"))
+	for col, expected := range map[string]uint8{"B": 0, "C": 1, "D": 0} {
+		level, err := f.GetColOutlineLevel("Sheet1", col)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, level, col)
+	}
+	visible, err = f.GetColsVisible("Sheet1", "A:E")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]bool{"A": true, "B": true, "C": true, "D": true, "E": true}, visible)
+	assert.False(t, ws.flatColRange(5, 5, 0)[5].Collapsed)
+	assert.NoError(t, f.UngroupCols("Sheet1", "B", "D"))
+	level, err := f.GetColOutlineLevel("Sheet1", "C")
+	assert.NoError(t, err)
+	assert.Zero(t, level)
+
+	// Test group columns with summary column on the left
+	assert.NoError(t, f.GroupCols("Sheet1", "A", "B", GroupOptions{SummaryLeft: true, Collapsed: true}))
+	assert.False(t, *ws.SheetPr.OutlinePr.SummaryRight)
+	assert.NoError(t, f.GroupCols("Sheet1", "G", "H", GroupOptions{SummaryLeft: true, Collapsed: true}))
+	assert.True(t, ws.flatColRange(6, 6, 0)[6].Collapsed)
+	assert.NoError(t, f.UngroupCols("Sheet1", "H", "G"))
+	assert.False(t, ws.flatColRange(6, 6, 0)[6].Collapsed)
+	colVisible, err := f.GetColVisible("Sheet1", "G")
+	assert.NoError(t, err)
+	assert.True(t, colVisible)
+	// Test group the columns with summary column out of the valid range
+	assert.NoError(t, f.GroupCols("Sheet1", "XFC", "XFD", GroupOptions{Collapsed: true}))
+
+	// Test nested groups exceeds the maximum outline level
+	for i := 0; i < 7; i++ {
+		assert.NoError(t, f.GroupCols("Sheet1", "K", "L", GroupOptions{}))
+	}
+	level, err = f.GetColOutlineLevel("Sheet1", "L")
+	assert.NoError(t, err)
+	assert.Equal(t, uint8(7), level)
+	assert.Equal(t, ErrOutlineLevel, f.GroupCols("Sheet1", "L", "M", GroupOptions{}))
+	level, err = f.GetColOutlineLevel("Sheet1", "M")
+	assert.NoError(t, err)
+	assert.Zero(t, level)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestGroupCols.xlsx")))
+
+	// Test group and ungroup columns on not exists worksheet
+	assert.EqualError(t, f.GroupCols("SheetN", "A", "B", GroupOptions{}), "sheet SheetN does not exist")
+	assert.EqualError(t, f.UngroupCols("SheetN", "A", "B"), "sheet SheetN does not exist")
+	// Test group and ungroup columns with illegal column name
+	assert.EqualError(t, f.GroupCols("Sheet1", "*", "B", GroupOptions{}), newInvalidColumnNameError("*").Error())
+	assert.EqualError(t, f.UngroupCols("Sheet1", "A", "*"), newInvalidColumnNameError("*").Error())
+	// Test ungroup columns without column definitions
+	_, err = f.NewSheet("Sheet2")
+	assert.NoError(t, err)
+	assert.NoError(t, f.UngroupCols("Sheet2", "A", "B"))
+	assert.NoError(t, f.Close())
+}
+
 func TestSetColStyle(t *testing.T) {
 	f := NewFile()
 	assert.NoError(t, f.SetCellValue("Sheet1", "B2", "Hello"))
@@ -372,50 +1929,782 @@ func TestSetColStyle(t *testing.T) {
 	assert.NoError(t, f.SetSheetProps("Sheet1", &SheetPropsOptions{DefaultColWidth: float64Ptr(20)}))
 	style, err = f.NewStyle(&Style{Alignment: &Alignment{Vertical: "center"}})
 	assert.NoError(t, err)
-	assert.NoError(t, f.SetColStyle("Sheet1", "A:Z", style))
-	width, err := f.GetColWidth("Sheet1", "B")
+	assert.NoError(t, f.SetColStyle("Sheet1", "A:Z", style))
+	width, err := f.GetColWidth("Sheet1", "B")
+	assert.NoError(t, err)
+	assert.Equal(t, 20.0, width)
+}
+
+func TestMergeColStyle(t *testing.T) {
+	f := NewFile()
+	boldStyle, err := f.NewStyle(&Style{Font: &Font{Bold: true}})
+	assert.NoError(t, err)
+	dateStyle, err := f.NewStyle(&Style{NumFmt: 14})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellValue("Sheet1", "D1", "Date"))
+	assert.NoError(t, f.SetCellValue("Sheet1", "E1", "Amount"))
+	assert.NoError(t, f.SetCellStyle("Sheet1", "D1", "E1", boldStyle))
+	assert.NoError(t, f.SetCellValue("Sheet1", "D2", 45000))
+	assert.NoError(t, f.SetCellValue("Sheet1", "D3", 45001))
+
+	// Test merge column style keeps the existing bold font of the headers
+	xfs := len(f.Styles.CellXfs.Xf)
+	assert.NoError(t, f.MergeColStyle("Sheet1", "D:E", dateStyle))
+	assert.Len(t, f.Styles.CellXfs.Xf, xfs+1)
+	header, err := f.GetCellStyle("Sheet1", "D1")
+	assert.NoError(t, err)
+	cellStyleID, err := f.GetCellStyle("Sheet1", "E1")
+	assert.NoError(t, err)
+	assert.Equal(t, header, cellStyleID)
+	style, err := f.GetStyle(header)
+	assert.NoError(t, err)
+	assert.True(t, style.Font.Bold)
+	assert.Equal(t, 14, style.NumFmt)
+	for _, cell := range []string{"D2", "D3", "E3"} {
+		cellStyleID, err = f.GetCellStyle("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, dateStyle, cellStyleID, cell)
+	}
+	colStyleID, err := f.GetColStyle("Sheet1", "E")
+	assert.NoError(t, err)
+	assert.Equal(t, dateStyle, colStyleID)
+	// Test merge column style reuses the existing merged style
+	assert.NoError(t, f.MergeColStyle("Sheet1", "D", dateStyle))
+	assert.Len(t, f.Styles.CellXfs.Xf, xfs+1)
+	cellStyleID, err = f.GetCellStyle("Sheet1", "D1")
+	assert.NoError(t, err)
+	assert.Equal(t, header, cellStyleID)
+	// Test merge column style overrides the explicitly set attribute groups
+	italicStyle, err := f.NewStyle(&Style{Font: &Font{Italic: true}})
+	assert.NoError(t, err)
+	assert.NoError(t, f.MergeColStyle("Sheet1", "D", italicStyle))
+	cellStyleID, err = f.GetCellStyle("Sheet1", "D1")
+	assert.NoError(t, err)
+	style, err = f.GetStyle(cellStyleID)
+	assert.NoError(t, err)
+	assert.False(t, style.Font.Bold)
+	assert.True(t, style.Font.Italic)
+	assert.Equal(t, 14, style.NumFmt)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestMergeColStyle.xlsx")))
+
+	// Test merge column style on the worksheet without rows
+	_, err = f.NewSheet("Sheet2")
+	assert.NoError(t, err)
+	assert.NoError(t, f.MergeColStyle("Sheet2", "A", dateStyle))
+	// Test merge column style with invalid cell style index
+	ws, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	ws.SheetData.Row[1].C[3].S = 100
+	assert.NoError(t, f.MergeColStyle("Sheet1", "D", boldStyle))
+	cellStyleID, err = f.GetCellStyle("Sheet1", "D2")
+	assert.NoError(t, err)
+	assert.Equal(t, boldStyle, cellStyleID)
+	// Test merge column style with exceeds cell styles limit
+	fillStyle, err := f.NewStyle(&Style{Fill: Fill{Type: "pattern", Color: []string{"94D3A2"}, Pattern: 1}})
+	assert.NoError(t, err)
+	f.Styles.CellXfs.Xf = append(f.Styles.CellXfs.Xf, make([]xlsxXf, MaxCellStyles-len(f.Styles.CellXfs.Xf))...)
+	assert.Equal(t, ErrCellStyles, f.MergeColStyle("Sheet1", "D", fillStyle))
+	// Test merge column style with not exists worksheet
+	assert.EqualError(t, f.MergeColStyle("SheetN", "E", dateStyle), "sheet SheetN does not exist")
+	// Test merge column style with illegal column name
+	assert.EqualError(t, f.MergeColStyle("Sheet1", "*", dateStyle), newInvalidColumnNameError("*").Error())
+	// Test merge column style with invalid style ID
+	assert.EqualError(t, f.MergeColStyle("Sheet1", "B", -1), newInvalidStyleID(-1).Error())
+	// Test merge column style with unsupported charset style sheet
+	f.Styles = nil
+	f.Pkg.Store(defaultXMLPathStyles, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.MergeColStyle("Sheet1", "C:F", dateStyle), "XML syntax error on line 1: invalid UTF-8")
+}
+
+func TestSetColNumFmt(t *testing.T) {
+	f := NewFile()
+	boldStyle, err := f.NewStyle(&Style{Font: &Font{Bold: true}})
+	assert.NoError(t, err)
+	fillStyle, err := f.NewStyle(&Style{Fill: Fill{Type: "pattern", Color: []string{"94D3A2"}, Pattern: 1}})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellValue("Sheet1", "D1", "Date"))
+	assert.NoError(t, f.SetCellStyle("Sheet1", "D1", "D1", boldStyle))
+	assert.NoError(t, f.SetColStyle("Sheet1", "E", fillStyle))
+
+	fmtCode, err := f.GetColNumFmt("Sheet1", "D")
+	assert.NoError(t, err)
+	assert.Empty(t, fmtCode)
+	assert.NoError(t, f.SetColNumFmt("Sheet1", "D:E", "yyyy-mm-dd"))
+	for _, col := range []string{"D", "E"} {
+		fmtCode, err = f.GetColNumFmt("Sheet1", col)
+		assert.NoError(t, err)
+		assert.Equal(t, "yyyy-mm-dd", fmtCode)
+	}
+	// Test set column number format keeps the existing column and cell styles
+	styleID, err := f.GetColStyle("Sheet1", "E")
+	assert.NoError(t, err)
+	style, err := f.GetStyle(styleID)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"94D3A2"}, style.Fill.Color)
+	styleID, err = f.GetCellStyle("Sheet1", "D1")
+	assert.NoError(t, err)
+	style, err = f.GetStyle(styleID)
+	assert.NoError(t, err)
+	assert.True(t, style.Font.Bold)
+	assert.Equal(t, "yyyy-mm-dd", *style.CustomNumFmt)
+
+	// Test the cells set later in the columns inherit the number format
+	date := time.Date(2024, time.October, 10, 12, 0, 0, 0, time.UTC)
+	assert.NoError(t, f.SetCellValue("Sheet1", "D2", 45575))
+	assert.NoError(t, f.SetCellValue("Sheet1", "D3", date))
+	assert.NoError(t, f.SetSheetRow("Sheet1", "D4", &[]interface{}{45575, date}))
+	for _, cell := range []string{"D2", "D3", "D4", "E4"} {
+		val, err := f.GetCellValue("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, "2024-10-10", val, cell)
+	}
+	// Test the explicit cell style and row style take precedence, even if the
+	// row style without the custom format flag
+	assert.NoError(t, f.SetCellStyle("Sheet1", "D5", "D5", boldStyle))
+	assert.NoError(t, f.SetCellValue("Sheet1", "D5", 45575))
+	assert.NoError(t, f.SetRowStyle("Sheet1", 6, 6, boldStyle))
+	assert.NoError(t, f.SetCellValue("Sheet1", "D6", 45575))
+	ws, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	ws.prepareSheetXML(1, 7)
+	ws.SheetData.Row[6].S, ws.SheetData.Row[6].CustomFormat = boldStyle, false
+	assert.NoError(t, f.SetCellValue("Sheet1", "D7", 45575))
+	for _, cell := range []string{"D5", "D6", "D7"} {
+		val, err := f.GetCellValue("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, "45575", val, cell)
+	}
+
+	// Test the stream writer inherits the column number format
+	_, err = f.NewSheet("Sheet2")
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetColNumFmt("Sheet2", "B", "0.00%"))
+	sw, err := f.NewStreamWriter("Sheet2")
+	assert.NoError(t, err)
+	assert.NoError(t, sw.SetRow("A1", []interface{}{0.5, 0.5}))
+	assert.NoError(t, sw.SetRow("A2", []interface{}{0.5, Cell{StyleID: boldStyle, Value: 0.5}}))
+	assert.NoError(t, sw.Flush())
+	buf, err := f.WriteToBuffer()
+	assert.NoError(t, err)
+	assert.NoError(t, f.Close())
+	f, err = OpenReader(buf)
+	assert.NoError(t, err)
+	rows, err := f.GetRows("Sheet2")
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{{"0.5", "50.00%"}, {"0.5", "0.5"}}, rows)
+	fmtCode, err = f.GetColNumFmt("Sheet2", "B")
+	assert.NoError(t, err)
+	assert.Equal(t, "0.00%", fmtCode)
+
+	// Test get column number format with built-in number format
+	assert.NoError(t, f.SetColNumFmt("Sheet1", "F", "0.00"))
+	fmtCode, err = f.GetColNumFmt("Sheet1", "F")
+	assert.NoError(t, err)
+	assert.Equal(t, "0.00", fmtCode)
+	dateStyle, err := f.NewStyle(&Style{NumFmt: 14})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetColStyle("Sheet1", "G", dateStyle))
+	fmtCode, err = f.GetColNumFmt("Sheet1", "G")
+	assert.NoError(t, err)
+	assert.Equal(t, "mm-dd-yy", fmtCode)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestSetColNumFmt.xlsx")))
+	// Test set column number format with empty number format code
+	assert.Equal(t, ErrCustomNumFmt, f.SetColNumFmt("Sheet1", "D", ""))
+	// Test set and get column number format with illegal column name
+	assert.EqualError(t, f.SetColNumFmt("Sheet1", "*", "0.00"), newInvalidColumnNameError("*").Error())
+	_, err = f.GetColNumFmt("Sheet1", "*")
+	assert.EqualError(t, err, newInvalidColumnNameError("*").Error())
+	// Test set and get column number format on not exists worksheet
+	assert.EqualError(t, f.SetColNumFmt("SheetN", "D", "0.00"), "sheet SheetN does not exist")
+	_, err = f.GetColNumFmt("SheetN", "D")
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+	// Test get column number format with unsupported charset style sheet
+	f.Styles = nil
+	f.Pkg.Store(defaultXMLPathStyles, MacintoshCyrillicCharset)
+	_, err = f.GetColNumFmt("Sheet1", "D")
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+}
+
+func TestSetColLocked(t *testing.T) {
+	f := NewFile()
+	fillStyle, err := f.NewStyle(&Style{
+		Fill:   Fill{Type: "pattern", Color: []string{"94D3A2"}, Pattern: 1},
+		NumFmt: 14,
+	})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A1", &[]interface{}{"A", "B", "C", "D"}))
+	assert.NoError(t, f.SetCellStyle("Sheet1", "B1", "B1", fillStyle))
+	assert.NoError(t, f.SetCellStyle("Sheet1", "C2", "C2", fillStyle))
+	assert.NoError(t, f.SetColStyle("Sheet1", "C", fillStyle))
+	xfs := len(f.Styles.CellXfs.Xf)
+	assert.NoError(t, f.SetColLocked("Sheet1", "B:C", false))
+	// Test the derived styles keep the other attribute groups and are reused
+	assert.Len(t, f.Styles.CellXfs.Xf, xfs+2)
+	for _, cell := range []string{"B1", "C1", "C2", "C3"} {
+		styleID, err := f.GetCellStyle("Sheet1", cell)
+		assert.NoError(t, err)
+		style, err := f.GetStyle(styleID)
+		assert.NoError(t, err)
+		assert.Equal(t, &Protection{Locked: false}, style.Protection, cell)
+		if cell != "C1" {
+			assert.Equal(t, []string{"94D3A2"}, style.Fill.Color, cell)
+			assert.Equal(t, 14, style.NumFmt, cell)
+		}
+	}
+	styleID, err := f.GetCellStyle("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Zero(t, styleID)
+	// Test the cells set later in the columns inherit the column protection
+	assert.NoError(t, f.SetCellValue("Sheet1", "B5", 1))
+	styleID, err = f.GetCellStyle("Sheet1", "B5")
+	assert.NoError(t, err)
+	style, err := f.GetStyle(styleID)
+	assert.NoError(t, err)
+	assert.Equal(t, &Protection{Locked: false}, style.Protection)
+	// Test hide the formulas and keep the locked state
+	assert.NoError(t, f.SetColFormulaHidden("Sheet1", "B", true))
+	styleID, err = f.GetCellStyle("Sheet1", "B1")
+	assert.NoError(t, err)
+	style, err = f.GetStyle(styleID)
+	assert.NoError(t, err)
+	assert.Equal(t, &Protection{Hidden: true, Locked: false}, style.Protection)
+	assert.Equal(t, []string{"94D3A2"}, style.Fill.Color)
+	assert.NoError(t, f.SetColLocked("Sheet1", "B", true))
+	styleID, err = f.GetColStyle("Sheet1", "B")
+	assert.NoError(t, err)
+	style, err = f.GetStyle(styleID)
+	assert.NoError(t, err)
+	assert.Equal(t, &Protection{Hidden: true, Locked: true}, style.Protection)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestSetColLocked.xlsx")))
+
+	// Test set column protection with illegal column name
+	assert.EqualError(t, f.SetColLocked("Sheet1", "*", false), newInvalidColumnNameError("*").Error())
+	// Test set column protection on not exists worksheet
+	assert.EqualError(t, f.SetColFormulaHidden("SheetN", "D", true), "sheet SheetN does not exist")
+	// Test set column protection with invalid cell reference
+	ws, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	ws.SheetData.Row[0].C[0].R = "A"
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), f.SetColLocked("Sheet1", "A", false))
+	ws.SheetData.Row[0].C[0].R = "A1"
+	// Test set column protection with invalid style ID of the cell
+	ws.SheetData.Row[0].C[3].S = MaxCellStyles
+	assert.NoError(t, f.SetColLocked("Sheet1", "D", false))
+	styleID, err = f.GetColStyle("Sheet1", "D")
+	assert.NoError(t, err)
+	assert.Equal(t, styleID, ws.SheetData.Row[0].C[3].S)
+	// Test set column protection with the cell styles exceeds the limit
+	f.Styles.CellXfs.Xf = append(f.Styles.CellXfs.Xf, make([]xlsxXf, MaxCellStyles-len(f.Styles.CellXfs.Xf))...)
+	assert.Equal(t, ErrCellStyles, f.SetColLocked("Sheet1", "E", true))
+	ws.SheetData.Row[0].C[2].S = MaxCellStyles - 1
+	ws.Cols = nil
+	assert.Equal(t, ErrCellStyles, f.SetColLocked("Sheet1", "C", false))
+	// Test set column protection without cell styles
+	f.Styles.CellXfs = nil
+	assert.Equal(t, newInvalidStyleID(0), f.SetColLocked("Sheet1", "D", false))
+	// Test set column protection with unsupported charset style sheet
+	f.Styles = nil
+	f.Pkg.Store(defaultXMLPathStyles, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.SetColLocked("Sheet1", "D", false), "XML syntax error on line 1: invalid UTF-8")
+}
+
+func TestSetColStyleAll(t *testing.T) {
+	prepare := func() *File {
+		f := NewFile()
+		_, err := f.NewSheet("Sheet2")
+		assert.NoError(t, err)
+		assert.NoError(t, f.SetCellValue("Sheet1", "B2", "Hello"))
+		assert.NoError(t, f.SetCellValue("Sheet2", "C5", "World"))
+		assert.NoError(t, f.AddChartSheet("Chart1", &Chart{
+			Type:   Col,
+			Series: []ChartSeries{{Name: "Sheet1!$A$1", Values: "Sheet1!$B$1:$D$1"}},
+		}))
+		return f
+	}
+	expected, f := prepare(), prepare()
+	styleID, err := expected.NewStyle(&Style{Fill: Fill{Type: "pattern", Color: []string{"94D3A2"}, Pattern: 1}})
+	assert.NoError(t, err)
+	_, err = f.NewStyle(&Style{Fill: Fill{Type: "pattern", Color: []string{"94D3A2"}, Pattern: 1}})
+	assert.NoError(t, err)
+	// Test set columns style on all worksheets match the SetColStyle function
+	for _, sheet := range []string{"Sheet1", "Sheet2"} {
+		assert.NoError(t, expected.SetColStyle(sheet, "B:C", styleID))
+	}
+	assert.NoError(t, f.SetColStyleAll(nil, "B:C", styleID))
+	for _, sheet := range []string{"Sheet1", "Sheet2"} {
+		ws, err := f.workSheetReader(sheet)
+		assert.NoError(t, err)
+		expectedWs, err := expected.workSheetReader(sheet)
+		assert.NoError(t, err)
+		assert.Equal(t, expectedWs.Cols, ws.Cols)
+		assert.Equal(t, expectedWs.SheetData, ws.SheetData)
+	}
+	// Test set columns style with the errors of each worksheet joined
+	err = f.SetColStyleAll([]string{"SheetN", "Sheet1", "Chart1"}, "D", styleID)
+	assert.ErrorIs(t, err, ErrSheetNotExist{"SheetN"})
+	assert.ErrorContains(t, err, newNotWorksheetError("Chart1").Error())
+	style, err := f.GetColStyle("Sheet1", "D")
+	assert.NoError(t, err)
+	assert.Equal(t, styleID, style)
+	// Test set columns style with invalid columns and style ID
+	assert.Equal(t, newInvalidColumnNameError("*"), f.SetColStyleAll(nil, "A:*", styleID))
+	assert.Equal(t, newInvalidStyleID(-1), f.SetColStyleAll(nil, "A", -1))
+	assert.Equal(t, newInvalidStyleID(10), f.SetColStyleAll(nil, "A", 10))
+	// Test set columns style with unsupported charset style sheet
+	f.Styles = nil
+	f.Pkg.Store(defaultXMLPathStyles, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.SetColStyleAll(nil, "A", styleID), "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+	assert.NoError(t, expected.Close())
+}
+
+func TestSetColWidthAll(t *testing.T) {
+	f := NewFile()
+	_, err := f.NewSheet("Sheet2")
+	assert.NoError(t, err)
+	assert.NoError(t, f.AddChartSheet("Chart1", &Chart{
+		Type:   Col,
+		Series: []ChartSeries{{Name: "Sheet1!$A$1", Values: "Sheet1!$B$1:$D$1"}},
+	}))
+	assert.NoError(t, f.SetColWidthAll(nil, "C", "A", 12))
+	assert.NoError(t, f.SetColWidthAll([]string{"Sheet2"}, "E", "E", 0))
+	for _, sheet := range []string{"Sheet1", "Sheet2"} {
+		width, err := f.GetColWidth(sheet, "B")
+		assert.NoError(t, err)
+		assert.Equal(t, 12.0, width)
+	}
+	visible, err := f.GetColVisible("Sheet2", "E")
+	assert.NoError(t, err)
+	assert.False(t, visible)
+	visible, err = f.GetColVisible("Sheet1", "E")
+	assert.NoError(t, err)
+	assert.True(t, visible)
+	// Test set columns width with the errors of each worksheet joined
+	err = f.SetColWidthAll([]string{"Sheet1", "SheetN", "Sheet:1"}, "D", "D", 20)
+	assert.ErrorIs(t, err, ErrSheetNotExist{"SheetN"})
+	assert.ErrorIs(t, err, ErrSheetNameInvalid)
+	width, err := f.GetColWidth("Sheet1", "D")
+	assert.NoError(t, err)
+	assert.Equal(t, 20.0, width)
+	// Test set columns width with invalid columns and width
+	assert.Equal(t, newInvalidColumnNameError("*"), f.SetColWidthAll(nil, "A", "*", 12))
+	assert.Equal(t, ErrColumnWidth, f.SetColWidthAll(nil, "A", "B", MaxColumnWidth+1))
+	assert.Equal(t, ErrColumnWidthValue, f.SetColWidthAll(nil, "A", "B", -1))
+	assert.NoError(t, f.Close())
+}
+
+func TestColWidth(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetColWidth("Sheet1", "B", "A", 12))
+	assert.NoError(t, f.SetColWidth("Sheet1", "A", "B", 12))
+	width, err := f.GetColWidth("Sheet1", "A")
+	assert.Equal(t, float64(12), width)
+	assert.NoError(t, err)
+	width, err = f.GetColWidth("Sheet1", "C")
+	assert.Equal(t, defaultColWidth, width)
+	assert.NoError(t, err)
+
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	ws.(*xlsxWorksheet).SheetFormatPr = &xlsxSheetFormatPr{DefaultColWidth: 10}
+	ws.(*xlsxWorksheet).Cols = nil
+	width, err = f.GetColWidth("Sheet1", "A")
+	assert.NoError(t, err)
+	assert.Equal(t, 10.0, width)
+	assert.Equal(t, 80, f.getColWidth("Sheet1", 1, defaultMaxDigitWidth))
+
+	// Test set column width with zero to hide the columns
+	assert.NoError(t, f.SetColWidth("Sheet1", "B", "C", 0))
+	width, err = f.GetColWidth("Sheet1", "B")
+	assert.NoError(t, err)
+	assert.Zero(t, width)
+	visible, err := f.GetColVisible("Sheet1", "C")
+	assert.NoError(t, err)
+	assert.False(t, visible)
+	// Test set positive width on the zero-width hidden column to unhide it
+	assert.NoError(t, f.SetColWidth("Sheet1", "C", "C", 15))
+	width, err = f.GetColWidth("Sheet1", "C")
+	assert.NoError(t, err)
+	assert.Equal(t, 15.0, width)
+	visible, err = f.GetColVisible("Sheet1", "C")
+	assert.NoError(t, err)
+	assert.True(t, visible)
+	// Test the zero-width hidden columns authored by the spreadsheet
+	// applications round-trip unchanged
+	ws.(*xlsxWorksheet).Cols = &xlsxCols{Col: []xlsxCol{{Min: 4, Max: 4, Width: float64Ptr(0), Hidden: true, CustomWidth: true}}}
+	buf, err := f.WriteToBuffer()
+	assert.NoError(t, err)
+	f2, err := OpenReader(buf)
+	assert.NoError(t, err)
+	width, err = f2.GetColWidth("Sheet1", "D")
+	assert.NoError(t, err)
+	assert.Zero(t, width)
+	visible, err = f2.GetColVisible("Sheet1", "D")
+	assert.NoError(t, err)
+	assert.False(t, visible)
+	ws2, err := f2.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, ws.(*xlsxWorksheet).Cols.Col, ws2.Cols.Col)
+	assert.NoError(t, f2.Close())
+
+	// Test set column width with negative, NaN and infinite width
+	for _, width := range []float64{-1, math.NaN(), math.Inf(1), math.Inf(-1)} {
+		assert.Equal(t, ErrColumnWidthValue, f.SetColWidth("Sheet1", "A", "B", width))
+		assert.Equal(t, ErrColumnWidthValue, f.SetColProps("Sheet1", "A", ColProps{Width: float64Ptr(width)}))
+	}
+	assert.Equal(t, ErrColumnWidthValue, f.SetColWidths("Sheet1", map[string]float64{"A": math.NaN()}))
+
+	// Test set and get column width with illegal cell reference
+	width, err = f.GetColWidth("Sheet1", "*")
+	assert.Equal(t, defaultColWidth, width)
+	assert.EqualError(t, err, newInvalidColumnNameError("*").Error())
+	assert.EqualError(t, f.SetColWidth("Sheet1", "*", "B", 1), newInvalidColumnNameError("*").Error())
+	assert.EqualError(t, f.SetColWidth("Sheet1", "A", "*", 1), newInvalidColumnNameError("*").Error())
+
+	// Test set column width on not exists worksheet
+	assert.EqualError(t, f.SetColWidth("SheetN", "B", "A", 12), "sheet SheetN does not exist")
+	// Test get column width on not exists worksheet
+	_, err = f.GetColWidth("SheetN", "A")
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+	// Test get column width invalid sheet name
+	_, err = f.GetColWidth("Sheet:1", "A")
+	assert.EqualError(t, err, ErrSheetNameInvalid.Error())
+
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestColWidth.xlsx")))
+	convertRowHeightToPixels(0)
+}
+
+func TestSetColWidths(t *testing.T) {
+	f := NewFile()
+	style, err := f.NewStyle(&Style{Font: &Font{Bold: true}})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetColStyle("Sheet1", "A:Z", style))
+	assert.NoError(t, f.SetColVisible("Sheet1", "E", false))
+	widths := map[string]float64{"B": 12, "C:D": 12, "E": 20, "H:F": 30}
+	for col := 27; col <= 226; col += 2 {
+		name, err := ColumnNumberToName(col)
+		assert.NoError(t, err)
+		widths[name] = 15
+	}
+	assert.NoError(t, f.SetColWidths("Sheet1", widths))
+	for col, expected := range map[string]float64{
+		"A": defaultColWidth, "B": 12, "D": 12, "E": 20, "F": 30, "H": 30, "I": defaultColWidth, "AA": 15, "AB": defaultColWidth, "HQ": 15,
+	} {
+		width, err := f.GetColWidth("Sheet1", col)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, width, col)
+	}
+	visible, err := f.GetColVisible("Sheet1", "E")
+	assert.NoError(t, err)
+	assert.False(t, visible)
+	styleID, err := f.GetColStyle("Sheet1", "D")
+	assert.NoError(t, err)
+	assert.Equal(t, style, styleID)
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	assert.Equal(t, []xlsxCol{
+		{Min: 1, Max: 1, Style: style, Width: float64Ptr(defaultColWidth)},
+		{Min: 2, Max: 4, Style: style, Width: float64Ptr(12), CustomWidth: true},
+		{Min: 5, Max: 5, Style: style, Width: float64Ptr(20), CustomWidth: true, Hidden: true},
+		{Min: 6, Max: 8, Style: style, Width: float64Ptr(30), CustomWidth: true},
+		{Min: 9, Max: 26, Style: style, Width: float64Ptr(defaultColWidth)},
+	}, ws.(*xlsxWorksheet).Cols.Col[:5])
+	assert.Len(t, ws.(*xlsxWorksheet).Cols.Col, 105)
+	// Test set column widths produces the same widths as set one by one
+	f2 := NewFile()
+	for cols, width := range widths {
+		rng := strings.Split(cols, ":")
+		assert.NoError(t, f2.SetColWidth("Sheet1", rng[0], rng[len(rng)-1], width))
+	}
+	for col := 1; col <= 230; col++ {
+		name, err := ColumnNumberToName(col)
+		assert.NoError(t, err)
+		expected, err := f2.GetColWidth("Sheet1", name)
+		assert.NoError(t, err)
+		width, err := f.GetColWidth("Sheet1", name)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, width, name)
+	}
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestSetColWidths.xlsx")))
+	f3 := NewFile()
+	assert.NoError(t, f3.SetColWidths("Sheet1", nil))
+	ws, ok = f3.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	assert.Nil(t, ws.(*xlsxWorksheet).Cols)
+
+	// Test set column widths with invalid parameters
+	assert.Equal(t, ErrParameterInvalid, f.SetColWidths("Sheet1", map[string]float64{"A:C": 10, "C": 12}))
+	assert.Equal(t, ErrColumnWidth, f.SetColWidths("Sheet1", map[string]float64{"A": 256}))
+	assert.Equal(t, ErrColumnWidthValue, f.SetColWidths("Sheet1", map[string]float64{"A": -1}))
+	assert.Equal(t, ErrColumnWidthValue, f.SetColWidth("Sheet1", "A", "A", -1))
+	// Test set column widths with overlapping existing column ranges
+	ws.(*xlsxWorksheet).Cols = &xlsxCols{Col: []xlsxCol{
+		{Min: 1, Max: 10, Width: float64Ptr(20), CustomWidth: true},
+		{Min: 3, Max: 4, Width: float64Ptr(30), CustomWidth: true, Hidden: true},
+	}}
+	assert.NoError(t, f3.SetColWidths("Sheet1", map[string]float64{"B": 15}))
+	assert.Equal(t, []xlsxCol{
+		{Min: 1, Max: 1, Width: float64Ptr(20), CustomWidth: true},
+		{Min: 2, Max: 2, Width: float64Ptr(15), CustomWidth: true},
+		{Min: 3, Max: 4, Width: float64Ptr(30), CustomWidth: true, Hidden: true},
+		{Min: 5, Max: 10, Width: float64Ptr(20), CustomWidth: true},
+	}, ws.(*xlsxWorksheet).Cols.Col)
+	assert.EqualError(t, f.SetColWidths("Sheet1", map[string]float64{"*": 10}), newInvalidColumnNameError("*").Error())
+	assert.EqualError(t, f.SetColWidths("SheetN", map[string]float64{"A": 10}), "sheet SheetN does not exist")
+	assert.NoError(t, f.Close())
+}
+
+func TestAutoFitColWidthFontMetrics(t *testing.T) {
+	f := NewFile()
+	customFontStyle, err := f.NewStyle(&Style{Font: &Font{Family: "Custom", Size: 22}})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A1", &[]interface{}{"WWWW", "abc"}))
+	assert.NoError(t, f.SetCellStyle("Sheet1", "B1", "B1", customFontStyle))
+	assert.NoError(t, f.AutoFitColWidth("Sheet1", "A", "B"))
+	for col, expected := range map[string]float64{"A": 4.625, "B": 6.625} {
+		width, err := f.GetColWidth("Sheet1", col)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, width, col)
+	}
+	// Test auto fit column width with the registered font metrics
+	assert.NoError(t, f.RegisterFontMetrics("calibri", FontMetrics{MaxDigitWidth: 7, CharWidths: map[rune]float64{'W': 14}}))
+	assert.NoError(t, f.RegisterFontMetrics("Custom", FontMetrics{MaxDigitWidth: 10, DefaultCharWidth: 5}))
+	assert.NoError(t, f.AutoFitColWidth("Sheet1", "A", "B"))
+	for col, expected := range map[string]float64{"A": 8.7109375, "B": 5} {
+		width, err := f.GetColWidth("Sheet1", col)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, width, col)
+	}
+	// Test convert the column width and pixels with the registered metrics of
+	// the default font
+	assert.NoError(t, f.SetColWidthPixels("Sheet1", "C", "C", 70))
+	width, err := f.GetColWidth("Sheet1", "C")
+	assert.NoError(t, err)
+	assert.Equal(t, 10.0, width)
+	pixels, err := f.GetColWidthPixels("Sheet1", "C")
+	assert.NoError(t, err)
+	assert.Equal(t, 70, pixels)
+	assert.NoError(t, f.SetDefaultFont("Arial", 11))
+	pixels, err = f.GetColWidthPixels("Sheet1", "C")
+	assert.NoError(t, err)
+	assert.Equal(t, 80, pixels)
+	assert.NoError(t, f.Close())
+}
+
+func TestColWidthPixels(t *testing.T) {
+	f := NewFile()
+	for pixels := 1; pixels <= 300; pixels++ {
+		assert.NoError(t, f.SetColWidthPixels("Sheet1", "A", "B", pixels))
+		result, err := f.GetColWidthPixels("Sheet1", "B")
+		assert.NoError(t, err)
+		assert.InDelta(t, pixels, result, 1)
+		assert.Equal(t, pixels, ColWidthToPixels(PixelsToColWidth(pixels)))
+	}
+	assert.NoError(t, f.SetColWidthPixels("Sheet1", "C", "C", 100))
+	width, err := f.GetColWidth("Sheet1", "C")
+	assert.NoError(t, err)
+	assert.Equal(t, 12.5, width)
+	// Test get the width of default and hidden column in pixels
+	pixels, err := f.GetColWidthPixels("Sheet1", "D")
+	assert.NoError(t, err)
+	assert.Equal(t, int(defaultColWidthPixels), pixels)
+	assert.NoError(t, f.SetColVisible("Sheet1", "C", false))
+	pixels, err = f.GetColWidthPixels("Sheet1", "C")
+	assert.NoError(t, err)
+	assert.Zero(t, pixels)
+	// Test set and get column width in pixels with invalid parameters
+	assert.Equal(t, ErrParameterInvalid, f.SetColWidthPixels("Sheet1", "A", "A", -1))
+	assert.Equal(t, ErrColumnWidth, f.SetColWidthPixels("Sheet1", "A", "A", 2048))
+	assert.EqualError(t, f.SetColWidthPixels("Sheet1", "*", "A", 1), newInvalidColumnNameError("*").Error())
+	_, err = f.GetColWidthPixels("Sheet1", "*")
+	assert.EqualError(t, err, newInvalidColumnNameError("*").Error())
+	_, err = f.GetColWidthPixels("SheetN", "A")
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+	assert.NoError(t, f.Close())
+}
+
+func TestColWidthUnit(t *testing.T) {
+	f := NewFile()
+	for _, c := range []struct {
+		value float64
+		unit  MeasurementUnit
+	}{
+		{2.5, UnitCentimeters}, {1, UnitInches}, {0.75, UnitInches}, {30, UnitMillimeters}, {48, UnitPoints}, {0.2, UnitCentimeters},
+	} {
+		assert.NoError(t, f.SetColWidthUnit("Sheet1", "A:C", c.value, c.unit))
+		for _, col := range []string{"A", "C"} {
+			width, err := f.GetColWidthUnit("Sheet1", col, c.unit)
+			assert.NoError(t, err)
+			assert.InDelta(t, c.value, width, c.unit.fromPixels(1))
+		}
+	}
+	// Test get the width of default column, 84 pixels in 96 DPI
+	width, err := f.GetColWidthUnit("Sheet1", "D", UnitInches)
+	assert.NoError(t, err)
+	assert.Equal(t, 0.875, width)
+	width, err = f.GetColWidthUnit("Sheet1", "D", UnitCentimeters)
+	assert.NoError(t, err)
+	assert.InDelta(t, 2.22, width, 0.005)
+	// Test set and get column width with invalid parameters
+	assert.Equal(t, ErrParameterInvalid, f.SetColWidthUnit("Sheet1", "A", -1, UnitInches))
+	assert.Equal(t, ErrParameterInvalid, f.SetColWidthUnit("Sheet1", "A", 1, MeasurementUnit(10)))
+	assert.Equal(t, ErrColumnWidth, f.SetColWidthUnit("Sheet1", "A", 30, UnitInches))
+	assert.EqualError(t, f.SetColWidthUnit("Sheet1", "*", 1, UnitInches), newInvalidColumnNameError("*").Error())
+	_, err = f.GetColWidthUnit("Sheet1", "A", MeasurementUnit(10))
+	assert.Equal(t, ErrParameterInvalid, err)
+	_, err = f.GetColWidthUnit("SheetN", "A", UnitInches)
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+	assert.NoError(t, f.Close())
+}
+
+func TestAutoFitColWidth(t *testing.T) {
+	f := NewFile()
+	boldStyle, err := f.NewStyle(&Style{Font: &Font{Bold: true}})
+	assert.NoError(t, err)
+	numFmtStyle, err := f.NewStyle(&Style{NumFmt: 4})
+	assert.NoError(t, err)
+	largeFontStyle, err := f.NewStyle(&Style{Font: &Font{Size: 22}})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A1", &[]interface{}{"Name", 1234.5678, "中文字符", "x", strings.Repeat("x", 300), "ab\nabcdef", nil, "Header12", "abcd"}))
+	assert.NoError(t, f.SetSheetCol("Sheet1", "A2", &[]interface{}{"Alice", "Bartholomew"}))
+	assert.NoError(t, f.SetCellValue("Sheet1", "H2", "abc"))
+	assert.NoError(t, f.SetCellStyle("Sheet1", "A1", "A1", boldStyle))
+	assert.NoError(t, f.SetCellStyle("Sheet1", "H1", "H1", boldStyle))
+	assert.NoError(t, f.SetCellStyle("Sheet1", "B1", "B1", numFmtStyle))
+	assert.NoError(t, f.SetCellStyle("Sheet1", "I1", "I1", largeFontStyle))
+
+	assert.NoError(t, f.AutoFitColWidth("Sheet1", "A", "C"))
+	assert.NoError(t, f.AutoFitColWidth("Sheet1", "I", "F", AutoFitOptions{ConsiderBold: true}))
+	assert.NoError(t, f.AutoFitColWidth("Sheet1", "D", "D", AutoFitOptions{MinWidth: 8}))
+	assert.NoError(t, f.AutoFitColWidth("Sheet1", "E", "E", AutoFitOptions{MaxWidth: 50}))
+	for col, expected := range map[string]float64{
+		"A": 11.625, "B": 8.625, "C": 8.625, "D": 8, "E": 50, "F": 6.625,
+		"G": defaultColWidth, "H": 9.421875, "I": 8.625,
+	} {
+		width, err := f.GetColWidth("Sheet1", col)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, width, col)
+	}
+	// Test auto fit column width without the max width limit
+	assert.NoError(t, f.AutoFitColWidth("Sheet1", "E", "E"))
+	width, err := f.GetColWidth("Sheet1", "E")
+	assert.NoError(t, err)
+	assert.Equal(t, float64(MaxColumnWidth), width)
+	// Test auto fit column width with indented cells
+	indentStyle, err := f.NewStyle(&Style{Alignment: &Alignment{Horizontal: "left", Indent: 2}})
 	assert.NoError(t, err)
-	assert.Equal(t, 20.0, width)
+	assert.NoError(t, f.SetCellValue("Sheet1", "J1", "abcd"))
+	assert.NoError(t, f.SetCellStyle("Sheet1", "J1", "J1", indentStyle))
+	assert.NoError(t, f.AutoFitColWidth("Sheet1", "J", "J"))
+	width, err = f.GetColWidth("Sheet1", "J")
+	assert.NoError(t, err)
+	assert.Equal(t, 10.625, width)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAutoFitColWidth.xlsx")))
+
+	// Test auto fit column width with invalid options
+	assert.Equal(t, ErrParameterInvalid, f.AutoFitColWidth("Sheet1", "A", "A", AutoFitOptions{MinWidth: -1}))
+	assert.Equal(t, ErrParameterInvalid, f.AutoFitColWidth("Sheet1", "A", "A", AutoFitOptions{MinWidth: 20, MaxWidth: 10}))
+	// Test auto fit column width with illegal column name
+	assert.EqualError(t, f.AutoFitColWidth("Sheet1", "*", "A"), newInvalidColumnNameError("*").Error())
+	// Test auto fit column width on not exists worksheet
+	assert.EqualError(t, f.AutoFitColWidth("SheetN", "A", "A"), "sheet SheetN does not exist")
+	// Test auto fit column width with unsupported charset style sheet
+	f.Styles = nil
+	f.Pkg.Store(defaultXMLPathStyles, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.AutoFitColWidth("Sheet1", "A", "A"), "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
 }
 
-func TestColWidth(t *testing.T) {
+func TestColProps(t *testing.T) {
 	f := NewFile()
-	assert.NoError(t, f.SetColWidth("Sheet1", "B", "A", 12))
-	assert.NoError(t, f.SetColWidth("Sheet1", "A", "B", 12))
-	width, err := f.GetColWidth("Sheet1", "A")
-	assert.Equal(t, float64(12), width)
-	assert.NoError(t, err)
-	width, err = f.GetColWidth("Sheet1", "C")
-	assert.Equal(t, defaultColWidth, width)
+	props, err := f.GetColProps("Sheet1", "D")
 	assert.NoError(t, err)
+	assert.Equal(t, ColProps{
+		Width: float64Ptr(defaultColWidth), Hidden: boolPtr(false), BestFit: boolPtr(false),
+		Collapsed: boolPtr(false), OutlineLevel: uint8Ptr(0), Style: intPtr(0), Phonetic: boolPtr(false),
+	}, props)
 
-	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
-	assert.True(t, ok)
-	ws.(*xlsxWorksheet).SheetFormatPr = &xlsxSheetFormatPr{DefaultColWidth: 10}
-	ws.(*xlsxWorksheet).Cols = nil
-	width, err = f.GetColWidth("Sheet1", "A")
+	styleID, err := f.NewStyle(&Style{Font: &Font{Bold: true}})
 	assert.NoError(t, err)
-	assert.Equal(t, 10.0, width)
-	assert.Equal(t, 80, f.getColWidth("Sheet1", 1))
+	assert.NoError(t, f.SetColWidth("Sheet1", "D", "F", 20))
+	assert.NoError(t, f.SetColOutlineLevel("Sheet1", "E", 2))
+	assert.NoError(t, f.SetColProps("Sheet1", "E:G", ColProps{
+		BestFit: boolPtr(true), Collapsed: boolPtr(true), Style: intPtr(styleID), Phonetic: boolPtr(true),
+	}))
+	// Test set column settings keeps the settings of the nil fields
+	props, err = f.GetColProps("Sheet1", "E")
+	assert.NoError(t, err)
+	assert.Equal(t, ColProps{
+		Width: float64Ptr(20), Hidden: boolPtr(false), BestFit: boolPtr(true),
+		Collapsed: boolPtr(true), OutlineLevel: uint8Ptr(2), Style: intPtr(styleID), Phonetic: boolPtr(true),
+	}, props)
+	props, err = f.GetColProps("Sheet1", "G")
+	assert.NoError(t, err)
+	assert.Equal(t, defaultColWidth, *props.Width)
+	assert.True(t, *props.BestFit)
+	props, err = f.GetColProps("Sheet1", "D")
+	assert.NoError(t, err)
+	assert.Equal(t, 20.0, *props.Width)
+	assert.False(t, *props.BestFit)
+	assert.NoError(t, f.SetColProps("Sheet1", "D:E", ColProps{
+		Width: float64Ptr(30), Hidden: boolPtr(true), OutlineLevel: uint8Ptr(1), BestFit: boolPtr(false),
+	}))
+	for _, col := range []string{"D", "E"} {
+		props, err = f.GetColProps("Sheet1", col)
+		assert.NoError(t, err)
+		assert.Equal(t, 30.0, *props.Width)
+		assert.True(t, *props.Hidden)
+		assert.False(t, *props.BestFit)
+		assert.Equal(t, uint8(1), *props.OutlineLevel)
+		width, err := f.GetColWidth("Sheet1", col)
+		assert.NoError(t, err)
+		assert.Equal(t, 30.0, width)
+		visible, err := f.GetColVisible("Sheet1", col)
+		assert.NoError(t, err)
+		assert.False(t, visible)
+	}
+	props, err = f.GetColProps("Sheet1", "E")
+	assert.NoError(t, err)
+	assert.True(t, *props.Collapsed)
+	assert.Equal(t, styleID, *props.Style)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestColProps.xlsx")))
+	assert.NoError(t, f.Close())
 
-	// Test set and get column width with illegal cell reference
-	width, err = f.GetColWidth("Sheet1", "*")
-	assert.Equal(t, defaultColWidth, width)
+	// Test set and get column settings in a worksheet without columns
+	f, err = OpenFile(filepath.Join("test", "TestColProps.xlsx"))
+	assert.NoError(t, err)
+	props, err = f.GetColProps("Sheet1", "F")
+	assert.NoError(t, err)
+	assert.True(t, *props.BestFit)
+	assert.True(t, *props.Phonetic)
+	_, err = f.NewSheet("Sheet2")
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetColProps("Sheet2", "B:C", ColProps{Collapsed: boolPtr(true)}))
+	ws, err := f.workSheetReader("Sheet2")
+	assert.NoError(t, err)
+	assert.Equal(t, []xlsxCol{{Min: 2, Max: 3, Collapsed: true}}, ws.Cols.Col)
+	// Test get column settings with default column width of the worksheet
+	ws.SheetFormatPr = &xlsxSheetFormatPr{DefaultColWidth: 12}
+	props, err = f.GetColProps("Sheet2", "B")
+	assert.NoError(t, err)
+	assert.Equal(t, 12.0, *props.Width)
+	// Test set column settings with invalid settings
+	assert.Equal(t, ErrColumnWidth, f.SetColProps("Sheet1", "A", ColProps{Width: float64Ptr(MaxColumnWidth + 1)}))
+	assert.Equal(t, ErrOutlineLevel, f.SetColProps("Sheet1", "A", ColProps{OutlineLevel: uint8Ptr(8)}))
+	assert.EqualError(t, f.SetColProps("Sheet1", "A", ColProps{Style: intPtr(-1)}), newInvalidStyleID(-1).Error())
+	// Test set and get column settings with illegal column name
+	assert.EqualError(t, f.SetColProps("Sheet1", "*", ColProps{}), newInvalidColumnNameError("*").Error())
+	_, err = f.GetColProps("Sheet1", "*")
 	assert.EqualError(t, err, newInvalidColumnNameError("*").Error())
-	assert.EqualError(t, f.SetColWidth("Sheet1", "*", "B", 1), newInvalidColumnNameError("*").Error())
-	assert.EqualError(t, f.SetColWidth("Sheet1", "A", "*", 1), newInvalidColumnNameError("*").Error())
-
-	// Test set column width on not exists worksheet
-	assert.EqualError(t, f.SetColWidth("SheetN", "B", "A", 12), "sheet SheetN does not exist")
-	// Test get column width on not exists worksheet
-	_, err = f.GetColWidth("SheetN", "A")
+	// Test set and get column settings on not exists worksheet
+	assert.EqualError(t, f.SetColProps("SheetN", "A", ColProps{}), "sheet SheetN does not exist")
+	_, err = f.GetColProps("SheetN", "A")
 	assert.EqualError(t, err, "sheet SheetN does not exist")
-	// Test get column width invalid sheet name
-	_, err = f.GetColWidth("Sheet:1", "A")
-	assert.EqualError(t, err, ErrSheetNameInvalid.Error())
-
-	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestColWidth.xlsx")))
-	convertRowHeightToPixels(0)
+	// Test set column settings with unsupported charset style sheet
+	f.Styles = nil
+	f.Pkg.Store(defaultXMLPathStyles, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.SetColProps("Sheet1", "A", ColProps{Style: intPtr(1)}), "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
 }
 
 func TestGetColStyle(t *testing.T) {
@@ -483,6 +2772,734 @@ func TestRemoveCol(t *testing.T) {
 	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestRemoveCol.xlsx")))
 }
 
+func TestRemoveColCleanup(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, fillCells(f, "Sheet1", 5, 10))
+	assert.NoError(t, f.MergeCell("Sheet1", "C1", "C2"))
+	assert.NoError(t, f.MergeCell("Sheet1", "B3", "D3"))
+	assert.NoError(t, f.MergeCell("Sheet1", "C4", "D4"))
+	assert.NoError(t, f.SetCellHyperLink("Sheet1", "C5", "https://github.com/xuri/excelize", "External"))
+	assert.NoError(t, f.SetCellHyperLink("Sheet1", "D5", "https://github.com", "External"))
+	assert.NoError(t, f.SetCellHyperLink("Sheet1", "C6", "Sheet1!A1", "Location"))
+	assert.NoError(t, f.AddComment("Sheet1", Comment{Cell: "C7", Author: "Excelize", Text: "C7"}))
+	assert.NoError(t, f.AddComment("Sheet1", Comment{Cell: "D7", Author: "Excelize", Text: "D7"}))
+	ws, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	ws.Hyperlinks.Hyperlink = append(ws.Hyperlinks.Hyperlink,
+		xlsxHyperlink{Ref: "C8:C9", Location: "Sheet1!A1"}, xlsxHyperlink{Ref: "B10:D10", Location: "Sheet1!A1"})
+	assert.NoError(t, f.RemoveCol("Sheet1", "C"))
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestRemoveColCleanup.xlsx")))
+	assert.NoError(t, f.Close())
+
+	f, err = OpenFile(filepath.Join("test", "TestRemoveColCleanup.xlsx"))
+	assert.NoError(t, err)
+	// Test the merged cells in the removed column are deleted, and the merged
+	// cells straddle the removed column are shrunk
+	mergeCells, err := f.GetMergeCells("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, mergeCells, 1)
+	assert.Equal(t, "B3:C3", mergeCells[0].GetStartAxis()+":"+mergeCells[0].GetEndAxis())
+	// Test the hyperlinks and relationships anchored in the removed column are
+	// deleted
+	ws, err = f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	var refs []string
+	for _, link := range ws.Hyperlinks.Hyperlink {
+		refs = append(refs, link.Ref)
+	}
+	assert.Equal(t, []string{"C5", "B10:C10"}, refs)
+	ok, target, err := f.GetCellHyperLink("Sheet1", "C5")
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, "https://github.com", target)
+	rels, err := f.relsReader("xl/worksheets/_rels/sheet1.xml.rels")
+	assert.NoError(t, err)
+	for _, rel := range rels.Relationships {
+		assert.NotEqual(t, "https://github.com/xuri/excelize", rel.Target)
+	}
+	// Test the comments in the removed column are deleted
+	comments, err := f.GetComments("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, comments, 1)
+	assert.Equal(t, "C7", comments[0].Cell)
+	assert.Equal(t, "D7", comments[0].Text)
+	assert.NoError(t, f.Close())
+}
+
+func TestRemoveCols(t *testing.T) {
+	prepare := func() *File {
+		f := NewFile()
+		assert.NoError(t, fillCells(f, "Sheet1", 10, 6))
+		assert.NoError(t, f.SetCellFormula("Sheet1", "J1", "G1+H1"))
+		assert.NoError(t, f.SetCellFormula("Sheet1", "J2", "SUM($G$2:H2)"))
+		assert.NoError(t, f.SetColWidth("Sheet1", "B", "D", 20))
+		assert.NoError(t, f.SetColWidth("Sheet1", "E", "H", 30))
+		assert.NoError(t, f.MergeCell("Sheet1", "B4", "D4"))
+		assert.NoError(t, f.MergeCell("Sheet1", "E5", "H5"))
+		assert.NoError(t, f.MergeCell("Sheet1", "G6", "I6"))
+		for _, sqref := range []string{"C1:D1", "G1:H3"} {
+			dv := NewDataValidation(true)
+			dv.SetSqref(sqref)
+			assert.NoError(t, dv.SetRange(1, 10, DataValidationTypeWhole, DataValidationOperatorBetween))
+			assert.NoError(t, f.AddDataValidation("Sheet1", dv))
+		}
+		return f
+	}
+	f := prepare()
+	assert.NoError(t, f.RemoveCols("Sheet1", "C", "F"))
+	formula, err := f.GetCellFormula("Sheet1", "F1")
+	assert.NoError(t, err)
+	assert.Equal(t, "C1+D1", formula)
+	formula, err = f.GetCellFormula("Sheet1", "F2")
+	assert.NoError(t, err)
+	assert.Equal(t, "SUM($C$2:D2)", formula)
+	cols, err := f.GetCols("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, cols, 6)
+	assert.Equal(t, "G1", cols[2][0])
+	for col, width := range map[string]float64{"A": defaultColWidth, "B": 20, "C": 30, "D": 30, "E": defaultColWidth} {
+		w, err := f.GetColWidth("Sheet1", col)
+		assert.NoError(t, err)
+		assert.Equal(t, width, w, col)
+	}
+	mergeCells, err := f.GetMergeCells("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, mergeCells, 2)
+	assert.Equal(t, "C5:D5", mergeCells[0].GetStartAxis()+":"+mergeCells[0].GetEndAxis())
+	assert.Equal(t, "C6:E6", mergeCells[1].GetStartAxis()+":"+mergeCells[1].GetEndAxis())
+	dvs, err := f.GetDataValidations("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, dvs, 1)
+	assert.Equal(t, "C1:D3", dvs[0].Sqref)
+
+	// Test remove columns range is the same as remove columns one by one
+	expected := prepare()
+	for i := 0; i < 4; i++ {
+		assert.NoError(t, expected.RemoveCol("Sheet1", "C"))
+	}
+	ws, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	expectedWs, err := expected.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, expectedWs.SheetData, ws.SheetData)
+	assert.Equal(t, expectedWs.Cols, ws.Cols)
+	assert.Equal(t, expectedWs.MergeCells.Cells, ws.MergeCells.Cells)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestRemoveCols.xlsx")))
+
+	// Test remove columns with invalid columns range
+	assert.Equal(t, ErrParameterInvalid, f.RemoveCols("Sheet1", "D", "C"))
+	assert.EqualError(t, f.RemoveCols("Sheet1", "*", "C"), newInvalidColumnNameError("*").Error())
+	assert.EqualError(t, f.RemoveCols("Sheet1", "A", "*"), newInvalidColumnNameError("*").Error())
+	assert.Equal(t, ErrColumnNumber, f.RemoveCols("Sheet1", "A", "XFE"))
+	// Test remove columns on not exists worksheet
+	assert.EqualError(t, f.RemoveCols("SheetN", "A", "B"), "sheet SheetN does not exist")
+}
+
+func TestMoveCols(t *testing.T) {
+	f := NewFile()
+	for col := 1; col <= 7; col++ {
+		name, err := ColumnNumberToName(col)
+		assert.NoError(t, err)
+		assert.NoError(t, f.SetCellValue("Sheet1", name+"1", name))
+		assert.NoError(t, f.SetCellValue("Sheet1", name+"2", col))
+	}
+	style, err := f.NewStyle(&Style{Font: &Font{Bold: true}})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellStyle("Sheet1", "D2", "D2", style))
+	assert.NoError(t, f.SetColWidth("Sheet1", "D", "D", 20))
+	assert.NoError(t, f.SetColVisible("Sheet1", "E", false))
+	assert.NoError(t, f.SetColOutlineLevel("Sheet1", "F", 2))
+	assert.NoError(t, f.MergeCell("Sheet1", "D3", "E3"))
+	assert.NoError(t, f.MergeCell("Sheet1", "A4", "D4"))
+	assert.NoError(t, f.AddComment("Sheet1", Comment{Cell: "E4", Author: "Excelize", Text: "comment"}))
+	assert.NoError(t, f.AddComment("Sheet1", Comment{Cell: "B5", Author: "Excelize", Text: "shifted"}))
+	dv := NewDataValidation(true)
+	dv.SetSqref("F2:F5")
+	assert.NoError(t, dv.SetDropList([]string{"1", "2"}))
+	assert.NoError(t, f.AddDataValidation("Sheet1", dv))
+	assert.NoError(t, f.SetCellHyperLink("Sheet1", "D5", "https://github.com/xuri/excelize", "External"))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "A7", "D2+F2"))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "H2", "SUM(A2:G2)"))
+	_, err = f.NewSheet("Sheet 2")
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellFormula("Sheet 2", "A1", "Sheet1!E2*2"))
+	assert.NoError(t, f.SetDefinedName(&DefinedName{Name: "Moved", RefersTo: "Sheet1!$D$2:$F$2"}))
+
+	assert.NoError(t, f.MoveCols("Sheet1", "D:F", "B"))
+	rows, err := f.GetRows("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"A", "D", "E", "F", "B", "C", "G"}, rows[0])
+	assert.Equal(t, []string{"1", "4", "5", "6", "2", "3", "7"}, rows[1][:7])
+	styleID, err := f.GetCellStyle("Sheet1", "B2")
+	assert.NoError(t, err)
+	assert.Equal(t, style, styleID)
+	width, err := f.GetColWidth("Sheet1", "B")
+	assert.NoError(t, err)
+	assert.Equal(t, 20.0, width)
+	width, err = f.GetColWidth("Sheet1", "E")
+	assert.NoError(t, err)
+	assert.Equal(t, defaultColWidth, width)
+	visible, err := f.GetColVisible("Sheet1", "C")
+	assert.NoError(t, err)
+	assert.False(t, visible)
+	level, err := f.GetColOutlineLevel("Sheet1", "D")
+	assert.NoError(t, err)
+	assert.Equal(t, uint8(2), level)
+	mergeCells, err := f.GetMergeCells("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, mergeCells, 2)
+	assert.Equal(t, "B3:C3", mergeCells[0].GetStartAxis()+":"+mergeCells[0].GetEndAxis())
+	comments, err := f.GetComments("Sheet1")
+	assert.NoError(t, err)
+	cells := map[string]string{}
+	for _, comment := range comments {
+		cells[comment.Cell] = comment.Text
+	}
+	assert.Equal(t, map[string]string{"C4": "comment", "E5": "shifted"}, cells)
+	dvs, err := f.GetDataValidations("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, dvs, 1)
+	assert.Equal(t, "D2:D5", dvs[0].Sqref)
+	link, target, err := f.GetCellHyperLink("Sheet1", "B5")
+	assert.NoError(t, err)
+	assert.True(t, link)
+	assert.Equal(t, "https://github.com/xuri/excelize", target)
+	for cell, expected := range map[string]string{"Sheet1!A7": "B2+D2", "Sheet1!H2": "SUM(A2:G2)", "Sheet 2!A1": "Sheet1!C2*2"} {
+		ref := strings.Split(cell, "!")
+		formula, err := f.GetCellFormula(ref[0], ref[1])
+		assert.NoError(t, err)
+		assert.Equal(t, expected, formula, cell)
+	}
+	assert.Equal(t, "Sheet1!$B$2:$D$2", f.GetDefinedName()[0].RefersTo)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestMoveCols.xlsx")))
+
+	// Test move columns to the right side
+	assert.NoError(t, f.MoveCols("Sheet1", "B:D", "H"))
+	rows, err = f.GetRows("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"A", "B", "C", "G", "D", "E", "F"}, rows[0])
+	for cell, expected := range map[string]string{"Sheet1!A7": "E2+G2", "Sheet1!H2": "SUM(A2:D2)", "Sheet 2!A1": "Sheet1!F2*2"} {
+		ref := strings.Split(cell, "!")
+		formula, err := f.GetCellFormula(ref[0], ref[1])
+		assert.NoError(t, err)
+		assert.Equal(t, expected, formula, cell)
+	}
+	assert.Equal(t, "Sheet1!$E$2:$G$2", f.GetDefinedName()[0].RefersTo)
+	width, err = f.GetColWidth("Sheet1", "E")
+	assert.NoError(t, err)
+	assert.Equal(t, 20.0, width)
+	comments, err = f.GetComments("Sheet1")
+	assert.NoError(t, err)
+	cells = map[string]string{}
+	for _, comment := range comments {
+		cells[comment.Cell] = comment.Text
+	}
+	assert.Equal(t, map[string]string{"F4": "comment", "B5": "shifted"}, cells)
+
+	// Test move columns onto itself or into its own interior
+	for _, destCol := range []string{"B", "C", "D", "E"} {
+		assert.EqualError(t, f.MoveCols("Sheet1", "B:D", destCol), newMoveColsError("B:D", destCol).Error())
+	}
+	// Test move columns with invalid columns range
+	assert.EqualError(t, f.MoveCols("Sheet1", "*", "A"), newInvalidColumnNameError("*").Error())
+	assert.EqualError(t, f.MoveCols("Sheet1", "B", "*"), newInvalidColumnNameError("*").Error())
+	// Test move columns on not exists worksheet
+	assert.Equal(t, ErrSheetNotExist{"SheetN"}, f.MoveCols("SheetN", "B", "D"))
+	assert.NoError(t, f.Close())
+}
+
+func TestDuplicateCol(t *testing.T) {
+	f := NewFile()
+	for cell, value := range map[string]interface{}{"A1": 1, "B1": 2, "C1": 3} {
+		assert.NoError(t, f.SetCellValue("Sheet1", cell, value))
+	}
+	assert.NoError(t, f.SetCellFormula("Sheet1", "B2", "A1+B1"))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "D1", "SUM(B1:C1)"))
+	style, err := f.NewStyle(&Style{NumFmt: 4})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellStyle("Sheet1", "B1", "B1", style))
+	assert.NoError(t, f.SetColWidth("Sheet1", "B", "B", 25))
+	assert.NoError(t, f.SetColVisible("Sheet1", "B", false))
+	assert.NoError(t, f.MergeCell("Sheet1", "A3", "C3"))
+	assert.NoError(t, f.AddComment("Sheet1", Comment{Cell: "B1", Author: "Excelize", Text: "Duplicated"}))
+	assert.NoError(t, f.DuplicateCol("Sheet1", "B"))
+	for cell, expected := range map[string]string{"B1": "2.00", "C1": "2.00", "D1": "3"} {
+		val, err := f.GetCellValue("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, val, cell)
+	}
+	styleID, err := f.GetCellStyle("Sheet1", "C1")
+	assert.NoError(t, err)
+	assert.Equal(t, style, styleID)
+	for cell, expected := range map[string]string{"B2": "A1+B1", "C2": "B1+C1", "E1": "SUM(B1:D1)"} {
+		formula, err := f.GetCellFormula("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, formula, cell)
+	}
+	width, err := f.GetColWidth("Sheet1", "C")
+	assert.NoError(t, err)
+	assert.Equal(t, 25.0, width)
+	visible, err := f.GetColVisible("Sheet1", "C")
+	assert.NoError(t, err)
+	assert.False(t, visible)
+	mergeCells, err := f.GetMergeCells("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, mergeCells, 1)
+	assert.Equal(t, "A3:D3", mergeCells[0].GetStartAxis()+":"+mergeCells[0].GetEndAxis())
+	comments, err := f.GetComments("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, comments, 2)
+	for _, comment := range comments {
+		assert.Contains(t, []string{"B1", "C1"}, comment.Cell)
+		assert.Equal(t, "Duplicated", comment.Text)
+	}
+	// Test duplicate column to the position before it
+	assert.NoError(t, f.DuplicateColTo("Sheet1", "D", 1))
+	for cell, expected := range map[string]string{"A1": "3", "B1": "1", "E1": "3"} {
+		val, err := f.GetCellValue("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, val, cell)
+	}
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestDuplicateCol.xlsx")))
+	// Test duplicate column with invalid arguments
+	assert.Equal(t, newInvalidColumnNameError("-"), f.DuplicateCol("Sheet1", "-"))
+	assert.Equal(t, newInvalidColumnNameError("-"), f.DuplicateColTo("Sheet1", "-", 1))
+	assert.Equal(t, ErrColumnNumber, f.DuplicateColTo("Sheet1", "A", 0))
+	assert.Equal(t, ErrColumnNumber, f.DuplicateColTo("Sheet1", "A", MaxColumns+1))
+	assert.EqualError(t, f.DuplicateCol("SheetN", "A"), "sheet SheetN does not exist")
+	// Test duplicate column with invalid comment cell reference
+	f.Pkg.Store("xl/comments1.xml", []byte(xml.Header+`<comments xmlns="`+NameSpaceSpreadSheet.Value+`"><authors><author>Excelize</author></authors><commentList><comment ref="A" authorId="0"><text><t>Text</t></text></comment></commentList></comments>`))
+	f.Comments = map[string]*xlsxComments{}
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), f.DuplicateCol("Sheet1", "A"))
+	assert.NoError(t, f.Close())
+}
+
+func TestCopyCol(t *testing.T) {
+	f := NewFile()
+	for cell, value := range map[string]interface{}{"A1": "A1", "B1": "Header", "B2": 10, "B3": 20} {
+		assert.NoError(t, f.SetCellValue("Sheet1", cell, value))
+	}
+	style, err := f.NewStyle(&Style{NumFmt: 4})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellStyle("Sheet1", "B2", "B3", style))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "B4", "SUM(B2:B3)"))
+	formulaType, ref := STCellFormulaTypeShared, "B5:B7"
+	assert.NoError(t, f.SetCellFormula("Sheet1", "B5", "B2*2", FormulaOpts{Type: &formulaType, Ref: &ref}))
+	assert.NoError(t, f.MergeCell("Sheet1", "B8", "B9"))
+	display := "Excelize"
+	assert.NoError(t, f.SetCellHyperLink("Sheet1", "B10", "https://github.com/xuri/excelize", "External", HyperlinkOpts{Display: &display}))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "B11", "A1&$A$1"))
+	ref = "E1:F2"
+	assert.NoError(t, f.SetCellFormula("Sheet1", "E1", "A1+1", FormulaOpts{Type: &formulaType, Ref: &ref}))
+	assert.NoError(t, f.SetColWidth("Sheet1", "B", "B", 25))
+	assert.NoError(t, f.SetColOutlineLevel("Sheet1", "B", 1))
+	dv := NewDataValidation(true)
+	dv.SetSqref("B2:B3")
+	assert.NoError(t, dv.SetDropList([]string{"10", "20"}))
+	assert.NoError(t, f.AddDataValidation("Sheet1", dv))
+	format, err := f.NewConditionalStyle(&Style{Font: &Font{Color: "9A0511"}})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetConditionalFormat("Sheet1", "A1:C3", []ConditionalFormatOptions{
+		{Type: "cell", Criteria: ">", Format: &format, Value: "15"},
+	}))
+	assert.NoError(t, f.SetConditionalFormat("Sheet1", "A2:B3", []ConditionalFormatOptions{
+		{Type: "formula", Criteria: "B2>A$2", Format: &format},
+	}))
+	dv = NewDataValidation(true)
+	dv.SetSqref("B5:B7")
+	assert.NoError(t, dv.SetRange("A5", "$A$1", DataValidationTypeWhole, DataValidationOperatorBetween))
+	assert.NoError(t, f.AddDataValidation("Sheet1", dv))
+	_, err = f.NewSheet("Sheet2")
+	assert.NoError(t, err)
+
+	// Test copy column into another worksheet
+	assert.NoError(t, f.CopyCol("Sheet1", "B", "Sheet2", "D"))
+	for cell, expected := range map[string]string{"D1": "Header", "D2": "10.00", "D3": "20.00"} {
+		val, err := f.GetCellValue("Sheet2", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, val, cell)
+	}
+	styleID, err := f.GetCellStyle("Sheet2", "D2")
+	assert.NoError(t, err)
+	assert.Equal(t, style, styleID)
+	for cell, expected := range map[string]string{"D4": "SUM(D2:D3)", "D5": "D2*2", "D6": "D3*2", "D7": "D4*2", "D11": "C1&$A$1"} {
+		formula, err := f.GetCellFormula("Sheet2", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, formula, cell)
+	}
+	mergeCells, err := f.GetMergeCells("Sheet2")
+	assert.NoError(t, err)
+	assert.Len(t, mergeCells, 1)
+	assert.Equal(t, "D8:D9", mergeCells[0].GetStartAxis()+":"+mergeCells[0].GetEndAxis())
+	link, target, err := f.GetCellHyperLink("Sheet2", "D10")
+	assert.NoError(t, err)
+	assert.True(t, link)
+	assert.Equal(t, "https://github.com/xuri/excelize", target)
+	width, err := f.GetColWidth("Sheet2", "D")
+	assert.NoError(t, err)
+	assert.Equal(t, 25.0, width)
+	level, err := f.GetColOutlineLevel("Sheet2", "D")
+	assert.NoError(t, err)
+	assert.Equal(t, uint8(1), level)
+	dvs, err := f.GetDataValidations("Sheet2")
+	assert.NoError(t, err)
+	assert.Len(t, dvs, 2)
+	assert.Equal(t, "D2:D3", dvs[0].Sqref)
+	assert.Equal(t, "D5:D7", dvs[1].Sqref)
+	assert.Equal(t, "C5", dvs[1].Formula1)
+	assert.Equal(t, "$A$1", dvs[1].Formula2)
+	cfs, err := f.GetConditionalFormats("Sheet2")
+	assert.NoError(t, err)
+	assert.Len(t, cfs["D1:D3"], 1)
+	assert.Equal(t, "15", cfs["D1:D3"][0].Value)
+	assert.Len(t, cfs["D2:D3"], 1)
+	assert.Equal(t, "E2>D$2", cfs["D2:D3"][0].Criteria)
+
+	// Test copy column with shared formula across columns
+	assert.NoError(t, f.CopyCol("Sheet1", "E", "Sheet2", "H"))
+	for cell, expected := range map[string]string{"H1": "D1+1", "H2": "D2+1"} {
+		formula, err := f.GetCellFormula("Sheet2", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, formula, cell)
+	}
+	ws, err := f.workSheetReader("Sheet2")
+	assert.NoError(t, err)
+	assert.Empty(t, ws.SheetData.Row[0].C[7].F.T)
+
+	// Test copy column without values and properties
+	disable := false
+	assert.NoError(t, f.CopyCol("Sheet1", "B", "Sheet2", "F", CopyColOptions{Values: &disable, Properties: &disable}))
+	val, err := f.GetCellValue("Sheet2", "F1")
+	assert.NoError(t, err)
+	assert.Empty(t, val)
+	styleID, err = f.GetCellStyle("Sheet2", "F2")
+	assert.NoError(t, err)
+	assert.Equal(t, style, styleID)
+	width, err = f.GetColWidth("Sheet2", "F")
+	assert.NoError(t, err)
+	assert.Equal(t, defaultColWidth, width)
+
+	// Test copy column without definition overwrite the destination column
+	assert.NoError(t, f.CopyCol("Sheet1", "A", "Sheet2", "D", CopyColOptions{Styles: &disable}))
+	width, err = f.GetColWidth("Sheet2", "D")
+	assert.NoError(t, err)
+	assert.Equal(t, defaultColWidth, width)
+	val, err = f.GetCellValue("Sheet2", "D1")
+	assert.NoError(t, err)
+	assert.Equal(t, "A1", val)
+	for _, cell := range []string{"D2", "D4", "D5", "D11"} {
+		val, err = f.GetCellValue("Sheet2", cell)
+		assert.NoError(t, err)
+		assert.Empty(t, val, cell)
+		formula, err := f.GetCellFormula("Sheet2", cell)
+		assert.NoError(t, err)
+		assert.Empty(t, formula, cell)
+	}
+	styleID, err = f.GetCellStyle("Sheet2", "D2")
+	assert.NoError(t, err)
+	assert.Equal(t, style, styleID)
+	// Test copy column overwrite the styles of the destination column
+	assert.NoError(t, f.CopyCol("Sheet1", "A", "Sheet2", "D", CopyColOptions{Values: &disable}))
+	styleID, err = f.GetCellStyle("Sheet2", "D2")
+	assert.NoError(t, err)
+	assert.Zero(t, styleID)
+	val, err = f.GetCellValue("Sheet2", "D1")
+	assert.NoError(t, err)
+	assert.Equal(t, "A1", val)
+
+	// Test copy column by inserting a new column in the same worksheet
+	assert.NoError(t, f.CopyCol("Sheet1", "B", "Sheet1", "A", CopyColOptions{Insert: true}))
+	for _, cell := range []string{"A1", "C1"} {
+		val, err := f.GetCellValue("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, "Header", val, cell)
+	}
+	formula, err := f.GetCellFormula("Sheet1", "A4")
+	assert.NoError(t, err)
+	assert.Equal(t, "SUM(A2:A3)", formula)
+	width, err = f.GetColWidth("Sheet1", "A")
+	assert.NoError(t, err)
+	assert.Equal(t, 25.0, width)
+	// Test copy column to itself
+	assert.NoError(t, f.CopyCol("Sheet1", "A", "Sheet1", "A"))
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestCopyCol.xlsx")))
+
+	// Test copy column with invalid column names
+	assert.EqualError(t, f.CopyCol("Sheet1", "*", "Sheet2", "A"), newInvalidColumnNameError("*").Error())
+	assert.EqualError(t, f.CopyCol("Sheet1", "A", "Sheet2", "*"), newInvalidColumnNameError("*").Error())
+	// Test copy column on not exists worksheet
+	assert.Equal(t, ErrSheetNotExist{"SheetN"}, f.CopyCol("SheetN", "A", "Sheet2", "A"))
+	assert.Equal(t, ErrSheetNotExist{"SheetN"}, f.CopyCol("Sheet1", "A", "SheetN", "A"))
+	// Test copy column with invalid merged cell reference
+	ws, err = f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	ws.MergeCells.Cells[0] = &xlsxMergeCell{Ref: "A:B"}
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), f.CopyCol("Sheet1", "A", "Sheet2", "A"))
+	assert.NoError(t, f.Close())
+}
+
+func TestRelocateFormulaOperand(t *testing.T) {
+	colMap := func(col int) int {
+		if col >= 2 && col <= 3 {
+			return col + 3
+		}
+		return col
+	}
+	for operand, expected := range map[string]string{
+		"B1":              "E1",
+		"$B$1:C2":         "$E$1:F2",
+		"B:C":             "E:F",
+		"A1:B1":           "A1:E1",
+		"C1:E1":           "E1:F1",
+		"1:2":             "1:2",
+		"B1:C1:D1":        "B1:C1:D1",
+		"BC":              "BC",
+		"'Sheet1'!B1":     "'Sheet1'!E1",
+		"Sheet2!B1":       "Sheet2!B1",
+		"Sheet1!XFE1":     "Sheet1!XFE1",
+		"Sheet1!$A$1:$B2": "Sheet1!$A$1:$E2",
+	} {
+		assert.Equal(t, expected, relocateFormulaOperand("Sheet1", "Sheet1", operand, colMap), operand)
+	}
+}
+
 func TestConvertColWidthToPixels(t *testing.T) {
 	assert.Equal(t, -11.0, convertColWidthToPixels(-1))
+	assert.Equal(t, 800100, ColWidthToEMU(defaultColWidth))
+	assert.Zero(t, ColWidthToEMU(0))
+	assert.Equal(t, 190500, PixelsToEMU(20))
+	assert.Equal(t, 20, EMUToPixels(190500))
+	assert.Equal(t, 21, EMUToPixels(200000))
+	assert.Equal(t, 84, EMUToPixels(ColWidthToEMU(defaultColWidth)))
+}
+
+func TestPositionObjectPixels(t *testing.T) {
+	f := NewFile()
+	// Test anchor the object across the hidden column which is zero-width, the
+	// expected coordinates are derived from the default column width and row
+	// height by treating the hidden column as zero-width like Excel does
+	assert.NoError(t, f.SetColWidth("Sheet1", "B", "B", 20))
+	assert.NoError(t, f.SetColVisible("Sheet1", "B", false))
+	assert.Zero(t, f.getColWidth("Sheet1", 2, defaultMaxDigitWidth))
+	// Test get the width of the hidden column still returns the stored width
+	width, err := f.GetColWidth("Sheet1", "B")
+	assert.NoError(t, err)
+	assert.Equal(t, 20.0, width)
+	colIdx, rowIdx, colEnd, rowEnd, x1, y1, x2, y2, err := f.positionObjectPixels("Sheet1", 1, 1, 200, 128, &GraphicOptions{OffsetX: 10})
+	assert.NoError(t, err)
+	assert.Equal(t, []int{0, 0, 3, 6, 10, 0, 42, 8}, []int{colIdx, rowIdx, colEnd, rowEnd, x1, y1, x2, y2})
+	// Test anchor the object from the hidden column
+	_, _, colEnd, _, x1, _, x2, _, err = f.positionObjectPixels("Sheet1", 2, 1, 100, 20, &GraphicOptions{OffsetX: 10})
+	assert.NoError(t, err)
+	assert.Equal(t, []int{3, 0, 16}, []int{colEnd, x1, x2})
+	// Test anchor the object when all the following columns are hidden
+	assert.NoError(t, f.SetColVisible("Sheet1", "C:XFD", false))
+	assert.Equal(t, ErrGraphicOptions{Field: "OffsetX", Value: 0, Reason: "the object exceeds the last column of the worksheet"},
+		f.AddPicture("Sheet1", "A1", filepath.Join("test", "images", "excel.png"), nil))
+	// Test position the object with unsupported charset style sheet
+	f.Styles = nil
+	f.Pkg.Store(defaultXMLPathStyles, MacintoshCyrillicCharset)
+	_, _, _, _, _, _, _, _, err = f.positionObjectPixels("Sheet1", 1, 1, 200, 128, &GraphicOptions{})
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
+
+func TestGetColsUsedRange(t *testing.T) {
+	f := NewFile()
+	extents, err := f.GetColsUsedRange("Sheet1")
+	assert.NoError(t, err)
+	assert.Empty(t, extents)
+	ref, err := f.GetUsedRange("Sheet1")
+	assert.NoError(t, err)
+	assert.Empty(t, ref)
+	assert.NoError(t, f.SetCellValue("Sheet1", "B3", 1))
+	ref, err = f.GetUsedRange("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, "B3", ref)
+	// Test get the used range of the sparse worksheet
+	assert.NoError(t, f.SetCellValue("Sheet1", "A1", "A"))
+	assert.NoError(t, f.SetCellValue("Sheet1", "B10", 2))
+	assert.NoError(t, f.SetCellValue("Sheet1", "ALZ999999", "ALZ"))
+	extents, err = f.GetColsUsedRange("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]ColExtent{
+		"A":   {FirstRow: 1, LastRow: 1, CellCount: 1},
+		"B":   {FirstRow: 3, LastRow: 10, CellCount: 2},
+		"ALZ": {FirstRow: 999999, LastRow: 999999, CellCount: 1},
+	}, extents)
+	ref, err = f.GetUsedRange("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, "A1:ALZ999999", ref)
+	assert.NoError(t, f.Close())
+
+	// Test get the used range with the cells and rows without reference
+	f = NewFile()
+	f.Sheet.Delete("xl/worksheets/sheet1.xml")
+	f.Pkg.Store("xl/worksheets/sheet1.xml", []byte(`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData><row r="2"><c r="C2"><v>1</v></c><c s="1"/></row><row><c><v>1</v></c><c r="D3"/></row></sheetData></worksheet>`))
+	extents, err = f.GetColsUsedRange("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]ColExtent{
+		"A": {FirstRow: 3, LastRow: 3, CellCount: 1},
+		"C": {FirstRow: 2, LastRow: 2, CellCount: 1},
+		"D": {FirstRow: 2, LastRow: 3, CellCount: 2},
+	}, extents)
+	ref, err = f.GetUsedRange("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, "A2:D3", ref)
+
+	// Test get the used range with invalid worksheet
+	_, err = f.GetColsUsedRange("Sheet:1")
+	assert.Equal(t, ErrSheetNameInvalid, err)
+	_, err = f.GetUsedRange("SheetN")
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+	for _, sheetData := range []string{`<row r="x"/>`, `<row><c r="A"/></row>`} {
+		f.Pkg.Store("xl/worksheets/sheet1.xml", []byte(`<worksheet><sheetData>`+sheetData+`</sheetData></worksheet>`))
+		_, err = f.GetColsUsedRange("Sheet1")
+		assert.Error(t, err)
+		_, err = f.GetUsedRange("Sheet1")
+		assert.Error(t, err)
+	}
+	assert.NoError(t, f.Close())
+}
+
+func TestAppendCols(t *testing.T) {
+	f := NewFile()
+	for r, row := range [][]interface{}{{"Name", "Qty", "Price"}, {"A", 1, 1.5}, {"B", 2, 2.5}, {"C", 3, 3.5}} {
+		assert.NoError(t, f.SetSheetRow("Sheet1", fmt.Sprintf("A%d", r+1), &row))
+	}
+	assert.NoError(t, f.AddTable("Sheet1", &Table{Range: "A1:C4", Name: "Sales"}))
+	style, err := f.NewStyle(&Style{Font: &Font{Bold: true}})
+	assert.NoError(t, err)
+	date := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	assert.NoError(t, f.AppendCols("Sheet1", [][]interface{}{
+		{1.5, 5, 10.5},
+		{true, nil, false},
+		{date},
+	}, AppendColsOptions{
+		Headers: []string{"Total", "Checked", "Date"},
+		Widths:  []float64{12},
+		Styles:  []int{0, style},
+	}))
+	rows, err := f.GetRows("Sheet1", Options{RawCellValue: true})
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{
+		{"Name", "Qty", "Price", "Total", "Checked", "Date"},
+		{"A", "1", "1.5", "1.5", "1", "45352"},
+		{"B", "2", "2.5", "5"},
+		{"C", "3", "3.5", "10.5", "0"},
+	}, rows)
+	tables, err := f.GetTables("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, tables, 1)
+	assert.Equal(t, "A1:F4", tables[0].Range)
+	content, ok := f.Pkg.Load(tables[0].tableXML)
+	assert.True(t, ok)
+	assert.Contains(t, string(content.([]byte)), `<tableColumns count="6"><tableColumn id="1" name="Name"></tableColumn><tableColumn id="2" name="Qty"></tableColumn><tableColumn id="3" name="Price"></tableColumn><tableColumn id="4" name="Total"></tableColumn><tableColumn id="5" name="Checked"></tableColumn><tableColumn id="6" name="Date"></tableColumn></tableColumns>`)
+	width, err := f.GetColWidth("Sheet1", "D")
+	assert.NoError(t, err)
+	assert.Equal(t, 12.0, width)
+	styleID, err := f.GetCellStyle("Sheet1", "E2")
+	assert.NoError(t, err)
+	assert.Equal(t, style, styleID)
+	cellType, err := f.GetCellType("Sheet1", "E2")
+	assert.NoError(t, err)
+	assert.Equal(t, CellTypeBool, cellType)
+	val, err := f.GetCellValue("Sheet1", "F2")
+	assert.NoError(t, err)
+	assert.Equal(t, "Mar-24", val)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAppendCols.xlsx")))
+
+	// Test append columns without headers to extend the auto filter
+	_, err = f.NewSheet("Sheet2")
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetSheetCol("Sheet2", "A1", &[]interface{}{"Key", "x", "y"}))
+	assert.NoError(t, f.AutoFilter("Sheet2", "A1:A3", nil))
+	assert.NoError(t, f.AppendCols("Sheet2", [][]interface{}{{"Value", 1, 2}}))
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet2.xml")
+	assert.True(t, ok)
+	assert.Equal(t, "A1:B3", ws.(*xlsxWorksheet).AutoFilter.Ref)
+	wb, err := f.workbookReader()
+	assert.NoError(t, err)
+	assert.Equal(t, "'Sheet2'!$A$1:$B$3", wb.DefinedNames.DefinedName[0].Data)
+	// Test append columns with start row on an empty worksheet
+	_, err = f.NewSheet("Sheet3")
+	assert.NoError(t, err)
+	assert.NoError(t, f.AppendCols("Sheet3", [][]interface{}{{"a", "b"}, {math.NaN()}}, AppendColsOptions{StartRow: 3}))
+	rows, err = f.GetRows("Sheet3")
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{nil, nil, {"a", "NaN"}, {"b"}}, rows)
+	assert.NoError(t, f.AppendCols("Sheet3", nil))
+	// Test append columns on the worksheet with merged cells
+	assert.NoError(t, f.MergeCell("Sheet3", "C1", "D1"))
+	assert.NoError(t, f.AppendCols("Sheet3", [][]interface{}{{"merged", 1}}, AppendColsOptions{Headers: []string{"Header"}}))
+	rows, err = f.GetRows("Sheet3")
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{{"", "", "Header"}, {"", "", "merged"}, {"a", "NaN", "1"}, {"b"}}, rows)
+
+	// Test append columns with invalid settings
+	assert.Equal(t, newInvalidRowNumberError(-1), f.AppendCols("Sheet1", [][]interface{}{{1}}, AppendColsOptions{StartRow: -1}))
+	assert.Equal(t, ErrMaxRows, f.AppendCols("Sheet1", [][]interface{}{{1}}, AppendColsOptions{StartRow: TotalRows, Headers: []string{"Header"}}))
+	assert.EqualError(t, f.AppendCols("SheetN", [][]interface{}{{1}}), "sheet SheetN does not exist")
+	assert.NoError(t, f.SetCellValue("Sheet3", "XFD1", 1))
+	assert.Equal(t, ErrColumnNumber, f.AppendCols("Sheet3", [][]interface{}{{1}}))
+	assert.Equal(t, ErrColumnWidth, f.AppendCols("Sheet1", [][]interface{}{{1}}, AppendColsOptions{Widths: []float64{MaxColumnWidth + 1}}))
+	assert.Equal(t, newInvalidStyleID(100), f.AppendCols("Sheet1", [][]interface{}{{1}}, AppendColsOptions{Styles: []int{100}}))
+	assert.Equal(t, ErrCellCharsLength, f.AppendCols("Sheet1", [][]interface{}{{strings.Repeat("c", TotalCellChars+1)}}))
+	ws, ok = f.Sheet.Load("xl/worksheets/sheet2.xml")
+	assert.True(t, ok)
+	ws.(*xlsxWorksheet).AutoFilter.Ref = "A"
+	assert.Equal(t, ErrParameterInvalid, f.AppendCols("Sheet2", [][]interface{}{{1}}))
+	assert.NoError(t, f.Close())
+
+	// Test append columns with the change journal
+	f = NewFile(Options{TrackChanges: true})
+	assert.NoError(t, f.SetCellValue("Sheet1", "A1", "a"))
+	assert.NoError(t, f.AppendCols("Sheet1", [][]interface{}{{"b"}, {2}}))
+	changes, err := f.GetChangeJournal()
+	assert.NoError(t, err)
+	assert.Len(t, changes, 3)
+	rows, err = f.GetRows("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{{"a", "b", "2"}}, rows)
+	assert.NoError(t, f.Close())
+
+	// Test append columns with unsupported charset shared string table
+	f = NewFile()
+	f.SharedStrings = nil
+	f.Pkg.Store(defaultXMLPathSharedStrings, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.AppendCols("Sheet1", [][]interface{}{{"a"}}), "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
+
+func BenchmarkAppendCols(b *testing.B) {
+	cols := make([][]interface{}, 20)
+	for c := range cols {
+		cols[c] = make([]interface{}, 1000)
+		for r := range cols[c] {
+			cols[c][r] = r
+		}
+	}
+	b.Run("AppendCols", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			f := NewFile()
+			if err := f.AppendCols("Sheet1", cols); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("SetCellValue", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			f := NewFile()
+			for c := range cols {
+				for r, value := range cols[c] {
+					cell, _ := CoordinatesToCellName(c+1, r+1)
+					if err := f.SetCellValue("Sheet1", cell, value); err != nil {
+						b.Fatal(err)
+					}
+				}
+			}
+		}
+	})
 }
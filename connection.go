@@ -0,0 +1,366 @@
+// Copyright 2016 - 2025 The excelize Authors. All rights reserved. Use of
+// this source code is governed by a BSD-style license that can be found in
+// the LICENSE file.
+//
+// Package excelize providing a set of functions that allow you to write to and
+// read from XLAM / XLSM / XLSX / XLTM / XLTX files. Supports reading and
+// writing spreadsheet documents generated by Microsoft Excel™ 2007 and later.
+// Supports complex components by high compatibility, and provided streaming
+// API for generating or reading data from a worksheet with huge amounts of
+// data. This library needs Go version 1.23 or later.
+
+package excelize
+
+import (
+	"bytes"
+	"encoding/xml"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/net/html/charset"
+)
+
+// connectionTypes defined the mapping of the external data connection type
+// index and the type name.
+var connectionTypes = map[int]string{
+	1: "ODBC",
+	2: "DAO",
+	3: "File",
+	4: "Web",
+	5: "OLEDB",
+	6: "Text",
+	7: "ADO",
+	8: "DSP",
+}
+
+// queryTablePart directly maps the location of a query table part and the
+// table which it belongs to.
+type queryTablePart struct {
+	sheet        string
+	tableRange   string
+	tableXML     string
+	tableRels    string
+	rID          string
+	queryTable   string
+	name         string
+	connectionID int
+}
+
+// GetConnections provides a function to get all external data connections in
+// the workbook, such as the OLE DB connections created by Power Query. The
+// type of each connection will be one of the following values: ODBC, DAO,
+// File, Web, OLEDB, Text, ADO and DSP. The QueryTables field lists the name
+// of query tables which refreshed by the connection. For example, get the
+// external data connections of the workbook:
+//
+//	conns, err := f.GetConnections()
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	for _, conn := range conns {
+//	    fmt.Println(conn.Name, conn.Type, conn.RefreshOnLoad)
+//	}
+func (f *File) GetConnections() ([]ConnectionInfo, error) {
+	var conns []ConnectionInfo
+	connections, _, err := f.connectionsReader()
+	if err != nil || connections == nil {
+		return conns, err
+	}
+	queryTables, err := f.getQueryTables()
+	if err != nil {
+		return conns, err
+	}
+	for _, c := range connections.Connection {
+		conn := ConnectionInfo{
+			ID:                c.ID,
+			Name:              c.Name,
+			Description:       c.Description,
+			Type:              connectionTypes[c.Type],
+			RefreshOnLoad:     c.RefreshOnLoad,
+			BackgroundRefresh: c.Background,
+			SaveData:          c.SaveData,
+			RefreshInterval:   c.Interval,
+		}
+		if c.DbPr != nil {
+			conn.Connection, conn.Command = c.DbPr.Connection, c.DbPr.Command
+		}
+		for _, qt := range queryTables {
+			if qt.connectionID == c.ID {
+				conn.QueryTables = append(conn.QueryTables, qt.name)
+			}
+		}
+		conns = append(conns, conn)
+	}
+	return conns, err
+}
+
+// DeleteConnection provides a function to delete the external data connection
+// by given connection name. The query table parts refreshed by the connection
+// and the defined names of the query tables will be removed, and the tables
+// which linked with the connection will be converted to normal tables. Only
+// the connection element will be removed from the connections part, and the
+// connections part will be removed from the workbook when no connection left.
+// For example, delete the connection named "Query - Sales":
+//
+//	err := f.DeleteConnection("Query - Sales")
+func (f *File) DeleteConnection(name string) error {
+	connections, connectionsXML, err := f.connectionsReader()
+	if err != nil {
+		return err
+	}
+	if connections == nil {
+		return newNoExistConnectionError(name)
+	}
+	ID := -1
+	for _, c := range connections.Connection {
+		if c.Name == name {
+			ID = c.ID
+			break
+		}
+	}
+	if ID == -1 {
+		return newNoExistConnectionError(name)
+	}
+	if err = f.deleteConnectionQueryTables(ID); err != nil {
+		return err
+	}
+	if len(connections.Connection) > 1 {
+		content, _ := f.Pkg.Load(connectionsXML)
+		output, err := removeConnectionElement(content.([]byte), name)
+		if err != nil {
+			return err
+		}
+		f.Pkg.Store(connectionsXML, output)
+		return err
+	}
+	f.Pkg.Delete(connectionsXML)
+	f.xmlAttr.Delete(connectionsXML)
+	if err = f.removeContentTypesPart(ContentTypeSpreadSheetMLConnections, "/"+connectionsXML); err != nil {
+		return err
+	}
+	_, err = f.deleteWorkbookRels(SourceRelationshipConnections, f.getConnectionsTarget())
+	return err
+}
+
+// removeConnectionElement provides a function to remove the connection
+// element by given connections part content and connection name, all other
+// bytes of the part will be kept.
+func removeConnectionElement(content []byte, name string) ([]byte, error) {
+	d := xml.NewDecoder(bytes.NewReader(content))
+	d.CharsetReader = charset.NewReaderLabel
+	for depth := 0; ; {
+		start := d.InputOffset()
+		token, err := d.Token()
+		if err != nil {
+			if err == io.EOF {
+				err = nil
+			}
+			return content, err
+		}
+		switch element := token.(type) {
+		case xml.StartElement:
+			if depth == 1 && element.Name.Local == "connection" {
+				var connName string
+				for _, attr := range element.Attr {
+					if attr.Name.Local == "name" && attr.Name.Space == "" {
+						connName = attr.Value
+					}
+				}
+				if err = d.Skip(); err != nil {
+					return content, err
+				}
+				if connName == name {
+					end := d.InputOffset()
+					return append(content[:start:start], content[end:]...), err
+				}
+				continue
+			}
+			depth++
+		case xml.EndElement:
+			depth--
+		}
+	}
+}
+
+// deleteQueryTableDefinedNames provides a function to remove the defined
+// names of the query table, such as "ExternalData_1" and the
+// "_xlnm._FilterDatabase" which refers to the range of the query table.
+func (f *File) deleteQueryTableDefinedNames(qt queryTablePart) error {
+	wb, err := f.workbookReader()
+	if err != nil || wb.DefinedNames == nil {
+		return err
+	}
+	ref := strings.ReplaceAll(strings.ToUpper(qt.tableRange), "$", "")
+	for i := 0; i < len(wb.DefinedNames.DefinedName); i++ {
+		dn := wb.DefinedNames.DefinedName[i]
+		if dn.LocalSheetID == nil || f.GetSheetName(*dn.LocalSheetID) != qt.sheet {
+			continue
+		}
+		_, refersTo, _ := strings.Cut(dn.Data, "!")
+		if dn.Name == qt.name || (dn.Name == builtInDefinedNames[3] &&
+			strings.ReplaceAll(strings.ToUpper(refersTo), "$", "") == ref) {
+			wb.DefinedNames.DefinedName = append(wb.DefinedNames.DefinedName[:i], wb.DefinedNames.DefinedName[i+1:]...)
+			i--
+		}
+	}
+	if len(wb.DefinedNames.DefinedName) == 0 {
+		wb.DefinedNames = nil
+	}
+	return err
+}
+
+// getConnectionsTarget provides a function to get the target of the
+// connections part in the workbook relationships.
+func (f *File) getConnectionsTarget() string {
+	if rels, _ := f.relsReader(f.getWorkbookRelsPath()); rels != nil {
+		rels.mu.Lock()
+		defer rels.mu.Unlock()
+		for _, rel := range rels.Relationships {
+			if rel.Type == SourceRelationshipConnections {
+				return rel.Target
+			}
+		}
+	}
+	return ""
+}
+
+// getConnectionsPath provides a function to get the path of the connections
+// part in the spreadsheet.
+func (f *File) getConnectionsPath() string {
+	target := f.getConnectionsTarget()
+	if target == "" {
+		return ""
+	}
+	if strings.HasPrefix(target, "/") {
+		return strings.TrimPrefix(target, "/")
+	}
+	if wbDir := filepath.Dir(f.getWorkbookPath()); wbDir != "." {
+		return strings.TrimPrefix(filepath.ToSlash(filepath.Join(wbDir, target)), "/")
+	}
+	return target
+}
+
+// connectionsReader provides a function to get the pointer to the structure
+// after deserialization of xl/connections.xml and the path of the part. The
+// returned structure will be nil if the workbook doesn't contain any
+// external data connection.
+func (f *File) connectionsReader() (*xlsxConnections, string, error) {
+	connectionsXML := f.getConnectionsPath()
+	if connectionsXML == "" {
+		connectionsXML = defaultXMLPathConnections
+	}
+	content, ok := f.Pkg.Load(connectionsXML)
+	if !ok || content == nil {
+		return nil, connectionsXML, nil
+	}
+	if _, ok = f.xmlAttr.Load(connectionsXML); !ok {
+		d := f.xmlNewDecoder(bytes.NewReader(namespaceStrictToTransitional(content.([]byte))))
+		f.xmlAttr.Store(connectionsXML, getRootElement(d))
+	}
+	connections := new(xlsxConnections)
+	if err := f.xmlNewDecoder(bytes.NewReader(namespaceStrictToTransitional(content.([]byte)))).
+		Decode(connections); err != nil && err != io.EOF {
+		return nil, connectionsXML, err
+	}
+	return connections, connectionsXML, nil
+}
+
+// getQueryTables provides a function to get all query table parts linked with
+// the tables in the workbook.
+func (f *File) getQueryTables() ([]queryTablePart, error) {
+	var queryTables []queryTablePart
+	tbls, err := f.getTables()
+	if err != nil {
+		return queryTables, err
+	}
+	for _, sheet := range f.GetSheetList() {
+		for _, table := range tbls[sheet] {
+			tableRels := strings.Replace(table.tableXML, "xl/tables/", "xl/tables/_rels/", 1) + ".rels"
+			rels, err := f.relsReader(tableRels)
+			if err != nil {
+				return queryTables, err
+			}
+			if rels == nil {
+				continue
+			}
+			for _, rel := range rels.Relationships {
+				if rel.Type != SourceRelationshipQueryTable {
+					continue
+				}
+				queryTableXML := strings.ReplaceAll(rel.Target, "..", "xl")
+				content, ok := f.Pkg.Load(queryTableXML)
+				if !ok {
+					continue
+				}
+				var qt xlsxQueryTable
+				if err = f.xmlNewDecoder(bytes.NewReader(namespaceStrictToTransitional(content.([]byte)))).
+					Decode(&qt); err != nil && err != io.EOF {
+					return queryTables, err
+				}
+				queryTables = append(queryTables, queryTablePart{
+					sheet:        sheet,
+					tableRange:   table.Range,
+					tableXML:     table.tableXML,
+					tableRels:    tableRels,
+					rID:          rel.ID,
+					queryTable:   queryTableXML,
+					name:         qt.Name,
+					connectionID: qt.ConnectionID,
+				})
+			}
+		}
+	}
+	return queryTables, nil
+}
+
+// deleteConnectionQueryTables provides a function to remove the query table
+// parts refreshed by the given connection ID, and convert the tables which
+// linked with the connection to normal tables.
+func (f *File) deleteConnectionQueryTables(ID int) error {
+	queryTables, err := f.getQueryTables()
+	if err != nil {
+		return err
+	}
+	for _, qt := range queryTables {
+		if qt.connectionID != ID {
+			continue
+		}
+		f.Pkg.Delete(qt.queryTable)
+		if err = f.deleteQueryTableDefinedNames(qt); err != nil {
+			return err
+		}
+		if err = f.removeContentTypesPart(ContentTypeSpreadSheetMLQueryTable, "/"+qt.queryTable); err != nil {
+			return err
+		}
+		if rels, _ := f.relsReader(qt.tableRels); rels != nil {
+			rels.mu.Lock()
+			for k, v := range rels.Relationships {
+				if v.ID == qt.rID {
+					rels.Relationships = append(rels.Relationships[:k], rels.Relationships[k+1:]...)
+					break
+				}
+			}
+			rels.mu.Unlock()
+		}
+		content, ok := f.Pkg.Load(qt.tableXML)
+		if !ok {
+			continue
+		}
+		var t xlsxTable
+		if err = f.xmlNewDecoder(bytes.NewReader(namespaceStrictToTransitional(content.([]byte)))).
+			Decode(&t); err != nil && err != io.EOF {
+			return err
+		}
+		t.TableType, t.ConnectionID = "", 0
+		if t.TableColumns != nil {
+			for _, column := range t.TableColumns.TableColumn {
+				column.UniqueName, column.QueryTableFieldID = "", 0
+			}
+		}
+		table, _ := xml.Marshal(t)
+		f.saveFileList(qt.tableXML, table)
+	}
+	return err
+}
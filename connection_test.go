@@ -0,0 +1,185 @@
+package excelize
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// connectionsTestExtraXML defined the web and text connections used to test
+// the connections part will be kept when deleting the other connection.
+const connectionsTestExtraXML = `<connection id="3" xr16:uid="{6C0A7D6C-5D3F-4B5A-9E39-6A0E6E2E1B10}" name="Rates" type="4" refreshedVersion="8"><webPr url="https://example.com/rates" xl2000="1"/></connection><connection id="4" xr16:uid="{0D9B7E60-91A8-4D2B-A3C6-3A2B8F8C1E22}" name="Ledger" type="6" refreshedVersion="8"><textPr codePage="65001" sourceFile="C:\data\ledger.csv" comma="1"/></connection>`
+
+// prepareConnectionsTestBook provides a function to create a workbook with a
+// Power Query connection refreshing the table "Sales", and a connection only
+// query without query table.
+func prepareConnectionsTestBook(t *testing.T) *File {
+	f := NewFile()
+	for cell, value := range map[string]interface{}{
+		"A1": "Region", "B1": "Sales", "A2": "East", "B2": 10, "A3": "West", "B3": 20,
+	} {
+		assert.NoError(t, f.SetCellValue("Sheet1", cell, value))
+	}
+	assert.NoError(t, f.AddTable("Sheet1", &Table{Name: "Sales", Range: "A1:B3"}))
+	content, ok := f.Pkg.Load("xl/tables/table1.xml")
+	assert.True(t, ok)
+	f.Pkg.Store("xl/tables/table1.xml", []byte(strings.NewReplacer(
+		`ref="A1:B3"`, `ref="A1:B3" tableType="queryTable"`,
+		`name="Region"`, `uniqueName="1" name="Region" queryTableFieldId="1"`,
+		`name="Sales"></tableColumn>`, `uniqueName="2" name="Sales" queryTableFieldId="2"></tableColumn>`,
+	).Replace(string(content.([]byte)))))
+	f.Pkg.Store("xl/queryTables/queryTable1.xml", []byte(`<queryTable xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" name="ExternalData_1" connectionId="1" autoFormatId="16" applyNumberFormats="0" applyBorderFormats="0" applyFontFormats="0" applyPatternFormats="0" applyAlignmentFormats="0" applyWidthHeightFormats="0"><queryTableRefresh nextId="3"><queryTableFields count="2"><queryTableField id="1" name="Region" tableColumnId="1"/><queryTableField id="2" name="Sales" tableColumnId="2"/></queryTableFields></queryTableRefresh></queryTable>`))
+	f.addRels("xl/tables/_rels/table1.xml.rels", SourceRelationshipQueryTable, "../queryTables/queryTable1.xml", "")
+	f.Pkg.Store(defaultXMLPathConnections, []byte(`<connections xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:mc="http://schemas.openxmlformats.org/markup-compatibility/2006" mc:Ignorable="xr16" xmlns:xr16="http://schemas.microsoft.com/office/spreadsheetml/2017/revision16"><connection id="1" keepAlive="1" name="Query - Sales" description="Connection to the 'Sales' query in the workbook." type="5" refreshedVersion="8" background="1" refreshOnLoad="1" saveData="1"><dbPr connection="Provider=Microsoft.Mashup.OleDb.1;Data Source=$Workbook$;Location=Sales;Extended Properties=&quot;&quot;" command="SELECT * FROM [Sales]"/></connection><connection id="2" keepAlive="1" name="Query - Regions" type="5" refreshedVersion="8" background="1" interval="30"><dbPr connection="Provider=Microsoft.Mashup.OleDb.1;Data Source=$Workbook$;Location=Regions;Extended Properties=&quot;&quot;" command="SELECT * FROM [Regions]"/><extLst><ext uri="{DE250136-89BD-433C-8126-D09CA5730AF9}" xmlns:x15="http://schemas.microsoft.com/office/spreadsheetml/2010/11/main"><x15:connection id="" model="1"/></ext></extLst></connection></connections>`))
+	assert.NoError(t, f.SetDefinedName(&DefinedName{Name: "ExternalData_1", RefersTo: "Sheet1!$A$1:$B$3", Scope: "Sheet1"}))
+	assert.NoError(t, f.SetDefinedName(&DefinedName{Name: "_xlnm._FilterDatabase", RefersTo: "Sheet1!$A$1:$B$3", Scope: "Sheet1"}))
+	f.Pkg.Store("customXml/item1.xml", []byte(`<?xml version="1.0" encoding="utf-16"?><DataMashup xmlns="http://schemas.microsoft.com/DataMashup">AAAAAA==</DataMashup>`))
+	f.addRels(f.getWorkbookRelsPath(), SourceRelationshipConnections, "connections.xml", "")
+	assert.NoError(t, f.setContentTypes("/xl/queryTables/queryTable1.xml", ContentTypeSpreadSheetMLQueryTable))
+	assert.NoError(t, f.setContentTypes("/"+defaultXMLPathConnections, ContentTypeSpreadSheetMLConnections))
+	return f
+}
+
+func TestConnections(t *testing.T) {
+	f := prepareConnectionsTestBook(t)
+	// Test edit cells and insert rows in the query table, the connection and
+	// query table should be preserved
+	assert.NoError(t, f.SetCellValue("Sheet1", "B2", 15))
+	assert.NoError(t, f.InsertRows("Sheet1", 3, 1))
+	path := filepath.Join("test", "TestConnections.xlsx")
+	assert.NoError(t, f.SaveAs(path))
+	assert.NoError(t, f.Close())
+
+	f, err := OpenFile(path)
+	assert.NoError(t, err)
+	for _, part := range []string{defaultXMLPathConnections, "xl/queryTables/queryTable1.xml", "customXml/item1.xml"} {
+		_, ok := f.Pkg.Load(part)
+		assert.True(t, ok, part)
+	}
+	content, ok := f.Pkg.Load("xl/tables/table1.xml")
+	assert.True(t, ok)
+	assert.Contains(t, string(content.([]byte)), `ref="A1:B4" tableType="queryTable"`)
+	assert.Contains(t, string(content.([]byte)), `queryTableFieldId="2"`)
+	content, ok = f.Pkg.Load("xl/tables/_rels/table1.xml.rels")
+	assert.True(t, ok)
+	assert.Contains(t, string(content.([]byte)), "../queryTables/queryTable1.xml")
+	conns, err := f.GetConnections()
+	assert.NoError(t, err)
+	assert.Equal(t, []ConnectionInfo{
+		{
+			ID:                1,
+			Name:              "Query - Sales",
+			Description:       "Connection to the 'Sales' query in the workbook.",
+			Type:              "OLEDB",
+			Connection:        `Provider=Microsoft.Mashup.OleDb.1;Data Source=$Workbook$;Location=Sales;Extended Properties=""`,
+			Command:           "SELECT * FROM [Sales]",
+			RefreshOnLoad:     true,
+			BackgroundRefresh: true,
+			SaveData:          true,
+			QueryTables:       []string{"ExternalData_1"},
+		},
+		{
+			ID:                2,
+			Name:              "Query - Regions",
+			Type:              "OLEDB",
+			Connection:        `Provider=Microsoft.Mashup.OleDb.1;Data Source=$Workbook$;Location=Regions;Extended Properties=""`,
+			Command:           "SELECT * FROM [Regions]",
+			BackgroundRefresh: true,
+			RefreshInterval:   30,
+		},
+	}, conns)
+
+	// Test delete the connection with query table
+	original, ok := f.Pkg.Load(defaultXMLPathConnections)
+	assert.True(t, ok)
+	original = []byte(strings.Replace(string(original.([]byte)), "</connections>", connectionsTestExtraXML+"</connections>", 1))
+	f.Pkg.Store(defaultXMLPathConnections, original)
+	assert.NoError(t, f.DeleteConnection("Query - Sales"))
+	_, ok = f.Pkg.Load("xl/queryTables/queryTable1.xml")
+	assert.False(t, ok)
+	content, ok = f.Pkg.Load("xl/tables/table1.xml")
+	assert.True(t, ok)
+	assert.NotContains(t, string(content.([]byte)), "queryTable")
+	content, ok = f.Pkg.Load(defaultXMLPathConnections)
+	assert.True(t, ok)
+	assert.NotContains(t, string(content.([]byte)), "Query - Sales")
+	start := strings.Index(string(original.([]byte)), `<connection id="1"`)
+	end := strings.Index(string(original.([]byte)), `<connection id="2"`)
+	assert.Equal(t, string(original.([]byte)[:start])+string(original.([]byte)[end:]), string(content.([]byte)))
+	assert.Empty(t, f.GetDefinedName())
+	tables, err := f.GetTables("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, tables, 1)
+	conns, err = f.GetConnections()
+	assert.NoError(t, err)
+	assert.Len(t, conns, 3)
+	assert.Equal(t, "Query - Regions", conns[0].Name)
+
+	// Test delete the connections without query table
+	for _, name := range []string{"Rates", "Ledger"} {
+		assert.NoError(t, f.DeleteConnection(name))
+	}
+	content, ok = f.Pkg.Load(defaultXMLPathConnections)
+	assert.True(t, ok)
+	assert.Equal(t, strings.Replace(string(original.([]byte)[:start])+string(original.([]byte)[end:]), connectionsTestExtraXML, "", 1), string(content.([]byte)))
+
+	// Test delete the last connection
+	assert.NoError(t, f.DeleteConnection("Query - Regions"))
+	_, ok = f.Pkg.Load(defaultXMLPathConnections)
+	assert.False(t, ok)
+	assert.Empty(t, f.getConnectionsTarget())
+	conns, err = f.GetConnections()
+	assert.NoError(t, err)
+	assert.Empty(t, conns)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestDeleteConnection.xlsx")))
+	contentTypes, err := f.contentTypesReader()
+	assert.NoError(t, err)
+	for _, override := range contentTypes.Overrides {
+		assert.NotEqual(t, ContentTypeSpreadSheetMLConnections, override.ContentType)
+		assert.NotEqual(t, ContentTypeSpreadSheetMLQueryTable, override.ContentType)
+	}
+	// Test delete connection with not exists connection name
+	assert.EqualError(t, f.DeleteConnection("Query - Sales"), "connection Query - Sales does not exist")
+	assert.NoError(t, f.Close())
+
+	f = prepareConnectionsTestBook(t)
+	assert.EqualError(t, f.DeleteConnection("Query"), "connection Query does not exist")
+	// Test get and delete connections with unsupported charset connections
+	f.Pkg.Store(defaultXMLPathConnections, MacintoshCyrillicCharset)
+	_, err = f.GetConnections()
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	assert.EqualError(t, f.DeleteConnection("Query - Sales"), "XML syntax error on line 1: invalid UTF-8")
+
+	// Test get and delete connections with unsupported charset query table
+	f = prepareConnectionsTestBook(t)
+	f.Pkg.Store("xl/queryTables/queryTable1.xml", MacintoshCyrillicCharset)
+	_, err = f.GetConnections()
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	assert.EqualError(t, f.DeleteConnection("Query - Sales"), "XML syntax error on line 1: invalid UTF-8")
+
+	// Test get connections with unsupported charset table relationships
+	f = prepareConnectionsTestBook(t)
+	f.Relationships.Delete("xl/tables/_rels/table1.xml.rels")
+	f.Pkg.Store("xl/tables/_rels/table1.xml.rels", MacintoshCyrillicCharset)
+	_, err = f.GetConnections()
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+
+	// Test remove connection element with invalid connections part
+	_, err = removeConnectionElement([]byte(`<connections><connection name="Query">`), "Query")
+	assert.EqualError(t, err, "XML syntax error on line 1: unexpected EOF")
+	_, err = removeConnectionElement([]byte(`<connections></connection>`), "Query")
+	assert.EqualError(t, err, "XML syntax error on line 1: element <connections> closed by </connection>")
+
+	// Test delete connection with unsupported charset content types
+	for _, name := range []string{"Query - Sales", "Query - Regions"} {
+		f = prepareConnectionsTestBook(t)
+		if name == "Query - Regions" {
+			assert.NoError(t, f.DeleteConnection("Query - Sales"))
+		}
+		f.ContentTypes = nil
+		f.Pkg.Store(defaultXMLPathContentTypes, MacintoshCyrillicCharset)
+		assert.EqualError(t, f.DeleteConnection(name), "XML syntax error on line 1: invalid UTF-8")
+	}
+}
@@ -0,0 +1,261 @@
+// Copyright 2016 - 2025 The excelize Authors. All rights reserved. Use of
+// this source code is governed by a BSD-style license that can be found in
+// the LICENSE file.
+//
+// Package excelize providing a set of functions that allow you to write to and
+// read from XLAM / XLSM / XLSX / XLTM / XLTX files. Supports reading and
+// writing spreadsheet documents generated by Microsoft Excel™ 2007 and later.
+// Supports complex components by high compatibility, and provided streaming
+// API for generating or reading data from a worksheet with huge amounts of
+// data. This library needs Go version 1.23 or later.
+
+package excelize
+
+import (
+	"encoding/csv"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// csvNumberExp matches the decimal numbers which can be inferred as the
+// numeric cell value when importing the CSV, the numbers with leading zeros
+// are excluded to keep the codes like "007" as text.
+var csvNumberExp = regexp.MustCompile(`^[-+]?((0|[1-9]\d*)(\.\d+)?|\.\d+)([eE][-+]?\d+)?$`)
+
+// CSVOptions directly maps the settings of exporting a worksheet as CSV by the
+// WriteSheetCSV function and importing a worksheet from CSV by the
+// AddSheetFromCSV function.
+//
+// Delimiter specifies the field delimiter, the default value is comma.
+//
+// RawCellValue specifies if write the raw value of the cells without applying
+// the number format when exporting the CSV.
+//
+// TrimTrailingEmptyCells specifies if omit the trailing empty cells of each
+// row when exporting the CSV, the rows are padded to the same number of
+// fields by default.
+//
+// InferTypes specifies if convert the numbers, booleans and ISO 8601 dates
+// into the numeric, boolean and date cell values when importing the CSV,
+// all fields will be written as the string cell values by default.
+type CSVOptions struct {
+	Delimiter              rune
+	RawCellValue           bool
+	TrimTrailingEmptyCells bool
+	InferTypes             bool
+}
+
+// getCSVOptions returns the CSV options with the default values by given
+// optional CSV options.
+func getCSVOptions(opts ...CSVOptions) CSVOptions {
+	var options CSVOptions
+	for _, opt := range opts {
+		options = opt
+	}
+	if options.Delimiter == 0 {
+		options.Delimiter = ','
+	}
+	return options
+}
+
+// WriteSheetCSV provides a function to export the cells value of the
+// worksheet as CSV into the given writer by given worksheet name and optional
+// CSV options. The rows are read by the rows iterator one by one, so the
+// memory usage doesn't grow with the number of rows. The empty rows between
+// the rows with data will be written as the empty records, and all records
+// will be padded with the empty fields to the same number of fields unless
+// the TrimTrailingEmptyCells option is enabled, in which case the records end
+// at the last cell with data. For example,
+// export the formatted values of Sheet1 with semicolon delimiter:
+//
+//	file, err := os.Create("Sheet1.csv")
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	defer file.Close()
+//	err = f.WriteSheetCSV("Sheet1", file, excelize.CSVOptions{
+//	    Delimiter:              ';',
+//	    TrimTrailingEmptyCells: true,
+//	})
+func (f *File) WriteSheetCSV(sheet string, w io.Writer, opts ...CSVOptions) error {
+	if w == nil {
+		return ErrParameterInvalid
+	}
+	options := getCSVOptions(opts...)
+	rows, err := f.Rows(sheet)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+	var width int
+	if !options.TrimTrailingEmptyCells {
+		if width, err = f.getCSVWidth(sheet, options.RawCellValue); err != nil {
+			return err
+		}
+	}
+	writer := csv.NewWriter(w)
+	writer.Comma = options.Delimiter
+	for rows.Next() {
+		record, err := rows.Columns(Options{RawCellValue: options.RawCellValue})
+		if err != nil {
+			return err
+		}
+		if len(record) < width {
+			record = append(record, make([]string, width-len(record))...)
+		}
+		if err = writer.Write(record); err != nil {
+			return err
+		}
+	}
+	if err = rows.Error(); err != nil {
+		return err
+	}
+	if writer.Flush(); writer.Error() != nil {
+		return writer.Error()
+	}
+	return rows.Close()
+}
+
+// getCSVWidth provides a function to get the maximum number of columns of the
+// rows with data in the worksheet by reading the rows one by one, the records
+// exported by the WriteSheetCSV function will be padded to this width.
+func (f *File) getCSVWidth(sheet string, raw bool) (int, error) {
+	rows, err := f.Rows(sheet)
+	if err != nil {
+		return 0, err
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+	var width int
+	for rows.Next() {
+		record, err := rows.Columns(Options{RawCellValue: raw})
+		if err != nil {
+			return width, err
+		}
+		width = max(width, len(record))
+	}
+	if err = rows.Error(); err != nil {
+		return width, err
+	}
+	return width, rows.Close()
+}
+
+// AddSheetFromCSV provides a function to create a worksheet or overwrite the
+// cells of an existing worksheet with the records read from the given CSV
+// reader by given worksheet name and optional CSV options. The records are
+// written by the stream writer, so the cells of the existing worksheet will
+// be replaced and the records can be different lengths. The empty fields will
+// be skipped, the empty lines will be kept as the empty rows, and the other
+// fields will be written as the string cell values unless the InferTypes
+// option is enabled. With the InferTypes option, the decimal numbers without
+// leading zeros and with at most 15 significant digits, the case-insensitive
+// TRUE and FALSE, and the ISO 8601 dates in the YYYY-MM-DD,
+// YYYY-MM-DDThh:mm:ss and YYYY-MM-DD hh:mm:ss forms will be written as the
+// numeric, boolean and date cell values. For example, import the data.csv
+// into the worksheet named Data:
+//
+//	file, err := os.Open("data.csv")
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	defer file.Close()
+//	err = f.AddSheetFromCSV("Data", file, excelize.CSVOptions{InferTypes: true})
+func (f *File) AddSheetFromCSV(sheet string, r io.Reader, opts ...CSVOptions) error {
+	if r == nil {
+		return ErrParameterInvalid
+	}
+	if err := checkSheetName(sheet); err != nil {
+		return err
+	}
+	options := getCSVOptions(opts...)
+	if f.getSheetID(sheet) == -1 {
+		if _, err := f.NewSheet(sheet); err != nil {
+			return err
+		}
+	}
+	sw, err := f.NewStreamWriter(sheet)
+	if err != nil {
+		return err
+	}
+	reader := csv.NewReader(r)
+	reader.Comma, reader.FieldsPerRecord = options.Delimiter, -1
+	var dateStyle, rowNum, lastLine int
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		// The empty lines are skipped by the CSV reader, count them by the
+		// line number to keep the empty rows
+		line, _ := reader.FieldPos(0)
+		rowNum += max(line-lastLine, 1)
+		lastLine, _ = reader.FieldPos(len(record) - 1)
+		lastLine += strings.Count(record[len(record)-1], "\n")
+		values := make([]interface{}, len(record))
+		for i, field := range record {
+			if field == "" {
+				continue
+			}
+			if values[i] = field; !options.InferTypes {
+				continue
+			}
+			val, isDate := inferCSVValue(field)
+			if isDate {
+				if dateStyle == 0 {
+					if dateStyle, err = f.NewStyle(&Style{NumFmt: 14}); err != nil {
+						return err
+					}
+				}
+				val = Cell{StyleID: dateStyle, Value: val}
+			}
+			values[i] = val
+		}
+		cell, _ := CoordinatesToCellName(1, rowNum)
+		if err = sw.SetRow(cell, values); err != nil {
+			return err
+		}
+	}
+	return sw.Flush()
+}
+
+// inferCSVValue provides a function to convert the CSV field into the
+// numeric, boolean or time value, the field will be returned as is if it
+// can't be converted. The second return value is true if the field is a date
+// without the time part.
+func inferCSVValue(field string) (interface{}, bool) {
+	if strings.EqualFold(field, "TRUE") || strings.EqualFold(field, "FALSE") {
+		return strings.EqualFold(field, "TRUE"), false
+	}
+	if csvNumberExp.MatchString(field) {
+		mantissa := field
+		if idx := strings.IndexAny(field, "eE"); idx != -1 {
+			mantissa = field[:idx]
+		}
+		if len(strings.ReplaceAll(strings.TrimLeft(mantissa, "+-0."), ".", "")) <= 15 {
+			if num, err := strconv.ParseFloat(field, 64); err == nil {
+				return num, false
+			}
+		}
+		return field, false
+	}
+	if t, err := time.Parse(time.DateOnly, field); err == nil {
+		return t, true
+	}
+	for _, layout := range []string{"2006-01-02T15:04:05", time.DateTime} {
+		if t, err := time.Parse(layout, field); err == nil {
+			return t, false
+		}
+	}
+	return field, false
+}
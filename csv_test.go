@@ -0,0 +1,106 @@
+package excelize
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWriteSheetCSV(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A1", &[]interface{}{"Name", "Note", "Amount"}))
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A2", &[]interface{}{"a,b", "line1\nline2", 1.5}))
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A4", &[]interface{}{`say "hi"`, nil, 0.25, "x"}))
+	style, err := f.NewStyle(&Style{NumFmt: 10})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellStyle("Sheet1", "C4", "C4", style))
+	assert.NoError(t, f.SetCellStyle("Sheet1", "E4", "E4", style))
+	assert.NoError(t, f.SetCellValue("Sheet1", "D6", ""))
+
+	var buf bytes.Buffer
+	assert.NoError(t, f.WriteSheetCSV("Sheet1", &buf))
+	assert.Equal(t, "Name,Note,Amount,\n\"a,b\",\"line1\nline2\",1.5,\n,,,\n\"say \"\"hi\"\"\",,25.00%,x\n,,,\n,,,\n", buf.String())
+	// Test export the raw value with delimiter and trim the trailing empty cells
+	buf.Reset()
+	assert.NoError(t, f.WriteSheetCSV("Sheet1", &buf, CSVOptions{Delimiter: ';', RawCellValue: true, TrimTrailingEmptyCells: true}))
+	assert.Equal(t, "Name;Note;Amount\na,b;\"line1\nline2\";1.5\n\n\"say \"\"hi\"\"\";;0.25;x\n\n\n", buf.String())
+	// Test export the CSV with invalid settings
+	assert.Equal(t, ErrParameterInvalid, f.WriteSheetCSV("Sheet1", nil))
+	assert.EqualError(t, f.WriteSheetCSV("SheetN", &buf), "sheet SheetN does not exist")
+	assert.EqualError(t, f.WriteSheetCSV("Sheet1", &buf, CSVOptions{Delimiter: '"'}), "csv: invalid field or comment delimiter")
+	assert.NoError(t, f.Close())
+}
+
+func TestAddSheetFromCSV(t *testing.T) {
+	f := NewFile()
+	input := "Name,Note,Amount,Active,Date\n\"a,b\",\"line1\nline2\",1.5,TRUE,2024-03-01\n\n007,\"say \"\"hi\"\"\",-2e3,false,2024-03-01T08:30:00\n1234567890123456,,.5,yes,2024-13-01\n"
+	assert.NoError(t, f.AddSheetFromCSV("Data", strings.NewReader(input), CSVOptions{InferTypes: true}))
+	rows, err := f.GetRows("Data", Options{RawCellValue: true})
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{
+		{"Name", "Note", "Amount", "Active", "Date"},
+		{"a,b", "line1\nline2", "1.5", "1", "45352"},
+		nil,
+		{"007", `say "hi"`, "-2000", "0", "45352.354166666664"},
+		{"1234567890123456", "", "0.5", "yes", "2024-13-01"},
+	}, rows)
+	for cell, expected := range map[string]CellType{"C2": CellTypeUnset, "D2": CellTypeBool, "A4": CellTypeInlineString, "A5": CellTypeInlineString} {
+		typ, err := f.GetCellType("Data", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, typ, cell)
+	}
+	val, err := f.GetCellValue("Data", "E2")
+	assert.NoError(t, err)
+	assert.Equal(t, "03-01-24", val)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAddSheetFromCSV.xlsx")))
+
+	// Test the CSV round-trip with quoted fields and empty rows
+	var buf bytes.Buffer
+	input = "a;\"b;c\";\"multi\nline\"\n\n\"\"\"quoted\"\"\";2024-03-01\n"
+	assert.NoError(t, f.AddSheetFromCSV("Sheet1", strings.NewReader(input), CSVOptions{Delimiter: ';'}))
+	assert.NoError(t, f.WriteSheetCSV("Sheet1", &buf, CSVOptions{Delimiter: ';', TrimTrailingEmptyCells: true}))
+	assert.Equal(t, input, buf.String())
+	// Test overwrite the existing worksheet
+	assert.NoError(t, f.AddSheetFromCSV("Sheet1", strings.NewReader("x\n")))
+	rows, err = f.GetRows("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{{"x"}}, rows)
+	assert.Equal(t, []string{"Sheet1", "Data"}, f.GetSheetList())
+
+	// Test import the CSV with invalid settings
+	assert.Equal(t, ErrParameterInvalid, f.AddSheetFromCSV("Sheet1", nil))
+	assert.Equal(t, ErrSheetNameInvalid, f.AddSheetFromCSV("Sheet:1", strings.NewReader("")))
+	assert.EqualError(t, f.AddSheetFromCSV("Sheet1", strings.NewReader("a\"b\n")), `parse error on line 1, column 2: bare " in non-quoted-field`)
+	assert.EqualError(t, f.AddSheetFromCSV("Sheet1", strings.NewReader(strings.Repeat("a,", MaxColumns)+"a")), ErrColumnNumber.Error())
+	assert.NoError(t, f.Close())
+}
+
+func TestInferCSVValue(t *testing.T) {
+	for _, c := range []struct {
+		field    string
+		expected interface{}
+		isDate   bool
+	}{
+		{"True", true, false},
+		{"0", float64(0), false},
+		{"+12.50", 12.5, false},
+		{"1E-3", 0.001, false},
+		{"0.000000000000000001", 1e-18, false},
+		{"00", "00", false},
+		{"1.", "1.", false},
+		{"1e", "1e", false},
+		{"NaN", "NaN", false},
+		{"0x10", "0x10", false},
+		{"1234567890.1234567", "1234567890.1234567", false},
+		{"2024-03-01", time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), true},
+		{"2024-03-01 08:30:00", time.Date(2024, 3, 1, 8, 30, 0, 0, time.UTC), false},
+	} {
+		val, isDate := inferCSVValue(c.field)
+		assert.Equal(t, c.expected, val, c.field)
+		assert.Equal(t, c.isDate, isDate, c.field)
+	}
+}
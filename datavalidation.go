@@ -260,6 +260,13 @@ func (f *File) AddDataValidation(sheet string, dv *DataValidation) error {
 	}
 	ws.mu.Lock()
 	defer ws.mu.Unlock()
+	ws.addDataValidation(dv)
+	return err
+}
+
+// addDataValidation provides a function to append the data validation to the
+// worksheet by given data validation object.
+func (ws *xlsxWorksheet) addDataValidation(dv *DataValidation) {
 	if nil == ws.DataValidations {
 		ws.DataValidations = new(xlsxDataValidations)
 	}
@@ -285,7 +292,6 @@ func (f *File) AddDataValidation(sheet string, dv *DataValidation) error {
 	}
 	ws.DataValidations.DataValidation = append(ws.DataValidations.DataValidation, dataValidation)
 	ws.DataValidations.Count = len(ws.DataValidations.DataValidation)
-	return err
 }
 
 // GetDataValidations returns data validations list by given worksheet name.
@@ -413,6 +419,118 @@ func (f *File) DeleteDataValidation(sheet string, sqref ...string) error {
 	return nil
 }
 
+// AddColValidation provides a function to set data validation on the whole
+// columns by given worksheet name, column name or columns range, data
+// validation object and optional settings. The number of the header rows at
+// the top of the columns can be skipped by the HeaderRows option. The
+// reference sequence of the data validation object will be ignored, and the
+// data validation will be adjusted or deleted when inserting or deleting
+// columns. An error will be returned if the data validation overlaps with an
+// existing data validation on the worksheet. This function is concurrency
+// safe. For example, create in-cell dropdown on column D except for the first
+// row on Sheet1:
+//
+//	dv := excelize.NewDataValidation(true)
+//	if err := dv.SetDropList([]string{"Open", "Closed"}); err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	err := f.AddColValidation("Sheet1", "D", dv, excelize.ColValidationOptions{HeaderRows: 1})
+func (f *File) AddColValidation(sheet, col string, dv *DataValidation, opts ...ColValidationOptions) error {
+	if dv == nil {
+		return ErrParameterRequired
+	}
+	minVal, maxVal, err := f.parseColRange(col)
+	if err != nil {
+		return err
+	}
+	var options ColValidationOptions
+	if len(opts) > 0 {
+		options = opts[len(opts)-1]
+	}
+	if options.HeaderRows < 0 || options.HeaderRows >= TotalRows {
+		return ErrParameterInvalid
+	}
+	rect := []int{minVal, options.HeaderRows + 1, maxVal, TotalRows}
+	sqref, _ := coordinatesToRangeRef(rect)
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return err
+	}
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	if ws.DataValidations != nil {
+		for _, v := range ws.DataValidations.DataValidation {
+			if v == nil {
+				continue
+			}
+			refs, err := sqrefToCoordinates(v.Sqref)
+			if err != nil {
+				return err
+			}
+			for _, coordinates := range refs {
+				if isOverlap(rect, coordinates) {
+					return newDataValidationOverlapError(sqref, v.Sqref)
+				}
+			}
+		}
+	}
+	colValidation := *dv
+	colValidation.Sqref = sqref
+	ws.addDataValidation(&colValidation)
+	return err
+}
+
+// GetColValidations returns the data validations on the whole column by given
+// worksheet name and column name, which are applied from any row to the last
+// row of the worksheet, such as the data validations created by the
+// AddColValidation function. For example, get the data validations on column
+// D of Sheet1:
+//
+//	dvs, err := f.GetColValidations("Sheet1", "D")
+func (f *File) GetColValidations(sheet, col string) ([]*DataValidation, error) {
+	colNum, err := ColumnNameToNumber(col)
+	if err != nil {
+		return nil, err
+	}
+	dataValidations, err := f.GetDataValidations(sheet)
+	if err != nil {
+		return nil, err
+	}
+	var colValidations []*DataValidation
+	for _, dv := range dataValidations {
+		refs, err := sqrefToCoordinates(dv.Sqref)
+		if err != nil {
+			return colValidations, err
+		}
+		for _, coordinates := range refs {
+			if coordinates[0] <= colNum && colNum <= coordinates[2] && coordinates[3] == TotalRows {
+				colValidations = append(colValidations, dv)
+				break
+			}
+		}
+	}
+	return colValidations, err
+}
+
+// sqrefToCoordinates provides a function to convert the reference sequence
+// to the sorted coordinates of each cell reference or range reference.
+func sqrefToCoordinates(sqref string) ([][]int, error) {
+	var refs [][]int
+	for _, ref := range strings.Fields(sqref) {
+		if !strings.Contains(ref, ":") {
+			ref += ":" + ref
+		}
+		coordinates, err := rangeRefToCoordinates(ref)
+		if err != nil {
+			return refs, err
+		}
+		_ = sortCoordinates(coordinates)
+		refs = append(refs, coordinates)
+	}
+	return refs, nil
+}
+
 // squashSqref generates cell reference sequence by given cells coordinates list.
 func squashSqref(cells [][]int) []string {
 	if len(cells) == 1 {
@@ -243,3 +243,65 @@ func TestDeleteDataValidation(t *testing.T) {
 	assert.NoError(t, f.DeleteDataValidation("Sheet1"))
 	assert.Nil(t, ws.(*xlsxWorksheet).DataValidations)
 }
+
+func TestAddColValidation(t *testing.T) {
+	f := NewFile()
+	dv := NewDataValidation(true)
+	dv.Sqref = "A1"
+	assert.NoError(t, dv.SetDropList([]string{"Open", "Closed"}))
+	assert.NoError(t, f.AddColValidation("Sheet1", "D", dv, ColValidationOptions{HeaderRows: 1}))
+	assert.Equal(t, "A1", dv.Sqref)
+	assert.NoError(t, f.AddColValidation("Sheet1", "F:E", NewDataValidation(false)))
+	dvs, err := f.GetColValidations("Sheet1", "D")
+	assert.NoError(t, err)
+	assert.Len(t, dvs, 1)
+	assert.Equal(t, "D2:D1048576", dvs[0].Sqref)
+	assert.Equal(t, "\"Open,Closed\"", dvs[0].Formula1)
+	dvs, err = f.GetColValidations("Sheet1", "F")
+	assert.NoError(t, err)
+	assert.Len(t, dvs, 1)
+	assert.Equal(t, "E1:F1048576", dvs[0].Sqref)
+	// Test get column data validations which are not applied to the last row
+	assert.NoError(t, f.AddDataValidation("Sheet1", &DataValidation{Sqref: "A1:A10 H5"}))
+	dvs, err = f.GetColValidations("Sheet1", "A")
+	assert.NoError(t, err)
+	assert.Empty(t, dvs)
+	// Test add column data validation which overlaps with existing data validations
+	assert.EqualError(t, f.AddColValidation("Sheet1", "D", dv), "data validation on D1:D1048576 overlaps with the existing data validation on D2:D1048576")
+	assert.EqualError(t, f.AddColValidation("Sheet1", "H", dv, ColValidationOptions{HeaderRows: 4}), "data validation on H5:H1048576 overlaps with the existing data validation on A1:A10 H5")
+	assert.NoError(t, f.AddColValidation("Sheet1", "H", dv, ColValidationOptions{HeaderRows: 5}))
+	// Test inserting and removing columns adjust the column data validations
+	assert.NoError(t, f.InsertCols("Sheet1", "C", 2))
+	dvs, err = f.GetColValidations("Sheet1", "F")
+	assert.NoError(t, err)
+	assert.Len(t, dvs, 1)
+	assert.Equal(t, "F2:F1048576", dvs[0].Sqref)
+	assert.NoError(t, f.RemoveCol("Sheet1", "F"))
+	dvs, err = f.GetColValidations("Sheet1", "F")
+	assert.NoError(t, err)
+	assert.Len(t, dvs, 1)
+	assert.Equal(t, "F1:G1048576", dvs[0].Sqref)
+	dvs, err = f.GetDataValidations("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, dvs, 3)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAddColValidation.xlsx")))
+	// Test add column data validation with invalid parameters
+	assert.Equal(t, ErrParameterRequired, f.AddColValidation("Sheet1", "A", nil))
+	assert.Equal(t, newInvalidColumnNameError("*"), f.AddColValidation("Sheet1", "*", dv))
+	assert.Equal(t, ErrParameterInvalid, f.AddColValidation("Sheet1", "A", dv, ColValidationOptions{HeaderRows: -1}))
+	assert.Equal(t, ErrParameterInvalid, f.AddColValidation("Sheet1", "A", dv, ColValidationOptions{HeaderRows: TotalRows}))
+	_, err = f.GetColValidations("Sheet1", "*")
+	assert.Equal(t, newInvalidColumnNameError("*"), err)
+	// Test add and get column data validation on not exists worksheet
+	assert.EqualError(t, f.AddColValidation("SheetN", "A", dv), "sheet SheetN does not exist")
+	_, err = f.GetColValidations("SheetN", "A")
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+	// Test add and get column data validation with invalid reference sequence
+	ws, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	ws.DataValidations = &xlsxDataValidations{DataValidation: []*xlsxDataValidation{nil, {Sqref: "A"}}}
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), f.AddColValidation("Sheet1", "B", dv))
+	_, err = f.GetColValidations("Sheet1", "B")
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), err)
+	assert.NoError(t, f.Close())
+}
@@ -97,6 +97,38 @@ func (f *File) GetAppProps() (ret *AppProperties, err error) {
 	return
 }
 
+// SetHyperlinkBase provides a function to set the base address used for
+// resolving the relative hyperlinks in the workbook, which stored in the
+// document application properties. The relative targets of the "External"
+// hyperlinks set by the SetCellHyperLink function will be resolved against
+// this base by the spreadsheet application. Set an empty string to remove the
+// hyperlink base. For example:
+//
+//	err := f.SetHyperlinkBase("https://example.com/share/")
+func (f *File) SetHyperlinkBase(base string) error {
+	app := new(xlsxProperties)
+	if err := f.xmlNewDecoder(bytes.NewReader(namespaceStrictToTransitional(f.readXML(defaultXMLPathDocPropsApp)))).
+		Decode(app); err != nil && err != io.EOF {
+		return err
+	}
+	app.HyperlinkBase = base
+	app.Vt = NameSpaceDocumentPropertiesVariantTypes.Value
+	output, err := xml.Marshal(app)
+	f.saveFileList(defaultXMLPathDocPropsApp, output)
+	return err
+}
+
+// GetHyperlinkBase provides a function to get the base address used for
+// resolving the relative hyperlinks in the workbook.
+func (f *File) GetHyperlinkBase() (string, error) {
+	app := new(xlsxProperties)
+	if err := f.xmlNewDecoder(bytes.NewReader(namespaceStrictToTransitional(f.readXML(defaultXMLPathDocPropsApp)))).
+		Decode(app); err != nil && err != io.EOF {
+		return "", err
+	}
+	return app.HyperlinkBase, nil
+}
+
 // SetDocProps provides a function to set document core properties. The
 // properties that can be set are:
 //
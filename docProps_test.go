@@ -65,6 +65,53 @@ func TestGetAppProps(t *testing.T) {
 	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
 }
 
+func TestHyperlinkBase(t *testing.T) {
+	f := NewFile()
+	base, err := f.GetHyperlinkBase()
+	assert.NoError(t, err)
+	assert.Empty(t, base)
+	assert.NoError(t, f.SetHyperlinkBase("https://example.com/share/"))
+	assert.NoError(t, f.SetCellHyperLink("Sheet1", "A1", "reports/2024.xlsx", "External"))
+	// Test the hyperlink base should be kept on set application properties
+	assert.NoError(t, f.SetAppProps(&AppProperties{Company: "Company Name"}))
+	absPath := `<mc:Choice Requires="x15"><x15ac:absPath xmlns:x15ac="http://schemas.microsoft.com/office/spreadsheetml/2010/11/ac" url="C:\Reports\"></x15ac:absPath></mc:Choice>`
+	wb, err := f.workbookReader()
+	assert.NoError(t, err)
+	wb.DecodeAlternateContent = &xlsxInnerXML{Content: absPath}
+	path := filepath.Join("test", "TestHyperlinkBase.xlsx")
+	assert.NoError(t, f.SaveAs(path))
+	assert.NoError(t, f.Close())
+
+	// Test the workbook absolute path and relative hyperlinks should be
+	// preserved after open and save the workbook
+	for i := 0; i < 2; i++ {
+		f, err = OpenFile(path)
+		assert.NoError(t, err)
+		assert.Contains(t, string(f.readXML(defaultXMLPathWorkbook)), absPath)
+		base, err = f.GetHyperlinkBase()
+		assert.NoError(t, err)
+		assert.Equal(t, "https://example.com/share/", base)
+		link, target, err := f.GetCellHyperLink("Sheet1", "A1")
+		assert.NoError(t, err)
+		assert.True(t, link)
+		assert.Equal(t, "reports/2024.xlsx", target)
+		assert.NoError(t, f.SetCellValue("Sheet1", "B1", i))
+		assert.NoError(t, f.Save())
+		assert.NoError(t, f.Close())
+	}
+
+	// Test remove the hyperlink base
+	f = NewFile()
+	assert.NoError(t, f.SetHyperlinkBase("https://example.com/share/"))
+	assert.NoError(t, f.SetHyperlinkBase(""))
+	assert.NotContains(t, string(f.readXML(defaultXMLPathDocPropsApp)), "HyperlinkBase")
+	// Test set and get the hyperlink base with unsupported charset
+	f.Pkg.Store(defaultXMLPathDocPropsApp, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.SetHyperlinkBase(""), "XML syntax error on line 1: invalid UTF-8")
+	_, err = f.GetHyperlinkBase()
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+}
+
 func TestSetDocProps(t *testing.T) {
 	f, err := OpenFile(filepath.Join("test", "Book1.xlsx"))
 	if !assert.NoError(t, err) {
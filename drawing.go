@@ -16,6 +16,7 @@ import (
 	"encoding/xml"
 	"io"
 	"reflect"
+	"sort"
 	"strconv"
 	"strings"
 )
@@ -795,7 +796,7 @@ func (f *File) drawShapeFill(fill Fill, spPr *cSpPr) *cSpPr {
 			spPr = &cSpPr{}
 		}
 		if len(fill.Color) == 1 {
-			spPr.SolidFill = &aSolidFill{SrgbClr: &attrValString{Val: stringPtr(strings.TrimPrefix(fill.Color[0], "#"))}}
+			spPr.SolidFill = &aSolidFill{SrgbClr: &attrValString{Val: stringPtr(f.getDrawingColor(fill.Color[0]))}}
 			return spPr
 		}
 		spPr.SolidFill = nil
@@ -855,7 +856,28 @@ func (f *File) drawChartSeriesDPt(i int, opts *Chart) []*cDPt {
 		},
 	}}
 	chartSeriesDPt := map[ChartType][]*cDPt{Pie: dpt, Pie3D: dpt}
-	return chartSeriesDPt[opts.Type]
+	dpts := chartSeriesDPt[opts.Type]
+	for _, dp := range opts.Series[i].DataPoints {
+		spPr := f.drawShapeFill(dp.Fill, nil)
+		if ln := f.drawChartLn(&dp.Border); ln != nil {
+			if spPr == nil {
+				spPr = &cSpPr{}
+			}
+			spPr.Ln = ln
+		}
+		d := &cDPt{
+			IDx:      &attrValInt{Val: intPtr(dp.Index)},
+			Bubble3D: &attrValBool{Val: boolPtr(false)},
+			SpPr:     spPr,
+		}
+		idx := sort.Search(len(dpts), func(j int) bool { return *dpts[j].IDx.Val >= dp.Index })
+		if idx < len(dpts) && *dpts[idx].IDx.Val == dp.Index {
+			dpts[idx] = d
+			continue
+		}
+		dpts = append(dpts[:idx], append([]*cDPt{d}, dpts[idx:]...)...)
+	}
+	return dpts
 }
 
 // drawChartSeriesCat provides a function to draw the c:cat element by given
@@ -1032,7 +1054,7 @@ func (f *File) drawChartSeriesDLbls(i int, opts *Chart) *cDLbls {
 	dLbl := opts.Series[i].DataLabel
 	dLbls.SpPr = f.drawShapeFill(dLbl.Fill, dLbls.SpPr)
 	dLbls.TxPr = &cTxPr{BodyPr: aBodyPr{}, P: aP{PPr: &aPPr{DefRPr: aRPr{}}}}
-	drawChartFont(&dLbl.Font, &dLbls.TxPr.P.PPr.DefRPr)
+	f.drawChartFont(&dLbl.Font, &dLbls.TxPr.P.PPr.DefRPr)
 	return dLbls
 }
 
@@ -1182,7 +1204,7 @@ func (f *File) drawPlotAreaSerAx(opts *Chart) []*cAxs {
 }
 
 // drawChartFont provides a function to draw the a:rPr element.
-func drawChartFont(fnt *Font, r *aRPr) {
+func (f *File) drawChartFont(fnt *Font, r *aRPr) {
 	if fnt == nil {
 		return
 	}
@@ -1196,7 +1218,7 @@ func drawChartFont(fnt *Font, r *aRPr) {
 			r.SolidFill = &aSolidFill{}
 		}
 		r.SolidFill.SchemeClr = nil
-		r.SolidFill.SrgbClr = &attrValString{Val: stringPtr(strings.ReplaceAll(strings.ToUpper(fnt.Color), "#", ""))}
+		r.SolidFill.SrgbClr = &attrValString{Val: stringPtr(f.getDrawingColor(fnt.Color))}
 	}
 	if fnt.Family != "" {
 		if r.Latin == nil {
@@ -1220,7 +1242,7 @@ func (f *File) drawPlotAreaTitles(runs []RichTextRun, vert string) *cTitle {
 	title := &cTitle{Tx: cTx{Rich: &cRich{}}, Overlay: &attrValBool{Val: boolPtr(false)}}
 	for _, run := range runs {
 		r := &aR{T: run.Text}
-		drawChartFont(run.Font, &r.RPr)
+		f.drawChartFont(run.Font, &r.RPr)
 		title.Tx.Rich.P = append(title.Tx.Rich.P, aP{
 			PPr:        &aPPr{DefRPr: aRPr{}},
 			R:          r,
@@ -1303,7 +1325,7 @@ func (f *File) drawPlotAreaTxPr(opts *ChartAxis) *cTxPr {
 		},
 	}
 	if opts != nil {
-		drawChartFont(&opts.Font, &cTxPr.P.PPr.DefRPr)
+		f.drawChartFont(&opts.Font, &cTxPr.P.PPr.DefRPr)
 		if -90 <= opts.Alignment.TextRotation && opts.Alignment.TextRotation <= 90 {
 			cTxPr.BodyPr.Rot = opts.Alignment.TextRotation * 60000
 		}
@@ -1406,7 +1428,13 @@ func (f *File) addDrawingChart(sheet, drawingXML, cell string, width, height, rI
 	}
 	width = int(float64(width) * opts.ScaleX)
 	height = int(float64(height) * opts.ScaleY)
-	colStart, rowStart, colEnd, rowEnd, x1, y1, x2, y2 := f.positionObjectPixels(sheet, col, row, width, height, opts)
+	colStart, rowStart, colEnd, rowEnd, x1, y1, x2, y2, err := f.positionObjectPixels(sheet, col, row, width, height, opts)
+	if err != nil {
+		return err
+	}
+	if err = opts.checkAnchor(colEnd, rowEnd); err != nil {
+		return err
+	}
 	content, cNvPrID, err := f.drawingParser(drawingXML)
 	if err != nil {
 		return err
@@ -1428,10 +1456,7 @@ func (f *File) addDrawingChart(sheet, drawingXML, cell string, width, height, rI
 
 	graphicFrame := xlsxGraphicFrame{
 		NvGraphicFramePr: xlsxNvGraphicFramePr{
-			CNvPr: &xlsxCNvPr{
-				ID:   cNvPrID,
-				Name: "Chart " + strconv.Itoa(cNvPrID),
-			},
+			CNvPr: opts.newCNvPr(cNvPrID, f.drawingObjectName(content, "Chart", cNvPrID)),
 		},
 		Graphic: &xlsxGraphic{
 			GraphicData: &xlsxGraphicData{
@@ -1471,10 +1496,7 @@ func (f *File) addSheetDrawingChart(drawingXML string, rID int, opts *GraphicOpt
 
 	graphicFrame := xlsxGraphicFrame{
 		NvGraphicFramePr: xlsxNvGraphicFramePr{
-			CNvPr: &xlsxCNvPr{
-				ID:   cNvPrID,
-				Name: "Chart " + strconv.Itoa(cNvPrID),
-			},
+			CNvPr: opts.newCNvPr(cNvPrID, f.drawingObjectName(content, "Chart", cNvPrID)),
 		},
 		Graphic: &xlsxGraphic{
 			GraphicData: &xlsxGraphicData{
@@ -1559,6 +1581,7 @@ func (f *File) deleteDrawing(col, row int, drawingXML, drawingType string) ([]st
 	if wsDr.TwoCellAnchor, err = deleteCellAnchor(wsDr.TwoCellAnchor); err != nil {
 		return delRID, err
 	}
+	wsDr.objectNames = nil
 	f.Drawings.Store(drawingXML, wsDr)
 	return getUnusedCellAnchorRID(delRID, refRID, rIDMaps), err
 }
@@ -1613,3 +1636,177 @@ func (f *File) genAxID(opts *Chart) []*attrValInt {
 	}
 	return []*attrValInt{{Val: intPtr(opts.XAxis.axID)}, {Val: intPtr(opts.YAxis.axID)}}
 }
+
+// DrawingOrder is the type of the drawing object stacking order operation.
+type DrawingOrder byte
+
+// Drawing object stacking order operations enumeration.
+const (
+	DrawingOrderBringToFront DrawingOrder = iota
+	DrawingOrderSendToBack
+	DrawingOrderBringForward
+	DrawingOrderSendBackward
+)
+
+// drawingAnchors returns the cell anchors in the drawing part with the anchor
+// type in the serialized order.
+func (wsDr *xlsxWsDr) drawingAnchors() ([]string, []*[]*xdrCellAnchor) {
+	return []string{"absoluteAnchor", "oneCellAnchor", "twoCellAnchor"},
+		[]*[]*xdrCellAnchor{&wsDr.AbsoluteAnchor, &wsDr.OneCellAnchor, &wsDr.TwoCellAnchor}
+}
+
+// getDrawingObject provides a function to parse the name, type and top-left
+// cell of the drawing object by given cell anchor.
+func (f *File) getDrawingObject(anchor *xdrCellAnchor) DrawingObject {
+	var obj DrawingObject
+	content, _ := xml.Marshal(anchor)
+	decodeObj := decodeDrawingObject{}
+	_ = f.xmlNewDecoder(bytes.NewReader(content)).Decode(&decodeObj)
+	if anchor.From != nil {
+		obj.Cell, _ = CoordinatesToCellName(anchor.From.Col+1, anchor.From.Row+1)
+	} else if decodeObj.From != nil {
+		obj.Cell, _ = CoordinatesToCellName(decodeObj.From.Col+1, decodeObj.From.Row+1)
+	}
+	for _, v := range []struct {
+		typ   string
+		cNvPr *decodeCNvPr
+	}{
+		{"Picture", decodeObj.Pic}, {"Shape", decodeObj.Sp}, {"Chart", decodeObj.GraphicFrame},
+		{"Group", decodeObj.GrpSp}, {"Connector", decodeObj.CxnSp}, {"Slicer", decodeObj.Slicer},
+	} {
+		if v.cNvPr != nil {
+			obj.Type, obj.Name = v.typ, v.cNvPr.Name
+			break
+		}
+	}
+	return obj
+}
+
+// drawingObjectName returns an unused drawing object name in the drawing by
+// given object type name and the preferred object ID, the ID will be
+// increased until the name is unique in the drawing. The used names are
+// collected from the cell anchors once and cached in the drawing, and the
+// returned name will be marked as used.
+func (f *File) drawingObjectName(wsDr *xlsxWsDr, typ string, ID int) string {
+	wsDr.mu.Lock()
+	defer wsDr.mu.Unlock()
+	if wsDr.objectNames == nil {
+		wsDr.objectNames = make(map[string]bool)
+		_, anchors := wsDr.drawingAnchors()
+		for _, cellAnchors := range anchors {
+			for _, anchor := range *cellAnchors {
+				switch {
+				case anchor.Pic != nil:
+					wsDr.objectNames[anchor.Pic.NvPicPr.CNvPr.Name] = true
+				case anchor.Sp != nil && anchor.Sp.NvSpPr != nil && anchor.Sp.NvSpPr.CNvPr != nil:
+					wsDr.objectNames[anchor.Sp.NvSpPr.CNvPr.Name] = true
+				default:
+					wsDr.objectNames[f.getDrawingObject(anchor).Name] = true
+				}
+			}
+		}
+	}
+	for wsDr.objectNames[typ+" "+strconv.Itoa(ID)] {
+		ID++
+	}
+	name := typ + " " + strconv.Itoa(ID)
+	wsDr.objectNames[name] = true
+	return name
+}
+
+// GetDrawingObjects provides a function to get all drawing objects, including
+// pictures, charts, shapes and slicers, by given worksheet name. The objects
+// are returned in the stacking order, and the object on the top will be the
+// last one. For example, get the drawing objects on Sheet1:
+//
+//	objects, err := f.GetDrawingObjects("Sheet1")
+func (f *File) GetDrawingObjects(sheet string) ([]DrawingObject, error) {
+	var objects []DrawingObject
+	ws, err := f.workSheetReader(sheet)
+	if err != nil || ws.Drawing == nil {
+		return objects, err
+	}
+	target := f.getSheetRelationshipsTargetByID(sheet, ws.Drawing.RID)
+	drawingXML := strings.TrimPrefix(strings.ReplaceAll(target, "..", "xl"), "/")
+	wsDr, _, err := f.drawingParser(drawingXML)
+	if err != nil {
+		return objects, err
+	}
+	types, anchors := wsDr.drawingAnchors()
+	for idx, cellAnchors := range anchors {
+		for _, anchor := range *cellAnchors {
+			obj := f.getDrawingObject(anchor)
+			obj.Anchor, obj.ZIndex = types[idx], len(objects)
+			objects = append(objects, obj)
+		}
+	}
+	return objects, err
+}
+
+// SetDrawingObjectOrder provides a function to change the stacking order of
+// the drawing object by given worksheet name, the drawing object name and
+// order operation. The drawing object name should be unique in the worksheet,
+// get the names by the GetDrawingObjects function. Note that the objects with
+// different anchor types are serialized in groups, so the stacking order only
+// can be changed among the objects with the same anchor type. For example,
+// bring the picture named "Picture 2" to the front on Sheet1:
+//
+//	err := f.SetDrawingObjectOrder("Sheet1", "Picture 2", excelize.DrawingOrderBringToFront)
+func (f *File) SetDrawingObjectOrder(sheet, name string, order DrawingOrder) error {
+	if order > DrawingOrderSendBackward {
+		return ErrParameterInvalid
+	}
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return err
+	}
+	if ws.Drawing == nil {
+		return newNoExistDrawingObjectError(name)
+	}
+	target := f.getSheetRelationshipsTargetByID(sheet, ws.Drawing.RID)
+	drawingXML := strings.TrimPrefix(strings.ReplaceAll(target, "..", "xl"), "/")
+	wsDr, _, err := f.drawingParser(drawingXML)
+	if err != nil {
+		return err
+	}
+	wsDr.mu.Lock()
+	defer wsDr.mu.Unlock()
+	var (
+		cellAnchors *[]*xdrCellAnchor
+		idx         = -1
+	)
+	_, anchors := wsDr.drawingAnchors()
+	for _, ca := range anchors {
+		for i, anchor := range *ca {
+			if f.getDrawingObject(anchor).Name != name {
+				continue
+			}
+			if idx != -1 {
+				return newDuplicateDrawingObjectError(name)
+			}
+			cellAnchors, idx = ca, i
+		}
+	}
+	if idx == -1 {
+		return newNoExistDrawingObjectError(name)
+	}
+	ca := *cellAnchors
+	anchor := ca[idx]
+	switch order {
+	case DrawingOrderBringToFront:
+		ca = append(append(ca[:idx:idx], ca[idx+1:]...), anchor)
+	case DrawingOrderSendToBack:
+		ca = append([]*xdrCellAnchor{anchor}, append(ca[:idx:idx], ca[idx+1:]...)...)
+	case DrawingOrderBringForward:
+		if idx < len(ca)-1 {
+			ca[idx], ca[idx+1] = ca[idx+1], ca[idx]
+		}
+	case DrawingOrderSendBackward:
+		if idx > 0 {
+			ca[idx], ca[idx-1] = ca[idx-1], ca[idx]
+		}
+	}
+	*cellAnchors = ca
+	f.Drawings.Store(drawingXML, wsDr)
+	return err
+}
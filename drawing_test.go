@@ -13,6 +13,7 @@ package excelize
 
 import (
 	"encoding/xml"
+	"path/filepath"
 	"sync"
 	"testing"
 
@@ -47,3 +48,110 @@ func TestDeleteDrawingRels(t *testing.T) {
 	f.Pkg.Store(rels, MacintoshCyrillicCharset)
 	f.deleteDrawingRels(rels, "")
 }
+
+func TestDrawingObjectOrder(t *testing.T) {
+	f := NewFile()
+	// Test get drawing objects on the worksheet without drawing
+	objects, err := f.GetDrawingObjects("Sheet1")
+	assert.NoError(t, err)
+	assert.Empty(t, objects)
+	assert.EqualError(t, f.SetDrawingObjectOrder("Sheet1", "Shape 2", DrawingOrderBringToFront), "drawing object Shape 2 does not exist")
+	for _, cell := range []string{"A1", "B2", "C3"} {
+		assert.NoError(t, f.AddShape("Sheet1", &Shape{Cell: cell, Type: "rect"}))
+	}
+	assert.NoError(t, f.AddChart("Sheet1", "D4", &Chart{
+		Type:   Line,
+		Series: []ChartSeries{{Name: "Sheet1!$A$1", Values: "Sheet1!$A$1:$A$2"}},
+	}))
+	names := func() []string {
+		objects, err := f.GetDrawingObjects("Sheet1")
+		assert.NoError(t, err)
+		var names []string
+		for i, obj := range objects {
+			assert.Equal(t, i, obj.ZIndex)
+			assert.Equal(t, "twoCellAnchor", obj.Anchor)
+			names = append(names, obj.Name)
+		}
+		return names
+	}
+	objects, err = f.GetDrawingObjects("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, DrawingObject{Name: "Shape 2", Type: "Shape", Anchor: "twoCellAnchor", Cell: "A1"}, objects[0])
+	assert.Equal(t, DrawingObject{Name: "Chart 5", Type: "Chart", Anchor: "twoCellAnchor", Cell: "D4", ZIndex: 3}, objects[3])
+	assert.Equal(t, []string{"Shape 2", "Shape 3", "Shape 4", "Chart 5"}, names())
+	assert.NoError(t, f.SetDrawingObjectOrder("Sheet1", "Shape 2", DrawingOrderBringToFront))
+	assert.Equal(t, []string{"Shape 3", "Shape 4", "Chart 5", "Shape 2"}, names())
+	assert.NoError(t, f.SetDrawingObjectOrder("Sheet1", "Chart 5", DrawingOrderSendToBack))
+	assert.Equal(t, []string{"Chart 5", "Shape 3", "Shape 4", "Shape 2"}, names())
+	assert.NoError(t, f.SetDrawingObjectOrder("Sheet1", "Shape 3", DrawingOrderSendBackward))
+	assert.Equal(t, []string{"Shape 3", "Chart 5", "Shape 4", "Shape 2"}, names())
+	assert.NoError(t, f.SetDrawingObjectOrder("Sheet1", "Shape 3", DrawingOrderSendBackward))
+	assert.NoError(t, f.SetDrawingObjectOrder("Sheet1", "Shape 4", DrawingOrderBringForward))
+	assert.NoError(t, f.SetDrawingObjectOrder("Sheet1", "Shape 4", DrawingOrderBringForward))
+	assert.Equal(t, []string{"Shape 3", "Chart 5", "Shape 2", "Shape 4"}, names())
+
+	// Test the serialized anchor order of the drawing part
+	buf, err := f.WriteToBuffer()
+	assert.NoError(t, err)
+	f2, err := OpenReader(buf)
+	assert.NoError(t, err)
+	objects, err = f2.GetDrawingObjects("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, objects, 4)
+	assert.Equal(t, DrawingObject{Name: "Shape 4", Type: "Shape", Anchor: "twoCellAnchor", Cell: "C3", ZIndex: 3}, objects[3])
+	assert.NoError(t, f2.SetDrawingObjectOrder("Sheet1", "Shape 4", DrawingOrderSendToBack))
+	objects, err = f2.GetDrawingObjects("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, "Shape 4", objects[0].Name)
+	assert.NoError(t, f2.Close())
+
+	// Test set drawing object order with invalid order
+	assert.Equal(t, ErrParameterInvalid, f.SetDrawingObjectOrder("Sheet1", "Shape 2", DrawingOrder(4)))
+	// Test set drawing object order with not exist object name
+	assert.EqualError(t, f.SetDrawingObjectOrder("Sheet1", "Shape 1", DrawingOrderBringToFront), "drawing object Shape 1 does not exist")
+	// Test set drawing object order with duplicate object name
+	drawing, ok := f.Drawings.Load("xl/drawings/drawing1.xml")
+	assert.True(t, ok)
+	wsDr := drawing.(*xlsxWsDr)
+	wsDr.TwoCellAnchor[0].Sp.NvSpPr.CNvPr.Name = "Shape 4"
+	assert.EqualError(t, f.SetDrawingObjectOrder("Sheet1", "Shape 4", DrawingOrderBringToFront), "drawing object name Shape 4 is not unique")
+	// Test get and set drawing objects with not exist worksheet
+	_, err = f.GetDrawingObjects("SheetN")
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+	assert.EqualError(t, f.SetDrawingObjectOrder("SheetN", "Shape 4", DrawingOrderBringToFront), "sheet SheetN does not exist")
+	// Test get and set drawing objects with unsupported charset drawing part
+	f.Drawings.Delete("xl/drawings/drawing1.xml")
+	f.Pkg.Store("xl/drawings/drawing1.xml", MacintoshCyrillicCharset)
+	_, err = f.GetDrawingObjects("Sheet1")
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	assert.EqualError(t, f.SetDrawingObjectOrder("Sheet1", "Shape 4", DrawingOrderBringToFront), "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
+
+func TestDrawingObjectName(t *testing.T) {
+	f := NewFile()
+	chart := &Chart{Type: Line, Series: []ChartSeries{{Name: "Sheet1!$A$1", Values: "Sheet1!$A$1:$A$2"}}}
+	for _, cell := range []string{"A1", "J1"} {
+		assert.NoError(t, f.AddChart("Sheet1", cell, chart))
+	}
+	// Test the object names are unique in the drawing after deleting objects
+	assert.NoError(t, f.DeleteChart("Sheet1", "A1"))
+	assert.NoError(t, f.AddChart("Sheet1", "A20", chart))
+	assert.NoError(t, f.AddShape("Sheet1", &Shape{Cell: "A40", Type: "rect"}))
+	drawing, ok := f.Drawings.Load("xl/drawings/drawing1.xml")
+	assert.True(t, ok)
+	wsDr := drawing.(*xlsxWsDr)
+	assert.Equal(t, map[string]bool{"Chart 3": true, "Chart 4": true, "Shape 4": true}, wsDr.objectNames)
+	// Test the used names are collected again after the cache has been reset
+	wsDr.TwoCellAnchor[2].Sp.NvSpPr.CNvPr.Name = "Picture 5"
+	wsDr.objectNames = nil
+	assert.NoError(t, f.AddPicture("Sheet1", "J40", filepath.Join("test", "images", "excel.png"), nil))
+	objects, err := f.GetDrawingObjects("Sheet1")
+	assert.NoError(t, err)
+	var names []string
+	for _, obj := range objects {
+		names = append(names, obj.Name)
+	}
+	assert.Equal(t, []string{"Chart 3", "Chart 4", "Picture 5", "Picture 6"}, names)
+	assert.NoError(t, f.Close())
+}
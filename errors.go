@@ -27,14 +27,32 @@ var (
 	// ErrCellCharsLength defined the error message for receiving a cell
 	// characters length that exceeds the limit.
 	ErrCellCharsLength = fmt.Errorf("cell value must be 0-%d characters", TotalCellChars)
+	// ErrCellFormulaLength defined the error message for receiving a cell
+	// formula exceeds the limit.
+	ErrCellFormulaLength = fmt.Errorf("cell formula must be 0-%d characters", TotalFormulaChars)
 	// ErrCellStyles defined the error message on cell styles exceeds the limit.
 	ErrCellStyles = fmt.Errorf("the cell styles exceeds the %d limit", MaxCellStyles)
+	// ErrChartOverlap defined the error message on receive an invalid chart
+	// series overlap value.
+	ErrChartOverlap = errors.New("the chart series overlap must be between -100 and 100")
 	// ErrColumnNumber defined the error message on receive an invalid column
 	// number.
 	ErrColumnNumber = fmt.Errorf("the column number must be greater than or equal to %d and less than or equal to %d", MinColumns, MaxColumns)
+	// ErrColumnRangeOverlap defined the error message on receiving the
+	// overlapped column ranges.
+	ErrColumnRangeOverlap = errors.New("the column ranges must not overlap")
 	// ErrColumnWidth defined the error message on receive an invalid column
 	// width.
 	ErrColumnWidth = fmt.Errorf("the width of the column must be less than or equal to %d characters", MaxColumnWidth)
+	// ErrColumnWidthValue defined the error message on receive a negative,
+	// NaN or infinite column width.
+	ErrColumnWidthValue = errors.New("the width of the column must be a non-negative finite number")
+	// ErrComputedColExists defined the error message on adding a computed
+	// column to a column which already contains data.
+	ErrComputedColExists = errors.New("the destination column already contains data")
+	// ErrComputedColOptions defined the error message on receiving the
+	// computed column options without exactly one value source.
+	ErrComputedColOptions = errors.New("exactly one of the FormulaTemplate and ValueFunc must be specified")
 	// ErrCoordinates defined the error message on invalid coordinates tuples
 	// length.
 	ErrCoordinates = errors.New("coordinates length must be 4")
@@ -52,6 +70,9 @@ var (
 	// ErrDefinedNameScope defined the error message on not found defined name
 	// in the given scope.
 	ErrDefinedNameScope = errors.New("no defined name on the scope")
+	// ErrDefinedNameValueLength defined the error message on receiving the
+	// string constant of the defined name exceeds the limit.
+	ErrDefinedNameValueLength = fmt.Errorf("the string constant of the defined name exceeds the %d characters limit", MaxFieldLength)
 	// ErrExistsSheet defined the error message on given sheet already exists.
 	ErrExistsSheet = errors.New("the same name sheet already exists")
 	// ErrExistsTableName defined the error message on given table already exists.
@@ -69,9 +90,18 @@ var (
 	// ErrImgExt defined the error message on receive an unsupported image
 	// extension.
 	ErrImgExt = errors.New("unsupported image extension")
+	// ErrIndent defined the error message on receive an invalid alignment
+	// indent.
+	ErrIndent = fmt.Errorf("indent must be between 0 and %d", MaxIndent)
+	// ErrIndexedColors defined the error message on receive an invalid
+	// indexed color palette.
+	ErrIndexedColors = errors.New("the indexed color palette must contain exactly 64 colors")
 	// ErrInvalidFormula defined the error message on receive an invalid
 	// formula.
 	ErrInvalidFormula = errors.New("formula not valid")
+	// ErrMacroFileFormat defined the error message on saving the workbook
+	// contains a VBA project without macros enabled file extension.
+	ErrMacroFileFormat = errors.New("the workbook contains a VBA project and must be saved with a macro-enabled file extension")
 	// ErrMaxFilePathLength defined the error message on receive the file path
 	// length overflow.
 	ErrMaxFilePathLength = fmt.Errorf("file path length exceeds maximum limit %d characters", MaxFilePathLength)
@@ -104,6 +134,12 @@ var (
 	// ErrPivotTableClassicLayout defined the error message on enable
 	// ClassicLayout and CompactData in the same time.
 	ErrPivotTableClassicLayout = errors.New("cannot enable ClassicLayout and CompactData in the same time")
+	// ErrReadingOrder defined the error message on receive an invalid
+	// alignment reading order.
+	ErrReadingOrder = errors.New("reading order must be 0, 1 or 2")
+	// ErrRowsCheckpoint defined the error message on receiving the invalid or
+	// outdated rows iterator checkpoint.
+	ErrRowsCheckpoint = errors.New("the rows checkpoint is invalid or the worksheet has been changed")
 	// ErrSave defined the error message for saving file.
 	ErrSave = errors.New("no path defined for file, consider File.WriteTo or File.Write")
 	// ErrSheetIdx defined the error message on receive the invalid worksheet
@@ -136,9 +172,21 @@ var (
 	// ErrSparklineType defined the error message on receive the invalid
 	// sparkline Type parameters.
 	ErrSparklineType = errors.New("parameter 'Type' must be 'line', 'column' or 'win_loss'")
+	// ErrStopWalk defined the error which returned by the callback function
+	// of the WalkCols and WalkRows functions to stop the traversal early.
+	ErrStopWalk = errors.New("stop walk")
+	// ErrStreamSetCol defined the error message on set rows and columns in
+	// the same stream writer.
+	ErrStreamSetCol = errors.New("the SetRow and SetCol functions can not be used in the same stream writer")
+	// ErrStreamSetColOutlineLevel defined the error message on set column
+	// outline level in stream writing mode.
+	ErrStreamSetColOutlineLevel = errors.New("must call the SetColOutlineLevel function before the SetRow function")
 	// ErrStreamSetColStyle defined the error message on set column style in
 	// stream writing mode.
 	ErrStreamSetColStyle = errors.New("must call the SetColStyle function before the SetRow function")
+	// ErrStreamSetColVisible defined the error message on set column
+	// visibility in stream writing mode.
+	ErrStreamSetColVisible = errors.New("must call the SetColVisible function before the SetRow function")
 	// ErrStreamSetColWidth defined the error message on set column width in
 	// stream writing mode.
 	ErrStreamSetColWidth = errors.New("must call the SetColWidth function before the SetRow function")
@@ -148,6 +196,9 @@ var (
 	// ErrTotalSheetHyperlinks defined the error message on hyperlinks count
 	// overflow.
 	ErrTotalSheetHyperlinks = errors.New("over maximum limit hyperlinks in a worksheet")
+	// ErrTrackChangesDisabled defined the error message on get the change
+	// journal without the TrackChanges option enabled.
+	ErrTrackChangesDisabled = errors.New("the TrackChanges option is not enabled")
 	// ErrUnknownEncryptMechanism defined the error message on unsupported
 	// encryption mechanism.
 	ErrUnknownEncryptMechanism = errors.New("unknown encryption mechanism")
@@ -190,6 +241,104 @@ func (err ErrSheetNotExist) Error() string {
 	return fmt.Sprintf("sheet %s does not exist", err.SheetName)
 }
 
+// ErrCalcLimitExceeded defined an error of the formula calculation exceeded
+// the limit, the Limit field is the name of the exceeded limit option, such
+// as MaxCalcSteps, MaxCalcRangeCells, MaxCalcDepth and CalcTimeout.
+type ErrCalcLimitExceeded struct {
+	Limit string
+	Value interface{}
+}
+
+// Error returns the error message on the formula calculation exceeded the
+// limit.
+func (err ErrCalcLimitExceeded) Error() string {
+	return fmt.Sprintf("formula calculation exceeded the %s limit %v", err.Limit, err.Value)
+}
+
+// CellParseError defined an error of the cell value can't be parsed as the
+// expected data type.
+type CellParseError struct {
+	Cell   string
+	Value  string
+	Reason string
+}
+
+// Error returns the error message on parsing the cell value.
+func (err CellParseError) Error() string {
+	return fmt.Sprintf("cannot parse cell %s value %q: %s", err.Cell, err.Value, err.Reason)
+}
+
+// ErrGraphicOptions defined an error of the invalid field value in the format
+// settings of the drawing objects, such as pictures, charts and shapes.
+type ErrGraphicOptions struct {
+	Field  string
+	Value  interface{}
+	Reason string
+}
+
+// Error returns the error message on receiving the invalid field value of the
+// graphic options.
+func (err ErrGraphicOptions) Error() string {
+	return fmt.Sprintf("invalid graphic options field %s value %v: %s", err.Field, err.Value, err.Reason)
+}
+
+// ErrInvalidCellRef defined an error of the invalid cell reference of the
+// cell element in the worksheet, which was skipped by the rows and columns
+// iterators with the IgnoreInvalidCellRef option. The Row is the approximate
+// row number of the cell counted by the iterator.
+type ErrInvalidCellRef struct {
+	Sheet string
+	Row   int
+	Ref   string
+	Err   error
+}
+
+// Error returns the error message on reading the invalid cell reference.
+func (err ErrInvalidCellRef) Error() string {
+	return fmt.Sprintf("invalid cell reference %q in sheet %s near row %d: %v", err.Ref, err.Sheet, err.Row, err.Err)
+}
+
+// Unwrap returns the error of parsing the invalid cell reference.
+func (err ErrInvalidCellRef) Unwrap() error {
+	return err.Err
+}
+
+// ErrTransformCellValue defined an error of transforming the cell value by
+// the CellValueTransformer function.
+type ErrTransformCellValue struct {
+	Sheet string
+	Cell  string
+	Err   error
+}
+
+// Error returns the error message on transforming the cell value.
+func (err ErrTransformCellValue) Error() string {
+	return fmt.Sprintf("cannot transform the value of cell %s in sheet %s: %v", err.Cell, err.Sheet, err.Err)
+}
+
+// Unwrap returns the error returned by the cell value transformer.
+func (err ErrTransformCellValue) Unwrap() error {
+	return err.Err
+}
+
+// newBindCellValueError defined the error message on converting the cell
+// value to the struct field type.
+func newBindCellValueError(cell, field, value string, err error) error {
+	return fmt.Errorf("cannot bind cell %s value %q to field %s: %v", cell, value, field, err)
+}
+
+// newBindFieldError defined the error message on receiving a tagged struct
+// field which can't be bound to a column.
+func newBindFieldError(field, reason string) error {
+	return fmt.Errorf("cannot bind field %s: %s", field, reason)
+}
+
+// newCalcFunctionConflictError defined the error message on registering a
+// custom formula function which conflicts with a built-in function.
+func newCalcFunctionConflictError(name string) error {
+	return fmt.Errorf("formula function %s conflicts with the built-in function", name)
+}
+
 // newCellNameToCoordinatesError defined the error message on converts
 // alphanumeric cell name to coordinates.
 func newCellNameToCoordinatesError(cell string, err error) error {
@@ -202,6 +351,18 @@ func newCoordinatesToCellNameError(col, row int) error {
 	return fmt.Errorf("invalid cell reference [%d, %d]", col, row)
 }
 
+// newDataValidationOverlapError defined the error message on adding the data
+// validation which overlaps with an existing data validation.
+func newDataValidationOverlapError(sqref, existing string) error {
+	return fmt.Errorf("data validation on %s overlaps with the existing data validation on %s", sqref, existing)
+}
+
+// newDuplicateDrawingObjectError defined the error message on receiving the
+// drawing object name which used by multiple objects.
+func newDuplicateDrawingObjectError(name string) error {
+	return fmt.Errorf("drawing object name %s is not unique", name)
+}
+
 // newFieldLengthError defined the error message on receiving the field length
 // overflow.
 func newFieldLengthError(name string) error {
@@ -232,6 +393,12 @@ func newInvalidCellNameError(cell string) error {
 	return fmt.Errorf("invalid cell name %q", cell)
 }
 
+// newInvalidColorError defined the error message on receiving the invalid
+// color settings.
+func newInvalidColorError(attr string, value interface{}) error {
+	return fmt.Errorf("invalid color %s value %v", attr, value)
+}
+
 // newInvalidColumnNameError defined the error message on receiving the
 // invalid column name.
 func newInvalidColumnNameError(col string) error {
@@ -280,6 +447,24 @@ func newInvalidStyleID(styleID int) error {
 	return fmt.Errorf("invalid style ID %d", styleID)
 }
 
+// newMoveColsError defined the error message on moving columns onto
+// themselves or into their own interior.
+func newMoveColsError(srcRange, destCol string) error {
+	return fmt.Errorf("cannot move columns %s before column %s: the destination is within or adjacent to the moved columns", srcRange, destCol)
+}
+
+// newNoExistConnectionError defined the error message on receiving the non
+// existing connection name.
+func newNoExistConnectionError(name string) error {
+	return fmt.Errorf("connection %s does not exist", name)
+}
+
+// newNoExistDrawingObjectError defined the error message on receiving the non
+// existing drawing object name.
+func newNoExistDrawingObjectError(name string) error {
+	return fmt.Errorf("drawing object %s does not exist", name)
+}
+
 // newNoExistSlicerError defined the error message on receiving the non existing
 // slicer name.
 func newNoExistSlicerError(name string) error {
@@ -310,12 +495,43 @@ func newPivotTableRangeError(msg string) error {
 	return fmt.Errorf("parameter 'PivotTableRange' parsing error: %s", msg)
 }
 
+// newSortKeyColumnError defined the error message on receiving the sort key
+// column outside of the sort range.
+func newSortKeyColumnError(col, rangeRef string) error {
+	return fmt.Errorf("sort key column %s is outside of the range %s", col, rangeRef)
+}
+
+// newSortRangeFormulaError defined the error message on sorting the range
+// which contains formula cells with the formulas rejected.
+func newSortRangeFormulaError(cell string) error {
+	return fmt.Errorf("cannot sort the range: the cell %s contains a formula", cell)
+}
+
+// newSortRangeMergeCellError defined the error message on sorting the range
+// which intersects merged cells.
+func newSortRangeMergeCellError(rangeRef, mergeCell string) error {
+	return fmt.Errorf("cannot sort the range %s: the merged cell %s intersects the range, to do this, all the merged cells need to be the same size", rangeRef, mergeCell)
+}
+
 // newStreamSetRowError defined the error message on the stream writer
 // receiving the non-ascending row number.
 func newStreamSetRowError(row int) error {
 	return fmt.Errorf("row %d has already been written", row)
 }
 
+// newStreamSetColError defined the error message on the stream writer
+// receiving the non-ascending column number.
+func newStreamSetColError(col int) error {
+	name, _ := ColumnNumberToName(col)
+	return fmt.Errorf("column %s has already been written", name)
+}
+
+// newTransformRowError defined the error message on transforming the row of
+// the worksheet by the TransformSheet function.
+func newTransformRowError(row int, err error) error {
+	return fmt.Errorf("failed to transform row %d: %v", row, err)
+}
+
 // newUnknownFilterTokenError defined the error message on receiving a unknown
 // filter operator token.
 func newUnknownFilterTokenError(token string) error {
@@ -18,25 +18,41 @@ import (
 	"encoding/xml"
 	"io"
 	"os"
+	"path"
 	"path/filepath"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	"golang.org/x/net/html/charset"
 )
 
 // File define a populated spreadsheet file struct.
+//
+// The locks should be acquired in the order of the file lock, the worksheet
+// lock and the style sheet lock, and a lock must not be acquired while a lock
+// after it in this order is held. The lazy loading of the style sheet is
+// guarded by its own lock, so it doesn't require the file lock and no other
+// lock will be acquired when loading.
 type File struct {
 	mu               sync.Mutex
+	stylesMu         sync.Mutex
+	calcFuncs        sync.Map
 	checked          sync.Map
+	colRanges        []*colRange
 	formulaChecked   bool
+	fontMetrics      sync.Map
+	journal          *changeJournal
+	truncated        truncatedCells
 	zip64Entries     []string
 	options          *Options
 	sharedStringItem [][]uint
 	sharedStringsMap map[string]int
 	sharedStringTemp *os.File
 	sheetMap         map[string]string
+	sheetMapMu       sync.RWMutex
 	streams          map[string]*StreamWriter
 	tempFiles        sync.Map
 	xmlAttr          sync.Map
@@ -69,11 +85,119 @@ type charsetTranscoderFn func(charset string, input io.Reader) (rdr io.Reader, e
 // MaxCalcIterations specifies the maximum iterations for iterative
 // calculation, the default value is 0.
 //
+// MaxCalcSteps, MaxCalcRangeCells, MaxCalcDepth and CalcTimeout specify the
+// limits of the formula calculation by the CalcCellValue function and the
+// CalcFormulaOnRead option, which prevent the formula in the untrusted
+// spreadsheet from running effectively forever or allocating unbounded
+// memory. MaxCalcSteps specifies the maximum evaluated tokens and resolved
+// cells, the default value is 16777216. MaxCalcRangeCells specifies the
+// maximum cells expanded from the cell ranges, the default value is 4194304.
+// MaxCalcDepth specifies the maximum recursion depth of the nested functions
+// and the cell references, the default value is 16384. CalcTimeout specifies
+// the wall-clock time limit, the default value is 1 minute. The default value
+// will be used if the limit is not specified, and the ErrCalcLimitExceeded
+// error identifying the exceeded limit will be returned. Use the
+// CalcCellValueContext function to cancel the calculation by the context.
+//
 // Password specifies the password of the spreadsheet in plain text.
 //
 // RawCellValue specifies if apply the number format for the cell value or get
 // the raw value.
 //
+// SkipRows specifies the number of leading rows to be skipped by the GetRows
+// function, the default value is 0. This option only takes effect in the
+// options of the GetRows function call.
+//
+// TrackChanges specifies if record the cell value, style and hyperlink
+// changes made by the mutating cell functions into an in-memory change
+// journal, the changes will be recorded only if the function succeeded, the
+// journal can be get by the GetChangeJournal function.
+//
+// ChangeJournalSize specifies the maximum number of entries kept in the
+// change journal, the oldest entries will be discarded when the limit is
+// reached, the default value 0 means unlimited.
+//
+// TruncateCellValue specifies if truncate the cell value exceeds 32767
+// characters and the cell formula exceeds 8192 characters instead of
+// returning an error when setting cells, it is useful for bulk imports. The
+// truncated cells can be get by the GetTruncatedCells function.
+//
+// TrimTrailingEmptyCells specifies if truncate the trailing empty cells of
+// each column returned by the Cols.Rows and GetCols functions, so the
+// columns will end at the last cell with data like the rows returned by the
+// GetRows function. The empty cells between the cells with data will be kept,
+// and the index of the cell still corresponds to the row number.
+//
+// BlankCellValue specifies the value returned for the blank cells by the
+// columns and rows iterators, such as the GetCols and GetRows functions, to
+// distinguish the missing cells and the cells without value from the cells
+// with an empty string value, which are returned as empty strings. The blank
+// cells will be returned as empty strings if this option is not specified.
+// The cells after the last cell with value in each row are not returned by
+// the rows iterator, and the TrimTrailingEmptyCells option truncates the
+// trailing blank cells of each column with this value.
+//
+// CalcFormulaOnRead specifies if calculate the formula cells without cached
+// value when reading cells by the columns and rows iterators, such as the
+// GetCols and GetRows functions. The formula decoded by the iterator will be
+// calculated, and the worksheet will be loaded only if the formula
+// referencing other cells. The cell will be empty if the formula can't be
+// calculated, and the error can be get by the Error function of the
+// iterator, the error will be dropped by the GetCols and GetRows functions.
+//
+// FormulaTextOnRead specifies if return the formula of the formula cells
+// instead of the value when reading cells by the columns and rows iterators,
+// this option takes precedence over the CalcFormulaOnRead option. The
+// CalcFormulaOnRead and FormulaTextOnRead options only take effect when
+// passed to the function call of reading cells, and will be ignored when
+// opening the workbook.
+//
+// SkipHiddenCols specifies if omit the hidden columns when reading cells by
+// the columns iterator, such as the GetCols function.
+//
+// SkipHiddenRows specifies if omit the hidden rows when reading cells by the
+// rows iterator, such as the GetRows function.
+//
+// SkipFilteredRows specifies if omit the rows hidden by the AutoFilter when
+// reading cells by the rows and columns iterator, such as the GetRows and
+// GetCols functions. The rows in the AutoFilter range with the hidden flag
+// persisted in the worksheet will be omitted, and the filter criteria will
+// not be re-evaluated. The SkipHiddenCols, SkipHiddenRows and
+// SkipFilteredRows options only take effect when passed to the function call
+// of reading cells, and will be ignored when opening the workbook.
+//
+// IgnoreInvalidCellRef specifies if skip the invalid cell references when
+// reading cells by the rows and columns iterators instead of returning an
+// error, the cells will be positioned by the order of them in the row, and
+// the skipped references can be get by the Warnings function of the
+// iterator. This option only takes effect when passed to the function call
+// of creating the iterator, and will be ignored when opening the workbook.
+//
+// CellValueTransformer specifies the function to transform the value of each
+// cell with a value when reading cells by the GetRows and GetCols functions,
+// the rows and columns iterators, such as normalizing the date strings or
+// decimal separators. The function will be called with the cell reference,
+// the metadata of the cell and the value read from the cell, and the returned
+// value will be used as the cell value. Returning an error will abort the
+// reading with an ErrTransformCellValue error which carries the sheet name and
+// cell reference. The function may be called concurrently when reading cells
+// concurrently, so it must be concurrency safe.
+//
+// UnknownFunctionAsNameError specifies if the CalcCellValue function returns
+// the #NAME? error value like the spreadsheet applications instead of an error
+// when calculating the formula with a function which is neither built-in nor
+// registered by the RegisterCalcFunction function. This option only takes
+// effect when passed to the CalcCellValue function call, and will be ignored
+// when opening the workbook.
+//
+// StripVBAProject specifies if remove the VBA project from the workbook when
+// saving it as a spreadsheet without macros enabled (XLSX or XLTX) instead of
+// returning an error.
+//
+// StrictRowLimit specifies if return the ErrMaxRows error when reading a
+// worksheet which contains the row elements with a row number exceeding the
+// maximum limit, these row elements will be ignored by default.
+//
 // UnzipSizeLimit specifies to unzip size limit in bytes on open the
 // spreadsheet, this value should be greater than or equal to
 // UnzipXMLSizeLimit, the default size limit is 16GB.
@@ -99,15 +223,35 @@ type charsetTranscoderFn func(charset string, input io.Reader) (rdr io.Reader, e
 // CultureInfo specifies the country code for applying built-in language number
 // format code these effect by the system's local language settings.
 type Options struct {
-	MaxCalcIterations uint
-	Password          string
-	RawCellValue      bool
-	UnzipSizeLimit    int64
-	UnzipXMLSizeLimit int64
-	ShortDatePattern  string
-	LongDatePattern   string
-	LongTimePattern   string
-	CultureInfo       CultureName
+	MaxCalcIterations          uint
+	MaxCalcSteps               int
+	MaxCalcRangeCells          int
+	MaxCalcDepth               int
+	CalcTimeout                time.Duration
+	Password                   string
+	RawCellValue               bool
+	SkipRows                   int
+	TrackChanges               bool
+	ChangeJournalSize          int
+	TruncateCellValue          bool
+	TrimTrailingEmptyCells     bool
+	BlankCellValue             string
+	CalcFormulaOnRead          bool
+	FormulaTextOnRead          bool
+	SkipHiddenCols             bool
+	SkipHiddenRows             bool
+	SkipFilteredRows           bool
+	IgnoreInvalidCellRef       bool
+	CellValueTransformer       func(cell string, meta CellMeta, value string) (string, error)
+	UnknownFunctionAsNameError bool
+	StripVBAProject            bool
+	StrictRowLimit             bool
+	UnzipSizeLimit             int64
+	UnzipXMLSizeLimit          int64
+	ShortDatePattern           string
+	LongDatePattern            string
+	LongTimePattern            string
+	CultureInfo                CultureName
 }
 
 // OpenFile take the name of a spreadsheet file and returns a populated
@@ -185,6 +329,7 @@ func OpenReader(r io.Reader, opts ...Options) (*File, error) {
 	if err = f.checkOpenReaderOptions(); err != nil {
 		return nil, err
 	}
+	f.prepareChangeJournal()
 	if bytes.Contains(b, oleIdentifier) {
 		if b, err = Decrypt(b, f.options); err != nil {
 			return nil, ErrWorkbookFileFormat
@@ -228,6 +373,25 @@ func (f *File) getOptions(opts ...Options) *Options {
 	return options
 }
 
+// getCallOptions provides a function to get the options specified by the
+// function call without falling back to the options for opening the
+// workbook, which is used for the options only take effect on each call.
+func getCallOptions(opts ...Options) Options {
+	var options Options
+	for _, opt := range opts {
+		options = opt
+	}
+	return options
+}
+
+// prepareChangeJournal provides a function to initialize the change journal
+// if the TrackChanges option enabled.
+func (f *File) prepareChangeJournal() {
+	if f.options.TrackChanges {
+		f.journal = &changeJournal{size: max(f.options.ChangeJournalSize, 0)}
+	}
+}
+
 // CharsetTranscoder Set user defined codepage transcoder function for open
 // workbook from non UTF-8 encoding.
 func (f *File) CharsetTranscoder(fn charsetTranscoderFn) *File { f.CharsetReader = fn; return f }
@@ -241,12 +405,27 @@ func (f *File) xmlNewDecoder(rdr io.Reader) (ret *xml.Decoder) {
 
 // setDefaultTimeStyle provides a function to set default numbers format for
 // time.Time type cell value by given worksheet name, cell reference and
-// number format code.
+// number format code. The date and time number format inherited from the
+// row or column style will be kept.
 func (f *File) setDefaultTimeStyle(sheet, cell string, format int) error {
 	styleIdx, err := f.GetCellStyle(sheet, cell)
 	if err != nil {
 		return err
 	}
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return err
+	}
+	col, row, err := CellNameToCoordinates(cell)
+	if err != nil {
+		return err
+	}
+	ws.mu.Lock()
+	inherited := ws.prepareCellStyle(col, row, 0) == styleIdx
+	ws.mu.Unlock()
+	if inherited && f.isDateTimeNumFmt(styleIdx) {
+		return err
+	}
 	if styleIdx == 0 {
 		styleIdx, _ = f.NewStyle(&Style{NumFmt: format})
 	} else {
@@ -273,6 +452,7 @@ func (f *File) workSheetReader(sheet string) (ws *xlsxWorksheet, err error) {
 	}
 	if worksheet, ok := f.Sheet.Load(name); ok && worksheet != nil {
 		ws = worksheet.(*xlsxWorksheet)
+		ws.generation.Add(1)
 		return
 	}
 	for _, sheetType := range []string{"xl/chartsheets", "xl/dialogsheet", "xl/macrosheet"} {
@@ -296,22 +476,54 @@ func (f *File) workSheetReader(sheet string) (ws *xlsxWorksheet, err error) {
 	}
 	err = nil
 	if _, ok = f.checked.Load(name); !ok {
+		if f.options != nil && f.options.StrictRowLimit {
+			for _, r := range ws.SheetData.Row {
+				if r.R > TotalRows {
+					err = ErrMaxRows
+					return
+				}
+			}
+		}
 		ws.checkSheet()
 		if err = ws.checkRow(); err != nil {
 			return
 		}
 		f.checked.Store(name, true)
 	}
+	ws.generation.Add(1)
 	f.Sheet.Store(name, ws)
 	return
 }
 
+// flushWorksheet provides a function to save the worksheet which has been
+// loaded into the package by given worksheet XML path before reading the
+// worksheet XML directly. Each call of the workSheetReader function counts as
+// a modification of the worksheet, so the worksheet will be serialized again
+// only if it has been accessed since the last serialization, otherwise the
+// previously serialized worksheet XML will be reused.
+func (f *File) flushWorksheet(name string) {
+	if worksheet, ok := f.Sheet.Load(name); ok && worksheet != nil {
+		ws := worksheet.(*xlsxWorksheet)
+		ws.mu.Lock()
+		defer ws.mu.Unlock()
+		if generation := ws.generation.Load(); generation != ws.flushedGeneration {
+			output, _ := xml.Marshal(ws)
+			f.saveFileList(name, f.replaceNameSpaceBytes(name, output))
+			ws.flushedGeneration = generation
+		}
+	}
+}
+
 // checkSheet provides a function to fill each row element and make that is
-// continuous in a worksheet of XML.
+// continuous in a worksheet of XML. The rows will be sorted in ascending order,
+// and the cells of the duplicate row elements will be merged into a single row,
+// the later cells take precedence. The row elements with a row number exceeds
+// the maximum limit will be ignored.
 func (ws *xlsxWorksheet) checkSheet() {
 	var (
 		row        int
 		r0Rows     []xlsxRow
+		rows       = map[int]int{}
 		lastRowNum = func(r xlsxRow) int {
 			var num int
 			for _, cell := range r.C {
@@ -326,7 +538,18 @@ func (ws *xlsxWorksheet) checkSheet() {
 	)
 	for i := 0; i < len(ws.SheetData.Row); i++ {
 		r := ws.SheetData.Row[i]
-		if r.R == 0 || r.R == row {
+		if r.R > TotalRows {
+			ws.SheetData.Row = append(ws.SheetData.Row[:i], ws.SheetData.Row[i+1:]...)
+			i--
+			continue
+		}
+		if idx, ok := rows[r.R]; ok && r.R > 0 {
+			ws.SheetData.Row[idx].merge(r)
+			ws.SheetData.Row = append(ws.SheetData.Row[:i], ws.SheetData.Row[i+1:]...)
+			i--
+			continue
+		}
+		if r.R <= 0 || r.R == row {
 			num := lastRowNum(r)
 			if num > row {
 				row = num
@@ -340,7 +563,8 @@ func (ws *xlsxWorksheet) checkSheet() {
 			i--
 			continue
 		}
-		if r.R != 0 && r.R > row {
+		rows[r.R] = i
+		if r.R > row {
 			row = r.R
 		}
 	}
@@ -362,6 +586,52 @@ func (ws *xlsxWorksheet) checkSheet() {
 	}
 }
 
+// merge provides a function to merge the cells and attributes of the
+// duplicate row element into the row by the cell reference, the cells and the
+// attributes specified in the duplicate row take precedence.
+func (r *xlsxRow) merge(dup xlsxRow) {
+	var (
+		cols  []int
+		cells = map[int]xlsxC{}
+	)
+	for _, row := range []xlsxRow{*r, dup} {
+		for i, cell := range row.C {
+			col := i + 1
+			if cell.R == "" {
+				cell.R, _ = CoordinatesToCellName(col, r.R)
+			} else if c, _, err := CellNameToCoordinates(cell.R); err == nil {
+				col = c
+			}
+			if _, ok := cells[col]; !ok {
+				cols = append(cols, col)
+			}
+			cells[col] = cell
+		}
+	}
+	sort.Ints(cols)
+	r.C = make([]xlsxC, 0, len(cols))
+	for _, col := range cols {
+		r.C = append(r.C, cells[col])
+	}
+	if dup.Spans != "" {
+		r.Spans = dup.Spans
+	}
+	if dup.S != 0 || dup.CustomFormat {
+		r.S, r.CustomFormat = dup.S, dup.CustomFormat
+	}
+	if dup.Ht != nil {
+		r.Ht, r.CustomHeight = dup.Ht, dup.CustomHeight
+	}
+	if dup.OutlineLevel != 0 {
+		r.OutlineLevel = dup.OutlineLevel
+	}
+	r.Hidden = r.Hidden || dup.Hidden
+	r.Collapsed = r.Collapsed || dup.Collapsed
+	r.ThickTop = r.ThickTop || dup.ThickTop
+	r.ThickBot = r.ThickBot || dup.ThickBot
+	r.Ph = r.Ph || dup.Ph
+}
+
 // checkSheetR0 handle the row element with r="0" attribute, cells in this row
 // could be disorderly, the cell in this row can be used as the value of
 // which cell is empty in the normal rows.
@@ -566,18 +836,25 @@ func (f *File) AddVBAProject(file []byte) error {
 }
 
 // setContentTypePartProjectExtensions provides a function to set the content
-// type for relationship parts and the main document part.
+// type for relationship parts and the main document part. The VBA project
+// content type will be kept only for the macro-enabled main document part.
 func (f *File) setContentTypePartProjectExtensions(contentType string) error {
-	var ok bool
+	macroEnabled := contentType == ContentTypeMacro || contentType == ContentTypeTemplateMacro || contentType == ContentTypeAddinMacro
+	if !macroEnabled {
+		if err := f.removeVBAProject(); err != nil {
+			return err
+		}
+	}
 	content, err := f.contentTypesReader()
 	if err != nil {
 		return err
 	}
 	content.mu.Lock()
 	defer content.mu.Unlock()
-	for _, v := range content.Defaults {
+	binIdx := -1
+	for idx, v := range content.Defaults {
 		if v.Extension == "bin" {
-			ok = true
+			binIdx = idx
 		}
 	}
 	for idx, o := range content.Overrides {
@@ -585,12 +862,44 @@ func (f *File) setContentTypePartProjectExtensions(contentType string) error {
 			content.Overrides[idx].ContentType = contentType
 		}
 	}
-	if !ok {
+	if macroEnabled && binIdx == -1 {
 		content.Defaults = append(content.Defaults, xlsxDefault{
 			Extension:   "bin",
 			ContentType: ContentTypeVBA,
 		})
 	}
+	if !macroEnabled && binIdx != -1 && content.Defaults[binIdx].ContentType == ContentTypeVBA {
+		content.Defaults = append(content.Defaults[:binIdx], content.Defaults[binIdx+1:]...)
+	}
+	return err
+}
+
+// removeVBAProject provides a function to remove the VBA project part and
+// relationship from the workbook for saving it without macros enabled, it
+// will return an error if the workbook contains a VBA project and the
+// StripVBAProject option is not enabled.
+func (f *File) removeVBAProject() error {
+	rels, err := f.relsReader(f.getWorkbookRelsPath())
+	if err != nil || rels == nil {
+		return err
+	}
+	rels.mu.Lock()
+	defer rels.mu.Unlock()
+	for idx, rel := range rels.Relationships {
+		if rel.Type != SourceRelationshipVBAProject {
+			continue
+		}
+		if f.options == nil || !f.options.StripVBAProject {
+			return ErrMacroFileFormat
+		}
+		rels.Relationships = append(rels.Relationships[:idx], rels.Relationships[idx+1:]...)
+		target := strings.TrimPrefix(rel.Target, "/")
+		if !strings.HasPrefix(rel.Target, "/") {
+			target = path.Join(path.Dir(f.getWorkbookPath()), target)
+		}
+		f.Pkg.Delete(target)
+		return f.removeContentTypesPart(ContentTypeVBA, "/"+target)
+	}
 	return err
 }
 
@@ -71,7 +71,7 @@ func TestOpenFile(t *testing.T) {
 
 	assert.NoError(t, f.SetCellStr("Sheet2", "C11", "Knowns"))
 	// Test max characters in a cell
-	assert.NoError(t, f.SetCellStr("Sheet2", "D11", strings.Repeat("c", TotalCellChars+2)))
+	assert.Equal(t, ErrCellCharsLength, f.SetCellStr("Sheet2", "D11", strings.Repeat("c", TotalCellChars+2)))
 	_, err = f.NewSheet(":\\/?*[]Maximum 31 characters allowed in sheet title.")
 	assert.EqualError(t, err, ErrSheetNameLength.Error())
 	// Test set worksheet name with illegal name
@@ -469,6 +469,81 @@ func TestSetCellHyperLink(t *testing.T) {
 	assert.Error(t, f.SetCellHyperLink("Sheet1", "B2", "", "None"), newCellNameToCoordinatesError("A", newInvalidCellNameError("A")))
 }
 
+func TestSetRangeHyperLink(t *testing.T) {
+	f := NewFile()
+	display, tooltip := "Excelize", "Excelize on GitHub"
+	assert.NoError(t, f.SetCellHyperLink("Sheet1", "C2", "Sheet1!A1", "Location"))
+	assert.NoError(t, f.SetCellHyperLink("Sheet1", "E2", "Sheet1!A1", "Location"))
+	assert.NoError(t, f.SetRangeHyperLink("Sheet1", "D2:B2", "https://github.com/xuri/excelize", "External", HyperlinkOpts{
+		Display: &display, Tooltip: &tooltip,
+	}))
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	assert.Equal(t, []xlsxHyperlink{{Ref: "E2", Location: "Sheet1!A1"}, {Ref: "B2:D2", Display: display, Tooltip: tooltip, RID: "rId1"}}, ws.(*xlsxWorksheet).Hyperlinks.Hyperlink)
+	for _, cell := range []string{"B2", "C2", "D2"} {
+		ok, link, err := f.GetCellHyperLinkDetails("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.True(t, ok)
+		assert.Equal(t, HyperlinkDetails{Ref: "B2:D2", Target: "https://github.com/xuri/excelize", Display: display, Tooltip: tooltip, External: true}, link)
+		ok, target, err := f.GetCellHyperLink("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.True(t, ok)
+		assert.Equal(t, "https://github.com/xuri/excelize", target)
+	}
+	ok, link, err := f.GetCellHyperLinkDetails("Sheet1", "E2")
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, HyperlinkDetails{Ref: "E2", Location: "Sheet1!A1"}, link)
+	ok, link, err = f.GetCellHyperLinkDetails("Sheet1", "A2")
+	assert.NoError(t, err)
+	assert.False(t, ok)
+	assert.Equal(t, HyperlinkDetails{}, link)
+	// Test update the range hyperlink
+	assert.NoError(t, f.SetRangeHyperLink("Sheet1", "B2:D2", "https://github.com", "External"))
+	_, target, err := f.GetCellHyperLink("Sheet1", "C2")
+	assert.NoError(t, err)
+	assert.Equal(t, "https://github.com", target)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestSetRangeHyperLink.xlsx")))
+	assert.NoError(t, f.Close())
+	f, err = OpenFile(filepath.Join("test", "TestSetRangeHyperLink.xlsx"))
+	assert.NoError(t, err)
+	ok, link, err = f.GetCellHyperLinkDetails("Sheet1", "D2")
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, HyperlinkDetails{Ref: "B2:D2", Target: "https://github.com", External: true}, link)
+	// Test set range hyperlink with a single cell range
+	assert.NoError(t, f.SetRangeHyperLink("Sheet1", "A1:A1", "Sheet1!A2", "Location"))
+	ok, link, err = f.GetCellHyperLinkDetails("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, HyperlinkDetails{Ref: "A1", Location: "Sheet1!A2"}, link)
+	// Test remove the hyperlinks overlapping with the range
+	assert.NoError(t, f.SetRangeHyperLink("Sheet1", "A1:C1", "", "None"))
+	assert.NoError(t, f.SetRangeHyperLink("Sheet1", "D2:E3", "", "None"))
+	ok, _, err = f.GetCellHyperLinkDetails("Sheet1", "B2")
+	assert.NoError(t, err)
+	assert.False(t, ok)
+	ws, ok = f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	assert.Nil(t, ws.(*xlsxWorksheet).Hyperlinks)
+	assert.NoError(t, f.SetRangeHyperLink("Sheet1", "A1:B2", "", "None"))
+	// Test set range hyperlink with invalid range reference
+	assert.Equal(t, ErrParameterInvalid, f.SetRangeHyperLink("Sheet1", "A1", "Sheet1!A2", "Location"))
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), f.SetRangeHyperLink("Sheet1", "A:B", "Sheet1!A2", "Location"))
+	// Test set range hyperlink with invalid link type
+	assert.Equal(t, newInvalidLinkTypeError(""), f.SetRangeHyperLink("Sheet1", "A1:B2", "Sheet1!A2", ""))
+	// Test set range hyperlink on not exists worksheet
+	assert.EqualError(t, f.SetRangeHyperLink("SheetN", "A1:B2", "Sheet1!A2", "Location"), "sheet SheetN does not exist")
+	// Test set range hyperlink with invalid hyperlink reference in the worksheet
+	ws.(*xlsxWorksheet).Hyperlinks = &xlsxHyperlinks{Hyperlink: []xlsxHyperlink{{Ref: "A:A"}}}
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), f.SetRangeHyperLink("Sheet1", "A1:B2", "Sheet1!A2", "Location"))
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), f.SetRangeHyperLink("Sheet1", "A1:B2", "", "None"))
+	// Test get cell hyperlink details with invalid cell reference
+	_, _, err = f.GetCellHyperLinkDetails("Sheet1", "A")
+	assert.Equal(t, newInvalidCellNameError("A"), err)
+	assert.NoError(t, f.Close())
+}
+
 func TestGetCellHyperLink(t *testing.T) {
 	f, err := OpenFile(filepath.Join("test", "Book1.xlsx"))
 	assert.NoError(t, err)
@@ -1050,6 +1125,36 @@ func TestCopySheet(t *testing.T) {
 	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestCopySheet.xlsx")))
 }
 
+func TestCopySheetTabColor(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetSheetProps("Sheet1", &SheetPropsOptions{
+		CodeName: stringPtr("code"), TabColorTheme: intPtr(4), TabColorTint: float64Ptr(-0.249977111117893),
+	}))
+	idx, err := f.NewSheet("Sheet2")
+	assert.NoError(t, err)
+	assert.NoError(t, f.CopySheet(0, idx))
+	// Test the tab color of the copied worksheet independent of the source
+	assert.NoError(t, f.SetSheetProps("Sheet1", &SheetPropsOptions{TabColorTheme: intPtr(5)}))
+	path := filepath.Join("test", "TestCopySheetTabColor.xlsx")
+	assert.NoError(t, f.SaveAs(path))
+	assert.NoError(t, f.Close())
+
+	f, err = OpenFile(path)
+	assert.NoError(t, err)
+	opts, err := f.GetSheetProps("Sheet2")
+	assert.NoError(t, err)
+	assert.Equal(t, "code", *opts.CodeName)
+	assert.Equal(t, 4, *opts.TabColorTheme)
+	assert.Equal(t, -0.249977111117893, *opts.TabColorTint)
+	clr, err := f.GetSheetTabColor("Sheet2")
+	assert.NoError(t, err)
+	assert.Equal(t, ThemeColor("5B9BD5", -0.249977111117893), clr)
+	opts, err = f.GetSheetProps("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, 5, *opts.TabColorTheme)
+	assert.NoError(t, f.Close())
+}
+
 func TestCopySheetError(t *testing.T) {
 	f, err := prepareTestBook1()
 	assert.NoError(t, err)
@@ -1319,8 +1424,82 @@ func TestSetSheetCol(t *testing.T) {
 	assert.EqualError(t, f.SetSheetCol("Sheet:1", "A1", &[]interface{}{nil}), ErrSheetNameInvalid.Error())
 	assert.EqualError(t, f.SetSheetCol("Sheet1", "B27", []interface{}{}), ErrParameterInvalid.Error())
 	assert.EqualError(t, f.SetSheetCol("Sheet1", "B27", &f), ErrParameterInvalid.Error())
+	// Test set worksheet column values exceeds the maximum rows limit
+	assert.Equal(t, ErrMaxRows, f.SetSheetCol("Sheet1", "C1048575", &[]interface{}{1, 2, 3}))
+	val, err := f.GetCellValue("Sheet1", "C1048575")
+	assert.NoError(t, err)
+	assert.Empty(t, val)
+	assert.NoError(t, f.SetSheetCol("Sheet1", "C1048575", &[]int{1, 2}))
 	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestSetSheetCol.xlsx")))
 	assert.NoError(t, f.Close())
+
+	// Test set worksheet column values are the same with the SetCellValue function
+	values := []interface{}{
+		"text", []byte("bytes"), int8(-8), 42, uint16(16), uint64(64), float32(1.5), 3.25,
+		math.NaN(), math.Inf(-1), true, false, time.Date(2024, 1, 2, 10, 30, 0, 0, time.UTC),
+		time.Date(1800, 1, 1, 0, 0, 0, 0, time.UTC), 90 * time.Minute, struct{ Name string }{"x"}, "text",
+	}
+	f = NewFile()
+	style, err := f.NewStyle(&Style{Font: &Font{Bold: true}})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetColStyle("Sheet1", "A:B", style))
+	for i, value := range values {
+		assert.NoError(t, f.SetCellValue("Sheet1", fmt.Sprintf("A%d", i+1), value))
+	}
+	assert.NoError(t, f.SetSheetCol("Sheet1", "B1", &values))
+	for row := 1; row <= len(values); row++ {
+		expected, err := f.GetCellValue("Sheet1", fmt.Sprintf("A%d", row), Options{RawCellValue: true})
+		assert.NoError(t, err)
+		actual, err := f.GetCellValue("Sheet1", fmt.Sprintf("B%d", row), Options{RawCellValue: true})
+		assert.NoError(t, err)
+		assert.Equal(t, expected, actual, row)
+		expected, err = f.GetCellValue("Sheet1", fmt.Sprintf("A%d", row))
+		assert.NoError(t, err)
+		actual, err = f.GetCellValue("Sheet1", fmt.Sprintf("B%d", row))
+		assert.NoError(t, err)
+		assert.Equal(t, expected, actual, row)
+	}
+	// Test set worksheet column values skip the nil values
+	assert.NoError(t, f.SetCellFormula("Sheet1", "B2", "A1"))
+	assert.NoError(t, f.SetSheetCol("Sheet1", "B1", &[]interface{}{nil, nil, "new"}))
+	formula, err := f.GetCellFormula("Sheet1", "B2")
+	assert.NoError(t, err)
+	assert.Equal(t, "A1", formula)
+	val, err = f.GetCellValue("Sheet1", "B3")
+	assert.NoError(t, err)
+	assert.Equal(t, "new", val)
+	// Test set worksheet row values clear the cells for the nil values
+	assert.NoError(t, f.SetSheetRow("Sheet1", "B2", &[]interface{}{nil}))
+	formula, err = f.GetCellFormula("Sheet1", "B2")
+	assert.NoError(t, err)
+	assert.Empty(t, formula)
+	// Test set worksheet column values on the merged cells
+	assert.NoError(t, f.MergeCell("Sheet1", "D1", "E2"))
+	assert.NoError(t, f.SetSheetCol("Sheet1", "E1", &[]string{"merged", "below"}))
+	val, err = f.GetCellValue("Sheet1", "D1")
+	assert.NoError(t, err)
+	assert.Equal(t, "below", val)
+	// Test set worksheet column values on not exists worksheet
+	assert.EqualError(t, f.SetSheetCol("SheetN", "A1", &values), "sheet SheetN does not exist")
+	// Test set worksheet column values with the TrackChanges option enabled
+	g := NewFile(Options{TrackChanges: true})
+	assert.NoError(t, g.SetSheetCol("Sheet1", "A1", &[]interface{}{"a", nil, 1}))
+	changes, err := g.GetChangeJournal()
+	assert.NoError(t, err)
+	assert.Len(t, changes, 2)
+	assert.Equal(t, "A3", changes[1].Cell)
+	assert.NoError(t, g.Close())
+	// Test set worksheet column values with unsupported charset workbook and
+	// shared strings table
+	f.WorkBook = nil
+	f.Pkg.Store(defaultXMLPathWorkbook, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.SetSheetCol("Sheet1", "A1", &values), "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+	f = NewFile()
+	f.SharedStrings = nil
+	f.Pkg.Store(defaultXMLPathSharedStrings, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.SetSheetCol("Sheet1", "A1", &values), "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
 }
 
 func TestSetSheetRow(t *testing.T) {
@@ -1431,6 +1610,28 @@ func TestProtectSheet(t *testing.T) {
 	assert.EqualError(t, f.ProtectSheet("Sheet:1", nil), ErrSheetNameInvalid.Error())
 }
 
+func TestProtectSheetAllowOutlines(t *testing.T) {
+	f := NewFile()
+	sheetName := f.GetSheetName(0)
+	assert.NoError(t, f.SetRowOutlineLevel(sheetName, 2, 1))
+	assert.NoError(t, f.SetRowVisible(sheetName, 2, false))
+	assert.NoError(t, f.SetColOutlineLevel(sheetName, "B", 1))
+	assert.NoError(t, f.SetColVisible(sheetName, "B", false))
+	assert.NoError(t, f.ProtectSheetAllowOutlines(sheetName, "password"))
+	ws, err := f.workSheetReader(sheetName)
+	assert.NoError(t, err)
+	assert.Equal(t, "83AF", ws.SheetProtection.Password)
+	output, err := xml.Marshal(ws.SheetProtection)
+	assert.NoError(t, err)
+	assert.Equal(t, `<sheetProtection password="83AF" sheet="true" objects="true" scenarios="true" formatCells="true" formatColumns="false" formatRows="false" insertColumns="true" insertRows="true" insertHyperlinks="true" deleteColumns="true" deleteRows="true" selectLockedCells="false" sort="true" autoFilter="true" pivotTables="true" selectUnlockedCells="false"></sheetProtection>`, string(output))
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestProtectSheetAllowOutlines.xlsx")))
+	assert.NoError(t, f.UnprotectSheet(sheetName, "password"))
+	// Test protect worksheet allow outlines with invalid sheet name
+	assert.EqualError(t, f.ProtectSheetAllowOutlines("Sheet:1", "password"), ErrSheetNameInvalid.Error())
+	// Test protect worksheet allow outlines with not exists worksheet
+	assert.EqualError(t, f.ProtectSheetAllowOutlines("SheetN", "password"), "sheet SheetN does not exist")
+}
+
 func TestUnprotectSheet(t *testing.T) {
 	f, err := OpenFile(filepath.Join("test", "Book1.xlsx"))
 	assert.NoError(t, err)
@@ -1548,6 +1749,70 @@ func TestAddVBAProject(t *testing.T) {
 	assert.EqualError(t, f.AddVBAProject(file), "XML syntax error on line 1: invalid UTF-8")
 }
 
+func TestSaveAsVBAProject(t *testing.T) {
+	vbaProject, err := os.ReadFile(filepath.Join("test", "vbaProject.bin"))
+	assert.NoError(t, err)
+	hasVBADefault := func(f *File) bool {
+		content, err := f.contentTypesReader()
+		assert.NoError(t, err)
+		for _, v := range content.Defaults {
+			if v.Extension == "bin" && v.ContentType == ContentTypeVBA {
+				return true
+			}
+		}
+		return false
+	}
+	workbookContentType := func(f *File) string {
+		content, err := f.contentTypesReader()
+		assert.NoError(t, err)
+		for _, o := range content.Overrides {
+			if o.PartName == "/xl/workbook.xml" {
+				return o.ContentType
+			}
+		}
+		return ""
+	}
+	for _, ext := range []string{".xlsm", ".xlsx"} {
+		for _, macros := range []bool{true, false} {
+			f := NewFile()
+			if macros {
+				assert.NoError(t, f.AddVBAProject(vbaProject))
+			}
+			name := filepath.Join("test", fmt.Sprintf("TestSaveAsVBAProject%t%s", macros, ext))
+			if ext == ".xlsx" && macros {
+				// Test save workbook contains VBA project without macros enabled
+				assert.Equal(t, ErrMacroFileFormat, f.SaveAs(name))
+				assert.NoError(t, f.SaveAs(name, Options{StripVBAProject: true}))
+				_, ok := f.Pkg.Load("xl/vbaProject.bin")
+				assert.False(t, ok)
+				rels, err := f.relsReader(defaultXMLPathWorkbookRels)
+				assert.NoError(t, err)
+				for _, rel := range rels.Relationships {
+					assert.NotEqual(t, SourceRelationshipVBAProject, rel.Type)
+				}
+			} else {
+				assert.NoError(t, f.SaveAs(name))
+			}
+			assert.Equal(t, supportedContentTypes[ext], workbookContentType(f))
+			assert.Equal(t, ext == ".xlsm", hasVBADefault(f))
+			assert.NoError(t, f.Close())
+
+			f, err = OpenFile(name)
+			assert.NoError(t, err)
+			_, ok := f.Pkg.Load("xl/vbaProject.bin")
+			assert.Equal(t, ext == ".xlsm" && macros, ok)
+			assert.Equal(t, supportedContentTypes[ext], workbookContentType(f))
+			assert.NoError(t, f.Close())
+		}
+	}
+	// Test save workbook with unsupported charset workbook relationships
+	f := NewFile()
+	f.Path = filepath.Join("test", "TestSaveAsVBAProject.xlsx")
+	f.Relationships.Delete(defaultXMLPathWorkbookRels)
+	f.Pkg.Store(defaultXMLPathWorkbookRels, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.Write(io.Discard), "XML syntax error on line 1: invalid UTF-8")
+}
+
 func TestContentTypesReader(t *testing.T) {
 	// Test unsupported charset
 	f := NewFile()
@@ -1566,6 +1831,35 @@ func TestWorkbookReader(t *testing.T) {
 	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
 }
 
+func TestFlushWorksheet(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetCellValue("Sheet1", "A1", 1))
+	rows, err := f.GetRows("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{{"1"}}, rows)
+	// Test the serialized worksheet will be reused if the worksheet has not
+	// been accessed since the last serialization
+	f.Pkg.Store("xl/worksheets/sheet1.xml", []byte(`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData><row r="1"><c r="A1"><v>2</v></c></row></sheetData></worksheet>`))
+	rows, err = f.GetRows("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{{"2"}}, rows)
+	cols, err := f.GetCols("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{{"2"}}, cols)
+	result, err := f.SearchSheet("Sheet1", "2")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"A1"}, result)
+	// Test the worksheet will be serialized again after modified
+	assert.NoError(t, f.SetCellValue("Sheet1", "B1", 3))
+	rows, err = f.GetRows("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{{"1", "3"}}, rows)
+	cols, err = f.GetCols("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{{"1"}, {"3"}}, cols)
+	assert.NoError(t, f.Close())
+}
+
 func TestWorkSheetReader(t *testing.T) {
 	// Test unsupported charset
 	f := NewFile()
@@ -1582,6 +1876,44 @@ func TestWorkSheetReader(t *testing.T) {
 	f.checked = sync.Map{}
 	_, err = f.workSheetReader("Sheet1")
 	assert.NoError(t, err)
+
+	// Test read worksheet with negative and exceeds maximum limit row numbers
+	f = NewFile()
+	f.Sheet.Delete("xl/worksheets/sheet1.xml")
+	f.Pkg.Store("xl/worksheets/sheet1.xml", []byte(`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData><row r="-1"><c><v>1</v></c></row></sheetData></worksheet>`))
+	f.checked = sync.Map{}
+	rows, err := f.GetRows("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{{"1"}}, rows)
+	f.Sheet.Delete("xl/worksheets/sheet1.xml")
+	f.Pkg.Store("xl/worksheets/sheet1.xml", []byte(fmt.Sprintf(`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData><row r="%d"/></sheetData></worksheet>`, TotalRows+1)))
+	f.checked = sync.Map{}
+	_, err = f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	// Test read worksheet with the row number exceeds the maximum limit
+	f.Sheet.Delete("xl/worksheets/sheet1.xml")
+	f.Pkg.Store("xl/worksheets/sheet1.xml", []byte(fmt.Sprintf(`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData><row r="1"><c r="A1"><v>1</v></c></row><row r="%d"><c r="A1"><v>2</v></c></row></sheetData></worksheet>`, TotalRows+1)))
+	f.checked = sync.Map{}
+	rows, err = f.GetRows("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{{"1"}}, rows)
+	ws, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, ws.SheetData.Row, 1)
+	f.Sheet.Delete("xl/worksheets/sheet1.xml")
+	f.checked = sync.Map{}
+	f.options.StrictRowLimit = true
+	iter, err := f.Rows("Sheet1")
+	assert.NoError(t, err)
+	assert.True(t, iter.Next())
+	cells, err := iter.Columns()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"1"}, cells)
+	assert.False(t, iter.Next())
+	assert.Equal(t, ErrMaxRows, iter.Error())
+	assert.NoError(t, iter.Close())
+	_, err = f.workSheetReader("Sheet1")
+	assert.Equal(t, ErrMaxRows, err)
 }
 
 func TestRelsReader(t *testing.T) {
@@ -1744,3 +2076,102 @@ func BenchmarkOpenFile(b *testing.B) {
 		}
 	}
 }
+
+func FuzzOpenReaderWorksheet(f *testing.F) {
+	for _, seed := range []string{
+		`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData><row r="1"><c r="A1" t="s"><v>0</v></c><c r="B1" s="1"><v>1</v></c></row></sheetData></worksheet>`,
+		`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><cols><col min="1" max="2" width="12" style="1"/></cols><sheetData><row r="0"><c r="A0" t="s"><v>-1</v></c></row><row r="2" s="99" customFormat="1"><c r="C2" s="-1"><v>1</v></c></row></sheetData></worksheet>`,
+		`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData><row r="1048577"><c r="XFE1048577" t="s"><v>99</v></c></row></sheetData><mergeCells><mergeCell ref="A1:B2"/></mergeCells></worksheet>`,
+		`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData><row r="-3" s="-2" customFormat="1"><c s="-1"><v>1</v></c></row></sheetData><mergeCells><mergeCell ref=""/><mergeCell ref="B2:A1"/></mergeCells></worksheet>`,
+		`<c>`,
+	} {
+		f.Add([]byte(seed))
+	}
+	f.Fuzz(func(t *testing.T, data []byte) {
+		fuzzOpenReader(t, "xl/worksheets/sheet1.xml", data)
+	})
+}
+
+func FuzzOpenReaderSharedStrings(f *testing.F) {
+	for _, seed := range []string{
+		`<sst xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" count="1" uniqueCount="1"><si><t>a</t></si></sst>`,
+		`<sst xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><si><r><rPr><b/></rPr><t>a</t></r><r><t>b</t></r></si></sst>`,
+		`<sst xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"></sst>`,
+	} {
+		f.Add([]byte(seed))
+	}
+	f.Fuzz(func(t *testing.T, data []byte) {
+		fuzzOpenReader(t, defaultXMLPathSharedStrings, data)
+	})
+}
+
+func FuzzOpenReaderStyles(f *testing.F) {
+	for _, seed := range []string{
+		`<styleSheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><fonts count="1"><font><sz val="11"/></font></fonts><fills count="1"><fill><patternFill patternType="none"/></fill></fills><borders count="1"><border/></borders><cellXfs count="2"><xf/><xf numFmtId="14" fontId="0" fillId="0" borderId="0"/></cellXfs></styleSheet>`,
+		`<styleSheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><cellXfs count="2"><xf/><xf numFmtId="-1" fontId="-1" fillId="-1" borderId="-1"/></cellXfs></styleSheet>`,
+		`<styleSheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><numFmts><numFmt numFmtId="164" formatCode="[$-F800]"/></numFmts><cellXfs><xf numFmtId="164" fontId="9" fillId="9" borderId="9"/><xf numFmtId="164"/></cellXfs></styleSheet>`,
+	} {
+		f.Add([]byte(seed))
+	}
+	f.Fuzz(func(t *testing.T, data []byte) {
+		fuzzOpenReader(t, defaultXMLPathStyles, data)
+	})
+}
+
+// fuzzOpenReader replaces the given part of a prepared workbook with the
+// fuzzed data, opens it and exercises the common read paths. Any returned
+// errors are acceptable, only panics fail the fuzz target.
+func fuzzOpenReader(t *testing.T, partName string, data []byte) {
+	f := NewFile()
+	assert.NoError(t, f.SetCellValue("Sheet1", "A1", "a"))
+	assert.NoError(t, f.SetCellValue("Sheet1", "B1", 1))
+	style, err := f.NewStyle(&Style{NumFmt: 14, Font: &Font{Bold: true}})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellStyle("Sheet1", "B1", "B1", style))
+	source, err := f.WriteToBuffer()
+	assert.NoError(t, err)
+	assert.NoError(t, f.Close())
+
+	zr, err := zip.NewReader(bytes.NewReader(source.Bytes()), int64(source.Len()))
+	assert.NoError(t, err)
+	buf := new(bytes.Buffer)
+	zw := zip.NewWriter(buf)
+	for _, item := range zr.File {
+		if item.Name == partName {
+			continue
+		}
+		assert.NoError(t, zw.Copy(item))
+	}
+	fi, err := zw.Create(partName)
+	assert.NoError(t, err)
+	_, err = fi.Write(data)
+	assert.NoError(t, err)
+	assert.NoError(t, zw.Close())
+
+	if f, err = OpenReader(buf); err != nil {
+		return
+	}
+	defer f.Close()
+	_, _ = f.GetRows("Sheet1")
+	_, _ = f.GetCols("Sheet1")
+	_, _ = f.GetMergeCells("Sheet1")
+	if rows, err := f.Rows("Sheet1"); err == nil {
+		for rows.Next() {
+			_, _ = rows.Columns()
+		}
+		_ = rows.Close()
+	}
+	for _, cell := range []string{"A1", "B1", "C2"} {
+		_, _ = f.GetCellValue("Sheet1", cell)
+		_, _ = f.GetCellType("Sheet1", cell)
+		if styleID, err := f.GetCellStyle("Sheet1", cell); err == nil {
+			_, _ = f.GetStyle(styleID)
+		}
+	}
+	for styleID := -1; styleID < 4; styleID++ {
+		_, _ = f.GetStyle(styleID)
+	}
+	_, _ = f.GetColWidth("Sheet1", "A")
+	_, _ = f.GetColStyle("Sheet1", "A")
+	_, _ = f.GetRowHeight("Sheet1", 1)
+}
@@ -53,6 +53,7 @@ func NewFile(opts ...Options) *File {
 	f.Sheet.Store("xl/worksheets/sheet1.xml", ws)
 	f.Theme, _ = f.themeReader()
 	f.options = f.getOptions(opts...)
+	f.prepareChangeJournal()
 	return f
 }
 
@@ -94,6 +95,7 @@ func (f *File) Close() error {
 	}
 	for _, stream := range f.streams {
 		_ = stream.rawData.Close()
+		_ = stream.colData.Close()
 	}
 	f.streams = nil
 	f.tempFiles.Range(func(k, v interface{}) bool {
@@ -211,7 +213,7 @@ func (f *File) writeToZip(zw *zip.Writer) error {
 			break
 		}
 		content, _ := f.Pkg.Load(path)
-		if n, err = fi.Write(content.([]byte)); n > math.MaxUint32 {
+		if n, err = fi.Write(f.replaceColRanges(path, content.([]byte))); n > math.MaxUint32 {
 			f.zip64Entries = append(f.zip64Entries, path)
 		}
 	}
@@ -0,0 +1,508 @@
+// Copyright 2016 - 2025 The excelize Authors. All rights reserved. Use of
+// this source code is governed by a BSD-style license that can be found in
+// the LICENSE file.
+//
+// Package excelize providing a set of functions that allow you to write to and
+// read from XLAM / XLSM / XLSX / XLTM / XLTX files. Supports reading and
+// writing spreadsheet documents generated by Microsoft Excel™ 2007 and later.
+// Supports complex components by high compatibility, and provided streaming
+// API for generating or reading data from a worksheet with huge amounts of
+// data. This library needs Go version 1.23 or later.
+
+package excelize
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode/utf16"
+)
+
+// absPathURLPattern matches the URL of the absolute path where the workbook
+// was last saved in the alternate content of the workbook.
+var absPathURLPattern = regexp.MustCompile(`absPath\b[^>]*\surl="([^"]*)"`)
+
+// InspectDocument provides a function to find the personal information,
+// hidden content and external dependencies in the workbook, like the
+// document inspector of the spreadsheet applications. The personal
+// information includes the document properties which identify the people or
+// organizations, such as the creator, last modified by, manager and company,
+// the authors of the comments, the persons of the threaded comments, the
+// printer settings of the worksheets, and the absolute path where the
+// workbook was last saved. The hidden content includes the hidden
+// worksheets, rows, columns and defined names, and the built-in defined
+// names will be skipped. The external dependencies are the same as the
+// GetExternalDependencies function returns. Use the StripDocument function to
+// remove them. For example, print the authors of the comments in the
+// workbook:
+//
+//	report, err := f.InspectDocument()
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	for _, item := range report.CommentAuthors {
+//	    fmt.Println(item.Sheet, item.Value)
+//	}
+func (f *File) InspectDocument() (InspectionReport, error) {
+	var (
+		report InspectionReport
+		err    error
+	)
+	if report.DocProps, err = f.inspectDocProps(); err != nil {
+		return report, err
+	}
+	if report.ThreadedCommentPersons, err = f.inspectPersons(); err != nil {
+		return report, err
+	}
+	wb, err := f.workbookReader()
+	if err != nil {
+		return report, err
+	}
+	wbPath := f.getWorkbookPath()
+	if match := absPathURLPattern.FindStringSubmatch(getAlternateContent(wb)); match != nil {
+		report.AbsolutePath = append(report.AbsolutePath, InspectionItem{Part: wbPath, Ref: "absPath", Value: match[1]})
+	}
+	sheets := f.GetSheetList()
+	for _, sheet := range f.getSheets() {
+		if sheet.State == "hidden" || sheet.State == "veryHidden" {
+			sheetXMLPath, _ := f.getSheetXMLPath(sheet.Name)
+			report.HiddenSheets = append(report.HiddenSheets, InspectionItem{Part: sheetXMLPath, Sheet: sheet.Name, Value: sheet.State})
+		}
+	}
+	if wb.DefinedNames != nil {
+		for _, dn := range wb.DefinedNames.DefinedName {
+			if !dn.Hidden || strings.HasPrefix(dn.Name, "_xlnm.") {
+				continue
+			}
+			item := InspectionItem{Part: wbPath, Ref: dn.Name, Value: dn.Data}
+			if dn.LocalSheetID != nil && *dn.LocalSheetID >= 0 && *dn.LocalSheetID < len(sheets) {
+				item.Sheet = sheets[*dn.LocalSheetID]
+			}
+			report.HiddenNames = append(report.HiddenNames, item)
+		}
+	}
+	if err = f.forEachWorksheet(nil, func(sheet string, ws *xlsxWorksheet) error {
+		return f.inspectWorksheet(sheet, ws, &report)
+	}); err != nil {
+		return report, err
+	}
+	report.ExternalDependencies, err = f.GetExternalDependencies()
+	return report, err
+}
+
+// inspectDocProps provides a function to get the document properties which
+// might identify the people or organizations in the core and application
+// properties parts.
+func (f *File) inspectDocProps() ([]InspectionItem, error) {
+	var items []InspectionItem
+	core := new(decodeCoreProperties)
+	if err := f.xmlNewDecoder(bytes.NewReader(namespaceStrictToTransitional(f.readXML(defaultXMLPathDocPropsCore)))).
+		Decode(core); err != nil && err != io.EOF {
+		return items, err
+	}
+	app := new(xlsxProperties)
+	if err := f.xmlNewDecoder(bytes.NewReader(namespaceStrictToTransitional(f.readXML(defaultXMLPathDocPropsApp)))).
+		Decode(app); err != nil && err != io.EOF {
+		return items, err
+	}
+	for _, prop := range []struct{ part, name, value string }{
+		{defaultXMLPathDocPropsCore, "title", core.Title},
+		{defaultXMLPathDocPropsCore, "subject", core.Subject},
+		{defaultXMLPathDocPropsCore, "creator", core.Creator},
+		{defaultXMLPathDocPropsCore, "keywords", core.Keywords},
+		{defaultXMLPathDocPropsCore, "description", core.Description},
+		{defaultXMLPathDocPropsCore, "lastModifiedBy", core.LastModifiedBy},
+		{defaultXMLPathDocPropsCore, "category", core.Category},
+		{defaultXMLPathDocPropsCore, "contentStatus", core.ContentStatus},
+		{defaultXMLPathDocPropsApp, "Manager", app.Manager},
+		{defaultXMLPathDocPropsApp, "Company", app.Company},
+	} {
+		if prop.value != "" {
+			items = append(items, InspectionItem{Part: prop.part, Ref: prop.name, Value: prop.value})
+		}
+	}
+	return items, nil
+}
+
+// inspectPersons provides a function to get the persons of the threaded
+// comments in the workbook.
+func (f *File) inspectPersons() ([]InspectionItem, error) {
+	var items []InspectionItem
+	rels, err := f.getRelsByType(f.getWorkbookRelsPath(), SourceRelationshipPerson)
+	if err != nil {
+		return items, err
+	}
+	for _, rel := range rels {
+		part := getRelsTargetPath(f.getWorkbookPath(), rel.Target)
+		persons := new(xlsxPersonList)
+		if err = f.xmlNewDecoder(bytes.NewReader(namespaceStrictToTransitional(f.readXML(part)))).
+			Decode(persons); err != nil && err != io.EOF {
+			return items, err
+		}
+		for _, person := range persons.Person {
+			items = append(items, InspectionItem{Part: part, Ref: person.ID, Value: person.DisplayName})
+		}
+	}
+	return items, nil
+}
+
+// inspectWorksheet provides a function to find the comment authors, printer
+// settings, hidden rows and columns in the worksheet.
+func (f *File) inspectWorksheet(sheet string, ws *xlsxWorksheet, report *InspectionReport) error {
+	sheetXMLPath, _ := f.getSheetXMLPath(sheet)
+	commentsXML := f.getSheetComments(filepath.Base(sheetXMLPath))
+	if commentsXML != "" {
+		commentsXML = getRelsTargetPath(sheetXMLPath, commentsXML)
+		cmts, err := f.commentsReader(commentsXML)
+		if err != nil {
+			return err
+		}
+		if cmts != nil {
+			for _, author := range cmts.Authors.Author {
+				report.CommentAuthors = append(report.CommentAuthors, InspectionItem{Part: commentsXML, Sheet: sheet, Value: author})
+			}
+		}
+	}
+	rels, err := f.getRelsByType(getSheetRelsPath(sheetXMLPath), SourceRelationshipPrinterSettings)
+	if err != nil {
+		return err
+	}
+	for _, rel := range rels {
+		part := getRelsTargetPath(sheetXMLPath, rel.Target)
+		report.PrinterSettings = append(report.PrinterSettings, InspectionItem{Part: part, Sheet: sheet, Ref: rel.ID, Value: getPrinterName(f.readBytes(part))})
+	}
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	for _, row := range ws.SheetData.Row {
+		if row.Hidden {
+			report.HiddenRows = append(report.HiddenRows, InspectionItem{Part: sheetXMLPath, Sheet: sheet, Ref: fmt.Sprintf("%d:%d", row.R, row.R)})
+		}
+	}
+	if ws.Cols != nil {
+		for _, col := range ws.Cols.Col {
+			if !col.Hidden {
+				continue
+			}
+			minCol, _ := ColumnNumberToName(col.Min)
+			maxCol, _ := ColumnNumberToName(col.Max)
+			report.HiddenCols = append(report.HiddenCols, InspectionItem{Part: sheetXMLPath, Sheet: sheet, Ref: minCol + ":" + maxCol})
+		}
+	}
+	return nil
+}
+
+// getAlternateContent provides a function to get the alternate content of
+// the workbook, which has been decoded from the workbook part or will be
+// written to it.
+func getAlternateContent(wb *xlsxWorkbook) string {
+	if wb.DecodeAlternateContent != nil {
+		return wb.DecodeAlternateContent.Content
+	}
+	if wb.AlternateContent != nil {
+		return wb.AlternateContent.Content
+	}
+	return ""
+}
+
+// getSheetRelsPath provides a function to get the relationships part path of
+// the worksheet by given worksheet part path.
+func getSheetRelsPath(sheetXMLPath string) string {
+	return "xl/worksheets/_rels/" + strings.TrimPrefix(sheetXMLPath, "xl/worksheets/") + ".rels"
+}
+
+// getRelsByType provides a function to get the relationships by given
+// relationships part path and relationship type.
+func (f *File) getRelsByType(relsPath, relType string) ([]xlsxRelationship, error) {
+	var relationships []xlsxRelationship
+	rels, err := f.relsReader(relsPath)
+	if err != nil || rels == nil {
+		return relationships, err
+	}
+	rels.mu.Lock()
+	defer rels.mu.Unlock()
+	for _, rel := range rels.Relationships {
+		if rel.Type == relType {
+			relationships = append(relationships, rel)
+		}
+	}
+	return relationships, err
+}
+
+// deleteRelsByType provides a function to delete the relationships by given
+// relationships part path and relationship types, and returns the deleted
+// relationships.
+func (f *File) deleteRelsByType(relsPath string, relTypes ...string) ([]xlsxRelationship, error) {
+	var deleted []xlsxRelationship
+	rels, err := f.relsReader(relsPath)
+	if err != nil || rels == nil {
+		return deleted, err
+	}
+	rels.mu.Lock()
+	defer rels.mu.Unlock()
+	var kept []xlsxRelationship
+	for _, rel := range rels.Relationships {
+		if inStrSlice(relTypes, rel.Type, true) != -1 {
+			deleted = append(deleted, rel)
+			continue
+		}
+		kept = append(kept, rel)
+	}
+	rels.Relationships = kept
+	return deleted, err
+}
+
+// getPrinterName provides a function to get the device name of the printer
+// in the DEVMODE structure of the printer settings part, which is stored as
+// a null terminated UTF-16LE string in the first 64 bytes.
+func getPrinterName(content []byte) string {
+	var name []uint16
+	for i := 0; i+1 < len(content) && i < 64; i += 2 {
+		c := binary.LittleEndian.Uint16(content[i:])
+		if c == 0 {
+			break
+		}
+		name = append(name, c)
+	}
+	return string(utf16.Decode(name))
+}
+
+// StripDocument provides a function to remove the personal information and
+// hidden content found by the InspectDocument function in the workbook by
+// given categories. The document properties which might identify the people
+// or organizations will be cleared. The comments, threaded comments and the
+// persons part will be deleted, and the VML drawing part will be deleted if
+// there are no other shapes in it. The printer settings parts and the
+// absolute path of the workbook will be deleted. The hidden worksheets, rows,
+// columns and defined names will be deleted, and the formulas and defined
+// names which reference them will be adjusted in the same way as the
+// DeleteSheet, RemoveRows and RemoveCols functions do. The external
+// dependencies will not be removed. For example, remove the comments and
+// the document properties in the workbook:
+//
+//	err := f.StripDocument(excelize.StripOptions{DocProps: true, Comments: true})
+func (f *File) StripDocument(opts StripOptions) error {
+	if opts.HiddenSheets {
+		for _, sheet := range f.getSheets() {
+			if sheet.State == "hidden" || sheet.State == "veryHidden" {
+				if err := f.DeleteSheet(sheet.Name); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	wb, err := f.workbookReader()
+	if err != nil {
+		return err
+	}
+	if opts.HiddenNames && wb.DefinedNames != nil {
+		var definedNames []xlsxDefinedName
+		for _, dn := range wb.DefinedNames.DefinedName {
+			if !dn.Hidden || strings.HasPrefix(dn.Name, "_xlnm.") {
+				definedNames = append(definedNames, dn)
+			}
+		}
+		wb.DefinedNames.DefinedName = definedNames
+		if len(definedNames) == 0 {
+			wb.DefinedNames = nil
+		}
+	}
+	if opts.AbsolutePath && absPathURLPattern.MatchString(getAlternateContent(wb)) {
+		wb.AlternateContent, wb.DecodeAlternateContent = nil, nil
+	}
+	if err = f.forEachWorksheet(nil, func(sheet string, ws *xlsxWorksheet) error {
+		return f.stripWorksheet(sheet, ws, opts)
+	}); err != nil {
+		return err
+	}
+	if opts.Comments {
+		if err = f.stripPersons(); err != nil {
+			return err
+		}
+	}
+	if opts.DocProps {
+		return f.stripDocProps()
+	}
+	return err
+}
+
+// stripWorksheet provides a function to remove the personal information and
+// hidden content in the worksheet by given categories.
+func (f *File) stripWorksheet(sheet string, ws *xlsxWorksheet, opts StripOptions) error {
+	var (
+		rows []int
+		cols [][]int
+	)
+	ws.mu.Lock()
+	for _, row := range ws.SheetData.Row {
+		if row.Hidden {
+			rows = append(rows, row.R)
+		}
+	}
+	if ws.Cols != nil {
+		for _, col := range ws.Cols.Col {
+			if col.Hidden {
+				cols = append(cols, []int{col.Min, col.Max})
+			}
+		}
+	}
+	ws.mu.Unlock()
+	if opts.HiddenRows && len(rows) > 0 {
+		if err := f.RemoveRows(sheet, rows); err != nil {
+			return err
+		}
+	}
+	if opts.HiddenCols {
+		sort.Slice(cols, func(i, j int) bool { return cols[i][0] > cols[j][0] })
+		for _, col := range cols {
+			minCol, _ := ColumnNumberToName(col[0])
+			maxCol, _ := ColumnNumberToName(col[1])
+			if err := f.RemoveCols(sheet, minCol, maxCol); err != nil {
+				return err
+			}
+		}
+	}
+	if opts.Comments {
+		if err := f.stripComments(sheet, ws); err != nil {
+			return err
+		}
+	}
+	if opts.PrinterSettings {
+		sheetXMLPath, _ := f.getSheetXMLPath(sheet)
+		rels, err := f.deleteRelsByType(getSheetRelsPath(sheetXMLPath), SourceRelationshipPrinterSettings)
+		if err != nil {
+			return err
+		}
+		for _, rel := range rels {
+			part := getRelsTargetPath(sheetXMLPath, rel.Target)
+			f.Pkg.Delete(part)
+			if err = f.removeContentTypesPart(ContentTypeSpreadSheetMLPrinterSettings, "/"+part); err != nil {
+				return err
+			}
+		}
+		ws.mu.Lock()
+		if ws.PageSetUp != nil {
+			ws.PageSetUp.RID = ""
+		}
+		ws.mu.Unlock()
+	}
+	return nil
+}
+
+// stripComments provides a function to delete the comments and threaded
+// comments in the worksheet, the VML drawing part will be deleted if there
+// are no other shapes in it.
+func (f *File) stripComments(sheet string, ws *xlsxWorksheet) error {
+	comments, err := f.GetComments(sheet)
+	if err != nil {
+		return err
+	}
+	for _, comment := range comments {
+		if err = f.DeleteComment(sheet, comment.Cell); err != nil {
+			return err
+		}
+	}
+	sheetXMLPath, _ := f.getSheetXMLPath(sheet)
+	rels, err := f.deleteRelsByType(getSheetRelsPath(sheetXMLPath), SourceRelationshipComments, SourceRelationshipThreadedComment)
+	if err != nil {
+		return err
+	}
+	for _, rel := range rels {
+		part, contentType := getRelsTargetPath(sheetXMLPath, rel.Target), ContentTypeSpreadSheetMLComments
+		if rel.Type == SourceRelationshipThreadedComment {
+			contentType = ContentTypeSpreadSheetMLThreadedComments
+		}
+		delete(f.Comments, part)
+		f.Pkg.Delete(part)
+		if err = f.removeContentTypesPart(contentType, "/"+part); err != nil {
+			return err
+		}
+	}
+	if ws.LegacyDrawing == nil {
+		return err
+	}
+	target := f.getSheetRelationshipsTargetByID(sheet, ws.LegacyDrawing.RID)
+	drawingVML, vml, err := f.vmlDrawingReader(target)
+	if err != nil || len(vml.Shape) > 0 {
+		return err
+	}
+	f.deleteSheetRelationships(sheet, ws.LegacyDrawing.RID)
+	delete(f.VMLDrawing, drawingVML)
+	f.Pkg.Delete(drawingVML)
+	ws.mu.Lock()
+	ws.LegacyDrawing = nil
+	ws.mu.Unlock()
+	return err
+}
+
+// stripPersons provides a function to delete the persons part of the
+// threaded comments in the workbook.
+func (f *File) stripPersons() error {
+	rels, err := f.deleteRelsByType(f.getWorkbookRelsPath(), SourceRelationshipPerson)
+	if err != nil {
+		return err
+	}
+	for _, rel := range rels {
+		part := getRelsTargetPath(f.getWorkbookPath(), rel.Target)
+		f.Pkg.Delete(part)
+		if err = f.removeContentTypesPart(ContentTypeSpreadSheetMLPerson, "/"+part); err != nil {
+			return err
+		}
+	}
+	return err
+}
+
+// stripDocProps provides a function to clear the document properties which
+// might identify the people or organizations in the core and application
+// properties parts.
+func (f *File) stripDocProps() error {
+	if content := f.readXML(defaultXMLPathDocPropsCore); len(content) > 0 {
+		core := new(decodeCoreProperties)
+		if err := f.xmlNewDecoder(bytes.NewReader(namespaceStrictToTransitional(content))).
+			Decode(core); err != nil && err != io.EOF {
+			return err
+		}
+		newProps := &xlsxCoreProperties{
+			Dc:         NameSpaceDublinCore,
+			Dcterms:    NameSpaceDublinCoreTerms,
+			Dcmitype:   NameSpaceDublinCoreMetadataInitiative,
+			XSI:        NameSpaceXMLSchemaInstance,
+			Language:   core.Language,
+			Identifier: core.Identifier,
+			Revision:   core.Revision,
+			Version:    core.Version,
+		}
+		if core.Created != nil {
+			newProps.Created = &xlsxDcTerms{Type: core.Created.Type, Text: core.Created.Text}
+		}
+		if core.Modified != nil {
+			newProps.Modified = &xlsxDcTerms{Type: core.Modified.Type, Text: core.Modified.Text}
+		}
+		output, err := xml.Marshal(newProps)
+		if err != nil {
+			return err
+		}
+		f.saveFileList(defaultXMLPathDocPropsCore, output)
+	}
+	if content := f.readXML(defaultXMLPathDocPropsApp); len(content) > 0 {
+		app := new(xlsxProperties)
+		if err := f.xmlNewDecoder(bytes.NewReader(namespaceStrictToTransitional(content))).
+			Decode(app); err != nil && err != io.EOF {
+			return err
+		}
+		app.Manager, app.Company = "", ""
+		app.Vt = NameSpaceDocumentPropertiesVariantTypes.Value
+		output, err := xml.Marshal(app)
+		if err != nil {
+			return err
+		}
+		f.saveFileList(defaultXMLPathDocPropsApp, output)
+	}
+	return nil
+}
@@ -0,0 +1,164 @@
+package excelize
+
+import (
+	"encoding/binary"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+	"unicode/utf16"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInspectDocument(t *testing.T) {
+	f := NewFile()
+	for r := 1; r <= 5; r++ {
+		for c := 1; c <= 5; c++ {
+			cell, err := CoordinatesToCellName(c, r)
+			assert.NoError(t, err)
+			assert.NoError(t, f.SetCellValue("Sheet1", cell, cell))
+		}
+	}
+	// Prepare the workbook which contains every category of the personal
+	// information and hidden content
+	assert.NoError(t, f.SetDocProps(&DocProperties{Title: "Report", Creator: "Alice", LastModifiedBy: "Bob"}))
+	assert.NoError(t, f.SetAppProps(&AppProperties{Company: "Company Name"}))
+	assert.NoError(t, f.AddComment("Sheet1", Comment{Cell: "A1", Author: "Charlie", Text: "Note"}))
+	personID := "{AB1D9C8E-3E4F-4B5A-8C6D-7E8F9A0B1C2D}"
+	f.Pkg.Store("xl/persons/person.xml", []byte(fmt.Sprintf(`<personList xmlns="http://schemas.microsoft.com/office/spreadsheetml/2018/threadedcomments"><person displayName="Dave" id="%s" userId="dave@example.com" providerId="AD"/></personList>`, personID)))
+	f.Pkg.Store("xl/threadedComments/threadedComment1.xml", []byte(fmt.Sprintf(`<ThreadedComments xmlns="http://schemas.microsoft.com/office/spreadsheetml/2018/threadedcomments"><threadedComment ref="A1" personId="%s" id="{00000000-0001-0000-0000-000000000000}"><text>Note</text></threadedComment></ThreadedComments>`, personID)))
+	f.addRels(f.getWorkbookRelsPath(), SourceRelationshipPerson, "persons/person.xml", "")
+	sheetRels := "xl/worksheets/_rels/sheet1.xml.rels"
+	f.addRels(sheetRels, SourceRelationshipThreadedComment, "../threadedComments/threadedComment1.xml", "")
+	printer := make([]byte, 64)
+	for i, c := range utf16.Encode([]rune("Printer A")) {
+		binary.LittleEndian.PutUint16(printer[i*2:], c)
+	}
+	f.Pkg.Store("xl/printerSettings/printerSettings1.bin", printer)
+	rID := f.addRels(sheetRels, SourceRelationshipPrinterSettings, "../printerSettings/printerSettings1.bin", "")
+	ws, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	ws.PageSetUp = &xlsxPageSetUp{RID: fmt.Sprintf("rId%d", rID)}
+	content, err := f.contentTypesReader()
+	assert.NoError(t, err)
+	content.Overrides = append(content.Overrides,
+		xlsxOverride{PartName: "/xl/persons/person.xml", ContentType: ContentTypeSpreadSheetMLPerson},
+		xlsxOverride{PartName: "/xl/threadedComments/threadedComment1.xml", ContentType: ContentTypeSpreadSheetMLThreadedComments},
+	)
+	wb, err := f.workbookReader()
+	assert.NoError(t, err)
+	wb.DecodeAlternateContent = &xlsxInnerXML{
+		Content: `<mc:Choice Requires="x15"><x15ac:absPath xmlns:x15ac="http://schemas.microsoft.com/office/spreadsheetml/2010/11/ac" url="C:\Reports\"></x15ac:absPath></mc:Choice>`,
+	}
+	_, err = f.NewSheet("Sheet2")
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetSheetVisible("Sheet2", false))
+	assert.NoError(t, f.SetRowVisible("Sheet1", 3, false))
+	assert.NoError(t, f.SetColVisible("Sheet1", "D", false))
+	assert.NoError(t, f.SetHiddenName("Secret", "value"))
+	assert.NoError(t, f.SetDefinedName(&DefinedName{Name: "Visible", RefersTo: "Sheet1!$A$1"}))
+	assert.NoError(t, f.SetCellHyperLink("Sheet1", "A2", "https://example.com", "External"))
+	path := filepath.Join("test", "TestInspectDocument.xlsx")
+	assert.NoError(t, f.SaveAs(path))
+	assert.NoError(t, f.Close())
+
+	f, err = OpenFile(path)
+	assert.NoError(t, err)
+	report, err := f.InspectDocument()
+	assert.NoError(t, err)
+	assert.Equal(t, []InspectionItem{
+		{Part: defaultXMLPathDocPropsCore, Ref: "title", Value: "Report"},
+		{Part: defaultXMLPathDocPropsCore, Ref: "creator", Value: "Alice"},
+		{Part: defaultXMLPathDocPropsCore, Ref: "lastModifiedBy", Value: "Bob"},
+		{Part: defaultXMLPathDocPropsApp, Ref: "Company", Value: "Company Name"},
+	}, report.DocProps)
+	assert.Equal(t, []InspectionItem{{Part: "xl/comments1.xml", Sheet: "Sheet1", Value: "Charlie"}}, report.CommentAuthors)
+	assert.Equal(t, []InspectionItem{{Part: "xl/persons/person.xml", Ref: personID, Value: "Dave"}}, report.ThreadedCommentPersons)
+	assert.Equal(t, []InspectionItem{{Part: "xl/printerSettings/printerSettings1.bin", Sheet: "Sheet1", Ref: fmt.Sprintf("rId%d", rID), Value: "Printer A"}}, report.PrinterSettings)
+	assert.Equal(t, []InspectionItem{{Part: "xl/workbook.xml", Ref: "absPath", Value: `C:\Reports\`}}, report.AbsolutePath)
+	assert.Equal(t, []InspectionItem{{Part: "xl/worksheets/sheet2.xml", Sheet: "Sheet2", Value: "hidden"}}, report.HiddenSheets)
+	assert.Equal(t, []InspectionItem{{Part: "xl/worksheets/sheet1.xml", Sheet: "Sheet1", Ref: "3:3"}}, report.HiddenRows)
+	assert.Equal(t, []InspectionItem{{Part: "xl/worksheets/sheet1.xml", Sheet: "Sheet1", Ref: "D:D"}}, report.HiddenCols)
+	assert.Len(t, report.HiddenNames, 1)
+	assert.Equal(t, "Secret", report.HiddenNames[0].Ref)
+	assert.Len(t, report.ExternalDependencies, 1)
+	assert.Equal(t, "https://example.com", report.ExternalDependencies[0].Target)
+
+	// Test strip all categories of the personal information and hidden content
+	assert.NoError(t, f.StripDocument(StripOptions{
+		DocProps: true, Comments: true, PrinterSettings: true, AbsolutePath: true,
+		HiddenSheets: true, HiddenRows: true, HiddenCols: true, HiddenNames: true,
+	}))
+	assert.NoError(t, f.SaveAs(path))
+	assert.NoError(t, f.Close())
+
+	f, err = OpenFile(path)
+	assert.NoError(t, err)
+	report, err = f.InspectDocument()
+	assert.NoError(t, err)
+	assert.Equal(t, InspectionReport{ExternalDependencies: report.ExternalDependencies}, report)
+	assert.Len(t, report.ExternalDependencies, 1)
+	for _, part := range []string{
+		"xl/comments1.xml", "xl/drawings/vmlDrawing1.vml", "xl/persons/person.xml",
+		"xl/threadedComments/threadedComment1.xml", "xl/printerSettings/printerSettings1.bin",
+	} {
+		_, ok := f.Pkg.Load(part)
+		assert.False(t, ok, part)
+		assert.NotContains(t, string(f.readXML(defaultXMLPathContentTypes)), part)
+	}
+	assert.NotContains(t, string(f.readXML("xl/worksheets/_rels/sheet1.xml.rels")), "printerSettings")
+	assert.Equal(t, []string{"Sheet1"}, f.GetSheetList())
+	comments, err := f.GetComments("Sheet1")
+	assert.NoError(t, err)
+	assert.Empty(t, comments)
+	for cell, expected := range map[string]string{"A1": "A1", "C1": "C1", "D1": "E1", "A3": "A4", "D4": "E5"} {
+		value, err := f.GetCellValue("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, value, cell)
+	}
+	definedNames := f.GetDefinedName()
+	assert.Len(t, definedNames, 1)
+	assert.Equal(t, "Visible", definedNames[0].Name)
+	props, err := f.GetDocProps()
+	assert.NoError(t, err)
+	assert.Empty(t, props.Creator)
+	assert.Empty(t, props.LastModifiedBy)
+	assert.Empty(t, props.Title)
+	assert.NotEmpty(t, props.Created)
+	assert.NotContains(t, string(f.readXML(defaultXMLPathWorkbook)), "absPath")
+	assert.NoError(t, f.Close())
+
+	// Test inspect and strip document with unsupported charset
+	for _, part := range []string{defaultXMLPathDocPropsCore, defaultXMLPathDocPropsApp} {
+		f = NewFile()
+		f.Pkg.Store(part, MacintoshCyrillicCharset)
+		_, err = f.InspectDocument()
+		assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+		assert.EqualError(t, f.StripDocument(StripOptions{DocProps: true}), "XML syntax error on line 1: invalid UTF-8")
+		assert.NoError(t, f.Close())
+	}
+	f = NewFile()
+	f.Pkg.Store("xl/persons/person.xml", MacintoshCyrillicCharset)
+	f.addRels(f.getWorkbookRelsPath(), SourceRelationshipPerson, "persons/person.xml", "")
+	_, err = f.InspectDocument()
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+	f = NewFile()
+	f.WorkBook = nil
+	f.Pkg.Store(defaultXMLPathWorkbook, MacintoshCyrillicCharset)
+	_, err = f.InspectDocument()
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+	f = NewFile()
+	f.WorkBook = nil
+	f.Pkg.Store(defaultXMLPathWorkbook, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.StripDocument(StripOptions{}), "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
+
+func TestGetPrinterName(t *testing.T) {
+	assert.Empty(t, getPrinterName(nil))
+	assert.Equal(t, "P", getPrinterName([]byte{'P', 0, 0, 0, 'Q', 0}))
+	assert.Equal(t, strings.Repeat("P", 32), getPrinterName([]byte(strings.Repeat("P\x00", 40))))
+}
@@ -233,18 +233,35 @@ func ColumnNumberToName(num int) (string, error) {
 	if num < MinColumns || num > MaxColumns {
 		return "", ErrColumnNumber
 	}
-	estimatedLength := 0
-	for n := num; n > 0; n = (n - 1) / 26 {
-		estimatedLength++
+	if num < len(columnNames) {
+		return columnNames[num], nil
 	}
+	var buf [3]byte
+	return string(appendColumnName(buf[:0], num)), nil
+}
+
+// columnNames is the lookup table of the column names indexed by the column
+// number for the first 702 columns (from A to ZZ).
+var columnNames = func() (names [703]string) {
+	for num := MinColumns; num < len(names); num++ {
+		names[num] = names[(num-1)/26] + string(rune('A'+(num-1)%26))
+	}
+	return
+}()
 
-	result := make([]byte, estimatedLength)
-	for num > 0 {
-		estimatedLength--
-		result[estimatedLength] = byte((num-1)%26 + 'A')
+// appendColumnName provides a function to append the column name by given
+// byte slice and column number, the column number must be in the range from
+// 1 to the maximum number of columns.
+func appendColumnName(dst []byte, num int) []byte {
+	if num < len(columnNames) {
+		return append(dst, columnNames[num]...)
+	}
+	var name [3]byte
+	for i := len(name) - 1; i >= 0; i-- {
+		name[i] = byte((num-1)%26 + 'A')
 		num = (num - 1) / 26
 	}
-	return string(result), nil
+	return append(dst, name[:]...)
 }
 
 // CellNameToCoordinates converts alphanumeric cell name to [X, Y] coordinates
@@ -255,6 +272,9 @@ func ColumnNumberToName(num int) (string, error) {
 //	excelize.CellNameToCoordinates("A1") // returns 1, 1, nil
 //	excelize.CellNameToCoordinates("Z3") // returns 26, 3, nil
 func CellNameToCoordinates(cell string) (int, int, error) {
+	if col, row, ok := parseCellName(cell); ok {
+		return col, row, nil
+	}
 	colName, row, err := SplitCellName(cell)
 	if err != nil {
 		return -1, -1, newCellNameToCoordinatesError(cell, err)
@@ -280,14 +300,63 @@ func CoordinatesToCellName(col, row int, abs ...bool) (string, error) {
 	if row > TotalRows {
 		return "", ErrMaxRows
 	}
-	sign := ""
+	if col > MaxColumns {
+		return "", ErrColumnNumber
+	}
+	var absolute bool
 	for _, a := range abs {
-		if a {
-			sign = "$"
+		absolute = absolute || a
+	}
+	var buf [16]byte
+	cell := buf[:0]
+	if absolute {
+		cell = append(cell, '$')
+	}
+	if cell = appendColumnName(cell, col); absolute {
+		cell = append(cell, '$')
+	}
+	return string(strconv.AppendInt(cell, int64(row), 10)), nil
+}
+
+// parseCellName provides a function to parse the cell reference consisting
+// of at most 3 letters and 7 digits with optional absolute signs, such as
+// "A1" and "$A$1", without allocation. The last return value will be false if
+// the cell reference isn't in this form or out of the worksheet limits, and
+// the coordinates should be converted by the SplitCellName and
+// ColumnNameToNumber functions to get the error.
+func parseCellName(cell string) (col, row int, ok bool) {
+	var i int
+	if i < len(cell) && cell[i] == '$' {
+		i++
+	}
+	start := i
+	for ; i < len(cell) && i-start < 3; i++ {
+		c := cell[i] | 0x20
+		if c < 'a' || c > 'z' {
+			break
+		}
+		col = col*26 + int(c-'a'+1)
+	}
+	if i == start || col > MaxColumns {
+		return 0, 0, false
+	}
+	if i < len(cell) && cell[i] == '$' {
+		i++
+	}
+	if i == len(cell) || len(cell)-i > 7 {
+		return 0, 0, false
+	}
+	for ; i < len(cell); i++ {
+		c := cell[i]
+		if c < '0' || c > '9' {
+			return 0, 0, false
 		}
+		row = row*10 + int(c-'0')
+	}
+	if row < 1 || row > TotalRows {
+		return 0, 0, false
 	}
-	colName, err := ColumnNumberToName(col)
-	return sign + colName + sign + strconv.Itoa(row), err
+	return col, row, true
 }
 
 // rangeRefToCoordinates provides a function to convert range reference to a
@@ -441,6 +510,10 @@ func intPtr(i int) *int { return &i }
 // uintPtr returns a pointer to an unsigned integer with the given value.
 func uintPtr(u uint) *uint { return &u }
 
+// uint8Ptr returns a pointer to an 8-bit unsigned integer with the given
+// value.
+func uint8Ptr(u uint8) *uint8 { return &u }
+
 // float64Ptr returns a pointer to a float64 with the given value.
 func float64Ptr(f float64) *float64 { return &f }
 
@@ -786,7 +859,7 @@ func isNumeric(s string) (bool, int, float64) {
 
 var (
 	bstrExp       = regexp.MustCompile(`_x[a-fA-F\d]{4}_`)
-	bstrEscapeExp = regexp.MustCompile(`x[a-fA-F\d]{4}_`)
+	bstrEscapeExp = regexp.MustCompile(`^x[a-fA-F\d]{4}_$`)
 )
 
 // bstrUnmarshal parses the binary basic string, this will trim escaped string
@@ -801,6 +874,9 @@ var (
 // initial underscore shall itself be escaped (i.e. stored as _x005F_). For
 // example: The string literal _x0008_ would be stored as _x005F_x0008_.
 func bstrUnmarshal(s string) (result string) {
+	if !strings.Contains(s, "_x") {
+		return s
+	}
 	matches, l, cursor := bstrExp.FindAllStringSubmatchIndex(s, -1), len(s), 0
 	for _, match := range matches {
 		result += s[cursor:match[0]]
@@ -823,36 +899,31 @@ func bstrUnmarshal(s string) (result string) {
 }
 
 // bstrMarshal encode the escaped string literal which not permitted in an XML
-// 1.0 document.
-func bstrMarshal(s string) (result string) {
-	matches, l, cursor := bstrExp.FindAllStringSubmatchIndex(s, -1), len(s), 0
-	for _, match := range matches {
-		result += s[cursor:match[0]]
-		subStr := s[match[0]:match[1]]
-		if subStr == "_x005F_" {
-			cursor = match[1]
-			if match[1]+6 <= l && bstrEscapeExp.MatchString(s[match[1]:match[1]+6]) {
-				_, err := strconv.Unquote(`"\u` + s[match[1]+1:match[1]+5] + `"`)
-				if err == nil {
-					result += subStr + "x005F" + subStr
-					continue
-				}
-			}
-			result += subStr + "x005F_"
-			continue
-		}
-		if bstrExp.MatchString(subStr) {
-			cursor = match[1]
-			if _, err := strconv.Unquote(`"\u` + s[match[0]+2:match[1]-1] + `"`); err == nil {
-				result += "_x005F" + subStr
-				continue
-			}
-		}
+// 1.0 document. The control characters except the tab and line feed will be
+// escaped in the _xHHHH_ format, and the initial underscore of the literal
+// form of an escape sequence will be escaped as _x005F_.
+func bstrMarshal(s string) string {
+	if strings.IndexFunc(s, func(r rune) bool { return r == '_' || isBstrControl(r) }) == -1 {
+		return s
 	}
-	if cursor < l {
-		result += s[cursor:]
+	var buf strings.Builder
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case c == '_' && bstrEscapeExp.MatchString(s[i+1:min(i+7, len(s))]):
+			buf.WriteString("_x005F_")
+		case isBstrControl(rune(c)):
+			buf.WriteString(fmt.Sprintf("_x%04X_", c))
+		default:
+			buf.WriteByte(c)
+		}
 	}
-	return result
+	return buf.String()
+}
+
+// isBstrControl returns whether the given character is a control character
+// which should be escaped in the binary basic string.
+func isBstrControl(r rune) bool {
+	return r < 0x20 && r != '\t' && r != '\n'
 }
 
 // newRat converts decimals to rational fractions with the required precision.
@@ -216,6 +216,161 @@ func TestCoordinatesToCellName_Error(t *testing.T) {
 	}
 }
 
+// legacyCellNameToCoordinates, legacyCoordinatesToCellName and
+// legacyColumnNumberToName are the implementations of the conversion
+// functions before using the lookup table, which are used for checking the
+// consistency and comparing the performance. The cell name will be empty if
+// the column number is invalid.
+func legacyCellNameToCoordinates(cell string) (int, int, error) {
+	colName, row, err := SplitCellName(cell)
+	if err != nil {
+		return -1, -1, newCellNameToCoordinatesError(cell, err)
+	}
+	if row > TotalRows {
+		return -1, -1, ErrMaxRows
+	}
+	col, err := ColumnNameToNumber(colName)
+	return col, row, err
+}
+
+func legacyCoordinatesToCellName(col, row int, abs ...bool) (string, error) {
+	if col < 1 || row < 1 {
+		return "", newCoordinatesToCellNameError(col, row)
+	}
+	if row > TotalRows {
+		return "", ErrMaxRows
+	}
+	sign := ""
+	for _, a := range abs {
+		if a {
+			sign = "$"
+		}
+	}
+	colName, err := legacyColumnNumberToName(col)
+	if err != nil {
+		return "", err
+	}
+	return sign + colName + sign + strconv.Itoa(row), err
+}
+
+func legacyColumnNumberToName(num int) (string, error) {
+	if num < MinColumns || num > MaxColumns {
+		return "", ErrColumnNumber
+	}
+	estimatedLength := 0
+	for n := num; n > 0; n = (n - 1) / 26 {
+		estimatedLength++
+	}
+	result := make([]byte, estimatedLength)
+	for num > 0 {
+		estimatedLength--
+		result[estimatedLength] = byte((num-1)%26 + 'A')
+		num = (num - 1) / 26
+	}
+	return string(result), nil
+}
+
+func TestCellNameConversionConsistency(t *testing.T) {
+	check := func(col, row int) {
+		for _, abs := range []bool{false, true} {
+			expected, _ := legacyCoordinatesToCellName(col, row, abs)
+			cell, err := CoordinatesToCellName(col, row, abs)
+			require.NoError(t, err)
+			require.Equal(t, expected, cell)
+			c, r, err := CellNameToCoordinates(cell)
+			require.NoError(t, err)
+			require.Equal(t, []int{col, row}, []int{c, r})
+			c, r, err = CellNameToCoordinates(strings.ToLower(cell))
+			require.NoError(t, err)
+			require.Equal(t, []int{col, row}, []int{c, r})
+		}
+	}
+	for col := MinColumns; col <= MaxColumns; col++ {
+		expected, _ := legacyColumnNumberToName(col)
+		name, err := ColumnNumberToName(col)
+		require.NoError(t, err)
+		require.Equal(t, expected, name)
+		check(col, 1)
+		check(col, TotalRows)
+	}
+	for row := 1; row <= TotalRows; row += 7 {
+		check(MaxColumns, row)
+	}
+	for _, cell := range []string{"$$A1", "A$$1", "A1$", "AAAA1", "XFE1", "A01", "A00000001", "A0", "A+1", "A1B2", "A 1", "\uff21\uff11", "A1048577", "A99999999999999999999"} {
+		col, row, err := CellNameToCoordinates(cell)
+		expectedCol, expectedRow, expectedErr := legacyCellNameToCoordinates(cell)
+		assert.Equal(t, []int{expectedCol, expectedRow}, []int{col, row}, cell)
+		assert.Equal(t, expectedErr, err, cell)
+	}
+}
+
+func FuzzCellNameToCoordinates(f *testing.F) {
+	for _, seed := range []string{"A1", "$A$1", "xfd1048576", "$$A1", "XFE1", "A1048577", "A+1", "A1B2", ""} {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, cell string) {
+		col, row, err := CellNameToCoordinates(cell)
+		expectedCol, expectedRow, expectedErr := legacyCellNameToCoordinates(cell)
+		assert.Equal(t, []int{expectedCol, expectedRow}, []int{col, row}, cell)
+		assert.Equal(t, expectedErr, err, cell)
+	})
+}
+
+func FuzzCoordinatesToCellName(f *testing.F) {
+	for _, seed := range [][]int{{1, 1}, {702, 1}, {703, 2}, {MaxColumns, TotalRows}, {MaxColumns + 1, 1}, {1, TotalRows + 1}, {0, 0}} {
+		f.Add(seed[0], seed[1], false)
+		f.Add(seed[0], seed[1], true)
+	}
+	f.Fuzz(func(t *testing.T, col, row int, abs bool) {
+		cell, err := CoordinatesToCellName(col, row, abs)
+		expected, expectedErr := legacyCoordinatesToCellName(col, row, abs)
+		assert.Equal(t, expected, cell)
+		assert.Equal(t, expectedErr, err)
+	})
+}
+
+func BenchmarkCellNameToCoordinates(b *testing.B) {
+	for _, c := range []struct {
+		name string
+		fn   func(cell string) (int, int, error)
+	}{
+		{"legacy", legacyCellNameToCoordinates},
+		{"parse", CellNameToCoordinates},
+	} {
+		b.Run(c.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				for _, cell := range []string{"A1", "Z100", "$AB$1024", "XFD1048576"} {
+					if _, _, err := c.fn(cell); err != nil {
+						b.Fatal(err)
+					}
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkCoordinatesToCellName(b *testing.B) {
+	for _, c := range []struct {
+		name string
+		fn   func(col, row int, abs ...bool) (string, error)
+	}{
+		{"legacy", legacyCoordinatesToCellName},
+		{"lookup", CoordinatesToCellName},
+	} {
+		b.Run(c.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				for _, coordinates := range [][]int{{1, 1}, {26, 100}, {28, 1024}, {MaxColumns, TotalRows}} {
+					if _, err := c.fn(coordinates[0], coordinates[1]); err != nil {
+						b.Fatal(err)
+					}
+				}
+			}
+		})
+	}
+}
+
 func TestCoordinatesToRangeRef(t *testing.T) {
 	_, err := coordinatesToRangeRef([]int{})
 	assert.EqualError(t, err, ErrCoordinates.Error())
@@ -347,9 +502,14 @@ func TestBstrMarshal(t *testing.T) {
 		"*_x005F_*":       "*_x005F_x005F_*",
 		"*_x005F_xG006_*": "*_x005F_x005F_xG006_*",
 		"*_x005F_x0006_*": "*_x005F_x005F_x005F_x0006_*",
+		"_x0041_":         "_x005F_x0041_",
+		"\t\r\n\x07":      "\t_x000D_\n_x0007_",
+		"_x":              "_x",
+		"你好_":             "你好_",
 	}
 	for bstr, expected := range bstrs {
 		assert.Equal(t, expected, bstrMarshal(bstr))
+		assert.Equal(t, bstr, bstrUnmarshal(expected))
 	}
 }
 
@@ -218,4 +218,9 @@ func TestMergeCellsParser(t *testing.T) {
 	ws := &xlsxWorksheet{MergeCells: &xlsxMergeCells{Cells: []*xlsxMergeCell{nil}}}
 	_, err := ws.mergeCellsParser("A1")
 	assert.NoError(t, err)
+	// Test merged cells parser with empty range reference
+	ws = &xlsxWorksheet{MergeCells: &xlsxMergeCells{Cells: []*xlsxMergeCell{{Ref: ""}}}}
+	cell, err := ws.mergeCellsParser("A1")
+	assert.NoError(t, err)
+	assert.Equal(t, "A1", cell)
 }
@@ -4857,6 +4857,40 @@ func (f *File) getBuiltInNumFmtCode(numFmtID int) (string, bool) {
 	return "", false
 }
 
+// isDateTimeNumFmt provides a function to check if the number format applied
+// on the given style ID is a date or time number format.
+func (f *File) isDateTimeNumFmt(styleID int) bool {
+	styleSheet, err := f.stylesReader()
+	if err != nil {
+		return false
+	}
+	styleSheet.mu.Lock()
+	if styleSheet.CellXfs == nil || styleID < 0 || styleID >= len(styleSheet.CellXfs.Xf) {
+		styleSheet.mu.Unlock()
+		return false
+	}
+	var numFmtID int
+	if styleSheet.CellXfs.Xf[styleID].NumFmtID != nil {
+		numFmtID = *styleSheet.CellXfs.Xf[styleID].NumFmtID
+	}
+	fmtCode, ok := styleSheet.getCustomNumFmtCode(numFmtID)
+	styleSheet.mu.Unlock()
+	if !ok {
+		if fmtCode, ok = f.getBuiltInNumFmtCode(numFmtID); !ok {
+			return false
+		}
+	}
+	p := nfp.NumberFormatParser()
+	for _, section := range p.Parse(fmtCode) {
+		for _, token := range section.Items {
+			if inStrSlice(supportedDateTimeTokenTypes, token.TType, true) != -1 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 // prepareNumberic split the number into two before and after parts by a
 // decimal point.
 func (nf *numberFormat) prepareNumberic(value string) {
@@ -7189,16 +7223,20 @@ func (nf *numberFormat) secondsHandler(token nfp.Token) {
 // elapsedDateTimesHandler will be handling elapsed date and times types tokens
 // for a number format expression.
 func (nf *numberFormat) elapsedDateTimesHandler(token nfp.Token) {
+	epoch := excel1900Epoc
+	if nf.date1904 {
+		epoch = excel1904Epoc
+	}
 	if strings.Contains(strings.ToUpper(token.TValue), "H") {
-		nf.result += fmt.Sprintf("%.f", math.Floor(nf.t.Sub(excel1900Epoc).Hours()))
+		nf.result += fmt.Sprintf("%.f", math.Floor(nf.t.Sub(epoch).Hours()))
 		return
 	}
 	if strings.Contains(strings.ToUpper(token.TValue), "M") {
-		nf.result += fmt.Sprintf("%.f", math.Floor(nf.t.Sub(excel1900Epoc).Minutes()))
+		nf.result += fmt.Sprintf("%.f", math.Floor(nf.t.Sub(epoch).Minutes()))
 		return
 	}
 	if strings.Contains(strings.ToUpper(token.TValue), "S") {
-		nf.result += fmt.Sprintf("%.f", math.Floor(nf.t.Sub(excel1900Epoc).Seconds()))
+		nf.result += fmt.Sprintf("%.f", math.Floor(nf.t.Sub(epoch).Seconds()))
 		return
 	}
 }
@@ -7209,7 +7247,7 @@ func (nf *numberFormat) hoursNext(i int) int {
 	for idx := i + 1; idx < len(tokens); idx++ {
 		if tokens[idx].TType == nfp.TokenTypeDateTimes {
 			if strings.Contains(strings.ToUpper(tokens[idx].TValue), "H") {
-				t := timeFromExcelTime(nf.number, false)
+				t := timeFromExcelTime(nf.number, nf.date1904)
 				return t.Hour()
 			}
 		}
@@ -16,6 +16,7 @@ import (
 	"encoding/xml"
 	"image"
 	"io"
+	"math"
 	"os"
 	"path"
 	"path/filepath"
@@ -61,6 +62,77 @@ func parseGraphicOptions(opts *GraphicOptions) *GraphicOptions {
 	return opts
 }
 
+// validate provides a function to check the format settings of the drawing
+// object after the default values have been applied, an ErrGraphicOptions
+// error naming the invalid field will be returned.
+func (opts *GraphicOptions) validate() error {
+	if opts.Positioning != "" && inStrSlice(supportedPositioning, opts.Positioning, true) == -1 {
+		return ErrGraphicOptions{Field: "Positioning", Value: opts.Positioning,
+			Reason: "acceptable value should be one of " + strings.Join(supportedPositioning, ", ")}
+	}
+	for i, scale := range []float64{opts.ScaleX, opts.ScaleY} {
+		if !(scale > 0) || math.IsInf(scale, 0) {
+			return ErrGraphicOptions{Field: []string{"ScaleX", "ScaleY"}[i], Value: scale, Reason: "scale must be greater than 0"}
+		}
+	}
+	if opts.OffsetX < 0 {
+		return ErrGraphicOptions{Field: "OffsetX", Value: opts.OffsetX, Reason: "offset must be greater than or equal to 0"}
+	}
+	if opts.OffsetY < 0 {
+		return ErrGraphicOptions{Field: "OffsetY", Value: opts.OffsetY, Reason: "offset must be greater than or equal to 0"}
+	}
+	if opts.HyperlinkType != "" && inStrSlice(supportedDrawingHyperlinkTypes, opts.HyperlinkType, true) == -1 {
+		return ErrGraphicOptions{Field: "HyperlinkType", Value: opts.HyperlinkType,
+			Reason: "acceptable value should be one of " + strings.Join(supportedDrawingHyperlinkTypes, ", ")}
+	}
+	if opts.Decorative && opts.AltText != "" {
+		return ErrGraphicOptions{Field: "AltText", Value: opts.AltText, Reason: "decorative object should not have alternative text"}
+	}
+	return nil
+}
+
+// newCNvPr provides a function to create the non-visual drawing properties
+// of the drawing object with the alternative text, title and decorative
+// settings by given ID and name.
+func (opts *GraphicOptions) newCNvPr(ID int, name string) *xlsxCNvPr {
+	cNvPr := &xlsxCNvPr{ID: ID, Name: name, Descr: opts.AltText, Title: opts.Title}
+	if opts.Decorative {
+		cNvPr.ExtLst = &xlsxCNvPrExtLst{Ext: []xlsxCNvPrExt{{
+			URI: ExtURIDecorative,
+			Decorative: &xlsxDecorative{
+				XMLNSAdec: NameSpaceDrawing2017Decorative.Value,
+				Val:       true,
+			},
+		}}}
+	}
+	return cNvPr
+}
+
+// hyperlinkType provides a function to get the relationship target mode of
+// the hyperlink of the drawing object. The hyperlink begins with "#" will be
+// treated as a location in the workbook if the hyperlink type is not
+// specified.
+func (opts *GraphicOptions) hyperlinkType() string {
+	if opts.HyperlinkType == "External" ||
+		(opts.HyperlinkType == "" && !strings.HasPrefix(opts.Hyperlink, "#")) {
+		return "External"
+	}
+	return ""
+}
+
+// checkAnchor provides a function to check the end cell of the two cell
+// anchor drawing object is within the worksheet bounds by given zero-based
+// end column and row number.
+func (opts *GraphicOptions) checkAnchor(colEnd, rowEnd int) error {
+	if colEnd >= MaxColumns {
+		return ErrGraphicOptions{Field: "OffsetX", Value: opts.OffsetX, Reason: "the object exceeds the last column of the worksheet"}
+	}
+	if rowEnd >= TotalRows {
+		return ErrGraphicOptions{Field: "OffsetY", Value: opts.OffsetY, Reason: "the object exceeds the last row of the worksheet"}
+	}
+	return nil
+}
+
 // AddPicture provides the method to add picture in a sheet by given picture
 // format set (such as offset, scale, aspect ratio setting and print settings)
 // and file path, supported image types: BMP, EMF, EMZ, GIF, JPEG, JPG, PNG,
@@ -128,6 +200,14 @@ func parseGraphicOptions(opts *GraphicOptions) *GraphicOptions {
 // The optional parameter "AltText" is used to add alternative text to a graph
 // object.
 //
+// The optional parameter "Title" specifies the title of the graph object,
+// which is shown as the tooltip and read by the screen readers.
+//
+// The optional parameter "Decorative" marks the graph object as decorative,
+// the screen readers will skip the object and the accessibility checker will
+// not report the missing alternative text of it. A decorative object should
+// not have the alternative text.
+//
 // The optional parameter "PrintObject" indicates whether the graph object is
 // printed when the worksheet is printed, the default value of that is 'true'.
 //
@@ -162,13 +242,20 @@ func parseGraphicOptions(opts *GraphicOptions) *GraphicOptions {
 // The optional parameter "HyperlinkType" defines two types of
 // hyperlink "External" for website or "Location" for moving to one of the
 // cells in this workbook. When the "HyperlinkType" is "Location",
-// coordinates need to start with "#".
+// coordinates need to start with "#". If the "HyperlinkType" is omitted, the
+// hyperlink starts with "#" will be treated as a location, otherwise as an
+// external link.
 //
 // The optional parameter "Positioning" defines 3 types of the position of a
 // graph object in a spreadsheet: "oneCell" (Move but don't size with
 // cells), "twoCell" (Move and size with cells), and "absolute" (Don't move or
 // size with cells). If you don't set this parameter, the default positioning
 // is to move and size with cells.
+//
+// An ErrGraphicOptions error naming the invalid field will be returned if the
+// scale is not a positive number, the offset is negative, the positioning or
+// hyperlink type is unsupported, a decorative object has alternative text, or
+// the graph object exceeds the worksheet bounds.
 func (f *File) AddPicture(sheet, cell, name string, opts *GraphicOptions) error {
 	var err error
 	// Check picture exists first.
@@ -227,7 +314,6 @@ func (f *File) AddPicture(sheet, cell, name string, opts *GraphicOptions) error
 //	}
 func (f *File) AddPictureFromBytes(sheet, cell string, pic *Picture) error {
 	var drawingHyperlinkRID int
-	var hyperlinkType string
 	ext, ok := supportedImageTypes[strings.ToLower(pic.Extension)]
 	if !ok {
 		return ErrImgExt
@@ -236,6 +322,9 @@ func (f *File) AddPictureFromBytes(sheet, cell string, pic *Picture) error {
 		return ErrParameterInvalid
 	}
 	options := parseGraphicOptions(pic.Format)
+	if err := options.validate(); err != nil {
+		return err
+	}
 	img, _, err := image.DecodeConfig(bytes.NewReader(pic.File))
 	if err != nil {
 		return err
@@ -265,14 +354,12 @@ func (f *File) AddPictureFromBytes(sheet, cell string, pic *Picture) error {
 		}
 	}
 	if drawingRID == 0 {
-		drawingRID = f.addRels(drawingRels, SourceRelationshipImage, mediaStr, hyperlinkType)
+		drawingRID = f.addRels(drawingRels, SourceRelationshipImage, mediaStr, "")
 	}
-	// Add picture with hyperlink.
-	if options.Hyperlink != "" && options.HyperlinkType != "" {
-		if options.HyperlinkType == "External" {
-			hyperlinkType = options.HyperlinkType
-		}
-		drawingHyperlinkRID = f.addRels(drawingRels, SourceRelationshipHyperLink, options.Hyperlink, hyperlinkType)
+	// Add picture with hyperlink, the relationship of the hyperlink belongs to
+	// the drawing part which the a:hlinkClick element is placed in.
+	if options.Hyperlink != "" {
+		drawingHyperlinkRID = f.addRels(drawingRels, SourceRelationshipHyperLink, options.Hyperlink, options.hyperlinkType())
 	}
 	ws.mu.Unlock()
 	err = f.addDrawingPicture(sheet, drawingXML, cell, ext, drawingRID, drawingHyperlinkRID, img, options)
@@ -366,7 +453,13 @@ func (f *File) addDrawingPicture(sheet, drawingXML, cell, ext string, rID, hyper
 		width = int(float64(width) * opts.ScaleX)
 		height = int(float64(height) * opts.ScaleY)
 	}
-	colStart, rowStart, colEnd, rowEnd, x1, y1, x2, y2 := f.positionObjectPixels(sheet, col, row, width, height, opts)
+	colStart, rowStart, colEnd, rowEnd, x1, y1, x2, y2, err := f.positionObjectPixels(sheet, col, row, width, height, opts)
+	if err != nil {
+		return err
+	}
+	if err = opts.checkAnchor(colEnd, rowEnd); err != nil {
+		return err
+	}
 	content, cNvPrID, err := f.drawingParser(drawingXML)
 	if err != nil {
 		return err
@@ -391,9 +484,7 @@ func (f *File) addDrawingPicture(sheet, drawingXML, cell, ext string, rID, hyper
 
 	pic := xlsxPic{}
 	pic.NvPicPr.CNvPicPr.PicLocks.NoChangeAspect = opts.LockAspectRatio
-	pic.NvPicPr.CNvPr.ID = cNvPrID
-	pic.NvPicPr.CNvPr.Descr = opts.AltText
-	pic.NvPicPr.CNvPr.Name = "Picture " + strconv.Itoa(cNvPrID)
+	pic.NvPicPr.CNvPr = *opts.newCNvPr(cNvPrID, f.drawingObjectName(content, "Picture", cNvPrID))
 	if hyperlinkRID != 0 {
 		pic.NvPicPr.CNvPr.HlinkClick = &xlsxHlinkClick{
 			R:   SourceRelationship.Value,
@@ -483,8 +574,9 @@ func (f *File) addMedia(file []byte, ext string) string {
 
 // GetPictures provides a function to get picture meta info and raw content
 // embed in spreadsheet by given worksheet and cell name. This function
-// returns the image contents as []byte data types. This function is
-// concurrency safe. For example:
+// returns the image contents as []byte data types, and the alternative text,
+// title, decorative setting and hyperlink of the picture in the format
+// settings. This function is concurrency safe. For example:
 //
 //	f, err := excelize.OpenFile("Book1.xlsx")
 //	if err != nil {
@@ -638,7 +730,18 @@ func (f *File) getPicture(row, col int, drawingXML, drawingRelationships string)
 		pic := Picture{Extension: filepath.Ext(r.Target), Format: &GraphicOptions{}, InsertType: PictureInsertTypePlaceOverCells}
 		if buffer, _ := f.Pkg.Load(filepath.ToSlash(filepath.Clean("xl/drawings/" + r.Target))); buffer != nil {
 			pic.File = buffer.([]byte)
-			pic.Format.AltText = a.Pic.NvPicPr.CNvPr.Descr
+			cNvPr := a.Pic.NvPicPr.CNvPr
+			pic.Format.AltText, pic.Format.Title = cNvPr.Descr, cNvPr.Title
+			if cNvPr.ExtLst != nil {
+				for _, ext := range cNvPr.ExtLst.Ext {
+					if ext.URI == ExtURIDecorative && ext.Decorative != nil {
+						pic.Format.Decorative = ext.Decorative.Val
+					}
+				}
+			}
+			if cNvPr.HlinkClick != nil {
+				pic.Format.Hyperlink, pic.Format.HyperlinkType = f.getDrawingHyperlink(drawingRelationships, cNvPr.HlinkClick.RID)
+			}
 			pics = append(pics, pic)
 		}
 	}
@@ -653,7 +756,18 @@ func (f *File) getPicture(row, col int, drawingXML, drawingRelationships string)
 		pic := Picture{Extension: filepath.Ext(target), Format: &GraphicOptions{}, InsertType: PictureInsertTypePlaceOverCells}
 		if buffer, _ := f.Pkg.Load(target); buffer != nil {
 			pic.File = buffer.([]byte)
-			pic.Format.AltText = a.Pic.NvPicPr.CNvPr.Descr
+			cNvPr := a.Pic.NvPicPr.CNvPr
+			pic.Format.AltText, pic.Format.Title = cNvPr.Descr, cNvPr.Title
+			if cNvPr.ExtLst != nil {
+				for _, ext := range cNvPr.ExtLst.Ext {
+					if ext.URI == ExtURIDecorative && ext.Decorative != nil {
+						pic.Format.Decorative = ext.Decorative.Val == "1" || ext.Decorative.Val == "true"
+					}
+				}
+			}
+			if cNvPr.HlinkClick != nil {
+				pic.Format.Hyperlink, pic.Format.HyperlinkType = f.getDrawingHyperlink(drawingRelationships, cNvPr.HlinkClick.RID)
+			}
 			pics = append(pics, pic)
 		}
 	}
@@ -712,6 +826,19 @@ func (f *File) extractDecodeCellAnchor(anchor *xdrCellAnchor, drawingRelationshi
 	}
 }
 
+// getDrawingHyperlink provides a function to get the hyperlink address and
+// type of the drawing object by given drawing relationships part path and
+// relationship ID.
+func (f *File) getDrawingHyperlink(drawingRelationships, rID string) (string, string) {
+	if rel := f.getDrawingRelationships(drawingRelationships, rID); rel != nil && rel.Type == SourceRelationshipHyperLink {
+		if rel.TargetMode == "External" {
+			return rel.Target, "External"
+		}
+		return rel.Target, "Location"
+	}
+	return "", ""
+}
+
 // getDrawingRelationships provides a function to get drawing relationships
 // from xl/drawings/_rels/drawing%s.xml.rels by given file name and
 // relationship ID.
@@ -752,7 +879,11 @@ func (f *File) drawingResize(sheet, cell string, width, height float64, opts *Gr
 	if c, r, err = CellNameToCoordinates(cell); err != nil {
 		return
 	}
-	cellWidth, cellHeight := f.getColWidth(sheet, c), f.getRowHeight(sheet, r)
+	maxDigitWidth, err := f.getMaxDigitWidth()
+	if err != nil {
+		return
+	}
+	cellWidth, cellHeight := f.getColWidth(sheet, c, maxDigitWidth), f.getRowHeight(sheet, r)
 	for _, mergeCell := range mergeCells {
 		if inMergeCell {
 			continue
@@ -766,7 +897,7 @@ func (f *File) drawingResize(sheet, cell string, width, height float64, opts *Gr
 		cellWidth, cellHeight = 0, 0
 		c, r = rng[0], rng[1]
 		for col := rng[0]; col <= rng[2]; col++ {
-			cellWidth += f.getColWidth(sheet, col)
+			cellWidth += f.getColWidth(sheet, col, maxDigitWidth)
 		}
 		for row := rng[1]; row <= rng[3]; row++ {
 			cellHeight += f.getRowHeight(sheet, row)
@@ -1010,6 +1141,7 @@ func (f *File) getDispImages(sheet, cell string) ([]Picture, error) {
 					if buffer, _ := f.Pkg.Load("xl/" + r.Target); buffer != nil {
 						pic.File = buffer.([]byte)
 						pic.Format.AltText = cellImg.Pic.NvPicPr.CNvPr.Descr
+						pic.Format.Title = cellImg.Pic.NvPicPr.CNvPr.Title
 						pics = append(pics, pic)
 					}
 				}
@@ -1,12 +1,14 @@
 package excelize
 
 import (
+	"encoding/xml"
 	"fmt"
 	"image"
 	_ "image/gif"
 	_ "image/jpeg"
 	_ "image/png"
 	"io"
+	"math"
 	"os"
 	"path/filepath"
 	"strings"
@@ -308,6 +310,104 @@ func TestAddDrawingPicture(t *testing.T) {
 	assert.EqualError(t, f.addDrawingPicture("sheet1", path, "A1", "", 0, 0, image.Config{}, opts), "XML syntax error on line 1: invalid UTF-8")
 }
 
+func TestGraphicOptionsValidate(t *testing.T) {
+	f := NewFile()
+	img := filepath.Join("test", "images", "excel.jpg")
+	for _, c := range []struct {
+		opts *GraphicOptions
+		err  error
+	}{
+		{&GraphicOptions{Positioning: "x"}, ErrGraphicOptions{Field: "Positioning", Value: "x", Reason: "acceptable value should be one of absolute, oneCell, twoCell"}},
+		{&GraphicOptions{ScaleX: -0.5}, ErrGraphicOptions{Field: "ScaleX", Value: -0.5, Reason: "scale must be greater than 0"}},
+		{&GraphicOptions{ScaleY: math.Inf(1)}, ErrGraphicOptions{Field: "ScaleY", Value: math.Inf(1), Reason: "scale must be greater than 0"}},
+		{&GraphicOptions{OffsetX: -1}, ErrGraphicOptions{Field: "OffsetX", Value: -1, Reason: "offset must be greater than or equal to 0"}},
+		{&GraphicOptions{OffsetY: -1}, ErrGraphicOptions{Field: "OffsetY", Value: -1, Reason: "offset must be greater than or equal to 0"}},
+		{&GraphicOptions{Hyperlink: "#Sheet1!A1", HyperlinkType: "location"}, ErrGraphicOptions{Field: "HyperlinkType", Value: "location", Reason: "acceptable value should be one of External, Location"}},
+		{&GraphicOptions{AltText: "logo", Decorative: true}, ErrGraphicOptions{Field: "AltText", Value: "logo", Reason: "decorative object should not have alternative text"}},
+	} {
+		assert.Equal(t, c.err, f.AddPicture("Sheet1", "A1", img, c.opts))
+		assert.Equal(t, c.err, f.AddChart("Sheet1", "A1", &Chart{Type: Col, Series: []ChartSeries{{Values: "Sheet1!$A$1:$A$2"}}, Format: *c.opts}))
+		assert.Equal(t, c.err, f.AddShape("Sheet1", &Shape{Cell: "A1", Type: "rect", Format: *c.opts}))
+	}
+	assert.EqualError(t, f.AddPicture("Sheet1", "A1", img, &GraphicOptions{ScaleX: math.NaN()}), "invalid graphic options field ScaleX value NaN: scale must be greater than 0")
+	// Test add graph objects exceeds the worksheet bounds
+	errCol := ErrGraphicOptions{Field: "OffsetX", Value: 0, Reason: "the object exceeds the last column of the worksheet"}
+	errRow := ErrGraphicOptions{Field: "OffsetY", Value: 10, Reason: "the object exceeds the last row of the worksheet"}
+	assert.Equal(t, errCol, f.AddPicture("Sheet1", "XFD1", img, nil))
+	assert.Equal(t, errRow, f.AddPicture("Sheet1", "A1048576", img, &GraphicOptions{OffsetY: 10}))
+	assert.Equal(t, errCol, f.AddChart("Sheet1", "XFA1", &Chart{Type: Col, Series: []ChartSeries{{Values: "Sheet1!$A$1:$A$2"}}}))
+	assert.Equal(t, errRow, f.AddShape("Sheet1", &Shape{Cell: "A1048570", Type: "rect", Format: GraphicOptions{OffsetY: 10}}))
+	// Test add graph objects within the worksheet bounds
+	assert.NoError(t, f.AddPicture("Sheet1", "XFD1", img, &GraphicOptions{ScaleX: 0.4}))
+	assert.NoError(t, f.AddPicture("Sheet1", "XFD1048576", img, &GraphicOptions{Positioning: "oneCell"}))
+	assert.NoError(t, f.AddShape("Sheet1", &Shape{Cell: "A1048560", Type: "rect", Format: GraphicOptions{Hyperlink: "https://github.com/xuri/excelize", HyperlinkType: "External"}}))
+	drawings, err := f.GetDrawingObjects("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, drawings, 3)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestGraphicOptionsValidate.xlsx")))
+	assert.NoError(t, f.Close())
+}
+
+func TestGraphicAccessibility(t *testing.T) {
+	f := NewFile()
+	img := filepath.Join("test", "images", "excel.png")
+	assert.NoError(t, f.AddPicture("Sheet1", "A1", img, &GraphicOptions{AltText: "Excel Logo", Title: "Logo", Hyperlink: "https://github.com/xuri/excelize"}))
+	assert.NoError(t, f.AddPicture("Sheet1", "H1", img, &GraphicOptions{Decorative: true, Hyperlink: "#Sheet1!A10"}))
+	assert.NoError(t, f.AddChart("Sheet1", "A20", &Chart{Type: Col, Series: []ChartSeries{{Values: "Sheet1!$A$1:$A$2"}}, Format: GraphicOptions{AltText: "Sales", Title: "Sales Chart"}}))
+	assert.NoError(t, f.AddShape("Sheet1", &Shape{Cell: "H20", Type: "rect", Format: GraphicOptions{Decorative: true, Title: "Border"}}))
+	// Test the hyperlink relationships belong to the drawing part
+	drawingRels, err := f.relsReader("xl/drawings/_rels/drawing1.xml.rels")
+	assert.NoError(t, err)
+	var links []xlsxRelationship
+	for _, rel := range drawingRels.Relationships {
+		if rel.Type == SourceRelationshipHyperLink {
+			links = append(links, rel)
+		}
+	}
+	assert.Equal(t, []xlsxRelationship{
+		{ID: "rId2", Type: SourceRelationshipHyperLink, Target: "https://github.com/xuri/excelize", TargetMode: "External"},
+		{ID: "rId3", Type: SourceRelationshipHyperLink, Target: "#Sheet1!A10"},
+	}, links)
+	sheetRels, err := f.relsReader("xl/worksheets/_rels/sheet1.xml.rels")
+	assert.NoError(t, err)
+	for _, rel := range sheetRels.Relationships {
+		assert.NotEqual(t, SourceRelationshipHyperLink, rel.Type)
+	}
+	check := func(f *File) {
+		pics, err := f.GetPictures("Sheet1", "A1")
+		assert.NoError(t, err)
+		assert.Len(t, pics, 1)
+		assert.Equal(t, "Excel Logo", pics[0].Format.AltText)
+		assert.Equal(t, "Logo", pics[0].Format.Title)
+		assert.False(t, pics[0].Format.Decorative)
+		assert.Equal(t, "https://github.com/xuri/excelize", pics[0].Format.Hyperlink)
+		assert.Equal(t, "External", pics[0].Format.HyperlinkType)
+		pics, err = f.GetPictures("Sheet1", "H1")
+		assert.NoError(t, err)
+		assert.Len(t, pics, 1)
+		assert.Empty(t, pics[0].Format.AltText)
+		assert.True(t, pics[0].Format.Decorative)
+		assert.Equal(t, "#Sheet1!A10", pics[0].Format.Hyperlink)
+		assert.Equal(t, "Location", pics[0].Format.HyperlinkType)
+	}
+	check(f)
+	f.Drawings.Range(func(k, v interface{}) bool {
+		output, err := xml.Marshal(v)
+		assert.NoError(t, err)
+		assert.Contains(t, string(output), `<a:ext uri="{C183D7F6-B498-43B3-948B-1728B52AA6E4}"><adec:decorative xmlns:adec="http://schemas.microsoft.com/office/drawing/2017/decorative" val="true"></adec:decorative></a:ext>`)
+		assert.Contains(t, string(output), `descr="Sales" title="Sales Chart"`)
+		assert.Contains(t, string(output), `title="Border"`)
+		return true
+	})
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestGraphicAccessibility.xlsx")))
+	assert.NoError(t, f.Close())
+	// Test get the accessibility attributes of the pictures in the saved file
+	f, err = OpenFile(filepath.Join("test", "TestGraphicAccessibility.xlsx"))
+	assert.NoError(t, err)
+	check(f)
+	assert.NoError(t, f.Close())
+}
+
 func TestAddPictureFromBytes(t *testing.T) {
 	f := NewFile()
 	imgFile, err := os.ReadFile("logo.png")
@@ -395,6 +495,22 @@ func TestDeletePicture(t *testing.T) {
 	assert.NoError(t, f.Close())
 }
 
+func TestAddPictureAcrossHiddenColumn(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetColVisible("Sheet1", "B", false))
+	assert.NoError(t, f.AddPicture("Sheet1", "A1", filepath.Join("test", "images", "excel.png"), &GraphicOptions{OffsetX: 10}))
+	drawing, ok := f.Drawings.Load("xl/drawings/drawing1.xml")
+	assert.True(t, ok)
+	anchor := drawing.(*xlsxWsDr).TwoCellAnchor[0]
+	// The hidden column B is zero-width, so the picture with 200 pixels width
+	// ends at column D rather than column C, the expected coordinates are
+	// derived from the default column width and row height by this rule
+	assert.Equal(t, xlsxFrom{Col: 0, ColOff: PixelsToEMU(10), Row: 0, RowOff: 0}, *anchor.From)
+	assert.Equal(t, xlsxTo{Col: 3, ColOff: PixelsToEMU(42), Row: 6, RowOff: PixelsToEMU(8)}, *anchor.To)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAddPictureAcrossHiddenColumn.xlsx")))
+	assert.NoError(t, f.Close())
+}
+
 func TestDrawingResize(t *testing.T) {
 	f := NewFile()
 	// Test calculate drawing resize on not exists worksheet
@@ -13,12 +13,20 @@ package excelize
 
 import (
 	"bytes"
+	"cmp"
+	"crypto/sha256"
+	"encoding/binary"
 	"encoding/xml"
+	"fmt"
+	"hash/crc32"
 	"io"
 	"math"
 	"os"
+	"reflect"
+	"sort"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/tiendc/go-deepcopy"
 )
@@ -42,7 +50,12 @@ var duplicateHelperFunc = [3]func(*File, *xlsxWorksheet, string, int, int) error
 // the applied value will be used, otherwise the original value will be used.
 // GetRows fetched the rows with value or formula cells, the continually blank
 // cells in the tail of each row will be skipped, so the length of each row
-// may be inconsistent.
+// may be inconsistent. Set the SkipRows field of the options to skip the
+// given number of leading rows, enable the SkipHiddenRows option to omit the
+// hidden rows, and enable the SkipFilteredRows option to omit the rows hidden
+// by the AutoFilter. The errors of calculating the formula cells with the
+// CalcFormulaOnRead option will be dropped, use the rows iterator to get them
+// by the Error function.
 //
 // For example, get and traverse the value of all cells by rows on a worksheet
 // named 'Sheet1':
@@ -59,15 +72,22 @@ var duplicateHelperFunc = [3]func(*File, *xlsxWorksheet, string, int, int) error
 //	    fmt.Println()
 //	}
 func (f *File) GetRows(sheet string, opts ...Options) ([][]string, error) {
-	rows, err := f.Rows(sheet)
+	rows, err := f.Rows(sheet, opts...)
 	if err != nil {
 		return nil, err
 	}
-	results, cur, maxVal := make([][]string, 0, 64), 0, 0
+	skip := max(getCallOptions(opts...).SkipRows, 0)
+	results, cur, maxVal := make([][]string, 0, 64), 0, skip
 	for rows.Next() {
-		cur++
+		if cur++; cur <= skip {
+			continue
+		}
 		row, err := rows.Columns(opts...)
 		if err != nil {
+			if isTransformCellValueError(err) {
+				_ = rows.Close()
+				return nil, err
+			}
 			break
 		}
 		if len(row) > 0 {
@@ -78,7 +98,226 @@ func (f *File) GetRows(sheet string, opts ...Options) ([][]string, error) {
 			maxVal = cur
 		}
 	}
-	return results[:maxVal], rows.Close()
+	return results[:maxVal-skip], rows.Close()
+}
+
+// WalkRows provides a function to traverse the value of all cells by rows on
+// the worksheet based on the given worksheet name and callback function, the
+// callback function will be called with the row number and the cells value of
+// each row in ascending order. The cells value are the same as the GetRows
+// function returns, and the rows without any cell value will be skipped. The
+// rows will be read by the rows iterator without keeping all of them in
+// memory. Return ErrStopWalk in the callback function to stop the traversal
+// early without any error, and any other error returned by the callback
+// function will abort the traversal and be returned. For example, print the
+// cells value of the first 10 rows on Sheet1:
+//
+//	err := f.WalkRows("Sheet1", func(row int, cells []string) error {
+//	    fmt.Println(row, cells)
+//	    if row == 10 {
+//	        return excelize.ErrStopWalk
+//	    }
+//	    return nil
+//	})
+func (f *File) WalkRows(sheet string, fn func(row int, cells []string) error, opts ...Options) error {
+	rows, err := f.Rows(sheet, opts...)
+	if err != nil {
+		return err
+	}
+	for rows.Next() {
+		cells, err := rows.Columns(opts...)
+		if err != nil {
+			_ = rows.Close()
+			return err
+		}
+		if len(cells) == 0 {
+			continue
+		}
+		if err = fn(rows.seekRow, cells); err != nil {
+			if closeErr := rows.Close(); err == ErrStopWalk {
+				return closeErr
+			}
+			return err
+		}
+	}
+	return rows.Close()
+}
+
+// GetNumericRange provides a function to get the numeric value of cells in the
+// given range reference on the worksheet as a matrix of float64, and a mask
+// matrix which indicates which cells are numeric. This function streams the
+// worksheet and parses numeric cells directly from the stored value, without
+// applying the number format, so it's much faster than GetRows for reading
+// large numeric data blocks. The date and time cells will be returned as the
+// serial number, and the text, boolean, error and blank cells will be marked
+// as false in the mask matrix with zero value. The returned matrices are
+// clamped to the used range of the worksheet, so the trailing rows and columns
+// without any cell in the given range will not be included. For example, read
+// the cells in range A1:C1000 on Sheet1:
+//
+//	values, mask, err := f.GetNumericRange("Sheet1", "A1:C1000")
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	for r, row := range values {
+//	    for c, value := range row {
+//	        if mask[r][c] {
+//	            fmt.Print(value, "\t")
+//	        }
+//	    }
+//	    fmt.Println()
+//	}
+func (f *File) GetNumericRange(sheet, rangeRef string) ([][]float64, [][]bool, error) {
+	if !strings.Contains(rangeRef, ":") {
+		rangeRef += ":" + rangeRef
+	}
+	coordinates, err := rangeRefToCoordinates(rangeRef)
+	if err != nil {
+		return nil, nil, err
+	}
+	_ = sortCoordinates(coordinates)
+	rows, err := f.Rows(sheet)
+	if err != nil {
+		return nil, nil, err
+	}
+	var (
+		rowNum, lastRow, lastCol int
+		cells                    []numericCell
+	)
+	for {
+		var token xml.Token
+		if token, err = rows.decoder.RawToken(); err != nil {
+			if err == io.EOF {
+				err = nil
+			}
+			break
+		}
+		if xmlElement, ok := token.(xml.EndElement); ok && xmlElement.Name.Local == "sheetData" {
+			break
+		}
+		xmlElement, ok := token.(xml.StartElement)
+		if !ok || xmlElement.Name.Local != "row" {
+			continue
+		}
+		if r, _ := attrValToInt("r", xmlElement.Attr); r != 0 {
+			rowNum = r
+		} else {
+			rowNum++
+		}
+		if rowNum > coordinates[3] {
+			break
+		}
+		if rowNum < coordinates[1] {
+			continue
+		}
+		var maxCol int
+		if cells, maxCol, err = rows.numericRowHandler(coordinates, rowNum, cells); err != nil {
+			break
+		}
+		if maxCol > 0 {
+			lastRow, lastCol = rowNum, max(lastCol, maxCol)
+		}
+	}
+	if closeErr := rows.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	var width, height int
+	if lastRow > 0 {
+		width, height = lastCol-coordinates[0]+1, lastRow-coordinates[1]+1
+	}
+	values, mask := make([][]float64, height), make([][]bool, height)
+	valueData, maskData := make([]float64, width*height), make([]bool, width*height)
+	for i := range values {
+		values[i], mask[i] = valueData[i*width:(i+1)*width:(i+1)*width], maskData[i*width:(i+1)*width:(i+1)*width]
+	}
+	for _, cell := range cells {
+		values[cell.row-coordinates[1]][cell.col-coordinates[0]] = cell.value
+		mask[cell.row-coordinates[1]][cell.col-coordinates[0]] = true
+	}
+	return values, mask, err
+}
+
+// numericCell directly maps the numeric cell value with the coordinates which
+// read by the GetNumericRange function.
+type numericCell struct {
+	row, col int
+	value    float64
+}
+
+// numericRowHandler parse the numeric cells of the current row XML element of
+// the worksheet in the given coordinates by raw tokens, and append the values
+// into the given slice. This function returns the column number of the last
+// cell within the given coordinates in the row, or zero if there isn't one.
+func (rows *Rows) numericRowHandler(coordinates []int, rowNum int, cells []numericCell) ([]numericCell, int, error) {
+	var (
+		col, maxCol            int
+		numeric, date, inValue bool
+		value                  strings.Builder
+	)
+	for {
+		token, err := rows.decoder.RawToken()
+		if err != nil {
+			return cells, maxCol, err
+		}
+		switch xmlElement := token.(type) {
+		case xml.StartElement:
+			switch xmlElement.Name.Local {
+			case "c":
+				col++
+				numeric, date = true, false
+				for _, attr := range xmlElement.Attr {
+					switch attr.Name.Local {
+					case "r":
+						if col, _, err = CellNameToCoordinates(attr.Value); err != nil {
+							return cells, maxCol, err
+						}
+					case "t":
+						date = attr.Value == "d"
+						numeric = attr.Value == "" || attr.Value == "n" || date
+					}
+				}
+				if col >= coordinates[0] && col <= coordinates[2] {
+					maxCol = col
+				} else {
+					numeric = false
+				}
+			case "v":
+				inValue = numeric
+				value.Reset()
+			}
+		case xml.CharData:
+			if inValue {
+				value.Write(xmlElement)
+			}
+		case xml.EndElement:
+			switch xmlElement.Name.Local {
+			case "v":
+				if inValue {
+					if val, ok := parseNumericCellValue(value.String(), date); ok {
+						cells = append(cells, numericCell{row: rowNum, col: col, value: val})
+					}
+				}
+				inValue = false
+			case "row":
+				return cells, maxCol, nil
+			}
+		}
+	}
+}
+
+// parseNumericCellValue parse the stored value of the numeric or date cell,
+// the date cell value in the ISO 8601 format will be converted to the serial
+// number.
+func parseNumericCellValue(val string, date bool) (float64, bool) {
+	if date {
+		return parseCellDate(val, false)
+	}
+	num, err := strconv.ParseFloat(val, 64)
+	return num, err == nil && !math.IsNaN(num) && !math.IsInf(num, 0)
 }
 
 // Rows defines an iterator to a sheet.
@@ -86,36 +325,67 @@ type Rows struct {
 	err                     error
 	curRow, seekRow         int
 	needClose, rawCellValue bool
+	calcFormula             bool
+	formulaText             bool
+	skipHiddenRows          bool
+	filterStart, filterEnd  int
 	sheet                   string
+	sharedFormulas          map[int]xlsxC
 	f                       *File
 	tempFile                *os.File
 	sst                     *xlsxSST
 	decoder                 *xml.Decoder
 	token                   xml.Token
 	curRowOpts, seekRowOpts RowOpts
+	baseOffset, tokenOffset int64
+	rowOffset               int64
+	contentHash             []byte
+	colFilter               map[int][]int
+	colFilterLen            int
+	ignoreInvalidCellRef    bool
+	warnings                []error
+	transformer             func(string, CellMeta, string) (string, error)
 }
 
 // Next will return true if it finds the next row element.
 func (rows *Rows) Next() bool {
+	if rows.err == ErrMaxRows {
+		return false
+	}
 	rows.seekRow++
 	if rows.curRow >= rows.seekRow {
-		rows.curRowOpts = rows.seekRowOpts
-		return true
+		if !rows.isHiddenRow() {
+			rows.curRowOpts = rows.seekRowOpts
+			return true
+		}
+		rows.seekRow, rows.token = rows.seekRow+1, nil
 	}
 	for {
-		token, _ := rows.decoder.Token()
+		token := rows.nextToken()
 		if token == nil {
 			return false
 		}
 		switch xmlElement := token.(type) {
 		case xml.StartElement:
 			if xmlElement.Name.Local == "row" {
+				rowNum, _ := attrValToInt("r", xmlElement.Attr)
+				if rows.exceedRowLimit(rowNum) {
+					if rows.err != nil {
+						return false
+					}
+					continue
+				}
 				rows.curRow++
-				if rowNum, _ := attrValToInt("r", xmlElement.Attr); rowNum != 0 {
+				if rowNum != 0 {
 					rows.curRow = rowNum
 				}
-				rows.token = token
+				rows.token, rows.rowOffset = token, rows.tokenOffset
 				rows.curRowOpts = extractRowOpts(xmlElement.Attr)
+				rows.seekRowOpts = rows.curRowOpts
+				if rows.isHiddenRow() {
+					rows.seekRow, rows.token = rows.seekRow+1, nil
+					continue
+				}
 				return true
 			}
 		case xml.EndElement:
@@ -126,6 +396,46 @@ func (rows *Rows) Next() bool {
 	}
 }
 
+// exceedRowLimit returns true if the row number exceeds the maximum limit, the
+// row element will be skipped, or the ErrMaxRows error will be recorded if the
+// StrictRowLimit option is enabled.
+func (rows *Rows) exceedRowLimit(rowNum int) bool {
+	if rowNum <= TotalRows {
+		return false
+	}
+	if rows.f.options != nil && rows.f.options.StrictRowLimit {
+		rows.err = ErrMaxRows
+		return true
+	}
+	_ = rows.decoder.Skip()
+	return true
+}
+
+// nextToken reads the next token of the worksheet and records the offset of
+// the token in the worksheet XML.
+func (rows *Rows) nextToken() xml.Token {
+	rows.tokenOffset = rows.baseOffset + rows.decoder.InputOffset()
+	token, _ := rows.decoder.Token()
+	return token
+}
+
+// isHiddenRow returns true if the seeking row is the hidden row which has
+// been read, and the hidden rows or the rows hidden by the AutoFilter should
+// be skipped.
+func (rows *Rows) isHiddenRow() bool {
+	if rows.curRow != rows.seekRow || !rows.seekRowOpts.Hidden {
+		return false
+	}
+	return rows.skipHiddenRows || (rows.filterStart <= rows.seekRow && rows.seekRow <= rows.filterEnd)
+}
+
+// CurrentRow returns the row number of the current row, the row number
+// starts from 1, and 0 will be returned before the first call of the Next
+// function.
+func (rows *Rows) CurrentRow() int {
+	return rows.seekRow
+}
+
 // GetRowOpts will return the RowOpts of the current row.
 func (rows *Rows) GetRowOpts() RowOpts {
 	return rows.curRowOpts
@@ -136,6 +446,25 @@ func (rows *Rows) Error() error {
 	return rows.err
 }
 
+// Warnings returns the problems of the cells which have been skipped by the
+// rows iterator with the IgnoreInvalidCellRef option, each of them is an
+// ErrInvalidCellRef error.
+func (rows *Rows) Warnings() []error {
+	return rows.warnings
+}
+
+// invalidCellRef provides a function to check the error of parsing the cell
+// reference by the rows iterator, returns the error if the
+// IgnoreInvalidCellRef option is disabled, otherwise the error will be kept
+// as a warning.
+func (rows *Rows) invalidCellRef(ref string, err error) error {
+	if !rows.ignoreInvalidCellRef {
+		return err
+	}
+	rows.warnings = append(rows.warnings, ErrInvalidCellRef{Sheet: rows.sheet, Row: rows.curRow, Ref: ref, Err: err})
+	return nil
+}
+
 // Close closes the open worksheet XML file in the system temporary
 // directory.
 func (rows *Rows) Close() error {
@@ -147,31 +476,97 @@ func (rows *Rows) Close() error {
 	return nil
 }
 
+// SetColumnFilter provides a function to set the columns to be read by the
+// rows iterator by given column names. The cells out of the filter will be
+// skipped without decoding, and the Columns function will return the values
+// of the requested columns only in the requested order, the empty cells will
+// be returned as empty strings. The filter will be removed if no column
+// specified. For example, read the columns B, D and K on Sheet1:
+//
+//	rows, err := f.Rows("Sheet1")
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	if err = rows.SetColumnFilter("B", "D", "K"); err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	for rows.Next() {
+//	    row, err := rows.Columns()
+//	    if err != nil {
+//	        fmt.Println(err)
+//	    }
+//	    fmt.Println(row[0], row[1], row[2])
+//	}
+func (rows *Rows) SetColumnFilter(cols ...string) error {
+	if len(cols) == 0 {
+		rows.colFilter, rows.colFilterLen = nil, 0
+		return nil
+	}
+	colFilter := make(map[int][]int, len(cols))
+	for idx, col := range cols {
+		colNum, err := ColumnNameToNumber(col)
+		if err != nil {
+			return err
+		}
+		colFilter[colNum] = append(colFilter[colNum], idx)
+	}
+	rows.colFilter, rows.colFilterLen = colFilter, len(cols)
+	return nil
+}
+
+// newFilteredCells provides a function to create the cells of the row for
+// the column filter of the rows iterator with the given blank cell value,
+// returns nil without filter.
+func (rows *Rows) newFilteredCells(blank string) []string {
+	if rows.colFilter == nil {
+		return nil
+	}
+	cells := make([]string, rows.colFilterLen)
+	if blank != "" {
+		for idx := range cells {
+			cells[idx] = blank
+		}
+	}
+	return cells
+}
+
 // Columns return the current row's column values. This fetches the worksheet
 // data as a stream, returns each cell in a row as is, and will not skip empty
 // rows in the tail of the worksheet.
 func (rows *Rows) Columns(opts ...Options) ([]string, error) {
+	options := rows.f.getOptions(opts...)
 	if rows.curRow > rows.seekRow {
-		return nil, nil
+		return rows.newFilteredCells(options.BlankCellValue), nil
 	}
-	var rowIterator rowXMLIterator
+	rowIterator := rowXMLIterator{cells: rows.newFilteredCells(options.BlankCellValue), blankValue: options.BlankCellValue}
 	var token xml.Token
-	rows.rawCellValue = rows.f.getOptions(opts...).RawCellValue
+	callOpts := getCallOptions(opts...)
+	rows.rawCellValue, rows.transformer = options.RawCellValue, options.CellValueTransformer
+	rows.calcFormula, rows.formulaText = callOpts.CalcFormulaOnRead, callOpts.FormulaTextOnRead
 	if rows.sst, rowIterator.err = rows.f.sharedStringsReader(); rowIterator.err != nil {
 		return rowIterator.cells, rowIterator.err
 	}
 	for {
 		if rows.token != nil {
 			token = rows.token
-		} else if token, _ = rows.decoder.Token(); token == nil {
+		} else if token = rows.nextToken(); token == nil {
 			break
 		}
 		switch xmlElement := token.(type) {
 		case xml.StartElement:
 			rowIterator.inElement = xmlElement.Name.Local
 			if rowIterator.inElement == "row" {
+				rows.rowOffset = rows.tokenOffset
 				rowNum := 0
-				if rowNum, rowIterator.err = attrValToInt("r", xmlElement.Attr); rowNum != 0 {
+				if rowNum, rowIterator.err = attrValToInt("r", xmlElement.Attr); rows.exceedRowLimit(rowNum) {
+					if rows.token = nil; rows.err != nil {
+						return rowIterator.cells, rowIterator.err
+					}
+					continue
+				}
+				if rowNum != 0 {
 					rows.curRow = rowNum
 				} else if rows.token == nil {
 					rows.curRow++
@@ -212,10 +607,11 @@ func extractRowOpts(attrs []xml.Attr) RowOpts {
 	return rowOpts
 }
 
-// appendSpace append blank characters to slice by given length and source slice.
-func appendSpace(l int, s []string) []string {
+// appendSpace append blank characters to slice by given length, source slice
+// and the value of the blank cells.
+func appendSpace(l int, s []string, v string) []string {
 	for i := 1; i < l; i++ {
-		s = append(s, "")
+		s = append(s, v)
 	}
 	return s
 }
@@ -226,24 +622,83 @@ type rowXMLIterator struct {
 	inElement        string
 	cellCol, cellRow int
 	cells            []string
+	withTypes        bool
+	typedCells       []TypedCell
+	withRaw          bool
+	rawCells         []string
+	blankValue       string
 }
 
 // rowXMLHandler parse the row XML element of the worksheet.
 func (rows *Rows) rowXMLHandler(rowIterator *rowXMLIterator, xmlElement *xml.StartElement, raw bool) {
 	if rowIterator.inElement == "c" {
 		rowIterator.cellCol++
+		if rows.skipCell(rowIterator, xmlElement) {
+			return
+		}
 		colCell := xlsxC{}
 		colCell.cellXMLHandler(rows.decoder, xmlElement)
 		if colCell.R != "" {
-			if rowIterator.cellCol, _, rowIterator.err = CellNameToCoordinates(colCell.R); rowIterator.err != nil {
-				return
+			col, _, err := CellNameToCoordinates(colCell.R)
+			if err != nil {
+				if rowIterator.err = rows.invalidCellRef(colCell.R, err); rowIterator.err != nil {
+					return
+				}
+			} else {
+				rowIterator.cellCol = col
 			}
 		}
 		blank := rowIterator.cellCol - len(rowIterator.cells)
-		if val, _ := colCell.getValueFrom(rows.f, rows.sst, raw); val != "" || colCell.F != nil {
-			rowIterator.cells = append(appendSpace(blank, rowIterator.cells), val)
+		if val, _ := colCell.getValueFrom(rows.f, rows.sst, raw); val != "" || colCell.F != nil || (rowIterator.blankValue != "" && colCell.hasStringValue()) {
+			if colCell.F != nil && (rows.calcFormula || rows.formulaText) {
+				var err error
+				if rows.sharedFormulas == nil {
+					rows.sharedFormulas = make(map[int]xlsxC)
+				}
+				cell, _ := CoordinatesToCellName(rowIterator.cellCol, rows.curRow)
+				if val, err = rows.f.getFormulaCellValue(rows.sheet, cell, &colCell, val, raw, rows.formulaText, rows.sharedFormulas); err != nil {
+					rows.err = err
+				}
+			}
+			if rows.transformer != nil {
+				cell, _ := CoordinatesToCellName(rowIterator.cellCol, rows.curRow)
+				if val, rowIterator.err = rows.f.transformCellValue(rows.transformer, rows.sheet, cell, &colCell, val); rowIterator.err != nil {
+					return
+				}
+			}
+			if rows.colFilter != nil {
+				for _, idx := range rows.colFilter[rowIterator.cellCol] {
+					rowIterator.cells[idx] = val
+				}
+				return
+			}
+			rowIterator.cells = append(appendSpace(blank, rowIterator.cells, rowIterator.blankValue), val)
+		}
+	}
+}
+
+// skipCell provides a function to check if the cell is out of the column
+// filter of the rows iterator by the r attribute of the cell element, and
+// skip the cell element without decoding it.
+func (rows *Rows) skipCell(rowIterator *rowXMLIterator, xmlElement *xml.StartElement) bool {
+	if rows.colFilter == nil {
+		return false
+	}
+	for _, attr := range xmlElement.Attr {
+		if attr.Name.Local == "r" {
+			col, _, err := CellNameToCoordinates(attr.Value)
+			if err != nil {
+				return false
+			}
+			rowIterator.cellCol = col
+			break
 		}
 	}
+	if _, ok := rows.colFilter[rowIterator.cellCol]; ok {
+		return false
+	}
+	rowIterator.err = rows.decoder.Skip()
+	return true
 }
 
 // cellXMLAttrHandler parse the cell XML element attributes of the worksheet.
@@ -303,9 +758,54 @@ func (cell *xlsxC) cellXMLHandler(decoder *xml.Decoder, start *xml.StartElement)
 	}
 }
 
+// getFormulaCellValue provides a function to get the value of the formula
+// cell by given worksheet name, cell reference, cell, the cached value of the
+// cell and the shared formulas which have been read by the iterator. The
+// decoded formula of the cell will be returned if formulaText is true,
+// otherwise the formula will be calculated if the cell has no cached value.
+// The empty value will be returned with the error if the formula can't be
+// calculated.
+func (f *File) getFormulaCellValue(sheet, cell string, c *xlsxC, val string, raw, formulaText bool, shared map[int]xlsxC) (string, error) {
+	if c.F.T == STCellFormulaTypeShared && c.F.Si != nil && c.F.Ref != "" {
+		shared[*c.F.Si] = xlsxC{R: cell, F: &xlsxF{Content: c.F.Content}}
+	}
+	if !formulaText && c.V != "" {
+		return val, nil
+	}
+	formula, err := f.getDecodedCellFormula(sheet, cell, c, shared)
+	if err != nil || formulaText {
+		return formula, err
+	}
+	if formula == "" {
+		return val, err
+	}
+	result, err := f.calcFormulaValue(sheet, cell, formula, c.S, raw)
+	if err != nil {
+		return "", err
+	}
+	return result, err
+}
+
+// getDecodedCellFormula provides a function to get the formula of the cell
+// which has been decoded by the rows or columns iterator. The formula of the
+// shared formula cell will be converted from the master cell which has been
+// read by the iterator, and the worksheet will be loaded to find the master
+// cell only if it hasn't been read, such as out of the column range.
+func (f *File) getDecodedCellFormula(sheet, cell string, c *xlsxC, shared map[int]xlsxC) (string, error) {
+	if c.F.T != STCellFormulaTypeShared || c.F.Si == nil || c.F.Ref != "" {
+		return c.F.Content, nil
+	}
+	if master, ok := shared[*c.F.Si]; ok {
+		return master.convertSharedFormula(cell)
+	}
+	return f.GetCellFormula(sheet, cell)
+}
+
 // Rows returns a rows iterator, used for streaming reading data for a
-// worksheet with a large data. This function is concurrency safe. For
-// example:
+// worksheet with a large data. The hidden rows will be skipped by the Next
+// function if the SkipHiddenRows option is enabled, and the rows hidden by
+// the AutoFilter will be skipped if the SkipFilteredRows option is enabled.
+// This function is concurrency safe. For example:
 //
 //	rows, err := f.Rows("Sheet1")
 //	if err != nil {
@@ -325,7 +825,7 @@ func (cell *xlsxC) cellXMLHandler(decoder *xml.Decoder, start *xml.StartElement)
 //	if err = rows.Close(); err != nil {
 //	    fmt.Println(err)
 //	}
-func (f *File) Rows(sheet string) (*Rows, error) {
+func (f *File) Rows(sheet string, opts ...Options) (*Rows, error) {
 	if err := checkSheetName(sheet); err != nil {
 		return nil, err
 	}
@@ -333,107 +833,370 @@ func (f *File) Rows(sheet string) (*Rows, error) {
 	if !ok {
 		return nil, ErrSheetNotExist{sheet}
 	}
-	if worksheet, ok := f.Sheet.Load(name); ok && worksheet != nil {
-		ws := worksheet.(*xlsxWorksheet)
-		ws.mu.Lock()
-		defer ws.mu.Unlock()
-		// Flush data
-		output, _ := xml.Marshal(ws)
-		f.saveFileList(name, f.replaceNameSpaceBytes(name, output))
-	}
+	f.flushWorksheet(name)
 	var err error
-	rows := Rows{f: f, sheet: name}
+	callOpts := getCallOptions(opts...)
+	rows := Rows{f: f, sheet: sheet, skipHiddenRows: callOpts.SkipHiddenRows, ignoreInvalidCellRef: callOpts.IgnoreInvalidCellRef}
+	if callOpts.SkipFilteredRows {
+		if rows.filterStart, rows.filterEnd, err = f.getAutoFilterRows(name); err != nil {
+			return nil, err
+		}
+	}
 	rows.needClose, rows.decoder, rows.tempFile, err = f.xmlDecoder(name)
 	return &rows, err
 }
 
-// getFromStringItem build shared string item offset list from system temporary
-// file at one time, and return value by given to string index.
-func (f *File) getFromStringItem(index int) string {
-	if f.sharedStringTemp != nil {
-		if len(f.sharedStringItem) <= index {
-			return strconv.Itoa(index)
-		}
-		offsetRange := f.sharedStringItem[index]
-		buf := make([]byte, offsetRange[1]-offsetRange[0])
-		if _, err := f.sharedStringTemp.ReadAt(buf, int64(offsetRange[0])); err != nil {
-			return strconv.Itoa(index)
-		}
-		return string(buf)
+// getAutoFilterRows provides a function to get the first and last row number
+// of the data rows in the AutoFilter range by given worksheet XML path, the
+// header row of the range is excluded. The worksheet XML will be read in a
+// streaming pass, and zeros will be returned if the worksheet has no
+// AutoFilter.
+func (f *File) getAutoFilterRows(name string) (int, int, error) {
+	needClose, decoder, tempFile, err := f.xmlDecoder(name)
+	if needClose && tempFile != nil {
+		defer tempFile.Close()
 	}
-	needClose, decoder, tempFile, err := f.xmlDecoder(defaultXMLPathSharedStrings)
-	if needClose && err == nil {
-		defer func() {
-			err = tempFile.Close()
-		}()
+	if err != nil {
+		return 0, 0, err
 	}
-	f.sharedStringItem = [][]uint{}
-	f.sharedStringTemp, _ = os.CreateTemp("", "excelize-")
-	f.tempFiles.Store(defaultTempFileSST, f.sharedStringTemp.Name())
-	var (
-		inElement string
-		i, offset uint
-	)
+	return autoFilterRows(decoder)
+}
+
+// autoFilterRows provides a function to get the first and last row number of
+// the data rows in the AutoFilter range by reading the worksheet XML from the
+// current position of the given decoder, the sheet data will be skipped.
+func autoFilterRows(decoder *xml.Decoder) (int, int, error) {
 	for {
 		token, _ := decoder.Token()
 		if token == nil {
-			break
+			return 0, 0, nil
 		}
-		switch xmlElement := token.(type) {
-		case xml.StartElement:
-			inElement = xmlElement.Name.Local
-			if inElement == "si" {
-				si := xlsxSI{}
-				_ = decoder.DecodeElement(&si, &xmlElement)
-
-				startIdx := offset
-				n, _ := f.sharedStringTemp.WriteString(si.String())
-				offset += uint(n)
-				f.sharedStringItem = append(f.sharedStringItem, []uint{startIdx, offset})
-				i++
+		xmlElement, ok := token.(xml.StartElement)
+		if !ok {
+			continue
+		}
+		switch xmlElement.Name.Local {
+		case "sheetData":
+			if err := decoder.Skip(); err != nil {
+				return 0, 0, err
+			}
+		case "autoFilter":
+			for _, attr := range xmlElement.Attr {
+				if attr.Name.Local != "ref" {
+					continue
+				}
+				coordinates, err := rangeRefToCoordinates(attr.Value)
+				if err != nil {
+					return 0, 0, err
+				}
+				_ = sortCoordinates(coordinates)
+				return coordinates[1] + 1, coordinates[3], err
 			}
+			return 0, 0, nil
 		}
 	}
-	return f.getFromStringItem(index)
 }
 
-// xmlDecoder creates XML decoder by given path in the zip from memory data
-// or system temporary file.
-func (f *File) xmlDecoder(name string) (bool, *xml.Decoder, *os.File, error) {
-	var (
-		content  []byte
-		err      error
-		tempFile *os.File
-	)
-	if content = f.readXML(name); len(content) > 0 {
-		return false, f.xmlNewDecoder(bytes.NewReader(content)), tempFile, err
-	}
-	tempFile, err = f.readTemp(name)
-	return true, f.xmlNewDecoder(tempFile), tempFile, err
-}
+// rowsCheckpointVersion defined the version of the rows iterator checkpoint
+// format, and rowsCheckpointPrefix defined the ancestor elements of the rows
+// for resuming the worksheet XML decoding from the middle of the sheet data.
+const (
+	rowsCheckpointVersion = 1
+	rowsCheckpointPrefix  = "<worksheet><sheetData>"
+)
 
-// SetRowHeight provides a function to set the height of a single row. If the
-// value of height is 0, will hide the specified row, if the value of height is
-// -1, will unset the custom row height. For example, set the height of the
-// first row in Sheet1:
-//
-//	err := f.SetRowHeight("Sheet1", 1, 50)
-func (f *File) SetRowHeight(sheet string, row int, height float64) error {
-	if row < 1 {
-		return newInvalidRowNumberError(row)
-	}
-	if height > MaxRowHeight {
-		return ErrMaxRowHeight
-	}
-	if height < -1 {
-		return ErrParameterInvalid
-	}
-	ws, err := f.workSheetReader(sheet)
-	if err != nil {
-		return err
-	}
+// The rows iterator checkpoint consists of the format version, the SHA-256
+// hash of the worksheet content, the offset of the row element in the
+// worksheet XML, the row number of the row element, the row number of the
+// seeking row, the option flags and the CRC-32 checksum of the preceding
+// fields. These constants defined the positions of the fields.
+const (
+	cpVersionPos  = 0
+	cpHashPos     = cpVersionPos + 1
+	cpOffsetPos   = cpHashPos + sha256.Size
+	cpCurRowPos   = cpOffsetPos + 8
+	cpSeekRowPos  = cpCurRowPos + 4
+	cpFlagsPos    = cpSeekRowPos + 4
+	cpChecksumPos = cpFlagsPos + 1
+	cpLength      = cpChecksumPos + 4
+)
 
-	ws.prepareSheetXML(0, row)
+// cpFlagSkipHiddenRows and cpFlagSkipFilteredRows defined the option flags
+// of the rows iterator checkpoint for skipping hidden rows and the rows hidden
+// by the AutoFilter.
+const (
+	cpFlagSkipHiddenRows   byte = 1
+	cpFlagSkipFilteredRows byte = 2
+)
+
+// Checkpoint returns an opaque checkpoint of the rows iterator, which can be
+// used by the RowsFromCheckpoint function to create a rows iterator that
+// continues from the next row of the current row without re-reading the
+// previous rows. The checkpoint contains the offset of the current row in the
+// worksheet XML and the hash of the worksheet content, so the checkpoint will
+// be invalidated if the worksheet has been changed. For example, save the
+// checkpoint every 10000 rows:
+//
+//	rows, err := f.Rows("Sheet1")
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	for rows.Next() {
+//	    row, err := rows.Columns()
+//	    if err != nil {
+//	        fmt.Println(err)
+//	        break
+//	    }
+//	    process(row)
+//	    if rows.CurrentRow()%10000 == 0 {
+//	        checkpoint, err := rows.Checkpoint()
+//	        if err != nil {
+//	            fmt.Println(err)
+//	            break
+//	        }
+//	        save(checkpoint)
+//	    }
+//	}
+func (rows *Rows) Checkpoint() ([]byte, error) {
+	if rows.contentHash == nil {
+		hash, err := rows.f.sheetContentHash(rows.sheet)
+		if err != nil {
+			return nil, err
+		}
+		rows.contentHash = hash
+	}
+	buf := make([]byte, cpLength)
+	buf[cpVersionPos] = rowsCheckpointVersion
+	copy(buf[cpHashPos:cpOffsetPos], rows.contentHash)
+	binary.LittleEndian.PutUint64(buf[cpOffsetPos:cpCurRowPos], uint64(rows.rowOffset))
+	binary.LittleEndian.PutUint32(buf[cpCurRowPos:cpSeekRowPos], uint32(rows.curRow))
+	binary.LittleEndian.PutUint32(buf[cpSeekRowPos:cpFlagsPos], uint32(rows.seekRow))
+	if rows.skipHiddenRows {
+		buf[cpFlagsPos] |= cpFlagSkipHiddenRows
+	}
+	if rows.filterEnd > 0 {
+		buf[cpFlagsPos] |= cpFlagSkipFilteredRows
+	}
+	binary.LittleEndian.PutUint32(buf[cpChecksumPos:], crc32.ChecksumIEEE(buf[:cpChecksumPos]))
+	return buf, nil
+}
+
+// RowsFromCheckpoint returns a rows iterator by given worksheet name and the
+// checkpoint created by the Checkpoint function of the rows iterator, the
+// rows iterator continues from the next row of the row on which the
+// checkpoint was created. It returns ErrRowsCheckpoint if the checkpoint is
+// invalid or the worksheet has been changed after the checkpoint was
+// created. For example, resume the rows processing from the saved checkpoint:
+//
+//	rows, err := f.RowsFromCheckpoint("Sheet1", checkpoint)
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	for rows.Next() {
+//	    row, err := rows.Columns()
+//	    if err != nil {
+//	        fmt.Println(err)
+//	        break
+//	    }
+//	    process(row)
+//	}
+//	if err = rows.Close(); err != nil {
+//	    fmt.Println(err)
+//	}
+func (f *File) RowsFromCheckpoint(sheet string, checkpoint []byte) (*Rows, error) {
+	if len(checkpoint) != cpLength || checkpoint[cpVersionPos] != rowsCheckpointVersion ||
+		crc32.ChecksumIEEE(checkpoint[:cpChecksumPos]) != binary.LittleEndian.Uint32(checkpoint[cpChecksumPos:]) {
+		return nil, ErrRowsCheckpoint
+	}
+	rows, err := f.Rows(sheet, Options{
+		SkipHiddenRows:   checkpoint[cpFlagsPos]&cpFlagSkipHiddenRows != 0,
+		SkipFilteredRows: checkpoint[cpFlagsPos]&cpFlagSkipFilteredRows != 0,
+	})
+	if err != nil {
+		return rows, err
+	}
+	if err = rows.resume(checkpoint); err != nil {
+		_ = rows.Close()
+		return nil, err
+	}
+	return rows, err
+}
+
+// resume provides a function to move the rows iterator to the position
+// recorded in the given checkpoint.
+func (rows *Rows) resume(checkpoint []byte) error {
+	hash, err := rows.f.sheetContentHash(rows.sheet)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(hash, checkpoint[cpHashPos:cpOffsetPos]) {
+		return ErrRowsCheckpoint
+	}
+	rows.contentHash = hash
+	offset := int64(binary.LittleEndian.Uint64(checkpoint[cpOffsetPos:cpCurRowPos]))
+	curRow := int(binary.LittleEndian.Uint32(checkpoint[cpCurRowPos:cpSeekRowPos]))
+	seekRow := int(binary.LittleEndian.Uint32(checkpoint[cpSeekRowPos:cpFlagsPos]))
+	if curRow == 0 {
+		return err
+	}
+	var reader io.Reader
+	name, _ := rows.f.getSheetXMLPath(rows.sheet)
+	if content := rows.f.readXML(name); len(content) > 0 {
+		if offset < 0 || offset >= int64(len(content)) {
+			return ErrRowsCheckpoint
+		}
+		reader = bytes.NewReader(content[offset:])
+	} else {
+		if _, err = rows.tempFile.Seek(offset, io.SeekStart); err != nil {
+			return ErrRowsCheckpoint
+		}
+		reader = rows.tempFile
+	}
+	rows.decoder = rows.f.xmlNewDecoder(io.MultiReader(strings.NewReader(rowsCheckpointPrefix), reader))
+	rows.baseOffset = offset - int64(len(rowsCheckpointPrefix))
+	for i := 0; i < 2; i++ {
+		_, _ = rows.decoder.Token()
+	}
+	xmlElement, ok := rows.nextToken().(xml.StartElement)
+	if !ok || xmlElement.Name.Local != "row" || curRow > TotalRows || seekRow > TotalRows {
+		return ErrRowsCheckpoint
+	}
+	if rowNum, _ := attrValToInt("r", xmlElement.Attr); rowNum != 0 && rowNum != curRow {
+		return ErrRowsCheckpoint
+	}
+	rows.curRow, rows.seekRow, rows.rowOffset = curRow, seekRow, offset
+	if seekRow < curRow {
+		rows.token, rows.seekRowOpts = xml.CopyToken(xmlElement), extractRowOpts(xmlElement.Attr)
+		return err
+	}
+	if err = rows.decoder.Skip(); err != nil {
+		return ErrRowsCheckpoint
+	}
+	return err
+}
+
+// sheetContentHash returns the SHA-256 hash of the worksheet XML content by
+// given worksheet name.
+func (f *File) sheetContentHash(sheet string) ([]byte, error) {
+	name, ok := f.getSheetXMLPath(sheet)
+	if !ok {
+		return nil, ErrSheetNotExist{sheet}
+	}
+	hash := sha256.New()
+	if content := f.readXML(name); len(content) > 0 {
+		_, _ = hash.Write(content)
+		return hash.Sum(nil), nil
+	}
+	tempFile, err := f.readTemp(name)
+	if err != nil || tempFile == nil {
+		return hash.Sum(nil), err
+	}
+	defer tempFile.Close()
+	_, err = io.Copy(hash, tempFile)
+	return hash.Sum(nil), err
+}
+
+// getFromStringItem build shared string item offset list from system temporary
+// file at one time, and return value by given to string index.
+func (f *File) getFromStringItem(index int) string {
+	if f.sharedStringTemp != nil {
+		if index < 0 || len(f.sharedStringItem) <= index {
+			return strconv.Itoa(index)
+		}
+		offsetRange := f.sharedStringItem[index]
+		buf := make([]byte, offsetRange[1]-offsetRange[0])
+		if _, err := f.sharedStringTemp.ReadAt(buf, int64(offsetRange[0])); err != nil {
+			return strconv.Itoa(index)
+		}
+		return string(buf)
+	}
+	needClose, decoder, tempFile, err := f.xmlDecoder(defaultXMLPathSharedStrings)
+	if needClose && err == nil {
+		defer func() {
+			err = tempFile.Close()
+		}()
+	}
+	f.sharedStringItem = [][]uint{}
+	f.sharedStringTemp, _ = os.CreateTemp("", "excelize-")
+	f.tempFiles.Store(defaultTempFileSST, f.sharedStringTemp.Name())
+	var (
+		inElement string
+		i, offset uint
+	)
+	for {
+		token, _ := decoder.Token()
+		if token == nil {
+			break
+		}
+		switch xmlElement := token.(type) {
+		case xml.StartElement:
+			inElement = xmlElement.Name.Local
+			if inElement == "si" {
+				si := xlsxSI{}
+				_ = decoder.DecodeElement(&si, &xmlElement)
+
+				startIdx := offset
+				n, _ := f.sharedStringTemp.WriteString(si.String())
+				offset += uint(n)
+				f.sharedStringItem = append(f.sharedStringItem, []uint{startIdx, offset})
+				i++
+			}
+		}
+	}
+	return f.getFromStringItem(index)
+}
+
+// xmlDecoder creates XML decoder by given path in the zip from memory data
+// or system temporary file.
+func (f *File) xmlDecoder(name string) (bool, *xml.Decoder, *os.File, error) {
+	var (
+		content  []byte
+		err      error
+		tempFile *os.File
+	)
+	if content = f.readXML(name); len(content) > 0 {
+		return false, f.xmlNewDecoder(bytes.NewReader(content)), tempFile, err
+	}
+	tempFile, err = f.readTemp(name)
+	return true, f.xmlNewDecoder(tempFile), tempFile, err
+}
+
+// checkRowNumber provides a function to check if the given row number is
+// within the bounds of the worksheet.
+func checkRowNumber(row int) error {
+	if row < 1 {
+		return newInvalidRowNumberError(row)
+	}
+	if row > TotalRows {
+		return ErrMaxRows
+	}
+	return nil
+}
+
+// SetRowHeight provides a function to set the height of a single row. If the
+// value of height is 0, will hide the specified row, if the value of height is
+// -1, will unset the custom row height. For example, set the height of the
+// first row in Sheet1:
+//
+//	err := f.SetRowHeight("Sheet1", 1, 50)
+func (f *File) SetRowHeight(sheet string, row int, height float64) error {
+	if err := checkRowNumber(row); err != nil {
+		return err
+	}
+	if height > MaxRowHeight {
+		return ErrMaxRowHeight
+	}
+	if height < -1 {
+		return ErrParameterInvalid
+	}
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return err
+	}
+
+	ws.prepareSheetXML(0, row)
 
 	rowIdx := row - 1
 	if height == -1 {
@@ -475,8 +1238,8 @@ func (f *File) getRowHeight(sheet string, row int) int {
 //
 //	height, err := f.GetRowHeight("Sheet1", 1)
 func (f *File) GetRowHeight(sheet string, row int) (float64, error) {
-	if row < 1 {
-		return defaultRowHeight, newInvalidRowNumberError(row)
+	if err := checkRowNumber(row); err != nil {
+		return defaultRowHeight, err
 	}
 	ht := defaultRowHeight
 	ws, err := f.workSheetReader(sheet)
@@ -498,6 +1261,37 @@ func (f *File) GetRowHeight(sheet string, row int) (float64, error) {
 	return ht, nil
 }
 
+// SetRowHeightUnit provides a function to set the height of a single row in
+// the given physical measurement unit, the height will be converted to
+// points by the 96 DPI mapping. For example, set the height of the first row
+// in Sheet1 to 1 centimeter:
+//
+//	err := f.SetRowHeightUnit("Sheet1", 1, 1, excelize.UnitCentimeters)
+func (f *File) SetRowHeightUnit(sheet string, row int, value float64, unit MeasurementUnit) error {
+	pixels, err := unit.toPixels(value)
+	if err != nil {
+		return err
+	}
+	return f.SetRowHeight(sheet, row, UnitPoints.fromPixels(pixels))
+}
+
+// GetRowHeightUnit provides a function to get the height of the row in the
+// given physical measurement unit by given worksheet name and row number. For
+// example, get the height of the first row in Sheet1 in millimeters:
+//
+//	height, err := f.GetRowHeightUnit("Sheet1", 1, excelize.UnitMillimeters)
+func (f *File) GetRowHeightUnit(sheet string, row int, unit MeasurementUnit) (float64, error) {
+	if _, err := unit.toPixels(0); err != nil {
+		return 0, err
+	}
+	height, err := f.GetRowHeight(sheet, row)
+	if err != nil {
+		return 0, err
+	}
+	pixels, _ := UnitPoints.toPixels(height)
+	return unit.fromPixels(pixels), err
+}
+
 // sharedStringsReader provides a function to get the pointer to the structure
 // after deserialization of xl/sharedStrings.xml.
 func (f *File) sharedStringsReader() (*xlsxSST, error) {
@@ -548,8 +1342,8 @@ func (f *File) sharedStringsReader() (*xlsxSST, error) {
 //
 //	err := f.SetRowVisible("Sheet1", 2, false)
 func (f *File) SetRowVisible(sheet string, row int, visible bool) error {
-	if row < 1 {
-		return newInvalidRowNumberError(row)
+	if err := checkRowNumber(row); err != nil {
+		return err
 	}
 
 	ws, err := f.workSheetReader(sheet)
@@ -567,8 +1361,8 @@ func (f *File) SetRowVisible(sheet string, row int, visible bool) error {
 //
 //	visible, err := f.GetRowVisible("Sheet1", 2)
 func (f *File) GetRowVisible(sheet string, row int) (bool, error) {
-	if row < 1 {
-		return false, newInvalidRowNumberError(row)
+	if err := checkRowNumber(row); err != nil {
+		return false, err
 	}
 
 	ws, err := f.workSheetReader(sheet)
@@ -581,14 +1375,51 @@ func (f *File) GetRowVisible(sheet string, row int) (bool, error) {
 	return !ws.SheetData.Row[row-1].Hidden, nil
 }
 
+// GetFilteredRows provides a function to get the row numbers of the rows
+// hidden by the AutoFilter in ascending order by given worksheet name. The
+// rows in the AutoFilter range with the hidden flag persisted in the
+// worksheet will be returned, and the filter criteria will not be
+// re-evaluated, so the rows hidden manually in the range will also be
+// returned, and the changes of the cell values after the filter applied will
+// not be reflected. This function is concurrency safe. For example, get the
+// filtered rows in Sheet1:
+//
+//	rows, err := f.GetFilteredRows("Sheet1")
+func (f *File) GetFilteredRows(sheet string) ([]int, error) {
+	f.mu.Lock()
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		f.mu.Unlock()
+		return nil, err
+	}
+	f.mu.Unlock()
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	var rows []int
+	if ws.AutoFilter == nil {
+		return rows, err
+	}
+	coordinates, err := rangeRefToCoordinates(ws.AutoFilter.Ref)
+	if err != nil {
+		return rows, err
+	}
+	_ = sortCoordinates(coordinates)
+	for _, row := range ws.SheetData.Row {
+		if row.Hidden && coordinates[1] < row.R && row.R <= coordinates[3] {
+			rows = append(rows, row.R)
+		}
+	}
+	return rows, err
+}
+
 // SetRowOutlineLevel provides a function to set outline level number of a
 // single row by given worksheet name and Excel row number. The value of
 // parameter 'level' is 1-7. For example, outline row 2 in Sheet1 to level 1:
 //
 //	err := f.SetRowOutlineLevel("Sheet1", 2, 1)
 func (f *File) SetRowOutlineLevel(sheet string, row int, level uint8) error {
-	if row < 1 {
-		return newInvalidRowNumberError(row)
+	if err := checkRowNumber(row); err != nil {
+		return err
 	}
 	if level > 7 || level < 1 {
 		return ErrOutlineLevel
@@ -608,8 +1439,8 @@ func (f *File) SetRowOutlineLevel(sheet string, row int, level uint8) error {
 //
 //	level, err := f.GetRowOutlineLevel("Sheet1", 2)
 func (f *File) GetRowOutlineLevel(sheet string, row int) (uint8, error) {
-	if row < 1 {
-		return 0, newInvalidRowNumberError(row)
+	if err := checkRowNumber(row); err != nil {
+		return 0, err
 	}
 	ws, err := f.workSheetReader(sheet)
 	if err != nil {
@@ -631,8 +1462,8 @@ func (f *File) GetRowOutlineLevel(sheet string, row int) (uint8, error) {
 // worksheet, it will cause a file error when you open it. The excelize only
 // partially updates these references currently.
 func (f *File) RemoveRow(sheet string, row int) error {
-	if row < 1 {
-		return newInvalidRowNumberError(row)
+	if err := checkRowNumber(row); err != nil {
+		return err
 	}
 
 	ws, err := f.workSheetReader(sheet)
@@ -643,16 +1474,562 @@ func (f *File) RemoveRow(sheet string, row int) error {
 	if row > len(ws.SheetData.Row) {
 		return f.adjustHelper(sheet, rows, row, -1)
 	}
-	keep := 0
-	for rowIdx := range ws.SheetData.Row {
-		v := &ws.SheetData.Row[rowIdx]
-		if v.R != row {
-			ws.SheetData.Row[keep] = *v
-			keep++
+	keep := 0
+	for rowIdx := range ws.SheetData.Row {
+		v := &ws.SheetData.Row[rowIdx]
+		if v.R != row {
+			ws.SheetData.Row[keep] = *v
+			keep++
+		}
+	}
+	ws.SheetData.Row = ws.SheetData.Row[:keep]
+	return f.adjustHelper(sheet, rows, row, -1)
+}
+
+// RemoveRows provides a function to remove multiple rows by given worksheet
+// name and the row numbers, which could be unordered, duplicated and not
+// contiguous. All the rows are removed in a single pass over the worksheet
+// data, which is much faster than calling RemoveRow in a loop for a large
+// number of rows. For example, remove the rows 3, 5 and 6 in Sheet1:
+//
+//	err := f.RemoveRows("Sheet1", []int{3, 5, 6})
+//
+// Use this method with caution, which will affect changes in references such
+// as formulas, charts, and so on. If there is any referenced value of the
+// worksheet, it will cause a file error when you open it. The excelize only
+// partially updates these references currently.
+func (f *File) RemoveRows(sheet string, rowNums []int) error {
+	doomed := make(rowsRemap, 0, len(rowNums))
+	for _, row := range rowNums {
+		if err := checkRowNumber(row); err != nil {
+			return err
+		}
+		doomed = append(doomed, row)
+	}
+	if len(doomed) == 0 {
+		return nil
+	}
+	sort.Ints(doomed)
+	for i := 1; i < len(doomed); i++ {
+		if doomed[i] == doomed[i-1] {
+			doomed = append(doomed[:i], doomed[i+1:]...)
+			i--
+		}
+	}
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return err
+	}
+	ws.formulaSI.Clear()
+	keep := 0
+	for rowIdx := range ws.SheetData.Row {
+		v := &ws.SheetData.Row[rowIdx]
+		idx := sort.SearchInts(doomed, v.R)
+		if idx < len(doomed) && doomed[idx] == v.R {
+			continue
+		}
+		if idx > 0 {
+			v.adjustSingleRowDimensions(-idx)
+		}
+		ws.SheetData.Row[keep] = *v
+		keep++
+	}
+	ws.SheetData.Row = ws.SheetData.Row[:keep]
+	return f.adjustRemovedRows(ws, sheet, doomed)
+}
+
+// adjustRemovedRows provides a function to adjust the references when
+// removing the rows, the worksheet data should be compacted before calling
+// this function. The row numbers mapping is computed once, and applied to
+// the formulas, merged cells, hyperlinks, conditional formats, data
+// validations, defined names, tables, drawings and comments in a single pass.
+func (f *File) adjustRemovedRows(ws *xlsxWorksheet, sheet string, m rowsRemap) error {
+	for _, sheetN := range f.GetSheetList() {
+		worksheet, err := f.workSheetReader(sheetN)
+		if err != nil {
+			if err.Error() == newNotWorksheetError(sheetN).Error() {
+				continue
+			}
+			return err
+		}
+		for rowIdx := range worksheet.SheetData.Row {
+			row := &worksheet.SheetData.Row[rowIdx]
+			for i := range row.C {
+				if err = f.remapFormula(sheet, sheetN, &row.C[i], m); err != nil {
+					return err
+				}
+			}
+		}
+		if err = f.remapDataValidations(worksheet, sheet, sheetN, m); err != nil {
+			return err
+		}
+	}
+	ws.checkSheet()
+	_ = ws.checkRow()
+	sheetID := f.getSheetID(sheet)
+	if err := f.updateExtLst(sheet, m.cellRef, func(sheetN, formula string) (string, error) {
+		return f.remapFormulaRef(sheet, sheetN, formula, false, m)
+	}); err != nil {
+		return err
+	}
+	if err := f.remapHyperlinks(ws, sheet, m); err != nil {
+		return err
+	}
+	if err := ws.remapMergeCells(m); err != nil {
+		return err
+	}
+	if err := f.remapConditionalFormats(ws, sheet, m); err != nil {
+		return err
+	}
+	if err := f.remapDefinedNames(sheet, m); err != nil {
+		return err
+	}
+	if err := ws.remapAutoFilter(m); err != nil {
+		return err
+	}
+	if err := f.remapTables(ws, sheet, m); err != nil {
+		return err
+	}
+	if err := f.remapCalcChain(sheetID, m); err != nil {
+		return err
+	}
+	if err := f.remapVolatileDeps(sheetID, m); err != nil {
+		return err
+	}
+	if err := f.remapDrawings(ws, sheet, m); err != nil {
+		return err
+	}
+	if err := f.remapComments(ws, sheet, m); err != nil {
+		return err
+	}
+	if ws.Dimension != nil {
+		return ws.updateDimension()
+	}
+	return nil
+}
+
+// RemoveRowsWhere provides a function to remove the rows which match the
+// given predicate function by given worksheet name, and returns the number
+// of the removed rows. The predicate function receives the row number
+// starting from 1 and the formatted cell values of the row. For example,
+// remove the rows in Sheet1 which the value of the first cell is empty:
+//
+//	n, err := f.RemoveRowsWhere("Sheet1", func(rowIdx int, values []string) bool {
+//	    return len(values) == 0 || values[0] == ""
+//	})
+func (f *File) RemoveRowsWhere(sheet string, fn func(rowIdx int, values []string) bool) (int, error) {
+	data, err := f.GetRows(sheet)
+	if err != nil {
+		return 0, err
+	}
+	var rowNums []int
+	for i, values := range data {
+		if fn(i+1, values) {
+			rowNums = append(rowNums, i+1)
+		}
+	}
+	return len(rowNums), f.RemoveRows(sheet, rowNums)
+}
+
+// SortKind is the type of the value kind used to compare the cell values of
+// the sort key column by the SortRange function.
+type SortKind byte
+
+// This section defines the currently supported sort value kinds.
+const (
+	SortNumeric SortKind = iota
+	SortText
+	SortDate
+)
+
+// SortKey directly maps the sort key of the SortRange function. The Col is
+// the column name of the key column inside the range, and the Kind specifies
+// how to compare the cell values of the key column. The numeric and date
+// values are sorted before the text values, the text values are compared
+// case-insensitively, and the blank cells are always sorted last regardless
+// of the sort order.
+type SortKey struct {
+	Col        string
+	Descending bool
+	Kind       SortKind
+}
+
+// SortRangeOptions directly maps the settings of the SortRange function. The
+// formula cells in the range will be moved verbatim by default, set the
+// RejectFormulas to true to return an error if the range contains any formula
+// cell instead.
+type SortRangeOptions struct {
+	RejectFormulas bool
+}
+
+// sortValue directly maps the cell value of the sort key column.
+type sortValue struct {
+	blank, numeric bool
+	num            float64
+	text           string
+}
+
+// compare provides a function to compare the sort values by given sort
+// order, the blank values are always sorted last.
+func (v sortValue) compare(o sortValue, desc bool) int {
+	if v.blank || o.blank {
+		if v.blank == o.blank {
+			return 0
+		}
+		if v.blank {
+			return 1
+		}
+		return -1
+	}
+	var n int
+	switch {
+	case v.numeric && o.numeric:
+		n = cmp.Compare(v.num, o.num)
+	case v.numeric:
+		n = -1
+	case o.numeric:
+		n = 1
+	default:
+		n = strings.Compare(v.text, o.text)
+	}
+	if desc {
+		return -n
+	}
+	return n
+}
+
+// SortRange provides a function to sort the rows inside the range by given
+// worksheet name, range reference and one or more sort keys, just like the
+// "Custom Sort" in Excel. The rows of the range will be reordered with the
+// cell values, styles, hyperlinks and comments carried together, the cells
+// outside of the range will be untouched, and the rows with equal keys keep
+// their original order. An error will be returned if any merged cell
+// intersects the range. For example, sort the range A2:D10 on Sheet1 by the
+// date values of column B in descending order, and then by the text values
+// of column A in ascending order:
+//
+//	err := f.SortRange("Sheet1", "A2:D10", []excelize.SortKey{
+//	    {Col: "B", Descending: true, Kind: excelize.SortDate},
+//	    {Col: "A", Kind: excelize.SortText},
+//	})
+//
+// The formula cells in the range will be moved verbatim, without updating
+// the references of the formulas, and the shared formulas which have any
+// cell in the range will be converted to normal formulas. Reject sorting the
+// range which contains formula cells:
+//
+//	err := f.SortRange("Sheet1", "A2:D10", []excelize.SortKey{{Col: "C"}},
+//	    excelize.SortRangeOptions{RejectFormulas: true})
+func (f *File) SortRange(sheet, rangeRef string, keys []SortKey, opts ...SortRangeOptions) error {
+	var options SortRangeOptions
+	for _, opt := range opts {
+		options = opt
+	}
+	coordinates, err := rangeRefToCoordinates(rangeRef)
+	if err != nil {
+		return err
+	}
+	_ = sortCoordinates(coordinates)
+	if len(keys) == 0 {
+		return ErrParameterInvalid
+	}
+	keyCols := make([]int, len(keys))
+	for i, key := range keys {
+		if keyCols[i], err = ColumnNameToNumber(key.Col); err != nil {
+			return err
+		}
+		if keyCols[i] < coordinates[0] || keyCols[i] > coordinates[2] {
+			return newSortKeyColumnError(key.Col, rangeRef)
+		}
+		if key.Kind > SortDate {
+			return ErrParameterInvalid
+		}
+	}
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return err
+	}
+	if err = ws.checkSortMergeCells(rangeRef, coordinates); err != nil {
+		return err
+	}
+	comments, err := f.GetComments(sheet)
+	if err != nil {
+		return err
+	}
+	ws.prepareSheetXML(coordinates[2], coordinates[3])
+	ws.makeContiguousColumns(coordinates[1], coordinates[3], coordinates[2])
+	if options.RejectFormulas {
+		for row := coordinates[1]; row <= coordinates[3]; row++ {
+			for _, c := range ws.SheetData.Row[row-1].C[coordinates[0]-1 : coordinates[2]] {
+				if c.F != nil {
+					return newSortRangeFormulaError(c.R)
+				}
+			}
+		}
+	}
+	if err = ws.unshareRangeFormulas(coordinates); err != nil {
+		return err
+	}
+	values, err := f.getSortValues(ws, coordinates, keys, keyCols)
+	if err != nil {
+		return err
+	}
+	order := make([]int, len(values))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		for k, key := range keys {
+			if n := values[order[i]][k].compare(values[order[j]][k], key.Descending); n != 0 {
+				return n < 0
+			}
+		}
+		return false
+	})
+	rowMap, err := f.sortRangeCells(ws, sheet, coordinates, order)
+	if err != nil {
+		return err
+	}
+	if err = ws.sortRangeHyperlinks(coordinates, rowMap); err != nil {
+		return err
+	}
+	return f.sortRangeComments(sheet, comments, coordinates, rowMap)
+}
+
+// checkSortMergeCells provides a function to check if any merged cell
+// intersects the sort range.
+func (ws *xlsxWorksheet) checkSortMergeCells(rangeRef string, coordinates []int) error {
+	if ws.MergeCells == nil {
+		return nil
+	}
+	for _, mergeCell := range ws.MergeCells.Cells {
+		if mergeCell == nil {
+			continue
+		}
+		rect, err := rangeRefToCoordinates(mergeCell.Ref)
+		if err != nil {
+			return err
+		}
+		_ = sortCoordinates(rect)
+		if rect[0] <= coordinates[2] && coordinates[0] <= rect[2] &&
+			rect[1] <= coordinates[3] && coordinates[1] <= rect[3] {
+			return newSortRangeMergeCellError(rangeRef, mergeCell.Ref)
+		}
+	}
+	return nil
+}
+
+// unshareRangeFormulas provides a function to convert the shared formulas
+// which have any cell in the given range to normal formulas, the worksheet
+// data should be prepared to cover the range before calling this function.
+func (ws *xlsxWorksheet) unshareRangeFormulas(coordinates []int) error {
+	shared := map[int]bool{}
+	for row := coordinates[1]; row <= coordinates[3]; row++ {
+		for _, c := range ws.SheetData.Row[row-1].C[coordinates[0]-1 : coordinates[2]] {
+			if c.F != nil && c.F.T == STCellFormulaTypeShared && c.F.Si != nil {
+				shared[*c.F.Si] = true
+			}
+		}
+	}
+	if len(shared) == 0 {
+		return nil
+	}
+	ws.formulaSI.Clear()
+	var (
+		cells    []*xlsxC
+		formulas []string
+	)
+	for i := range ws.SheetData.Row {
+		for j := range ws.SheetData.Row[i].C {
+			c := &ws.SheetData.Row[i].C[j]
+			if c.F == nil || c.F.T != STCellFormulaTypeShared || c.F.Si == nil || !shared[*c.F.Si] {
+				continue
+			}
+			formula, err := getSharedFormula(ws, *c.F.Si, c.R)
+			if err != nil {
+				return err
+			}
+			cells, formulas = append(cells, c), append(formulas, formula)
+		}
+	}
+	for i, c := range cells {
+		c.F, c.f = &xlsxF{Content: formulas[i]}, ""
+	}
+	ws.formulaSI.Clear()
+	return nil
+}
+
+// getSortValues provides a function to get the sort values of the key
+// columns for each row of the sort range.
+func (f *File) getSortValues(ws *xlsxWorksheet, coordinates []int, keys []SortKey, keyCols []int) ([][]sortValue, error) {
+	sst, err := f.sharedStringsReader()
+	if err != nil {
+		return nil, err
+	}
+	var date1904 bool
+	wb, err := f.workbookReader()
+	if err != nil {
+		return nil, err
+	}
+	if wb != nil && wb.WorkbookPr != nil {
+		date1904 = wb.WorkbookPr.Date1904
+	}
+	values := make([][]sortValue, coordinates[3]-coordinates[1]+1)
+	for i := range values {
+		values[i] = make([]sortValue, len(keys))
+		for k, key := range keys {
+			c := &ws.SheetData.Row[coordinates[1]-1+i].C[keyCols[k]-1]
+			if values[i][k], err = f.getSortValue(c, sst, key.Kind, date1904); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return values, err
+}
+
+// getSortValue provides a function to get the sort value of the cell by
+// given sort value kind.
+func (f *File) getSortValue(c *xlsxC, sst *xlsxSST, kind SortKind, date1904 bool) (sortValue, error) {
+	var v sortValue
+	raw, err := c.getValueFrom(f, sst, true)
+	if err != nil {
+		return v, err
+	}
+	if v.blank = raw == ""; v.blank {
+		return v, err
+	}
+	if kind != SortText && (c.T == "" || c.T == "n" || c.T == "d" || (c.T == "str" && c.F != nil)) {
+		if num, err := strconv.ParseFloat(raw, 64); err == nil {
+			v.numeric, v.num = true, num
+			return v, err
+		}
+	}
+	formatted, err := c.getValueFrom(f, sst, false)
+	if err != nil {
+		return v, err
+	}
+	if kind == SortDate {
+		for _, layout := range []string{time.RFC3339, "2006-01-02T15:04:05", "2006-01-02 15:04:05", "2006-01-02"} {
+			if t, err := time.Parse(layout, formatted); err == nil {
+				if v.num, err = timeToExcelTime(t, date1904); err == nil {
+					v.numeric = true
+					return v, err
+				}
+			}
+		}
+	}
+	v.text = strings.ToLower(formatted)
+	return v, err
+}
+
+// sortRangeCells provides a function to reorder the cells of the rows in the
+// sort range by given sorted order of the rows, and returns the mapping of
+// the original row numbers to the sorted row numbers.
+func (f *File) sortRangeCells(ws *xlsxWorksheet, sheet string, coordinates []int, order []int) (map[int]int, error) {
+	rowMap, cells := make(map[int]int, len(order)), make([][]xlsxC, len(order))
+	for i := range cells {
+		cells[i] = append([]xlsxC(nil), ws.SheetData.Row[coordinates[1]-1+i].C[coordinates[0]-1:coordinates[2]]...)
+	}
+	sheetID := f.getSheetID(sheet)
+	for i, src := range order {
+		row := coordinates[1] + i
+		if rowMap[coordinates[1]+src] = row; src == i {
+			continue
+		}
+		for j, c := range cells[src] {
+			if c.F != nil {
+				if err := f.deleteCalcChain(sheetID, c.R); err != nil {
+					return rowMap, err
+				}
+			}
+			dst := &ws.SheetData.Row[row-1].C[coordinates[0]-1+j]
+			c.R, c.f = dst.R, ""
+			if f.journal != nil {
+				if oldValue, newValue := f.getCellChangeValue(dst), f.getCellChangeValue(&c); oldValue != newValue {
+					f.journal.add(CellChange{Sheet: sheet, Cell: c.R, OldValue: oldValue, NewValue: newValue})
+				}
+			}
+			*dst = c
+		}
+	}
+	return rowMap, nil
+}
+
+// sortRangeHyperlinks provides a function to move the hyperlinks inside the
+// sort range with the sorted rows, the hyperlinks across rows will be kept.
+func (ws *xlsxWorksheet) sortRangeHyperlinks(coordinates []int, rowMap map[int]int) error {
+	if ws.Hyperlinks == nil {
+		return nil
+	}
+	for i := range ws.Hyperlinks.Hyperlink {
+		link := &ws.Hyperlinks.Hyperlink[i]
+		ref := link.Ref
+		if !strings.Contains(ref, ":") {
+			ref += ":" + ref
+		}
+		rect, err := rangeRefToCoordinates(ref)
+		if err != nil {
+			return err
+		}
+		_ = sortCoordinates(rect)
+		if rect[1] != rect[3] || rect[0] < coordinates[0] || rect[2] > coordinates[2] ||
+			rect[1] < coordinates[1] || rect[3] > coordinates[3] {
+			continue
+		}
+		row := rowMap[rect[1]]
+		if rect[0] == rect[2] {
+			link.Ref, _ = CoordinatesToCellName(rect[0], row)
+			continue
+		}
+		link.Ref, _ = coordinatesToRangeRef([]int{rect[0], row, rect[2], row})
+	}
+	return nil
+}
+
+// sortRangeComments provides a function to move the comments inside the sort
+// range with the sorted rows.
+func (f *File) sortRangeComments(sheet string, comments []Comment, coordinates []int, rowMap map[int]int) error {
+	var moved []Comment
+	for _, comment := range comments {
+		col, row, err := CellNameToCoordinates(comment.Cell)
+		if err != nil {
+			return err
+		}
+		if col < coordinates[0] || col > coordinates[2] || row < coordinates[1] || row > coordinates[3] || rowMap[row] == row {
+			continue
+		}
+		if err = f.DeleteComment(sheet, comment.Cell); err != nil {
+			return err
+		}
+		comment.Cell, _ = CoordinatesToCellName(col, rowMap[row])
+		moved = append(moved, comment)
+	}
+	for _, comment := range moved {
+		if err := f.AddComment(sheet, comment); err != nil {
+			return err
 		}
 	}
-	ws.SheetData.Row = ws.SheetData.Row[:keep]
-	return f.adjustHelper(sheet, rows, row, -1)
+	return nil
+}
+
+// RowStyleInheritance is the type of the source row for the new rows
+// inherit the formatting from when inserting rows.
+type RowStyleInheritance byte
+
+// Row style inheritance enumeration.
+const (
+	InheritStyleNone RowStyleInheritance = iota
+	InheritStyleAbove
+	InheritStyleBelow
+)
+
+// InsertRowsOptions directly maps the settings of inserting rows. The
+// InheritStyle specifies the adjacent row which the height, row style and
+// cell styles will be copied from to the new rows, like the "Format Same As
+// Above" and "Format Same As Below" insert options in Excel. The new rows will
+// be blank without any formatting by default.
+type InsertRowsOptions struct {
+	InheritStyle RowStyleInheritance
 }
 
 // InsertRows provides a function to insert new rows after the given Excel row
@@ -661,11 +2038,25 @@ func (f *File) RemoveRow(sheet string, row int) error {
 //
 //	err := f.InsertRows("Sheet1", 3, 2)
 //
+// Create two rows before row 3 in Sheet1 with the same formatting as row 2:
+//
+//	err := f.InsertRows("Sheet1", 3, 2, excelize.InsertRowsOptions{
+//	    InheritStyle: excelize.InheritStyleAbove,
+//	})
+//
+// The pictures, charts, shapes, form controls and comments anchored at or
+// beyond the inserted rows will be moved with the cells, except the objects
+// with "absolute" positioning.
+//
 // Use this method with caution, which will affect changes in references such
 // as formulas, charts, and so on. If there is any referenced value of the
 // worksheet, it will cause a file error when you open it. The excelize only
 // partially updates these references currently.
-func (f *File) InsertRows(sheet string, row, n int) error {
+func (f *File) InsertRows(sheet string, row, n int, opts ...InsertRowsOptions) error {
+	var options InsertRowsOptions
+	for _, opt := range opts {
+		options = opt
+	}
 	if row < 1 {
 		return newInvalidRowNumberError(row)
 	}
@@ -675,7 +2066,64 @@ func (f *File) InsertRows(sheet string, row, n int) error {
 	if n < 1 {
 		return ErrParameterInvalid
 	}
-	return f.adjustHelper(sheet, rows, row, n)
+	if options.InheritStyle > InheritStyleBelow {
+		return ErrParameterInvalid
+	}
+	if err := f.adjustHelper(sheet, rows, row, n); err != nil {
+		return err
+	}
+	switch options.InheritStyle {
+	case InheritStyleAbove:
+		return f.inheritRowStyle(sheet, row-1, row, n)
+	case InheritStyleBelow:
+		return f.inheritRowStyle(sheet, row+n, row, n)
+	}
+	return nil
+}
+
+// inheritRowStyle provides a function to copy the height, row style and cell
+// styles of the source row to the given number of rows start from the given
+// row number.
+func (f *File) inheritRowStyle(sheet string, srcRow, row, n int) error {
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return err
+	}
+	var src *xlsxRow
+	for i := range ws.SheetData.Row {
+		if ws.SheetData.Row[i].R == srcRow {
+			src = &ws.SheetData.Row[i]
+			break
+		}
+	}
+	if src == nil {
+		return err
+	}
+	ht, customHeight, styleID, customFormat := src.Ht, src.CustomHeight, src.S, src.CustomFormat
+	cellStyles := map[int]int{}
+	for _, c := range src.C {
+		if c.S == 0 {
+			continue
+		}
+		col, _, err := CellNameToCoordinates(c.R)
+		if err != nil {
+			return err
+		}
+		cellStyles[col] = c.S
+	}
+	for r := row; r < row+n; r++ {
+		ws.prepareSheetXML(0, r)
+		rowData := &ws.SheetData.Row[r-1]
+		if ht != nil {
+			rowData.Ht = float64Ptr(*ht)
+		}
+		rowData.CustomHeight, rowData.S, rowData.CustomFormat = customHeight, styleID, customFormat
+		for col, styleID := range cellStyles {
+			fillColumns(rowData, col, r)
+			rowData.C[col-1].S = styleID
+		}
+	}
+	return err
 }
 
 // DuplicateRow inserts a copy of specified row (by its Excel row number) below
@@ -700,8 +2148,8 @@ func (f *File) DuplicateRow(sheet string, row int) error {
 // worksheet, it will cause a file error when you open it. The excelize only
 // partially updates these references currently.
 func (f *File) DuplicateRowTo(sheet string, row, row2 int) error {
-	if row < 1 {
-		return newInvalidRowNumberError(row)
+	if err := checkRowNumber(row); err != nil {
+		return err
 	}
 
 	ws, err := f.workSheetReader(sheet)
@@ -712,6 +2160,9 @@ func (f *File) DuplicateRowTo(sheet string, row, row2 int) error {
 	if row2 < 1 || row == row2 {
 		return err
 	}
+	if row2 > TotalRows {
+		return ErrMaxRows
+	}
 
 	var ok bool
 	var rowCopy xlsxRow
@@ -748,6 +2199,13 @@ func (f *File) DuplicateRowTo(sheet string, row, row2 int) error {
 	} else {
 		ws.SheetData.Row = append(ws.SheetData.Row, rowCopy)
 	}
+	if f.journal != nil {
+		for i := range rowCopy.C {
+			if rowCopy.C[i].hasValue() {
+				f.journal.add(CellChange{Sheet: sheet, Cell: rowCopy.C[i].R, NewValue: f.getCellChangeValue(&rowCopy.C[i])})
+			}
+		}
+	}
 	for _, fn := range duplicateHelperFunc {
 		if err := fn(f, ws, sheet, row, row2); err != nil {
 			return err
@@ -979,19 +2437,25 @@ func (f *File) SetRowStyle(sheet string, start, end, styleID int) error {
 	if end > TotalRows {
 		return ErrMaxRows
 	}
+	f.mu.Lock()
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		f.mu.Unlock()
+		return err
+	}
+	f.mu.Unlock()
 	s, err := f.stylesReader()
 	if err != nil {
 		return err
 	}
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
 	s.mu.Lock()
-	defer s.mu.Unlock()
 	if styleID < 0 || s.CellXfs == nil || len(s.CellXfs.Xf) <= styleID {
+		s.mu.Unlock()
 		return newInvalidStyleID(styleID)
 	}
-	ws, err := f.workSheetReader(sheet)
-	if err != nil {
-		return err
-	}
+	s.mu.Unlock()
 	ws.prepareSheetXML(0, end)
 	for row := start - 1; row < end; row++ {
 		ws.SheetData.Row[row].S = styleID
@@ -1014,3 +2478,556 @@ func convertRowHeightToPixels(height float64) float64 {
 	}
 	return float64(int(height*4.0/3.0 + 0.5))
 }
+
+// RowHeightToPixels provides a function to convert the height of a row from
+// points to pixels, the height will be rounded to the whole pixels in the same
+// way as the library positions the objects. For example, get the height of
+// the row with 30 points in pixels:
+//
+//	pixels := excelize.RowHeightToPixels(30)
+func RowHeightToPixels(height float64) int {
+	return int(convertRowHeightToPixels(height))
+}
+
+// ColumnType is the type of inferred column value type.
+type ColumnType byte
+
+// Inferred column value types enumeration.
+const (
+	ColumnTypeString ColumnType = iota
+	ColumnTypeFloat
+	ColumnTypeBool
+	ColumnTypeTime
+)
+
+// ReadTableOptions directly maps the settings of the ReadTable function.
+//
+// SkipRows specifies the number of leading rows before the header row.
+//
+// SampleSize specifies the number of non-empty values in each column used to
+// infer the column type, the default value 0 means all values will be used.
+//
+// DateLayouts specifies the layouts used to parse time values, the
+// RFC 3339 and ISO 8601 date layouts will be used by default.
+//
+// DecimalComma specifies if the comma is used as the decimal separator, and
+// the period is used as the thousands separator.
+//
+// RawCellValue specifies if apply the number format for the cell value or get
+// the raw value.
+type ReadTableOptions struct {
+	SkipRows     int
+	SampleSize   int
+	DateLayouts  []string
+	DecimalComma bool
+	RawCellValue bool
+}
+
+// TableData directly maps the data read by the ReadTable function. The
+// Headers holds the header names in column order, and the Columns holds the
+// column values keyed by the header names.
+type TableData struct {
+	Headers []string
+	Columns map[string]*Column
+}
+
+// Column directly maps a column of the TableData with the inferred type of
+// the column values. The Issues holds the reason why a column falls back to
+// the string type.
+type Column struct {
+	Name   string
+	Type   ColumnType
+	Values []string
+	Issues []string
+	opts   *ReadTableOptions
+}
+
+// defaultDateLayouts defined the default layouts for parsing the time values
+// on reading table.
+var defaultDateLayouts = []string{
+	time.RFC3339, "2006-01-02 15:04:05", "2006-01-02T15:04:05", "2006-01-02",
+}
+
+// ReadTable provides a function to read a worksheet with a header row as
+// typed columns by given worksheet name and read table options. The first row
+// after the skipped rows will be used as the header row, the blank header
+// name will be replaced with the column name, and the duplicate header name
+// will be suffixed with an underscore and the sequence number. The type of
+// each column will be inferred from its values, the mixed type column falls
+// back to the string type. For example, skip the title row and read the table
+// on Sheet1:
+//
+//	data, err := f.ReadTable("Sheet1", excelize.ReadTableOptions{SkipRows: 1})
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	prices, err := data.Columns["Price"].Floats()
+func (f *File) ReadTable(sheet string, opts ReadTableOptions) (*TableData, error) {
+	rows, err := f.GetRows(sheet, Options{RawCellValue: opts.RawCellValue, SkipRows: opts.SkipRows})
+	if err != nil {
+		return nil, err
+	}
+	if len(opts.DateLayouts) == 0 {
+		opts.DateLayouts = defaultDateLayouts
+	}
+	data := &TableData{Columns: make(map[string]*Column)}
+	if len(rows) == 0 {
+		return data, err
+	}
+	for colIdx, header := range rows[0] {
+		name := strings.TrimSpace(header)
+		if name == "" {
+			name, _ = ColumnNumberToName(colIdx + 1)
+		}
+		for seq, base := 2, name; data.Columns[name] != nil; seq++ {
+			name = base + "_" + strconv.Itoa(seq)
+		}
+		col := &Column{Name: name, Values: make([]string, len(rows)-1), opts: &opts}
+		for rowIdx, row := range rows[1:] {
+			if colIdx < len(row) {
+				col.Values[rowIdx] = row[colIdx]
+			}
+		}
+		col.inferType(opts.SkipRows + 2)
+		data.Headers = append(data.Headers, name)
+		data.Columns[name] = col
+	}
+	return data, err
+}
+
+// inferType provides a function to infer the column type by sampling the
+// non-empty values, the start row number used in the issue message.
+func (col *Column) inferType(startRow int) {
+	candidates := map[ColumnType]bool{ColumnTypeFloat: true, ColumnTypeBool: true, ColumnTypeTime: true}
+	var sampled int
+	for idx, val := range col.Values {
+		if val = strings.TrimSpace(val); val == "" {
+			continue
+		}
+		if col.opts.SampleSize > 0 && sampled >= col.opts.SampleSize {
+			break
+		}
+		sampled++
+		_, err := col.parseFloat(val)
+		matched := map[ColumnType]bool{ColumnTypeFloat: err == nil}
+		_, err = strconv.ParseBool(val)
+		matched[ColumnTypeBool] = err == nil && !matched[ColumnTypeFloat]
+		_, err = col.parseTime(val)
+		matched[ColumnTypeTime] = err == nil
+		var ok bool
+		for typ := range candidates {
+			ok = ok || candidates[typ] && matched[typ]
+		}
+		if !ok {
+			col.Issues = append(col.Issues, fmt.Sprintf("mixed types: value %q in row %d conflicts with the previous values", val, startRow+idx))
+			continue
+		}
+		for typ := range candidates {
+			candidates[typ] = candidates[typ] && matched[typ]
+		}
+	}
+	col.Type = ColumnTypeString
+	if sampled == 0 || len(col.Issues) > 0 {
+		return
+	}
+	for _, typ := range []ColumnType{ColumnTypeBool, ColumnTypeFloat, ColumnTypeTime} {
+		if candidates[typ] {
+			col.Type = typ
+			return
+		}
+	}
+}
+
+// parseFloat parse the column value as a float number, the thousands
+// separators are only allowed between the groups of three digits in the
+// integer part of the number.
+func (col *Column) parseFloat(val string) (float64, error) {
+	decimalSep, groupSep := ".", ","
+	if col.opts.DecimalComma {
+		decimalSep, groupSep = ",", "."
+	}
+	val = strings.TrimSpace(val)
+	intPart, fracPart, hasFrac := strings.Cut(val, decimalSep)
+	if strings.Contains(fracPart, decimalSep) || strings.Contains(fracPart, groupSep) {
+		return 0, &strconv.NumError{Func: "ParseFloat", Num: val, Err: strconv.ErrSyntax}
+	}
+	if strings.Contains(intPart, groupSep) {
+		sign := strings.IndexFunc(intPart, func(r rune) bool { return r != '+' && r != '-' })
+		if sign > 1 || sign == -1 {
+			return 0, &strconv.NumError{Func: "ParseFloat", Num: val, Err: strconv.ErrSyntax}
+		}
+		groups := strings.Split(intPart[sign:], groupSep)
+		for idx, group := range groups {
+			if len(group) == 0 || len(group) > 3 || (idx > 0 && len(group) != 3) ||
+				strings.IndexFunc(group, func(r rune) bool { return r < '0' || r > '9' }) != -1 {
+				return 0, &strconv.NumError{Func: "ParseFloat", Num: val, Err: strconv.ErrSyntax}
+			}
+		}
+		intPart = intPart[:sign] + strings.Join(groups, "")
+	}
+	if hasFrac {
+		intPart += "." + fracPart
+	}
+	return strconv.ParseFloat(intPart, 64)
+}
+
+// parseTime parse the column value as a time by the date layouts.
+func (col *Column) parseTime(val string) (t time.Time, err error) {
+	for _, layout := range col.opts.DateLayouts {
+		if t, err = time.Parse(layout, strings.TrimSpace(val)); err == nil {
+			return
+		}
+	}
+	return
+}
+
+// Strings returns the column values as strings.
+func (col *Column) Strings() []string {
+	return col.Values
+}
+
+// Floats returns the column values as float numbers, the empty value will be
+// converted to 0.
+func (col *Column) Floats() ([]float64, error) {
+	values := make([]float64, len(col.Values))
+	for i, val := range col.Values {
+		if strings.TrimSpace(val) == "" {
+			continue
+		}
+		num, err := col.parseFloat(val)
+		if err != nil {
+			return values, err
+		}
+		values[i] = num
+	}
+	return values, nil
+}
+
+// Bools returns the column values as boolean values, the empty value will be
+// converted to false.
+func (col *Column) Bools() ([]bool, error) {
+	values := make([]bool, len(col.Values))
+	for i, val := range col.Values {
+		if val = strings.TrimSpace(val); val == "" {
+			continue
+		}
+		b, err := strconv.ParseBool(val)
+		if err != nil {
+			return values, err
+		}
+		values[i] = b
+	}
+	return values, nil
+}
+
+// Times returns the column values as times, the empty value will be
+// converted to the zero time.
+func (col *Column) Times() ([]time.Time, error) {
+	values := make([]time.Time, len(col.Values))
+	for i, val := range col.Values {
+		if strings.TrimSpace(val) == "" {
+			continue
+		}
+		t, err := col.parseTime(val)
+		if err != nil {
+			return values, err
+		}
+		values[i] = t
+	}
+	return values, nil
+}
+
+// BindOptions directly maps the settings of binding the worksheet rows with
+// the structs. The HeaderRow specifies the row number of the header row, the
+// tag values of the struct fields will be resolved as the header names in
+// this row, and the rows after it will be bound. The default value 0 means
+// the worksheet has no header row, the tag values must be column names and
+// the rows will be bound from the first row. The tag value with the "col="
+// prefix, such as "col=A", is always resolved as a column name.
+type BindOptions struct {
+	HeaderRow int
+}
+
+// bindField directly maps a tagged struct field with the column number.
+type bindField struct {
+	index []int
+	name  string
+	col   int
+}
+
+// GetRowsInto provides a function to read the rows of the worksheet into a
+// slice of structs by given worksheet name, pointer to the slice of structs
+// or pointers to structs, and bind options. The struct fields are bound with
+// the columns by the "xlsx" tag, the tag value is a header name in the header
+// row, or a column name in upper case when the header row is not specified.
+// Use the "col=" prefix to bind a field with a column name explicitly, such as
+// `xlsx:"col=A"`. The supported field types are string, bool, the
+// integer and float number types, time.Time and the pointers of these types,
+// the pointer field will be left nil for the empty cell. The numeric time
+// values will be converted by the 1900 or 1904 date system of the workbook.
+// The empty rows will be skipped. For example, read the rows after the header
+// row on Sheet1:
+//
+//	type Product struct {
+//	    SKU   string     `xlsx:"col=A"`
+//	    Name  string     `xlsx:"Product Name"`
+//	    Price float64    `xlsx:"Price"`
+//	    Date  *time.Time `xlsx:"Launch Date"`
+//	}
+//	var products []Product
+//	err := f.GetRowsInto("Sheet1", &products, excelize.BindOptions{HeaderRow: 1})
+func (f *File) GetRowsInto(sheet string, dest interface{}, opts ...BindOptions) error {
+	v := reflect.ValueOf(dest)
+	if v.Kind() != reflect.Ptr || v.Elem().Kind() != reflect.Slice {
+		return ErrParameterInvalid
+	}
+	elemType := v.Elem().Type().Elem()
+	structType := elemType
+	if structType.Kind() == reflect.Ptr {
+		structType = structType.Elem()
+	}
+	if structType.Kind() != reflect.Struct {
+		return ErrParameterInvalid
+	}
+	var options BindOptions
+	for _, opt := range opts {
+		options = opt
+	}
+	fields, err := f.getBindFields(sheet, structType, options.HeaderRow, false)
+	if err != nil {
+		return err
+	}
+	var date1904 bool
+	wb, err := f.workbookReader()
+	if err != nil {
+		return err
+	}
+	if wb != nil && wb.WorkbookPr != nil {
+		date1904 = wb.WorkbookPr.Date1904
+	}
+	rows, err := f.GetRows(sheet, Options{RawCellValue: true})
+	if err != nil {
+		return err
+	}
+	slice := reflect.MakeSlice(v.Elem().Type(), 0, max(len(rows)-options.HeaderRow, 0))
+	for rowIdx := options.HeaderRow; rowIdx < len(rows); rowIdx++ {
+		elem, bound := reflect.New(structType), false
+		for _, field := range fields {
+			if field.col > len(rows[rowIdx]) || rows[rowIdx][field.col-1] == "" {
+				continue
+			}
+			val := rows[rowIdx][field.col-1]
+			if err = setBindFieldValue(elem.Elem().FieldByIndex(field.index), val, date1904); err != nil {
+				cell, _ := CoordinatesToCellName(field.col, rowIdx+1)
+				return newBindCellValueError(cell, field.name, val, err)
+			}
+			bound = true
+		}
+		if !bound {
+			continue
+		}
+		if elemType.Kind() != reflect.Ptr {
+			elem = elem.Elem()
+		}
+		slice = reflect.Append(slice, elem)
+	}
+	v.Elem().Set(slice)
+	return err
+}
+
+// SetRowsFrom provides a function to write a slice of structs into the rows
+// of the worksheet by given worksheet name, slice of structs or pointers to
+// structs, and bind options. The struct fields are bound with the columns in
+// the same way as the GetRowsInto function, the header name which doesn't
+// exist in the header row will be appended to it. The rows will be written
+// from the row after the header row, and the nil pointer fields will be
+// skipped. For example, write the products into Sheet1 with the header row:
+//
+//	err := f.SetRowsFrom("Sheet1", products, excelize.BindOptions{HeaderRow: 1})
+func (f *File) SetRowsFrom(sheet string, src interface{}, opts ...BindOptions) error {
+	v := reflect.ValueOf(src)
+	if v.Kind() == reflect.Ptr {
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Slice {
+		return ErrParameterInvalid
+	}
+	structType := v.Type().Elem()
+	if structType.Kind() == reflect.Ptr {
+		structType = structType.Elem()
+	}
+	if structType.Kind() != reflect.Struct {
+		return ErrParameterInvalid
+	}
+	var options BindOptions
+	for _, opt := range opts {
+		options = opt
+	}
+	if options.HeaderRow+v.Len() > TotalRows {
+		return ErrMaxRows
+	}
+	fields, err := f.getBindFields(sheet, structType, options.HeaderRow, true)
+	if err != nil {
+		return err
+	}
+	for i := 0; i < v.Len(); i++ {
+		elem := v.Index(i)
+		if elem.Kind() == reflect.Ptr {
+			if elem.IsNil() {
+				continue
+			}
+			elem = elem.Elem()
+		}
+		for _, field := range fields {
+			val := elem.FieldByIndex(field.index)
+			if val.Kind() == reflect.Ptr {
+				if val.IsNil() {
+					continue
+				}
+				val = val.Elem()
+			}
+			cell, _ := CoordinatesToCellName(field.col, options.HeaderRow+i+1)
+			if err = f.SetCellValue(sheet, cell, val.Interface()); err != nil {
+				return err
+			}
+		}
+	}
+	return err
+}
+
+// getBindFields provides a function to resolve the columns of the tagged
+// struct fields by given worksheet name, struct type and header row number.
+// The header names which don't exist in the header row will be appended to
+// it if the create is true.
+func (f *File) getBindFields(sheet string, typ reflect.Type, headerRow int, create bool) ([]bindField, error) {
+	if headerRow < 0 || headerRow >= TotalRows {
+		return nil, newInvalidRowNumberError(headerRow)
+	}
+	headers := map[string]string{}
+	var lastCol int
+	if headerRow > 0 {
+		var err error
+		if headers, err = f.GetColIndexByHeader(sheet, headerRow); err != nil {
+			return nil, err
+		}
+		for _, name := range headers {
+			col, _ := ColumnNameToNumber(name)
+			lastCol = max(lastCol, col)
+		}
+	}
+	var fields []bindField
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		tag := strings.TrimSpace(field.Tag.Get("xlsx"))
+		if !field.IsExported() || tag == "" || tag == "-" {
+			continue
+		}
+		if !isBindFieldType(field.Type) {
+			return nil, newBindFieldError(field.Name, "unsupported field type "+field.Type.String())
+		}
+		bf := bindField{index: field.Index, name: field.Name}
+		if colName, ok := strings.CutPrefix(tag, "col="); ok {
+			col, err := ColumnNameToNumber(strings.TrimSpace(colName))
+			if err != nil {
+				return nil, newBindFieldError(field.Name, err.Error())
+			}
+			bf.col = col
+		} else if name, ok := headers[tag]; ok {
+			bf.col, _ = ColumnNameToNumber(name)
+		} else if col, err := ColumnNameToNumber(tag); err == nil && headerRow == 0 && tag == strings.ToUpper(tag) {
+			bf.col = col
+		} else if create && headerRow > 0 {
+			if lastCol++; lastCol > MaxColumns {
+				return nil, ErrColumnNumber
+			}
+			cell, _ := CoordinatesToCellName(lastCol, headerRow)
+			if err := f.SetCellStr(sheet, cell, tag); err != nil {
+				return nil, err
+			}
+			headers[tag], _ = ColumnNumberToName(lastCol)
+			bf.col = lastCol
+		} else {
+			return nil, newBindFieldError(field.Name, fmt.Sprintf("no column matches the tag %q", tag))
+		}
+		fields = append(fields, bf)
+	}
+	return fields, nil
+}
+
+// isBindFieldType returns true if the type can be bound with the cell value.
+func isBindFieldType(typ reflect.Type) bool {
+	if typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	if typ == reflect.TypeOf(time.Time{}) {
+		return true
+	}
+	switch typ.Kind() {
+	case reflect.String, reflect.Bool, reflect.Float32, reflect.Float64,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return true
+	}
+	return false
+}
+
+// setBindFieldValue provides a function to convert the cell value to the
+// type of the struct field and set the field value.
+func setBindFieldValue(field reflect.Value, val string, date1904 bool) error {
+	if field.Kind() == reflect.Ptr {
+		ptr := reflect.New(field.Type().Elem())
+		if err := setBindFieldValue(ptr.Elem(), val, date1904); err != nil {
+			return err
+		}
+		field.Set(ptr)
+		return nil
+	}
+	if field.Kind() == reflect.String {
+		field.SetString(val)
+		return nil
+	}
+	val = strings.TrimSpace(val)
+	if field.Type() == reflect.TypeOf(time.Time{}) {
+		if num, err := strconv.ParseFloat(val, 64); err == nil {
+			field.Set(reflect.ValueOf(timeFromExcelTime(num, date1904)))
+			return nil
+		}
+		t, err := time.Time{}, ErrParameterInvalid
+		for _, layout := range defaultDateLayouts {
+			if t, err = time.Parse(layout, val); err == nil {
+				field.Set(reflect.ValueOf(t))
+				break
+			}
+		}
+		return err
+	}
+	switch field.Kind() {
+	case reflect.Bool:
+		b, err := strconv.ParseBool(val)
+		field.SetBool(b)
+		return err
+	case reflect.Float32, reflect.Float64:
+		num, err := strconv.ParseFloat(val, field.Type().Bits())
+		field.SetFloat(num)
+		return err
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		num, err := strconv.ParseInt(val, 10, field.Type().Bits())
+		if err != nil {
+			if n, e := strconv.ParseFloat(val, 64); e == nil && n == math.Trunc(n) && !field.OverflowInt(int64(n)) {
+				num, err = int64(n), nil
+			}
+		}
+		field.SetInt(num)
+		return err
+	default:
+		num, err := strconv.ParseUint(val, 10, field.Type().Bits())
+		if err != nil {
+			if n, e := strconv.ParseFloat(val, 64); e == nil && n >= 0 && n == math.Trunc(n) && !field.OverflowUint(uint64(n)) {
+				num, err = uint64(n), nil
+			}
+		}
+		field.SetUint(num)
+		return err
+	}
+}
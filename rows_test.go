@@ -2,11 +2,17 @@ package excelize
 
 import (
 	"bytes"
+	"encoding/binary"
 	"encoding/xml"
 	"fmt"
+	"hash/crc32"
+	"math"
 	"path/filepath"
 	"strconv"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -33,11 +39,13 @@ func TestRows(t *testing.T) {
 
 	rows, err := f.Rows(sheet2)
 	assert.NoError(t, err)
+	assert.Equal(t, 0, rows.CurrentRow())
 	var collectedRows [][]string
 	for rows.Next() {
 		columns, err := rows.Columns()
 		assert.NoError(t, err)
 		collectedRows = append(collectedRows, trimSliceSpace(columns))
+		assert.Len(t, collectedRows, rows.CurrentRow())
 	}
 	if !assert.NoError(t, rows.Error()) {
 		t.FailNow()
@@ -82,6 +90,257 @@ func TestRows(t *testing.T) {
 	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
 }
 
+func TestWalkRows(t *testing.T) {
+	f, err := OpenFile(filepath.Join("test", "Book1.xlsx"))
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	for _, opts := range []Options{{}, {RawCellValue: true}} {
+		expected, err := f.GetRows("Sheet2", opts)
+		assert.NoError(t, err)
+		var rowNums []int
+		assert.NoError(t, f.WalkRows("Sheet2", func(row int, cells []string) error {
+			assert.Equal(t, expected[row-1], cells, row)
+			rowNums = append(rowNums, row)
+			return nil
+		}, opts))
+		assert.Len(t, rowNums, len(expected))
+	}
+	// Test walk rows with stop early
+	var rowNums []int
+	assert.NoError(t, f.WalkRows("Sheet2", func(row int, cells []string) error {
+		if rowNums = append(rowNums, row); row == 3 {
+			return ErrStopWalk
+		}
+		return nil
+	}))
+	assert.Equal(t, []int{1, 2, 3}, rowNums)
+	// Test walk rows with error returned by the callback function
+	assert.Equal(t, ErrParameterInvalid, f.WalkRows("Sheet2", func(row int, cells []string) error {
+		return ErrParameterInvalid
+	}))
+	// Test walk rows on not exists worksheet
+	assert.EqualError(t, f.WalkRows("SheetN", nil), "sheet SheetN does not exist")
+	assert.NoError(t, f.Close())
+
+	// Test walk rows with sparse rows
+	f = NewFile()
+	assert.NoError(t, f.SetCellValue("Sheet1", "B2", "B2"))
+	assert.NoError(t, f.SetCellValue("Sheet1", "A5", "A5"))
+	walked := map[int][]string{}
+	assert.NoError(t, f.WalkRows("Sheet1", func(row int, cells []string) error {
+		walked[row] = cells
+		return nil
+	}))
+	assert.Equal(t, map[int][]string{2: {"", "B2"}, 5: {"A5"}}, walked)
+	// Test walk rows with invalid cell reference
+	f.Sheet.Delete("xl/worksheets/sheet1.xml")
+	f.Pkg.Store("xl/worksheets/sheet1.xml", []byte(fmt.Sprintf(`<worksheet xmlns="%s"><sheetData><row r="1"><c r="A" t="inlineStr"><is><t>A</t></is></c></row></sheetData></worksheet>`, NameSpaceSpreadSheet.Value)))
+	f.checked = sync.Map{}
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), f.WalkRows("Sheet1", func(row int, cells []string) error {
+		return nil
+	}))
+	assert.NoError(t, f.Close())
+}
+
+func TestGetNumericRange(t *testing.T) {
+	f := NewFile()
+	date := time.Date(2024, 1, 2, 12, 0, 0, 0, time.UTC)
+	for cell, value := range map[string]interface{}{
+		"A1": 1, "B1": "text", "C1": true, "D1": 4,
+		"A2": date, "B2": "123", "C2": 3.5,
+		"A4": -2.25, "C4": 1e-3,
+	} {
+		assert.NoError(t, f.SetCellValue("Sheet1", cell, value))
+	}
+	assert.NoError(t, f.SetCellFormula("Sheet1", "B4", "A1+A4"))
+	serial, err := timeToExcelTime(date, false)
+	assert.NoError(t, err)
+	expectedValues := [][]float64{{1, 0, 0}, {serial, 0, 3.5}, {0, 0, 0}, {-2.25, 0, 1e-3}}
+	expectedMask := [][]bool{{true, false, false}, {true, false, true}, {false, false, false}, {true, false, true}}
+	for _, rangeRef := range []string{"A1:C4", "C4:A1", "$A$1:$C$4"} {
+		values, mask, err := f.GetNumericRange("Sheet1", rangeRef)
+		assert.NoError(t, err)
+		assert.Equal(t, expectedValues, values)
+		assert.Equal(t, expectedMask, mask)
+	}
+	// Test get numeric range with a single cell and out of the used range
+	values, mask, err := f.GetNumericRange("Sheet1", "C2")
+	assert.NoError(t, err)
+	assert.Equal(t, [][]float64{{3.5}}, values)
+	assert.Equal(t, [][]bool{{true}}, mask)
+	values, mask, err = f.GetNumericRange("Sheet1", "D2:E3")
+	assert.NoError(t, err)
+	assert.Empty(t, values)
+	assert.Empty(t, mask)
+	// Test get numeric range clamped to the used range
+	values, mask, err = f.GetNumericRange("Sheet1", "B1:XFD1048576")
+	assert.NoError(t, err)
+	assert.Equal(t, [][]float64{{0, 0, 4}, {0, 3.5, 0}, {0, 0, 0}, {0, 1e-3, 0}}, values)
+	assert.Equal(t, [][]bool{{false, false, true}, {false, true, false}, {false, false, false}, {false, true, false}}, mask)
+	// Test get numeric range with invalid range reference
+	_, _, err = f.GetNumericRange("Sheet1", "A")
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), err)
+	// Test get numeric range on not exists worksheet
+	_, _, err = f.GetNumericRange("SheetN", "A1:B2")
+	assert.Equal(t, ErrSheetNotExist{"SheetN"}, err)
+	// Test get numeric range with invalid sheet name
+	_, _, err = f.GetNumericRange("Sheet:1", "A1:B2")
+	assert.Equal(t, ErrSheetNameInvalid, err)
+	// Test get numeric range with rows and cells without reference
+	f.Sheet.Delete("xl/worksheets/sheet1.xml")
+	f.Pkg.Store("xl/worksheets/sheet1.xml", []byte(fmt.Sprintf(`<worksheet xmlns="%s"><sheetData><row><c><v>1</v></c><c t="s"><v>0</v></c><c t="n"><v>3</v></c></row><row><c t="e"><v>#DIV/0!</v></c><c><v>NaN1</v></c><c t="b"><v>1</v></c></row><row><c/><c t="str"><v>4</v></c><c><f>A1</f></c></row><row r="5"><c r="C5"><v>5</v></c></row></sheetData></worksheet>`, NameSpaceSpreadSheet.Value)))
+	f.checked = sync.Map{}
+	values, mask, err = f.GetNumericRange("Sheet1", "B1:C4")
+	assert.NoError(t, err)
+	assert.Equal(t, [][]float64{{0, 3}, {0, 0}, {0, 0}}, values)
+	assert.Equal(t, [][]bool{{false, true}, {false, false}, {false, false}}, mask)
+	// Test get numeric range with date cells and non-finite numbers
+	f.Sheet.Delete("xl/worksheets/sheet1.xml")
+	f.Pkg.Store("xl/worksheets/sheet1.xml", []byte(fmt.Sprintf(`<worksheet xmlns="%s"><sheetData><row r="1"><c r="A1" t="d"><v>2024-01-02T12:00:00Z</v></c><c r="B1" t="d"><v>text</v></c><c r="C1"><v>NaN</v></c><c r="D1"><v>Inf</v></c></row></sheetData></worksheet>`, NameSpaceSpreadSheet.Value)))
+	f.checked = sync.Map{}
+	values, mask, err = f.GetNumericRange("Sheet1", "A1:D1")
+	assert.NoError(t, err)
+	assert.Equal(t, [][]float64{{serial, 0, 0, 0}}, values)
+	assert.Equal(t, [][]bool{{true, false, false, false}}, mask)
+	// Test get numeric range with malformed worksheet XML
+	f.Pkg.Store("xl/worksheets/sheet1.xml", []byte(fmt.Sprintf(`<worksheet xmlns="%s"><sheetData><row r="1"><c r="A1"><v>1`, NameSpaceSpreadSheet.Value)))
+	_, _, err = f.GetNumericRange("Sheet1", "A1:B2")
+	assert.Error(t, err)
+	f.Pkg.Store("xl/worksheets/sheet1.xml", []byte(fmt.Sprintf(`<worksheet xmlns="%s"><sheetData><row r="1"></row><row r="2" &></row></sheetData></worksheet>`, NameSpaceSpreadSheet.Value)))
+	_, _, err = f.GetNumericRange("Sheet1", "A1:B2")
+	assert.Error(t, err)
+	// Test get numeric range with invalid cell reference
+	f.Pkg.Store("xl/worksheets/sheet1.xml", []byte(fmt.Sprintf(`<worksheet xmlns="%s"><sheetData><row r="1"><c r="A"><v>1</v></c></row></sheetData></worksheet>`, NameSpaceSpreadSheet.Value)))
+	_, _, err = f.GetNumericRange("Sheet1", "A1:B2")
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), err)
+	assert.NoError(t, f.Close())
+}
+
+func TestRowsCheckpoint(t *testing.T) {
+	f := NewFile()
+	for r := 1; r <= 100; r++ {
+		if r > 50 && r < 60 {
+			continue
+		}
+		assert.NoError(t, f.SetSheetRow("Sheet1", fmt.Sprintf("A%d", r), &[]interface{}{r, fmt.Sprintf("R%d", r)}))
+	}
+	path := filepath.Join("test", "TestRowsCheckpoint.xlsx")
+	assert.NoError(t, f.SaveAs(path))
+	assert.NoError(t, f.Close())
+
+	type row struct {
+		num  int
+		cols []string
+	}
+	readRows := func(rows *Rows) []row {
+		result := []row{}
+		for rows.Next() {
+			cols, err := rows.Columns()
+			assert.NoError(t, err)
+			result = append(result, row{rows.CurrentRow(), cols})
+		}
+		assert.NoError(t, rows.Close())
+		return result
+	}
+	for _, opts := range []Options{{}, {UnzipXMLSizeLimit: 128}} {
+		f, err := OpenFile(path, opts)
+		assert.NoError(t, err)
+		rows, err := f.Rows("Sheet1")
+		assert.NoError(t, err)
+		expected := readRows(rows)
+		assert.Len(t, expected, 100)
+		// Test resume from the checkpoint on the first row, the middle of the
+		// worksheet, the empty rows, and the last row, with or without reading
+		// the columns of the current row
+		for _, n := range []int{0, 1, 30, 50, 55, 59, 60, 99, 100} {
+			for _, withColumns := range []bool{false, true} {
+				rows, err := f.Rows("Sheet1")
+				assert.NoError(t, err)
+				for i := 0; i < n && rows.Next(); i++ {
+					if withColumns {
+						_, err = rows.Columns()
+						assert.NoError(t, err)
+					}
+				}
+				checkpoint, err := rows.Checkpoint()
+				assert.NoError(t, err)
+				assert.NoError(t, rows.Close())
+
+				rows, err = f.RowsFromCheckpoint("Sheet1", checkpoint)
+				assert.NoError(t, err)
+				assert.Equal(t, expected[n:], readRows(rows), n)
+			}
+		}
+		assert.NoError(t, f.Close())
+	}
+
+	// Test resume from the checkpoint in another opened workbook
+	f, err := OpenFile(path)
+	assert.NoError(t, err)
+	rows, err := f.Rows("Sheet1")
+	assert.NoError(t, err)
+	for rows.CurrentRow() < 40 {
+		assert.True(t, rows.Next())
+	}
+	checkpoint, err := rows.Checkpoint()
+	assert.NoError(t, err)
+	assert.NoError(t, rows.Close())
+	assert.NoError(t, f.Close())
+	f, err = OpenFile(path)
+	assert.NoError(t, err)
+	rows, err = f.RowsFromCheckpoint("Sheet1", checkpoint)
+	assert.NoError(t, err)
+	assert.True(t, rows.Next())
+	assert.Equal(t, 41, rows.CurrentRow())
+	cols, err := rows.Columns()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"41", "R41"}, cols)
+	assert.NoError(t, rows.Close())
+
+	// Test resume with tampered checkpoints
+	for _, tamper := range []func(cp []byte) []byte{
+		func(cp []byte) []byte { return cp[:cpLength-1] },
+		func(cp []byte) []byte { cp[cpVersionPos]++; return cp },
+		func(cp []byte) []byte { cp[cpOffsetPos]++; return cp },
+		func(cp []byte) []byte { cp[cpChecksumPos]++; return cp },
+	} {
+		cp := tamper(append([]byte{}, checkpoint...))
+		rows, err = f.RowsFromCheckpoint("Sheet1", cp)
+		assert.Nil(t, rows)
+		assert.Equal(t, ErrRowsCheckpoint, err)
+	}
+	// Test resume with a tampered checkpoint which has a valid checksum
+	for _, tamper := range []func(cp []byte) []byte{
+		func(cp []byte) []byte { cp[cpOffsetPos]++; return cp },
+		func(cp []byte) []byte { binary.LittleEndian.PutUint64(cp[cpOffsetPos:], math.MaxInt32); return cp },
+		func(cp []byte) []byte { cp[cpCurRowPos]++; return cp },
+		func(cp []byte) []byte { binary.LittleEndian.PutUint32(cp[cpSeekRowPos:], TotalRows+1); return cp },
+	} {
+		cp := tamper(append([]byte{}, checkpoint...))
+		binary.LittleEndian.PutUint32(cp[cpChecksumPos:], crc32.ChecksumIEEE(cp[:cpChecksumPos]))
+		rows, err = f.RowsFromCheckpoint("Sheet1", cp)
+		assert.Nil(t, rows)
+		assert.Equal(t, ErrRowsCheckpoint, err)
+	}
+	// Test resume the checkpoint after the worksheet has been changed
+	assert.NoError(t, f.SetCellValue("Sheet1", "C1", "changed"))
+	rows, err = f.RowsFromCheckpoint("Sheet1", checkpoint)
+	assert.Nil(t, rows)
+	assert.Equal(t, ErrRowsCheckpoint, err)
+	// Test resume the checkpoint on not exists worksheet
+	_, err = f.RowsFromCheckpoint("SheetN", checkpoint)
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+	// Test create checkpoint on not exists worksheet
+	rows, err = f.Rows("Sheet1")
+	assert.NoError(t, err)
+	rows.sheet = "SheetN"
+	_, err = rows.Checkpoint()
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+	assert.NoError(t, rows.Close())
+	assert.NoError(t, f.Close())
+}
+
 func TestRowsIterator(t *testing.T) {
 	sheetName, rowCount, expectedNumRow := "Sheet2", 0, 11
 	f, err := OpenFile(filepath.Join("test", "Book1.xlsx"))
@@ -113,6 +372,85 @@ func TestRowsIterator(t *testing.T) {
 	assert.Equal(t, expectedNumRow, rowCount)
 }
 
+func TestRowsSetColumnFilter(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A1", &[]interface{}{"A1", "B1", "C1", "D1", "E1", "F1", "G1", "H1", "I1", "J1", "K1"}))
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A3", &[]interface{}{1, 2, 3}))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "K3", "=A3+B3"))
+	assert.NoError(t, f.SetCellValue("Sheet1", "K4", true))
+	rows, err := f.Rows("Sheet1")
+	assert.NoError(t, err)
+	// Test set column filter with invalid column name
+	assert.Equal(t, newInvalidColumnNameError("*"), rows.SetColumnFilter("B", "*"))
+	assert.NoError(t, rows.SetColumnFilter("K", "B", "D", "B"))
+	var results [][]string
+	for rows.Next() {
+		row, err := rows.Columns(Options{CalcFormulaOnRead: true})
+		assert.NoError(t, err)
+		results = append(results, row)
+	}
+	assert.NoError(t, rows.Close())
+	assert.Equal(t, [][]string{
+		{"K1", "B1", "D1", "B1"},
+		{"", "", "", ""},
+		{"3", "2", "", "2"},
+		{"TRUE", "", "", ""},
+	}, results)
+	// Test remove the column filter
+	rows, err = f.Rows("Sheet1")
+	assert.NoError(t, err)
+	assert.NoError(t, rows.SetColumnFilter("B"))
+	assert.True(t, rows.Next())
+	row, err := rows.Columns()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"B1"}, row)
+	assert.NoError(t, rows.SetColumnFilter())
+	assert.True(t, rows.Next())
+	assert.True(t, rows.Next())
+	row, err = rows.Columns()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"1", "2", "3", "", "", "", "", "", "", "", ""}, row)
+	assert.NoError(t, rows.Close())
+	// Test read the cells without reference with column filter
+	f.Sheet.Delete("xl/worksheets/sheet1.xml")
+	f.Pkg.Store("xl/worksheets/sheet1.xml", []byte(fmt.Sprintf(`<worksheet xmlns="%s"><sheetData><row r="1"><c t="inlineStr"><is><t>A1</t></is></c><c t="inlineStr"><is><t>B1</t></is></c><c r="*" t="inlineStr"><is><t>C1</t></is></c></row></sheetData></worksheet>`, NameSpaceSpreadSheet.Value)))
+	f.checked = sync.Map{}
+	rows, err = f.Rows("Sheet1")
+	assert.NoError(t, err)
+	assert.NoError(t, rows.SetColumnFilter("B"))
+	assert.True(t, rows.Next())
+	_, err = rows.Columns()
+	assert.EqualError(t, err, newCellNameToCoordinatesError("*", newInvalidCellNameError("*")).Error())
+	assert.NoError(t, rows.Close())
+	assert.NoError(t, f.Close())
+}
+
+func BenchmarkRowsColumnFilter(b *testing.B) {
+	f := prepareBenchmarkCols(b, 300, 1000)
+	for _, cols := range [][]string{nil, {"B", "D", "K"}} {
+		b.Run(fmt.Sprintf("columns=%d", len(cols)), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				rows, err := f.Rows("Sheet1")
+				if err != nil {
+					b.Fatal(err)
+				}
+				if err = rows.SetColumnFilter(cols...); err != nil {
+					b.Fatal(err)
+				}
+				for rows.Next() {
+					if _, err = rows.Columns(); err != nil {
+						b.Error(err)
+					}
+				}
+				if err = rows.Close(); err != nil {
+					b.Error(err)
+				}
+			}
+		})
+	}
+}
+
 func TestRowsGetRowOpts(t *testing.T) {
 	sheetName := "Sheet2"
 	expectedRowStyleID1 := RowOpts{Height: 17.0, Hidden: false, StyleID: 1}
@@ -149,6 +487,102 @@ func TestRowsError(t *testing.T) {
 	assert.NoError(t, f.Close())
 }
 
+func TestRowsIgnoreInvalidCellRef(t *testing.T) {
+	f := NewFile()
+	f.Sheet.Delete("xl/worksheets/sheet1.xml")
+	f.Pkg.Store("xl/worksheets/sheet1.xml", []byte(fmt.Sprintf(`<worksheet xmlns="%s"><sheetData><row r="1"><c r="A1" t="str"><v>a</v></c><c r="1B" t="str"><v>b</v></c><c r="C1" t="str"><v>c</v></c></row><row r="2"><c r="A2" t="str"><v>d</v></c><c t="str"><v>e</v></c></row></sheetData></worksheet>`, NameSpaceSpreadSheet.Value)))
+	f.checked = sync.Map{}
+	rows, err := f.Rows("Sheet1")
+	assert.NoError(t, err)
+	assert.True(t, rows.Next())
+	_, err = rows.Columns()
+	assert.Equal(t, newCellNameToCoordinatesError("1B", newInvalidCellNameError("1B")), err)
+	assert.NoError(t, rows.Close())
+	// Test read rows with the invalid cell reference skipped
+	rows, err = f.Rows("Sheet1", Options{IgnoreInvalidCellRef: true})
+	assert.NoError(t, err)
+	var results [][]string
+	for rows.Next() {
+		row, err := rows.Columns()
+		assert.NoError(t, err)
+		results = append(results, row)
+	}
+	assert.Equal(t, [][]string{{"a", "b", "c"}, {"d", "e"}}, results)
+	assert.Equal(t, []error{ErrInvalidCellRef{
+		Sheet: "Sheet1", Row: 1, Ref: "1B", Err: newCellNameToCoordinatesError("1B", newInvalidCellNameError("1B")),
+	}}, rows.Warnings())
+	assert.EqualError(t, rows.Warnings()[0], `invalid cell reference "1B" in sheet Sheet1 near row 1: cannot convert cell "1B" to coordinates: invalid cell name "1B"`)
+	assert.ErrorIs(t, rows.Warnings()[0], rows.Warnings()[0].(ErrInvalidCellRef).Err)
+	assert.NoError(t, rows.Close())
+	results, err = f.GetRows("Sheet1", Options{IgnoreInvalidCellRef: true})
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{{"a", "b", "c"}, {"d", "e"}}, results)
+	assert.NoError(t, f.Close())
+}
+
+func TestRowsCellValueTransformer(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A1", &[]interface{}{"1,5", 45000, nil, "x"}))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "A2", "B1+1"))
+	style, err := f.NewStyle(&Style{NumFmt: 14})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellStyle("Sheet1", "B1", "C1", style))
+	var (
+		mu    sync.Mutex
+		metas []CellMeta
+	)
+	transformer := func(cell string, meta CellMeta, value string) (string, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		metas = append(metas, meta)
+		if value == "x" {
+			return value, ErrParameterInvalid
+		}
+		return strings.ReplaceAll(value, ",", "."), nil
+	}
+	rows, err := f.Rows("Sheet1", Options{CellValueTransformer: transformer})
+	assert.NoError(t, err)
+	assert.True(t, rows.Next())
+	_, err = rows.Columns(Options{CellValueTransformer: transformer})
+	assert.Equal(t, ErrTransformCellValue{Sheet: "Sheet1", Cell: "D1", Err: ErrParameterInvalid}, err)
+	assert.EqualError(t, err, "cannot transform the value of cell D1 in sheet Sheet1: parameter is invalid")
+	assert.ErrorIs(t, err, ErrParameterInvalid)
+	assert.Equal(t, []CellMeta{
+		{Sheet: "Sheet1", Cell: "A1", Type: CellTypeSharedString},
+		{Sheet: "Sheet1", Cell: "B1", Type: CellTypeNumber, NumFmtID: 14},
+		{Sheet: "Sheet1", Cell: "D1", Type: CellTypeSharedString},
+	}, metas)
+	assert.True(t, rows.Next())
+	cells, err := rows.Columns(Options{CellValueTransformer: transformer})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{""}, cells)
+	assert.Equal(t, CellMeta{Sheet: "Sheet1", Cell: "A2", Type: CellTypeFormula}, metas[len(metas)-1])
+	assert.NoError(t, rows.Close())
+	// Test get rows with the cell value transformer
+	_, err = f.GetRows("Sheet1", Options{CellValueTransformer: transformer})
+	assert.Equal(t, ErrTransformCellValue{Sheet: "Sheet1", Cell: "D1", Err: ErrParameterInvalid}, err)
+	assert.NoError(t, f.SetCellValue("Sheet1", "D1", nil))
+	// Test get rows with the cell value transformer concurrently
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results, err := f.GetRows("Sheet1", Options{CellValueTransformer: transformer})
+			assert.NoError(t, err)
+			assert.Equal(t, [][]string{{"1.5", "03-15-23"}, {""}}, results)
+		}()
+	}
+	wg.Wait()
+	// Test walk rows with the cell value transformer
+	assert.Equal(t, ErrTransformCellValue{Sheet: "Sheet1", Cell: "A1", Err: ErrParameterInvalid}, f.WalkRows("Sheet1", func(row int, cells []string) error {
+		return nil
+	}, Options{CellValueTransformer: func(cell string, meta CellMeta, value string) (string, error) {
+		return value, ErrParameterInvalid
+	}}))
+	assert.NoError(t, f.Close())
+}
+
 func TestRowHeight(t *testing.T) {
 	f := NewFile()
 	sheet1 := f.GetSheetName(0)
@@ -213,6 +647,41 @@ func TestRowHeight(t *testing.T) {
 	}
 
 	assert.Equal(t, 0.0, convertColWidthToPixels(0))
+	assert.Equal(t, 40, RowHeightToPixels(30))
+	assert.Equal(t, 21, RowHeightToPixels(defaultRowHeight))
+	assert.Zero(t, RowHeightToPixels(0))
+}
+
+func TestRowHeightUnit(t *testing.T) {
+	f := NewFile()
+	for _, c := range []struct {
+		value float64
+		unit  MeasurementUnit
+	}{
+		{1, UnitCentimeters}, {0.5, UnitInches}, {12.7, UnitMillimeters}, {30, UnitPoints},
+	} {
+		assert.NoError(t, f.SetRowHeightUnit("Sheet1", 1, c.value, c.unit))
+		height, err := f.GetRowHeightUnit("Sheet1", 1, c.unit)
+		assert.NoError(t, err)
+		assert.InDelta(t, c.value, height, 1e-9)
+	}
+	height, err := f.GetRowHeight("Sheet1", 1)
+	assert.NoError(t, err)
+	assert.Equal(t, 30.0, height)
+	// Test get the height of default row, 15.6 points in 96 DPI
+	height, err = f.GetRowHeightUnit("Sheet1", 2, UnitCentimeters)
+	assert.NoError(t, err)
+	assert.InDelta(t, 0.55, height, 0.005)
+	// Test set and get row height with invalid parameters
+	assert.Equal(t, ErrParameterInvalid, f.SetRowHeightUnit("Sheet1", 1, -1, UnitPoints))
+	assert.Equal(t, ErrParameterInvalid, f.SetRowHeightUnit("Sheet1", 1, 1, MeasurementUnit(10)))
+	assert.Equal(t, ErrMaxRowHeight, f.SetRowHeightUnit("Sheet1", 1, 10, UnitInches))
+	assert.EqualError(t, f.SetRowHeightUnit("Sheet1", 0, 1, UnitPoints), newInvalidRowNumberError(0).Error())
+	_, err = f.GetRowHeightUnit("Sheet1", 1, MeasurementUnit(10))
+	assert.Equal(t, ErrParameterInvalid, err)
+	_, err = f.GetRowHeightUnit("Sheet1", 0, UnitPoints)
+	assert.EqualError(t, err, newInvalidRowNumberError(0).Error())
+	assert.NoError(t, f.Close())
 }
 
 func TestColumns(t *testing.T) {
@@ -369,6 +838,278 @@ func TestRemoveRow(t *testing.T) {
 	assert.EqualError(t, f.RemoveRow("Sheet:1", 1), ErrSheetNameInvalid.Error())
 }
 
+func TestRemoveRows(t *testing.T) {
+	prepare := func() *File {
+		f := NewFile()
+		_, err := f.NewSheet("Sheet2")
+		assert.NoError(t, err)
+		for row := 1; row <= 20; row++ {
+			cell, err := CoordinatesToCellName(1, row)
+			assert.NoError(t, err)
+			assert.NoError(t, f.SetSheetRow("Sheet1", cell, &[]interface{}{row, row * 10}))
+			assert.NoError(t, f.SetCellFormula("Sheet1", fmt.Sprintf("C%d", row), fmt.Sprintf("A%d+B%d", row, row)))
+		}
+		assert.NoError(t, f.SetCellFormula("Sheet1", "D1", "SUM(A2:A19)"))
+		assert.NoError(t, f.SetCellFormula("Sheet2", "A1", "Sheet1!A5+SUM(Sheet1!B3:B12)"))
+		assert.NoError(t, f.MergeCell("Sheet1", "E4", "F9"))
+		assert.NoError(t, f.MergeCell("Sheet1", "E12", "F12"))
+		assert.NoError(t, f.MergeCell("Sheet1", "E15", "F17"))
+		assert.NoError(t, f.SetCellHyperLink("Sheet1", "G5", "https://github.com/xuri/excelize", "External"))
+		assert.NoError(t, f.SetCellHyperLink("Sheet1", "G14", "https://github.com/xuri/excelize", "External"))
+		dv := NewDataValidation(true)
+		dv.Sqref = "H2:H16"
+		assert.NoError(t, dv.SetRange(1, 10, DataValidationTypeWhole, DataValidationOperatorBetween))
+		assert.NoError(t, f.AddDataValidation("Sheet1", dv))
+		format, err := f.NewConditionalStyle(&Style{Font: &Font{Color: "9A0511"}})
+		assert.NoError(t, err)
+		assert.NoError(t, f.SetConditionalFormat("Sheet1", "A3:A18", []ConditionalFormatOptions{
+			{Type: "cell", Criteria: ">", Format: &format, Value: "6"},
+		}))
+		assert.NoError(t, f.AddComment("Sheet1", Comment{Cell: "B7", Author: "Excelize", Text: "removed"}))
+		assert.NoError(t, f.AddComment("Sheet1", Comment{Cell: "B15", Author: "Excelize", Text: "kept"}))
+		assert.NoError(t, f.AddPicture("Sheet1", "I10", filepath.Join("test", "images", "excel.png"), nil))
+		assert.NoError(t, f.SetDefinedName(&DefinedName{Name: "Amount", RefersTo: "Sheet1!$A$5:$A$15"}))
+		assert.NoError(t, f.AddTable("Sheet1", &Table{Range: "J2:K20", Name: "Table1"}))
+		return f
+	}
+	doomed := []int{14, 3, 7, 8, 9, 12, 3, 17, 25}
+	expected, actual := prepare(), prepare()
+	sorted := []int{25, 17, 14, 12, 9, 8, 7, 3}
+	for _, row := range sorted {
+		assert.NoError(t, expected.RemoveRow("Sheet1", row))
+	}
+	assert.NoError(t, actual.RemoveRows("Sheet1", doomed))
+	for _, sheet := range []string{"Sheet1", "Sheet2"} {
+		expectedRows, err := expected.GetRows(sheet)
+		assert.NoError(t, err)
+		actualRows, err := actual.GetRows(sheet)
+		assert.NoError(t, err)
+		assert.Equal(t, expectedRows, actualRows)
+		for row := 1; row <= 20; row++ {
+			for col := 1; col <= 4; col++ {
+				cell, err := CoordinatesToCellName(col, row)
+				assert.NoError(t, err)
+				expectedFormula, err := expected.GetCellFormula(sheet, cell)
+				assert.NoError(t, err)
+				actualFormula, err := actual.GetCellFormula(sheet, cell)
+				assert.NoError(t, err)
+				assert.Equal(t, expectedFormula, actualFormula, cell)
+			}
+		}
+	}
+	formula, err := actual.GetCellFormula("Sheet1", "D1")
+	assert.NoError(t, err)
+	assert.Equal(t, "SUM(A2:A12)", formula)
+	formula, err = actual.GetCellFormula("Sheet2", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, "Sheet1!A4+SUM(Sheet1!B2:B7)", formula)
+	expectedMergeCells, err := expected.GetMergeCells("Sheet1")
+	assert.NoError(t, err)
+	actualMergeCells, err := actual.GetMergeCells("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, expectedMergeCells, actualMergeCells)
+	for _, cell := range []string{"G4", "G5", "G10", "G11"} {
+		expectedLink, expectedTarget, err := expected.GetCellHyperLink("Sheet1", cell)
+		assert.NoError(t, err)
+		actualLink, actualTarget, err := actual.GetCellHyperLink("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expectedLink, actualLink, cell)
+		assert.Equal(t, expectedTarget, actualTarget, cell)
+	}
+	expectedDV, err := expected.GetDataValidations("Sheet1")
+	assert.NoError(t, err)
+	actualDV, err := actual.GetDataValidations("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, expectedDV, actualDV)
+	expectedCF, err := expected.GetConditionalFormats("Sheet1")
+	assert.NoError(t, err)
+	actualCF, err := actual.GetConditionalFormats("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, expectedCF, actualCF)
+	expectedComments, err := expected.GetComments("Sheet1")
+	assert.NoError(t, err)
+	actualComments, err := actual.GetComments("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, expectedComments, actualComments)
+	assert.Len(t, actualComments, 1)
+	assert.Equal(t, "B9", actualComments[0].Cell)
+	expectedPictureCells, err := expected.GetPictureCells("Sheet1")
+	assert.NoError(t, err)
+	actualPictureCells, err := actual.GetPictureCells("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, expectedPictureCells, actualPictureCells)
+	assert.Equal(t, []string{"I6"}, actualPictureCells)
+	assert.Equal(t, expected.GetDefinedName(), actual.GetDefinedName())
+	assert.Equal(t, "Sheet1!$A$4:$A$9", actual.GetDefinedName()[0].RefersTo)
+	expectedTables, err := expected.GetTables("Sheet1")
+	assert.NoError(t, err)
+	actualTables, err := actual.GetTables("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, expectedTables, actualTables)
+	assert.Equal(t, "J2:K13", actualTables[0].Range)
+	assert.NoError(t, actual.SaveAs(filepath.Join("test", "TestRemoveRows.xlsx")))
+
+	// Test remove rows with empty row numbers
+	assert.NoError(t, actual.RemoveRows("Sheet1", nil))
+	// Test remove rows with invalid row number
+	assert.EqualError(t, actual.RemoveRows("Sheet1", []int{1, 0}), newInvalidRowNumberError(0).Error())
+	// Test remove rows on not exists worksheet
+	assert.EqualError(t, actual.RemoveRows("SheetN", []int{1}), "sheet SheetN does not exist")
+	// Test remove rows with unsupported charset worksheet
+	actual.Sheet.Delete("xl/worksheets/sheet2.xml")
+	actual.Pkg.Store("xl/worksheets/sheet2.xml", MacintoshCyrillicCharset)
+	assert.EqualError(t, actual.RemoveRows("Sheet1", []int{1}), "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, expected.Close())
+	assert.NoError(t, actual.Close())
+}
+
+func TestRemoveRowsWhere(t *testing.T) {
+	f := NewFile()
+	for row := 1; row <= 10; row++ {
+		cell, err := CoordinatesToCellName(1, row)
+		assert.NoError(t, err)
+		if row%3 == 0 {
+			continue
+		}
+		assert.NoError(t, f.SetSheetRow("Sheet1", cell, &[]interface{}{fmt.Sprintf("row %d", row), row}))
+	}
+	n, err := f.RemoveRowsWhere("Sheet1", func(rowIdx int, values []string) bool {
+		return len(values) == 0 || rowIdx == 1
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 4, n)
+	rows, err := f.GetRows("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{
+		{"row 2", "2"}, {"row 4", "4"}, {"row 5", "5"}, {"row 7", "7"}, {"row 8", "8"}, {"row 10", "10"},
+	}, rows)
+	// Test remove rows where on not exists worksheet
+	n, err = f.RemoveRowsWhere("SheetN", func(int, []string) bool { return true })
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+	assert.Zero(t, n)
+	assert.NoError(t, f.Close())
+}
+
+func TestSortRange(t *testing.T) {
+	f := NewFile(Options{TrackChanges: true})
+	for i, row := range [][]interface{}{
+		{"Name", "Score", "Date", "Note"},
+		{"carol", 30, time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), "D2"},
+		{"Alice", 10, "2024-01-15", "D3"},
+		{"bob", nil, time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC), "D4"},
+		{"Dave", 30, nil, "D5"},
+		{"alice", "n/a", time.Date(2023, 12, 1, 0, 0, 0, 0, time.UTC), "D6"},
+	} {
+		cell, err := CoordinatesToCellName(1, i+1)
+		assert.NoError(t, err)
+		assert.NoError(t, f.SetSheetRow("Sheet1", cell, &row))
+	}
+	style, err := f.NewStyle(&Style{Font: &Font{Bold: true}})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellStyle("Sheet1", "A3", "A3", style))
+	assert.NoError(t, f.SetCellHyperLink("Sheet1", "A3", "https://github.com/xuri/excelize", "External"))
+	assert.NoError(t, f.SetCellHyperLink("Sheet1", "D3", "Sheet1!A1", "Location"))
+	assert.NoError(t, f.AddComment("Sheet1", Comment{Cell: "B4", Author: "Excelize", Text: "blank"}))
+	getCol := func(col string) []string {
+		cols, err := f.GetCols("Sheet1")
+		assert.NoError(t, err)
+		num, err := ColumnNameToNumber(col)
+		assert.NoError(t, err)
+		return cols[num-1][1:]
+	}
+	// Test sort range by numeric values in ascending order, the text values
+	// are sorted after the numeric values, and the blank cells are sorted last
+	assert.NoError(t, f.SortRange("Sheet1", "C6:A2", []SortKey{{Col: "B", Kind: SortNumeric}}))
+	assert.Equal(t, []string{"Alice", "carol", "Dave", "alice", "bob"}, getCol("A"))
+	assert.Equal(t, []string{"D2", "D3", "D4", "D5", "D6"}, getCol("D"))
+	styleID, err := f.GetCellStyle("Sheet1", "A2")
+	assert.NoError(t, err)
+	assert.Equal(t, style, styleID)
+	link, target, err := f.GetCellHyperLink("Sheet1", "A2")
+	assert.NoError(t, err)
+	assert.True(t, link)
+	assert.Equal(t, "https://github.com/xuri/excelize", target)
+	link, _, err = f.GetCellHyperLink("Sheet1", "D3")
+	assert.NoError(t, err)
+	assert.True(t, link)
+	comments, err := f.GetComments("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, comments, 1)
+	assert.Equal(t, "B6", comments[0].Cell)
+	changes, err := f.GetChangeJournal()
+	assert.NoError(t, err)
+	assert.Contains(t, changes, CellChange{Sheet: "Sheet1", Cell: "A2", OldValue: "carol", NewValue: "Alice"})
+	// Test sort range by numeric values in descending order and then by text
+	// values case-insensitively in descending order
+	assert.NoError(t, f.SortRange("Sheet1", "A2:C6", []SortKey{
+		{Col: "B", Descending: true}, {Col: "A", Descending: true, Kind: SortText},
+	}))
+	assert.Equal(t, []string{"alice", "Dave", "carol", "Alice", "bob"}, getCol("A"))
+	// Test sort range by date values, the date text will be parsed
+	assert.NoError(t, f.SortRange("Sheet1", "A2:C6", []SortKey{{Col: "C", Kind: SortDate}}))
+	assert.Equal(t, []string{"alice", "Alice", "bob", "carol", "Dave"}, getCol("A"))
+	// Test sort range by text values with the stable order for equal keys
+	assert.NoError(t, f.SortRange("Sheet1", "A2:C6", []SortKey{{Col: "A", Kind: SortText}}))
+	assert.Equal(t, []string{"alice", "Alice", "bob", "carol", "Dave"}, getCol("A"))
+	// Test sort range with the formula cells moved verbatim, the shared
+	// formulas will be converted to normal formulas
+	assert.NoError(t, f.SetCellFormula("Sheet1", "E2", "B2*2", FormulaOpts{Type: &[]string{STCellFormulaTypeShared}[0], Ref: &[]string{"E2:E6"}[0]}))
+	assert.NoError(t, f.SetCellValue("Sheet1", "B2", 5))
+	assert.NoError(t, f.SortRange("Sheet1", "A2:E6", []SortKey{{Col: "B", Descending: true}}))
+	assert.Equal(t, []string{"carol", "Dave", "Alice", "alice", "bob"}, getCol("A"))
+	for cell, expected := range map[string]string{"E2": "B5*2", "E3": "B6*2", "E4": "B3*2", "E5": "B2*2", "E6": "B4*2"} {
+		formula, err := f.GetCellFormula("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, formula, cell)
+	}
+	// Test sort range with the formula cells rejected
+	assert.Equal(t, newSortRangeFormulaError("E2"), f.SortRange("Sheet1", "A2:E6", []SortKey{{Col: "A"}}, SortRangeOptions{RejectFormulas: true}))
+	assert.NoError(t, f.SortRange("Sheet1", "A2:D6", []SortKey{{Col: "A"}}, SortRangeOptions{RejectFormulas: true}))
+	// Test sort range with the merged cell intersects the range
+	assert.NoError(t, f.MergeCell("Sheet1", "D6", "E7"))
+	assert.EqualError(t, f.SortRange("Sheet1", "A2:D6", []SortKey{{Col: "A"}}), "cannot sort the range A2:D6: the merged cell D6:E7 intersects the range, to do this, all the merged cells need to be the same size")
+	assert.NoError(t, f.SortRange("Sheet1", "A2:C6", []SortKey{{Col: "A"}}))
+	// Test sort range with invalid range reference
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), f.SortRange("Sheet1", "A:B", []SortKey{{Col: "A"}}))
+	// Test sort range without sort keys
+	assert.Equal(t, ErrParameterInvalid, f.SortRange("Sheet1", "A2:C6", nil))
+	// Test sort range with invalid sort key column name
+	assert.Equal(t, newInvalidColumnNameError("*"), f.SortRange("Sheet1", "A2:C6", []SortKey{{Col: "*"}}))
+	// Test sort range with the sort key column outside of the range
+	assert.EqualError(t, f.SortRange("Sheet1", "A2:C6", []SortKey{{Col: "D"}}), "sort key column D is outside of the range A2:C6")
+	// Test sort range with invalid sort value kind
+	assert.Equal(t, ErrParameterInvalid, f.SortRange("Sheet1", "A2:C6", []SortKey{{Col: "A", Kind: 3}}))
+	// Test sort range on not exists worksheet
+	assert.EqualError(t, f.SortRange("SheetN", "A2:C6", []SortKey{{Col: "A"}}), "sheet SheetN does not exist")
+	// Test sort range with invalid merged cell reference
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	ws.(*xlsxWorksheet).MergeCells.Cells[0].Ref = "D6"
+	assert.Equal(t, ErrParameterInvalid, f.SortRange("Sheet1", "A2:C6", []SortKey{{Col: "A"}}))
+	ws.(*xlsxWorksheet).MergeCells = nil
+	// Test sort range with invalid hyperlink reference
+	ws.(*xlsxWorksheet).Hyperlinks.Hyperlink[0].Ref = "A"
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), f.SortRange("Sheet1", "A2:C6", []SortKey{{Col: "A", Descending: true}}))
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestSortRange.xlsx")))
+	assert.NoError(t, f.Close())
+
+	f = NewFile()
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A1", &[]interface{}{"b"}))
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A2", &[]interface{}{"a"}))
+	// Test sort range with unsupported charset shared strings table
+	f.SharedStrings = nil
+	f.Pkg.Store(defaultXMLPathSharedStrings, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.SortRange("Sheet1", "A1:A2", []SortKey{{Col: "A"}}), "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+
+	f = NewFile()
+	// Test sort range with unsupported charset workbook
+	f.WorkBook = nil
+	f.Pkg.Store(defaultXMLPathWorkbook, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.SortRange("Sheet1", "A1:A2", []SortKey{{Col: "A"}}), "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
+
 func TestInsertRows(t *testing.T) {
 	f := NewFile()
 	sheet1 := f.GetSheetName(0)
@@ -426,6 +1167,116 @@ func TestInsertRowsInEmptyFile(t *testing.T) {
 	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestInsertRowInEmptyFile.xlsx")))
 }
 
+func TestInsertRowsInheritStyle(t *testing.T) {
+	f := NewFile()
+	rowStyle, err := f.NewStyle(&Style{Fill: Fill{Type: "pattern", Color: []string{"E0EBF5"}, Pattern: 1}})
+	assert.NoError(t, err)
+	boldStyle, err := f.NewStyle(&Style{Font: &Font{Bold: true}})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetRowStyle("Sheet1", 2, 2, rowStyle))
+	assert.NoError(t, f.SetRowHeight("Sheet1", 2, 30))
+	assert.NoError(t, f.SetCellValue("Sheet1", "B2", "B2"))
+	assert.NoError(t, f.SetCellStyle("Sheet1", "D2", "D2", boldStyle))
+	assert.NoError(t, f.SetCellValue("Sheet1", "A3", "A3"))
+	assert.NoError(t, f.SetCellStyle("Sheet1", "C3", "C3", boldStyle))
+	assertRowStyles := func(srcRow int, rows ...int) {
+		srcHeight, err := f.GetRowHeight("Sheet1", srcRow)
+		assert.NoError(t, err)
+		for _, row := range rows {
+			height, err := f.GetRowHeight("Sheet1", row)
+			assert.NoError(t, err)
+			assert.Equal(t, srcHeight, height)
+			for col := 1; col <= 5; col++ {
+				srcCell, err := CoordinatesToCellName(col, srcRow)
+				assert.NoError(t, err)
+				cell, err := CoordinatesToCellName(col, row)
+				assert.NoError(t, err)
+				srcStyle, err := f.GetCellStyle("Sheet1", srcCell)
+				assert.NoError(t, err)
+				styleID, err := f.GetCellStyle("Sheet1", cell)
+				assert.NoError(t, err)
+				assert.Equal(t, srcStyle, styleID, cell)
+				val, err := f.GetCellValue("Sheet1", cell)
+				assert.NoError(t, err)
+				assert.Empty(t, val, cell)
+			}
+		}
+	}
+	// Test insert rows with the formatting of the row above
+	assert.NoError(t, f.InsertRows("Sheet1", 3, 2, InsertRowsOptions{InheritStyle: InheritStyleAbove}))
+	assertRowStyles(2, 3, 4)
+	val, err := f.GetCellValue("Sheet1", "A5")
+	assert.NoError(t, err)
+	assert.Equal(t, "A3", val)
+	// Test insert rows with the formatting of the row below
+	assert.NoError(t, f.InsertRows("Sheet1", 5, 1, InsertRowsOptions{InheritStyle: InheritStyleBelow}))
+	assertRowStyles(6, 5)
+	// Test insert rows without inherit formatting by default
+	assert.NoError(t, f.InsertRows("Sheet1", 3, 1))
+	height, err := f.GetRowHeight("Sheet1", 3)
+	assert.NoError(t, err)
+	assert.Equal(t, defaultRowHeight, height)
+	for _, cell := range []string{"B3", "D3"} {
+		styleID, err := f.GetCellStyle("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Zero(t, styleID)
+	}
+	// Test insert rows inherit formatting from the row doesn't exist
+	assert.NoError(t, f.InsertRows("Sheet1", 1, 1, InsertRowsOptions{InheritStyle: InheritStyleAbove}))
+	assert.NoError(t, f.InsertRows("Sheet1", 20, 1, InsertRowsOptions{InheritStyle: InheritStyleBelow}))
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestInsertRowsInheritStyle.xlsx")))
+	// Test insert rows with invalid inherit style option
+	assert.Equal(t, ErrParameterInvalid, f.InsertRows("Sheet1", 1, 1, InsertRowsOptions{InheritStyle: 3}))
+	// Test insert rows inherit formatting with invalid cell reference
+	ws, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	for i := range ws.SheetData.Row[2].C {
+		if ws.SheetData.Row[2].C[i].S != 0 {
+			ws.SheetData.Row[2].C[i].R = "A"
+		}
+	}
+	assert.Error(t, f.inheritRowStyle("Sheet1", 3, 4, 1))
+	// Test insert rows inherit formatting on not exists worksheet
+	assert.EqualError(t, f.inheritRowStyle("SheetN", 1, 2, 1), "sheet SheetN does not exist")
+	assert.NoError(t, f.Close())
+
+	// Test duplicate row keeps the row level attributes
+	f = NewFile()
+	rowStyle, err = f.NewStyle(&Style{Fill: Fill{Type: "pattern", Color: []string{"E0EBF5"}, Pattern: 1}})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetRowStyle("Sheet1", 1, 1, rowStyle))
+	assert.NoError(t, f.SetRowHeight("Sheet1", 1, 30))
+	assert.NoError(t, f.SetRowOutlineLevel("Sheet1", 1, 2))
+	assert.NoError(t, f.SetCellValue("Sheet1", "A1", "A1"))
+	assert.NoError(t, f.DuplicateRowTo("Sheet1", 1, 3))
+	assert.NoError(t, f.DuplicateRow("Sheet1", 3))
+	ws, err = f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, ws.SheetData.Row, 4)
+	src := ws.SheetData.Row[0]
+	for _, dst := range []xlsxRow{ws.SheetData.Row[2], ws.SheetData.Row[3]} {
+		assert.Equal(t, src.S, dst.S)
+		assert.True(t, dst.CustomFormat)
+		assert.True(t, dst.CustomHeight)
+		assert.Equal(t, uint8(2), dst.OutlineLevel)
+		assert.Equal(t, 30.0, *dst.Ht)
+		assert.NotSame(t, src.Ht, dst.Ht)
+	}
+	assert.Equal(t, []int{3, 4}, []int{ws.SheetData.Row[2].R, ws.SheetData.Row[3].R})
+	// Test change the duplicated row attributes doesn't affect the source row
+	assert.NoError(t, f.SetRowHeight("Sheet1", 3, 15))
+	assert.NoError(t, f.SetRowOutlineLevel("Sheet1", 4, 1))
+	for row, expected := range map[int]float64{1: 30, 3: 15, 4: 30} {
+		height, err := f.GetRowHeight("Sheet1", row)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, height, row)
+	}
+	level, err := f.GetRowOutlineLevel("Sheet1", 1)
+	assert.NoError(t, err)
+	assert.Equal(t, uint8(2), level)
+	assert.NoError(t, f.Close())
+}
+
 func prepareTestBook2() (*File, error) {
 	f := NewFile()
 	for cell, val := range map[string]string{
@@ -1012,6 +1863,61 @@ func TestCheckRow(t *testing.T) {
 	assert.EqualError(t, f.SetCellValue("Sheet1", "A1", false), newCellNameToCoordinatesError("-", newInvalidCellNameError("-")).Error())
 }
 
+func TestCheckSheetDisorderedRows(t *testing.T) {
+	f := NewFile()
+	f.Pkg.Store("xl/worksheets/sheet1.xml", []byte(xml.Header+`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData><row r="3"><c r="A3" t="inlineStr"><is><t>A3</t></is></c></row><row r="1"><c r="A1" t="inlineStr"><is><t>A1</t></is></c><c r="B1" t="inlineStr"><is><t>B1</t></is></c></row><row r="2"><c r="A2" t="inlineStr"><is><t>A2</t></is></c></row><row r="1"><c r="A1" t="inlineStr"><is><t>A1 later</t></is></c><c r="C1" t="inlineStr"><is><t>C1</t></is></c></row></sheetData></worksheet>`))
+	f.Sheet.Delete("xl/worksheets/sheet1.xml")
+	f.checked.Delete("xl/worksheets/sheet1.xml")
+	assert.NoError(t, f.SetCellValue("Sheet1", "D1", "D1"))
+	assert.NoError(t, f.SetCellValue("Sheet1", "B2", "B2"))
+	expected := [][]string{{"A1 later", "B1", "C1", "D1"}, {"A2", "B2"}, {"A3"}}
+	rows, err := f.GetRows("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, expected, rows)
+	ws, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, ws.SheetData.Row, 3)
+	for i, row := range ws.SheetData.Row {
+		assert.Equal(t, i+1, row.R)
+	}
+	// Test the normalized rows are kept after save and reopen
+	var buf bytes.Buffer
+	assert.NoError(t, f.Write(&buf))
+	f, err = OpenReader(&buf)
+	assert.NoError(t, err)
+	rows, err = f.GetRows("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, expected, rows)
+	assert.NoError(t, f.Close())
+}
+
+func TestCheckSheetDuplicateRows(t *testing.T) {
+	f := NewFile()
+	f.Pkg.Store("xl/worksheets/sheet1.xml", []byte(xml.Header+`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData><row r="1"><c r="B1" t="inlineStr"><is><t>b</t></is></c></row><row r="1" ht="30" customHeight="1" hidden="1" s="1" customFormat="1"><c r="A1" t="inlineStr"><is><t>a</t></is></c></row></sheetData></worksheet>`))
+	f.Sheet.Delete("xl/worksheets/sheet1.xml")
+	f.checked.Delete("xl/worksheets/sheet1.xml")
+	for cell, expected := range map[string]string{"A1": "a", "B1": "b"} {
+		val, err := f.GetCellValue("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, val)
+	}
+	ws, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, ws.SheetData.Row, 1)
+	row := ws.SheetData.Row[0]
+	assert.Equal(t, []string{"A1", "B1"}, []string{row.C[0].R, row.C[1].R})
+	assert.Equal(t, float64Ptr(30), row.Ht)
+	assert.True(t, row.CustomHeight)
+	assert.True(t, row.Hidden)
+	assert.Equal(t, 1, row.S)
+	assert.True(t, row.CustomFormat)
+
+	// Test merge duplicate rows with the cells without reference
+	r := xlsxRow{R: 2, C: []xlsxC{{V: "1"}, {R: "C2", V: "3"}}}
+	r.merge(xlsxRow{R: 2, C: []xlsxC{{V: "one"}, {V: "2"}}, OutlineLevel: 1, Spans: "1:3"})
+	assert.Equal(t, xlsxRow{R: 2, C: []xlsxC{{R: "A2", V: "one"}, {R: "B2", V: "2"}, {R: "C2", V: "3"}}, OutlineLevel: 1, Spans: "1:3"}, r)
+}
+
 func TestSetRowStyle(t *testing.T) {
 	f := NewFile()
 	style1, err := f.NewStyle(&Style{Fill: Fill{Type: "pattern", Color: []string{"63BE7B"}, Pattern: 1}})
@@ -1218,6 +2124,65 @@ func TestCellXMLHandler(t *testing.T) {
 	}
 }
 
+func BenchmarkGetNumericRange(b *testing.B) {
+	f := prepareBenchmarkNumericRange(b)
+	b.ResetTimer()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, _, err := f.GetNumericRange("Sheet1", "A1:ALL1000"); err != nil {
+			b.Error(err)
+		}
+	}
+}
+
+func BenchmarkGetRowsParseFloat(b *testing.B) {
+	f := prepareBenchmarkNumericRange(b)
+	b.ResetTimer()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		rows, err := f.GetRows("Sheet1")
+		if err != nil {
+			b.Error(err)
+		}
+		values := make([][]float64, len(rows))
+		for r, row := range rows {
+			values[r] = make([]float64, len(row))
+			for c, cell := range row {
+				values[r][c], _ = strconv.ParseFloat(cell, 64)
+			}
+		}
+	}
+}
+
+func prepareBenchmarkNumericRange(b *testing.B) *File {
+	f := NewFile()
+	sw, err := f.NewStreamWriter("Sheet1")
+	if err != nil {
+		b.Fatal(err)
+	}
+	row := make([]interface{}, 1000)
+	for r := 1; r <= 1000; r++ {
+		for c := range row {
+			row[c] = float64(r*c) / 7
+		}
+		cell, _ := CoordinatesToCellName(1, r)
+		if err = sw.SetRow(cell, row); err != nil {
+			b.Fatal(err)
+		}
+	}
+	if err = sw.Flush(); err != nil {
+		b.Fatal(err)
+	}
+	buf, err := f.WriteToBuffer()
+	if err != nil {
+		b.Fatal(err)
+	}
+	if f, err = OpenReader(buf); err != nil {
+		b.Fatal(err)
+	}
+	return f
+}
+
 func BenchmarkRows(b *testing.B) {
 	f, _ := OpenFile(filepath.Join("test", "Book1.xlsx"))
 	for i := 0; i < b.N; i++ {
@@ -1250,3 +2215,504 @@ func trimSliceSpace(s []string) []string {
 	}
 	return s
 }
+
+func TestGetRowsBlankCellValue(t *testing.T) {
+	f := NewFile()
+	styleID, err := f.NewStyle(&Style{Font: &Font{Bold: true}})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellValue("Sheet1", "A1", "A1"))
+	assert.NoError(t, f.SetCellValue("Sheet1", "B1", ""))
+	assert.NoError(t, f.SetCellValue("Sheet1", "D1", "D1"))
+	assert.NoError(t, f.SetCellStyle("Sheet1", "A2", "A2", styleID))
+	assert.NoError(t, f.SetCellValue("Sheet1", "B2", "B2"))
+	assert.NoError(t, f.SetCellValue("Sheet1", "B3", ""))
+	for _, f := range []*File{f, func() *File {
+		buf, err := f.WriteToBuffer()
+		assert.NoError(t, err)
+		f, err := OpenReader(buf)
+		assert.NoError(t, err)
+		return f
+	}()} {
+		rows, err := f.GetRows("Sheet1")
+		assert.NoError(t, err)
+		assert.Equal(t, [][]string{{"A1", "", "", "D1"}, {"", "B2"}}, rows)
+		rows, err = f.GetRows("Sheet1", Options{BlankCellValue: "<blank>"})
+		assert.NoError(t, err)
+		assert.Equal(t, [][]string{{"A1", "", "<blank>", "D1"}, {"<blank>", "B2"}, {"<blank>", ""}}, rows)
+		// Test get rows with blank cell value and column filter
+		iter, err := f.Rows("Sheet1")
+		assert.NoError(t, err)
+		assert.NoError(t, iter.SetColumnFilter("B", "C"))
+		var cells [][]string
+		for iter.Next() {
+			row, err := iter.Columns(Options{BlankCellValue: "<blank>"})
+			assert.NoError(t, err)
+			cells = append(cells, row)
+		}
+		assert.NoError(t, iter.Close())
+		assert.Equal(t, [][]string{{"", "<blank>"}, {"B2", "<blank>"}, {"", "<blank>"}}, cells)
+		assert.NoError(t, f.Close())
+	}
+}
+
+func TestGetRowsSkipRows(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A1", &[]interface{}{"Title"}))
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A3", &[]interface{}{"Name", "Age"}))
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A5", &[]interface{}{"Bob", 30}))
+	rows, err := f.GetRows("Sheet1", Options{SkipRows: 2})
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{{"Name", "Age"}, nil, {"Bob", "30"}}, rows)
+	rows, err = f.GetRows("Sheet1", Options{SkipRows: 5})
+	assert.NoError(t, err)
+	assert.Empty(t, rows)
+	assert.NoError(t, f.Close())
+
+	// Test the SkipRows option for opening the workbook doesn't affect the
+	// functions based on the GetRows function
+	f = NewFile(Options{SkipRows: 1})
+	for i, value := range []string{"v1", "v2", "v3", "v4"} {
+		assert.NoError(t, f.SetCellValue("Sheet1", fmt.Sprintf("A%d", i+1), value))
+	}
+	rows, err = f.GetRows("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{{"v1"}, {"v2"}, {"v3"}, {"v4"}}, rows)
+	n, err := f.RemoveRowsWhere("Sheet1", func(_ int, values []string) bool {
+		return len(values) > 0 && values[0] == "v3"
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, n)
+	rows, err = f.GetRows("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{{"v1"}, {"v2"}, {"v4"}}, rows)
+	assert.NoError(t, f.Close())
+}
+
+func TestGetFilteredRows(t *testing.T) {
+	f := NewFile()
+	for row := 1; row <= 8; row++ {
+		if row == 7 {
+			continue
+		}
+		assert.NoError(t, f.SetSheetRow("Sheet1", fmt.Sprintf("A%d", row), &[]interface{}{fmt.Sprintf("A%d", row), row}))
+	}
+	assert.NoError(t, f.AutoFilter("Sheet1", "A1:B6", nil))
+	for _, row := range []int{3, 5, 8} {
+		assert.NoError(t, f.SetRowVisible("Sheet1", row, false))
+	}
+	filtered, err := f.GetFilteredRows("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, []int{3, 5}, filtered)
+	// Test get rows skip the rows hidden by the AutoFilter, the hidden rows
+	// outside of the AutoFilter range will be kept
+	rows, err := f.GetRows("Sheet1", Options{SkipFilteredRows: true})
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{{"A1", "1"}, {"A2", "2"}, {"A4", "4"}, {"A6", "6"}, nil, {"A8", "8"}}, rows)
+	rows, err = f.GetRows("Sheet1", Options{SkipFilteredRows: true, SkipHiddenRows: true})
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{{"A1", "1"}, {"A2", "2"}, {"A4", "4"}, {"A6", "6"}}, rows)
+	// Test resume the rows iterator which skip the filtered rows from the checkpoint
+	iter, err := f.Rows("Sheet1", Options{SkipFilteredRows: true})
+	assert.NoError(t, err)
+	assert.True(t, iter.Next())
+	assert.True(t, iter.Next())
+	checkpoint, err := iter.Checkpoint()
+	assert.NoError(t, err)
+	assert.NoError(t, iter.Close())
+	iter, err = f.RowsFromCheckpoint("Sheet1", checkpoint)
+	assert.NoError(t, err)
+	assert.True(t, iter.Next())
+	assert.Equal(t, 4, iter.CurrentRow())
+	assert.NoError(t, iter.Close())
+	// Test get rows skip the filtered rows from the worksheet in the temporary file
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestGetFilteredRows.xlsx")))
+	assert.NoError(t, f.Close())
+	f, err = OpenFile(filepath.Join("test", "TestGetFilteredRows.xlsx"), Options{UnzipXMLSizeLimit: 128})
+	assert.NoError(t, err)
+	rows, err = f.GetRows("Sheet1", Options{SkipFilteredRows: true})
+	assert.NoError(t, err)
+	assert.Len(t, rows, 6)
+	assert.NoError(t, f.Close())
+	// Test get rows skip the filtered rows without AutoFilter
+	f = NewFile()
+	assert.NoError(t, f.SetCellValue("Sheet1", "A2", "A2"))
+	assert.NoError(t, f.SetRowVisible("Sheet1", 1, false))
+	filtered, err = f.GetFilteredRows("Sheet1")
+	assert.NoError(t, err)
+	assert.Empty(t, filtered)
+	rows, err = f.GetRows("Sheet1", Options{SkipFilteredRows: true})
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{nil, {"A2"}}, rows)
+	// Test get rows skip the filtered rows with the AutoFilter without range
+	f.Sheet.Delete("xl/worksheets/sheet1.xml")
+	f.Pkg.Store("xl/worksheets/sheet1.xml", []byte(`<worksheet><sheetData><row r="1" hidden="1"><c r="A1" t="inlineStr"><is><t>A1</t></is></c></row></sheetData><autoFilter/></worksheet>`))
+	rows, err = f.GetRows("Sheet1", Options{SkipFilteredRows: true})
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{{"A1"}}, rows)
+	// Test get rows skip the filtered rows with invalid AutoFilter range
+	f.Pkg.Store("xl/worksheets/sheet1.xml", []byte(`<worksheet xmlns="`+NameSpaceSpreadSheet.Value+`"><sheetData/><autoFilter ref="A1"/></worksheet>`))
+	_, err = f.GetRows("Sheet1", Options{SkipFilteredRows: true})
+	assert.Equal(t, ErrParameterInvalid, err)
+	_, err = f.GetFilteredRows("Sheet1")
+	assert.Equal(t, ErrParameterInvalid, err)
+	// Test get rows skip the filtered rows with invalid sheet data
+	f.Pkg.Store("xl/worksheets/sheet1.xml", []byte(`<worksheet><sheetData><row></sheetData></worksheet>`))
+	f.Sheet.Delete("xl/worksheets/sheet1.xml")
+	_, _, err = f.getAutoFilterRows("xl/worksheets/sheet1.xml")
+	assert.Error(t, err)
+	// Test get filtered rows on not exists worksheet
+	_, err = f.GetFilteredRows("SheetN")
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+	assert.NoError(t, f.Close())
+}
+
+func TestGetRowsSkipHiddenRows(t *testing.T) {
+	f := NewFile()
+	for row := 1; row <= 8; row++ {
+		if row == 5 {
+			continue
+		}
+		assert.NoError(t, f.SetCellValue("Sheet1", fmt.Sprintf("A%d", row), row))
+	}
+	for _, row := range []int{2, 3, 6, 8} {
+		assert.NoError(t, f.SetRowVisible("Sheet1", row, false))
+	}
+	rows, err := f.GetRows("Sheet1", Options{SkipHiddenRows: true})
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{{"1"}, {"4"}, nil, {"7"}}, rows)
+	rows, err = f.GetRows("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, rows, 8)
+	// Test rows iterator skip hidden rows with and without reading the cells
+	for _, read := range []bool{true, false} {
+		iter, err := f.Rows("Sheet1", Options{SkipHiddenRows: true})
+		assert.NoError(t, err)
+		var rowNums []int
+		for iter.Next() {
+			if read {
+				_, err = iter.Columns()
+				assert.NoError(t, err)
+			}
+			assert.False(t, iter.GetRowOpts().Hidden)
+			rowNums = append(rowNums, iter.CurrentRow())
+		}
+		assert.Equal(t, []int{1, 4, 5, 7}, rowNums)
+		assert.NoError(t, iter.Close())
+	}
+	var walked []int
+	assert.NoError(t, f.WalkRows("Sheet1", func(row int, cells []string) error {
+		walked = append(walked, row)
+		return nil
+	}, Options{SkipHiddenRows: true}))
+	assert.Equal(t, []int{1, 4, 7}, walked)
+	// Test the skip hidden rows option of the workbook be ignored
+	f.options.SkipHiddenRows = true
+	rows, err = f.GetRows("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, rows, 8)
+	assert.NoError(t, f.Close())
+}
+
+func TestReadTable(t *testing.T) {
+	f := NewFile()
+	for idx, row := range [][]interface{}{
+		{"Report"},
+		{"Name", "Price", "Active", "Date", "Mixed", "", "Name"},
+		{"Apple", 1.5, true, "2025-01-02", 1, "x", "A"},
+		{"Banana", "1,200.5", false, "2025-01-03", "two"},
+		{"", nil, nil, "", 3},
+	} {
+		cell, _ := CoordinatesToCellName(1, idx+1)
+		assert.NoError(t, f.SetSheetRow("Sheet1", cell, &row))
+	}
+	data, err := f.ReadTable("Sheet1", ReadTableOptions{SkipRows: 1})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Name", "Price", "Active", "Date", "Mixed", "F", "Name_2"}, data.Headers)
+	assert.Equal(t, ColumnTypeString, data.Columns["Name"].Type)
+	assert.Equal(t, []string{"Apple", "Banana", ""}, data.Columns["Name"].Strings())
+	assert.Equal(t, ColumnTypeFloat, data.Columns["Price"].Type)
+	prices, err := data.Columns["Price"].Floats()
+	assert.NoError(t, err)
+	assert.Equal(t, []float64{1.5, 1200.5, 0}, prices)
+	assert.Equal(t, ColumnTypeBool, data.Columns["Active"].Type)
+	active, err := data.Columns["Active"].Bools()
+	assert.NoError(t, err)
+	assert.Equal(t, []bool{true, false, false}, active)
+	assert.Equal(t, ColumnTypeTime, data.Columns["Date"].Type)
+	dates, err := data.Columns["Date"].Times()
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2025, 1, 3, 0, 0, 0, 0, time.UTC), dates[1])
+	assert.True(t, dates[2].IsZero())
+	assert.Equal(t, ColumnTypeString, data.Columns["Mixed"].Type)
+	assert.Equal(t, []string{"mixed types: value \"two\" in row 4 conflicts with the previous values"}, data.Columns["Mixed"].Issues)
+	_, err = data.Columns["Mixed"].Floats()
+	assert.Error(t, err)
+	_, err = data.Columns["Name"].Bools()
+	assert.Error(t, err)
+	_, err = data.Columns["Name"].Times()
+	assert.Error(t, err)
+
+	// Test read table with sample size
+	data, err = f.ReadTable("Sheet1", ReadTableOptions{SkipRows: 1, SampleSize: 1})
+	assert.NoError(t, err)
+	assert.Equal(t, ColumnTypeFloat, data.Columns["Mixed"].Type)
+	assert.Empty(t, data.Columns["Mixed"].Issues)
+
+	// Test read table with decimal comma and custom date layouts
+	f2 := NewFile()
+	assert.NoError(t, f2.SetSheetRow("Sheet1", "A1", &[]interface{}{"Amount", "Day"}))
+	assert.NoError(t, f2.SetSheetRow("Sheet1", "A2", &[]interface{}{"1.234,5", "02/01/2025"}))
+	data, err = f2.ReadTable("Sheet1", ReadTableOptions{DecimalComma: true, DateLayouts: []string{"02/01/2006"}})
+	assert.NoError(t, err)
+	amounts, err := data.Columns["Amount"].Floats()
+	assert.NoError(t, err)
+	assert.Equal(t, []float64{1234.5}, amounts)
+	assert.Equal(t, ColumnTypeTime, data.Columns["Day"].Type)
+
+	// Test parse numbers with the thousands separators
+	for _, c := range []struct {
+		val          string
+		decimalComma bool
+		expected     float64
+		ok           bool
+	}{
+		{"1,234,567.25", false, 1234567.25, true},
+		{"-12,345", false, -12345, true},
+		{"+123,456.5", false, 123456.5, true},
+		{"1e3", false, 1000, true},
+		{"1,2,3", false, 0, false},
+		{"1,23", false, 0, false},
+		{"1234,567", false, 0, false},
+		{",123", false, 0, false},
+		{"1.5,5", false, 0, false},
+		{"1.5.5", false, 0, false},
+		{"--1,000", false, 0, false},
+		{"1,0a0", false, 0, false},
+		{"1.234.567,25", true, 1234567.25, true},
+		{"1,5", true, 1.5, true},
+		{"1.5", true, 0, false},
+		{"12.34", true, 0, false},
+	} {
+		col := &Column{opts: &ReadTableOptions{DecimalComma: c.decimalComma}}
+		num, err := col.parseFloat(c.val)
+		assert.Equal(t, c.ok, err == nil, c.val)
+		assert.Equal(t, c.expected, num, c.val)
+	}
+	// Test collect all the mixed types issues of the column
+	col := &Column{Values: []string{"1", "two", "3", "", "four"}, opts: &ReadTableOptions{DateLayouts: defaultDateLayouts}}
+	col.inferType(2)
+	assert.Equal(t, ColumnTypeString, col.Type)
+	assert.Equal(t, []string{
+		"mixed types: value \"two\" in row 3 conflicts with the previous values",
+		"mixed types: value \"four\" in row 6 conflicts with the previous values",
+	}, col.Issues)
+
+	// Test read table on empty worksheet
+	data, err = NewFile().ReadTable("Sheet1", ReadTableOptions{})
+	assert.NoError(t, err)
+	assert.Empty(t, data.Headers)
+	// Test read table with not exist worksheet
+	_, err = f.ReadTable("SheetN", ReadTableOptions{})
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+	assert.NoError(t, f.Close())
+}
+
+func BenchmarkRemoveRows(b *testing.B) {
+	const step = 10
+	for _, totalRows := range []int{10000, 50000} {
+		prepare := func() *File {
+			f := NewFile()
+			sw, _ := f.NewStreamWriter("Sheet1")
+			for row := 1; row <= totalRows; row++ {
+				cell, _ := CoordinatesToCellName(1, row)
+				_ = sw.SetRow(cell, []interface{}{row, "text", true, Cell{Formula: fmt.Sprintf("A%d*2", row)}})
+				if row%100 == 1 {
+					_ = sw.MergeCell(fmt.Sprintf("E%d", row), fmt.Sprintf("F%d", row+5))
+				}
+			}
+			_ = sw.Flush()
+			_ = f.SetConditionalFormat("Sheet1", fmt.Sprintf("A1:A%d", totalRows), []ConditionalFormatOptions{
+				{Type: "cell", Criteria: ">", Value: "100"},
+			})
+			return f
+		}
+		var doomed []int
+		for row := 1; row <= totalRows; row += step {
+			doomed = append(doomed, row)
+		}
+		b.Run(fmt.Sprintf("RemoveRow/%d", totalRows), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				b.StopTimer()
+				f := prepare()
+				b.StartTimer()
+				for j := len(doomed) - 1; j >= 0; j-- {
+					_ = f.RemoveRow("Sheet1", doomed[j])
+				}
+			}
+		})
+		b.Run(fmt.Sprintf("RemoveRows/%d", totalRows), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				b.StopTimer()
+				f := prepare()
+				b.StartTimer()
+				_ = f.RemoveRows("Sheet1", doomed)
+			}
+		})
+	}
+}
+
+func TestGetRowsInto(t *testing.T) {
+	type product struct {
+		SKU      string     `xlsx:"col=A"`
+		Name     string     `xlsx:"Product Name"`
+		Price    float64    `xlsx:"Price"`
+		Qty      uint16     `xlsx:"Qty"`
+		Stock    *int       `xlsx:"Stock"`
+		Active   bool       `xlsx:"Active"`
+		Launched *time.Time `xlsx:"Launch Date"`
+		Note     string     `xlsx:"-"`
+		internal string
+	}
+	f := NewFile()
+	launched := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	for cell, row := range map[string]*[]interface{}{
+		"A1": {"SKU", " Product Name ", "Price", "Qty", "Stock", "Active", "Launch Date"},
+		"A2": {"S-1", "Apple", 1.5, 10, 3, true, launched},
+		"A4": {"S-2", "Pear", "2.25", "7", nil, "FALSE", "2025-04-01"},
+	} {
+		assert.NoError(t, f.SetSheetRow("Sheet1", cell, row))
+	}
+	var products []product
+	assert.NoError(t, f.GetRowsInto("Sheet1", &products, BindOptions{HeaderRow: 1}))
+	assert.Len(t, products, 2)
+	assert.Equal(t, product{SKU: "S-1", Name: "Apple", Price: 1.5, Qty: 10, Stock: intPtr(3), Active: true, Launched: &launched}, products[0])
+	assert.Equal(t, "Pear", products[1].Name)
+	assert.Equal(t, 2.25, products[1].Price)
+	assert.Equal(t, uint16(7), products[1].Qty)
+	assert.Nil(t, products[1].Stock)
+	assert.Equal(t, time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC), *products[1].Launched)
+	// Test read rows into a slice of struct pointers by column names
+	var items []*struct {
+		SKU  string `xlsx:"A"`
+		Name string `xlsx:"B"`
+	}
+	assert.NoError(t, f.GetRowsInto("Sheet1", &items))
+	assert.Len(t, items, 3)
+	assert.Equal(t, "SKU", items[0].SKU)
+	assert.Equal(t, " Product Name ", items[0].Name)
+	// Test read rows with the 1904 date system
+	assert.NoError(t, f.SetWorkbookProps(&WorkbookPropsOptions{Date1904: boolPtr(true)}))
+	assert.NoError(t, f.GetRowsInto("Sheet1", &products, BindOptions{HeaderRow: 1}))
+	assert.Equal(t, launched.AddDate(4, 0, 1), *products[0].Launched)
+	// Test read rows with conversion failure
+	assert.NoError(t, f.SetCellValue("Sheet1", "D4", "many"))
+	assert.EqualError(t, f.GetRowsInto("Sheet1", &products, BindOptions{HeaderRow: 1}),
+		"cannot bind cell D4 value \"many\" to field Qty: strconv.ParseUint: parsing \"many\": invalid syntax")
+	assert.NoError(t, f.SetCellValue("Sheet1", "D4", 1.5))
+	assert.Error(t, f.GetRowsInto("Sheet1", &products, BindOptions{HeaderRow: 1}))
+	assert.NoError(t, f.SetCellValue("Sheet1", "D4", 7))
+	for _, cell := range []string{"E4", "F4", "G4"} {
+		assert.NoError(t, f.SetCellValue("Sheet1", cell, "x"))
+		assert.Error(t, f.GetRowsInto("Sheet1", &products, BindOptions{HeaderRow: 1}))
+		assert.NoError(t, f.SetCellValue("Sheet1", cell, nil))
+	}
+	var ints []struct {
+		Qty int8 `xlsx:"Qty"`
+	}
+	assert.NoError(t, f.SetCellValue("Sheet1", "D4", 2e3))
+	assert.EqualError(t, f.GetRowsInto("Sheet1", &ints, BindOptions{HeaderRow: 1}),
+		"cannot bind cell D4 value \"2000\" to field Qty: strconv.ParseInt: parsing \"2000\": value out of range")
+	assert.NoError(t, f.SetCellValue("Sheet1", "D4", "4.0"))
+	assert.NoError(t, f.GetRowsInto("Sheet1", &ints, BindOptions{HeaderRow: 1}))
+	assert.Equal(t, int8(4), ints[1].Qty)
+	// Test read rows with the header which looks like a column name
+	assert.EqualError(t, f.GetRowsInto("Sheet1", &[]struct {
+		VAT float64 `xlsx:"VAT"`
+	}{}, BindOptions{HeaderRow: 1}), "cannot bind field VAT: no column matches the tag \"VAT\"")
+	// Test read rows with invalid column name tag
+	assert.EqualError(t, f.GetRowsInto("Sheet1", &[]struct {
+		SKU string `xlsx:"col=A1"`
+	}{}), "cannot bind field SKU: "+newInvalidColumnNameError("A1").Error())
+	// Test read rows with unknown header and unsupported field type
+	assert.EqualError(t, f.GetRowsInto("Sheet1", &[]struct {
+		Color string `xlsx:"Color"`
+	}{}, BindOptions{HeaderRow: 1}), "cannot bind field Color: no column matches the tag \"Color\"")
+	assert.EqualError(t, f.GetRowsInto("Sheet1", &[]struct {
+		Tags []string `xlsx:"A"`
+	}{}), "cannot bind field Tags: unsupported field type []string")
+	// Test read rows with invalid arguments
+	assert.Equal(t, ErrParameterInvalid, f.GetRowsInto("Sheet1", products))
+	assert.Equal(t, ErrParameterInvalid, f.GetRowsInto("Sheet1", &[]string{}))
+	assert.Equal(t, newInvalidRowNumberError(-1), f.GetRowsInto("Sheet1", &products, BindOptions{HeaderRow: -1}))
+	assert.EqualError(t, f.GetRowsInto("SheetN", &products, BindOptions{HeaderRow: 1}), "sheet SheetN does not exist")
+	assert.EqualError(t, f.GetRowsInto("SheetN", &items), "sheet SheetN does not exist")
+	// Test read rows with unsupported charset workbook
+	f.WorkBook = nil
+	f.Pkg.Store(defaultXMLPathWorkbook, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.GetRowsInto("Sheet1", &items), "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
+
+func TestSetRowsFrom(t *testing.T) {
+	type product struct {
+		SKU    string     `xlsx:"col=A"`
+		Name   string     `xlsx:"Name"`
+		Price  *float64   `xlsx:"Price"`
+		Date   *time.Time `xlsx:"Date"`
+		Active bool       `xlsx:"Active"`
+	}
+	f := NewFile()
+	assert.NoError(t, f.SetCellValue("Sheet1", "C1", "Name"))
+	date := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	products := []*product{
+		{SKU: "S-1", Name: "Apple", Price: float64Ptr(1.5), Date: &date, Active: true},
+		nil,
+		{SKU: "S-2", Name: "Pear"},
+	}
+	assert.NoError(t, f.SetRowsFrom("Sheet1", products, BindOptions{HeaderRow: 1}))
+	rows, err := f.GetRows("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{
+		{"", "", "Name", "Price", "Date", "Active"},
+		{"S-1", "", "Apple", "1.5", "Mar-25", "TRUE"},
+		nil,
+		{"S-2", "", "Pear", "", "", "FALSE"},
+	}, rows)
+	var result []product
+	assert.NoError(t, f.GetRowsInto("Sheet1", &result, BindOptions{HeaderRow: 1}))
+	assert.Equal(t, []product{*products[0], *products[2]}, result)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestSetRowsFrom.xlsx")))
+	// Test write rows with the header which looks like a column name
+	assert.NoError(t, f.SetRowsFrom("Sheet1", []struct {
+		SKU string `xlsx:"SKU"`
+	}{{SKU: "S-3"}}, BindOptions{HeaderRow: 1}))
+	rows, err = f.GetRows("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"", "", "Name", "Price", "Date", "Active", "SKU"}, rows[0])
+	assert.Equal(t, []string{"S-1", "", "Apple", "1.5", "Mar-25", "TRUE", "S-3"}, rows[1])
+	// Test write rows without header row
+	assert.EqualError(t, f.SetRowsFrom("Sheet1", &products), "cannot bind field Name: no column matches the tag \"Name\"")
+	_, err = f.NewSheet("Sheet2")
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetRowsFrom("Sheet2", &[]struct {
+		Name string `xlsx:"B"`
+	}{{Name: "a"}}))
+	name, err := f.GetCellValue("Sheet2", "B1")
+	assert.NoError(t, err)
+	assert.Equal(t, "a", name)
+	// Test write rows with invalid arguments
+	assert.Equal(t, ErrParameterInvalid, f.SetRowsFrom("Sheet1", product{}))
+	assert.Equal(t, ErrParameterInvalid, f.SetRowsFrom("Sheet1", []int{1}))
+	assert.Equal(t, ErrMaxRows, f.SetRowsFrom("Sheet1", products, BindOptions{HeaderRow: TotalRows - 1}))
+	assert.Equal(t, newInvalidRowNumberError(TotalRows), f.SetRowsFrom("Sheet1", []product{}, BindOptions{HeaderRow: TotalRows}))
+	assert.EqualError(t, f.SetRowsFrom("SheetN", []struct {
+		Name string `xlsx:"B"`
+	}{{Name: "a"}}), "sheet SheetN does not exist")
+	assert.EqualError(t, f.SetRowsFrom("SheetN", products, BindOptions{HeaderRow: 1}), "sheet SheetN does not exist")
+	assert.EqualError(t, f.SetRowsFrom("Sheet:1", products, BindOptions{HeaderRow: 1}), ErrSheetNameInvalid.Error())
+	// Test write rows with the header columns exceeds the limit
+	f = NewFile()
+	assert.NoError(t, f.SetCellValue("Sheet1", "XFD1", "Last"))
+	assert.Equal(t, ErrColumnNumber, f.SetRowsFrom("Sheet1", products, BindOptions{HeaderRow: 1}))
+	assert.NoError(t, f.Close())
+}
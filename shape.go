@@ -46,7 +46,7 @@ func parseShapeOptions(opts *Shape) (*Shape, error) {
 	if opts.Line.Width == nil {
 		opts.Line.Width = float64Ptr(defaultShapeLineWidth)
 	}
-	return opts, nil
+	return opts, opts.Format.validate()
 }
 
 // AddShape provides the method to add shape in a sheet by given worksheet
@@ -331,7 +331,13 @@ func (f *File) twoCellAnchorShape(sheet, drawingXML, cell string, width, height
 	}
 	w := int(float64(width) * format.ScaleX)
 	h := int(float64(height) * format.ScaleY)
-	colStart, rowStart, colEnd, rowEnd, x1, y1, x2, y2 := f.positionObjectPixels(sheet, fromCol, fromRow, w, h, &format)
+	colStart, rowStart, colEnd, rowEnd, x1, y1, x2, y2, err := f.positionObjectPixels(sheet, fromCol, fromRow, w, h, &format)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+	if err = format.checkAnchor(colEnd, rowEnd); err != nil {
+		return nil, nil, 0, err
+	}
 	content, cNvPrID, err := f.drawingParser(drawingXML)
 	if err != nil {
 		return content, nil, cNvPrID, err
@@ -368,10 +374,7 @@ func (f *File) addDrawingShape(sheet, drawingXML, cell string, opts *Shape) erro
 	shape := xdrSp{
 		Macro: opts.Macro,
 		NvSpPr: &xdrNvSpPr{
-			CNvPr: &xlsxCNvPr{
-				ID:   cNvPrID,
-				Name: "Shape " + strconv.Itoa(cNvPrID),
-			},
+			CNvPr: opts.Format.newCNvPr(cNvPrID, f.drawingObjectName(content, "Shape", cNvPrID)),
 			CNvSpPr: &xdrCNvSpPr{
 				TxBox: true,
 			},
@@ -407,7 +410,7 @@ func (f *File) addDrawingShape(sheet, drawingXML, cell string, opts *Shape) erro
 			W: f.ptToEMUs(*opts.Line.Width),
 		}
 	}
-	defaultFont, err := f.GetDefaultFont()
+	defaultFont, defaultSize, err := f.GetDefaultFont()
 	if err != nil {
 		return err
 	}
@@ -419,7 +422,7 @@ func (f *File) addDrawingShape(sheet, drawingXML, cell string, opts *Shape) erro
 					Italic:    false,
 					Underline: "none",
 					Family:    defaultFont,
-					Size:      11,
+					Size:      defaultSize,
 					Color:     "000000",
 				},
 				Text: " ",
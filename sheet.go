@@ -14,6 +14,7 @@ package excelize
 import (
 	"bytes"
 	"encoding/xml"
+	"errors"
 	"fmt"
 	"io"
 	"os"
@@ -62,9 +63,8 @@ func (f *File) NewSheet(sheet string) (int, error) {
 	}
 	_ = f.DeleteSheet(sheet)
 	f.SheetCount++
-	wb, _ := f.workbookReader()
 	sheetID := 0
-	for _, v := range wb.Sheets.Sheet {
+	for _, v := range f.getSheets() {
 		if v.SheetID > sheetID {
 			sheetID = v.SheetID
 		}
@@ -118,36 +118,14 @@ func (f *File) getWorksheetPath(relTarget string) (path string) {
 	return path
 }
 
-// mergeExpandedCols merge expanded columns.
+// mergeExpandedCols merge expanded columns, the adjacent columns with
+// identical width, visibility, style, outline level and other settings will
+// be written as a single column range.
 func (f *File) mergeExpandedCols(ws *xlsxWorksheet) {
-	sort.Slice(ws.Cols.Col, func(i, j int) bool {
+	sort.SliceStable(ws.Cols.Col, func(i, j int) bool {
 		return ws.Cols.Col[i].Min < ws.Cols.Col[j].Min
 	})
-	var columns []xlsxCol
-	for i, n := 0, len(ws.Cols.Col); i < n; {
-		left := i
-		for i++; i < n && reflect.DeepEqual(
-			xlsxCol{
-				BestFit:      ws.Cols.Col[i-1].BestFit,
-				Collapsed:    ws.Cols.Col[i-1].Collapsed,
-				CustomWidth:  ws.Cols.Col[i-1].CustomWidth,
-				Hidden:       ws.Cols.Col[i-1].Hidden,
-				Max:          ws.Cols.Col[i-1].Max + 1,
-				Min:          ws.Cols.Col[i-1].Min + 1,
-				OutlineLevel: ws.Cols.Col[i-1].OutlineLevel,
-				Phonetic:     ws.Cols.Col[i-1].Phonetic,
-				Style:        ws.Cols.Col[i-1].Style,
-				Width:        ws.Cols.Col[i-1].Width,
-			}, ws.Cols.Col[i]); i++ {
-		}
-		var column xlsxCol
-		deepcopy.Copy(&column, ws.Cols.Col[left])
-		if left < i-1 {
-			column.Max = ws.Cols.Col[i-1].Min
-		}
-		columns = append(columns, column)
-	}
-	ws.Cols.Col = columns
+	ws.Cols.Col = coalesceCols(ws.Cols.Col)
 }
 
 // workSheetWriter provides a function to save xl/worksheets/sheet%d.xml after
@@ -168,6 +146,7 @@ func (f *File) workSheetWriter() {
 				f.mergeExpandedCols(sheet)
 			}
 			sheet.SheetData.Row = trimRow(&sheet.SheetData)
+			f.resolveColRanges(p.(string), sheet)
 			if sheet.SheetPr != nil || sheet.Drawing != nil || sheet.Hyperlinks != nil || sheet.Picture != nil || sheet.TableParts != nil {
 				f.addNameSpaces(p.(string), SourceRelationship)
 			}
@@ -255,7 +234,7 @@ func (f *File) setSheet(index int, name string) {
 		},
 	}
 	sheetXMLPath := "xl/worksheets/sheet" + strconv.Itoa(index) + ".xml"
-	f.sheetMap[name] = sheetXMLPath
+	f.setSheetXMLPath(name, sheetXMLPath)
 	f.Sheet.Store(sheetXMLPath, &ws)
 	f.xmlAttr.Store(sheetXMLPath, []xml.Attr{NameSpaceSpreadSheet})
 }
@@ -293,7 +272,7 @@ func (f *File) SetActiveSheet(index int) {
 		index = 0
 	}
 	wb, _ := f.workbookReader()
-	for activeTab := range wb.Sheets.Sheet {
+	for activeTab := range f.getSheets() {
 		if activeTab == index {
 			if wb.BookViews == nil {
 				wb.BookViews = &xlsxBookViews{}
@@ -337,13 +316,10 @@ func (f *File) SetActiveSheet(index int) {
 // spreadsheet. If not found the active sheet will be return integer 0.
 func (f *File) GetActiveSheetIndex() (index int) {
 	sheetID := f.getActiveSheetID()
-	wb, _ := f.workbookReader()
-	if wb != nil {
-		for idx, sheet := range wb.Sheets.Sheet {
-			if sheet.SheetID == sheetID {
-				index = idx
-				return
-			}
+	for idx, sheet := range f.getSheets() {
+		if sheet.SheetID == sheetID {
+			index = idx
+			return
 		}
 	}
 	return
@@ -354,14 +330,15 @@ func (f *File) GetActiveSheetIndex() (index int) {
 func (f *File) getActiveSheetID() int {
 	wb, _ := f.workbookReader()
 	if wb != nil {
+		sheets := f.getSheets()
 		if wb.BookViews != nil && len(wb.BookViews.WorkBookView) > 0 {
 			activeTab := wb.BookViews.WorkBookView[0].ActiveTab
-			if len(wb.Sheets.Sheet) > activeTab && wb.Sheets.Sheet[activeTab].SheetID != 0 {
-				return wb.Sheets.Sheet[activeTab].SheetID
+			if len(sheets) > activeTab && sheets[activeTab].SheetID != 0 {
+				return sheets[activeTab].SheetID
 			}
 		}
-		if len(wb.Sheets.Sheet) >= 1 {
-			return wb.Sheets.Sheet[0].SheetID
+		if len(sheets) >= 1 {
+			return sheets[0].SheetID
 		}
 	}
 	return 0
@@ -371,7 +348,9 @@ func (f *File) getActiveSheetID() int {
 // target worksheet names. Maximum 31 characters are allowed in sheet title and
 // this function only changes the name of the sheet and will not update the
 // sheet name in the formula or reference associated with the cell. So there
-// may be problem formula error or reference missing.
+// may be problem formula error or reference missing. The source worksheet
+// name is case-insensitive, and the target worksheet name can not be the
+// same as the name of another worksheet regardless of case.
 func (f *File) SetSheetName(source, target string) error {
 	var err error
 	if err = checkSheetName(source); err != nil {
@@ -383,18 +362,20 @@ func (f *File) SetSheetName(source, target string) error {
 	if target == source {
 		return err
 	}
-	wb, _ := f.workbookReader()
-	for k, v := range wb.Sheets.Sheet {
-		if v.Name == source {
-			wb.Sheets.Sheet[k].Name = target
-			f.sheetMap[target] = f.sheetMap[source]
-			delete(f.sheetMap, source)
-		}
+	wb, err := f.workbookReader()
+	if err != nil {
+		return err
+	}
+	if source, err = f.renameSheet(wb, source, target); err != nil {
+		return err
 	}
 	if wb.DefinedNames == nil {
 		return err
 	}
 	for i, dn := range wb.DefinedNames.DefinedName {
+		if dn.isStringConstant() {
+			continue
+		}
 		wb.DefinedNames.DefinedName[i].Data = adjustRangeSheetName(dn.Data, source, target)
 	}
 	return err
@@ -456,12 +437,9 @@ func (f *File) GetSheetIndex(sheet string) (int, error) {
 //	    fmt.Println(index, name)
 //	}
 func (f *File) GetSheetMap() map[int]string {
-	wb, _ := f.workbookReader()
 	sheetMap := map[int]string{}
-	if wb != nil {
-		for _, sheet := range wb.Sheets.Sheet {
-			sheetMap[sheet.SheetID] = sheet.Name
-		}
+	for _, sheet := range f.getSheets() {
+		sheetMap[sheet.SheetID] = sheet.Name
 	}
 	return sheetMap
 }
@@ -469,21 +447,59 @@ func (f *File) GetSheetMap() map[int]string {
 // GetSheetList provides a function to get worksheets, chart sheets, and
 // dialog sheets name list of the workbook.
 func (f *File) GetSheetList() (list []string) {
+	for _, sheet := range f.getSheets() {
+		list = append(list, sheet.Name)
+	}
+	return
+}
+
+// getSheets provides a function to get a copy of the sheets in the workbook.
+// All reads of the sheets in the workbook go through this function, and all
+// writes of them should hold the sheetMapMu lock, so that the sheets can be
+// accessed concurrently.
+func (f *File) getSheets() []xlsxSheet {
 	wb, _ := f.workbookReader()
-	if wb != nil {
-		for _, sheet := range wb.Sheets.Sheet {
-			list = append(list, sheet.Name)
+	if wb == nil {
+		return nil
+	}
+	f.sheetMapMu.RLock()
+	defer f.sheetMapMu.RUnlock()
+	return append([]xlsxSheet(nil), wb.Sheets.Sheet...)
+}
+
+// forEachWorksheet provides a function to call the given function for each
+// worksheet by given worksheet names, all worksheets will be used and the
+// chart sheets and dialog sheets will be skipped if no worksheet specified.
+// The errors of each worksheet will be joined instead of stopping at the
+// first failure.
+func (f *File) forEachWorksheet(sheets []string, fn func(sheet string, ws *xlsxWorksheet) error) error {
+	all := len(sheets) == 0
+	if all {
+		sheets = f.GetSheetList()
+	}
+	var errs []error
+	for _, sheet := range sheets {
+		f.mu.Lock()
+		ws, err := f.workSheetReader(sheet)
+		f.mu.Unlock()
+		if err != nil {
+			if !all || err.Error() != newNotWorksheetError(sheet).Error() {
+				errs = append(errs, err)
+			}
+			continue
+		}
+		if err = fn(sheet, ws); err != nil {
+			errs = append(errs, err)
 		}
 	}
-	return
+	return errors.Join(errs...)
 }
 
 // getSheetMap provides a function to get worksheet name and XML file path map
 // of the spreadsheet.
 func (f *File) getSheetMap() (map[string]string, error) {
 	maps := map[string]string{}
-	wb, err := f.workbookReader()
-	if err != nil {
+	if _, err := f.workbookReader(); err != nil {
 		return nil, err
 	}
 	rels, err := f.relsReader(f.getWorkbookRelsPath())
@@ -493,7 +509,7 @@ func (f *File) getSheetMap() (map[string]string, error) {
 	if rels == nil {
 		return maps, nil
 	}
-	for _, v := range wb.Sheets.Sheet {
+	for _, v := range f.getSheets() {
 		for _, rel := range rels.Relationships {
 			if rel.ID == v.ID {
 				sheetXMLPath := f.getWorksheetPath(rel.Target)
@@ -509,20 +525,64 @@ func (f *File) getSheetMap() (map[string]string, error) {
 	return maps, nil
 }
 
+// renameSheet provides a function to rename the worksheet in the workbook
+// and the worksheet XML path map at the same time by given case-insensitive
+// source and target worksheet names, and returns the original name of the
+// source worksheet.
+func (f *File) renameSheet(wb *xlsxWorkbook, source, target string) (string, error) {
+	f.sheetMapMu.Lock()
+	defer f.sheetMapMu.Unlock()
+	idx := -1
+	for k, v := range wb.Sheets.Sheet {
+		if strings.EqualFold(v.Name, source) {
+			idx = k
+			continue
+		}
+		if strings.EqualFold(v.Name, target) {
+			return source, ErrExistsSheet
+		}
+	}
+	if idx == -1 {
+		return source, ErrSheetNotExist{source}
+	}
+	source = wb.Sheets.Sheet[idx].Name
+	wb.Sheets.Sheet[idx].Name = target
+	if sheetXMLPath, ok := f.sheetMap[source]; ok {
+		delete(f.sheetMap, source)
+		f.sheetMap[target] = sheetXMLPath
+	}
+	return source, nil
+}
+
 // getSheetXMLPath provides a function to get XML file path by given sheet
-// name.
+// name, the sheet name is case-insensitive. This function is concurrency
+// safe.
 func (f *File) getSheetXMLPath(sheet string) (string, bool) {
-	var (
-		name string
-		ok   bool
-	)
+	f.sheetMapMu.RLock()
+	defer f.sheetMapMu.RUnlock()
+	if name, ok := f.sheetMap[sheet]; ok {
+		return name, ok
+	}
 	for sheetName, filePath := range f.sheetMap {
 		if strings.EqualFold(sheetName, sheet) {
-			name, ok = filePath, true
-			break
+			return filePath, true
+		}
+	}
+	return "", false
+}
+
+// setSheetXMLPath provides a function to set the XML file path of the sheet
+// by given sheet name, the existing path of the sheet with the same
+// case-insensitive name will be replaced. This function is concurrency safe.
+func (f *File) setSheetXMLPath(sheet, sheetXMLPath string) {
+	f.sheetMapMu.Lock()
+	defer f.sheetMapMu.Unlock()
+	for sheetName := range f.sheetMap {
+		if strings.EqualFold(sheetName, sheet) {
+			delete(f.sheetMap, sheetName)
 		}
 	}
-	return name, ok
+	f.sheetMap[sheet] = sheetXMLPath
 }
 
 // SetSheetBackground provides a function to set background picture by given
@@ -585,12 +645,10 @@ func (f *File) DeleteSheet(sheet string) error {
 	deleteLocalSheetID, _ := f.GetSheetIndex(sheet)
 	deleteAndAdjustDefinedNames(wb, deleteLocalSheetID)
 
-	for idx, v := range wb.Sheets.Sheet {
+	for _, v := range f.getSheets() {
 		if !strings.EqualFold(v.Name, sheet) {
 			continue
 		}
-
-		wb.Sheets.Sheet = append(wb.Sheets.Sheet[:idx], wb.Sheets.Sheet[idx+1:]...)
 		var sheetXML, rels string
 		if wbRels != nil {
 			for _, rel := range wbRels.Relationships {
@@ -604,19 +662,34 @@ func (f *File) DeleteSheet(sheet string) error {
 		target := f.deleteSheetFromWorkbookRels(v.ID)
 		_ = f.removeContentTypesPart(ContentTypeSpreadSheetMLWorksheet, target)
 		_ = f.deleteCalcChain(f.getSheetID(sheet), "")
-		delete(f.sheetMap, v.Name)
+		f.deleteSheet(wb, v.Name)
 		f.Pkg.Delete(sheetXML)
 		f.Pkg.Delete(rels)
 		f.Relationships.Delete(rels)
 		f.Sheet.Delete(sheetXML)
 		f.xmlAttr.Delete(sheetXML)
 		f.SheetCount--
+		break
 	}
 	index, err := f.GetSheetIndex(activeSheetName)
 	f.SetActiveSheet(index)
 	return err
 }
 
+// deleteSheet provides a function to delete the worksheet in the workbook and
+// the worksheet XML path map at the same time by given name of the worksheet.
+func (f *File) deleteSheet(wb *xlsxWorkbook, sheet string) {
+	f.sheetMapMu.Lock()
+	defer f.sheetMapMu.Unlock()
+	for idx, v := range wb.Sheets.Sheet {
+		if v.Name == sheet {
+			wb.Sheets.Sheet = append(wb.Sheets.Sheet[:idx], wb.Sheets.Sheet[idx+1:]...)
+			break
+		}
+	}
+	delete(f.sheetMap, sheet)
+}
+
 // MoveSheet moves a sheet to a specified position in the workbook. The function
 // moves the source sheet before the target sheet. After moving, other sheets
 // will be shifted to the left or right. If the sheet is already at the target
@@ -649,15 +722,42 @@ func (f *File) MoveSheet(source, target string) error {
 	}
 	_ = f.UngroupSheets()
 	activeSheetName := f.GetSheetName(f.GetActiveSheetIndex())
+	if err = f.moveSheet(wb, source, target); err != nil {
+		return err
+	}
+	activeSheetIdx, _ := f.GetSheetIndex(activeSheetName)
+	f.SetActiveSheet(activeSheetIdx)
+	return err
+}
+
+// moveSheet provides a function to move the source sheet before the target
+// sheet in the workbook by given case-insensitive sheet names, the indexes
+// of the sheets are resolved and updated under the same lock.
+func (f *File) moveSheet(wb *xlsxWorkbook, source, target string) error {
+	f.sheetMapMu.Lock()
+	defer f.sheetMapMu.Unlock()
+	sourceIdx, targetIdx := -1, -1
+	for idx, v := range wb.Sheets.Sheet {
+		if strings.EqualFold(v.Name, source) {
+			sourceIdx = idx
+		}
+		if strings.EqualFold(v.Name, target) {
+			targetIdx = idx
+		}
+	}
+	if sourceIdx < 0 {
+		return ErrSheetNotExist{source}
+	}
+	if targetIdx < 0 {
+		return ErrSheetNotExist{target}
+	}
 	sourceSheet := wb.Sheets.Sheet[sourceIdx]
 	wb.Sheets.Sheet = append(wb.Sheets.Sheet[:sourceIdx], wb.Sheets.Sheet[sourceIdx+1:]...)
 	if targetIdx > sourceIdx {
 		targetIdx--
 	}
 	wb.Sheets.Sheet = append(wb.Sheets.Sheet[:targetIdx], append([]xlsxSheet{sourceSheet}, wb.Sheets.Sheet[targetIdx:]...)...)
-	activeSheetIdx, _ := f.GetSheetIndex(activeSheetName)
-	f.SetActiveSheet(activeSheetIdx)
-	return err
+	return nil
 }
 
 // deleteAndAdjustDefinedNames delete and adjust defined name in the workbook
@@ -815,20 +915,16 @@ func (f *File) SetSheetVisible(sheet string, visible bool, veryHidden ...bool) e
 		return err
 	}
 	if visible {
-		for k, v := range wb.Sheets.Sheet {
-			if strings.EqualFold(v.Name, sheet) {
-				wb.Sheets.Sheet[k].State = ""
-			}
-		}
+		f.setSheetState(wb, sheet, "")
 		return err
 	}
-	count, state := 0, getSheetState(visible, veryHidden)
-	for _, v := range wb.Sheets.Sheet {
+	count, state, sheets := 0, getSheetState(visible, veryHidden), f.getSheets()
+	for _, v := range sheets {
 		if v.State != state {
 			count++
 		}
 	}
-	for k, v := range wb.Sheets.Sheet {
+	for _, v := range sheets {
 		ws, err := f.workSheetReader(v.Name)
 		if err != nil {
 			return err
@@ -843,22 +939,42 @@ func (f *File) SetSheetVisible(sheet string, visible bool, veryHidden ...bool) e
 			tabSelected = ws.SheetViews.SheetView[0].TabSelected
 		}
 		if strings.EqualFold(v.Name, sheet) && count > 1 && !tabSelected {
-			wb.Sheets.Sheet[k].State = state
+			f.setSheetState(wb, sheet, state)
 		}
 	}
 	return err
 }
 
+// setSheetState provides a function to set the visible state of the sheet in
+// the workbook by given case-insensitive sheet name.
+func (f *File) setSheetState(wb *xlsxWorkbook, sheet, state string) {
+	f.sheetMapMu.Lock()
+	defer f.sheetMapMu.Unlock()
+	for k, v := range wb.Sheets.Sheet {
+		if strings.EqualFold(v.Name, sheet) {
+			wb.Sheets.Sheet[k].State = state
+		}
+	}
+}
+
 // setPanes set create freeze panes and split panes by given options.
 func (ws *xlsxWorksheet) setPanes(panes *Panes) error {
 	if panes == nil {
 		return ErrParameterInvalid
 	}
+	return ws.setPanesPosition(panes, float64(panes.XSplit), float64(panes.YSplit))
+}
+
+// setPanesPosition provides a function to set the panes of the worksheet by
+// given panes settings and the split position, which is the number of columns
+// and rows for the frozen panes, or the position in 1/20th of a point for the
+// split panes.
+func (ws *xlsxWorksheet) setPanesPosition(panes *Panes, xSplit, ySplit float64) error {
 	p := &xlsxPane{
 		ActivePane:  panes.ActivePane,
 		TopLeftCell: panes.TopLeftCell,
-		XSplit:      float64(panes.XSplit),
-		YSplit:      float64(panes.YSplit),
+		XSplit:      xSplit,
+		YSplit:      ySplit,
 	}
 	if panes.Freeze {
 		p.State = "frozen"
@@ -937,7 +1053,9 @@ func (ws *xlsxWorksheet) setPanes(panes *Panes) error {
 //
 // XSplit (Horizontal Split Position): Horizontal position of the split, in
 // 1/20th of a point; 0 (zero) if none. If the pane is frozen, this value
-// indicates the number of columns visible in the top pane.
+// indicates the number of columns visible in the top pane. Note that the split
+// position isn't the number of columns for the split panes, use the
+// SetSplitPanes function to split the panes on the border of a cell.
 //
 // YSplit (Vertical Split Position): Vertical position of the split, in 1/20th
 // of a point; 0 (zero) if none. If the pane is frozen, this value indicates the
@@ -1009,6 +1127,237 @@ func (f *File) SetPanes(sheet string, panes *Panes) error {
 	return ws.setPanes(panes)
 }
 
+// SetSplitPanes provides a function to create adjustable split panes by given
+// worksheet name and split pane options. Unlike the frozen panes, the split
+// position of the split panes was measured in 1/20th of a point. If the Cell
+// is specified, the split bars will be placed on the top left border of the
+// cell, and the XSplit and YSplit will be calculated from the widths of the
+// columns on the left side and the heights of the rows above it, otherwise
+// the XSplit and YSplit will be used as is. The TopLeftCell defaults to the
+// Cell, and the ActivePane defaults to the pane on the bottom right side of
+// the split bars. For example, split Sheet1 on the top left border of cell C4:
+//
+//	err := f.SetSplitPanes("Sheet1", &excelize.SplitPaneOptions{Cell: "C4"})
+//
+// Split Sheet1 horizontally at 1800 twips from the top of the worksheet:
+//
+//	err := f.SetSplitPanes("Sheet1", &excelize.SplitPaneOptions{YSplit: 1800})
+//
+// Use the SetPanes function with both Freeze and Split as false to remove the
+// split panes.
+func (f *File) SetSplitPanes(sheet string, opts *SplitPaneOptions) error {
+	if opts == nil || opts.XSplit < 0 || opts.YSplit < 0 {
+		return ErrParameterInvalid
+	}
+	xSplit, ySplit, topLeftCell := opts.XSplit, opts.YSplit, opts.TopLeftCell
+	if opts.Cell != "" {
+		col, row, err := CellNameToCoordinates(opts.Cell)
+		if err != nil {
+			return err
+		}
+		if xSplit, ySplit, err = f.getSplitPanePosition(sheet, col, row); err != nil {
+			return err
+		}
+		if topLeftCell == "" {
+			topLeftCell = opts.Cell
+		}
+	}
+	if xSplit == 0 && ySplit == 0 {
+		return ErrParameterInvalid
+	}
+	activePane := opts.ActivePane
+	if activePane == "" {
+		activePane = "bottomRight"
+		if ySplit == 0 {
+			activePane = "topRight"
+		}
+		if xSplit == 0 {
+			activePane = "bottomLeft"
+		}
+	}
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return err
+	}
+	return ws.setPanesPosition(&Panes{
+		Split: true, TopLeftCell: topLeftCell, ActivePane: activePane, Selection: opts.Selection,
+	}, xSplit, ySplit)
+}
+
+// getSplitPanePosition calculates the position of the split bars on the top
+// left border of the given cell in 1/20th of a point, the width of the columns
+// and the height of the rows will be converted by the 96 DPI mapping. Excel
+// counts the row headings and column headings in the position of the split
+// bars, the width of the row headings fits the digits of the last row number
+// in the default font, and the height of the column headings is the default
+// row height of the worksheet.
+func (f *File) getSplitPanePosition(sheet string, col, row int) (float64, float64, error) {
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return 0, 0, err
+	}
+	maxDigitWidth, err := f.getMaxDigitWidth()
+	if err != nil {
+		return 0, 0, err
+	}
+	var width, height int
+	for c := 1; c < col; c++ {
+		width += f.getColWidth(sheet, c, maxDigitWidth)
+	}
+	for r := 1; r < row; r++ {
+		height += f.getRowHeight(sheet, r)
+	}
+	ws.mu.Lock()
+	lastRow, headingHeight := row, defaultRowHeightPixels*15
+	if rows := len(ws.SheetData.Row); rows > 0 {
+		lastRow = max(lastRow, ws.SheetData.Row[rows-1].R)
+	}
+	if ws.SheetFormatPr != nil && ws.SheetFormatPr.DefaultRowHeight > 0 {
+		headingHeight = ws.SheetFormatPr.DefaultRowHeight * 20
+	}
+	ws.mu.Unlock()
+	var xSplit, ySplit float64
+	if width > 0 {
+		headingWidth := float64(int(float64(max(len(strconv.Itoa(lastRow)), 3))*maxDigitWidth)) + 2
+		xSplit = (float64(width) + headingWidth) * 15
+	}
+	if height > 0 {
+		ySplit = float64(height)*15 + headingHeight
+	}
+	return xSplit, ySplit, nil
+}
+
+// FreezePanes provides a function to freeze the rows above and the columns on
+// the left side of the given cell by given worksheet name and cell reference,
+// the XSplit, YSplit, TopLeftCell and ActivePane of the panes will be
+// calculated by the cell. The existing selections of the worksheet view will
+// be kept and placed in the panes which contain their active cells, and the
+// pane of the active cell will be activated. For example, freeze the first
+// row and column A on Sheet1:
+//
+//	err := f.FreezePanes("Sheet1", "B2")
+func (f *File) FreezePanes(sheet, cell string) error {
+	col, row, err := CellNameToCoordinates(cell)
+	if err != nil {
+		return err
+	}
+	return f.freezePanes(sheet, col-1, row-1)
+}
+
+// FreezeCols provides a function to freeze the columns from column A through
+// the given column by given worksheet name and column name. For example,
+// freeze columns A to C on Sheet1:
+//
+//	err := f.FreezeCols("Sheet1", "C")
+func (f *File) FreezeCols(sheet, throughCol string) error {
+	col, err := ColumnNameToNumber(throughCol)
+	if err != nil {
+		return err
+	}
+	return f.freezePanes(sheet, col, 0)
+}
+
+// FreezeRows provides a function to freeze the rows from the first row
+// through the given row by given worksheet name and row number. For example,
+// freeze the first 2 rows on Sheet1:
+//
+//	err := f.FreezeRows("Sheet1", 2)
+func (f *File) FreezeRows(sheet string, throughRow int) error {
+	if throughRow < 1 {
+		return newInvalidRowNumberError(throughRow)
+	}
+	return f.freezePanes(sheet, 0, throughRow)
+}
+
+// freezePanes provides a function to freeze the given number of columns and
+// rows, the last column and row of the worksheet could not be frozen.
+func (f *File) freezePanes(sheet string, cols, rows int) error {
+	if cols >= MaxColumns {
+		return ErrColumnNumber
+	}
+	if rows >= TotalRows {
+		return ErrMaxRows
+	}
+	if cols == 0 && rows == 0 {
+		return ErrParameterInvalid
+	}
+	view, err := f.getSheetView(sheet, -1)
+	if err != nil {
+		return err
+	}
+	view.freezePanes(cols, rows)
+	return err
+}
+
+// freezePanes provides a function to freeze the given number of columns and
+// rows of the sheet view, the existing selections will be placed in the panes
+// which contain their active cells.
+func (view *xlsxSheetView) freezePanes(cols, rows int) {
+	topLeftCell, _ := CoordinatesToCellName(cols+1, rows+1)
+	view.Pane = &xlsxPane{
+		XSplit:      float64(cols),
+		YSplit:      float64(rows),
+		TopLeftCell: topLeftCell,
+		State:       "frozen",
+	}
+	view.Pane.ActivePane = getPaneByCell(cols+1, rows+1, cols, rows)
+	var selections []*xlsxSelection
+	for _, s := range view.Selection {
+		if s == nil {
+			continue
+		}
+		pane := view.Pane.ActivePane
+		if col, row, err := CellNameToCoordinates(s.ActiveCell); err == nil {
+			pane = getPaneByCell(col, row, cols, rows)
+			view.Pane.ActivePane = pane
+		}
+		if s.Pane = pane; pane == "topLeft" {
+			s.Pane = ""
+		}
+		for i := 0; i < len(selections); i++ {
+			if selections[i].Pane == s.Pane {
+				selections = append(selections[:i], selections[i+1:]...)
+				i--
+			}
+		}
+		selections = append(selections, s)
+	}
+	view.Selection = selections
+}
+
+// UnfreezePanes provides a function to remove the frozen panes by given
+// worksheet name, the selection of the active pane will be kept as the
+// selection of the worksheet view. The split panes will not be removed by this
+// function. For example, unfreeze panes on Sheet1:
+//
+//	err := f.UnfreezePanes("Sheet1")
+func (f *File) UnfreezePanes(sheet string) error {
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return err
+	}
+	if ws.SheetViews == nil || len(ws.SheetViews.SheetView) == 0 {
+		return err
+	}
+	sw := &ws.SheetViews.SheetView[len(ws.SheetViews.SheetView)-1]
+	if sw.Pane == nil || (sw.Pane.State != "frozen" && sw.Pane.State != "frozenSplit") {
+		return err
+	}
+	var selection *xlsxSelection
+	for _, s := range sw.Selection {
+		if s != nil && (selection == nil || s.Pane == sw.Pane.ActivePane ||
+			(s.Pane == "" && sw.Pane.ActivePane == "topLeft")) {
+			selection = s
+		}
+	}
+	sw.Pane, sw.Selection = nil, nil
+	if selection != nil {
+		selection.Pane = ""
+		sw.Selection = []*xlsxSelection{selection}
+	}
+	return err
+}
+
 // getPanes returns freeze panes, split panes, and views of the worksheet.
 func (ws *xlsxWorksheet) getPanes() Panes {
 	var (
@@ -1033,8 +1382,11 @@ func (ws *xlsxWorksheet) getPanes() Panes {
 		return panes
 	}
 	panes.ActivePane = sw.Pane.ActivePane
-	if sw.Pane.State == "frozen" {
+	switch sw.Pane.State {
+	case "frozen", "frozenSplit":
 		panes.Freeze = true
+	default:
+		panes.Split = sw.Pane.XSplit > 0 || sw.Pane.YSplit > 0
 	}
 	panes.TopLeftCell = sw.Pane.TopLeftCell
 	panes.XSplit = int(sw.Pane.XSplit)
@@ -1043,7 +1395,10 @@ func (ws *xlsxWorksheet) getPanes() Panes {
 }
 
 // GetPanes provides a function to get freeze panes, split panes, and worksheet
-// views by given worksheet name.
+// views by given worksheet name. The Freeze will be true if the XSplit and
+// YSplit indicate the number of columns and rows visible in the frozen panes,
+// and the Split will be true if they indicate the position of the adjustable
+// split bars in 1/20th of a point.
 func (f *File) GetPanes(sheet string) (Panes, error) {
 	var panes Panes
 	ws, err := f.workSheetReader(sheet)
@@ -1062,10 +1417,9 @@ func (f *File) GetSheetVisible(sheet string) (bool, error) {
 	if err := checkSheetName(sheet); err != nil {
 		return visible, err
 	}
-	wb, _ := f.workbookReader()
-	for k, v := range wb.Sheets.Sheet {
+	for _, v := range f.getSheets() {
 		if strings.EqualFold(v.Name, sheet) {
-			if wb.Sheets.Sheet[k].State == "" || wb.Sheets.Sheet[k].State == "visible" {
+			if v.State == "" || v.State == "visible" {
 				visible = true
 			}
 		}
@@ -1102,11 +1456,7 @@ func (f *File) SearchSheet(sheet, value string, reg ...bool) ([]string, error) {
 	if !ok {
 		return result, ErrSheetNotExist{sheet}
 	}
-	if ws, ok := f.Sheet.Load(name); ok && ws != nil {
-		// Flush data
-		output, _ := xml.Marshal(ws.(*xlsxWorksheet))
-		f.saveFileList(name, f.replaceNameSpaceBytes(name, output))
-	}
+	f.flushWorksheet(name)
 	return f.searchSheet(name, value, regSearch)
 }
 
@@ -1445,6 +1795,26 @@ func (f *File) ProtectSheet(sheet string, opts *SheetProtectionOptions) error {
 	return err
 }
 
+// ProtectSheetAllowOutlines provides a function to protect a worksheet with
+// the given password while still allowing users to expand and collapse the
+// outline groups of rows and columns. It allows formatting rows and columns,
+// and selecting locked and unlocked cells, all other actions stay protected.
+// Note that the outline symbols must be shown on the worksheet, and some
+// spreadsheet applications also require the application level outlining
+// setting enabled on protected worksheets, which is not stored in the
+// workbook. For example, protect Sheet1 with outline interaction allowed:
+//
+//	err := f.ProtectSheetAllowOutlines("Sheet1", "password")
+func (f *File) ProtectSheetAllowOutlines(sheet, password string) error {
+	return f.ProtectSheet(sheet, &SheetProtectionOptions{
+		FormatColumns:       true,
+		FormatRows:          true,
+		Password:            password,
+		SelectLockedCells:   true,
+		SelectUnlockedCells: true,
+	})
+}
+
 // UnprotectSheet provides a function to remove protection for a sheet,
 // specified the second optional password parameter to remove sheet
 // protection with password verification.
@@ -1765,8 +2135,8 @@ func (f *File) SetDefinedName(definedName *DefinedName) error {
 	}
 	d := xlsxDefinedName{
 		Name:    definedName.Name,
-		Comment: definedName.Comment,
-		Data:    definedName.RefersTo,
+		Comment: bstrMarshal(definedName.Comment),
+		Data:    bstrMarshal(definedName.RefersTo),
 	}
 	if definedName.Scope != "" {
 		if sheetIndex, _ := f.GetSheetIndex(definedName.Scope); sheetIndex >= 0 {
@@ -1833,8 +2203,8 @@ func (f *File) GetDefinedName() []DefinedName {
 		for _, dn := range wb.DefinedNames.DefinedName {
 			definedName := DefinedName{
 				Name:     dn.Name,
-				Comment:  dn.Comment,
-				RefersTo: dn.Data,
+				Comment:  bstrUnmarshal(dn.Comment),
+				RefersTo: bstrUnmarshal(dn.Data),
 				Scope:    "Workbook",
 			}
 			if dn.LocalSheetID != nil && *dn.LocalSheetID >= 0 {
@@ -1846,6 +2216,142 @@ func (f *File) GetDefinedName() []DefinedName {
 	return definedNames
 }
 
+// r1c1NameExp is the regular expression to match the names that conflict
+// with the R1C1 reference style, which are not allowed in the defined names.
+var r1c1NameExp = regexp.MustCompile(`^(?i)(r[0-9]*c[0-9]*|r[0-9]*|c[0-9]*)$`)
+
+// checkHiddenName provides a function to check the name of the hidden
+// defined name follows the defined name rules of Excel, the name can not be
+// a cell reference, a R1C1 style reference or a built-in defined name.
+func checkHiddenName(name string) error {
+	if name == "" {
+		return ErrParameterInvalid
+	}
+	if err := checkDefinedName(name); err != nil {
+		return err
+	}
+	if _, _, err := CellNameToCoordinates(name); err == nil || r1c1NameExp.MatchString(name) ||
+		strings.HasPrefix(strings.ToLower(name), "_xlnm.") {
+		return newInvalidNameError(name)
+	}
+	return nil
+}
+
+// parseStringConstant provides a function to parse the string constant of
+// the defined name, and returns the unescaped value and a boolean value
+// indicating whether the formula is a string constant.
+func parseStringConstant(formula string) (string, bool) {
+	if len(formula) < 2 || formula[0] != '"' || formula[len(formula)-1] != '"' {
+		return "", false
+	}
+	value := formula[1 : len(formula)-1]
+	if strings.Count(value, `"`) != 2*strings.Count(value, `""`) {
+		return "", false
+	}
+	return strings.ReplaceAll(value, `""`, `"`), true
+}
+
+// isStringConstant returns whether the defined name refers to a string
+// constant, which doesn't contain any reference to be adjusted.
+func (dn *xlsxDefinedName) isStringConstant() bool {
+	_, ok := parseStringConstant(bstrUnmarshal(dn.Data))
+	return ok
+}
+
+// getWorkbookName provides a function to get the index of the workbook scope
+// defined name by given name, the name is case-insensitive, and returns -1 if
+// the name does not exist.
+func (wb *xlsxWorkbook) getWorkbookName(name string) int {
+	if wb.DefinedNames == nil {
+		return -1
+	}
+	for idx, dn := range wb.DefinedNames.DefinedName {
+		if dn.LocalSheetID == nil && strings.EqualFold(dn.Name, name) {
+			return idx
+		}
+	}
+	return -1
+}
+
+// SetHiddenName provides a function to set a hidden workbook scope defined
+// name which refers to a string constant by given name and value, it could be
+// used to store the metadata of the workbook, such as the template version,
+// which not visible in the Name Manager of Excel. The existing hidden name
+// will be updated, and an error will be returned if a visible workbook scope
+// defined name with the same name exists. The name should follow the defined
+// name rules of Excel, and the value should be less than or equal to 255
+// characters. For example, set the hidden name "TemplateVersion":
+//
+//	err := f.SetHiddenName("TemplateVersion", "2.1")
+func (f *File) SetHiddenName(name, value string) error {
+	if err := checkHiddenName(name); err != nil {
+		return err
+	}
+	if utf8.RuneCountInString(value) > MaxFieldLength {
+		return ErrDefinedNameValueLength
+	}
+	wb, err := f.workbookReader()
+	if err != nil {
+		return err
+	}
+	data := bstrMarshal(`"` + strings.ReplaceAll(value, `"`, `""`) + `"`)
+	if idx := wb.getWorkbookName(name); idx != -1 {
+		dn := &wb.DefinedNames.DefinedName[idx]
+		if !dn.Hidden {
+			return ErrDefinedNameDuplicate
+		}
+		dn.Data = data
+		return err
+	}
+	if wb.DefinedNames == nil {
+		wb.DefinedNames = &xlsxDefinedNames{}
+	}
+	wb.DefinedNames.DefinedName = append(wb.DefinedNames.DefinedName, xlsxDefinedName{
+		Name: name, Hidden: true, Data: data,
+	})
+	return err
+}
+
+// GetHiddenName provides a function to get the string constant of the hidden
+// workbook scope defined name by given name, the second return value
+// indicating whether the hidden name exists. For example, get the hidden name
+// "TemplateVersion":
+//
+//	value, ok, err := f.GetHiddenName("TemplateVersion")
+func (f *File) GetHiddenName(name string) (string, bool, error) {
+	wb, err := f.workbookReader()
+	if err != nil {
+		return "", false, err
+	}
+	idx := wb.getWorkbookName(name)
+	if idx == -1 || !wb.DefinedNames.DefinedName[idx].Hidden {
+		return "", false, err
+	}
+	value, ok := parseStringConstant(bstrUnmarshal(wb.DefinedNames.DefinedName[idx].Data))
+	return value, ok, err
+}
+
+// DeleteHiddenName provides a function to delete the hidden workbook scope
+// defined name by given name. For example, delete the hidden name
+// "TemplateVersion":
+//
+//	err := f.DeleteHiddenName("TemplateVersion")
+func (f *File) DeleteHiddenName(name string) error {
+	wb, err := f.workbookReader()
+	if err != nil {
+		return err
+	}
+	idx := wb.getWorkbookName(name)
+	if idx == -1 || !wb.DefinedNames.DefinedName[idx].Hidden {
+		return ErrDefinedNameScope
+	}
+	if wb.DefinedNames.DefinedName = append(wb.DefinedNames.DefinedName[:idx],
+		wb.DefinedNames.DefinedName[idx+1:]...); len(wb.DefinedNames.DefinedName) == 0 {
+		wb.DefinedNames = nil
+	}
+	return err
+}
+
 // GroupSheets provides a function to group worksheets by given worksheets
 // name. Group worksheets must contain an active worksheet.
 func (f *File) GroupSheets(sheets []string) error {
@@ -2019,13 +2525,237 @@ func (f *File) RemovePageBreak(sheet, cell string) error {
 	return err
 }
 
-// relsReader provides a function to get the pointer to the structure
-// after deserialization of relationships parts.
-func (f *File) relsReader(path string) (*xlsxRelationships, error) {
-	rels, _ := f.Relationships.Load(path)
-	if rels == nil {
-		if _, ok := f.Pkg.Load(path); ok {
-			c := xlsxRelationships{}
+// GetPrintLayout provides a function to get all settings which affect the
+// pagination of a worksheet in one call by given worksheet name, including
+// page setup, margins, print area, print titles, manual page breaks, header
+// and footer, hidden rows and columns, and the column widths and row heights
+// in points of the used range. The header and footer images are referenced by
+// the &G code in the header and footer strings. For example, get the print
+// layout of Sheet1:
+//
+//	layout, err := f.GetPrintLayout("Sheet1")
+func (f *File) GetPrintLayout(sheet string) (*PrintLayout, error) {
+	var (
+		layout = &PrintLayout{ColWidths: map[string]float64{}, RowHeights: map[int]float64{}}
+		err    error
+	)
+	if layout.PageLayout, err = f.GetPageLayout(sheet); err != nil {
+		return nil, err
+	}
+	if layout.PageMargins, err = f.GetPageMargins(sheet); err != nil {
+		return nil, err
+	}
+	if layout.HeaderFooter, err = f.GetHeaderFooter(sheet); err != nil {
+		return nil, err
+	}
+	for _, dn := range f.GetDefinedName() {
+		if !strings.EqualFold(dn.Scope, sheet) {
+			continue
+		}
+		switch dn.Name {
+		case builtInDefinedNames[0]:
+			layout.PrintArea = dn.RefersTo
+		case builtInDefinedNames[1]:
+			layout.PrintTitles = dn.RefersTo
+		}
+	}
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return nil, err
+	}
+	ws.mu.Lock()
+	var lastCol, lastRow int
+	if ws.RowBreaks != nil {
+		for _, brk := range ws.RowBreaks.Brk {
+			layout.RowBreaks = append(layout.RowBreaks, brk.ID)
+		}
+	}
+	if ws.ColBreaks != nil {
+		for _, brk := range ws.ColBreaks.Brk {
+			layout.ColBreaks = append(layout.ColBreaks, brk.ID)
+		}
+	}
+	for _, row := range ws.SheetData.Row {
+		if row.Hidden {
+			layout.HiddenRows = append(layout.HiddenRows, row.R)
+		}
+		lastRow = max(lastRow, row.R)
+		for _, c := range row.C {
+			if col, _, err := CellNameToCoordinates(c.R); err == nil {
+				lastCol = max(lastCol, col)
+			}
+		}
+	}
+	if ws.Cols != nil {
+		for _, c := range ws.Cols.Col {
+			if c.Max < MaxColumns {
+				lastCol = max(lastCol, c.Max)
+			}
+			for col := c.Min; c.Hidden && col <= c.Max; col++ {
+				name, _ := ColumnNumberToName(col)
+				layout.HiddenCols = append(layout.HiddenCols, name)
+			}
+		}
+	}
+	layout.DefaultColWidth, layout.DefaultRowHeight = defaultColWidth, defaultRowHeight
+	if ws.SheetFormatPr != nil {
+		if ws.SheetFormatPr.DefaultColWidth > 0 {
+			layout.DefaultColWidth = ws.SheetFormatPr.DefaultColWidth
+		}
+		if ws.SheetFormatPr.CustomHeight {
+			layout.DefaultRowHeight = ws.SheetFormatPr.DefaultRowHeight
+		}
+	}
+	ws.mu.Unlock()
+	if layout.HeaderFooterImages, err = f.getHeaderFooterImages(sheet, ws); err != nil {
+		return nil, err
+	}
+	for col := 1; col <= lastCol; col++ {
+		name, _ := ColumnNumberToName(col)
+		width, err := f.GetColWidth(sheet, name)
+		if err != nil {
+			return nil, err
+		}
+		layout.ColWidths[name] = width
+	}
+	for row := 1; row <= lastRow; row++ {
+		height, err := f.GetRowHeight(sheet, row)
+		if err != nil {
+			return nil, err
+		}
+		layout.RowHeights[row] = height
+	}
+	return layout, err
+}
+
+// SetPrintLayout provides a function to apply the print layout settings
+// returned by the GetPrintLayout function to a worksheet by given worksheet
+// name. The page breaks, print area, print titles and hidden rows and columns
+// of the worksheet will be replaced, the header and footer images will replace
+// the images at the same position, and only the column widths and row heights
+// which differ from the worksheet will be changed. The DefaultColWidth and
+// DefaultRowHeight fields are ignored. For example:
+//
+//	layout, err := f.GetPrintLayout("Sheet1")
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	err = f.SetPrintLayout("Sheet2", layout)
+func (f *File) SetPrintLayout(sheet string, layout *PrintLayout) error {
+	if layout == nil {
+		return ErrParameterInvalid
+	}
+	current, err := f.GetPrintLayout(sheet)
+	if err != nil {
+		return err
+	}
+	if err = f.SetPageLayout(sheet, &layout.PageLayout); err != nil {
+		return err
+	}
+	if err = f.SetPageMargins(sheet, &layout.PageMargins); err != nil {
+		return err
+	}
+	if err = f.SetHeaderFooter(sheet, layout.HeaderFooter); err != nil {
+		return err
+	}
+	for name, refersTo := range map[string]string{
+		builtInDefinedNames[0]: layout.PrintArea, builtInDefinedNames[1]: layout.PrintTitles,
+	} {
+		_ = f.DeleteDefinedName(&DefinedName{Name: name, Scope: sheet})
+		if refersTo == "" {
+			continue
+		}
+		if err = f.SetDefinedName(&DefinedName{Name: name, RefersTo: refersTo, Scope: sheet}); err != nil {
+			return err
+		}
+	}
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return err
+	}
+	if err = ws.setPageBreaks(layout.RowBreaks, layout.ColBreaks); err != nil {
+		return err
+	}
+	for i := range layout.HeaderFooterImages {
+		if err = f.AddHeaderFooterImage(sheet, &layout.HeaderFooterImages[i]); err != nil {
+			return err
+		}
+	}
+	for _, row := range current.HiddenRows {
+		if err = f.SetRowVisible(sheet, row, true); err != nil {
+			return err
+		}
+	}
+	for _, row := range layout.HiddenRows {
+		if err = f.SetRowVisible(sheet, row, false); err != nil {
+			return err
+		}
+	}
+	for _, col := range current.HiddenCols {
+		if err = f.SetColVisible(sheet, col, true); err != nil {
+			return err
+		}
+	}
+	for _, col := range layout.HiddenCols {
+		if err = f.SetColVisible(sheet, col, false); err != nil {
+			return err
+		}
+	}
+	for col, width := range layout.ColWidths {
+		if pts, ok := current.ColWidths[col]; ok && pts == width {
+			continue
+		}
+		if err = f.SetColWidth(sheet, col, col, width); err != nil {
+			return err
+		}
+	}
+	for row, height := range layout.RowHeights {
+		if ht, ok := current.RowHeights[row]; ok && ht == height {
+			continue
+		}
+		if err = f.SetRowHeight(sheet, row, height); err != nil {
+			return err
+		}
+	}
+	return err
+}
+
+// setPageBreaks provides a function to replace the manual page breaks of the
+// worksheet by given 1-based row and column numbers after which the page
+// breaks are placed.
+func (ws *xlsxWorksheet) setPageBreaks(rowBreaks, colBreaks []int) error {
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	ws.RowBreaks, ws.ColBreaks = nil, nil
+	for _, row := range rowBreaks {
+		cell, err := CoordinatesToCellName(1, row+1)
+		if err != nil {
+			return err
+		}
+		if err = ws.insertPageBreak(cell); err != nil {
+			return err
+		}
+	}
+	for _, col := range colBreaks {
+		cell, err := CoordinatesToCellName(col+1, 1)
+		if err != nil {
+			return err
+		}
+		if err = ws.insertPageBreak(cell); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// relsReader provides a function to get the pointer to the structure
+// after deserialization of relationships parts.
+func (f *File) relsReader(path string) (*xlsxRelationships, error) {
+	rels, _ := f.Relationships.Load(path)
+	if rels == nil {
+		if _, ok := f.Pkg.Load(path); ok {
+			c := xlsxRelationships{}
 			if err := f.xmlNewDecoder(bytes.NewReader(namespaceStrictToTransitional(f.readXML(path)))).
 				Decode(&c); err != nil && err != io.EOF {
 				return nil, err
@@ -2132,6 +2862,55 @@ func (f *File) GetSheetDimension(sheet string) (string, error) {
 	return ref, err
 }
 
+// UpdateSheetDimension provides the method to recalculate the used range of
+// the worksheet by the cells which have a value, formula or style, and update
+// the dimension of the worksheet. The dimension will be "A1" if the worksheet
+// is empty. The dimension will be updated automatically after inserting or
+// removing the rows and columns if the worksheet has the dimension. For
+// example, update the dimension of Sheet1:
+//
+//	err := f.UpdateSheetDimension("Sheet1")
+func (f *File) UpdateSheetDimension(sheet string) error {
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return err
+	}
+	return ws.updateDimension()
+}
+
+// updateDimension provides a function to recalculate the used range of the
+// worksheet by the cells which have a value, formula or style, and update the
+// dimension of the worksheet.
+func (ws *xlsxWorksheet) updateDimension() error {
+	coordinates := []int{0, 0, 0, 0}
+	for _, row := range ws.SheetData.Row {
+		for _, c := range row.C {
+			if c.V == "" && c.F == nil && c.IS == nil && c.S == 0 {
+				continue
+			}
+			col, rowNum, err := CellNameToCoordinates(c.R)
+			if err != nil {
+				return err
+			}
+			if coordinates[0] == 0 {
+				coordinates = []int{col, rowNum, col, rowNum}
+				continue
+			}
+			coordinates[0], coordinates[1] = min(coordinates[0], col), min(coordinates[1], rowNum)
+			coordinates[2], coordinates[3] = max(coordinates[2], col), max(coordinates[3], rowNum)
+		}
+	}
+	ref := "A1"
+	if coordinates[0] != 0 {
+		ref, _ = CoordinatesToCellName(coordinates[0], coordinates[1])
+		if coordinates[0] != coordinates[2] || coordinates[1] != coordinates[3] {
+			ref, _ = coordinatesToRangeRef(coordinates)
+		}
+	}
+	ws.Dimension = &xlsxDimension{Ref: ref}
+	return nil
+}
+
 // AddIgnoredErrors provides the method to ignored error for a range of cells.
 func (f *File) AddIgnoredErrors(sheet, rangeRef string, ignoredErrorsType IgnoredErrorsType) error {
 	ws, err := f.workSheetReader(sheet)
@@ -2163,3 +2942,595 @@ func (f *File) AddIgnoredErrors(sheet, rangeRef string, ignoredErrorsType Ignore
 	ws.IgnoredErrors.IgnoredError = append(ws.IgnoredErrors.IgnoredError, ie)
 	return err
 }
+
+// ClearFlags is the type of the flags for specifying what to be cleared by
+// the ClearRange and ClearSheet functions, the flags could be combined.
+type ClearFlags byte
+
+// Clear flags enumeration.
+const (
+	ClearContents ClearFlags = 1 << iota
+	ClearFormats
+	ClearHyperlinks
+	ClearComments
+	ClearValidations
+	ClearAll = ClearContents | ClearFormats | ClearHyperlinks | ClearComments | ClearValidations
+)
+
+// ClearRange provides a function to clear the cells in the range by given
+// worksheet name, range reference and clear flags without shifting the other
+// cells. The ClearContents clears the values and formulas of the cells and
+// keeps the cell styles, the ClearFormats resets the cell styles to the
+// default style and unmerges the merged cells which intersect the range, the
+// ClearHyperlinks removes the hyperlinks which intersect the range, the
+// ClearComments removes the comments in the range, and the ClearValidations
+// removes the range from the data validations. For example, clear the values
+// and styles of the cells in range A1:C10 on Sheet1:
+//
+//	err := f.ClearRange("Sheet1", "A1:C10", excelize.ClearContents|excelize.ClearFormats)
+func (f *File) ClearRange(sheet, rangeRef string, what ClearFlags) error {
+	if what == 0 || what > ClearAll {
+		return ErrParameterInvalid
+	}
+	if !strings.Contains(rangeRef, ":") {
+		rangeRef += ":" + rangeRef
+	}
+	rect, err := rangeRefToCoordinates(rangeRef)
+	if err != nil {
+		return err
+	}
+	_ = sortCoordinates(rect)
+	topLeftCell, _ := CoordinatesToCellName(rect[0], rect[1])
+	bottomRightCell, _ := CoordinatesToCellName(rect[2], rect[3])
+	f.mu.Lock()
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		f.mu.Unlock()
+		return err
+	}
+	f.mu.Unlock()
+	if what&ClearFormats != 0 {
+		if err = f.UnmergeCell(sheet, topLeftCell, bottomRightCell); err != nil {
+			return err
+		}
+	}
+	ws.mu.Lock()
+	if err = f.clearCells(ws, sheet, rect, what); err == nil && what&ClearHyperlinks != 0 {
+		err = f.clearHyperlinks(ws, sheet, rect)
+	}
+	ws.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	if what&ClearComments != 0 {
+		if err = f.clearComments(sheet, rect); err != nil {
+			return err
+		}
+	}
+	if what&ClearValidations != 0 {
+		return f.DeleteDataValidation(sheet, topLeftCell+":"+bottomRightCell)
+	}
+	return err
+}
+
+// ClearSheet provides a function to clear the worksheet by given worksheet
+// name and clear flags without deleting the worksheet. The ClearContents
+// clears the values and formulas of all cells and keeps the cell styles, and
+// the ClearFormats resets the styles of the cells, rows and columns, the
+// column widths, row heights, merged cells and page setup of the worksheet,
+// the sheet data will be reset if both of them are specified. The
+// ClearHyperlinks, ClearComments and ClearValidations remove all hyperlinks,
+// comments and data validations in the worksheet. For example, clear all
+// values and keep the formatting of Sheet1:
+//
+//	err := f.ClearSheet("Sheet1", excelize.ClearContents)
+func (f *File) ClearSheet(sheet string, what ClearFlags) error {
+	if what == 0 || what > ClearAll {
+		return ErrParameterInvalid
+	}
+	f.mu.Lock()
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		f.mu.Unlock()
+		return err
+	}
+	f.mu.Unlock()
+	ws.mu.Lock()
+	if what&ClearContents != 0 {
+		ws.formulaSI.Clear()
+	}
+	if what&(ClearContents|ClearFormats) == ClearContents|ClearFormats {
+		ws.SheetData = xlsxSheetData{}
+		err = f.deleteCalcChain(f.getSheetID(sheet), "")
+	} else {
+		err = f.clearCells(ws, sheet, []int{1, 1, MaxColumns, TotalRows}, what)
+	}
+	if err == nil && what&ClearFormats != 0 {
+		for r := range ws.SheetData.Row {
+			row := &ws.SheetData.Row[r]
+			row.S, row.CustomFormat, row.Ht, row.CustomHeight = 0, false, nil, false
+		}
+		if ws.PageSetUp != nil && ws.PageSetUp.RID != "" {
+			f.deleteSheetRelationships(sheet, ws.PageSetUp.RID)
+		}
+		ws.Cols, ws.MergeCells, ws.PageSetUp, ws.PageMargins, ws.PrintOptions = nil, nil, nil, nil, nil
+	}
+	if err == nil && what&ClearHyperlinks != 0 {
+		err = f.clearHyperlinks(ws, sheet, []int{1, 1, MaxColumns, TotalRows})
+	}
+	ws.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	if what&ClearComments != 0 {
+		if err = f.clearComments(sheet, []int{1, 1, MaxColumns, TotalRows}); err != nil {
+			return err
+		}
+	}
+	if what&ClearValidations != 0 {
+		return f.DeleteDataValidation(sheet)
+	}
+	return err
+}
+
+// clearCells provides a function to clear the values, formulas and styles of
+// the cells in the range by given coordinates and clear flags.
+func (f *File) clearCells(ws *xlsxWorksheet, sheet string, rect []int, what ClearFlags) error {
+	if what&(ClearContents|ClearFormats) == 0 {
+		return nil
+	}
+	for r := range ws.SheetData.Row {
+		row := &ws.SheetData.Row[r]
+		if row.R < rect[1] || row.R > rect[3] {
+			continue
+		}
+		for i := range row.C {
+			c := &row.C[i]
+			col, _, err := CellNameToCoordinates(c.R)
+			if err != nil {
+				return err
+			}
+			if col < rect[0] || col > rect[2] {
+				continue
+			}
+			if what&ClearContents != 0 {
+				if err = f.removeFormula(c, ws, sheet); err != nil {
+					return err
+				}
+				c.T, c.V, c.F, c.IS, c.Cm, c.Vm = "", "", nil, nil, nil, nil
+			}
+			if what&ClearFormats != 0 {
+				c.S = 0
+			}
+		}
+	}
+	return nil
+}
+
+// clearHyperlinks provides a function to remove the hyperlinks which
+// intersect the range by given coordinates.
+func (f *File) clearHyperlinks(ws *xlsxWorksheet, sheet string, rect []int) error {
+	if ws.Hyperlinks == nil {
+		return nil
+	}
+	var links []xlsxHyperlink
+	for _, link := range ws.Hyperlinks.Hyperlink {
+		ref := link.Ref
+		if !strings.Contains(ref, ":") {
+			ref += ":" + ref
+		}
+		coordinates, err := rangeRefToCoordinates(ref)
+		if err != nil {
+			return err
+		}
+		_ = sortCoordinates(coordinates)
+		if isOverlap(rect, coordinates) {
+			f.deleteSheetRelationships(sheet, link.RID)
+			continue
+		}
+		links = append(links, link)
+	}
+	if ws.Hyperlinks.Hyperlink = links; len(links) == 0 {
+		ws.Hyperlinks = nil
+	}
+	return nil
+}
+
+// clearComments provides a function to delete the comments in the range by
+// given worksheet name and coordinates.
+func (f *File) clearComments(sheet string, rect []int) error {
+	comments, err := f.GetComments(sheet)
+	if err != nil {
+		return err
+	}
+	for _, comment := range comments {
+		col, row, err := CellNameToCoordinates(comment.Cell)
+		if err != nil {
+			return err
+		}
+		if cellInRange([]int{col, row}, rect) {
+			if err = f.DeleteComment(sheet, comment.Cell); err != nil {
+				return err
+			}
+		}
+	}
+	return err
+}
+
+// TrimSheetToUsedRange provides a function to remove the rows and columns
+// beyond the used range of the worksheet by given worksheet name. The used
+// range is determined by the cells which have a value or formula, the stray
+// cell, row and column formats outside of it will be removed and the
+// dimension of the worksheet will be updated. The merged cells, conditional
+// formats, data validations, hyperlinks and auto filter will be clipped to the
+// used range, or removed if they are entirely outside of it. The worksheet
+// will not be changed if any cell or range reference is invalid. For example,
+// trim Sheet1:
+//
+//	err := f.TrimSheetToUsedRange("Sheet1")
+func (f *File) TrimSheetToUsedRange(sheet string) error {
+	f.mu.Lock()
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		f.mu.Unlock()
+		return err
+	}
+	f.mu.Unlock()
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	var lastCol, lastRow int
+	cellCols := make([][]int, len(ws.SheetData.Row))
+	for i, row := range ws.SheetData.Row {
+		cellCols[i] = make([]int, len(row.C))
+		for j, c := range row.C {
+			col, _, err := CellNameToCoordinates(c.R)
+			if err != nil {
+				return err
+			}
+			if cellCols[i][j] = col; c.V == "" && c.F == nil && c.IS == nil {
+				continue
+			}
+			lastCol, lastRow = max(lastCol, col), max(lastRow, row.R)
+		}
+	}
+	used := []int{1, 1, lastCol, lastRow}
+	trimmed, err := trimSheetRanges(ws, used)
+	if err != nil {
+		return err
+	}
+	rows := ws.SheetData.Row[:0]
+	for i, row := range ws.SheetData.Row {
+		if row.R > lastRow {
+			continue
+		}
+		cells := row.C[:0]
+		for j, c := range row.C {
+			if cellCols[i][j] <= lastCol {
+				cells = append(cells, c)
+			}
+		}
+		if len(cells) != len(row.C) {
+			row.Spans = ""
+		}
+		row.C = cells
+		rows = append(rows, row)
+	}
+	ws.SheetData.Row = rows
+	if ws.Cols != nil {
+		var cols []xlsxCol
+		for _, col := range ws.Cols.Col {
+			if col.Min > lastCol {
+				continue
+			}
+			col.Max = min(col.Max, lastCol)
+			cols = append(cols, col)
+		}
+		if ws.Cols.Col = cols; len(cols) == 0 {
+			ws.Cols = nil
+		}
+	}
+	f.applyTrimmedRanges(ws, sheet, trimmed)
+	ref := "A1"
+	if lastCol > 0 {
+		cell, _ := CoordinatesToCellName(lastCol, lastRow)
+		ref += ":" + cell
+	}
+	ws.Dimension = &xlsxDimension{Ref: ref}
+	return err
+}
+
+// trimmedRanges directly maps the clipped range references of the merged
+// cells, conditional formats, data validations, hyperlinks and auto filter of
+// the worksheet, an empty reference means the range will be removed.
+type trimmedRanges struct {
+	mergeCells, conditionalFormats, dataValidations, hyperlinks []string
+	autoFilter                                                  string
+}
+
+// trimSheetRanges provides a function to clip the range references of the
+// worksheet by given coordinates of the used range without changing the
+// worksheet, so that any invalid reference could be reported before the
+// worksheet be mutated.
+func trimSheetRanges(ws *xlsxWorksheet, used []int) (trimmedRanges, error) {
+	var (
+		trimmed trimmedRanges
+		err     error
+	)
+	if ws.MergeCells != nil {
+		trimmed.mergeCells = make([]string, len(ws.MergeCells.Cells))
+		for i, mc := range ws.MergeCells.Cells {
+			if trimmed.mergeCells[i], err = clipSqref(mc.Ref, used); err != nil {
+				return trimmed, err
+			}
+			if !strings.Contains(trimmed.mergeCells[i], ":") {
+				trimmed.mergeCells[i] = ""
+			}
+		}
+	}
+	trimmed.conditionalFormats = make([]string, len(ws.ConditionalFormatting))
+	for i, cf := range ws.ConditionalFormatting {
+		if trimmed.conditionalFormats[i], err = clipSqref(cf.SQRef, used); err != nil {
+			return trimmed, err
+		}
+	}
+	if ws.DataValidations != nil {
+		trimmed.dataValidations = make([]string, len(ws.DataValidations.DataValidation))
+		for i, dv := range ws.DataValidations.DataValidation {
+			if trimmed.dataValidations[i], err = clipSqref(dv.Sqref, used); err != nil {
+				return trimmed, err
+			}
+		}
+	}
+	if ws.Hyperlinks != nil {
+		trimmed.hyperlinks = make([]string, len(ws.Hyperlinks.Hyperlink))
+		for i, link := range ws.Hyperlinks.Hyperlink {
+			if trimmed.hyperlinks[i], err = clipSqref(link.Ref, used); err != nil {
+				return trimmed, err
+			}
+		}
+	}
+	if ws.AutoFilter != nil {
+		trimmed.autoFilter, err = clipSqref(ws.AutoFilter.Ref, used)
+	}
+	return trimmed, err
+}
+
+// applyTrimmedRanges provides a function to update the range references of
+// the worksheet by given clipped range references, the ranges which out of
+// the used range will be removed, and the relationships of the removed
+// hyperlinks will be deleted.
+func (f *File) applyTrimmedRanges(ws *xlsxWorksheet, sheet string, trimmed trimmedRanges) {
+	if ws.MergeCells != nil {
+		var mergeCells []*xlsxMergeCell
+		for i, mc := range ws.MergeCells.Cells {
+			if trimmed.mergeCells[i] != "" {
+				mc.Ref = trimmed.mergeCells[i]
+				mergeCells = append(mergeCells, mc)
+			}
+		}
+		ws.MergeCells.Cells, ws.MergeCells.Count = mergeCells, len(mergeCells)
+		if len(mergeCells) == 0 {
+			ws.MergeCells = nil
+		}
+	}
+	var conditionalFormats []*xlsxConditionalFormatting
+	for i, cf := range ws.ConditionalFormatting {
+		if trimmed.conditionalFormats[i] != "" {
+			cf.SQRef = trimmed.conditionalFormats[i]
+			conditionalFormats = append(conditionalFormats, cf)
+		}
+	}
+	ws.ConditionalFormatting = conditionalFormats
+	if ws.DataValidations != nil {
+		var dataValidations []*xlsxDataValidation
+		for i, dv := range ws.DataValidations.DataValidation {
+			if trimmed.dataValidations[i] != "" {
+				dv.Sqref = trimmed.dataValidations[i]
+				dataValidations = append(dataValidations, dv)
+			}
+		}
+		ws.DataValidations.DataValidation, ws.DataValidations.Count = dataValidations, len(dataValidations)
+		if len(dataValidations) == 0 {
+			ws.DataValidations = nil
+		}
+	}
+	if ws.Hyperlinks != nil {
+		var hyperlinks []xlsxHyperlink
+		for i, link := range ws.Hyperlinks.Hyperlink {
+			if trimmed.hyperlinks[i] == "" {
+				if link.RID != "" {
+					f.deleteSheetRelationships(sheet, link.RID)
+				}
+				continue
+			}
+			link.Ref = trimmed.hyperlinks[i]
+			hyperlinks = append(hyperlinks, link)
+		}
+		if ws.Hyperlinks.Hyperlink = hyperlinks; len(hyperlinks) == 0 {
+			ws.Hyperlinks = nil
+		}
+	}
+	if ws.AutoFilter != nil {
+		if ws.AutoFilter.Ref = trimmed.autoFilter; trimmed.autoFilter == "" {
+			ws.AutoFilter = nil
+		}
+		f.trimFilterDatabase(sheet, trimmed.autoFilter)
+	}
+}
+
+// trimFilterDatabase provides a function to update the hidden defined name of
+// the auto filter by given worksheet name and clipped range reference, the
+// defined name will be removed if the range reference is empty.
+func (f *File) trimFilterDatabase(sheet, ref string) {
+	wb, _ := f.workbookReader()
+	if wb == nil || wb.DefinedNames == nil {
+		return
+	}
+	sheetID, _ := f.GetSheetIndex(sheet)
+	definedNames := wb.DefinedNames.DefinedName[:0]
+	for _, definedName := range wb.DefinedNames.DefinedName {
+		localSheetID := 0
+		if definedName.LocalSheetID != nil {
+			localSheetID = *definedName.LocalSheetID
+		}
+		if definedName.Name == builtInDefinedNames[3] && localSheetID == sheetID && definedName.Hidden {
+			if ref == "" {
+				continue
+			}
+			if !strings.Contains(ref, ":") {
+				ref += ":" + ref
+			}
+			coordinates, _ := rangeRefToCoordinates(ref)
+			absRef, _ := coordinatesToRangeRef(coordinates, true)
+			definedName.Data = fmt.Sprintf("'%s'!%s", sheet, absRef)
+		}
+		definedNames = append(definedNames, definedName)
+	}
+	wb.DefinedNames.DefinedName = definedNames
+}
+
+// clipSqref provides a function to clip the space-separated cell or range
+// references by given coordinates of the used range, the references out of
+// the used range will be removed.
+func clipSqref(sqref string, used []int) (string, error) {
+	var refs []string
+	for _, ref := range strings.Fields(sqref) {
+		if !strings.Contains(ref, ":") {
+			ref += ":" + ref
+		}
+		coordinates, err := rangeRefToCoordinates(ref)
+		if err != nil {
+			return "", err
+		}
+		_ = sortCoordinates(coordinates)
+		if coordinates[0] > used[2] || coordinates[1] > used[3] {
+			continue
+		}
+		coordinates[2], coordinates[3] = min(coordinates[2], used[2]), min(coordinates[3], used[3])
+		if coordinates[0] == coordinates[2] && coordinates[1] == coordinates[3] {
+			cell, _ := CoordinatesToCellName(coordinates[0], coordinates[1])
+			refs = append(refs, cell)
+			continue
+		}
+		clipped, _ := coordinatesToRangeRef(coordinates)
+		refs = append(refs, clipped)
+	}
+	return strings.Join(refs, " "), nil
+}
+
+// AddTableOfContents provides a function to create or rewrite the table of
+// contents worksheet by given options. The table of contents contains one row
+// for each visible worksheet with a hyperlink to the cell A1 of the worksheet
+// and an optional description. The new table of contents worksheet will be
+// moved to the first position of the workbook, and the cells and hyperlinks of
+// an existing one will be cleared before writing the entries, so it can be
+// called again to update the table of contents after adding or removing
+// worksheets. The back links will be set in the given cell of each worksheet
+// if the BackLinkCell is specified, note that the back link will overwrite the
+// value of the cell, and the back link in the cell A1 will be used as the
+// description if the DescriptionFromFirstCell is enabled. For example,
+// generate a table of contents named Index with the back links in the cell
+// H1 of each worksheet:
+//
+//	err := f.AddTableOfContents(excelize.TOCOptions{
+//	    Sheet:        "Index",
+//	    Title:        "Table of Contents",
+//	    Descriptions: map[string]string{"Sales": "Monthly sales report"},
+//	    BackLinkCell: "H1",
+//	})
+func (f *File) AddTableOfContents(opts TOCOptions) error {
+	if opts.Sheet == "" {
+		opts.Sheet = "Contents"
+	}
+	if opts.BackLinkText == "" {
+		opts.BackLinkText = "Back to contents"
+	}
+	if err := checkSheetName(opts.Sheet); err != nil {
+		return err
+	}
+	if opts.BackLinkCell != "" {
+		if _, _, err := CellNameToCoordinates(opts.BackLinkCell); err != nil {
+			return err
+		}
+	}
+	var err error
+	if opts.LinkStyle == 0 {
+		if opts.LinkStyle, err = f.NewStyle(&Style{Font: &Font{Color: "0563C1", Underline: "single"}}); err != nil {
+			return err
+		}
+	}
+	sheets := f.GetSheetList()
+	toc := opts.Sheet
+	if idx := inStrSlice(sheets, toc, false); idx != -1 {
+		toc = sheets[idx]
+		if err = f.ClearSheet(toc, ClearContents|ClearHyperlinks); err != nil {
+			return err
+		}
+	} else {
+		if _, err = f.NewSheet(toc); err != nil {
+			return err
+		}
+		if len(sheets) > 0 {
+			if err = f.MoveSheet(toc, sheets[0]); err != nil {
+				return err
+			}
+		}
+	}
+	row := 1
+	if opts.Title != "" {
+		if err = f.setTOCCell(toc, "A1", opts.Title, opts.TitleStyle); err != nil {
+			return err
+		}
+		row++
+	}
+	for _, sheet := range sheets {
+		if sheet == toc {
+			continue
+		}
+		if visible, _ := f.GetSheetVisible(sheet); !visible {
+			continue
+		}
+		if name, _ := f.getSheetXMLPath(sheet); !strings.HasPrefix(name, "xl/worksheets/") {
+			continue
+		}
+		desc, ok := opts.Descriptions[sheet]
+		if !ok && opts.DescriptionFromFirstCell {
+			if desc, err = f.GetCellValue(sheet, "A1"); err != nil {
+				return err
+			}
+		}
+		cell, _ := CoordinatesToCellName(1, row)
+		if err = f.setTOCCell(toc, cell, sheet, opts.LinkStyle); err != nil {
+			return err
+		}
+		if err = f.SetCellHyperLink(toc, cell, escapeSheetName(sheet)+"!A1", "Location"); err != nil {
+			return err
+		}
+		if desc != "" {
+			cell, _ = CoordinatesToCellName(2, row)
+			if err = f.setTOCCell(toc, cell, desc, opts.DescriptionStyle); err != nil {
+				return err
+			}
+		}
+		if opts.BackLinkCell != "" {
+			if err = f.setTOCCell(sheet, opts.BackLinkCell, opts.BackLinkText, opts.LinkStyle); err != nil {
+				return err
+			}
+			if err = f.SetCellHyperLink(sheet, opts.BackLinkCell, escapeSheetName(toc)+"!A1", "Location"); err != nil {
+				return err
+			}
+		}
+		row++
+	}
+	return err
+}
+
+// setTOCCell provides a function to set the value and style of the cell in
+// the table of contents by given worksheet name, cell reference, cell value
+// and style ID.
+func (f *File) setTOCCell(sheet, cell, value string, styleID int) error {
+	if err := f.SetCellStr(sheet, cell, value); err != nil {
+		return err
+	}
+	return f.SetCellStyle(sheet, cell, cell, styleID)
+}
@@ -139,6 +139,84 @@ func TestPanes(t *testing.T) {
 	))
 }
 
+func TestSetSplitPanes(t *testing.T) {
+	f := NewFile()
+	// Test split panes on the top left border of the cell with default column
+	// widths and row heights
+	assert.NoError(t, f.SetSplitPanes("Sheet1", &SplitPaneOptions{Cell: "B2"}))
+	panes, err := f.GetPanes("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, Panes{Split: true, XSplit: 1650, YSplit: 600, TopLeftCell: "B2", ActivePane: "bottomRight"}, panes)
+	// Test split panes with the 64 pixels Excel default column width, the
+	// positions are the same as Excel writes for the same visual split
+	assert.NoError(t, f.SetColWidthPixels("Sheet1", "A", "A", 64))
+	assert.NoError(t, f.SetSplitPanes("Sheet1", &SplitPaneOptions{Cell: "B2"}))
+	panes, err = f.GetPanes("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, Panes{Split: true, XSplit: 1350, YSplit: 600, TopLeftCell: "B2", ActivePane: "bottomRight"}, panes)
+	// Test split panes with custom column widths and row heights
+	assert.NoError(t, f.SetColWidth("Sheet1", "A", "B", 20))
+	assert.NoError(t, f.SetRowHeight("Sheet1", 2, 30))
+	assert.NoError(t, f.SetSplitPanes("Sheet1", &SplitPaneOptions{Cell: "C3", TopLeftCell: "D5"}))
+	panes, err = f.GetPanes("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, Panes{Split: true, XSplit: 5190, YSplit: 1200, TopLeftCell: "D5", ActivePane: "bottomRight"}, panes)
+	// Test split panes vertically and horizontally only
+	assert.NoError(t, f.SetSplitPanes("Sheet1", &SplitPaneOptions{Cell: "B1"}))
+	panes, err = f.GetPanes("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, Panes{Split: true, XSplit: 2790, TopLeftCell: "B1", ActivePane: "topRight"}, panes)
+	assert.NoError(t, f.SetSplitPanes("Sheet1", &SplitPaneOptions{Cell: "A2"}))
+	panes, err = f.GetPanes("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, Panes{Split: true, YSplit: 600, TopLeftCell: "A2", ActivePane: "bottomLeft"}, panes)
+	// Test split panes with the row headings fits the last row number in the
+	// default font and the column headings in the default row height
+	f2 := NewFile()
+	assert.NoError(t, f2.RegisterFontMetrics("Calibri", FontMetrics{MaxDigitWidth: 7}))
+	assert.NoError(t, f2.SetColWidthPixels("Sheet1", "A", "A", 64))
+	assert.NoError(t, f2.SetCellValue("Sheet1", "A1000", 1))
+	assert.NoError(t, f2.SetSheetProps("Sheet1", &SheetPropsOptions{DefaultRowHeight: float64Ptr(20), CustomHeight: boolPtr(true)}))
+	assert.NoError(t, f2.SetSplitPanes("Sheet1", &SplitPaneOptions{Cell: "B2"}))
+	panes, err = f2.GetPanes("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, Panes{Split: true, XSplit: 1410, YSplit: 805, TopLeftCell: "B2", ActivePane: "bottomRight"}, panes)
+	assert.NoError(t, f2.Close())
+	// Test split panes by raw position
+	assert.NoError(t, f.SetSplitPanes("Sheet1", &SplitPaneOptions{
+		XSplit: 3270, YSplit: 1800, TopLeftCell: "N57", ActivePane: "bottomLeft",
+		Selection: []Selection{{SQRef: "J60", ActiveCell: "J60", Pane: "bottomLeft"}},
+	}))
+	panes, err = f.GetPanes("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, Panes{
+		Split: true, XSplit: 3270, YSplit: 1800, TopLeftCell: "N57", ActivePane: "bottomLeft",
+		Selection: []Selection{{SQRef: "J60", ActiveCell: "J60", Pane: "bottomLeft"}},
+	}, panes)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestSetSplitPanes.xlsx")))
+	// Test split panes with invalid options
+	for _, opts := range []*SplitPaneOptions{nil, {}, {Cell: "A1"}, {XSplit: -1, YSplit: 600}} {
+		assert.Equal(t, ErrParameterInvalid, f.SetSplitPanes("Sheet1", opts))
+	}
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), f.SetSplitPanes("Sheet1", &SplitPaneOptions{Cell: "A"}))
+	// Test split panes on not exists worksheet
+	assert.EqualError(t, f.SetSplitPanes("SheetN", &SplitPaneOptions{Cell: "B2"}), "sheet SheetN does not exist")
+	assert.EqualError(t, f.SetSplitPanes("SheetN", &SplitPaneOptions{XSplit: 1350}), "sheet SheetN does not exist")
+	// Test get panes with default pane state
+	f = NewFile()
+	ws, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	ws.SheetViews.SheetView[0].Pane = &xlsxPane{XSplit: 1350, State: "frozenSplit"}
+	panes, err = f.GetPanes("Sheet1")
+	assert.NoError(t, err)
+	assert.True(t, panes.Freeze)
+	assert.False(t, panes.Split)
+	ws.SheetViews.SheetView[0].Pane = &xlsxPane{XSplit: 1350}
+	panes, err = f.GetPanes("Sheet1")
+	assert.NoError(t, err)
+	assert.True(t, panes.Split)
+}
+
 func TestSearchSheet(t *testing.T) {
 	f, err := OpenFile(filepath.Join("test", "SharedStrings.xlsx"))
 	if !assert.NoError(t, err) {
@@ -346,6 +424,93 @@ func TestDefinedName(t *testing.T) {
 		"XML syntax error on line 1: invalid UTF-8")
 }
 
+func TestHiddenName(t *testing.T) {
+	f := NewFile()
+	_, err := f.NewSheet("Sheet2")
+	assert.NoError(t, err)
+	values := map[string]string{
+		"TemplateVersion": "2.1",
+		"SchemaID":        `say "hello", Sheet1!A1:B2`,
+		"Description":     "模板元数据 ✓ \"引号\"",
+		"_Empty":          "",
+	}
+	for name, value := range values {
+		assert.NoError(t, f.SetHiddenName(name, value))
+	}
+	// Test update the existing hidden name case-insensitively
+	assert.NoError(t, f.SetHiddenName("templateversion", "2.2"))
+	values["TemplateVersion"] = "2.2"
+	wb, err := f.workbookReader()
+	assert.NoError(t, err)
+	assert.Len(t, wb.DefinedNames.DefinedName, 4)
+	assert.Equal(t, xlsxDefinedName{Name: "SchemaID", Hidden: true, Data: `"say ""hello"", Sheet1!A1:B2"`}, wb.DefinedNames.DefinedName[wb.getWorkbookName("SchemaID")])
+	// Test the hidden names survive the worksheet operations
+	assert.NoError(t, f.InsertRows("Sheet1", 1, 2))
+	assert.NoError(t, f.InsertCols("Sheet1", "A", 2))
+	assert.NoError(t, f.RemoveRows("Sheet1", []int{1}))
+	assert.NoError(t, f.MoveCols("Sheet1", "A:B", "D"))
+	assert.NoError(t, f.SetSheetName("Sheet1", "Data"))
+	assert.NoError(t, f.DeleteSheet("Sheet2"))
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestHiddenName.xlsx")))
+	assert.NoError(t, f.Close())
+	f, err = OpenFile(filepath.Join("test", "TestHiddenName.xlsx"))
+	assert.NoError(t, err)
+	for name, value := range values {
+		val, ok, err := f.GetHiddenName(name)
+		assert.NoError(t, err)
+		assert.True(t, ok, name)
+		assert.Equal(t, value, val, name)
+	}
+	// Test get the hidden name which does not exist
+	val, ok, err := f.GetHiddenName("NoExist")
+	assert.NoError(t, err)
+	assert.False(t, ok)
+	assert.Empty(t, val)
+	// Test set and get the hidden name conflicts with the visible defined name
+	assert.NoError(t, f.SetDefinedName(&DefinedName{Name: "Amount", RefersTo: "Data!$A$1"}))
+	assert.Equal(t, ErrDefinedNameDuplicate, f.SetHiddenName("Amount", "1"))
+	_, ok, err = f.GetHiddenName("Amount")
+	assert.NoError(t, err)
+	assert.False(t, ok)
+	assert.Equal(t, ErrDefinedNameScope, f.DeleteHiddenName("Amount"))
+	// Test get the hidden name which not refers to a string constant
+	wb, err = f.workbookReader()
+	assert.NoError(t, err)
+	wb.DefinedNames.DefinedName = append(wb.DefinedNames.DefinedName, xlsxDefinedName{Name: "Range", Hidden: true, Data: `"a"&Data!$A$1`})
+	_, ok, err = f.GetHiddenName("Range")
+	assert.NoError(t, err)
+	assert.False(t, ok)
+	// Test set the hidden name with invalid names
+	for _, name := range []string{"", "1st", "A1", "xfd1048576", "R", "c", "R1C1", "r2", "C10", "_xlnm.Print_Area", "has space", strings.Repeat("a", MaxFieldLength+1)} {
+		assert.Error(t, f.SetHiddenName(name, "value"), name)
+	}
+	assert.NoError(t, f.SetHiddenName("RC_1", "value"))
+	// Test set the hidden name with the value exceeds the length limit
+	assert.Equal(t, ErrDefinedNameValueLength, f.SetHiddenName("Long", strings.Repeat("值", MaxFieldLength+1)))
+	assert.NoError(t, f.SetHiddenName("Long", strings.Repeat("值", MaxFieldLength)))
+	// Test delete the hidden names
+	for _, name := range []string{"TemplateVersion", "SchemaID", "Description", "_Empty", "Range", "RC_1", "Long"} {
+		assert.NoError(t, f.DeleteHiddenName(name))
+	}
+	assert.Equal(t, ErrDefinedNameScope, f.DeleteHiddenName("TemplateVersion"))
+	assert.NoError(t, f.DeleteDefinedName(&DefinedName{Name: "Amount"}))
+	assert.NoError(t, f.SetHiddenName("TemplateVersion", "3"))
+	assert.NoError(t, f.DeleteHiddenName("TemplateVersion"))
+	assert.Nil(t, wb.DefinedNames)
+	// Test set, get and delete the hidden name with unsupported charset workbook
+	f.WorkBook = nil
+	f.Pkg.Store(defaultXMLPathWorkbook, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.SetHiddenName("TemplateVersion", "3"), "XML syntax error on line 1: invalid UTF-8")
+	f.WorkBook = nil
+	f.Pkg.Store(defaultXMLPathWorkbook, MacintoshCyrillicCharset)
+	_, _, err = f.GetHiddenName("TemplateVersion")
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	f.WorkBook = nil
+	f.Pkg.Store(defaultXMLPathWorkbook, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.DeleteHiddenName("TemplateVersion"), "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
+
 func TestGroupSheets(t *testing.T) {
 	f := NewFile()
 	sheets := []string{"Sheet2", "Sheet3"}
@@ -413,6 +578,110 @@ func TestRemovePageBreak(t *testing.T) {
 	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestRemovePageBreak.xlsx")))
 }
 
+func TestPrintLayout(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetPageLayout("Sheet1", &PageLayoutOptions{
+		Size: intPtr(9), Orientation: stringPtr("landscape"), FitToWidth: intPtr(1), FitToHeight: intPtr(0),
+	}))
+	assert.NoError(t, f.SetPageMargins("Sheet1", &PageLayoutMarginsOptions{Top: float64Ptr(1), Horizontally: boolPtr(true)}))
+	assert.NoError(t, f.SetHeaderFooter("Sheet1", &HeaderFooterOptions{OddHeader: "&L&G&CPage &P of &N"}))
+	assert.NoError(t, f.SetDefinedName(&DefinedName{Name: "_xlnm.Print_Area", RefersTo: "Sheet1!$A$1:$F$30", Scope: "Sheet1"}))
+	assert.NoError(t, f.SetDefinedName(&DefinedName{Name: "_xlnm.Print_Titles", RefersTo: "Sheet1!$1:$1", Scope: "Sheet1"}))
+	assert.NoError(t, f.InsertPageBreak("Sheet1", "A11"))
+	assert.NoError(t, f.InsertPageBreak("Sheet1", "D1"))
+	assert.NoError(t, f.SetCellValue("Sheet1", "F30", "end"))
+	assert.NoError(t, f.SetRowVisible("Sheet1", 5, false))
+	assert.NoError(t, f.SetColVisible("Sheet1", "E", false))
+	assert.NoError(t, f.SetColWidth("Sheet1", "B", "C", 20))
+	assert.NoError(t, f.SetColWidth("Sheet1", "D", "D", 20.33))
+	assert.NoError(t, f.SetRowHeight("Sheet1", 2, 30))
+	img, err := os.ReadFile(filepath.Join("test", "images", "excel.png"))
+	assert.NoError(t, err)
+	assert.NoError(t, f.AddHeaderFooterImage("Sheet1", &HeaderFooterImageOptions{
+		Position: HeaderFooterImagePositionLeft, File: img, Extension: ".png", Width: "50pt", Height: "32pt",
+	}))
+	assert.NoError(t, f.AddHeaderFooterImage("Sheet1", &HeaderFooterImageOptions{
+		Position: HeaderFooterImagePositionRight, File: img, IsFooter: true, FirstPage: true,
+		Extension: ".png", Width: "25pt", Height: "16pt",
+	}))
+
+	layout, err := f.GetPrintLayout("Sheet1")
+	assert.NoError(t, err)
+	// Test the aggregate matches the individual getters
+	pageLayout, err := f.GetPageLayout("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, pageLayout, layout.PageLayout)
+	margins, err := f.GetPageMargins("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, margins, layout.PageMargins)
+	headerFooter, err := f.GetHeaderFooter("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, headerFooter, layout.HeaderFooter)
+	assert.Equal(t, "Sheet1!$A$1:$F$30", layout.PrintArea)
+	assert.Equal(t, "Sheet1!$1:$1", layout.PrintTitles)
+	assert.Equal(t, []int{10}, layout.RowBreaks)
+	assert.Equal(t, []int{3}, layout.ColBreaks)
+	assert.Equal(t, []int{5}, layout.HiddenRows)
+	assert.Equal(t, []string{"E"}, layout.HiddenCols)
+	assert.Len(t, layout.ColWidths, 6)
+	assert.Len(t, layout.RowHeights, 30)
+	for col, width := range layout.ColWidths {
+		expected, err := f.GetColWidth("Sheet1", col)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, width)
+	}
+	for row, pts := range layout.RowHeights {
+		height, err := f.GetRowHeight("Sheet1", row)
+		assert.NoError(t, err)
+		assert.Equal(t, height, pts)
+	}
+	assert.Equal(t, 20.0, layout.ColWidths["B"])
+	assert.Equal(t, 20.33, layout.ColWidths["D"])
+	assert.Equal(t, 30.0, layout.RowHeights[2])
+	assert.Equal(t, defaultColWidth, layout.DefaultColWidth)
+	assert.Equal(t, []HeaderFooterImageOptions{
+		{Position: HeaderFooterImagePositionLeft, File: img, Extension: ".png", Width: "50pt", Height: "32pt"},
+		{Position: HeaderFooterImagePositionRight, File: img, IsFooter: true, FirstPage: true, Extension: ".png", Width: "25pt", Height: "16pt"},
+	}, layout.HeaderFooterImages)
+	assert.Equal(t, defaultRowHeight, layout.DefaultRowHeight)
+
+	// Test round trip the print layout to another workbook
+	f2 := NewFile()
+	assert.NoError(t, f2.SetRowVisible("Sheet1", 3, false))
+	assert.NoError(t, f2.SetColVisible("Sheet1", "A", false))
+	assert.NoError(t, f2.InsertPageBreak("Sheet1", "A3"))
+	assert.NoError(t, f2.SetPrintLayout("Sheet1", layout))
+	assert.NoError(t, f2.SetCellValue("Sheet1", "F30", "end"))
+	layout2, err := f2.GetPrintLayout("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, layout, layout2)
+	assert.NoError(t, f2.SaveAs(filepath.Join("test", "TestPrintLayout.xlsx")))
+	// Test get print layout with header and footer images on opened workbook
+	f3, err := OpenFile(filepath.Join("test", "TestPrintLayout.xlsx"))
+	assert.NoError(t, err)
+	layout3, err := f3.GetPrintLayout("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, layout.HeaderFooterImages, layout3.HeaderFooterImages)
+	assert.NoError(t, f3.Close())
+
+	// Test set print layout with nil options
+	assert.Equal(t, ErrParameterInvalid, f.SetPrintLayout("Sheet1", nil))
+	// Test get and set print layout on not exists worksheet
+	_, err = f.GetPrintLayout("SheetN")
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+	assert.EqualError(t, f.SetPrintLayout("SheetN", layout), "sheet SheetN does not exist")
+	// Test set print layout with invalid settings
+	layout.RowBreaks = []int{-2}
+	assert.Equal(t, newCoordinatesToCellNameError(1, -1), f.SetPrintLayout("Sheet1", layout))
+	layout.ColBreaks, layout.RowBreaks = []int{-2}, nil
+	assert.Equal(t, newCoordinatesToCellNameError(-1, 1), f.SetPrintLayout("Sheet1", layout))
+	layout.HeaderFooterImages[0].Extension = ".txt"
+	layout.ColBreaks = nil
+	assert.Equal(t, ErrImgExt, f.SetPrintLayout("Sheet1", layout))
+	layout.PageLayout.Orientation = stringPtr("diagonal")
+	assert.Error(t, f.SetPrintLayout("Sheet1", layout))
+}
+
 func TestGetSheetName(t *testing.T) {
 	f, err := OpenFile(filepath.Join("test", "Book1.xlsx"))
 	assert.NoError(t, err)
@@ -506,6 +775,95 @@ func TestSetSheetName(t *testing.T) {
 	for i, expected := range []string{"'Sheet 2'!$A$1:$A$2", "$B$2", "$A1$2:A2", "'Sheet 2'!$A$1:'Sheet 2'!A1:'Sheet 2'!$A$1,'Sheet 2'!A1:Sheet3!A1,Sheet3!A1", "'Sheet 3'!$A1$2:A2"} {
 		assert.Equal(t, expected, f.WorkBook.DefinedNames.DefinedName[i].Data)
 	}
+	assert.Equal(t, []string{"Sheet 2", "Sheet 3"}, f.GetSheetList())
+
+	// Test set worksheet name with case-insensitive source name
+	f = NewFile()
+	assert.NoError(t, f.SetCellValue("Sheet1", "A1", "A1"))
+	assert.NoError(t, f.SetSheetName("sheet1", "Data"))
+	assert.Equal(t, []string{"Data"}, f.GetSheetList())
+	for _, sheet := range []string{"Data", "DATA"} {
+		val, err := f.GetCellValue(sheet, "A1")
+		assert.NoError(t, err)
+		assert.Equal(t, "A1", val)
+		idx, err := f.GetSheetIndex(sheet)
+		assert.NoError(t, err)
+		assert.Equal(t, 0, idx)
+	}
+	_, err = f.GetCellValue("Sheet1", "A1")
+	assert.Equal(t, ErrSheetNotExist{"Sheet1"}, err)
+	// Test set worksheet name with not exists source worksheet
+	assert.Equal(t, ErrSheetNotExist{"Sheet1"}, f.SetSheetName("Sheet1", "Sheet2"))
+	// Test set worksheet name with the name of another worksheet
+	_, err = f.NewSheet("Sheet2")
+	assert.NoError(t, err)
+	assert.Equal(t, ErrExistsSheet, f.SetSheetName("Data", "sheet2"))
+	assert.Equal(t, []string{"Data", "Sheet2"}, f.GetSheetList())
+	// Test delete worksheet after renamed with case-insensitive name
+	assert.NoError(t, f.DeleteSheet("data"))
+	assert.Equal(t, []string{"Sheet2"}, f.GetSheetList())
+	_, ok := f.getSheetXMLPath("Data")
+	assert.False(t, ok)
+	// Test stream writer with case-insensitive worksheet name
+	sw, err := f.NewStreamWriter("sheet2")
+	assert.NoError(t, err)
+	assert.NoError(t, sw.SetRow("A1", []interface{}{"A1", "B1"}))
+	assert.NoError(t, sw.AddTable(&Table{Range: "A1:B2"}))
+	assert.NoError(t, sw.Flush())
+	assert.NoError(t, f.Close())
+	// Test set worksheet name with unsupported charset workbook
+	f = NewFile()
+	f.WorkBook = nil
+	f.Pkg.Store(defaultXMLPathWorkbook, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.SetSheetName("Sheet1", "Sheet2"), "XML syntax error on line 1: invalid UTF-8")
+}
+
+func TestSetSheetNameConcurrency(t *testing.T) {
+	f := NewFile()
+	_, err := f.NewSheet("Sheet2")
+	assert.NoError(t, err)
+	for row := 1; row <= 10; row++ {
+		for _, sheet := range []string{"Sheet1", "Sheet2"} {
+			assert.NoError(t, f.SetCellValue(sheet, fmt.Sprintf("A%d", row), row))
+		}
+	}
+	var wg sync.WaitGroup
+	names := []string{"Sheet1", "Data"}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 200; i++ {
+			assert.NoError(t, f.SetSheetName(strings.ToLower(names[i%2]), names[(i+1)%2]))
+		}
+	}()
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				rows, err := f.GetRows("Sheet2")
+				assert.NoError(t, err)
+				assert.Len(t, rows, 10)
+				for _, sheet := range []string{"sheet1", "DATA"} {
+					val, err := f.GetCellValue(sheet, "A5")
+					if err != nil {
+						assert.Equal(t, ErrSheetNotExist{sheet}, err)
+						continue
+					}
+					assert.Equal(t, "5", val)
+				}
+				sheets := f.GetSheetList()
+				assert.Len(t, sheets, 2)
+				assert.Contains(t, names, sheets[0])
+			}
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, []string{"Sheet1", "Sheet2"}, f.GetSheetList())
+	val, err := f.GetCellValue("Sheet1", "A10")
+	assert.NoError(t, err)
+	assert.Equal(t, "10", val)
+	assert.NoError(t, f.Close())
 }
 
 func TestWorksheetWriter(t *testing.T) {
@@ -601,6 +959,38 @@ func TestMoveSheet(t *testing.T) {
 	assert.EqualError(t, f.MoveSheet("Sheet2", "Sheet1"), "XML syntax error on line 1: invalid UTF-8")
 }
 
+func TestMoveSheetConcurrency(t *testing.T) {
+	f := NewFile()
+	names := []string{"Sheet1", "Sheet2", "Sheet3", "Sheet4", "Sheet5"}
+	for _, name := range names[1:] {
+		_, err := f.NewSheet(name)
+		assert.NoError(t, err)
+	}
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 200; i++ {
+			assert.NoError(t, f.MoveSheet(names[i%5], names[(i+2)%5]))
+		}
+	}()
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 200; j++ {
+				idx, err := f.GetSheetIndex("Sheet3")
+				assert.NoError(t, err)
+				assert.NotEqual(t, -1, idx)
+				assert.ElementsMatch(t, names, f.GetSheetList())
+			}
+		}()
+	}
+	wg.Wait()
+	assert.ElementsMatch(t, names, f.GetSheetList())
+	assert.NoError(t, f.Close())
+}
+
 func TestDeleteAndAdjustDefinedNames(t *testing.T) {
 	deleteAndAdjustDefinedNames(nil, 0)
 	deleteAndAdjustDefinedNames(&xlsxWorkbook{}, 0)
@@ -828,6 +1218,59 @@ func TestSheetDimension(t *testing.T) {
 	assert.EqualError(t, err, "sheet SheetN does not exist")
 }
 
+func TestUpdateSheetDimension(t *testing.T) {
+	f := NewFile()
+	// Test update the dimension of an empty worksheet
+	assert.NoError(t, f.SetSheetDimension("Sheet1", "A1:D5"))
+	assert.NoError(t, f.UpdateSheetDimension("Sheet1"))
+	dimension, err := f.GetSheetDimension("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, "A1", dimension)
+	// Test update the dimension after removing the right-most populated column
+	for cell, val := range map[string]interface{}{"B2": 1, "C3": "x", "E6": true} {
+		assert.NoError(t, f.SetCellValue("Sheet1", cell, val))
+	}
+	assert.NoError(t, f.UpdateSheetDimension("Sheet1"))
+	dimension, err = f.GetSheetDimension("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, "B2:E6", dimension)
+	assert.NoError(t, f.RemoveCol("Sheet1", "E"))
+	dimension, err = f.GetSheetDimension("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, "B2:C3", dimension)
+	// Test update the dimension after inserting and removing rows and columns
+	assert.NoError(t, f.InsertCols("Sheet1", "A", 2))
+	dimension, err = f.GetSheetDimension("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, "D2:E3", dimension)
+	assert.NoError(t, f.RemoveRows("Sheet1", []int{2}))
+	dimension, err = f.GetSheetDimension("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, "E2", dimension)
+	// Test update the dimension by the styled cells
+	style, err := f.NewStyle(&Style{Font: &Font{Bold: true}})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellStyle("Sheet1", "A1", "A1", style))
+	assert.NoError(t, f.UpdateSheetDimension("Sheet1"))
+	dimension, err = f.GetSheetDimension("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, "A1:E2", dimension)
+	// Test keep the worksheet without dimension after removing columns
+	assert.NoError(t, f.SetSheetDimension("Sheet1", ""))
+	assert.NoError(t, f.RemoveCol("Sheet1", "A"))
+	dimension, err = f.GetSheetDimension("Sheet1")
+	assert.NoError(t, err)
+	assert.Empty(t, dimension)
+	// Test update the dimension with invalid cell reference
+	ws, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	ws.SheetData.Row[0].C = append(ws.SheetData.Row[0].C, xlsxC{R: "A", V: "1"})
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), f.UpdateSheetDimension("Sheet1"))
+	// Test update the dimension on not exists worksheet
+	assert.EqualError(t, f.UpdateSheetDimension("SheetN"), "sheet SheetN does not exist")
+	assert.NoError(t, f.Close())
+}
+
 func TestAddIgnoredErrors(t *testing.T) {
 	f := NewFile()
 	assert.NoError(t, f.AddIgnoredErrors("Sheet1", "A1", IgnoredErrorsEvalError))
@@ -847,3 +1290,555 @@ func TestAddIgnoredErrors(t *testing.T) {
 	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAddIgnoredErrors.xlsx")))
 	assert.NoError(t, f.Close())
 }
+
+func TestMergeExpandedCols(t *testing.T) {
+	f := NewFile()
+	styleID, err := f.NewStyle(&Style{Font: &Font{Bold: true}})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetColStyle("Sheet1", "A:XFD", styleID))
+	buf, err := f.WriteToBuffer()
+	assert.NoError(t, err)
+	assert.NoError(t, f.Close())
+
+	f, err = OpenReader(buf)
+	assert.NoError(t, err)
+	ws, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, []xlsxCol{{Min: 1, Max: MaxColumns, Style: styleID, Width: float64Ptr(defaultColWidth)}}, ws.Cols.Col)
+
+	// Test merge columns after overlapping column operations
+	assert.NoError(t, f.SetColVisible("Sheet1", "C:E", false))
+	assert.NoError(t, f.SetColOutlineLevel("Sheet1", "D", 2))
+	assert.NoError(t, f.SetColWidth("Sheet1", "B", "F", 20))
+	assert.NoError(t, f.SetColVisible("Sheet1", "D", false))
+	expected := make(map[string][]interface{})
+	for _, col := range []string{"A", "B", "C", "D", "E", "F", "G", "XFD"} {
+		width, err := f.GetColWidth("Sheet1", col)
+		assert.NoError(t, err)
+		visible, err := f.GetColVisible("Sheet1", col)
+		assert.NoError(t, err)
+		style, err := f.GetColStyle("Sheet1", col)
+		assert.NoError(t, err)
+		level, err := f.GetColOutlineLevel("Sheet1", col)
+		assert.NoError(t, err)
+		expected[col] = []interface{}{width, visible, style, level}
+	}
+	buf, err = f.WriteToBuffer()
+	assert.NoError(t, err)
+	assert.NoError(t, f.Close())
+
+	f, err = OpenReader(buf)
+	assert.NoError(t, err)
+	ws, err = f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, ws.Cols.Col, 7)
+	for col, values := range expected {
+		width, err := f.GetColWidth("Sheet1", col)
+		assert.NoError(t, err)
+		visible, err := f.GetColVisible("Sheet1", col)
+		assert.NoError(t, err)
+		style, err := f.GetColStyle("Sheet1", col)
+		assert.NoError(t, err)
+		level, err := f.GetColOutlineLevel("Sheet1", col)
+		assert.NoError(t, err)
+		assert.Equal(t, values, []interface{}{width, visible, style, level}, col)
+	}
+	assert.NoError(t, f.Close())
+}
+
+func TestClearRange(t *testing.T) {
+	prepare := func() (*File, int) {
+		f := NewFile()
+		style, err := f.NewStyle(&Style{Font: &Font{Bold: true}})
+		assert.NoError(t, err)
+		for _, cell := range []string{"A1", "B2", "C3", "D4"} {
+			assert.NoError(t, f.SetCellValue("Sheet1", cell, cell))
+		}
+		assert.NoError(t, f.SetCellStyle("Sheet1", "A1", "D4", style))
+		assert.NoError(t, f.SetCellFormula("Sheet1", "C2", "SUM(A1:B1)"))
+		assert.NoError(t, f.MergeCell("Sheet1", "B3", "B5"))
+		assert.NoError(t, f.SetCellHyperLink("Sheet1", "B2", "https://github.com/xuri/excelize", "External"))
+		assert.NoError(t, f.SetCellHyperLink("Sheet1", "D4", "Sheet1!A1", "Location"))
+		assert.NoError(t, f.AddComment("Sheet1", Comment{Cell: "B2", Author: "Excelize", Text: "B2"}))
+		assert.NoError(t, f.AddComment("Sheet1", Comment{Cell: "D4", Author: "Excelize", Text: "D4"}))
+		dv := NewDataValidation(true)
+		dv.SetSqref("A1:D4")
+		assert.NoError(t, dv.SetRange(1, 10, DataValidationTypeWhole, DataValidationOperatorBetween))
+		assert.NoError(t, f.AddDataValidation("Sheet1", dv))
+		return f, style
+	}
+	check := func(f *File, style int, what ClearFlags) {
+		for cell, expected := range map[string]string{"A1": "A1", "B2": "", "C3": "", "D4": "D4"} {
+			if what&ClearContents == 0 && expected == "" {
+				expected = cell
+			}
+			val, err := f.GetCellValue("Sheet1", cell)
+			assert.NoError(t, err)
+			assert.Equal(t, expected, val, cell)
+		}
+		formula, err := f.GetCellFormula("Sheet1", "C2")
+		assert.NoError(t, err)
+		assert.Equal(t, what&ClearContents == 0, formula != "")
+		for cell, expected := range map[string]int{"A1": style, "B2": 0, "C3": 0, "D4": style} {
+			if what&ClearFormats == 0 {
+				expected = style
+			}
+			styleID, err := f.GetCellStyle("Sheet1", cell)
+			assert.NoError(t, err)
+			assert.Equal(t, expected, styleID, cell)
+		}
+		mergeCells, err := f.GetMergeCells("Sheet1")
+		assert.NoError(t, err)
+		assert.Equal(t, what&ClearFormats == 0, len(mergeCells) == 1)
+		link, _, err := f.GetCellHyperLink("Sheet1", "B2")
+		assert.NoError(t, err)
+		assert.Equal(t, what&ClearHyperlinks == 0, link)
+		link, _, err = f.GetCellHyperLink("Sheet1", "D4")
+		assert.NoError(t, err)
+		assert.True(t, link)
+		comments, err := f.GetComments("Sheet1")
+		assert.NoError(t, err)
+		assert.Equal(t, what&ClearComments == 0, len(comments) == 2)
+		dvs, err := f.GetDataValidations("Sheet1")
+		assert.NoError(t, err)
+		assert.Len(t, dvs, 1)
+		assert.Equal(t, what&ClearValidations == 0, dvs[0].Sqref == "A1:D4")
+	}
+	// Test clear range with each flag combination
+	for what := ClearContents; what <= ClearAll; what++ {
+		f, style := prepare()
+		assert.NoError(t, f.ClearRange("Sheet1", "C3:B2", what))
+		check(f, style, what)
+		assert.NoError(t, f.Close())
+	}
+	// Test clear contents and formats of the cell
+	f, _ := prepare()
+	assert.NoError(t, f.ClearRange("Sheet1", "A1", ClearContents|ClearFormats))
+	ws, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, xlsxC{R: "A1"}, ws.SheetData.Row[0].C[0])
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestClearRange.xlsx")))
+	// Test clear range with invalid flags
+	for _, what := range []ClearFlags{0, ClearAll + 1} {
+		assert.Equal(t, ErrParameterInvalid, f.ClearRange("Sheet1", "A1:B2", what))
+	}
+	// Test clear range with invalid range reference
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), f.ClearRange("Sheet1", "A:B2", ClearAll))
+	// Test clear range on not exists worksheet
+	assert.EqualError(t, f.ClearRange("SheetN", "A1:B2", ClearAll), "sheet SheetN does not exist")
+	// Test clear range with invalid cell reference
+	ws, err = f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	ws.SheetData.Row[1].C[0].R = "A"
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), f.ClearRange("Sheet1", "A1:B2", ClearContents))
+	ws.SheetData.Row[1].C[0].R = "A2"
+	ws.Hyperlinks.Hyperlink[0].Ref = "B"
+	assert.Equal(t, newCellNameToCoordinatesError("B", newInvalidCellNameError("B")), f.ClearRange("Sheet1", "A1:B2", ClearHyperlinks))
+	ws.MergeCells = &xlsxMergeCells{Cells: []*xlsxMergeCell{{Ref: "A:A"}}}
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), f.ClearRange("Sheet1", "A1:B2", ClearFormats))
+	// Test clear comments with unsupported charset comments
+	f, _ = prepare()
+	f.Comments["xl/comments1.xml"] = nil
+	f.Pkg.Store("xl/comments1.xml", MacintoshCyrillicCharset)
+	assert.EqualError(t, f.ClearRange("Sheet1", "A1:B2", ClearComments), "XML syntax error on line 1: invalid UTF-8")
+	// Test clear comments with invalid cell reference
+	f, _ = prepare()
+	cmts, err := f.commentsReader("xl/comments1.xml")
+	assert.NoError(t, err)
+	cmts.CommentList.Comment[0].Ref = "A"
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), f.ClearRange("Sheet1", "A1:B2", ClearComments))
+	// Test clear contents with unsupported charset calculation chain
+	f, _ = prepare()
+	f.CalcChain = nil
+	f.Pkg.Store(defaultXMLPathCalcChain, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.ClearRange("Sheet1", "C2", ClearContents), "XML syntax error on line 1: invalid UTF-8")
+}
+
+func TestClearSheet(t *testing.T) {
+	prepare := func() *File {
+		f := NewFile()
+		style, err := f.NewStyle(&Style{Font: &Font{Bold: true}})
+		assert.NoError(t, err)
+		assert.NoError(t, f.SetSheetRow("Sheet1", "A1", &[]interface{}{"A1", 2, true}))
+		assert.NoError(t, f.SetCellFormula("Sheet1", "D1", "B1*2"))
+		assert.NoError(t, f.SetCellStyle("Sheet1", "A1", "D1", style))
+		assert.NoError(t, f.SetRowHeight("Sheet1", 1, 30))
+		assert.NoError(t, f.SetColWidth("Sheet1", "A", "A", 20))
+		assert.NoError(t, f.MergeCell("Sheet1", "A3", "B3"))
+		assert.NoError(t, f.SetPageLayout("Sheet1", &PageLayoutOptions{Orientation: stringPtr("landscape")}))
+		assert.NoError(t, f.SetCellHyperLink("Sheet1", "A1", "https://github.com/xuri/excelize", "External"))
+		assert.NoError(t, f.AddComment("Sheet1", Comment{Cell: "A1", Author: "Excelize", Text: "A1"}))
+		dv := NewDataValidation(true)
+		dv.SetSqref("A1:D4")
+		assert.NoError(t, dv.SetRange(1, 10, DataValidationTypeWhole, DataValidationOperatorBetween))
+		assert.NoError(t, f.AddDataValidation("Sheet1", dv))
+		return f
+	}
+	// Test clear sheet with each flag combination
+	for what := ClearContents; what <= ClearAll; what++ {
+		f := prepare()
+		assert.NoError(t, f.ClearSheet("Sheet1", what))
+		val, err := f.GetCellValue("Sheet1", "A1")
+		assert.NoError(t, err)
+		assert.Equal(t, what&ClearContents == 0, val == "A1")
+		formula, err := f.GetCellFormula("Sheet1", "D1")
+		assert.NoError(t, err)
+		assert.Equal(t, what&ClearContents == 0, formula == "B1*2")
+		styleID, err := f.GetCellStyle("Sheet1", "A1")
+		assert.NoError(t, err)
+		assert.Equal(t, what&ClearFormats == 0, styleID != 0)
+		height, err := f.GetRowHeight("Sheet1", 1)
+		assert.NoError(t, err)
+		assert.Equal(t, what&ClearFormats == 0, height == 30)
+		width, err := f.GetColWidth("Sheet1", "A")
+		assert.NoError(t, err)
+		assert.Equal(t, what&ClearFormats == 0, width == 20)
+		mergeCells, err := f.GetMergeCells("Sheet1")
+		assert.NoError(t, err)
+		assert.Equal(t, what&ClearFormats == 0, len(mergeCells) == 1)
+		layout, err := f.GetPageLayout("Sheet1")
+		assert.NoError(t, err)
+		assert.Equal(t, what&ClearFormats == 0, *layout.Orientation == "landscape")
+		link, _, err := f.GetCellHyperLink("Sheet1", "A1")
+		assert.NoError(t, err)
+		assert.Equal(t, what&ClearHyperlinks == 0, link)
+		comments, err := f.GetComments("Sheet1")
+		assert.NoError(t, err)
+		assert.Equal(t, what&ClearComments == 0, len(comments) == 1)
+		dvs, err := f.GetDataValidations("Sheet1")
+		assert.NoError(t, err)
+		assert.Equal(t, what&ClearValidations == 0, len(dvs) == 1)
+		assert.NoError(t, f.Close())
+	}
+	f := prepare()
+	assert.NoError(t, f.ClearSheet("Sheet1", ClearAll))
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestClearSheet.xlsx")))
+	// Test clear sheet with invalid flags
+	for _, what := range []ClearFlags{0, ClearAll + 1} {
+		assert.Equal(t, ErrParameterInvalid, f.ClearSheet("Sheet1", what))
+	}
+	// Test clear sheet on not exists worksheet
+	assert.EqualError(t, f.ClearSheet("SheetN", ClearAll), "sheet SheetN does not exist")
+	// Test clear sheet with unsupported charset calculation chain
+	f = prepare()
+	f.CalcChain = nil
+	f.Pkg.Store(defaultXMLPathCalcChain, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.ClearSheet("Sheet1", ClearAll), "XML syntax error on line 1: invalid UTF-8")
+	// Test clear sheet with unsupported charset comments
+	f = prepare()
+	f.Comments["xl/comments1.xml"] = nil
+	f.Pkg.Store("xl/comments1.xml", MacintoshCyrillicCharset)
+	assert.EqualError(t, f.ClearSheet("Sheet1", ClearComments), "XML syntax error on line 1: invalid UTF-8")
+	// Test clear sheet with page setup relationship
+	f = prepare()
+	ws, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	ws.PageSetUp.RID = "rId1"
+	assert.NoError(t, f.ClearSheet("Sheet1", ClearFormats))
+	assert.Nil(t, ws.PageSetUp)
+	// Test clear sheet resets the cached shared formulas
+	for _, what := range []ClearFlags{ClearContents, ClearAll} {
+		f = NewFile()
+		formulaType, ref := STCellFormulaTypeShared, "A1:A3"
+		assert.NoError(t, f.SetCellFormula("Sheet1", "A1", "B1*2", FormulaOpts{Type: &formulaType, Ref: &ref}))
+		formula, err := f.GetCellFormula("Sheet1", "A2")
+		assert.NoError(t, err)
+		assert.Equal(t, "B2*2", formula)
+		assert.NoError(t, f.ClearSheet("Sheet1", what))
+		ref = "C1:C3"
+		assert.NoError(t, f.SetCellFormula("Sheet1", "C1", "D1+1", FormulaOpts{Type: &formulaType, Ref: &ref}))
+		formula, err = f.GetCellFormula("Sheet1", "C2")
+		assert.NoError(t, err)
+		assert.Equal(t, "D2+1", formula)
+		assert.NoError(t, f.Close())
+	}
+}
+
+func TestTrimSheetToUsedRange(t *testing.T) {
+	f := NewFile()
+	style, err := f.NewStyle(&Style{Font: &Font{Bold: true}})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A1", &[]interface{}{"A1", 2}))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "C3", "A1&B1"))
+	assert.NoError(t, f.SetCellStyle("Sheet1", "A5", "F8", style))
+	assert.NoError(t, f.SetCellStyle("Sheet1", "A2", "E2", style))
+	assert.NoError(t, f.SetRowHeight("Sheet1", 10, 30))
+	assert.NoError(t, f.SetColWidth("Sheet1", "B", "H", 20))
+	assert.NoError(t, f.SetColWidth("Sheet1", "K", "K", 20))
+	assert.NoError(t, f.TrimSheetToUsedRange("Sheet1"))
+	dimension, err := f.GetSheetDimension("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, "A1:C3", dimension)
+	ws, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, ws.SheetData.Row, 3)
+	assert.Len(t, ws.SheetData.Row[1].C, 3)
+	assert.Equal(t, 3, ws.Cols.Col[len(ws.Cols.Col)-1].Max)
+	width, err := f.GetColWidth("Sheet1", "C")
+	assert.NoError(t, err)
+	assert.Equal(t, 20.0, width)
+	styleID, err := f.GetCellStyle("Sheet1", "C2")
+	assert.NoError(t, err)
+	assert.Equal(t, style, styleID)
+	formula, err := f.GetCellFormula("Sheet1", "C3")
+	assert.NoError(t, err)
+	assert.Equal(t, "A1&B1", formula)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestTrimSheetToUsedRange.xlsx")))
+	// Test trim the ranges which point past the used range
+	f = NewFile()
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A1", &[]interface{}{1, 2, 3}))
+	assert.NoError(t, f.SetCellValue("Sheet1", "C3", 4))
+	for _, ref := range [][]string{{"A2", "A3"}, {"B2", "E2"}, {"C3", "E4"}, {"E5", "F6"}} {
+		assert.NoError(t, f.MergeCell("Sheet1", ref[0], ref[1]))
+	}
+	format, err := f.NewConditionalStyle(&Style{Font: &Font{Bold: true}})
+	assert.NoError(t, err)
+	for _, ref := range []string{"A1:B2 D5:E6", "E5:F6"} {
+		assert.NoError(t, f.SetConditionalFormat("Sheet1", ref, []ConditionalFormatOptions{
+			{Type: "cell", Criteria: ">", Format: &format, Value: "1"},
+		}))
+	}
+	for _, ref := range []string{"B1:D4", "F1:F2"} {
+		dv := NewDataValidation(true)
+		dv.Sqref = ref
+		assert.NoError(t, dv.SetRange(1, 10, DataValidationTypeWhole, DataValidationOperatorBetween))
+		assert.NoError(t, f.AddDataValidation("Sheet1", dv))
+	}
+	assert.NoError(t, f.SetCellHyperLink("Sheet1", "A1", "https://github.com/xuri/excelize", "External"))
+	assert.NoError(t, f.SetCellHyperLink("Sheet1", "E9", "https://github.com/xuri/excelize", "External"))
+	assert.NoError(t, f.AutoFilter("Sheet1", "A1:E9", nil))
+	assert.NoError(t, f.TrimSheetToUsedRange("Sheet1"))
+	mergeCells, err := f.GetMergeCells("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, mergeCells, 2)
+	assert.Equal(t, "A2:A3", mergeCells[0].GetStartAxis()+":"+mergeCells[0].GetEndAxis())
+	assert.Equal(t, "B2:C2", mergeCells[1].GetStartAxis()+":"+mergeCells[1].GetEndAxis())
+	conditionalFormats, err := f.GetConditionalFormats("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, conditionalFormats, 1)
+	assert.Contains(t, conditionalFormats, "A1:B2")
+	dataValidations, err := f.GetDataValidations("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, dataValidations, 1)
+	assert.Equal(t, "B1:C3", dataValidations[0].Sqref)
+	ws, err = f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, ws.DataValidations.Count)
+	assert.Len(t, ws.Hyperlinks.Hyperlink, 1)
+	assert.Equal(t, "A1", ws.Hyperlinks.Hyperlink[0].Ref)
+	rels, err := f.relsReader("xl/worksheets/_rels/sheet1.xml.rels")
+	assert.NoError(t, err)
+	assert.Len(t, rels.Relationships, 1)
+	assert.Equal(t, "A1:C3", ws.AutoFilter.Ref)
+	definedNames := f.GetDefinedName()
+	assert.Len(t, definedNames, 1)
+	assert.Equal(t, "'Sheet1'!$A$1:$C$3", definedNames[0].RefersTo)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestTrimSheetToUsedRange.xlsx")))
+	// Test trim the auto filter entirely outside of the used range
+	assert.NoError(t, f.AutoFilter("Sheet1", "E5:F9", nil))
+	assert.NoError(t, f.TrimSheetToUsedRange("Sheet1"))
+	ws, err = f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	assert.Nil(t, ws.AutoFilter)
+	assert.Empty(t, f.GetDefinedName())
+	// Test trim worksheet with invalid range reference
+	ws.MergeCells = &xlsxMergeCells{Cells: []*xlsxMergeCell{{Ref: "A1:B2"}, {Ref: "A:B"}}}
+	ws.SheetData.Row = append(ws.SheetData.Row, xlsxRow{R: 5, C: []xlsxC{{R: "A5"}}})
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), f.TrimSheetToUsedRange("Sheet1"))
+	assert.Len(t, ws.SheetData.Row, 4)
+	for _, ws := range []*xlsxWorksheet{
+		{ConditionalFormatting: []*xlsxConditionalFormatting{{SQRef: "A1 B"}}},
+		{DataValidations: &xlsxDataValidations{DataValidation: []*xlsxDataValidation{{Sqref: "B"}}}},
+		{Hyperlinks: &xlsxHyperlinks{Hyperlink: []xlsxHyperlink{{Ref: "B"}}}},
+		{AutoFilter: &xlsxAutoFilter{Ref: "B"}},
+	} {
+		_, err = trimSheetRanges(ws, []int{1, 1, 3, 3})
+		assert.Equal(t, newCellNameToCoordinatesError("B", newInvalidCellNameError("B")), err)
+	}
+	// Test trim worksheet without any values
+	f = NewFile()
+	assert.NoError(t, f.SetRowHeight("Sheet1", 3, 30))
+	assert.NoError(t, f.SetColWidth("Sheet1", "A", "A", 20))
+	assert.NoError(t, f.TrimSheetToUsedRange("Sheet1"))
+	ws, err = f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	assert.Empty(t, ws.SheetData.Row)
+	assert.Nil(t, ws.Cols)
+	assert.Equal(t, "A1", ws.Dimension.Ref)
+	// Test trim not exists worksheet
+	assert.EqualError(t, f.TrimSheetToUsedRange("SheetN"), "sheet SheetN does not exist")
+	// Test trim worksheet with invalid cell reference
+	ws.SheetData.Row = []xlsxRow{{R: 1, C: []xlsxC{{R: "A", V: "1"}}}}
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), f.TrimSheetToUsedRange("Sheet1"))
+	ws.SheetData.Row = []xlsxRow{{R: 1, C: []xlsxC{{R: "A1", V: "1"}, {R: "A"}}}, {R: 2, C: []xlsxC{{R: "B2"}}}}
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), f.TrimSheetToUsedRange("Sheet1"))
+	assert.Equal(t, []xlsxRow{{R: 1, C: []xlsxC{{R: "A1", V: "1"}, {R: "A"}}}, {R: 2, C: []xlsxC{{R: "B2"}}}}, ws.SheetData.Row)
+}
+
+func TestFreezePanes(t *testing.T) {
+	f := NewFile()
+	// Test freeze panes by cell reference
+	assert.NoError(t, f.FreezePanes("Sheet1", "C3"))
+	panes, err := f.GetPanes("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, Panes{Freeze: true, XSplit: 2, YSplit: 2, TopLeftCell: "C3", ActivePane: "bottomRight"}, panes)
+	// Test freeze columns and rows
+	assert.NoError(t, f.FreezeCols("Sheet1", "B"))
+	panes, err = f.GetPanes("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, Panes{Freeze: true, XSplit: 2, TopLeftCell: "C1", ActivePane: "topRight"}, panes)
+	assert.NoError(t, f.FreezeRows("Sheet1", 1))
+	panes, err = f.GetPanes("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, Panes{Freeze: true, YSplit: 1, TopLeftCell: "A2", ActivePane: "bottomLeft"}, panes)
+	// Test freeze panes keeps the existing selections
+	assert.NoError(t, f.UnfreezePanes("Sheet1"))
+	assert.NoError(t, f.SetSelection("Sheet1", "D5:E6", "E6"))
+	assert.NoError(t, f.FreezePanes("Sheet1", "B2"))
+	panes, err = f.GetPanes("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, Panes{
+		Freeze: true, XSplit: 1, YSplit: 1, TopLeftCell: "B2", ActivePane: "bottomRight",
+		Selection: []Selection{{SQRef: "D5:E6", ActiveCell: "E6", Pane: "bottomRight"}},
+	}, panes)
+	assert.NoError(t, f.FreezeCols("Sheet1", "F"))
+	panes, err = f.GetPanes("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, Panes{
+		Freeze: true, XSplit: 6, TopLeftCell: "G1", ActivePane: "topLeft",
+		Selection: []Selection{{SQRef: "D5:E6", ActiveCell: "E6"}},
+	}, panes)
+	cell, err := f.GetActiveCell("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, "E6", cell)
+	// Test freeze panes with multiple selections in the same pane
+	ws, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	ws.SheetViews.SheetView[0].Selection = []*xlsxSelection{
+		nil, {SQRef: "A1", Pane: "bottomLeft"}, {SQRef: "H9", ActiveCell: "H9"}, {SQRef: "J9", ActiveCell: "J9", Pane: "topRight"},
+	}
+	assert.NoError(t, f.FreezePanes("Sheet1", "B2"))
+	panes, err = f.GetPanes("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, []Selection{{SQRef: "J9", ActiveCell: "J9", Pane: "bottomRight"}}, panes.Selection)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestFreezePanes.xlsx")))
+
+	// Test unfreeze panes keeps the selection of the active pane
+	assert.NoError(t, f.UnfreezePanes("Sheet1"))
+	panes, err = f.GetPanes("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, Panes{Selection: []Selection{{SQRef: "J9", ActiveCell: "J9"}}}, panes)
+	assert.NoError(t, f.FreezeRows("Sheet1", 20))
+	ws, err = f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	ws.SheetViews.SheetView[0].Pane.ActivePane = "topLeft"
+	ws.SheetViews.SheetView[0].Selection = append(ws.SheetViews.SheetView[0].Selection, &xlsxSelection{SQRef: "B2", ActiveCell: "B2"})
+	assert.NoError(t, f.UnfreezePanes("Sheet1"))
+	cell, err = f.GetActiveCell("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, "B2", cell)
+	// Test unfreeze panes keeps the split panes
+	assert.NoError(t, f.SetSplitPanes("Sheet1", &SplitPaneOptions{Cell: "B2"}))
+	assert.NoError(t, f.UnfreezePanes("Sheet1"))
+	panes, err = f.GetPanes("Sheet1")
+	assert.NoError(t, err)
+	assert.True(t, panes.Split)
+	// Test unfreeze panes without sheet views
+	ws.SheetViews = nil
+	assert.NoError(t, f.UnfreezePanes("Sheet1"))
+	// Test freeze panes without sheet views
+	assert.NoError(t, f.FreezeRows("Sheet1", 1))
+	assert.Len(t, ws.SheetViews.SheetView, 1)
+
+	// Test freeze panes with invalid parameters
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), f.FreezePanes("Sheet1", "A"))
+	assert.Equal(t, ErrParameterInvalid, f.FreezePanes("Sheet1", "A1"))
+	assert.EqualError(t, f.FreezeCols("Sheet1", "*"), newInvalidColumnNameError("*").Error())
+	assert.Equal(t, ErrColumnNumber, f.FreezeCols("Sheet1", "XFD"))
+	assert.Equal(t, newInvalidRowNumberError(0), f.FreezeRows("Sheet1", 0))
+	assert.Equal(t, ErrMaxRows, f.FreezeRows("Sheet1", TotalRows))
+	// Test freeze and unfreeze panes on not exists worksheet
+	assert.EqualError(t, f.FreezeRows("SheetN", 1), "sheet SheetN does not exist")
+	assert.EqualError(t, f.UnfreezePanes("SheetN"), "sheet SheetN does not exist")
+}
+
+func TestAddTableOfContents(t *testing.T) {
+	f := NewFile()
+	_, err := f.NewSheet("Q1 Sales")
+	assert.NoError(t, err)
+	_, err = f.NewSheet("Hidden")
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetSheetVisible("Hidden", false))
+	assert.NoError(t, f.SetCellValue("Sheet1", "A1", "Summary"))
+	assert.NoError(t, f.SetCellValue("Q1 Sales", "A1", "Sales"))
+	style, err := f.NewStyle(&Style{Font: &Font{Bold: true}})
+	assert.NoError(t, err)
+	opts := TOCOptions{
+		Title:                    "Table of Contents",
+		TitleStyle:               style,
+		Descriptions:             map[string]string{"Q1 Sales": "Sales of the first quarter"},
+		DescriptionFromFirstCell: true,
+		BackLinkCell:             "H1",
+	}
+	assert.NoError(t, f.AddTableOfContents(opts))
+	assert.Equal(t, []string{"Contents", "Sheet1", "Q1 Sales", "Hidden"}, f.GetSheetList())
+	rows, err := f.GetRows("Contents")
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{{"Table of Contents"}, {"Sheet1", "Summary"}, {"Q1 Sales", "Sales of the first quarter"}}, rows)
+	for cell, expected := range map[string]string{"A2": "Sheet1!A1", "A3": "'Q1 Sales'!A1"} {
+		link, target, err := f.GetCellHyperLink("Contents", cell)
+		assert.NoError(t, err)
+		assert.True(t, link)
+		assert.Equal(t, expected, target)
+	}
+	styleID, err := f.GetCellStyle("Contents", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, style, styleID)
+	for _, sheet := range []string{"Sheet1", "Q1 Sales"} {
+		val, err := f.GetCellValue(sheet, "H1")
+		assert.NoError(t, err)
+		assert.Equal(t, "Back to contents", val)
+		link, target, err := f.GetCellHyperLink(sheet, "H1")
+		assert.NoError(t, err)
+		assert.True(t, link)
+		assert.Equal(t, "Contents!A1", target)
+	}
+	val, err := f.GetCellValue("Hidden", "H1")
+	assert.NoError(t, err)
+	assert.Empty(t, val)
+
+	// Test regenerate the table of contents after adding and removing worksheets
+	_, err = f.NewSheet("Sheet4")
+	assert.NoError(t, err)
+	assert.NoError(t, f.DeleteSheet("Q1 Sales"))
+	opts.Title = ""
+	assert.NoError(t, f.AddTableOfContents(opts))
+	assert.Equal(t, []string{"Contents", "Sheet1", "Hidden", "Sheet4"}, f.GetSheetList())
+	rows, err = f.GetRows("Contents")
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{{"Sheet1", "Summary"}, {"Sheet4"}}, rows)
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet4.xml")
+	assert.True(t, ok)
+	assert.Len(t, ws.(*xlsxWorksheet).Hyperlinks.Hyperlink, 2)
+	link, target, err := f.GetCellHyperLink("Contents", "A2")
+	assert.NoError(t, err)
+	assert.True(t, link)
+	assert.Equal(t, "Sheet4!A1", target)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAddTableOfContents.xlsx")))
+
+	// Test add the table of contents with invalid options
+	assert.Equal(t, ErrSheetNameInvalid, f.AddTableOfContents(TOCOptions{Sheet: "Sheet:1"}))
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), f.AddTableOfContents(TOCOptions{BackLinkCell: "A"}))
+	assert.EqualError(t, f.AddTableOfContents(TOCOptions{LinkStyle: 100}), newInvalidStyleID(100).Error())
+	assert.EqualError(t, f.AddTableOfContents(TOCOptions{TitleStyle: 100, Title: "Title"}), newInvalidStyleID(100).Error())
+	assert.EqualError(t, f.AddTableOfContents(TOCOptions{DescriptionStyle: 100, DescriptionFromFirstCell: true}), newInvalidStyleID(100).Error())
+	assert.NoError(t, f.Close())
+
+	// Test add the table of contents with unsupported charset worksheet
+	f = NewFile()
+	f.Sheet.Delete("xl/worksheets/sheet1.xml")
+	f.Pkg.Store("xl/worksheets/sheet1.xml", MacintoshCyrillicCharset)
+	assert.EqualError(t, f.AddTableOfContents(TOCOptions{Sheet: "Sheet1"}), "XML syntax error on line 1: invalid UTF-8")
+	assert.EqualError(t, f.AddTableOfContents(TOCOptions{DescriptionFromFirstCell: true}), "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
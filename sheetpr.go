@@ -226,3 +226,124 @@ func (f *File) GetSheetProps(sheet string) (SheetPropsOptions, error) {
 	}
 	return opts, err
 }
+
+// SetSheetFormatPr provides a function to set the sheet formatting properties
+// of the worksheet by given worksheet name, only the non-nil settings will be
+// changed. Note that the default row height only applies to the rows without
+// explicit height when the CustomHeight is true. This function is concurrency
+// safe. For example, set the default column width to 12 and the default row
+// height to 20 points on Sheet1:
+//
+//	width, height, customHeight := 12.0, 20.0, true
+//	err := f.SetSheetFormatPr("Sheet1", excelize.SheetFormatPrOptions{
+//	    DefaultColWidth:  &width,
+//	    DefaultRowHeight: &height,
+//	    CustomHeight:     &customHeight,
+//	})
+func (f *File) SetSheetFormatPr(sheet string, opts SheetFormatPrOptions) error {
+	if opts.DefaultColWidth != nil {
+		if err := checkColWidth(*opts.DefaultColWidth); err != nil {
+			return err
+		}
+	}
+	if opts.DefaultRowHeight != nil {
+		if *opts.DefaultRowHeight > MaxRowHeight {
+			return ErrMaxRowHeight
+		}
+		if *opts.DefaultRowHeight < 0 {
+			return ErrParameterInvalid
+		}
+	}
+	for _, level := range []*uint8{opts.OutlineLevelCol, opts.OutlineLevelRow} {
+		if level != nil && *level > 7 {
+			return ErrOutlineLevel
+		}
+	}
+	f.mu.Lock()
+	ws, err := f.workSheetReader(sheet)
+	f.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	if ws.SheetFormatPr == nil {
+		ws.SheetFormatPr = &xlsxSheetFormatPr{DefaultRowHeight: defaultRowHeight}
+	}
+	if opts.DefaultColWidth != nil {
+		ws.SheetFormatPr.DefaultColWidth = *opts.DefaultColWidth
+	}
+	if opts.DefaultRowHeight != nil {
+		ws.SheetFormatPr.DefaultRowHeight = *opts.DefaultRowHeight
+	}
+	if opts.CustomHeight != nil {
+		ws.SheetFormatPr.CustomHeight = *opts.CustomHeight
+	}
+	if opts.ZeroHeight != nil {
+		ws.SheetFormatPr.ZeroHeight = *opts.ZeroHeight
+	}
+	if opts.BaseColWidth != nil {
+		ws.SheetFormatPr.BaseColWidth = *opts.BaseColWidth
+	}
+	if opts.OutlineLevelCol != nil {
+		ws.SheetFormatPr.OutlineLevelCol = *opts.OutlineLevelCol
+	}
+	if opts.OutlineLevelRow != nil {
+		ws.SheetFormatPr.OutlineLevelRow = *opts.OutlineLevelRow
+	}
+	return err
+}
+
+// GetSheetFormatPr provides a function to get the sheet formatting properties
+// of the worksheet by given worksheet name. The default values will be
+// returned if the worksheet doesn't have the sheet formatting properties. This
+// function is concurrency safe.
+func (f *File) GetSheetFormatPr(sheet string) (SheetFormatPrOptions, error) {
+	opts := SheetFormatPrOptions{
+		DefaultColWidth:  float64Ptr(defaultColWidth),
+		DefaultRowHeight: float64Ptr(defaultRowHeight),
+		CustomHeight:     boolPtr(false),
+		ZeroHeight:       boolPtr(false),
+		BaseColWidth:     uint8Ptr(8),
+		OutlineLevelCol:  uint8Ptr(0),
+		OutlineLevelRow:  uint8Ptr(0),
+	}
+	f.mu.Lock()
+	ws, err := f.workSheetReader(sheet)
+	f.mu.Unlock()
+	if err != nil {
+		return opts, err
+	}
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	if ws.SheetFormatPr == nil {
+		return opts, err
+	}
+	if ws.SheetFormatPr.DefaultColWidth > 0 {
+		opts.DefaultColWidth = float64Ptr(ws.SheetFormatPr.DefaultColWidth)
+	}
+	if ws.SheetFormatPr.BaseColWidth > 0 {
+		opts.BaseColWidth = uint8Ptr(ws.SheetFormatPr.BaseColWidth)
+	}
+	opts.DefaultRowHeight = float64Ptr(ws.SheetFormatPr.DefaultRowHeight)
+	opts.CustomHeight = boolPtr(ws.SheetFormatPr.CustomHeight)
+	opts.ZeroHeight = boolPtr(ws.SheetFormatPr.ZeroHeight)
+	opts.OutlineLevelCol = uint8Ptr(ws.SheetFormatPr.OutlineLevelCol)
+	opts.OutlineLevelRow = uint8Ptr(ws.SheetFormatPr.OutlineLevelRow)
+	return opts, err
+}
+
+// GetSheetTabColor provides a function to get the ARGB hex color code of the
+// worksheet tab by given worksheet name, the theme color, indexed color and
+// tint value of the tab color will be resolved by the ResolveColor function.
+// It returns an empty string if the worksheet tab has no color.
+func (f *File) GetSheetTabColor(sheet string) (string, error) {
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return "", err
+	}
+	if ws.SheetPr == nil || ws.SheetPr.TabColor == nil {
+		return "", err
+	}
+	return f.ResolveColor(ws.SheetPr.TabColor.colorSettings())
+}
@@ -1,6 +1,7 @@
 package excelize
 
 import (
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -100,3 +101,93 @@ func TestGetSheetProps(t *testing.T) {
 	_, err = f.GetSheetProps("Sheet:1")
 	assert.Equal(t, ErrSheetNameInvalid, err)
 }
+
+func TestSheetFormatPr(t *testing.T) {
+	f := NewFile()
+	opts, err := f.GetSheetFormatPr("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, SheetFormatPrOptions{
+		DefaultColWidth: float64Ptr(defaultColWidth), DefaultRowHeight: float64Ptr(15),
+		CustomHeight: boolPtr(false), ZeroHeight: boolPtr(false), BaseColWidth: uint8Ptr(8),
+		OutlineLevelCol: uint8Ptr(0), OutlineLevelRow: uint8Ptr(0),
+	}, opts)
+	expected := SheetFormatPrOptions{
+		DefaultColWidth: float64Ptr(20), DefaultRowHeight: float64Ptr(25),
+		CustomHeight: boolPtr(true), ZeroHeight: boolPtr(true), BaseColWidth: uint8Ptr(10),
+		OutlineLevelCol: uint8Ptr(2), OutlineLevelRow: uint8Ptr(3),
+	}
+	assert.NoError(t, f.SetSheetFormatPr("Sheet1", expected))
+	// Test set the sheet formatting properties partially
+	assert.NoError(t, f.SetSheetFormatPr("Sheet1", SheetFormatPrOptions{ZeroHeight: boolPtr(false)}))
+	expected.ZeroHeight = boolPtr(false)
+	assert.NoError(t, f.SetColWidth("Sheet1", "B", "B", 5))
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestSheetFormatPr.xlsx")))
+	assert.NoError(t, f.Close())
+
+	f, err = OpenFile(filepath.Join("test", "TestSheetFormatPr.xlsx"))
+	assert.NoError(t, err)
+	opts, err = f.GetSheetFormatPr("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, expected, opts)
+	// Test get the width of the columns without explicit width
+	width, err := f.GetColWidth("Sheet1", "A")
+	assert.NoError(t, err)
+	assert.Equal(t, 20.0, width)
+	width, err = f.GetColWidth("Sheet1", "B")
+	assert.NoError(t, err)
+	assert.Equal(t, 5.0, width)
+	height, err := f.GetRowHeight("Sheet1", 1)
+	assert.NoError(t, err)
+	assert.Equal(t, 25.0, height)
+	assert.NoError(t, f.Close())
+
+	f = NewFile()
+	// Test set the sheet formatting properties with invalid settings
+	assert.Equal(t, ErrColumnWidthValue, f.SetSheetFormatPr("Sheet1", SheetFormatPrOptions{DefaultColWidth: float64Ptr(-1)}))
+	assert.Equal(t, ErrColumnWidth, f.SetSheetFormatPr("Sheet1", SheetFormatPrOptions{DefaultColWidth: float64Ptr(MaxColumnWidth + 1)}))
+	assert.Equal(t, ErrMaxRowHeight, f.SetSheetFormatPr("Sheet1", SheetFormatPrOptions{DefaultRowHeight: float64Ptr(MaxRowHeight + 1)}))
+	assert.Equal(t, ErrParameterInvalid, f.SetSheetFormatPr("Sheet1", SheetFormatPrOptions{DefaultRowHeight: float64Ptr(-1)}))
+	assert.Equal(t, ErrOutlineLevel, f.SetSheetFormatPr("Sheet1", SheetFormatPrOptions{OutlineLevelRow: uint8Ptr(8)}))
+	// Test set and get the sheet formatting properties on not exists worksheet
+	assert.EqualError(t, f.SetSheetFormatPr("SheetN", SheetFormatPrOptions{}), "sheet SheetN does not exist")
+	_, err = f.GetSheetFormatPr("SheetN")
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+	// Test set and get the sheet formatting properties with unsupported charset
+	f.Sheet.Delete("xl/worksheets/sheet1.xml")
+	f.Pkg.Store("xl/worksheets/sheet1.xml", MacintoshCyrillicCharset)
+	assert.EqualError(t, f.SetSheetFormatPr("Sheet1", SheetFormatPrOptions{}), "XML syntax error on line 1: invalid UTF-8")
+	_, err = f.GetSheetFormatPr("Sheet1")
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
+
+func TestGetSheetTabColor(t *testing.T) {
+	f := NewFile()
+	clr, err := f.GetSheetTabColor("Sheet1")
+	assert.NoError(t, err)
+	assert.Empty(t, clr)
+	for _, c := range []struct {
+		opts     SheetPropsOptions
+		expected string
+	}{
+		{SheetPropsOptions{TabColorRGB: stringPtr("FF0070C0")}, "FF0070C0"},
+		{SheetPropsOptions{TabColorRGB: stringPtr(""), TabColorIndexed: intPtr(10)}, "FFFF0000"},
+		{SheetPropsOptions{TabColorTheme: intPtr(4), TabColorTint: float64Ptr(0.5999938962981048)}, "FFBDD7EE"},
+	} {
+		ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+		assert.True(t, ok)
+		ws.(*xlsxWorksheet).SheetPr = nil
+		assert.NoError(t, f.SetSheetProps("Sheet1", &c.opts))
+		clr, err := f.GetSheetTabColor("Sheet1")
+		assert.NoError(t, err)
+		assert.Equal(t, c.expected, clr)
+	}
+	// Test get worksheet tab color with invalid color
+	assert.NoError(t, f.SetSheetProps("Sheet1", &SheetPropsOptions{TabColorTheme: intPtr(12)}))
+	_, err = f.GetSheetTabColor("Sheet1")
+	assert.Equal(t, newInvalidColorError("theme", 12), err)
+	// Test get worksheet tab color on not exists worksheet
+	_, err = f.GetSheetTabColor("SheetN")
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+	assert.NoError(t, f.Close())
+}
@@ -11,6 +11,8 @@
 
 package excelize
 
+import "strings"
+
 // getSheetView returns the SheetView object
 func (f *File) getSheetView(sheet string, viewIndex int) (*xlsxSheetView, error) {
 	ws, err := f.workSheetReader(sheet)
@@ -127,3 +129,234 @@ func (f *File) GetSheetView(sheet string, viewIndex int) (ViewOptions, error) {
 	}
 	return opts, err
 }
+
+// ApplySheetViewDefaults provides a function to apply the same view options,
+// frozen panes and selection to the worksheets by given sheet view settings
+// and worksheet names, all worksheets will be applied if no worksheet name
+// specified. The settings will be applied to every sheet view of the
+// worksheet, and the chart sheets and dialog sheets will be skipped. The
+// settings will be checked before any worksheet be changed. For example, set
+// the zoom scale to 85, hide the grid lines, freeze the header row and select
+// the cell A2 on all worksheets:
+//
+//	zoomScale, showGridLines := 85.0, false
+//	err := f.ApplySheetViewDefaults(excelize.SheetViewDefaults{
+//	    View: excelize.ViewOptions{
+//	        ZoomScale:     &zoomScale,
+//	        ShowGridLines: &showGridLines,
+//	    },
+//	    FreezeRows: 1,
+//	    Selection:  "A2",
+//	    ActiveCell: "A2",
+//	})
+func (f *File) ApplySheetViewDefaults(opts SheetViewDefaults, sheets ...string) error {
+	if opts.FreezeCols < 0 || opts.FreezeCols >= MaxColumns {
+		return ErrColumnNumber
+	}
+	if opts.FreezeRows < 0 || opts.FreezeRows >= TotalRows {
+		return ErrMaxRows
+	}
+	activeCellID := -1
+	if opts.ActiveCell != "" {
+		var err error
+		if activeCellID, err = checkSelection(opts.Selection, opts.ActiveCell); err != nil {
+			return err
+		}
+	}
+	if len(sheets) == 0 {
+		sheets = f.GetSheetList()
+	}
+	var worksheets []*xlsxWorksheet
+	for _, sheet := range sheets {
+		ws, err := f.workSheetReader(sheet)
+		if err != nil {
+			if err.Error() == newNotWorksheetError(sheet).Error() {
+				continue
+			}
+			return err
+		}
+		worksheets = append(worksheets, ws)
+	}
+	for _, ws := range worksheets {
+		if ws.SheetViews == nil || len(ws.SheetViews.SheetView) == 0 {
+			ws.SheetViews = &xlsxSheetViews{SheetView: []xlsxSheetView{{WorkbookViewID: 0}}}
+		}
+		for i := range ws.SheetViews.SheetView {
+			view := &ws.SheetViews.SheetView[i]
+			view.setSheetView(&opts.View)
+			if opts.FreezeCols > 0 || opts.FreezeRows > 0 {
+				view.freezePanes(opts.FreezeCols, opts.FreezeRows)
+			}
+			if activeCellID != -1 {
+				view.setSelection(opts.Selection, opts.ActiveCell, activeCellID)
+			}
+		}
+	}
+	return nil
+}
+
+// SetActiveCell provides a function to set the selected and active cell of
+// the worksheet by given worksheet name and cell reference. If the worksheet
+// has freeze panes, the selection will be placed in the pane which contains
+// the cell. For example, set the active cell of Sheet1 to B2:
+//
+//	err := f.SetActiveCell("Sheet1", "B2")
+func (f *File) SetActiveCell(sheet, cell string) error {
+	return f.SetSelection(sheet, cell, cell)
+}
+
+// GetActiveCell provides a function to get the active cell of the worksheet
+// by given worksheet name. The active cell of the active pane will be
+// returned if the worksheet has panes, and the A1 will be returned if the
+// worksheet has no selection. For example, get the active cell of Sheet1:
+//
+//	cell, err := f.GetActiveCell("Sheet1")
+func (f *File) GetActiveCell(sheet string) (string, error) {
+	view, err := f.getSheetView(sheet, -1)
+	if err != nil {
+		return "", err
+	}
+	var activePane string
+	if view.Pane != nil {
+		activePane = view.Pane.ActivePane
+	}
+	cell := "A1"
+	for i := len(view.Selection) - 1; i >= 0; i-- {
+		s := view.Selection[i]
+		if s == nil || s.ActiveCell == "" {
+			continue
+		}
+		if s.Pane == activePane || (activePane == "topLeft" && s.Pane == "") {
+			return s.ActiveCell, err
+		}
+		cell = s.ActiveCell
+	}
+	return cell, err
+}
+
+// SetSelection provides a function to set the selected ranges and the active
+// cell of the worksheet by given worksheet name, sequence of references
+// separated by spaces and the active cell reference, the active cell should
+// be in one of the selected ranges. If the worksheet has freeze panes, or
+// split panes with the top left cell of the bottom right pane, the selection
+// will be placed in the pane which contains the active cell, and this pane
+// will be activated, otherwise the selection will be placed in the active
+// pane. For example, select the ranges A1:B2 and D4:E5
+// on Sheet1 with the active cell D4:
+//
+//	err := f.SetSelection("Sheet1", "A1:B2 D4:E5", "D4")
+func (f *File) SetSelection(sheet, sqref, activeCell string) error {
+	activeCellID, err := checkSelection(sqref, activeCell)
+	if err != nil {
+		return err
+	}
+	view, err := f.getSheetView(sheet, -1)
+	if err != nil {
+		return err
+	}
+	view.setSelection(sqref, activeCell, activeCellID)
+	return err
+}
+
+// checkSelection provides a function to check the selected ranges and the
+// active cell, and returns the index of the range which contains the active
+// cell.
+func checkSelection(sqref, activeCell string) (int, error) {
+	col, row, err := CellNameToCoordinates(activeCell)
+	if err != nil {
+		return -1, err
+	}
+	activeCellID := -1
+	for i, ref := range strings.Fields(sqref) {
+		if !strings.Contains(ref, ":") {
+			ref += ":" + ref
+		}
+		coordinates, err := rangeRefToCoordinates(ref)
+		if err != nil {
+			return -1, err
+		}
+		_ = sortCoordinates(coordinates)
+		if activeCellID == -1 && cellInRange([]int{col, row}, coordinates) {
+			activeCellID = i
+		}
+	}
+	if activeCellID == -1 {
+		return -1, ErrParameterInvalid
+	}
+	return activeCellID, err
+}
+
+// setSelection provides a function to set the selection of the sheet view by
+// given checked selected ranges, active cell and the index of the range which
+// contains the active cell.
+func (view *xlsxSheetView) setSelection(sqref, activeCell string, activeCellID int) {
+	col, row, _ := CellNameToCoordinates(activeCell)
+	selection := &xlsxSelection{ActiveCell: activeCell, SQRef: strings.Join(strings.Fields(sqref), " ")}
+	if activeCellID > 0 {
+		selection.ActiveCellID = intPtr(activeCellID)
+	}
+	if view.Pane == nil {
+		view.Selection = []*xlsxSelection{selection}
+		return
+	}
+	selection.Pane = view.Pane.ActivePane
+	if xSplit, ySplit, ok := view.Pane.getSplitCoordinates(); ok {
+		view.Pane.ActivePane = getPaneByCell(col, row, xSplit, ySplit)
+		if selection.Pane = view.Pane.ActivePane; selection.Pane == "topLeft" {
+			selection.Pane = ""
+		}
+	}
+	selections := []*xlsxSelection{}
+	for _, s := range view.Selection {
+		if s != nil && s.Pane != selection.Pane {
+			selections = append(selections, s)
+		}
+	}
+	view.Selection = append(selections, selection)
+}
+
+// getSplitCoordinates provides a function to get the number of columns and
+// rows before the split of the panes. The frozen panes are split by the
+// number of columns and rows, and the split panes, which position is in
+// twips, are split at the top left cell of the bottom right pane. It returns
+// false if the split position of the split panes can't be determined.
+func (p *xlsxPane) getSplitCoordinates() (int, int, bool) {
+	if p.State == "frozen" || p.State == "frozenSplit" {
+		return int(p.XSplit), int(p.YSplit), true
+	}
+	col, row, err := CellNameToCoordinates(p.TopLeftCell)
+	if err != nil {
+		return 0, 0, false
+	}
+	var xSplit, ySplit int
+	if p.XSplit > 0 {
+		xSplit = col - 1
+	}
+	if p.YSplit > 0 {
+		ySplit = row - 1
+	}
+	return xSplit, ySplit, true
+}
+
+// getPaneByCell provides a function to get the pane which contains the given
+// cell coordinates by given number of columns and rows before the split.
+func getPaneByCell(col, row, xSplit, ySplit int) string {
+	right, bottom := col > xSplit, row > ySplit
+	switch {
+	case xSplit > 0 && ySplit > 0:
+		if bottom {
+			if right {
+				return "bottomRight"
+			}
+			return "bottomLeft"
+		}
+		if right {
+			return "topRight"
+		}
+	case xSplit > 0 && right:
+		return "topRight"
+	case ySplit > 0 && bottom:
+		return "bottomLeft"
+	}
+	return "topLeft"
+}
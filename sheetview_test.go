@@ -1,6 +1,7 @@
 package excelize
 
 import (
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -48,3 +49,163 @@ func TestGetView(t *testing.T) {
 	_, err = f.GetSheetView("SheetN", 0)
 	assert.EqualError(t, err, "sheet SheetN does not exist")
 }
+
+func TestActiveCell(t *testing.T) {
+	f := NewFile()
+	cell, err := f.GetActiveCell("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, "A1", cell)
+	assert.NoError(t, f.SetActiveCell("Sheet1", "C3"))
+	cell, err = f.GetActiveCell("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, "C3", cell)
+	assert.NoError(t, f.SetSelection("Sheet1", "A1:B2  D4:E5", "E5"))
+	cell, err = f.GetActiveCell("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, "E5", cell)
+	view, err := f.getSheetView("Sheet1", -1)
+	assert.NoError(t, err)
+	assert.Equal(t, []*xlsxSelection{{ActiveCell: "E5", ActiveCellID: intPtr(1), SQRef: "A1:B2 D4:E5"}}, view.Selection)
+
+	// Test set active cell with frozen panes
+	for _, c := range []struct {
+		xSplit, ySplit int
+		cell, pane     string
+	}{
+		{1, 2, "A1", "topLeft"}, {1, 2, "B2", "topRight"}, {1, 2, "A3", "bottomLeft"}, {1, 2, "B3", "bottomRight"},
+		{1, 0, "A5", "topLeft"}, {1, 0, "B5", "topRight"}, {0, 2, "E2", "topLeft"}, {0, 2, "E3", "bottomLeft"},
+	} {
+		assert.NoError(t, f.SetPanes("Sheet1", &Panes{Freeze: true, XSplit: c.xSplit, YSplit: c.ySplit}))
+		assert.NoError(t, f.SetActiveCell("Sheet1", c.cell))
+		cell, err = f.GetActiveCell("Sheet1")
+		assert.NoError(t, err)
+		assert.Equal(t, c.cell, cell)
+		view, err = f.getSheetView("Sheet1", -1)
+		assert.NoError(t, err)
+		assert.Equal(t, c.pane, view.Pane.ActivePane)
+		pane := c.pane
+		if pane == "topLeft" {
+			pane = ""
+		}
+		assert.Equal(t, pane, view.Selection[len(view.Selection)-1].Pane)
+	}
+	// Test set selection in different panes keeps the selection of other panes
+	assert.NoError(t, f.SetPanes("Sheet1", &Panes{Freeze: true, XSplit: 1, YSplit: 1}))
+	assert.NoError(t, f.SetActiveCell("Sheet1", "B2"))
+	assert.NoError(t, f.SetActiveCell("Sheet1", "B1"))
+	assert.NoError(t, f.SetActiveCell("Sheet1", "C1"))
+	view, err = f.getSheetView("Sheet1", -1)
+	assert.NoError(t, err)
+	assert.Equal(t, []*xlsxSelection{
+		{ActiveCell: "B2", Pane: "bottomRight", SQRef: "B2"},
+		{ActiveCell: "C1", Pane: "topRight", SQRef: "C1"},
+	}, view.Selection)
+	cell, err = f.GetActiveCell("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, "C1", cell)
+	// Test set active cell with split panes
+	assert.NoError(t, f.SetPanes("Sheet1", &Panes{Split: true, XSplit: 3270, YSplit: 1800, ActivePane: "bottomLeft"}))
+	assert.NoError(t, f.SetActiveCell("Sheet1", "J60"))
+	view, err = f.getSheetView("Sheet1", -1)
+	assert.NoError(t, err)
+	assert.Equal(t, []*xlsxSelection{{ActiveCell: "J60", Pane: "bottomLeft", SQRef: "J60"}}, view.Selection)
+	cell, err = f.GetActiveCell("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, "J60", cell)
+	view.Pane.ActivePane = "topRight"
+	cell, err = f.GetActiveCell("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, "J60", cell)
+	// Test set active cell with split panes at the top left cell
+	for _, c := range []struct {
+		xSplit, ySplit float64
+		cell, pane     string
+	}{
+		{3270, 1800, "C5", "topLeft"}, {3270, 1800, "D5", "topRight"}, {3270, 1800, "C6", "bottomLeft"}, {3270, 1800, "D6", "bottomRight"},
+		{3270, 0, "D60", "topRight"}, {0, 1800, "Z6", "bottomLeft"}, {0, 1800, "Z5", "topLeft"},
+	} {
+		view.Pane = &xlsxPane{State: "split", XSplit: c.xSplit, YSplit: c.ySplit, TopLeftCell: "D6", ActivePane: "bottomRight"}
+		view.Selection = nil
+		assert.NoError(t, f.SetActiveCell("Sheet1", c.cell))
+		assert.Equal(t, c.pane, view.Pane.ActivePane, c.cell)
+		cell, err = f.GetActiveCell("Sheet1")
+		assert.NoError(t, err)
+		assert.Equal(t, c.cell, cell)
+	}
+
+	// Test set selection with invalid parameters
+	assert.Equal(t, ErrParameterInvalid, f.SetSelection("Sheet1", "A1:B2", "C3"))
+	assert.EqualError(t, f.SetSelection("Sheet1", "A1:B2", "*"), newCellNameToCoordinatesError("*", newInvalidCellNameError("*")).Error())
+	assert.EqualError(t, f.SetSelection("Sheet1", "A1:*", "A1"), newCellNameToCoordinatesError("*", newInvalidCellNameError("*")).Error())
+	// Test set and get active cell on not exists worksheet
+	assert.EqualError(t, f.SetActiveCell("SheetN", "A1"), "sheet SheetN does not exist")
+	_, err = f.GetActiveCell("SheetN")
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+}
+
+func TestApplySheetViewDefaults(t *testing.T) {
+	f := NewFile()
+	for _, sheet := range []string{"Sheet2", "Sheet3"} {
+		_, err := f.NewSheet(sheet)
+		assert.NoError(t, err)
+	}
+	assert.NoError(t, f.AddChartSheet("Chart1", &Chart{
+		Type:   Col,
+		Series: []ChartSeries{{Name: "Sheet1!$A$1", Values: "Sheet1!$B$1:$D$1"}},
+	}))
+	// Test apply the settings on the worksheet with multiple sheet views
+	ws, err := f.workSheetReader("Sheet2")
+	assert.NoError(t, err)
+	ws.SheetViews.SheetView = append(ws.SheetViews.SheetView, xlsxSheetView{WorkbookViewID: 1})
+	ws, err = f.workSheetReader("Sheet3")
+	assert.NoError(t, err)
+	ws.SheetViews = nil
+	zoomScale, showGridLines := 85.0, false
+	assert.NoError(t, f.ApplySheetViewDefaults(SheetViewDefaults{
+		View:       ViewOptions{ZoomScale: &zoomScale, ShowGridLines: &showGridLines},
+		FreezeRows: 1,
+		Selection:  "A2:C5",
+		ActiveCell: "B3",
+	}))
+	for _, sheet := range []string{"Sheet1", "Sheet2", "Sheet3"} {
+		ws, err := f.workSheetReader(sheet)
+		assert.NoError(t, err)
+		for i := range ws.SheetViews.SheetView {
+			opts, err := f.GetSheetView(sheet, i)
+			assert.NoError(t, err)
+			assert.Equal(t, zoomScale, *opts.ZoomScale, sheet)
+			assert.False(t, *opts.ShowGridLines, sheet)
+			view := ws.SheetViews.SheetView[i]
+			assert.Equal(t, &xlsxPane{YSplit: 1, TopLeftCell: "A2", ActivePane: "bottomLeft", State: "frozen"}, view.Pane, sheet)
+			assert.Equal(t, []*xlsxSelection{{ActiveCell: "B3", SQRef: "A2:C5", Pane: "bottomLeft"}}, view.Selection, sheet)
+		}
+	}
+	ws, err = f.workSheetReader("Sheet2")
+	assert.NoError(t, err)
+	assert.Len(t, ws.SheetViews.SheetView, 2)
+	// Test apply the settings on the specified worksheets without changing the
+	// panes and selection
+	zoomScale = 120
+	assert.NoError(t, f.ApplySheetViewDefaults(SheetViewDefaults{View: ViewOptions{ZoomScale: &zoomScale}}, "Sheet1", "Chart1"))
+	opts, err := f.GetSheetView("Sheet1", 0)
+	assert.NoError(t, err)
+	assert.Equal(t, zoomScale, *opts.ZoomScale)
+	opts, err = f.GetSheetView("Sheet2", 0)
+	assert.NoError(t, err)
+	assert.Equal(t, 85.0, *opts.ZoomScale)
+	cell, err := f.GetActiveCell("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, "B3", cell)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestApplySheetViewDefaults.xlsx")))
+	// Test apply the settings with invalid arguments
+	assert.Equal(t, ErrColumnNumber, f.ApplySheetViewDefaults(SheetViewDefaults{FreezeCols: -1}))
+	assert.Equal(t, ErrColumnNumber, f.ApplySheetViewDefaults(SheetViewDefaults{FreezeCols: MaxColumns}))
+	assert.Equal(t, ErrMaxRows, f.ApplySheetViewDefaults(SheetViewDefaults{FreezeRows: TotalRows}))
+	assert.Equal(t, ErrParameterInvalid, f.ApplySheetViewDefaults(SheetViewDefaults{Selection: "A1", ActiveCell: "B2"}))
+	// Test apply the settings on not exists worksheet without changing others
+	assert.EqualError(t, f.ApplySheetViewDefaults(SheetViewDefaults{View: ViewOptions{ZoomScale: &zoomScale}}, "Sheet2", "SheetN"), "sheet SheetN does not exist")
+	opts, err = f.GetSheetView("Sheet2", 0)
+	assert.NoError(t, err)
+	assert.Equal(t, 85.0, *opts.ZoomScale)
+	assert.NoError(t, f.Close())
+}
@@ -12,7 +12,9 @@
 package excelize
 
 import (
+	"bytes"
 	"encoding/xml"
+	"fmt"
 	"io"
 	"sort"
 	"strings"
@@ -441,6 +443,67 @@ func (f *File) AddSparkline(sheet string, opts *SparklineOptions) error {
 	return err
 }
 
+// AddColSparkline provides a function to add a sparkline over the data rows
+// of the given column. The sparkline will be placed in the cell of the
+// location column which below the last data row. The range of the sparkline
+// start from the first populated cell which doesn't contain a text value, so
+// the header rows are excluded, and end at the last populated cell of the data
+// column. The range and location are computed from the populated extent of
+// the column when saving the workbook rather than calling this function, so
+// the rows appended after the call are included. The 'Location' and 'Range'
+// in the sparkline options will be ignored. For example, add a sparkline over
+// the data rows of the column B in the worksheet named 'Sheet1' to the column
+// C:
+//
+//	err := f.AddColSparkline("Sheet1", "C", "B", excelize.SparklineOptions{
+//	    Markers: true,
+//	})
+func (f *File) AddColSparkline(sheet, locationCol, dataCol string, opts SparklineOptions) error {
+	locCol, err := ColumnNameToNumber(locationCol)
+	if err != nil {
+		return err
+	}
+	col, err := ColumnNameToNumber(dataCol)
+	if err != nil {
+		return err
+	}
+	colName, _ := ColumnNumberToName(col)
+	location, _ := CoordinatesToCellName(locCol, TotalRows)
+	ref := fmt.Sprintf("%s!%s1:%s%d", escapeSheetName(sheet), colName, colName, TotalRows)
+	opts.Location, opts.Range = []string{location}, []string{ref}
+	if err = f.AddSparkline(sheet, &opts); err != nil {
+		return err
+	}
+	ws, _ := f.workSheetReader(sheet)
+	sheetXMLPath, _ := f.getSheetXMLPath(sheet)
+	f.addColRange(ws, &colRange{
+		sheetXML:    sheetXMLPath,
+		placeholder: sparklineXML(ref, location),
+		resolve: func(ws *xlsxWorksheet) string {
+			first, last := ws.getColExtent(col, 1, func(c *xlsxC) bool { return !isTextCell(c) })
+			if first == 0 {
+				first = 1
+			}
+			last = max(first, last)
+			location, _ := CoordinatesToCellName(locCol, min(last+1, TotalRows))
+			return sparklineXML(fmt.Sprintf("%s!%s%d:%s%d", escapeSheetName(sheet), colName, first, colName, last), location)
+		},
+	})
+	return err
+}
+
+// sparklineXML provides a function to get the XML content of the sparkline
+// element by given range and location.
+func sparklineXML(ref, location string) string {
+	var buf bytes.Buffer
+	buf.WriteString("<xm:f>")
+	_ = xml.EscapeText(&buf, []byte(ref))
+	buf.WriteString("</xm:f><xm:sqref>")
+	_ = xml.EscapeText(&buf, []byte(location))
+	buf.WriteString("</xm:sqref>")
+	return buf.String()
+}
+
 // parseFormatAddSparklineSet provides a function to validate sparkline
 // properties.
 func (f *File) parseFormatAddSparklineSet(sheet string, opts *SparklineOptions) (*xlsxWorksheet, error) {
@@ -281,6 +281,47 @@ func TestAddSparkline(t *testing.T) {
 	}), "XML syntax error on line 1: element <sparklineGroup> closed by </sparklines>")
 }
 
+func TestAddColSparkline(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetSheetRow("Sheet1", "B1", &[]interface{}{"Sales", 1}))
+	for row, val := range []interface{}{10, 20, 15} {
+		assert.NoError(t, f.SetCellValue("Sheet1", fmt.Sprintf("B%d", row+2), val))
+	}
+	assert.NoError(t, f.AddColSparkline("Sheet1", "D", "B", SparklineOptions{Markers: true}))
+	// Test the range tracks the rows appended after adding the sparkline
+	assert.NoError(t, f.SetCellValue("Sheet1", "B5", 30))
+	assert.NoError(t, f.SetCellStyle("Sheet1", "B6", "B8", 0))
+	getSparkline := func(f *File) string {
+		buf, err := f.WriteToBuffer()
+		assert.NoError(t, err)
+		newFile, err := OpenReader(buf)
+		assert.NoError(t, err)
+		content, ok := newFile.Pkg.Load("xl/worksheets/sheet1.xml")
+		assert.True(t, ok)
+		assert.NoError(t, newFile.Close())
+		return string(content.([]byte))
+	}
+	assert.Contains(t, getSparkline(f), "<xm:f>Sheet1!B2:B5</xm:f><xm:sqref>D6</xm:sqref>")
+	// Test save the workbook again after append rows
+	assert.NoError(t, f.SetCellFormula("Sheet1", "B6", "SUM(B2:B5)"))
+	assert.Contains(t, getSparkline(f), "<xm:f>Sheet1!B2:B6</xm:f><xm:sqref>D7</xm:sqref>")
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAddColSparkline.xlsx")))
+
+	// Test add sparkline with sheet name requires quotes on empty column
+	_, err := f.NewSheet("Sheet 2")
+	assert.NoError(t, err)
+	assert.NoError(t, f.AddColSparkline("Sheet 2", "A", "C", SparklineOptions{}))
+	assert.Equal(t, "<xm:f>&#39;Sheet 2&#39;!C1:C1</xm:f><xm:sqref>A2</xm:sqref>", string(f.replaceColRanges("xl/worksheets/sheet2.xml", []byte(sparklineXML("'Sheet 2'!C1:C1048576", "A1048576")))))
+
+	// Test add sparkline with invalid column name
+	assert.Equal(t, newInvalidColumnNameError("-"), f.AddColSparkline("Sheet1", "-", "B", SparklineOptions{}))
+	assert.Equal(t, newInvalidColumnNameError("-"), f.AddColSparkline("Sheet1", "D", "-", SparklineOptions{}))
+	// Test add sparkline on not exists worksheet
+	assert.EqualError(t, f.AddColSparkline("SheetN", "D", "B", SparklineOptions{}), "sheet SheetN does not exist")
+	// Test add sparkline with invalid style
+	assert.Equal(t, ErrSparklineStyle, f.AddColSparkline("Sheet1", "D", "B", SparklineOptions{Style: -1}))
+}
+
 func TestAppendSparkline(t *testing.T) {
 	// Test unsupported charset.
 	f := NewFile()
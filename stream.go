@@ -13,6 +13,8 @@ package excelize
 
 import (
 	"bytes"
+	"container/heap"
+	"encoding/binary"
 	"encoding/xml"
 	"fmt"
 	"io"
@@ -32,6 +34,10 @@ type StreamWriter struct {
 	worksheet       *xlsxWorksheet
 	rawData         bufferedWriter
 	rows            int
+	cols            int
+	colData         bufferedWriter
+	colDataSize     int64
+	streamCols      []*streamCol
 	mergeCellsCount int
 	mergeCells      strings.Builder
 	tableParts      string
@@ -234,7 +240,7 @@ func (sw *StreamWriter) AddTable(table *Table) error {
 	tableXML := strings.ReplaceAll(sheetRelationshipsTableXML, "..", "xl")
 
 	// Add first table for given sheet
-	sheetPath := sw.file.sheetMap[sw.Sheet]
+	sheetPath, _ := sw.file.getSheetXMLPath(sw.Sheet)
 	sheetRels := "xl/worksheets/_rels/" + strings.TrimPrefix(sheetPath, "xl/worksheets/") + ".rels"
 	rID := sw.file.addRels(sheetRels, SourceRelationshipTable, sheetRelationshipsTableXML, "")
 
@@ -250,6 +256,9 @@ func (sw *StreamWriter) AddTable(table *Table) error {
 
 // Extract values from a row in the StreamWriter.
 func (sw *StreamWriter) getRowValues(hRow, hCol, vCol int) (res []string, err error) {
+	if sw.cols > 0 {
+		return sw.getColValues(hRow, hCol, vCol)
+	}
 	res = make([]string, vCol-hCol+1)
 
 	r, err := sw.rawData.Reader()
@@ -389,6 +398,9 @@ func (sw *StreamWriter) SetRow(cell string, values []interface{}, opts ...RowOpt
 	if err != nil {
 		return err
 	}
+	if sw.cols > 0 {
+		return ErrStreamSetCol
+	}
 	if row <= sw.rows {
 		return newStreamSetRowError(row)
 	}
@@ -412,19 +424,8 @@ func (sw *StreamWriter) SetRow(cell string, values []interface{}, opts ...RowOpt
 		if err != nil {
 			return err
 		}
-		c := xlsxC{R: ref, S: sw.worksheet.prepareCellStyle(col, row, options.StyleID)}
-		var s int
-		if v, ok := val.(Cell); ok {
-			s, val = v.StyleID, v.Value
-			setCellFormula(&c, v.Formula)
-		} else if v, ok := val.(*Cell); ok && v != nil {
-			s, val = v.StyleID, v.Value
-			setCellFormula(&c, v.Formula)
-		}
-		if s > 0 {
-			c.S = s
-		}
-		if err = sw.setCellValFunc(&c, val); err != nil {
+		c := xlsxC{R: ref, S: sw.worksheet.prepareCellStyle(col+i, row, options.StyleID)}
+		if err = sw.setCellValue(&c, val); err != nil {
 			_, _ = sw.rawData.WriteString(`</row>`)
 			return err
 		}
@@ -434,6 +435,297 @@ func (sw *StreamWriter) SetRow(cell string, values []interface{}, opts ...RowOpt
 	return sw.rawData.Sync()
 }
 
+// setCellValue provides a function to set the value, formula and style of a
+// cell by given value, the Cell.StyleID will be applied to the cell if Cell
+// is used as the value.
+func (sw *StreamWriter) setCellValue(c *xlsxC, val interface{}) error {
+	var s int
+	if v, ok := val.(Cell); ok {
+		s, val = v.StyleID, v.Value
+		setCellFormula(c, v.Formula)
+	} else if v, ok := val.(*Cell); ok && v != nil {
+		s, val = v.StyleID, v.Value
+		setCellFormula(c, v.Formula)
+	}
+	if s > 0 {
+		c.S = s
+	}
+	return sw.setCellValFunc(c, val)
+}
+
+// ColOpts define the options for the set column, it can be used directly in
+// StreamWriter.SetCol to specify the style and properties of the column.
+type ColOpts struct {
+	Width        float64
+	Hidden       bool
+	StyleID      int
+	OutlineLevel int
+}
+
+// parseColOpts provides a function to parse the optional settings for
+// *StreamWriter.SetCol.
+func parseColOpts(opts ...ColOpts) *ColOpts {
+	options := &ColOpts{}
+	for _, opt := range opts {
+		options = &opt
+	}
+	return options
+}
+
+// setColOpts provides a function to validate and apply the column options
+// for the given column of the StreamWriter.
+func (sw *StreamWriter) setColOpts(col int, opts *ColOpts) error {
+	if opts.Width > MaxColumnWidth || opts.Width < 0 {
+		return ErrColumnWidth
+	}
+	if opts.OutlineLevel > 7 || opts.OutlineLevel < 0 {
+		return ErrOutlineLevel
+	}
+	if opts.StyleID < 0 {
+		return newInvalidStyleID(opts.StyleID)
+	}
+	if opts.StyleID > 0 {
+		s, err := sw.file.stylesReader()
+		if err != nil {
+			return err
+		}
+		s.mu.Lock()
+		invalid := s.CellXfs == nil || len(s.CellXfs.Xf) <= opts.StyleID
+		s.mu.Unlock()
+		if invalid {
+			return newInvalidStyleID(opts.StyleID)
+		}
+	}
+	if *opts == (ColOpts{}) {
+		return nil
+	}
+	c := sw.worksheet.flatColRange(col, col, 0)[col]
+	if opts.Width > 0 {
+		c.Width, c.CustomWidth = float64Ptr(opts.Width), true
+	}
+	if opts.StyleID > 0 {
+		c.Style = opts.StyleID
+	}
+	if opts.Hidden {
+		c.Hidden = true
+	}
+	if opts.OutlineLevel > 0 {
+		c.OutlineLevel = uint8(opts.OutlineLevel)
+	}
+	return nil
+}
+
+// streamCol directly maps the cells of a column written by the
+// StreamWriter.SetCol. The cells of each column are stored in the column data
+// buffer as continuous records, and each record consists of the row number
+// and the length of the cell in varint encoding, followed by the XML of the
+// cell.
+type streamCol struct {
+	col, row, size int
+	offset, end    int64
+}
+
+// next provides a function to read the header of the next record of the
+// column, and move the offset to the XML of the cell.
+func (c *streamCol) next(r io.ReaderAt) error {
+	header := make([]byte, min(int64(2*binary.MaxVarintLen64), c.end-c.offset))
+	if _, err := r.ReadAt(header, c.offset); err != nil && err != io.EOF {
+		return err
+	}
+	row, n := binary.Uvarint(header)
+	size, m := binary.Uvarint(header[n:])
+	if n <= 0 || m <= 0 {
+		return ErrParameterInvalid
+	}
+	c.row, c.size, c.offset = int(row), int(size), c.offset+int64(n+m)
+	return nil
+}
+
+// streamColHeap is a min-heap of the columns ordered by the row number of the
+// next cell and the column number, which used for interleave the cells of
+// the columns into rows.
+type streamColHeap []*streamCol
+
+func (h streamColHeap) Len() int { return len(h) }
+
+func (h streamColHeap) Less(i, j int) bool {
+	if h[i].row == h[j].row {
+		return h[i].col < h[j].col
+	}
+	return h[i].row < h[j].row
+}
+
+func (h streamColHeap) Swap(i, j int) { h[i], h[j] = h[j], h[i] }
+
+func (h *streamColHeap) Push(x interface{}) { *h = append(*h, x.(*streamCol)) }
+
+func (h *streamColHeap) Pop() interface{} {
+	old := *h
+	c := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return c
+}
+
+// SetCol writes an array to stream column by giving starting cell reference
+// and a pointer to an array of values. The cells of the columns will be
+// buffered and interleaved into rows in ascending order when calling the
+// 'Flush' function, so the memory usage stays proportional to one column.
+// Note that the order of column numbers must be ascending when set columns,
+// and the 'SetRow' and 'SetCol' functions can not be work mixed on the same
+// stream writer. As a special case, if Cell is used as a value, then the
+// Cell.StyleID will be applied to that cell. For example, write the values of
+// two sensors in columns A and B with a header row:
+//
+//	err := sw.SetCol("A1", []interface{}{"Sensor 1", 20.1, 20.4, 20.3},
+//	    excelize.ColOpts{Width: 12})
+//	err = sw.SetCol("B1", []interface{}{"Sensor 2", 18.5, 18.8, 19.2},
+//	    excelize.ColOpts{Width: 12})
+func (sw *StreamWriter) SetCol(cell string, values []interface{}, opts ...ColOpts) error {
+	col, row, err := CellNameToCoordinates(cell)
+	if err != nil {
+		return err
+	}
+	if sw.sheetWritten {
+		return ErrStreamSetCol
+	}
+	if col <= sw.cols {
+		return newStreamSetColError(col)
+	}
+	if row+len(values)-1 > TotalRows {
+		return ErrMaxRows
+	}
+	options := parseColOpts(opts...)
+	if err = sw.setColOpts(col, options); err != nil {
+		return err
+	}
+	sw.cols = col
+	var (
+		cellData bufferedWriter
+		header   = make([]byte, 2*binary.MaxVarintLen64)
+		sc       = &streamCol{col: col, offset: sw.colDataSize}
+	)
+	for i, val := range values {
+		if val == nil {
+			continue
+		}
+		ref, err := CoordinatesToCellName(col, row+i)
+		if err != nil {
+			return err
+		}
+		c := xlsxC{R: ref, S: sw.worksheet.prepareCellStyle(col, row+i, options.StyleID)}
+		if err = sw.setCellValue(&c, val); err != nil {
+			return err
+		}
+		cellData.buf.Reset()
+		writeCell(&cellData, c)
+		n := binary.PutUvarint(header, uint64(row+i))
+		n += binary.PutUvarint(header[n:], uint64(cellData.buf.Len()))
+		_, _ = sw.colData.Write(header[:n])
+		_, _ = sw.colData.Write(cellData.buf.Bytes())
+		sw.colDataSize += int64(n + cellData.buf.Len())
+	}
+	if sc.end = sw.colDataSize; sc.end > sc.offset {
+		sw.streamCols = append(sw.streamCols, sc)
+	}
+	return sw.colData.Sync()
+}
+
+// writeColData provides a function to interleave the cells of the columns
+// written by the StreamWriter.SetCol into rows in ascending order, and write
+// the rows to the worksheet data.
+func (sw *StreamWriter) writeColData() error {
+	if len(sw.streamCols) == 0 {
+		return nil
+	}
+	defer sw.colData.Close()
+	reader, err := sw.colData.Reader()
+	if err != nil {
+		return err
+	}
+	r, h := reader.(io.ReaderAt), make(streamColHeap, 0, len(sw.streamCols))
+	for _, c := range sw.streamCols {
+		if err = c.next(r); err != nil {
+			return err
+		}
+		h = append(h, c)
+	}
+	heap.Init(&h)
+	var (
+		row  int
+		cell []byte
+	)
+	for h.Len() > 0 {
+		c := h[0]
+		if c.row != row {
+			if row > 0 {
+				_, _ = sw.rawData.WriteString(`</row>`)
+				if err = sw.rawData.Sync(); err != nil {
+					return err
+				}
+			}
+			row = c.row
+			_, _ = sw.rawData.WriteString(`<row r="`)
+			_, _ = sw.rawData.WriteString(strconv.Itoa(row))
+			_, _ = sw.rawData.WriteString(`">`)
+		}
+		if cap(cell) < c.size {
+			cell = make([]byte, c.size)
+		}
+		if _, err = r.ReadAt(cell[:c.size], c.offset); err != nil && err != io.EOF {
+			return err
+		}
+		_, _ = sw.rawData.Write(cell[:c.size])
+		if c.offset += int64(c.size); c.offset < c.end {
+			if err = c.next(r); err != nil {
+				return err
+			}
+			heap.Fix(&h, 0)
+			continue
+		}
+		heap.Pop(&h)
+	}
+	sw.rows = row
+	_, _ = sw.rawData.WriteString(`</row>`)
+	return sw.rawData.Sync()
+}
+
+// getColValues provides a function to extract values from a row in the
+// columns written by the StreamWriter.SetCol.
+func (sw *StreamWriter) getColValues(hRow, hCol, vCol int) ([]string, error) {
+	res := make([]string, vCol-hCol+1)
+	reader, err := sw.colData.Reader()
+	if err != nil {
+		return nil, err
+	}
+	r := reader.(io.ReaderAt)
+	for _, sc := range sw.streamCols {
+		if sc.col < hCol || sc.col > vCol {
+			continue
+		}
+		for c := *sc; c.offset < c.end; c.offset += int64(c.size) {
+			if err = c.next(r); err != nil {
+				return nil, err
+			}
+			if c.row < hRow {
+				continue
+			}
+			if c.row == hRow {
+				cell := make([]byte, c.size)
+				if _, err = r.ReadAt(cell, c.offset); err != nil && err != io.EOF {
+					return nil, err
+				}
+				var x xlsxC
+				if err = sw.file.xmlNewDecoder(bytes.NewReader(cell)).Decode(&x); err != nil {
+					return nil, err
+				}
+				res[sc.col-hCol], _ = x.getValueFrom(sw.file, nil, false)
+			}
+			break
+		}
+	}
+	return res, nil
+}
+
 // SetColStyle provides a function to set the style of a single column or
 // multiple columns for the StreamWriter. Note that you must call
 // the 'SetColStyle' function before the 'SetRow' function. For example set
@@ -454,6 +746,8 @@ func (sw *StreamWriter) SetColStyle(minVal, maxVal, styleID int) error {
 	if err != nil {
 		return err
 	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
 	if styleID < 0 || s.CellXfs == nil || len(s.CellXfs.Xf) <= styleID {
 		return newInvalidStyleID(styleID)
 	}
@@ -474,8 +768,8 @@ func (sw *StreamWriter) SetColWidth(minVal, maxVal int, width float64) error {
 	if minVal < MinColumns || minVal > MaxColumns || maxVal < MinColumns || maxVal > MaxColumns {
 		return ErrColumnNumber
 	}
-	if width > MaxColumnWidth {
-		return ErrColumnWidth
+	if err := checkColWidth(width); err != nil {
+		return err
 	}
 	if minVal > maxVal {
 		minVal, maxVal = maxVal, minVal
@@ -484,6 +778,48 @@ func (sw *StreamWriter) SetColWidth(minVal, maxVal int, width float64) error {
 	return nil
 }
 
+// SetColVisible provides a function to set the visibility of a single column
+// or multiple columns for the StreamWriter. Note that you must call the
+// 'SetColVisible' function before the 'SetRow' function. For example hide
+// the columns D:F:
+//
+//	err := sw.SetColVisible(4, 6, false)
+func (sw *StreamWriter) SetColVisible(minVal, maxVal int, visible bool) error {
+	if sw.sheetWritten {
+		return ErrStreamSetColVisible
+	}
+	if minVal < MinColumns || minVal > MaxColumns || maxVal < MinColumns || maxVal > MaxColumns {
+		return ErrColumnNumber
+	}
+	if minVal > maxVal {
+		minVal, maxVal = maxVal, minVal
+	}
+	sw.worksheet.setColVisible(minVal, maxVal, visible)
+	return nil
+}
+
+// SetColOutlineLevel provides a function to set the outline level of a single
+// column for the StreamWriter. The value of parameter 'level' is 1-7. Note
+// that you must call the 'SetColOutlineLevel' function before the 'SetRow'
+// function. For example set outline level of column D to 2:
+//
+//	err := sw.SetColOutlineLevel(4, 2)
+func (sw *StreamWriter) SetColOutlineLevel(col int, level uint8) error {
+	if sw.sheetWritten {
+		return ErrStreamSetColOutlineLevel
+	}
+	if col < MinColumns || col > MaxColumns {
+		return ErrColumnNumber
+	}
+	if level > 7 || level < 1 {
+		return ErrOutlineLevel
+	}
+	sw.worksheet.updateCols(xlsxCol{Min: col, Max: col, CustomWidth: true}, func(c *xlsxCol) {
+		c.OutlineLevel = level
+	})
+	return nil
+}
+
 // InsertPageBreak creates a page break to determine where the printed page ends
 // and where begins the next one by a given cell reference, the content before
 // the page break will be printed on one page and after the page break on
@@ -519,6 +855,219 @@ func (sw *StreamWriter) MergeCell(topLeftCell, bottomRightCell string) error {
 	return nil
 }
 
+// TransformOptions directly maps the settings of transforming a worksheet by
+// the TransformSheet function. The CopyColWidths specifies if copy the column
+// widths of the source worksheet, and the CopyHeaderStyles specifies if copy
+// the cell styles of the first row of the source worksheet to the row written
+// from it, no styles will be copied if the first row is skipped by the
+// transform function. The RawCellValue specifies if read the raw value of the source
+// cells without applying the number format. The Progress callback function
+// will be called with the number of the read and written rows every
+// ProgressInterval source rows and after the last row, the default interval
+// is 10000 rows.
+type TransformOptions struct {
+	CopyColWidths    bool
+	CopyHeaderStyles bool
+	RawCellValue     bool
+	ProgressInterval int
+	Progress         func(read, written int)
+}
+
+// TransformSheet provides a function to copy the rows of a worksheet into the
+// stream writer of another workbook through a transform function, by given
+// source workbook, source worksheet name, destination stream writer,
+// transform function and transform options. The source rows are read by the
+// rows iterator one by one, so the memory usage doesn't grow with the number
+// of rows. The transform function will be called with the row number and the
+// cells value of each source row, the returned values will be written into
+// the next row of the stream writer, and the row will be skipped if it
+// returns nil. The error returned by the transform function aborts the
+// transformation with the row number. Note that the column widths must be
+// copied before any row written by the stream writer, and the Flush function
+// of the stream writer should be called after the transformation. For
+// example, copy the rows with a non-empty first cell from Sheet1 of
+// input.xlsx into the Sheet1 of a new workbook:
+//
+//	src, err := excelize.OpenFile("input.xlsx")
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	dst := excelize.NewFile()
+//	sw, err := dst.NewStreamWriter("Sheet1")
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	if err := excelize.TransformSheet(src, "Sheet1", sw, func(rowIdx int, in []string) ([]interface{}, error) {
+//	    if len(in) == 0 || in[0] == "" {
+//	        return nil, nil
+//	    }
+//	    out := make([]interface{}, len(in))
+//	    for i, val := range in {
+//	        out[i] = strings.ToUpper(val)
+//	    }
+//	    return out, nil
+//	}, excelize.TransformOptions{CopyColWidths: true, CopyHeaderStyles: true}); err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	if err := sw.Flush(); err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	err = dst.SaveAs("output.xlsx")
+func TransformSheet(src *File, srcSheet string, dst *StreamWriter, fn func(rowIdx int, in []string) ([]interface{}, error), opts TransformOptions) error {
+	if src == nil || dst == nil || fn == nil {
+		return ErrParameterInvalid
+	}
+	rows, err := src.Rows(srcSheet)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+	cols, header, err := src.getSheetHead(srcSheet, opts.CopyColWidths, opts.CopyHeaderStyles)
+	if err != nil {
+		return err
+	}
+	if cols != nil {
+		for _, col := range cols.Col {
+			if col.Width != nil {
+				if err = dst.SetColWidth(col.Min, min(col.Max, MaxColumns), *col.Width); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	if opts.ProgressInterval <= 0 {
+		opts.ProgressInterval = 10000
+	}
+	var read, written int
+	for rows.Next() {
+		in, err := rows.Columns(Options{RawCellValue: opts.RawCellValue})
+		if err != nil {
+			return err
+		}
+		read++
+		out, err := fn(rows.CurrentRow(), in)
+		if err != nil {
+			return newTransformRowError(rows.CurrentRow(), err)
+		}
+		if out != nil {
+			if rows.CurrentRow() == 1 && header != nil {
+				if out, err = src.copyHeaderStyles(dst.file, header, out); err != nil {
+					return err
+				}
+			}
+			cell, _ := CoordinatesToCellName(1, dst.rows+1)
+			if err = dst.SetRow(cell, out); err != nil {
+				return newTransformRowError(rows.CurrentRow(), err)
+			}
+			written++
+		}
+		if opts.Progress != nil && read%opts.ProgressInterval == 0 {
+			opts.Progress(read, written)
+		}
+	}
+	if err = rows.Error(); err != nil {
+		return err
+	}
+	if opts.Progress != nil && read%opts.ProgressInterval != 0 {
+		opts.Progress(read, written)
+	}
+	return rows.Close()
+}
+
+// getSheetHead provides a function to read the columns and the first row of
+// the worksheet by given worksheet name without loading the whole worksheet,
+// the columns and the row will be read only if the withCols and withRow are
+// true, and the row will be nil if the first row of the worksheet is absent.
+func (f *File) getSheetHead(sheet string, withCols, withRow bool) (*xlsxCols, *xlsxRow, error) {
+	if !withCols && !withRow {
+		return nil, nil, nil
+	}
+	name, _ := f.getSheetXMLPath(sheet)
+	needClose, decoder, tempFile, err := f.xmlDecoder(name)
+	if needClose && err == nil {
+		defer func() {
+			_ = tempFile.Close()
+		}()
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	var (
+		cols *xlsxCols
+		row  *xlsxRow
+	)
+	for {
+		token, _ := decoder.Token()
+		if token == nil {
+			break
+		}
+		if start, ok := token.(xml.StartElement); ok {
+			if start.Name.Local == "cols" && withCols {
+				cols = &xlsxCols{}
+				if err = decoder.DecodeElement(cols, &start); err != nil {
+					return nil, nil, err
+				}
+			}
+			if start.Name.Local == "row" {
+				if withRow {
+					row = &xlsxRow{}
+					if err = decoder.DecodeElement(row, &start); err != nil {
+						return nil, nil, err
+					}
+					if row.R > 1 {
+						row = nil
+					}
+				}
+				break
+			}
+		}
+	}
+	return cols, row, err
+}
+
+// copyHeaderStyles provides a function to copy the cell styles of the header
+// row into the destination workbook, and apply them to a copy of the cells
+// value transformed from the header row.
+func (f *File) copyHeaderStyles(dst *File, header *xlsxRow, values []interface{}) ([]interface{}, error) {
+	values = append([]interface{}(nil), values...)
+	styles := make(map[int]int)
+	for i, c := range header.C {
+		col := i + 1
+		if c.R != "" {
+			var err error
+			if col, _, err = CellNameToCoordinates(c.R); err != nil {
+				return values, err
+			}
+		}
+		if c.S == 0 || col > len(values) {
+			continue
+		}
+		switch values[col-1].(type) {
+		case Cell, *Cell, nil:
+			continue
+		}
+		styleID, ok := styles[c.S]
+		if !ok {
+			style, err := f.GetStyle(c.S)
+			if err != nil {
+				return values, err
+			}
+			if styleID, err = dst.NewStyle(style); err != nil {
+				return values, err
+			}
+			styles[c.S] = styleID
+		}
+		values[col-1] = Cell{StyleID: styleID, Value: values[col-1]}
+	}
+	return values, nil
+}
+
 // setCellFormula provides a function to set formula of a cell.
 func setCellFormula(c *xlsxC, formula string) {
 	if formula != "" {
@@ -664,6 +1213,7 @@ func (sw *StreamWriter) writeSheetData() {
 	if !sw.sheetWritten {
 		bulkAppendFields(&sw.rawData, sw.worksheet, 5, 6)
 		if sw.worksheet.Cols != nil {
+			sw.file.mergeExpandedCols(sw.worksheet)
 			_, _ = sw.rawData.WriteString("<cols>")
 			for _, col := range sw.worksheet.Cols.Col {
 				sw.rawData.WriteString(`<col min="`)
@@ -681,6 +1231,14 @@ func (sw *StreamWriter) writeSheetData() {
 					sw.rawData.WriteString(strconv.Itoa(col.Style))
 					sw.rawData.WriteString(`"`)
 				}
+				if col.Hidden {
+					sw.rawData.WriteString(` hidden="1"`)
+				}
+				if col.OutlineLevel != 0 {
+					sw.rawData.WriteString(` outlineLevel="`)
+					sw.rawData.WriteString(strconv.Itoa(int(col.OutlineLevel)))
+					sw.rawData.WriteString(`"`)
+				}
 				sw.rawData.WriteString(`/>`)
 			}
 			_, _ = sw.rawData.WriteString("</cols>")
@@ -693,6 +1251,9 @@ func (sw *StreamWriter) writeSheetData() {
 // Flush ending the streaming writing process.
 func (sw *StreamWriter) Flush() error {
 	sw.writeSheetData()
+	if err := sw.writeColData(); err != nil {
+		return err
+	}
 	_, _ = sw.rawData.WriteString(`</sheetData>`)
 	bulkAppendFields(&sw.rawData, sw.worksheet, 9, 16)
 	mergeCells := strings.Builder{}
@@ -712,7 +1273,7 @@ func (sw *StreamWriter) Flush() error {
 		return err
 	}
 
-	sheetPath := sw.file.sheetMap[sw.Sheet]
+	sheetPath, _ := sw.file.getSheetXMLPath(sw.Sheet)
 	sw.file.Sheet.Delete(sheetPath)
 	sw.file.checked.Delete(sheetPath)
 	sw.file.Pkg.Delete(sheetPath)
@@ -8,6 +8,7 @@ import (
 	"math/rand"
 	"os"
 	"path/filepath"
+	"runtime/metrics"
 	"strings"
 	"testing"
 	"time"
@@ -198,11 +199,60 @@ func TestStreamSetColWidth(t *testing.T) {
 	assert.Equal(t, ErrColumnNumber, streamWriter.SetColWidth(0, 3, 20))
 	assert.Equal(t, ErrColumnNumber, streamWriter.SetColWidth(MaxColumns+1, 3, 20))
 	assert.Equal(t, ErrColumnWidth, streamWriter.SetColWidth(1, 3, MaxColumnWidth+1))
+	assert.Equal(t, ErrColumnWidthValue, streamWriter.SetColWidth(1, 3, -1))
+	assert.Equal(t, ErrColumnWidthValue, streamWriter.SetColWidth(1, 3, math.NaN()))
 	assert.NoError(t, streamWriter.SetRow("A1", []interface{}{"A", "B", "C"}))
 	assert.Equal(t, ErrStreamSetColWidth, streamWriter.SetColWidth(2, 3, 20))
 	assert.NoError(t, streamWriter.Flush())
 }
 
+func TestStreamSetColVisibleAndOutlineLevel(t *testing.T) {
+	file := NewFile()
+	defer func() {
+		assert.NoError(t, file.Close())
+	}()
+	styleID, err := file.NewStyle(&Style{Font: &Font{Bold: true}})
+	assert.NoError(t, err)
+	streamWriter, err := file.NewStreamWriter("Sheet1")
+	assert.NoError(t, err)
+	assert.NoError(t, streamWriter.SetColVisible(3, 2, false))
+	assert.NoError(t, streamWriter.SetColStyle(4, 4, styleID))
+	assert.NoError(t, streamWriter.SetColOutlineLevel(3, 2))
+	assert.NoError(t, streamWriter.SetColOutlineLevel(4, 1))
+	assert.Equal(t, ErrColumnNumber, streamWriter.SetColVisible(0, 3, false))
+	assert.Equal(t, ErrColumnNumber, streamWriter.SetColVisible(1, MaxColumns+1, false))
+	assert.Equal(t, ErrColumnNumber, streamWriter.SetColOutlineLevel(0, 1))
+	assert.Equal(t, ErrColumnNumber, streamWriter.SetColOutlineLevel(MaxColumns+1, 1))
+	assert.Equal(t, ErrOutlineLevel, streamWriter.SetColOutlineLevel(1, 0))
+	assert.Equal(t, ErrOutlineLevel, streamWriter.SetColOutlineLevel(1, 8))
+	assert.NoError(t, streamWriter.SetRow("A1", []interface{}{"A", "B", "C", "D"}))
+	assert.Equal(t, ErrStreamSetColVisible, streamWriter.SetColVisible(2, 3, true))
+	assert.Equal(t, ErrStreamSetColOutlineLevel, streamWriter.SetColOutlineLevel(2, 1))
+	assert.NoError(t, streamWriter.Flush())
+	// Test the columns element is written ahead of the sheet data
+	sheetXML := string(file.readXML("xl/worksheets/sheet1.xml"))
+	assert.Contains(t, sheetXML, fmt.Sprintf(`<cols><col min="2" max="2" width="10.5" customWidth="1" hidden="1"/><col min="3" max="3" width="10.5" customWidth="1" hidden="1" outlineLevel="2"/><col min="4" max="4" width="10.5" customWidth="1" style="%d" outlineLevel="1"/></cols><sheetData>`, styleID))
+	path := filepath.Join("test", "TestStreamSetColVisibleAndOutlineLevel.xlsx")
+	assert.NoError(t, file.SaveAs(path))
+
+	f, err := OpenFile(path)
+	assert.NoError(t, err)
+	for col, expected := range map[string]bool{"A": true, "B": false, "C": false, "D": true} {
+		visible, err := f.GetColVisible("Sheet1", col)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, visible, col)
+	}
+	for col, expected := range map[string]uint8{"A": 0, "B": 0, "C": 2, "D": 1} {
+		level, err := f.GetColOutlineLevel("Sheet1", col)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, level, col)
+	}
+	style, err := f.GetColStyle("Sheet1", "D")
+	assert.NoError(t, err)
+	assert.Equal(t, styleID, style)
+	assert.NoError(t, f.Close())
+}
+
 func TestStreamSetPanes(t *testing.T) {
 	file, paneOpts := NewFile(), &Panes{
 		Freeze:      true,
@@ -338,6 +388,113 @@ func TestStreamSetRow(t *testing.T) {
 	assert.EqualError(t, streamWriter.SetRow("A2", []interface{}{time.Now()}), "XML syntax error on line 1: invalid UTF-8")
 }
 
+func TestStreamSetCol(t *testing.T) {
+	file := NewFile()
+	styleID, err := file.NewStyle(&Style{Font: &Font{Color: "777777"}})
+	assert.NoError(t, err)
+	streamWriter, err := file.NewStreamWriter("Sheet1")
+	assert.NoError(t, err)
+	assert.NoError(t, streamWriter.SetCol("A1", []interface{}{"Time", 1, 2, 3}))
+	assert.NoError(t, streamWriter.SetCol("B1", []interface{}{
+		"Sensor 1", 20.1, nil, Cell{StyleID: styleID, Value: 20.3},
+	}, ColOpts{Width: 20, OutlineLevel: 1}))
+	assert.NoError(t, streamWriter.SetCol("C2", []interface{}{
+		[]RichTextRun{{Text: "Rich "}, {Text: "Text", Font: &Font{Bold: true}}},
+		&Cell{Formula: "SUM(A2:B2)"},
+	}, ColOpts{StyleID: styleID, Hidden: true}))
+	assert.NoError(t, streamWriter.SetCol("E3", []interface{}{nil}))
+	assert.NoError(t, streamWriter.SetCol("F1", []interface{}{"Sensor 2"}))
+	// Test set column with non-ascending column number
+	assert.Equal(t, newStreamSetColError(6), streamWriter.SetCol("F2", []interface{}{1}))
+	// Test set row and column in the same stream writer
+	assert.Equal(t, ErrStreamSetCol, streamWriter.SetRow("A5", []interface{}{1}))
+	assert.NoError(t, streamWriter.AddTable(&Table{Range: "A1:B4"}))
+	assert.NoError(t, streamWriter.Flush())
+	assert.Equal(t, ErrStreamSetCol, streamWriter.SetCol("G1", []interface{}{1}))
+	assert.NoError(t, file.SaveAs(filepath.Join("test", "TestStreamSetCol.xlsx")))
+	assert.NoError(t, file.Close())
+
+	file, err = OpenFile(filepath.Join("test", "TestStreamSetCol.xlsx"))
+	assert.NoError(t, err)
+	rows, err := file.GetRows("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{
+		{"Time", "Sensor 1", "", "", "", "Sensor 2"},
+		{"1", "20.1", "Rich Text"},
+		{"2", "", ""},
+		{"3", "20.3"},
+	}, rows)
+	formula, err := file.GetCellFormula("Sheet1", "C3")
+	assert.NoError(t, err)
+	assert.Equal(t, "SUM(A2:B2)", formula)
+	for cell, expected := range map[string]int{"A2": 0, "B4": styleID, "C2": styleID, "C3": styleID} {
+		style, err := file.GetCellStyle("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, style, cell)
+	}
+	width, err := file.GetColWidth("Sheet1", "B")
+	assert.NoError(t, err)
+	assert.Equal(t, 20.0, width)
+	level, err := file.GetColOutlineLevel("Sheet1", "B")
+	assert.NoError(t, err)
+	assert.Equal(t, uint8(1), level)
+	visible, err := file.GetColVisible("Sheet1", "C")
+	assert.NoError(t, err)
+	assert.False(t, visible)
+	tables, err := file.GetTables("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, tables, 1)
+	assert.Equal(t, "A1:B4", tables[0].Range)
+	assert.NoError(t, file.Close())
+
+	// Test set column after set row
+	file = NewFile()
+	defer func() {
+		assert.NoError(t, file.Close())
+	}()
+	streamWriter, err = file.NewStreamWriter("Sheet1")
+	assert.NoError(t, err)
+	assert.NoError(t, streamWriter.SetRow("A1", []interface{}{1}))
+	assert.Equal(t, ErrStreamSetCol, streamWriter.SetCol("B1", []interface{}{1}))
+	// Test set column with invalid options
+	streamWriter, err = file.NewStreamWriter("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), streamWriter.SetCol("A", []interface{}{}))
+	assert.Equal(t, ErrMaxRows, streamWriter.SetCol("A1048576", []interface{}{1, 2}))
+	assert.Equal(t, ErrColumnWidth, streamWriter.SetCol("A1", nil, ColOpts{Width: MaxColumnWidth + 1}))
+	assert.Equal(t, ErrOutlineLevel, streamWriter.SetCol("A1", nil, ColOpts{OutlineLevel: 8}))
+	assert.Equal(t, newInvalidStyleID(-1), streamWriter.SetCol("A1", nil, ColOpts{StyleID: -1}))
+	assert.Equal(t, newInvalidStyleID(10), streamWriter.SetCol("A1", nil, ColOpts{StyleID: 10}))
+	// Test set column with unsupported charset workbook
+	file.WorkBook = nil
+	file.Pkg.Store(defaultXMLPathWorkbook, MacintoshCyrillicCharset)
+	assert.EqualError(t, streamWriter.SetCol("A1", []interface{}{time.Now()}), "XML syntax error on line 1: invalid UTF-8")
+	// Test set column with unsupported charset style sheet
+	file.Styles = nil
+	file.Pkg.Store(defaultXMLPathStyles, MacintoshCyrillicCharset)
+	assert.EqualError(t, streamWriter.SetCol("B1", nil, ColOpts{StyleID: 1}), "XML syntax error on line 1: invalid UTF-8")
+	// Test interleave columns with corrupted column data
+	streamWriter.streamCols = []*streamCol{{col: 1, end: 1}}
+	assert.Equal(t, ErrParameterInvalid, streamWriter.Flush())
+}
+
+func BenchmarkStreamSetCol(b *testing.B) {
+	values := make([]interface{}, 1000)
+	for i := range values {
+		values[i] = float64(i) / 10
+	}
+	for i := 0; i < b.N; i++ {
+		file := NewFile()
+		streamWriter, _ := file.NewStreamWriter("Sheet1")
+		for col := 1; col <= 100; col++ {
+			cell, _ := CoordinatesToCellName(col, 1)
+			_ = streamWriter.SetCol(cell, values)
+		}
+		_ = streamWriter.Flush()
+		_ = file.Close()
+	}
+}
+
 func TestStreamSetRowNilValues(t *testing.T) {
 	file := NewFile()
 	defer func() {
@@ -487,3 +644,241 @@ func TestStreamWriterGetRowElement(t *testing.T) {
 		assert.False(t, ok)
 	}
 }
+
+func TestTransformSheet(t *testing.T) {
+	src := NewFile()
+	styleID, err := src.NewStyle(&Style{Font: &Font{Bold: true, Color: "777777"}})
+	assert.NoError(t, err)
+	for cell, row := range map[string]*[]interface{}{
+		"A1": {"Name", "Score", "Note"},
+		"A2": {"Alice", 90},
+		"A4": {"Bob", 70, "retake"},
+		"A5": {"Carol", 85},
+	} {
+		assert.NoError(t, src.SetSheetRow("Sheet1", cell, row))
+	}
+	assert.NoError(t, src.SetCellStyle("Sheet1", "A1", "B1", styleID))
+	assert.NoError(t, src.SetColWidth("Sheet1", "A", "B", 20))
+	dst := NewFile()
+	sw, err := dst.NewStreamWriter("Sheet1")
+	assert.NoError(t, err)
+	var progress [][]int
+	assert.NoError(t, TransformSheet(src, "Sheet1", sw, func(rowIdx int, in []string) ([]interface{}, error) {
+		if len(in) == 0 || in[0] == "Bob" {
+			return nil, nil
+		}
+		out := []interface{}{strings.ToUpper(in[0]), rowIdx}
+		if rowIdx == 1 {
+			out[1] = Cell{Value: "Row"}
+		}
+		return out, nil
+	}, TransformOptions{
+		CopyColWidths:    true,
+		CopyHeaderStyles: true,
+		ProgressInterval: 2,
+		Progress: func(read, written int) {
+			progress = append(progress, []int{read, written})
+		},
+	}))
+	assert.NoError(t, sw.Flush())
+	assert.Equal(t, [][]int{{2, 2}, {4, 2}, {5, 3}}, progress)
+	rows, err := dst.GetRows("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{{"NAME", "Row"}, {"ALICE", "2"}, {"CAROL", "5"}}, rows)
+	width, err := dst.GetColWidth("Sheet1", "B")
+	assert.NoError(t, err)
+	assert.Equal(t, 20.0, width)
+	dstStyleID, err := dst.GetCellStyle("Sheet1", "A1")
+	assert.NoError(t, err)
+	style, err := dst.GetStyle(dstStyleID)
+	assert.NoError(t, err)
+	assert.True(t, style.Font.Bold)
+	dstStyleID, err = dst.GetCellStyle("Sheet1", "B1")
+	assert.NoError(t, err)
+	assert.Zero(t, dstStyleID)
+	assert.NoError(t, dst.SaveAs(filepath.Join("test", "TestTransformSheet.xlsx")))
+	assert.NoError(t, dst.Close())
+
+	// Test transform sheet without the header row, and the returned values be
+	// kept unchanged
+	dst = NewFile()
+	sw, err = dst.NewStreamWriter("Sheet1")
+	assert.NoError(t, err)
+	var returned [][]interface{}
+	assert.NoError(t, TransformSheet(src, "Sheet1", sw, func(rowIdx int, in []string) ([]interface{}, error) {
+		if len(in) == 0 || (rowIdx == 1 && in[0] == "") {
+			return nil, nil
+		}
+		out := []interface{}{in[0], in[1]}
+		returned = append(returned, out)
+		return out, nil
+	}, TransformOptions{CopyHeaderStyles: true}))
+	assert.NoError(t, sw.Flush())
+	assert.Equal(t, []interface{}{"Name", "Score"}, returned[0])
+	dstStyleID, err = dst.GetCellStyle("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.NotZero(t, dstStyleID)
+	assert.NoError(t, dst.Close())
+	dst = NewFile()
+	sw, err = dst.NewStreamWriter("Sheet1")
+	assert.NoError(t, err)
+	assert.NoError(t, TransformSheet(src, "Sheet1", sw, func(rowIdx int, in []string) ([]interface{}, error) {
+		if rowIdx == 1 || len(in) == 0 {
+			return nil, nil
+		}
+		return []interface{}{in[0], in[1]}, nil
+	}, TransformOptions{CopyHeaderStyles: true}))
+	assert.NoError(t, sw.Flush())
+	for _, cell := range []string{"A1", "B1"} {
+		dstStyleID, err = dst.GetCellStyle("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Zero(t, dstStyleID)
+	}
+	assert.NoError(t, dst.Close())
+	// Test transform sheet with the first row absent in the source worksheet
+	emptyHead := NewFile()
+	_, err = emptyHead.NewStyle(&Style{Font: &Font{Bold: true}})
+	assert.NoError(t, err)
+	emptyHead.Pkg.Store("xl/worksheets/sheet1.xml", []byte(xml.Header+`<worksheet xmlns="`+NameSpaceSpreadSheet.Value+`"><sheetData><row r="2"><c r="A2" s="1" t="inlineStr"><is><t>Alice</t></is></c></row></sheetData></worksheet>`))
+	emptyHead.Sheet.Delete("xl/worksheets/sheet1.xml")
+	dst = NewFile()
+	sw, err = dst.NewStreamWriter("Sheet1")
+	assert.NoError(t, err)
+	assert.NoError(t, TransformSheet(emptyHead, "Sheet1", sw, func(rowIdx int, in []string) ([]interface{}, error) {
+		if len(in) == 0 {
+			return nil, nil
+		}
+		return []interface{}{in[0]}, nil
+	}, TransformOptions{CopyHeaderStyles: true}))
+	assert.NoError(t, sw.Flush())
+	rows, err = dst.GetRows("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{{"Alice"}}, rows)
+	dstStyleID, err = dst.GetCellStyle("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Zero(t, dstStyleID)
+	assert.NoError(t, dst.Close())
+	assert.NoError(t, emptyHead.Close())
+
+	// Test transform sheet with error returned by the transform function
+	dst = NewFile()
+	sw, err = dst.NewStreamWriter("Sheet1")
+	assert.NoError(t, err)
+	assert.EqualError(t, TransformSheet(src, "Sheet1", sw, func(rowIdx int, in []string) ([]interface{}, error) {
+		if rowIdx == 4 {
+			return nil, ErrParameterInvalid
+		}
+		return []interface{}{rowIdx}, nil
+	}, TransformOptions{}), "failed to transform row 4: "+ErrParameterInvalid.Error())
+	// Test transform sheet with invalid arguments
+	transform := func(rowIdx int, in []string) ([]interface{}, error) { return nil, nil }
+	assert.Equal(t, ErrParameterInvalid, TransformSheet(nil, "Sheet1", sw, transform, TransformOptions{}))
+	assert.Equal(t, ErrParameterInvalid, TransformSheet(src, "Sheet1", nil, transform, TransformOptions{}))
+	assert.Equal(t, ErrParameterInvalid, TransformSheet(src, "Sheet1", sw, nil, TransformOptions{}))
+	assert.EqualError(t, TransformSheet(src, "SheetN", sw, transform, TransformOptions{}), "sheet SheetN does not exist")
+	// Test transform sheet with the column widths after the rows written
+	assert.Equal(t, ErrStreamSetColWidth, TransformSheet(src, "Sheet1", sw, transform, TransformOptions{CopyColWidths: true}))
+	// Test transform sheet with invalid row written by the stream writer
+	assert.EqualError(t, TransformSheet(src, "Sheet1", sw, func(rowIdx int, in []string) ([]interface{}, error) {
+		return []interface{}{strings.Repeat("c", TotalCellChars+1), []RichTextRun{{Text: strings.Repeat("c", TotalCellChars+1)}}}, nil
+	}, TransformOptions{}), "failed to transform row 1: "+ErrCellCharsLength.Error())
+	assert.NoError(t, dst.Close())
+	// Test transform sheet with invalid cell reference and style of the header
+	ws, err := src.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	ws.SheetData.Row[0].C[0].R = "A"
+	dst = NewFile()
+	sw, err = dst.NewStreamWriter("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")),
+		TransformSheet(src, "Sheet1", sw, transform, TransformOptions{CopyHeaderStyles: true}))
+	ws.SheetData.Row[0].C[0].R = "A1"
+	_, err = src.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	assert.NoError(t, dst.Close())
+	assert.NoError(t, src.Close())
+}
+
+func BenchmarkTransformSheet(b *testing.B) {
+	// The transformation reads the source rows by the rows iterator and
+	// writes them by the stream writer, the peak heap usage sampled during the
+	// transformation is reported as the peak-heap-bytes metric for comparing
+	// the memory usage with the different number of rows.
+	for _, totalRows := range []int{1e4, 1e5} {
+		srcPath := filepath.Join(b.TempDir(), "source.xlsx")
+		file := NewFile()
+		sw, err := file.NewStreamWriter("Sheet1")
+		if err != nil {
+			b.Fatal(err)
+		}
+		for row := 1; row <= totalRows; row++ {
+			cell, _ := CoordinatesToCellName(1, row)
+			if err = sw.SetRow(cell, []interface{}{row, "text"}); err != nil {
+				b.Fatal(err)
+			}
+		}
+		if err = sw.Flush(); err != nil {
+			b.Fatal(err)
+		}
+		if err = file.SaveAs(srcPath); err != nil {
+			b.Fatal(err)
+		}
+		b.Run(fmt.Sprintf("%d", totalRows), func(b *testing.B) {
+			src, err := OpenFile(srcPath)
+			if err != nil {
+				b.Fatal(err)
+			}
+			defer src.Close()
+			stop := sampleHeapPeak()
+			for i := 0; i < b.N; i++ {
+				dst := NewFile()
+				sw, err := dst.NewStreamWriter("Sheet1")
+				if err != nil {
+					b.Fatal(err)
+				}
+				if err = TransformSheet(src, "Sheet1", sw, func(rowIdx int, in []string) ([]interface{}, error) {
+					if rowIdx%2 == 0 {
+						return nil, nil
+					}
+					return []interface{}{in[0], strings.ToUpper(in[1])}, nil
+				}, TransformOptions{}); err != nil {
+					b.Fatal(err)
+				}
+				if err = sw.Flush(); err != nil {
+					b.Fatal(err)
+				}
+				if sw.rows != totalRows/2 {
+					b.Fatalf("expected %d rows, got %d", totalRows/2, sw.rows)
+				}
+				_ = dst.Close()
+			}
+			b.ReportMetric(float64(stop()), "peak-heap-bytes")
+		})
+	}
+}
+
+// sampleHeapPeak starts a goroutine which samples the heap usage every
+// millisecond, and returns a function to stop sampling and get the peak.
+func sampleHeapPeak() func() uint64 {
+	samples := []metrics.Sample{{Name: "/memory/classes/heap/objects:bytes"}}
+	done, result := make(chan struct{}), make(chan uint64)
+	go func() {
+		var peak uint64
+		ticker := time.NewTicker(time.Millisecond)
+		defer ticker.Stop()
+		for {
+			metrics.Read(samples)
+			peak = max(peak, samples[0].Value.Uint64())
+			select {
+			case <-done:
+				result <- peak
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+	return func() uint64 {
+		close(done)
+		return <-result
+	}
+}
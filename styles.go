@@ -13,19 +13,28 @@ package excelize
 
 import (
 	"bytes"
+	"encoding/binary"
+	"encoding/hex"
 	"encoding/xml"
 	"fmt"
 	"io"
 	"math"
+	"path"
 	"reflect"
+	"regexp"
 	"sort"
 	"strconv"
 	"strings"
+	"unicode/utf16"
 )
 
 // stylesReader provides a function to get the pointer to the structure after
-// deserialization of xl/styles.xml.
+// deserialization of xl/styles.xml. The style sheet will be loaded only once
+// under the style sheet loading lock, so this function doesn't require the
+// file lock, and the caller should lock the style sheet before access it.
 func (f *File) stylesReader() (*xlsxStyleSheet, error) {
+	f.stylesMu.Lock()
+	defer f.stylesMu.Unlock()
 	if f.Styles == nil {
 		f.Styles = new(xlsxStyleSheet)
 		if err := f.xmlNewDecoder(bytes.NewReader(namespaceStrictToTransitional(f.readXML(defaultXMLPathStyles)))).
@@ -134,6 +143,14 @@ func parseFormatStyleSet(style *Style) (*Style, error) {
 			return style, ErrFontSize
 		}
 	}
+	if style.Alignment != nil {
+		if style.Alignment.Indent < 0 || style.Alignment.Indent > MaxIndent {
+			return style, ErrIndent
+		}
+		if style.Alignment.ReadingOrder > 2 {
+			return style, ErrReadingOrder
+		}
+	}
 	if style.CustomNumFmt != nil && len(*style.CustomNumFmt) == 0 {
 		err = ErrCustomNumFmt
 	}
@@ -216,10 +233,10 @@ func parseFormatStyleSet(style *Style) (*Style, error) {
 //	 8     | darkUp          | 18    | gray0625
 //	 9     | darkGrid        |       |
 //
-// The 'Alignment.Indent' is an integer value, where an increment of 1
-// represents 3 spaces. Indicates the number of spaces (of the normal style
-// font) of indentation for text in a cell. The number of spaces to indent is
-// calculated as following:
+// The 'Alignment.Indent' is an integer value between 0 and 250, where an
+// increment of 1 represents 3 spaces. Indicates the number of spaces (of the
+// normal style font) of indentation for text in a cell. The number of spaces
+// to indent is calculated as following:
 //
 //	Number of spaces to indent = indent value * 3
 //
@@ -253,7 +270,7 @@ func parseFormatStyleSet(style *Style) (*Style, error) {
 //
 // The 'Alignment.ReadingOrder' is an uint64 value indicating whether the
 // reading order of the cell is left-to-right, right-to-left, or context
-// dependent. the valid value of this field was:
+// dependent. The valid value of this field was:
 //
 //	 Value | Description
 //	-------+----------------------------------------------------
@@ -269,6 +286,11 @@ func parseFormatStyleSet(style *Style) (*Style, error) {
 // The 'Alignment.RelativeIndent' is an integer value to indicate the additional
 // number of spaces of indentation to adjust for text in a cell.
 //
+// The 'Alignment.JustifyLastLine' is a boolean value indicating if the last
+// line of the text should be justified when the horizontal alignment is
+// distributed, and the 'Alignment.ShrinkToFit' is a boolean value indicating
+// if the displayed text in the cell should be shrunk to fit the cell width.
+//
 // The following table shows the type of font underline style used in
 // 'Font.Underline':
 //
@@ -974,13 +996,10 @@ func (f *File) NewStyle(style *Style) (int, error) {
 	if fs.DecimalPlaces != nil && (*fs.DecimalPlaces < 0 || *fs.DecimalPlaces > 30) {
 		fs.DecimalPlaces = intPtr(2)
 	}
-	f.mu.Lock()
 	s, err := f.stylesReader()
 	if err != nil {
-		f.mu.Unlock()
 		return cellXfsID, err
 	}
-	f.mu.Unlock()
 	s.mu.Lock()
 	defer s.mu.Unlock()
 	// check given style already exist.
@@ -1027,6 +1046,9 @@ func (f *File) NewStyle(style *Style) (int, error) {
 }
 
 var (
+	// zebraStripesFormulaExp matches the conditional formatting rule formulas
+	// created by the SetZebraStripes function.
+	zebraStripesFormulaExp = regexp.MustCompile(`^(MOD\(INT\(\(ROW\(\)-ROW\(\$[A-Z]+\$\d+\)(-1)?\)/\d+\),2\)=[01]|ROW\(\)=ROW\(\$[A-Z]+\$\d+\))\+N\("excelize:zebra"\)$`)
 	// styleBorders list all types of the cell border style.
 	styleBorders = []string{
 		"none",
@@ -1142,17 +1164,17 @@ var (
 		"fill": func(xf xlsxXf, s *xlsxStyleSheet) bool {
 			return (xf.ApplyFill == nil || (xf.ApplyFill != nil && *xf.ApplyFill)) &&
 				xf.FillID != nil && s.Fills != nil &&
-				*xf.FillID < len(s.Fills.Fill)
+				*xf.FillID >= 0 && *xf.FillID < len(s.Fills.Fill)
 		},
 		"border": func(xf xlsxXf, s *xlsxStyleSheet) bool {
 			return (xf.ApplyBorder == nil || (xf.ApplyBorder != nil && *xf.ApplyBorder)) &&
 				xf.BorderID != nil && s.Borders != nil &&
-				*xf.BorderID < len(s.Borders.Border)
+				*xf.BorderID >= 0 && *xf.BorderID < len(s.Borders.Border)
 		},
 		"font": func(xf xlsxXf, s *xlsxStyleSheet) bool {
 			return (xf.ApplyFont == nil || (xf.ApplyFont != nil && *xf.ApplyFont)) &&
 				xf.FontID != nil && s.Fonts != nil &&
-				*xf.FontID < len(s.Fonts.Font)
+				*xf.FontID >= 0 && *xf.FontID < len(s.Fonts.Font)
 		},
 		"alignment": func(xf xlsxXf, s *xlsxStyleSheet) bool {
 			return xf.ApplyAlignment == nil || (xf.ApplyAlignment != nil && *xf.ApplyAlignment)
@@ -1387,24 +1409,39 @@ func (clr *decodeCTColor) colorChoice() *string {
 	return nil
 }
 
+// getSchemeColor provides a function to get the hex color code in the color
+// scheme of the workbook theme by given zero-based theme color index, it
+// returns an empty string if the theme or the color doesn't exist.
+func (f *File) getSchemeColor(themeColor int) string {
+	if f.Theme == nil {
+		return ""
+	}
+	clrScheme := f.Theme.ThemeElements.ClrScheme
+	if val, ok := map[int]*string{
+		0:  clrScheme.Lt1.colorChoice(),
+		1:  clrScheme.Dk1.colorChoice(),
+		2:  clrScheme.Lt2.colorChoice(),
+		3:  clrScheme.Dk2.colorChoice(),
+		4:  clrScheme.Accent1.colorChoice(),
+		5:  clrScheme.Accent2.colorChoice(),
+		6:  clrScheme.Accent3.colorChoice(),
+		7:  clrScheme.Accent4.colorChoice(),
+		8:  clrScheme.Accent5.colorChoice(),
+		9:  clrScheme.Accent6.colorChoice(),
+		10: clrScheme.Hlink.colorChoice(),
+		11: clrScheme.FolHlink.colorChoice(),
+	}[themeColor]; ok && val != nil {
+		return *val
+	}
+	return ""
+}
+
 // GetBaseColor returns the preferred hex color code by giving hex color code,
 // indexed color, and theme color.
 func (f *File) GetBaseColor(hexColor string, indexedColor int, themeColor *int) string {
-	if f.Theme != nil && themeColor != nil {
-		clrScheme := f.Theme.ThemeElements.ClrScheme
-		if val, ok := map[int]*string{
-			0: clrScheme.Lt1.colorChoice(),
-			1: clrScheme.Dk1.colorChoice(),
-			2: clrScheme.Lt2.colorChoice(),
-			3: clrScheme.Dk2.colorChoice(),
-			4: clrScheme.Accent1.colorChoice(),
-			5: clrScheme.Accent2.colorChoice(),
-			6: clrScheme.Accent3.colorChoice(),
-			7: clrScheme.Accent4.colorChoice(),
-			8: clrScheme.Accent5.colorChoice(),
-			9: clrScheme.Accent6.colorChoice(),
-		}[*themeColor]; ok && val != nil {
-			return *val
+	if themeColor != nil && *themeColor < 10 {
+		if val := f.getSchemeColor(*themeColor); val != "" {
+			return val
 		}
 	}
 	if len(hexColor) == 6 {
@@ -1423,17 +1460,183 @@ func (f *File) GetBaseColor(hexColor string, indexedColor int, themeColor *int)
 	return hexColor
 }
 
+// colorSettings converts the color element to the color settings, the
+// indexed color will be used only if no RGB and theme color specified.
+func (clr *xlsxColor) colorSettings() Color {
+	c := Color{RGB: clr.RGB, Theme: clr.Theme, Tint: clr.Tint}
+	if c.RGB == "" && c.Theme == nil {
+		c.Indexed = intPtr(clr.Indexed)
+	}
+	return c
+}
+
 // getThemeColor provides a function to convert theme color or index color to
 // RGB color.
 func (f *File) getThemeColor(clr *xlsxColor) string {
-	var RGB string
 	if clr == nil || f.Theme == nil {
-		return RGB
+		return ""
+	}
+	RGB, err := f.ResolveColor(clr.colorSettings())
+	if err != nil {
+		return ""
+	}
+	return strings.TrimPrefix(RGB, "FF")
+}
+
+// getCondFmtColor provides a function to resolve the color of the conditional
+// format by the ResolveColor function, it returns the hex color code with the
+// "#" prefix, or an empty string if the color can't be resolved.
+func (f *File) getCondFmtColor(clr *xlsxColor) string {
+	if clr == nil {
+		return ""
+	}
+	RGB, err := f.ResolveColor(clr.colorSettings())
+	if err != nil {
+		return ""
+	}
+	return "#" + strings.TrimPrefix(RGB, "FF")
+}
+
+// getDrawingColor provides a function to resolve the hex color code of the
+// chart by the ResolveColor function, the alpha channel will be dropped, and
+// the given color will be used without the "#" prefix if it can't be
+// resolved.
+func (f *File) getDrawingColor(color string) string {
+	RGB, err := f.ResolveColor(Color{RGB: color})
+	if err != nil {
+		return strings.ReplaceAll(strings.ToUpper(color), "#", "")
+	}
+	return RGB[2:]
+}
+
+// ResolveColor provides a function to resolve the given color settings into
+// the ARGB hex color code, such as "FF5B9BD5". The theme color will be
+// resolved with the color scheme of the workbook theme, the indexed color
+// will be resolved with the custom indexed color palette of the workbook or
+// the legacy indexed color palette, and then the tint value will be applied
+// to the base color. The theme color takes precedence over the RGB color, and
+// the RGB color takes precedence over the indexed color. For example, get the
+// ARGB color code of the theme color "Blue, Accent 1, Lighter 60%":
+//
+//	theme := 4
+//	color, err := f.ResolveColor(excelize.Color{Theme: &theme, Tint: 0.6})
+func (f *File) ResolveColor(c Color) (string, error) {
+	if c.Tint < -1 || c.Tint > 1 {
+		return "", newInvalidColorError("tint", c.Tint)
+	}
+	alpha, baseColor := "FF", ""
+	if c.Theme != nil {
+		if *c.Theme < 0 || *c.Theme > 11 {
+			return "", newInvalidColorError("theme", *c.Theme)
+		}
+		baseColor = strings.ToUpper(f.getSchemeColor(*c.Theme))
+	}
+	if baseColor == "" && c.RGB != "" {
+		RGB := strings.ToUpper(strings.TrimPrefix(c.RGB, "#"))
+		if _, err := strconv.ParseUint(RGB, 16, 32); err != nil || (len(RGB) != 6 && len(RGB) != 8) {
+			return "", newInvalidColorError("RGB", c.RGB)
+		}
+		if len(RGB) == 8 {
+			alpha, RGB = RGB[:2], RGB[2:]
+		}
+		baseColor = RGB
+	}
+	if baseColor == "" && c.Indexed != nil {
+		if *c.Indexed < 0 {
+			return "", newInvalidColorError("indexed", *c.Indexed)
+		}
+		s, err := f.stylesReader()
+		if err != nil {
+			return "", err
+		}
+		if s.Colors != nil && s.Colors.IndexedColors != nil &&
+			*c.Indexed < len(s.Colors.IndexedColors.RgbColor) {
+			if baseColor = strings.ToUpper(s.Colors.IndexedColors.RgbColor[*c.Indexed].RGB); len(baseColor) == 8 {
+				baseColor = baseColor[2:]
+			}
+		} else if *c.Indexed < len(IndexedColorMapping) {
+			baseColor = IndexedColorMapping[*c.Indexed]
+		}
+		if len(baseColor) != 6 {
+			return "", newInvalidColorError("indexed", *c.Indexed)
+		}
+	}
+	if baseColor == "" {
+		if c.Theme != nil {
+			return "", newInvalidColorError("theme", *c.Theme)
+		}
+		return "", ErrParameterRequired
 	}
-	if RGB = f.GetBaseColor(clr.RGB, clr.Indexed, clr.Theme); RGB != "" {
-		RGB = strings.TrimPrefix(ThemeColor(RGB, clr.Tint), "FF")
+	return alpha + ThemeColor(baseColor, c.Tint)[2:], nil
+}
+
+// GetIndexedColors provides a function to get the custom indexed color
+// palette of the workbook, which overrides the legacy indexed color palette.
+// It returns 64 hex color codes without the alpha channel, or nil if the
+// legacy indexed color palette has not been overridden. For example:
+//
+//	palette, err := f.GetIndexedColors()
+func (f *File) GetIndexedColors() ([]string, error) {
+	s, err := f.stylesReader()
+	if err != nil {
+		return nil, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.Colors == nil || s.Colors.IndexedColors == nil {
+		return nil, err
+	}
+	var palette []string
+	for _, clr := range s.Colors.IndexedColors.RgbColor {
+		RGB := strings.ToUpper(clr.RGB)
+		if len(RGB) == 8 {
+			RGB = RGB[2:]
+		}
+		palette = append(palette, RGB)
 	}
-	return RGB
+	return palette, err
+}
+
+// SetIndexedColors provides a function to override the legacy indexed color
+// palette of the workbook by given 64 hex color codes, for compatibility with
+// the legacy tools. The indexed colors of the styles will be resolved with
+// the custom palette, and the override will be removed if the given palette
+// is empty. For example, override the indexed color 10 to orange:
+//
+//	palette := append([]string{}, excelize.IndexedColorMapping[:64]...)
+//	palette[10] = "FFA500"
+//	err := f.SetIndexedColors(palette)
+func (f *File) SetIndexedColors(palette []string) error {
+	if len(palette) != 0 && len(palette) != 64 {
+		return ErrIndexedColors
+	}
+	colors := make([]xlsxColor, len(palette))
+	for i, color := range palette {
+		RGB, err := f.ResolveColor(Color{RGB: color})
+		if err != nil {
+			return err
+		}
+		colors[i] = xlsxColor{RGB: RGB}
+	}
+	s, err := f.stylesReader()
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(colors) == 0 {
+		if s.Colors != nil {
+			if s.Colors.IndexedColors = nil; s.Colors.MruColors == nil {
+				s.Colors = nil
+			}
+		}
+		return err
+	}
+	if s.Colors == nil {
+		s.Colors = &xlsxStyleColors{}
+	}
+	s.Colors.IndexedColors = &xlsxIndexedColors{RgbColor: colors}
+	return err
 }
 
 // extractBorders provides a function to extract borders styles settings by
@@ -1610,12 +1813,12 @@ func (f *File) extractProtection(p *xlsxProtection, s *xlsxStyleSheet, style *St
 // GetStyle provides a function to get style definition by given style index.
 func (f *File) GetStyle(idx int) (*Style, error) {
 	var style *Style
-	f.mu.Lock()
 	s, err := f.stylesReader()
 	if err != nil {
 		return style, err
 	}
-	f.mu.Unlock()
+	s.mu.Lock()
+	defer s.mu.Unlock()
 	if idx < 0 || s.CellXfs == nil || len(s.CellXfs.Xf) <= idx {
 		return style, newInvalidStyleID(idx)
 	}
@@ -1676,17 +1879,16 @@ func (f *File) getStyleID(ss *xlsxStyleSheet, style *Style) (int, error) {
 // format by given style format. The parameters are the same with the NewStyle
 // function.
 func (f *File) NewConditionalStyle(style *Style) (int, error) {
-	f.mu.Lock()
 	s, err := f.stylesReader()
 	if err != nil {
-		f.mu.Unlock()
 		return 0, err
 	}
-	f.mu.Unlock()
 	fs, err := parseFormatStyleSet(style)
 	if err != nil {
 		return 0, err
 	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
 	if fs.DecimalPlaces != nil && (*fs.DecimalPlaces < 0 || *fs.DecimalPlaces > 30) {
 		fs.DecimalPlaces = intPtr(2)
 	}
@@ -1718,12 +1920,12 @@ func (f *File) NewConditionalStyle(style *Style) (int, error) {
 // style index.
 func (f *File) GetConditionalStyle(idx int) (*Style, error) {
 	var style *Style
-	f.mu.Lock()
 	s, err := f.stylesReader()
 	if err != nil {
 		return style, err
 	}
-	f.mu.Unlock()
+	s.mu.Lock()
+	defer s.mu.Unlock()
 	if idx < 0 || s.Dxfs == nil || len(s.Dxfs.Dxfs) <= idx {
 		return style, newInvalidStyleID(idx)
 	}
@@ -1781,36 +1983,62 @@ func newDxfNumFmt(styleSheet *xlsxStyleSheet, style *Style, dxf *xlsxDxf) *xlsxN
 	return &xlsxNumFmt{NumFmtID: numFmtID, FormatCode: fc}
 }
 
-// GetDefaultFont provides the default font name currently set in the
-// workbook. The spreadsheet generated by excelize default font is Calibri.
-func (f *File) GetDefaultFont() (string, error) {
+// GetDefaultFont provides the default font name and size in points currently
+// set in the workbook, which is the font of the Normal style. The spreadsheet
+// generated by excelize default font is Calibri with the size of 11 points.
+func (f *File) GetDefaultFont() (string, float64, error) {
 	font, err := f.readDefaultFont()
 	if err != nil {
-		return "", err
+		return "", 0, err
 	}
-	return *font.Name.Val, err
+	size := defaultFontSize
+	if font.Sz != nil && font.Sz.Val != nil && *font.Sz.Val > 0 {
+		size = *font.Sz.Val
+	}
+	return *font.Name.Val, size, err
 }
 
-// SetDefaultFont changes the default font in the workbook.
-func (f *File) SetDefaultFont(fontName string) error {
+// SetDefaultFont changes the default font name and size in points of the
+// workbook, which is the font of the Normal style. The minor and major fonts
+// of the theme will be changed to the font too, so the charts and shapes
+// which use the theme fonts inherit it. The maximum digit width for
+// converting the column width between the number of characters and pixels
+// will be scaled by the font size, so the column widths in pixels and the
+// positions of the pictures, charts and shapes follow the default font. For
+// example, change the default font to Arial with the size of 10 points:
+//
+//	err := f.SetDefaultFont("Arial", 10)
+func (f *File) SetDefaultFont(fontName string, size float64) error {
+	if size < MinFontSize || size > MaxFontSize {
+		return ErrFontSize
+	}
 	font, err := f.readDefaultFont()
 	if err != nil {
 		return err
 	}
-	font.Name.Val = stringPtr(fontName)
-	f.mu.Lock()
 	s, _ := f.stylesReader()
-	f.mu.Unlock()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	font.Name.Val = stringPtr(fontName)
+	font.Sz = &attrValFloat{Val: float64Ptr(size)}
 	s.Fonts.Font[0] = font
 	custom := true
 	s.CellStyles.CellStyle[0].CustomBuiltIn = &custom
+	if f.Theme != nil {
+		for _, fonts := range []*decodeFontCollection{
+			&f.Theme.ThemeElements.FontScheme.MajorFont,
+			&f.Theme.ThemeElements.FontScheme.MinorFont,
+		} {
+			fonts.Latin = &xlsxCTTextFont{Typeface: fontName}
+		}
+	}
 	return err
 }
 
-// readDefaultFont provides an un-marshalled font value.
+// readDefaultFont provides an un-marshalled font value. This function doesn't
+// lock the style sheet, because it will be called when creating the style with
+// the style sheet locked.
 func (f *File) readDefaultFont() (*xlsxFont, error) {
-	f.mu.Lock()
-	defer f.mu.Unlock()
 	s, err := f.stylesReader()
 	if err != nil {
 		return nil, err
@@ -1818,6 +2046,218 @@ func (f *File) readDefaultFont() (*xlsxFont, error) {
 	return s.Fonts.Font[0], err
 }
 
+// RegisterFontMetrics provides a function to register the metrics of the font
+// by given font family name and font metrics, the font family name is case
+// insensitive. The registered metrics will be used for measuring the text
+// width of the cells with the font by the AutoFitColWidth function, and the
+// maximum digit width of the registered metrics of the default font will be
+// used for converting the column width between the number of characters and
+// pixels, instead of the built-in estimates. This function is concurrency
+// safe. For example, register the metrics of a font with a maximum digit
+// width of 7 pixels:
+//
+//	err := f.RegisterFontMetrics("Arial", excelize.FontMetrics{
+//	    MaxDigitWidth: 7,
+//	    CharWidths:    map[rune]float64{'W': 11, 'i': 3},
+//	})
+func (f *File) RegisterFontMetrics(name string, metrics FontMetrics) error {
+	if name == "" || metrics.MaxDigitWidth <= 0 || metrics.DefaultCharWidth < 0 {
+		return ErrParameterInvalid
+	}
+	charWidths := make(map[rune]float64, len(metrics.CharWidths))
+	for r, width := range metrics.CharWidths {
+		if width < 0 {
+			return ErrParameterInvalid
+		}
+		charWidths[r] = width
+	}
+	metrics.CharWidths = charWidths
+	f.fontMetrics.Store(strings.ToLower(name), metrics)
+	return nil
+}
+
+// getFontMetrics provides a function to get the registered metrics of the
+// font by given font family name.
+func (f *File) getFontMetrics(name string) (FontMetrics, bool) {
+	if metrics, ok := f.fontMetrics.Load(strings.ToLower(name)); ok {
+		return metrics.(FontMetrics), ok
+	}
+	return FontMetrics{}, false
+}
+
+// getMaxDigitWidth provides a function to get the maximum digit width in
+// pixels of the default font, the built-in estimate will be used if the
+// metrics of the default font has not been registered. The width will be
+// scaled by the size of the default font and rounded to the whole pixels.
+func (f *File) getMaxDigitWidth() (float64, error) {
+	name, size, err := f.GetDefaultFont()
+	if err != nil {
+		return defaultMaxDigitWidth, err
+	}
+	maxDigitWidth := defaultMaxDigitWidth
+	if metrics, ok := f.getFontMetrics(name); ok {
+		maxDigitWidth = metrics.MaxDigitWidth
+	}
+	if size != defaultFontSize {
+		maxDigitWidth = math.Max(math.Round(maxDigitWidth*size/defaultFontSize), 1)
+	}
+	return maxDigitWidth, err
+}
+
+// getTextWidth provides a function to get the width of the text in pixels at
+// the font size of 11 points by the font metrics, the width of the longest
+// line will be returned for the multi-line text.
+func (metrics *FontMetrics) getTextWidth(text string) float64 {
+	defaultCharWidth := metrics.DefaultCharWidth
+	if defaultCharWidth == 0 {
+		defaultCharWidth = metrics.MaxDigitWidth
+	}
+	var maxWidth float64
+	for _, line := range strings.Split(text, "\n") {
+		var width float64
+		for _, r := range line {
+			if charWidth, ok := metrics.CharWidths[r]; ok {
+				width += charWidth
+				continue
+			}
+			width += defaultCharWidth
+		}
+		maxWidth = math.Max(maxWidth, width)
+	}
+	return maxWidth
+}
+
+// GetEmbeddedFonts provides a function to get the fonts embedded in the
+// workbook, the parts with font content type will be returned in the order of
+// the part path. The embedded font parts will be kept on saving the workbook.
+// For example:
+//
+//	fonts, err := f.GetEmbeddedFonts()
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	for _, font := range fonts {
+//	    fmt.Println(font.Name, font.Path, len(font.Data))
+//	}
+func (f *File) GetEmbeddedFonts() ([]EmbeddedFont, error) {
+	var fonts []EmbeddedFont
+	f.mu.Lock()
+	content, err := f.contentTypesReader()
+	f.mu.Unlock()
+	if err != nil {
+		return fonts, err
+	}
+	content.mu.Lock()
+	defaults, overrides := map[string]string{}, map[string]string{}
+	for _, v := range content.Defaults {
+		defaults[strings.ToLower(v.Extension)] = v.ContentType
+	}
+	for _, v := range content.Overrides {
+		overrides[strings.TrimPrefix(v.PartName, "/")] = v.ContentType
+	}
+	content.mu.Unlock()
+	f.Pkg.Range(func(k, v interface{}) bool {
+		name, ok := k.(string)
+		if !ok {
+			return true
+		}
+		contentType, ok := overrides[name]
+		if !ok {
+			contentType = defaults[strings.ToLower(strings.TrimPrefix(path.Ext(name), "."))]
+		}
+		if !strings.Contains(strings.ToLower(contentType), "font") {
+			return true
+		}
+		data, _ := v.([]byte)
+		fontData := data
+		if strings.Contains(strings.ToLower(contentType), "obfuscated") || strings.EqualFold(path.Ext(name), ".odttf") {
+			fontData = deobfuscateFont(name, data)
+		}
+		font := EmbeddedFont{Name: parseFontFamilyName(fontData), Path: name, Data: data}
+		if font.Name == "" {
+			font.Name = strings.TrimSuffix(path.Base(name), path.Ext(name))
+		}
+		fonts = append(fonts, font)
+		return true
+	})
+	sort.Slice(fonts, func(i, j int) bool { return fonts[i].Path < fonts[j].Path })
+	return fonts, err
+}
+
+// deobfuscateFont provides a function to restore the obfuscated font data by
+// the GUID in the file name of the font part, the first 32 bytes of the font
+// data was XORed with the font key which is the bytes of the GUID in reverse
+// order. A nil value will be returned if the file name isn't a GUID.
+func deobfuscateFont(name string, data []byte) []byte {
+	guid := strings.NewReplacer("{", "", "}", "", "-", "").Replace(strings.TrimSuffix(path.Base(name), path.Ext(name)))
+	key, err := hex.DecodeString(guid)
+	if err != nil || len(key) != 16 || len(data) < 32 {
+		return nil
+	}
+	fontData := make([]byte, len(data))
+	copy(fontData, data)
+	for i := 0; i < 32; i++ {
+		fontData[i] ^= key[15-i%16]
+	}
+	return fontData
+}
+
+// parseFontFamilyName provides a function to parse the font family name from
+// the naming table of the TrueType or OpenType font data, an empty string
+// will be returned if the font data can't be parsed.
+func parseFontFamilyName(data []byte) string {
+	if len(data) < 12 {
+		return ""
+	}
+	var nameTable []byte
+	for i, numTables := 0, int(binary.BigEndian.Uint16(data[4:6])); i < numTables; i++ {
+		record := 12 + i*16
+		if len(data) < record+16 {
+			return ""
+		}
+		if string(data[record:record+4]) != "name" {
+			continue
+		}
+		offset, length := int(binary.BigEndian.Uint32(data[record+8:record+12])), int(binary.BigEndian.Uint32(data[record+12:record+16]))
+		if offset < 0 || length < 6 || len(data) < offset+length {
+			return ""
+		}
+		nameTable = data[offset : offset+length]
+		break
+	}
+	if nameTable == nil {
+		return ""
+	}
+	var familyName string
+	count, storage := int(binary.BigEndian.Uint16(nameTable[2:4])), int(binary.BigEndian.Uint16(nameTable[4:6]))
+	for i := 0; i < count; i++ {
+		record := 6 + i*12
+		if len(nameTable) < record+12 {
+			break
+		}
+		platformID, nameID := binary.BigEndian.Uint16(nameTable[record:record+2]), binary.BigEndian.Uint16(nameTable[record+6:record+8])
+		length, offset := int(binary.BigEndian.Uint16(nameTable[record+8:record+10])), int(binary.BigEndian.Uint16(nameTable[record+10:record+12]))
+		if nameID != 1 || len(nameTable) < storage+offset+length {
+			continue
+		}
+		str := nameTable[storage+offset : storage+offset+length]
+		switch platformID {
+		case 0, 3:
+			units := make([]uint16, len(str)/2)
+			for j := range units {
+				units[j] = binary.BigEndian.Uint16(str[j*2 : j*2+2])
+			}
+			return string(utf16.Decode(units))
+		case 1:
+			if familyName == "" {
+				familyName = string(str)
+			}
+		}
+	}
+	return familyName
+}
+
 // getFontID provides a function to get font ID.
 // If given font does not exist, will return -1.
 func (f *File) getFontID(styleSheet *xlsxStyleSheet, style *Style) (int, error) {
@@ -1870,9 +2310,15 @@ func newFontColor(font *Font) *xlsxColor {
 // newFont provides a function to add font style by given cell format
 // settings.
 func (f *File) newFont(style *Style) (*xlsxFont, error) {
-	var err error
+	var (
+		err         error
+		defaultSize float64
+	)
 	if style.Font.Size < MinFontSize {
-		style.Font.Size = 11
+		if _, defaultSize, err = f.GetDefaultFont(); err != nil {
+			return nil, err
+		}
+		style.Font.Size = defaultSize
 	}
 	fnt := xlsxFont{
 		Sz:     &attrValFloat{Val: float64Ptr(style.Font.Size)},
@@ -1887,7 +2333,7 @@ func (f *File) newFont(style *Style) (*xlsxFont, error) {
 		fnt.I = &attrValBool{Val: &style.Font.Italic}
 	}
 	if *fnt.Name.Val == "" {
-		if *fnt.Name.Val, err = f.GetDefaultFont(); err != nil {
+		if *fnt.Name.Val, _, err = f.GetDefaultFont(); err != nil {
 			return &fnt, err
 		}
 	}
@@ -2186,6 +2632,78 @@ func setCellXfs(style *xlsxStyleSheet, fontID, numFmtID, fillID, borderID int, a
 	return style.CellXfs.Count - 1, nil
 }
 
+// mergeCellXfs provides a function to combine the cell formatting record by
+// given base and overlay style index. The attribute groups explicitly applied
+// by the overlay record take precedence over the base record, and the index
+// of an identical existing record will be returned instead of appending a new
+// one.
+func (ss *xlsxStyleSheet) mergeCellXfs(baseID, styleID int) (int, error) {
+	xf, overlay := ss.CellXfs.Xf[baseID], ss.CellXfs.Xf[styleID]
+	applied := func(ID *int, apply *bool) bool {
+		return (ID != nil && *ID != 0) || (apply != nil && *apply)
+	}
+	if applied(overlay.NumFmtID, overlay.ApplyNumberFormat) {
+		xf.NumFmtID, xf.ApplyNumberFormat = overlay.NumFmtID, overlay.ApplyNumberFormat
+	}
+	if applied(overlay.FontID, overlay.ApplyFont) {
+		xf.FontID, xf.ApplyFont = overlay.FontID, overlay.ApplyFont
+	}
+	if applied(overlay.FillID, overlay.ApplyFill) {
+		xf.FillID, xf.ApplyFill = overlay.FillID, overlay.ApplyFill
+	}
+	if applied(overlay.BorderID, overlay.ApplyBorder) {
+		xf.BorderID, xf.ApplyBorder = overlay.BorderID, overlay.ApplyBorder
+	}
+	if overlay.Alignment != nil {
+		xf.Alignment, xf.ApplyAlignment = overlay.Alignment, overlay.ApplyAlignment
+	}
+	if overlay.ApplyProtection != nil && *overlay.ApplyProtection {
+		xf.Protection, xf.ApplyProtection = overlay.Protection, overlay.ApplyProtection
+	}
+	return ss.appendCellXf(xf)
+}
+
+// setCellXfsProtection provides a function to derive a cell style from the
+// base style by given style index and the function to modify the protection
+// settings, the other attribute groups of the base style will be kept. The
+// cell is locked and the formula is not hidden if the base style has no
+// protection settings.
+func (ss *xlsxStyleSheet) setCellXfsProtection(baseID int, fn func(p *xlsxProtection)) (int, error) {
+	if baseID < 0 || len(ss.CellXfs.Xf) <= baseID {
+		baseID = 0
+	}
+	xf := ss.CellXfs.Xf[baseID]
+	protection := xlsxProtection{Hidden: boolPtr(false), Locked: boolPtr(true)}
+	if xf.Protection != nil {
+		if xf.Protection.Hidden != nil {
+			protection.Hidden = boolPtr(*xf.Protection.Hidden)
+		}
+		if xf.Protection.Locked != nil {
+			protection.Locked = boolPtr(*xf.Protection.Locked)
+		}
+	}
+	fn(&protection)
+	xf.Protection, xf.ApplyProtection = &protection, boolPtr(true)
+	return ss.appendCellXf(xf)
+}
+
+// appendCellXf provides a function to get the index of the cell style which
+// is identical to the given cell style, or append it to the cell styles if
+// it doesn't exist.
+func (ss *xlsxStyleSheet) appendCellXf(xf xlsxXf) (int, error) {
+	for ID, x := range ss.CellXfs.Xf {
+		if reflect.DeepEqual(x, xf) {
+			return ID, nil
+		}
+	}
+	if len(ss.CellXfs.Xf) == MaxCellStyles {
+		return 0, ErrCellStyles
+	}
+	ss.CellXfs.Xf = append(ss.CellXfs.Xf, xf)
+	ss.CellXfs.Count = len(ss.CellXfs.Xf)
+	return ss.CellXfs.Count - 1, nil
+}
+
 // GetCellStyle provides a function to get cell style index by given worksheet
 // name and cell reference. This function is concurrency safe.
 func (f *File) GetCellStyle(sheet, cell string) (int, error) {
@@ -2353,12 +2871,22 @@ func (f *File) SetCellStyle(sheet, topLeftCell, bottomRightCell string, styleID
 	ws.prepareSheetXML(vCol, vRow)
 	ws.makeContiguousColumns(hRow, vRow, vCol)
 
+	s.mu.Lock()
 	if styleID < 0 || s.CellXfs == nil || len(s.CellXfs.Xf) <= styleID {
+		s.mu.Unlock()
 		return newInvalidStyleID(styleID)
 	}
+	s.mu.Unlock()
 
 	for r := hRowIdx; r <= vRowIdx; r++ {
 		for k := hColIdx; k <= vColIdx; k++ {
+			if f.journal != nil {
+				cell, _ := CoordinatesToCellName(k+1, r+1)
+				f.journal.add(CellChange{
+					Sheet: sheet, Cell: cell, Type: CellChangeStyle,
+					OldValue: strconv.Itoa(ws.SheetData.Row[r].C[k].S), NewValue: strconv.Itoa(styleID),
+				})
+			}
 			ws.SheetData.Row[r].C[k].S = styleID
 		}
 	}
@@ -2778,13 +3306,53 @@ func (f *File) SetCellStyle(sheet, topLeftCell, bottomRightCell string, styleID
 // cells. When this parameter is set then subsequent rules are not evaluated
 // if the current rule is true.
 func (f *File) SetConditionalFormat(sheet, rangeRef string, opts []ConditionalFormatOptions) error {
+	_, err := f.setConditionalFormat(sheet, rangeRef, opts, false)
+	return err
+}
+
+// SetColConditionalFormat provides a function to create conditional
+// formatting rules for the whole columns by given worksheet name, columns
+// range, number of header rows to skip and conditional formatting options.
+// The rules are anchored to the rows from the row after the header rows to
+// the last row of the worksheet, so they also cover the rows appended later,
+// for example by the stream writer. The rules will be merged into the existing
+// conditional formatting with the same range, and the priorities of the
+// created rules will be returned. The rules could be removed with the
+// UnsetConditionalFormat function by the range reference like "F2:F1048576".
+// For example, create a data bar for column F on Sheet1 excluding the header
+// row:
+//
+//	priorities, err := f.SetColConditionalFormat("Sheet1", "F", 1,
+//	    []excelize.ConditionalFormatOptions{
+//	        {Type: "data_bar", Criteria: "=", MinType: "min", MaxType: "max", BarColor: "#638EC6"},
+//	    },
+//	)
+func (f *File) SetColConditionalFormat(sheet, columns string, skipHeaderRows int, opts []ConditionalFormatOptions) ([]int, error) {
+	if skipHeaderRows < 0 || skipHeaderRows >= TotalRows {
+		return nil, ErrParameterInvalid
+	}
+	minCol, maxCol, err := f.parseColRange(columns)
+	if err != nil {
+		return nil, err
+	}
+	topLeftCell, _ := CoordinatesToCellName(minCol, skipHeaderRows+1)
+	bottomRightCell, _ := CoordinatesToCellName(maxCol, TotalRows)
+	return f.setConditionalFormat(sheet, topLeftCell+":"+bottomRightCell, opts, true)
+}
+
+// setConditionalFormat provides a function to create conditional formatting
+// rules by given worksheet name, range reference and conditional formatting
+// options, and returns the priorities of the created rules. The rules will be
+// appended to the existing conditional formatting with the same range if the
+// merge parameter is true.
+func (f *File) setConditionalFormat(sheet, rangeRef string, opts []ConditionalFormatOptions, merge bool) ([]int, error) {
 	ws, err := f.workSheetReader(sheet)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	SQRef, mastCell, err := prepareConditionalFormatRange(rangeRef)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	// Create a pseudo GUID for each unique rule.
 	var rules int
@@ -2793,6 +3361,7 @@ func (f *File) SetConditionalFormat(sheet, rangeRef string, opts []ConditionalFo
 	}
 	var (
 		cfRule          []*xlsxCfRule
+		priorities      []int
 		noCriteriaTypes = []string{
 			"containsBlanks",
 			"notContainsBlanks",
@@ -2817,28 +3386,36 @@ func (f *File) SetConditionalFormat(sheet, rangeRef string, opts []ConditionalFo
 					rule, x14rule := drawFunc(priority, ct, mastCell,
 						fmt.Sprintf("{00000000-0000-0000-%04X-%012X}", f.getSheetID(sheet), priority), &opt)
 					if rule == nil {
-						return ErrParameterInvalid
+						return nil, ErrParameterInvalid
 					}
 					if x14rule != nil {
 						if err = f.appendCfRule(ws, x14rule); err != nil {
-							return err
+							return nil, err
 						}
 						f.addSheetNameSpace(sheet, NameSpaceSpreadSheetX14)
 					}
 					cfRule = append(cfRule, rule)
+					priorities = append(priorities, rule.Priority)
 					continue
 				}
 			}
-			return ErrParameterInvalid
+			return nil, ErrParameterInvalid
+		}
+		return nil, ErrParameterInvalid
+	}
+	if merge {
+		for _, cf := range ws.ConditionalFormatting {
+			if cf.SQRef == SQRef {
+				cf.CfRule = append(cf.CfRule, cfRule...)
+				return priorities, err
+			}
 		}
-		return ErrParameterInvalid
 	}
-
 	ws.ConditionalFormatting = append(ws.ConditionalFormatting, &xlsxConditionalFormatting{
 		SQRef:  SQRef,
 		CfRule: cfRule,
 	})
-	return err
+	return priorities, err
 }
 
 // prepareConditionalFormatRange returns checked cell range and master cell
@@ -3058,12 +3635,12 @@ func (f *File) extractCondFmtColorScale(c *xlsxCfRule, extLst *xlsxExtLst) Condi
 		if c.ColorScale.Cfvo[0].Val != "0" {
 			format.MinValue = c.ColorScale.Cfvo[0].Val
 		}
-		format.MinColor = "#" + f.getThemeColor(c.ColorScale.Color[0])
+		format.MinColor = f.getCondFmtColor(c.ColorScale.Color[0])
 		format.MaxType = c.ColorScale.Cfvo[1].Type
 		if c.ColorScale.Cfvo[1].Val != "0" {
 			format.MaxValue = c.ColorScale.Cfvo[1].Val
 		}
-		format.MaxColor = "#" + f.getThemeColor(c.ColorScale.Color[1])
+		format.MaxColor = f.getCondFmtColor(c.ColorScale.Color[1])
 	}
 	if colors == 3 {
 		format.Type = "3_color_scale"
@@ -3071,12 +3648,12 @@ func (f *File) extractCondFmtColorScale(c *xlsxCfRule, extLst *xlsxExtLst) Condi
 		if c.ColorScale.Cfvo[1].Val != "0" {
 			format.MidValue = c.ColorScale.Cfvo[1].Val
 		}
-		format.MidColor = "#" + f.getThemeColor(c.ColorScale.Color[1])
+		format.MidColor = f.getCondFmtColor(c.ColorScale.Color[1])
 		format.MaxType = c.ColorScale.Cfvo[2].Type
 		if c.ColorScale.Cfvo[2].Val != "0" {
 			format.MaxValue = c.ColorScale.Cfvo[2].Val
 		}
-		format.MaxColor = "#" + f.getThemeColor(c.ColorScale.Color[2])
+		format.MaxColor = f.getCondFmtColor(c.ColorScale.Color[2])
 	}
 	return format
 }
@@ -3092,7 +3669,7 @@ func (f *File) extractCondFmtDataBarRule(ID string, format *ConditionalFormatOpt
 					format.BarSolid = true
 				}
 				if rule.DataBar.BorderColor != nil {
-					format.BarBorderColor = "#" + f.getThemeColor(rule.DataBar.BorderColor)
+					format.BarBorderColor = f.getCondFmtColor(rule.DataBar.BorderColor)
 				}
 			}
 		}
@@ -3109,7 +3686,7 @@ func (f *File) extractCondFmtDataBar(c *xlsxCfRule, extLst *xlsxExtLst) Conditio
 		format.MinValue = c.DataBar.Cfvo[0].Val
 		format.MaxType = c.DataBar.Cfvo[1].Type
 		format.MaxValue = c.DataBar.Cfvo[1].Val
-		format.BarColor = "#" + f.getThemeColor(c.DataBar.Color[0])
+		format.BarColor = f.getCondFmtColor(c.DataBar.Color[0])
 		if c.DataBar.ShowValue != nil {
 			format.BarOnly = !*c.DataBar.ShowValue
 		}
@@ -3196,6 +3773,156 @@ func (f *File) UnsetConditionalFormat(sheet, rangeRef string) error {
 	return nil
 }
 
+// SetZebraStripes provides a function to fill the alternating rows in the
+// range by given worksheet name, range reference and zebra options, without
+// converting the range to a table. The stripes are driven by a conditional
+// formatting rule with the MOD and ROW formula, so the bands stay correct
+// when the rows are inserted or deleted. The default stripe color is F2F2F2,
+// the default band size is 1, and the default header color is D9D9D9. The
+// formulas of the created rules are tagged with the N function which returns
+// zero, so that the RemoveZebraStripes function could recognize them. For
+// example, fill every other two rows in range A1:F20 on Sheet1 with a header
+// row:
+//
+//	err := f.SetZebraStripes("Sheet1", "A1:F20", excelize.ZebraOptions{
+//	    Color:    "DDEBF7",
+//	    BandSize: 2,
+//	    Header:   true,
+//	})
+//
+// Fill the bands in range A1:F20 on Sheet1 with two alternating colors:
+//
+//	err := f.SetZebraStripes("Sheet1", "A1:F20", excelize.ZebraOptions{
+//	    Color:          "DDEBF7",
+//	    AlternateColor: "FFF2CC",
+//	})
+func (f *File) SetZebraStripes(sheet, rangeRef string, opts ZebraOptions) error {
+	if opts.BandSize < 0 {
+		return ErrParameterInvalid
+	}
+	if opts.BandSize == 0 {
+		opts.BandSize = 1
+	}
+	if opts.Color == "" {
+		opts.Color = "F2F2F2"
+	}
+	if opts.HeaderColor == "" {
+		opts.HeaderColor = "D9D9D9"
+	}
+	if strings.ContainsAny(rangeRef, " ,") {
+		return ErrParameterInvalid
+	}
+	ref := rangeRef
+	if !strings.Contains(ref, ":") {
+		ref += ":" + ref
+	}
+	coordinates, err := rangeRefToCoordinates(ref)
+	if err != nil {
+		return err
+	}
+	_ = sortCoordinates(coordinates)
+	firstCell, _ := CoordinatesToCellName(coordinates[0], coordinates[1], true)
+	stripe, err := f.NewConditionalStyle(&Style{Fill: Fill{Type: "pattern", Color: []string{opts.Color}, Pattern: 1}})
+	if err != nil {
+		return err
+	}
+	var rules []ConditionalFormatOptions
+	offset := ""
+	if opts.Header {
+		header, err := f.NewConditionalStyle(&Style{
+			Font: &Font{Bold: true},
+			Fill: Fill{Type: "pattern", Color: []string{opts.HeaderColor}, Pattern: 1},
+		})
+		if err != nil {
+			return err
+		}
+		rules = append(rules, ConditionalFormatOptions{
+			Type: "formula", Criteria: fmt.Sprintf("ROW()=ROW(%s)%s", firstCell, zebraStripesTag), Format: &header, StopIfTrue: true,
+		})
+		offset = "-1"
+	}
+	rules = append(rules, ConditionalFormatOptions{
+		Type:     "formula",
+		Criteria: fmt.Sprintf("MOD(INT((ROW()-ROW(%s)%s)/%d),2)=0%s", firstCell, offset, opts.BandSize, zebraStripesTag),
+		Format:   &stripe,
+	})
+	if opts.AlternateColor != "" {
+		alternate, err := f.NewConditionalStyle(&Style{Fill: Fill{Type: "pattern", Color: []string{opts.AlternateColor}, Pattern: 1}})
+		if err != nil {
+			return err
+		}
+		rules = append(rules, ConditionalFormatOptions{
+			Type:     "formula",
+			Criteria: fmt.Sprintf("MOD(INT((ROW()-ROW(%s)%s)/%d),2)=1%s", firstCell, offset, opts.BandSize, zebraStripesTag),
+			Format:   &alternate,
+		})
+	}
+	return f.SetConditionalFormat(sheet, rangeRef, rules)
+}
+
+// RemoveZebraStripes provides a function to remove the alternating row fills
+// created by the SetZebraStripes function which intersect the range by given
+// worksheet name and range reference, the other conditional formats will be
+// kept. For example, remove the stripes in range A1:F20 on Sheet1:
+//
+//	err := f.RemoveZebraStripes("Sheet1", "A1:F20")
+func (f *File) RemoveZebraStripes(sheet, rangeRef string) error {
+	ref := rangeRef
+	if !strings.Contains(ref, ":") {
+		ref += ":" + ref
+	}
+	rect, err := rangeRefToCoordinates(ref)
+	if err != nil {
+		return err
+	}
+	_ = sortCoordinates(rect)
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return err
+	}
+	var conditionalFormatting []*xlsxConditionalFormatting
+	for _, cf := range ws.ConditionalFormatting {
+		if cf == nil || !isZebraStripes(cf) {
+			conditionalFormatting = append(conditionalFormatting, cf)
+			continue
+		}
+		ref := cf.SQRef
+		if !strings.Contains(ref, ":") {
+			ref += ":" + ref
+		}
+		coordinates, err := rangeRefToCoordinates(ref)
+		if err != nil {
+			return err
+		}
+		_ = sortCoordinates(coordinates)
+		if !isOverlap(rect, coordinates) {
+			conditionalFormatting = append(conditionalFormatting, cf)
+		}
+	}
+	ws.ConditionalFormatting = conditionalFormatting
+	return err
+}
+
+// zebraStripesTag is the suffix of the conditional formatting rule formulas
+// created by the SetZebraStripes function, which doesn't change the result of
+// the formulas.
+const zebraStripesTag = `+N("excelize:zebra")`
+
+// isZebraStripes returns if the conditional formatting was created by the
+// SetZebraStripes function.
+func isZebraStripes(cf *xlsxConditionalFormatting) bool {
+	if len(cf.CfRule) == 0 || strings.Contains(cf.SQRef, " ") {
+		return false
+	}
+	for _, rule := range cf.CfRule {
+		if rule == nil || rule.Type != "expression" || len(rule.Formula) != 1 ||
+			!zebraStripesFormulaExp.MatchString(rule.Formula[0]) {
+			return false
+		}
+	}
+	return true
+}
+
 // drawCondFmtCellIs provides a function to create conditional formatting rule
 // for cell value (include between, not between, equal, not equal, greater
 // than and less than) by given priority, criteria type and format settings.
@@ -1,11 +1,16 @@
 package excelize
 
 import (
+	"encoding/binary"
+	"encoding/xml"
 	"fmt"
 	"math"
 	"path/filepath"
 	"strings"
+	"sync"
 	"testing"
+	"time"
+	"unicode/utf16"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -319,6 +324,143 @@ func TestUnsetConditionalFormat(t *testing.T) {
 	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestUnsetConditionalFormat.xlsx")))
 }
 
+func TestSetColConditionalFormat(t *testing.T) {
+	f := NewFile()
+	format, err := f.NewConditionalStyle(&Style{Font: &Font{Color: "9A0511"}})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetConditionalFormat("Sheet1", "A1:A10", []ConditionalFormatOptions{{Type: "cell", Criteria: ">", Format: &format, Value: "6"}}))
+	dataBar := ConditionalFormatOptions{Type: "data_bar", Criteria: "=", MinType: "min", MaxType: "max", BarColor: "#638EC6"}
+	priorities, err := f.SetColConditionalFormat("Sheet1", "F", 1, []ConditionalFormatOptions{dataBar})
+	assert.NoError(t, err)
+	assert.Equal(t, []int{2}, priorities)
+	// Test merge the rules into the existing conditional formatting with the same range
+	colorScale := ConditionalFormatOptions{Type: "2_color_scale", Criteria: "=", MinType: "min", MaxType: "max", MinColor: "#F8696B", MaxColor: "#63BE7B"}
+	priorities, err = f.SetColConditionalFormat("Sheet1", "F", 1, []ConditionalFormatOptions{colorScale, {Type: "cell", Criteria: "<", Format: &format, Value: "0"}})
+	assert.NoError(t, err)
+	assert.Equal(t, []int{3, 4}, priorities)
+	priorities, err = f.SetColConditionalFormat("Sheet1", "H:G", 0, []ConditionalFormatOptions{colorScale})
+	assert.NoError(t, err)
+	assert.Equal(t, []int{5}, priorities)
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	assert.Len(t, ws.(*xlsxWorksheet).ConditionalFormatting, 3)
+	opts, err := f.GetConditionalFormats("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, opts["F2:F1048576"], 3)
+	assert.Equal(t, []ConditionalFormatOptions{colorScale}, opts["G1:H1048576"])
+
+	// Test the rules cover the rows appended by the stream writer
+	sw, err := f.NewStreamWriter("Sheet1")
+	assert.NoError(t, err)
+	assert.NoError(t, sw.SetRow("F1", []interface{}{"Amount"}))
+	for row := 2; row <= 100; row++ {
+		cell, _ := CoordinatesToCellName(6, row)
+		assert.NoError(t, sw.SetRow(cell, []interface{}{row}))
+	}
+	assert.NoError(t, sw.Flush())
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestSetColConditionalFormat.xlsx")))
+	assert.NoError(t, f.Close())
+	f, err = OpenFile(filepath.Join("test", "TestSetColConditionalFormat.xlsx"))
+	assert.NoError(t, err)
+	opts, err = f.GetConditionalFormats("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, opts["F2:F1048576"], 3)
+	assert.Equal(t, dataBar, opts["F2:F1048576"][0])
+	// Test remove the rules by the range reference
+	assert.NoError(t, f.UnsetConditionalFormat("Sheet1", "F2:F1048576"))
+	opts, err = f.GetConditionalFormats("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, opts, 2)
+
+	// Test set the conditional formats with invalid parameters
+	for _, skipHeaderRows := range []int{-1, TotalRows} {
+		_, err = f.SetColConditionalFormat("Sheet1", "F", skipHeaderRows, []ConditionalFormatOptions{dataBar})
+		assert.Equal(t, ErrParameterInvalid, err)
+	}
+	_, err = f.SetColConditionalFormat("Sheet1", "*", 1, []ConditionalFormatOptions{dataBar})
+	assert.Equal(t, newInvalidColumnNameError("*"), err)
+	_, err = f.SetColConditionalFormat("Sheet1", "F", 1, []ConditionalFormatOptions{{Type: "unknown"}})
+	assert.Equal(t, ErrParameterInvalid, err)
+	_, err = f.SetColConditionalFormat("SheetN", "F", 1, []ConditionalFormatOptions{dataBar})
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+	assert.NoError(t, f.Close())
+}
+
+func TestSetZebraStripes(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetZebraStripes("Sheet1", "B2:D10", ZebraOptions{BandSize: 2, Header: true}))
+	opts, err := f.GetConditionalFormats("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, opts["B2:D10"], 2)
+	assert.Equal(t, "ROW()=ROW($B$2)+N(\"excelize:zebra\")", opts["B2:D10"][0].Criteria)
+	assert.True(t, opts["B2:D10"][0].StopIfTrue)
+	assert.Equal(t, "MOD(INT((ROW()-ROW($B$2)-1)/2),2)=0+N(\"excelize:zebra\")", opts["B2:D10"][1].Criteria)
+	// Test inserting rows keeps the banding anchored on the first row of range
+	assert.NoError(t, f.InsertRows("Sheet1", 5, 2))
+	opts, err = f.GetConditionalFormats("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, opts["B2:D12"], 2)
+	assert.Equal(t, "MOD(INT((ROW()-ROW($B$2)-1)/2),2)=0+N(\"excelize:zebra\")", opts["B2:D12"][1].Criteria)
+	assert.NoError(t, f.InsertRows("Sheet1", 1, 1))
+	opts, err = f.GetConditionalFormats("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, opts["B3:D13"], 2)
+	assert.Equal(t, "ROW()=ROW($B$3)+N(\"excelize:zebra\")", opts["B3:D13"][0].Criteria)
+	assert.Equal(t, "MOD(INT((ROW()-ROW($B$3)-1)/2),2)=0+N(\"excelize:zebra\")", opts["B3:D13"][1].Criteria)
+	// Test remove zebra stripes keeps the other conditional formats
+	format, err := f.NewConditionalStyle(&Style{Font: &Font{Color: "9A0511"}})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetConditionalFormat("Sheet1", "C3:C13", []ConditionalFormatOptions{{Type: "formula", Criteria: "ROW()=ROW($C$3)", Format: &format}, {Type: "cell", Criteria: ">", Format: &format, Value: "6"}}))
+	assert.NoError(t, f.SetConditionalFormat("Sheet1", "B3:B4", []ConditionalFormatOptions{{Type: "formula", Criteria: "MOD(INT((ROW()-ROW($B$3))/1),2)=0", Format: &format}}))
+	assert.NoError(t, f.SetZebraStripes("Sheet1", "F1", ZebraOptions{Color: "DDEBF7"}))
+	assert.NoError(t, f.RemoveZebraStripes("Sheet1", "A1:C3"))
+	opts, err = f.GetConditionalFormats("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, opts, 3)
+	assert.Len(t, opts["C3:C13"], 2)
+	assert.Len(t, opts["B3:B4"], 1)
+	assert.Equal(t, "MOD(INT((ROW()-ROW($F$1))/1),2)=0+N(\"excelize:zebra\")", opts["F1"][0].Criteria)
+	assert.NoError(t, f.RemoveZebraStripes("Sheet1", "F1"))
+	opts, err = f.GetConditionalFormats("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, opts, 2)
+	// Test set zebra stripes with two alternating colors
+	assert.NoError(t, f.SetZebraStripes("Sheet1", "H1:J10", ZebraOptions{Color: "DDEBF7", AlternateColor: "FFF2CC", Header: true}))
+	opts, err = f.GetConditionalFormats("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, opts["H1:J10"], 3)
+	assert.Equal(t, "MOD(INT((ROW()-ROW($H$1)-1)/1),2)=1+N(\"excelize:zebra\")", opts["H1:J10"][2].Criteria)
+	for i, color := range []string{"DDEBF7", "FFF2CC"} {
+		style, err := f.GetConditionalStyle(*opts["H1:J10"][i+1].Format)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{color}, style.Fill.Color)
+	}
+	assert.NoError(t, f.RemoveZebraStripes("Sheet1", "H1"))
+	opts, err = f.GetConditionalFormats("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, opts, 2)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestSetZebraStripes.xlsx")))
+	// Test set zebra stripes with invalid band size
+	assert.Equal(t, ErrParameterInvalid, f.SetZebraStripes("Sheet1", "A1:B2", ZebraOptions{BandSize: -1}))
+	// Test set zebra stripes with invalid range reference
+	assert.Equal(t, ErrParameterInvalid, f.SetZebraStripes("Sheet1", "A1:B2 C1:C2", ZebraOptions{}))
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), f.SetZebraStripes("Sheet1", "A:B2", ZebraOptions{}))
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), f.RemoveZebraStripes("Sheet1", "A:B2"))
+	// Test set and remove zebra stripes on not exists worksheet
+	assert.EqualError(t, f.SetZebraStripes("SheetN", "A1:B2", ZebraOptions{}), "sheet SheetN does not exist")
+	assert.EqualError(t, f.RemoveZebraStripes("SheetN", "A1:B2"), "sheet SheetN does not exist")
+	// Test remove zebra stripes with invalid conditional formatting range
+	ws, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	ws.ConditionalFormatting = []*xlsxConditionalFormatting{{SQRef: "A", CfRule: []*xlsxCfRule{{Type: "expression", Formula: []string{"ROW()=ROW($A$1)" + zebraStripesTag}}}}}
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), f.RemoveZebraStripes("Sheet1", "A1:B2"))
+	// Test set zebra stripes with unsupported charset style sheet
+	f.Styles = nil
+	f.Pkg.Store(defaultXMLPathStyles, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.SetZebraStripes("Sheet1", "A1:B2", ZebraOptions{}), "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
+
 func TestNewStyle(t *testing.T) {
 	f := NewFile()
 	for i := 0; i < 18; i++ {
@@ -495,29 +637,212 @@ func TestConditionalStyle(t *testing.T) {
 
 func TestGetDefaultFont(t *testing.T) {
 	f := NewFile()
-	s, err := f.GetDefaultFont()
+	s, size, err := f.GetDefaultFont()
 	assert.NoError(t, err)
 	assert.Equal(t, s, "Calibri", "Default font should be Calibri")
+	assert.Equal(t, 11.0, size)
+	// Test get default font without font size
+	styles, err := f.stylesReader()
+	assert.NoError(t, err)
+	styles.Fonts.Font[0].Sz = nil
+	_, size, err = f.GetDefaultFont()
+	assert.NoError(t, err)
+	assert.Equal(t, 11.0, size)
 	// Test get default font with unsupported charset style sheet
 	f.Styles = nil
 	f.Pkg.Store(defaultXMLPathStyles, MacintoshCyrillicCharset)
-	_, err = f.GetDefaultFont()
+	_, _, err = f.GetDefaultFont()
 	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
 }
 
 func TestSetDefaultFont(t *testing.T) {
 	f := NewFile()
-	assert.NoError(t, f.SetDefaultFont("Arial"))
+	assert.NoError(t, f.SetDefaultFont("Arial", 20))
 	styles, err := f.stylesReader()
 	assert.NoError(t, err)
-	s, err := f.GetDefaultFont()
+	s, size, err := f.GetDefaultFont()
 	assert.NoError(t, err)
 	assert.Equal(t, s, "Arial", "Default font should change to Arial")
+	assert.Equal(t, 20.0, size)
 	assert.Equal(t, *styles.CellStyles.CellStyle[0].CustomBuiltIn, true)
+	// Test the new font without size inherits the default font
+	style, err := f.NewStyle(&Style{Font: &Font{Bold: true}})
+	assert.NoError(t, err)
+	opts, err := f.GetStyle(style)
+	assert.NoError(t, err)
+	assert.Equal(t, "Arial", opts.Font.Family)
+	assert.Equal(t, 20.0, opts.Font.Size)
+	// Test the column widths in pixels and the pictures follow the default font
+	maxDigitWidth, err := f.getMaxDigitWidth()
+	assert.NoError(t, err)
+	assert.Equal(t, 15.0, maxDigitWidth)
+	pixels, err := f.GetColWidthPixels("Sheet1", "A")
+	assert.NoError(t, err)
+	assert.Equal(t, 158, pixels)
+	assert.Equal(t, 158, f.getColWidth("Sheet1", 1, maxDigitWidth))
+	assert.NoError(t, f.SetColWidth("Sheet1", "B", "B", 4))
+	assert.Equal(t, 60, f.getColWidth("Sheet1", 2, maxDigitWidth))
+	assert.NoError(t, f.AddPicture("Sheet1", "A1", filepath.Join("test", "images", "excel.png"), &GraphicOptions{OffsetX: 10}))
+	drawing, ok := f.Drawings.Load("xl/drawings/drawing1.xml")
+	assert.True(t, ok)
+	assert.Equal(t, xlsxTo{Col: 1, ColOff: PixelsToEMU(52), Row: 6, RowOff: PixelsToEMU(8)}, *drawing.(*xlsxWsDr).TwoCellAnchor[0].To)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestSetDefaultFont.xlsx")))
+	assert.NoError(t, f.Close())
+	// Test the theme fonts changed with the default font
+	f, err = OpenFile(filepath.Join("test", "TestSetDefaultFont.xlsx"))
+	assert.NoError(t, err)
+	assert.Equal(t, "Arial", f.Theme.ThemeElements.FontScheme.MajorFont.Latin.Typeface)
+	assert.Equal(t, "Arial", f.Theme.ThemeElements.FontScheme.MinorFont.Latin.Typeface)
+	s, size, err = f.GetDefaultFont()
+	assert.NoError(t, err)
+	assert.Equal(t, "Arial", s)
+	assert.Equal(t, 20.0, size)
+	assert.NoError(t, f.Close())
+
+	// Test set default font with invalid font size
+	f = NewFile()
+	for _, size := range []float64{0, MaxFontSize + 1} {
+		assert.Equal(t, ErrFontSize, f.SetDefaultFont("Arial", size))
+	}
+	// Test set default font without theme
+	f.Theme = nil
+	assert.NoError(t, f.SetDefaultFont("Arial", 11))
+	maxDigitWidth, err = f.getMaxDigitWidth()
+	assert.NoError(t, err)
+	assert.Equal(t, defaultMaxDigitWidth, maxDigitWidth)
 	// Test set default font with unsupported charset style sheet
 	f.Styles = nil
 	f.Pkg.Store(defaultXMLPathStyles, MacintoshCyrillicCharset)
-	assert.EqualError(t, f.SetDefaultFont("Arial"), "XML syntax error on line 1: invalid UTF-8")
+	assert.EqualError(t, f.SetDefaultFont("Arial", 11), "XML syntax error on line 1: invalid UTF-8")
+}
+
+func TestRegisterFontMetrics(t *testing.T) {
+	f := NewFile()
+	charWidths := map[rune]float64{'W': 11}
+	assert.NoError(t, f.RegisterFontMetrics("Arial", FontMetrics{MaxDigitWidth: 7, CharWidths: charWidths}))
+	charWidths['W'] = 20
+	metrics, ok := f.getFontMetrics("ARIAL")
+	assert.True(t, ok)
+	assert.Equal(t, 11.0, metrics.CharWidths['W'])
+	assert.Equal(t, 11.0+7*2, metrics.getTextWidth("Wab\nW"))
+	_, ok = f.getFontMetrics("Calibri")
+	assert.False(t, ok)
+	maxDigitWidth, err := f.getMaxDigitWidth()
+	assert.NoError(t, err)
+	assert.Equal(t, defaultMaxDigitWidth, maxDigitWidth)
+	// Test register font metrics with invalid parameters
+	for _, metrics := range []FontMetrics{
+		{}, {MaxDigitWidth: 7, DefaultCharWidth: -1}, {MaxDigitWidth: 7, CharWidths: map[rune]float64{'W': -1}},
+	} {
+		assert.Equal(t, ErrParameterInvalid, f.RegisterFontMetrics("Arial", metrics))
+	}
+	assert.Equal(t, ErrParameterInvalid, f.RegisterFontMetrics("", FontMetrics{MaxDigitWidth: 7}))
+	// Test get the maximum digit width with unsupported charset style sheet
+	f.Styles = nil
+	f.Pkg.Store(defaultXMLPathStyles, MacintoshCyrillicCharset)
+	_, err = f.getMaxDigitWidth()
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
+
+// newTestFontData provides a function to build the TrueType font data with
+// only the naming table by given font family names of the platforms.
+func newTestFontData(macName, winName string) []byte {
+	var records, storage []byte
+	addName := func(platformID uint16, str []byte) {
+		record := make([]byte, 12)
+		binary.BigEndian.PutUint16(record[0:], platformID)
+		binary.BigEndian.PutUint16(record[6:], 1)
+		binary.BigEndian.PutUint16(record[8:], uint16(len(str)))
+		binary.BigEndian.PutUint16(record[10:], uint16(len(storage)))
+		records, storage = append(records, record...), append(storage, str...)
+	}
+	if macName != "" {
+		addName(1, []byte(macName))
+	}
+	if winName != "" {
+		var str []byte
+		for _, u := range utf16.Encode([]rune(winName)) {
+			str = binary.BigEndian.AppendUint16(str, u)
+		}
+		addName(3, str)
+	}
+	nameTable := make([]byte, 6)
+	binary.BigEndian.PutUint16(nameTable[2:], uint16(len(records)/12))
+	binary.BigEndian.PutUint16(nameTable[4:], uint16(6+len(records)))
+	nameTable = append(append(nameTable, records...), storage...)
+	data := make([]byte, 12+16*2)
+	binary.BigEndian.PutUint32(data[0:], 0x00010000)
+	binary.BigEndian.PutUint16(data[4:], 2)
+	copy(data[12:], "head")
+	copy(data[28:], "name")
+	binary.BigEndian.PutUint32(data[36:], uint32(len(data)))
+	binary.BigEndian.PutUint32(data[40:], uint32(len(nameTable)))
+	return append(data, nameTable...)
+}
+
+func TestGetEmbeddedFonts(t *testing.T) {
+	f := NewFile()
+	fonts, err := f.GetEmbeddedFonts()
+	assert.NoError(t, err)
+	assert.Empty(t, fonts)
+	fontData := newTestFontData("Mac Font", "Font 字体")
+	f.Pkg.Store("xl/fonts/font1.ttf", fontData)
+	f.Pkg.Store("xl/fonts/font2.odttf", []byte("obfuscated"))
+	// Obfuscate the font data by the font key of the GUID in the part name
+	guid := "{0A1B2C3D-4E5F-6071-8293-A4B5C6D7E8F9}"
+	obfuscated, key := append([]byte{}, fontData...), []byte{0xF9, 0xE8, 0xD7, 0xC6, 0xB5, 0xA4, 0x93, 0x82, 0x71, 0x60, 0x5F, 0x4E, 0x3D, 0x2C, 0x1B, 0x0A}
+	for i := 0; i < 32; i++ {
+		obfuscated[i] ^= key[i%16]
+	}
+	f.Pkg.Store("xl/fonts/"+guid+".odttf", obfuscated)
+	content, err := f.contentTypesReader()
+	assert.NoError(t, err)
+	content.Defaults = append(content.Defaults, xlsxDefault{Extension: "TTF", ContentType: "application/x-font-ttf"})
+	content.Overrides = append(content.Overrides, xlsxOverride{
+		PartName: "/xl/fonts/font2.odttf", ContentType: "application/vnd.openxmlformats-officedocument.obfuscatedFont",
+	}, xlsxOverride{
+		PartName: "/xl/fonts/" + guid + ".odttf", ContentType: "application/vnd.openxmlformats-officedocument.obfuscatedFont",
+	})
+	buf, err := f.WriteToBuffer()
+	assert.NoError(t, err)
+	assert.NoError(t, f.Close())
+	// Test get embedded fonts after the workbook saved
+	f, err = OpenReader(buf)
+	assert.NoError(t, err)
+	fonts, err = f.GetEmbeddedFonts()
+	assert.NoError(t, err)
+	assert.Equal(t, []EmbeddedFont{
+		{Name: "Font 字体", Path: "xl/fonts/font1.ttf", Data: fontData},
+		{Name: "font2", Path: "xl/fonts/font2.odttf", Data: []byte("obfuscated")},
+		{Name: "Font 字体", Path: "xl/fonts/" + guid + ".odttf", Data: obfuscated},
+	}, fonts)
+	// Test get embedded fonts with unsupported charset content types
+	f.ContentTypes = nil
+	f.Pkg.Store(defaultXMLPathContentTypes, MacintoshCyrillicCharset)
+	_, err = f.GetEmbeddedFonts()
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
+
+func TestParseFontFamilyName(t *testing.T) {
+	assert.Equal(t, "Mac Font", parseFontFamilyName(newTestFontData("Mac Font", "")))
+	assert.Equal(t, "Win Font", parseFontFamilyName(newTestFontData("Mac Font", "Win Font")))
+	assert.Empty(t, parseFontFamilyName(newTestFontData("", "")))
+	data := newTestFontData("Mac Font", "")
+	for _, data := range [][]byte{
+		nil, data[:20], data[:50],
+		append(append([]byte{}, data[:36]...), 0xFF, 0xFF, 0xFF, 0xFF),
+	} {
+		assert.Empty(t, parseFontFamilyName(data))
+	}
+	// Test restore the obfuscated font data with invalid GUID or data
+	assert.Nil(t, deobfuscateFont("xl/fonts/font1.odttf", data))
+	assert.Nil(t, deobfuscateFont("xl/fonts/{0A1B2C3D-4E5F-6071-8293-A4B5C6D7E8F9}.odttf", data[:31]))
+	// Test parse font family name without the naming table
+	data = newTestFontData("Mac Font", "")
+	copy(data[28:], "post")
+	assert.Empty(t, parseFontFamilyName(data))
 }
 
 func TestStylesReader(t *testing.T) {
@@ -617,6 +942,234 @@ func TestGetThemeColor(t *testing.T) {
 	assert.Nil(t, clr.colorChoice())
 }
 
+func TestResolveColor(t *testing.T) {
+	f := NewFile()
+	// Test resolve theme colors with tint values, compared with the colors in
+	// the theme color palette of Excel
+	for _, c := range []struct {
+		theme    int
+		tint     float64
+		expected string
+	}{
+		{0, 0, "FFFFFFFF"},
+		{0, -0.0499893185216834, "FFF2F2F2"},
+		{0, -0.149998474074526, "FFD9D9D9"},
+		{0, -0.249977111117893, "FFBFBFBF"},
+		{0, -0.3499862666707358, "FFA6A6A6"},
+		{0, -0.499984740745262, "FF808080"},
+		{1, 0.0499893185216834, "FF0D0D0D"},
+		{1, 0.1499984740745262, "FF262626"},
+		{1, 0.249977111117893, "FF404040"},
+		{1, 0.3499862666707358, "FF595959"},
+		{2, -0.0999786370433668, "FFD0CECE"},
+		{3, 0.3999755851924192, "FF8497B0"},
+		{4, 0, "FF5B9BD5"},
+		{4, 0.5999938962981048, "FFBDD7EE"},
+		{5, 0.5999938962981048, "FFF8CBAD"},
+		{9, -0.249977111117893, "FF548235"},
+		{10, 0, "FF0563C1"},
+		{11, 0, "FF954F72"},
+	} {
+		theme := c.theme
+		clr, err := f.ResolveColor(Color{Theme: &theme, Tint: c.tint})
+		assert.NoError(t, err)
+		assert.Equal(t, c.expected, clr, c)
+	}
+	// Test resolve legacy indexed colors
+	for idx, expected := range map[int]string{
+		0: "FF000000", 2: "FFFF0000", 22: "FFC0C0C0", 63: "FF333333", 64: "FF000000", 65: "FFFFFFFF",
+	} {
+		clr, err := f.ResolveColor(Color{Indexed: intPtr(idx)})
+		assert.NoError(t, err)
+		assert.Equal(t, expected, clr, idx)
+	}
+	clr, err := f.ResolveColor(Color{Indexed: intPtr(2), Tint: 0.5})
+	assert.NoError(t, err)
+	assert.Equal(t, "FFFF8080", clr)
+	// Test resolve RGB colors
+	for rgb, expected := range map[string]string{
+		"5b9bd5": "FF5B9BD5", "#5B9BD5": "FF5B9BD5", "FF5B9BD5": "FF5B9BD5", "805B9BD5": "805B9BD5",
+	} {
+		clr, err := f.ResolveColor(Color{RGB: rgb})
+		assert.NoError(t, err)
+		assert.Equal(t, expected, clr, rgb)
+	}
+	// Test the theme color takes precedence over the RGB and indexed colors
+	theme := 4
+	clr, err = f.ResolveColor(Color{RGB: "FF0000", Indexed: intPtr(3), Theme: &theme})
+	assert.NoError(t, err)
+	assert.Equal(t, "FF5B9BD5", clr)
+	// Test resolve with custom indexed color palette
+	f.Styles.Colors = &xlsxStyleColors{IndexedColors: &xlsxIndexedColors{RgbColor: []xlsxColor{{RGB: "FF112233"}, {RGB: "445566"}, {RGB: "F"}}}}
+	clr, err = f.ResolveColor(Color{Indexed: intPtr(0)})
+	assert.NoError(t, err)
+	assert.Equal(t, "FF112233", clr)
+	clr, err = f.ResolveColor(Color{Indexed: intPtr(1)})
+	assert.NoError(t, err)
+	assert.Equal(t, "FF445566", clr)
+	clr, err = f.ResolveColor(Color{Indexed: intPtr(3)})
+	assert.NoError(t, err)
+	assert.Equal(t, "FF00FF00", clr)
+	// Test resolve with invalid color settings
+	_, err = f.ResolveColor(Color{Indexed: intPtr(2)})
+	assert.Equal(t, newInvalidColorError("indexed", 2), err)
+	_, err = f.ResolveColor(Color{Indexed: intPtr(-1)})
+	assert.Equal(t, newInvalidColorError("indexed", -1), err)
+	_, err = f.ResolveColor(Color{Indexed: intPtr(len(IndexedColorMapping))})
+	assert.Equal(t, newInvalidColorError("indexed", len(IndexedColorMapping)), err)
+	for _, rgb := range []string{"FFF", "GGGGGG", "FF5B9BD500"} {
+		_, err = f.ResolveColor(Color{RGB: rgb})
+		assert.Equal(t, newInvalidColorError("RGB", rgb), err)
+	}
+	theme = 12
+	_, err = f.ResolveColor(Color{Theme: &theme})
+	assert.Equal(t, newInvalidColorError("theme", 12), err)
+	_, err = f.ResolveColor(Color{RGB: "FFFFFF", Tint: 1.5})
+	assert.Equal(t, newInvalidColorError("tint", 1.5), err)
+	_, err = f.ResolveColor(Color{})
+	assert.Equal(t, ErrParameterRequired, err)
+	// Test resolve theme color without theme
+	f.Theme = nil
+	theme = 4
+	_, err = f.ResolveColor(Color{Theme: &theme})
+	assert.Equal(t, newInvalidColorError("theme", 4), err)
+	clr, err = f.ResolveColor(Color{Theme: &theme, RGB: "FF0000"})
+	assert.NoError(t, err)
+	assert.Equal(t, "FFFF0000", clr)
+	// Test resolve indexed color with unsupported charset styles part
+	f = NewFile()
+	f.Styles = nil
+	f.Pkg.Store(defaultXMLPathStyles, MacintoshCyrillicCharset)
+	_, err = f.ResolveColor(Color{Indexed: intPtr(2)})
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
+
+func TestIndexedColors(t *testing.T) {
+	// Test read the styles with the custom indexed color palette
+	f := NewFile()
+	palette := append([]string{}, IndexedColorMapping[:64]...)
+	palette[10] = "FFA500"
+	var rgbColors strings.Builder
+	for _, color := range palette {
+		rgbColors.WriteString(`<rgbColor rgb="FF` + color + `"/>`)
+	}
+	f.Styles = nil
+	f.Pkg.Store(defaultXMLPathStyles, []byte(fmt.Sprintf(`<styleSheet xmlns="%s"><fonts count="2"><font><sz val="11"/><name val="Calibri"/></font><font><color indexed="10"/><sz val="11"/><name val="Calibri"/></font></fonts><fills count="3"><fill><patternFill patternType="none"/></fill><fill><patternFill patternType="gray125"/></fill><fill><patternFill patternType="solid"><fgColor indexed="10"/></patternFill></fill></fills><borders count="1"><border><left/><right/><top/><bottom/><diagonal/></border></borders><cellXfs count="2"><xf numFmtId="0" fontId="0" fillId="0" borderId="0"/><xf numFmtId="0" fontId="1" fillId="2" borderId="0" applyFill="1"/></cellXfs><colors><indexedColors>%s</indexedColors></colors></styleSheet>`, NameSpaceSpreadSheet.Value, rgbColors.String())))
+	style, err := f.GetStyle(1)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"FFA500"}, style.Fill.Color)
+	assert.Equal(t, 10, style.Font.ColorIndexed)
+	clr, err := f.ResolveColor(Color{Indexed: intPtr(10)})
+	assert.NoError(t, err)
+	assert.Equal(t, "FFFFA500", clr)
+	assert.Equal(t, "FFA500", f.GetBaseColor("", 10, nil))
+	colors, err := f.GetIndexedColors()
+	assert.NoError(t, err)
+	assert.Equal(t, palette, colors)
+	assert.NoError(t, f.Close())
+
+	// Test write the custom indexed color palette
+	f = NewFile()
+	colors, err = f.GetIndexedColors()
+	assert.NoError(t, err)
+	assert.Nil(t, colors)
+	palette[10] = "#00ff00"
+	assert.NoError(t, f.SetIndexedColors(palette))
+	styleID, err := f.NewStyle(&Style{Fill: Fill{Type: "pattern", Pattern: 1}})
+	assert.NoError(t, err)
+	s, err := f.stylesReader()
+	assert.NoError(t, err)
+	s.Fills.Fill[*s.CellXfs.Xf[styleID].FillID].PatternFill.FgColor = &xlsxColor{Indexed: 10}
+	buf, err := f.WriteToBuffer()
+	assert.NoError(t, err)
+	assert.NoError(t, f.Close())
+	f, err = OpenReader(buf)
+	assert.NoError(t, err)
+	colors, err = f.GetIndexedColors()
+	assert.NoError(t, err)
+	assert.Len(t, colors, 64)
+	assert.Equal(t, "00FF00", colors[10])
+	style, err = f.GetStyle(styleID)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"00FF00"}, style.Fill.Color)
+	// Test clear the custom indexed color palette
+	assert.NoError(t, f.SetIndexedColors(nil))
+	colors, err = f.GetIndexedColors()
+	assert.NoError(t, err)
+	assert.Nil(t, colors)
+	style, err = f.GetStyle(styleID)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"FF0000"}, style.Fill.Color)
+	assert.NoError(t, f.SetIndexedColors(nil))
+	// Test set the custom indexed color palette with invalid colors
+	assert.Equal(t, ErrIndexedColors, f.SetIndexedColors(palette[:63]))
+	palette[10] = "orange"
+	assert.Equal(t, newInvalidColorError("RGB", "orange"), f.SetIndexedColors(palette))
+	assert.NoError(t, f.Close())
+	// Test get and set the custom indexed color palette with unsupported
+	// charset style sheet
+	f = NewFile()
+	f.Styles = nil
+	f.Pkg.Store(defaultXMLPathStyles, MacintoshCyrillicCharset)
+	_, err = f.GetIndexedColors()
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	f.Styles = nil
+	assert.EqualError(t, f.SetIndexedColors(nil), "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
+
+func TestGetBaseColor(t *testing.T) {
+	f := NewFile()
+	accent1 := "5b9bd5"
+	f.Theme.ThemeElements.ClrScheme.Accent1.SrgbClr.Val = &accent1
+	theme := 4
+	assert.Equal(t, "5b9bd5", f.GetBaseColor("", 0, &theme))
+	clr, err := f.ResolveColor(Color{Theme: &theme})
+	assert.NoError(t, err)
+	assert.Equal(t, "FF5B9BD5", clr)
+	// Test get base color with the hyperlink theme color
+	theme = 10
+	assert.Equal(t, "FF0000", f.GetBaseColor("FF0000", 0, &theme))
+	assert.NoError(t, f.Close())
+}
+
+func TestGetCondFmtColor(t *testing.T) {
+	f := NewFile()
+	theme := 4
+	assert.Empty(t, f.getCondFmtColor(nil))
+	assert.Equal(t, "#BDD7EE", f.getCondFmtColor(&xlsxColor{Theme: &theme, Tint: 0.5999938962981048}))
+	assert.Empty(t, f.getCondFmtColor(&xlsxColor{RGB: "F"}))
+	// Test get conditional format color scale with theme colors
+	assert.NoError(t, f.SetConditionalFormat("Sheet1", "A1:A10", []ConditionalFormatOptions{
+		{Type: "2_color_scale", Criteria: "=", MinType: "min", MaxType: "max", MinColor: "#F8696B", MaxColor: "#63BE7B"},
+	}))
+	ws, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	ws.ConditionalFormatting[0].CfRule[0].ColorScale.Color[1] = &xlsxColor{Theme: &theme}
+	opts, err := f.GetConditionalFormats("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, "#F8696B", opts["A1:A10"][0].MinColor)
+	assert.Equal(t, "#5B9BD5", opts["A1:A10"][0].MaxColor)
+	// Test get conditional format RGB colors without theme
+	f.Theme = nil
+	opts, err = f.GetConditionalFormats("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, "#F8696B", opts["A1:A10"][0].MinColor)
+	assert.Empty(t, opts["A1:A10"][0].MaxColor)
+	assert.NoError(t, f.Close())
+}
+
+func TestGetDrawingColor(t *testing.T) {
+	f := NewFile()
+	for color, expected := range map[string]string{
+		"#ff0000": "FF0000", "FF0000": "FF0000", "#80FF0000": "FF0000", "#red": "RED",
+	} {
+		assert.Equal(t, expected, f.getDrawingColor(color), color)
+	}
+	assert.NoError(t, f.Close())
+}
+
 func TestGetStyle(t *testing.T) {
 	f := NewFile()
 	expected := &Style{
@@ -754,6 +1307,13 @@ func TestGetStyle(t *testing.T) {
 	style, err = f.GetStyle(-1)
 	assert.Nil(t, style)
 	assert.Equal(t, err, newInvalidStyleID(-1))
+	// Test get style with negative font, fill and border index
+	f.Styles.CellXfs.Xf[0].FontID = intPtr(-1)
+	f.Styles.CellXfs.Xf[0].FillID = intPtr(-1)
+	f.Styles.CellXfs.Xf[0].BorderID = intPtr(-1)
+	style, err = f.GetStyle(0)
+	assert.NoError(t, err)
+	assert.Nil(t, style.Font)
 	// Test get style with unsupported charset style sheet
 	f.Styles = nil
 	f.Pkg.Store(defaultXMLPathStyles, MacintoshCyrillicCharset)
@@ -761,3 +1321,91 @@ func TestGetStyle(t *testing.T) {
 	assert.Nil(t, style)
 	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
 }
+
+func TestStyleAlignment(t *testing.T) {
+	f := NewFile()
+	// Test round-trip alignment settings from the Excel-authored styles
+	f.Styles = nil
+	f.Pkg.Store(defaultXMLPathStyles, []byte(xml.Header+`<styleSheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><fonts count="1"><font><sz val="11"/><color theme="1"/><name val="Calibri"/><family val="2"/><scheme val="minor"/></font></fonts><fills count="2"><fill><patternFill patternType="none"/></fill><fill><patternFill patternType="gray125"/></fill></fills><borders count="1"><border><left/><right/><top/><bottom/><diagonal/></border></borders><cellStyleXfs count="1"><xf numFmtId="0" fontId="0" fillId="0" borderId="0"/></cellStyleXfs><cellXfs count="4"><xf numFmtId="0" fontId="0" fillId="0" borderId="0" xfId="0"/><xf numFmtId="0" fontId="0" fillId="0" borderId="0" xfId="0" applyAlignment="1"><alignment horizontal="left" indent="20" readingOrder="2"/></xf><xf numFmtId="0" fontId="0" fillId="0" borderId="0" xfId="0" applyAlignment="1"><alignment horizontal="distributed" vertical="center" indent="250" justifyLastLine="1" readingOrder="1"/></xf><xf numFmtId="0" fontId="0" fillId="0" borderId="0" xfId="0" applyAlignment="1"><alignment shrinkToFit="1" relativeIndent="-1"/></xf></cellXfs><cellStyles count="1"><cellStyle name="Normal" xfId="0" builtinId="0"/></cellStyles><dxfs count="0"/><tableStyles count="0" defaultTableStyle="TableStyleMedium2" defaultPivotStyle="PivotStyleLight16"/></styleSheet>`))
+	expected := []*Alignment{
+		{Horizontal: "left", Indent: 20, ReadingOrder: 2},
+		{Horizontal: "distributed", Vertical: "center", Indent: 250, JustifyLastLine: true, ReadingOrder: 1},
+		{ShrinkToFit: true, RelativeIndent: -1},
+	}
+	for i, alignment := range expected {
+		style, err := f.GetStyle(i + 1)
+		assert.NoError(t, err)
+		assert.Equal(t, alignment, style.Alignment)
+		styleID, err := f.NewStyle(style)
+		assert.NoError(t, err)
+		style, err = f.GetStyle(styleID)
+		assert.NoError(t, err)
+		assert.Equal(t, alignment, style.Alignment)
+	}
+	buf, err := f.WriteToBuffer()
+	assert.NoError(t, err)
+	f, err = OpenReader(buf)
+	assert.NoError(t, err)
+	for i, alignment := range expected {
+		style, err := f.GetStyle(i + 1)
+		assert.NoError(t, err)
+		assert.Equal(t, alignment, style.Alignment)
+	}
+	// Test create style with alignment settings
+	styleID, err := f.NewStyle(&Style{Alignment: &Alignment{Horizontal: "right", Indent: 16, ReadingOrder: 2, ShrinkToFit: true}})
+	assert.NoError(t, err)
+	style, err := f.GetStyle(styleID)
+	assert.NoError(t, err)
+	assert.Equal(t, &Alignment{Horizontal: "right", Indent: 16, ReadingOrder: 2, ShrinkToFit: true}, style.Alignment)
+	// Test create style with invalid alignment settings
+	for _, alignment := range []*Alignment{{Indent: -1}, {Indent: MaxIndent + 1}} {
+		_, err = f.NewStyle(&Style{Alignment: alignment})
+		assert.Equal(t, ErrIndent, err)
+		_, err = f.NewConditionalStyle(&Style{Alignment: alignment})
+		assert.Equal(t, ErrIndent, err)
+	}
+	_, err = f.NewStyle(&Style{Alignment: &Alignment{ReadingOrder: 3}})
+	assert.Equal(t, ErrReadingOrder, err)
+	assert.NoError(t, f.Close())
+}
+
+func TestConcurrencyStyles(t *testing.T) {
+	f := NewFile()
+	duration := 3 * time.Second
+	if testing.Short() {
+		duration = 200 * time.Millisecond
+	}
+	deadline := time.Now().Add(duration)
+	done := make(chan struct{})
+	wg := new(sync.WaitGroup)
+	for i := 0; i < 16; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for n := 0; time.Now().Before(deadline); n++ {
+				style, err := f.NewStyle(&Style{Font: &Font{Size: float64(8 + (i*n)%64)}, NumFmt: 14})
+				assert.NoError(t, err)
+				col, _ := ColumnNumberToName(i + 1)
+				assert.NoError(t, f.SetColStyle("Sheet1", col, style))
+				assert.NoError(t, f.SetCellStyle("Sheet1", "A1", "C3", style))
+				assert.NoError(t, f.MergeColStyle("Sheet1", "D:E", style))
+				assert.NoError(t, f.SetColProps("Sheet1", "F", ColProps{Style: &style}))
+				assert.NoError(t, f.SetRowStyle("Sheet1", 5, 6, style))
+				_, err = f.GetStyle(style)
+				assert.NoError(t, err)
+				_, err = f.GetCellValue("Sheet1", "A1")
+				assert.NoError(t, err)
+			}
+		}(i)
+	}
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(duration + time.Minute):
+		t.Fatal("deadlock detected when accessing the styles concurrently")
+	}
+	assert.NoError(t, f.Close())
+}
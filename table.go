@@ -478,6 +478,17 @@ func (f *File) AutoFilter(sheet, rangeRef string, opts []AutoFilterOptions) erro
 	_ = sortCoordinates(coordinates)
 	// Correct reference range, such correct C1:B3 to B1:C3.
 	ref, _ := coordinatesToRangeRef(coordinates, true)
+	if err = f.setFilterDatabase(sheet, ref); err != nil {
+		return err
+	}
+	columns := coordinates[2] - coordinates[0]
+	return f.autoFilter(sheet, ref, columns, coordinates[0], opts)
+}
+
+// setFilterDatabase provides a function to set the hidden filter database
+// defined name of the worksheet by given worksheet name and absolute range
+// reference of the auto filter.
+func (f *File) setFilterDatabase(sheet, ref string) error {
 	wb, err := f.workbookReader()
 	if err != nil {
 		return err
@@ -513,8 +524,7 @@ func (f *File) AutoFilter(sheet, rangeRef string, opts []AutoFilterOptions) erro
 			wb.DefinedNames.DefinedName = append(wb.DefinedNames.DefinedName, d)
 		}
 	}
-	columns := coordinates[2] - coordinates[0]
-	return f.autoFilter(sheet, ref, columns, coordinates[0], opts)
+	return err
 }
 
 // autoFilter provides a function to extract the tokens from the filter
@@ -21,6 +21,7 @@ import "encoding/xml"
 var (
 	NameSpaceDocumentPropertiesVariantTypes = xml.Attr{Name: xml.Name{Local: "vt", Space: "xmlns"}, Value: "http://schemas.openxmlformats.org/officeDocument/2006/docPropsVTypes"}
 	NameSpaceDrawing2016SVG                 = xml.Attr{Name: xml.Name{Local: "asvg", Space: "xmlns"}, Value: "http://schemas.microsoft.com/office/drawing/2016/SVG/main"}
+	NameSpaceDrawing2017Decorative          = xml.Attr{Name: xml.Name{Local: "adec", Space: "xmlns"}, Value: "http://schemas.microsoft.com/office/drawing/2017/decorative"}
 	NameSpaceDrawingML                      = xml.Attr{Name: xml.Name{Local: "a", Space: "xmlns"}, Value: "http://schemas.openxmlformats.org/drawingml/2006/main"}
 	NameSpaceDrawingMLA14                   = xml.Attr{Name: xml.Name{Local: "a14", Space: "xmlns"}, Value: "http://schemas.microsoft.com/office/drawing/2010/main"}
 	NameSpaceDrawingMLChart                 = xml.Attr{Name: xml.Name{Local: "c", Space: "xmlns"}, Value: "http://schemas.openxmlformats.org/drawingml/2006/chart"}
@@ -52,10 +53,15 @@ const (
 	ContentTypeSlicerCache                        = "application/vnd.ms-excel.slicerCache+xml"
 	ContentTypeSpreadSheetMLChartsheet            = "application/vnd.openxmlformats-officedocument.spreadsheetml.chartsheet+xml"
 	ContentTypeSpreadSheetMLComments              = "application/vnd.openxmlformats-officedocument.spreadsheetml.comments+xml"
+	ContentTypeSpreadSheetMLConnections           = "application/vnd.openxmlformats-officedocument.spreadsheetml.connections+xml"
+	ContentTypeSpreadSheetMLPerson                = "application/vnd.ms-excel.person+xml"
 	ContentTypeSpreadSheetMLPivotCacheDefinition  = "application/vnd.openxmlformats-officedocument.spreadsheetml.pivotCacheDefinition+xml"
 	ContentTypeSpreadSheetMLPivotTable            = "application/vnd.openxmlformats-officedocument.spreadsheetml.pivotTable+xml"
+	ContentTypeSpreadSheetMLPrinterSettings       = "application/vnd.openxmlformats-officedocument.spreadsheetml.printerSettings"
+	ContentTypeSpreadSheetMLQueryTable            = "application/vnd.openxmlformats-officedocument.spreadsheetml.queryTable+xml"
 	ContentTypeSpreadSheetMLSharedStrings         = "application/vnd.openxmlformats-officedocument.spreadsheetml.sharedStrings+xml"
 	ContentTypeSpreadSheetMLTable                 = "application/vnd.openxmlformats-officedocument.spreadsheetml.table+xml"
+	ContentTypeSpreadSheetMLThreadedComments      = "application/vnd.ms-excel.threadedcomments+xml"
 	ContentTypeSpreadSheetMLWorksheet             = "application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"
 	ContentTypeTemplate                           = "application/vnd.openxmlformats-officedocument.spreadsheetml.template.main+xml"
 	ContentTypeTemplateMacro                      = "application/vnd.ms-excel.template.macroEnabled.main+xml"
@@ -71,19 +77,27 @@ const (
 	SourceRelationshipChart                       = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/chart"
 	SourceRelationshipChartsheet                  = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/chartsheet"
 	SourceRelationshipComments                    = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/comments"
+	SourceRelationshipConnections                 = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/connections"
 	SourceRelationshipDialogsheet                 = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/dialogsheet"
 	SourceRelationshipDrawingML                   = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/drawing"
 	SourceRelationshipDrawingVML                  = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/vmlDrawing"
 	SourceRelationshipExtendProperties            = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/extended-properties"
+	SourceRelationshipExternalLink                = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/externalLink"
+	SourceRelationshipExternalLinkPath            = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/externalLinkPath"
 	SourceRelationshipHyperLink                   = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/hyperlink"
 	SourceRelationshipImage                       = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/image"
+	SourceRelationshipOLEObject                   = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/oleObject"
 	SourceRelationshipOfficeDocument              = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument"
+	SourceRelationshipPerson                      = "http://schemas.microsoft.com/office/2017/10/relationships/person"
 	SourceRelationshipPivotCache                  = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/pivotCacheDefinition"
 	SourceRelationshipPivotTable                  = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/pivotTable"
+	SourceRelationshipPrinterSettings             = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/printerSettings"
+	SourceRelationshipQueryTable                  = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/queryTable"
 	SourceRelationshipSharedStrings               = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/sharedStrings"
 	SourceRelationshipSlicer                      = "http://schemas.microsoft.com/office/2007/relationships/slicer"
 	SourceRelationshipSlicerCache                 = "http://schemas.microsoft.com/office/2007/relationships/slicerCache"
 	SourceRelationshipTable                       = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/table"
+	SourceRelationshipThreadedComment             = "http://schemas.microsoft.com/office/2017/10/relationships/threadedComment"
 	SourceRelationshipVBAProject                  = "http://schemas.microsoft.com/office/2006/relationships/vbaProject"
 	SourceRelationshipWorkSheet                   = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet"
 	StrictNameSpaceDocumentPropertiesVariantTypes = "http://purl.oclc.org/ooxml/officeDocument/docPropsVTypes"
@@ -105,6 +119,7 @@ const (
 	ExtURIDataField                      = "{E15A36E0-9728-4E99-A89B-3F7291B0FE68}"
 	ExtURIDataModel                      = "{FCE2AD5D-F65C-4FA6-A056-5C36A1767C68}"
 	ExtURIDataValidations                = "{CCE6A557-97BC-4b89-ADB6-D9C93CAAB3DF}"
+	ExtURIDecorative                     = "{C183D7F6-B498-43B3-948B-1728B52AA6E4}"
 	ExtURIDrawingBlip                    = "{28A0092B-C50C-407E-A947-70E740481C1C}"
 	ExtURIExternalLinkPr                 = "{FCE6A71B-6B00-49CD-AB44-F6B1AE7CDE65}"
 	ExtURIIgnoredErrors                  = "{01252117-D84E-4E92-8308-4BE1C098FCBB}"
@@ -175,12 +190,14 @@ const (
 	MaxFormControlValue  = 30000
 	MaxFontFamilyLength  = 31
 	MaxFontSize          = 409
+	MaxIndent            = 250
 	MaxRowHeight         = 409
 	MaxSheetNameLength   = 31
 	MinColumns           = 1
 	MinFontSize          = 1
 	StreamChunkSize      = 1 << 24
 	TotalCellChars       = 32767
+	TotalFormulaChars    = 8192
 	TotalRows            = 1048576
 	TotalSheetHyperlinks = 65529
 	UnzipSizeLimit       = 1000 << 24
@@ -275,6 +292,7 @@ const (
 	defaultXMLPathCalcChain               = "xl/calcChain.xml"
 	defaultXMLPathCellImages              = "xl/cellimages.xml"
 	defaultXMLPathCellImagesRels          = "xl/_rels/cellimages.xml.rels"
+	defaultXMLPathConnections             = "xl/connections.xml"
 	defaultXMLPathContentTypes            = "[Content_Types].xml"
 	defaultXMLPathDocPropsApp             = "docProps/app.xml"
 	defaultXMLPathDocPropsCore            = "docProps/core.xml"
@@ -508,6 +526,10 @@ var supportedDrawingTextVerticalType = []string{"horz", "vert", "vert270", "word
 // supportedPositioning defined supported positioning types.
 var supportedPositioning = []string{"absolute", "oneCell", "twoCell"}
 
+// supportedDrawingHyperlinkTypes defined supported hyperlink types of the
+// drawing objects.
+var supportedDrawingHyperlinkTypes = []string{"External", "Location"}
+
 // supportedPageOrientation defined supported page setup page orientation.
 var supportedPageOrientation = []string{"portrait", "landscape"}
 
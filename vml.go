@@ -179,6 +179,58 @@ func (f *File) DeleteComment(sheet, cell string) error {
 	return f.deleteFormControl(sheetRelationshipsDrawingVML, cell, true)
 }
 
+// vmlDrawingReader provides a function to get the VML drawing by given
+// relationships target of the worksheet, the existing VML shapes will be
+// loaded from xl/drawings/vmlDrawing%d.vml if it has not been loaded yet.
+func (f *File) vmlDrawingReader(sheetRelationshipsDrawingVML string) (string, *vmlDrawing, error) {
+	vmlID, _ := strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(sheetRelationshipsDrawingVML, "../drawings/vmlDrawing"), ".vml"))
+	drawingVML := strings.ReplaceAll(sheetRelationshipsDrawingVML, "..", "xl")
+	vml := f.VMLDrawing[drawingVML]
+	if vml != nil {
+		return drawingVML, vml, nil
+	}
+	vml = &vmlDrawing{
+		XMLNSv:  "urn:schemas-microsoft-com:vml",
+		XMLNSo:  "urn:schemas-microsoft-com:office:office",
+		XMLNSx:  "urn:schemas-microsoft-com:office:excel",
+		XMLNSmv: "http://macVmlSchemaUri",
+		ShapeLayout: &xlsxShapeLayout{
+			Ext: "edit", IDmap: &xlsxIDmap{Ext: "edit", Data: vmlID},
+		},
+		ShapeType: &xlsxShapeType{
+			Stroke: &xlsxStroke{JoinStyle: "miter"},
+			VPath:  &vPath{GradientShapeOK: "t", ConnectType: "rect"},
+		},
+	}
+	// Load exist VML shapes from xl/drawings/vmlDrawing%d.vml
+	d, err := f.decodeVMLDrawingReader(drawingVML)
+	if err != nil {
+		return drawingVML, vml, err
+	}
+	if d != nil {
+		vml.ShapeType.ID = d.ShapeType.ID
+		vml.ShapeType.CoordSize = d.ShapeType.CoordSize
+		vml.ShapeType.Spt = d.ShapeType.Spt
+		vml.ShapeType.Path = d.ShapeType.Path
+		for _, v := range d.Shape {
+			s := xlsxShape{
+				ID:          v.ID,
+				Type:        v.Type,
+				Style:       v.Style,
+				Button:      v.Button,
+				Filled:      v.Filled,
+				FillColor:   v.FillColor,
+				InsetMode:   v.InsetMode,
+				Stroked:     v.Stroked,
+				StrokeColor: v.StrokeColor,
+				Val:         v.Val,
+			}
+			vml.Shape = append(vml.Shape, s)
+		}
+	}
+	return drawingVML, vml, err
+}
+
 // deleteFormControl provides the method to delete shape from
 // xl/drawings/vmlDrawing%d.xml by giving path, cell and shape type.
 func (f *File) deleteFormControl(sheetRelationshipsDrawingVML, cell string, isComment bool) error {
@@ -186,49 +238,9 @@ func (f *File) deleteFormControl(sheetRelationshipsDrawingVML, cell string, isCo
 	if err != nil {
 		return err
 	}
-	vmlID, _ := strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(sheetRelationshipsDrawingVML, "../drawings/vmlDrawing"), ".vml"))
-	drawingVML := strings.ReplaceAll(sheetRelationshipsDrawingVML, "..", "xl")
-	vml := f.VMLDrawing[drawingVML]
-	if vml == nil {
-		vml = &vmlDrawing{
-			XMLNSv:  "urn:schemas-microsoft-com:vml",
-			XMLNSo:  "urn:schemas-microsoft-com:office:office",
-			XMLNSx:  "urn:schemas-microsoft-com:office:excel",
-			XMLNSmv: "http://macVmlSchemaUri",
-			ShapeLayout: &xlsxShapeLayout{
-				Ext: "edit", IDmap: &xlsxIDmap{Ext: "edit", Data: vmlID},
-			},
-			ShapeType: &xlsxShapeType{
-				Stroke: &xlsxStroke{JoinStyle: "miter"},
-				VPath:  &vPath{GradientShapeOK: "t", ConnectType: "rect"},
-			},
-		}
-		// Load exist VML shapes from xl/drawings/vmlDrawing%d.vml
-		d, err := f.decodeVMLDrawingReader(drawingVML)
-		if err != nil {
-			return err
-		}
-		if d != nil {
-			vml.ShapeType.ID = d.ShapeType.ID
-			vml.ShapeType.CoordSize = d.ShapeType.CoordSize
-			vml.ShapeType.Spt = d.ShapeType.Spt
-			vml.ShapeType.Path = d.ShapeType.Path
-			for _, v := range d.Shape {
-				s := xlsxShape{
-					ID:          v.ID,
-					Type:        v.Type,
-					Style:       v.Style,
-					Button:      v.Button,
-					Filled:      v.Filled,
-					FillColor:   v.FillColor,
-					InsetMode:   v.InsetMode,
-					Stroked:     v.Stroked,
-					StrokeColor: v.StrokeColor,
-					Val:         v.Val,
-				}
-				vml.Shape = append(vml.Shape, s)
-			}
-		}
+	drawingVML, vml, err := f.vmlDrawingReader(sheetRelationshipsDrawingVML)
+	if err != nil {
+		return err
 	}
 	cond := func(objectType string) bool {
 		if isComment {
@@ -275,7 +287,7 @@ func (f *File) addComment(commentsXML string, opts vmlOptions) error {
 		cmts.Authors.Author = append(cmts.Authors.Author, opts.Author)
 		authorID = len(cmts.Authors.Author) - 1
 	}
-	defaultFont, err := f.GetDefaultFont()
+	defaultFont, _, err := f.GetDefaultFont()
 	if err != nil {
 		return err
 	}
@@ -852,7 +864,10 @@ func (f *File) addDrawingVML(sheetID int, drawingVML string, opts *vmlOptions) e
 		leftOffset, vmlID = 0, 201
 		style = "position:absolute;73.5pt;width:108pt;height:59.25pt;z-index:1;mso-wrap-style:tight"
 	}
-	colStart, rowStart, colEnd, rowEnd, _, _, x2, y2 := f.positionObjectPixels(opts.sheet, col, row, int(opts.FormControl.Width), int(opts.FormControl.Height), &opts.Format)
+	colStart, rowStart, colEnd, rowEnd, _, _, x2, y2, err := f.positionObjectPixels(opts.sheet, col, row, int(opts.FormControl.Width), int(opts.FormControl.Height), &opts.Format)
+	if err != nil {
+		return err
+	}
 	anchor := fmt.Sprintf("%d, %d, %d, 0, %d, %d, %d, %d", colStart, leftOffset, rowStart, colEnd, x2, rowEnd, y2)
 	if vml == nil {
 		vml = &vmlDrawing{
@@ -1225,3 +1240,75 @@ func (f *File) AddHeaderFooterImage(sheet string, opts *HeaderFooterImageOptions
 	}
 	return f.setContentTypePartVMLExtensions()
 }
+
+// getHeaderFooterImages provides a function to get the images referenced in
+// the header and footer definitions of the worksheet by given worksheet name.
+func (f *File) getHeaderFooterImages(sheet string, ws *xlsxWorksheet) ([]HeaderFooterImageOptions, error) {
+	var images []HeaderFooterImageOptions
+	if ws.LegacyDrawingHF == nil {
+		return images, nil
+	}
+	sheetRelationshipsDrawingVML := f.getSheetRelationshipsTargetByID(sheet, ws.LegacyDrawingHF.RID)
+	drawingVML := strings.ReplaceAll(sheetRelationshipsDrawingVML, "..", "xl")
+	drawingVMLRels := strings.Replace(drawingVML, "xl/drawings/", "xl/drawings/_rels/", 1) + ".rels"
+	var shapes []decodeShape
+	if vml := f.VMLDrawing[drawingVML]; vml != nil {
+		for _, shape := range vml.Shape {
+			shapes = append(shapes, decodeShape{ID: shape.ID, Style: shape.Style, Val: shape.Val})
+		}
+	} else {
+		d, err := f.decodeVMLDrawingReader(drawingVML)
+		if err != nil {
+			return images, err
+		}
+		if d != nil {
+			shapes = d.Shape
+		}
+	}
+	positions := map[byte]HeaderFooterImagePositionType{
+		'L': HeaderFooterImagePositionLeft,
+		'C': HeaderFooterImagePositionCenter,
+		'R': HeaderFooterImagePositionRight,
+	}
+	for _, shape := range shapes {
+		id := strings.TrimSuffix(shape.ID, "FIRST")
+		if len(id) != 2 || (id[1] != 'H' && id[1] != 'F') {
+			continue
+		}
+		position, ok := positions[id[0]]
+		if !ok {
+			continue
+		}
+		var img decodeShapeImage
+		if err := f.xmlNewDecoder(strings.NewReader("<shape>" + shape.Val + "</shape>")).Decode(&img); err != nil && err != io.EOF {
+			return images, err
+		}
+		rel := f.getDrawingRelationships(drawingVMLRels, img.ImageData.RelID)
+		if rel == nil {
+			continue
+		}
+		file, ok := f.Pkg.Load(strings.ReplaceAll(rel.Target, "..", "xl"))
+		if !ok {
+			continue
+		}
+		opts := HeaderFooterImageOptions{
+			Position:  position,
+			File:      file.([]byte),
+			IsFooter:  id[1] == 'F',
+			FirstPage: id != shape.ID,
+			Extension: filepath.Ext(rel.Target),
+		}
+		for _, attr := range strings.Split(shape.Style, ";") {
+			if k, v, ok := strings.Cut(attr, ":"); ok {
+				switch strings.TrimSpace(k) {
+				case "width":
+					opts.Width = strings.TrimSpace(v)
+				case "height":
+					opts.Height = strings.TrimSpace(v)
+				}
+			}
+		}
+		images = append(images, opts)
+	}
+	return images, nil
+}
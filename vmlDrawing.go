@@ -224,6 +224,14 @@ type decodeShapeVal struct {
 	ClientData decodeVMLClientData `xml:"ClientData"`
 }
 
+// decodeShapeImage defines the structure used to parse the image data of the
+// header and footer image shape.
+type decodeShapeImage struct {
+	ImageData struct {
+		RelID string `xml:"relid,attr"`
+	} `xml:"imagedata"`
+}
+
 // decodeVMLFontU defines the structure used to parse the u element in the VML.
 type decodeVMLFontU struct {
 	Class string `xml:"class,attr"`
@@ -268,19 +276,20 @@ type decodeVMLTextBox struct {
 // decodeVMLClientData defines the structure used to parse the x:ClientData
 // element in the file xl/drawings/vmlDrawing%d.vml.
 type decodeVMLClientData struct {
-	ObjectType string `xml:"ObjectType,attr"`
-	Anchor     string
-	FmlaMacro  string
-	Column     *int
-	Row        *int
-	Checked    int
-	FmlaLink   string
-	Val        uint
-	Min        uint
-	Max        uint
-	Inc        uint
-	Page       uint
-	Horiz      *string
+	ObjectType    string `xml:"ObjectType,attr"`
+	MoveWithCells *string
+	Anchor        string
+	FmlaMacro     string
+	Column        *int
+	Row           *int
+	Checked       int
+	FmlaLink      string
+	Val           uint
+	Min           uint
+	Max           uint
+	Inc           uint
+	Page          uint
+	Horiz         *string
 }
 
 // encodeShape defines the structure used to re-serialization shape element.
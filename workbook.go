@@ -15,10 +15,13 @@ import (
 	"bytes"
 	"encoding/xml"
 	"io"
+	"path"
 	"path/filepath"
 	"reflect"
+	"sort"
 	"strconv"
 	"strings"
+	"sync"
 )
 
 // SetWorkbookProps provides a function to sets workbook properties.
@@ -184,10 +187,270 @@ func (f *File) UnprotectWorkbook(password ...string) error {
 	return err
 }
 
+// SetFileSharing provides a function to set the file sharing settings of the
+// workbook, which let the spreadsheet application recommend the user to open
+// the workbook as read-only, or prompt for the password to modify the
+// workbook. This is not a security feature, the workbook content will not be
+// encrypted. The optional field AlgorithmName specified hash algorithm of the
+// password, support XOR, MD4, MD5, SHA-1, SHA-256, SHA-384, and SHA-512
+// currently, if no hash algorithm specified, will be using the legacy XOR
+// algorithm as default. The file sharing settings will be removed if all
+// settings are empty. For example, set the password to modify the workbook
+// and recommend read-only:
+//
+//	err := f.SetFileSharing(excelize.FileSharingOptions{
+//	    Password:            "password",
+//	    ReadOnlyRecommended: true,
+//	    UserName:            "Excelize",
+//	})
+func (f *File) SetFileSharing(opts FileSharingOptions) error {
+	wb, err := f.workbookReader()
+	if err != nil {
+		return err
+	}
+	if opts == (FileSharingOptions{}) {
+		wb.FileSharing = nil
+		return err
+	}
+	fileSharing := &xlsxFileSharing{ReadOnlyRecommended: opts.ReadOnlyRecommended, UserName: opts.UserName}
+	if opts.Password != "" {
+		if opts.AlgorithmName == "" || opts.AlgorithmName == "XOR" {
+			if len(opts.Password) > MaxFieldLength {
+				return ErrPasswordLengthInvalid
+			}
+			fileSharing.ReservationPassword = genSheetPasswd(opts.Password)
+		} else {
+			hashValue, saltValue, err := genISOPasswdHash(opts.Password, opts.AlgorithmName, "", int(workbookProtectionSpinCount))
+			if err != nil {
+				return err
+			}
+			fileSharing.AlgorithmName = opts.AlgorithmName
+			fileSharing.SaltValue = saltValue
+			fileSharing.HashValue = hashValue
+			fileSharing.SpinCount = int(workbookProtectionSpinCount)
+		}
+	}
+	wb.FileSharing = fileSharing
+	return err
+}
+
+// GetFileSharing provides a function to get the file sharing settings of the
+// workbook. The password to modify the workbook can't be read back, and the
+// returned Password field is always empty, the AlgorithmName field will be
+// "XOR" if the password was hashed with the legacy algorithm.
+func (f *File) GetFileSharing() (FileSharingOptions, error) {
+	var opts FileSharingOptions
+	wb, err := f.workbookReader()
+	if err != nil || wb.FileSharing == nil {
+		return opts, err
+	}
+	opts.ReadOnlyRecommended = wb.FileSharing.ReadOnlyRecommended
+	opts.UserName = wb.FileSharing.UserName
+	opts.AlgorithmName = wb.FileSharing.AlgorithmName
+	if opts.AlgorithmName == "" && wb.FileSharing.ReservationPassword != "" {
+		opts.AlgorithmName = "XOR"
+	}
+	return opts, err
+}
+
+// GetExternalDependencies provides a function to get all external resources
+// which the workbook might reach for, including external workbook links,
+// hyperlinks to files or URLs, OLE objects and OLE links with external
+// sources, DDE links, external data connections including the web queries,
+// and pictures linked rather than embedded. The dependencies are collected
+// by walking the relationships of every part in the workbook, and the
+// worksheet and cell reference will be resolved where the resource
+// referenced if possible. For example, print all external dependencies of the
+// workbook:
+//
+//	deps, err := f.GetExternalDependencies()
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	for _, dep := range deps {
+//	    fmt.Println(dep.Type, dep.Target, dep.Sheet, dep.Cell, dep.Part)
+//	}
+func (f *File) GetExternalDependencies() ([]Dependency, error) {
+	var (
+		deps       []Dependency
+		relsPaths  []string
+		seen       = map[string]bool{}
+		sheets     = map[string]string{}
+		partsRels  = map[string][]xlsxRelationship{}
+		hyperLinks = map[string]map[string]string{}
+	)
+	for _, sheet := range f.GetSheetList() {
+		if sheetXMLPath, ok := f.getSheetXMLPath(sheet); ok {
+			sheets[sheetXMLPath] = sheet
+		}
+	}
+	for _, m := range []*sync.Map{&f.Pkg, &f.Relationships} {
+		m.Range(func(k, v interface{}) bool {
+			if name := k.(string); strings.HasSuffix(name, ".rels") && !seen[name] {
+				seen[name] = true
+				relsPaths = append(relsPaths, name)
+			}
+			return true
+		})
+	}
+	sort.Strings(relsPaths)
+	for _, relsPath := range relsPaths {
+		rels, err := f.relsReader(relsPath)
+		if err != nil {
+			return deps, err
+		}
+		if rels == nil {
+			continue
+		}
+		part := getRelsSourcePart(relsPath)
+		rels.mu.Lock()
+		partsRels[part] = append([]xlsxRelationship{}, rels.Relationships...)
+		rels.mu.Unlock()
+	}
+	// Resolve the worksheets which the drawing parts belong to
+	for part, relationships := range partsRels {
+		sheet, ok := sheets[part]
+		if !ok {
+			continue
+		}
+		for _, rel := range relationships {
+			if rel.Type == SourceRelationshipDrawingML || rel.Type == SourceRelationshipDrawingVML {
+				sheets[getRelsTargetPath(part, rel.Target)] = sheet
+			}
+		}
+	}
+	for _, relsPath := range relsPaths {
+		part := getRelsSourcePart(relsPath)
+		for _, rel := range partsRels[part] {
+			if rel.TargetMode != "External" {
+				continue
+			}
+			dep := Dependency{Type: getDependencyType(rel.Type), Target: rel.Target, Sheet: sheets[part], Part: part}
+			if sheetXMLPath, ok := f.getSheetXMLPath(dep.Sheet); ok && sheetXMLPath == part && dep.Type == DependencyHyperLink {
+				if _, ok = hyperLinks[part]; !ok {
+					refs, err := f.getHyperLinkRefs(dep.Sheet)
+					if err != nil {
+						return deps, err
+					}
+					hyperLinks[part] = refs
+				}
+				dep.Cell = hyperLinks[part][rel.ID]
+			}
+			deps = append(deps, dep)
+		}
+	}
+	ddeLinks, err := f.getDDELinks(partsRels[getRelsSourcePart(f.getWorkbookRelsPath())])
+	if err != nil {
+		return deps, err
+	}
+	deps = append(deps, ddeLinks...)
+	connections, connectionsXML, err := f.connectionsReader()
+	if err != nil || connections == nil {
+		return deps, err
+	}
+	for _, c := range connections.Connection {
+		for _, target := range []string{c.SourceFile, c.OdcFile} {
+			if target != "" {
+				deps = append(deps, Dependency{Type: DependencyConnection, Target: target, Part: connectionsXML})
+			}
+		}
+		if c.DbPr != nil && c.DbPr.Connection != "" {
+			deps = append(deps, Dependency{Type: DependencyConnection, Target: c.DbPr.Connection, Part: connectionsXML})
+		}
+		if c.WebPr != nil && c.WebPr.URL != "" {
+			deps = append(deps, Dependency{Type: DependencyConnection, Target: c.WebPr.URL, Part: connectionsXML})
+		}
+	}
+	return deps, err
+}
+
+// getDDELinks provides a function to get the DDE links in the external link
+// parts by given relationships of the workbook. The target of the dependency
+// will be the service name and topic joined by a vertical bar, which is the
+// same as the syntax of the DDE link formula.
+func (f *File) getDDELinks(relationships []xlsxRelationship) ([]Dependency, error) {
+	var deps []Dependency
+	wbPath := getRelsSourcePart(f.getWorkbookRelsPath())
+	for _, rel := range relationships {
+		if rel.Type != SourceRelationshipExternalLink {
+			continue
+		}
+		part := getRelsTargetPath(wbPath, rel.Target)
+		content, ok := f.Pkg.Load(part)
+		if !ok {
+			continue
+		}
+		var link xlsxExternalLink
+		if err := f.xmlNewDecoder(bytes.NewReader(namespaceStrictToTransitional(content.([]byte)))).
+			Decode(&link); err != nil && err != io.EOF {
+			return deps, err
+		}
+		if link.DdeLink != nil {
+			deps = append(deps, Dependency{Type: DependencyDDELink, Target: link.DdeLink.DdeService + "|" + link.DdeLink.DdeTopic, Part: part})
+		}
+	}
+	return deps, nil
+}
+
+// getDependencyType provides a function to get the external dependency type
+// by given relationship type.
+func getDependencyType(relType string) DependencyType {
+	switch relType {
+	case SourceRelationshipExternalLinkPath:
+		return DependencyExternalLink
+	case SourceRelationshipHyperLink:
+		return DependencyHyperLink
+	case SourceRelationshipOLEObject:
+		return DependencyOLEObject
+	case SourceRelationshipImage:
+		return DependencyLinkedPicture
+	}
+	return DependencyOther
+}
+
+// getHyperLinkRefs provides a function to get the mapping of relationship ID
+// and cell reference of the hyperlinks in the worksheet by given worksheet
+// name.
+func (f *File) getHyperLinkRefs(sheet string) (map[string]string, error) {
+	refs := map[string]string{}
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return refs, err
+	}
+	if ws.Hyperlinks != nil {
+		for _, link := range ws.Hyperlinks.Hyperlink {
+			if link.RID != "" {
+				refs[link.RID] = link.Ref
+			}
+		}
+	}
+	return refs, err
+}
+
+// getRelsSourcePart provides a function to get the path of the source part
+// by given relationships part path, for example, the source part of
+// "xl/worksheets/_rels/sheet1.xml.rels" is "xl/worksheets/sheet1.xml".
+func getRelsSourcePart(relsPath string) string {
+	dir, name := path.Split(strings.TrimSuffix(relsPath, ".rels"))
+	return strings.TrimPrefix(path.Join(strings.TrimSuffix(path.Clean(dir), "_rels"), name), "/")
+}
+
+// getRelsTargetPath provides a function to get the absolute path of the
+// internal relationship target by given source part path and target.
+func getRelsTargetPath(part, target string) string {
+	if strings.HasPrefix(target, "/") {
+		return strings.TrimPrefix(target, "/")
+	}
+	return path.Join(path.Dir(part), target)
+}
+
 // setWorkbook update workbook property of the spreadsheet. Maximum 31
 // characters are allowed in sheet title.
 func (f *File) setWorkbook(name string, sheetID, rid int) {
 	wb, _ := f.workbookReader()
+	f.sheetMapMu.Lock()
+	defer f.sheetMapMu.Unlock()
 	wb.Sheets.Sheet = append(wb.Sheets.Sheet, xlsxSheet{
 		Name:    name,
 		SheetID: sheetID,
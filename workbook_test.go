@@ -1,6 +1,12 @@
 package excelize
 
 import (
+	"encoding/xml"
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -71,6 +77,64 @@ func TestCalcProps(t *testing.T) {
 	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
 }
 
+func TestFileSharing(t *testing.T) {
+	f := NewFile()
+	opts, err := f.GetFileSharing()
+	assert.NoError(t, err)
+	assert.Equal(t, FileSharingOptions{}, opts)
+	// Test set the password to modify with the legacy hash algorithm
+	assert.NoError(t, f.SetFileSharing(FileSharingOptions{Password: "password", ReadOnlyRecommended: true, UserName: "Excelize"}))
+	wb, err := f.workbookReader()
+	assert.NoError(t, err)
+	output, err := xml.Marshal(wb.FileSharing)
+	assert.NoError(t, err)
+	assert.Equal(t, `<xlsxFileSharing readOnlyRecommended="true" userName="Excelize" reservationPassword="83AF"></xlsxFileSharing>`, string(output))
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestFileSharing.xlsx")))
+	assert.NoError(t, f.Close())
+	// Test the file sharing settings are kept after reopen the workbook
+	f, err = OpenFile(filepath.Join("test", "TestFileSharing.xlsx"))
+	assert.NoError(t, err)
+	opts, err = f.GetFileSharing()
+	assert.NoError(t, err)
+	assert.Equal(t, FileSharingOptions{AlgorithmName: "XOR", ReadOnlyRecommended: true, UserName: "Excelize"}, opts)
+	wb, err = f.workbookReader()
+	assert.NoError(t, err)
+	assert.Equal(t, "83AF", wb.FileSharing.ReservationPassword)
+	// Test set the password to modify with the ISO hash algorithm
+	assert.NoError(t, f.SetFileSharing(FileSharingOptions{AlgorithmName: "SHA-512", Password: "password"}))
+	assert.Equal(t, "SHA-512", wb.FileSharing.AlgorithmName)
+	assert.Equal(t, int(workbookProtectionSpinCount), wb.FileSharing.SpinCount)
+	hashValue, _, err := genISOPasswdHash("password", "SHA-512", wb.FileSharing.SaltValue, wb.FileSharing.SpinCount)
+	assert.NoError(t, err)
+	assert.Equal(t, hashValue, wb.FileSharing.HashValue)
+	assert.Empty(t, wb.FileSharing.ReservationPassword)
+	opts, err = f.GetFileSharing()
+	assert.NoError(t, err)
+	assert.Equal(t, FileSharingOptions{AlgorithmName: "SHA-512"}, opts)
+	// Test remove the file sharing settings
+	assert.NoError(t, f.SetFileSharing(FileSharingOptions{}))
+	assert.Nil(t, wb.FileSharing)
+	// Test set the file sharing settings with invalid settings
+	assert.Equal(t, ErrUnsupportedHashAlgorithm, f.SetFileSharing(FileSharingOptions{AlgorithmName: "RIPEMD-160", Password: "password"}))
+	assert.Equal(t, ErrPasswordLengthInvalid, f.SetFileSharing(FileSharingOptions{Password: strings.Repeat("*", MaxFieldLength+1)}))
+	assert.NoError(t, f.Close())
+	// Test get the file sharing settings from the workbook generated by Excel
+	f = NewFile()
+	f.WorkBook = nil
+	f.Pkg.Store(defaultXMLPathWorkbook, []byte(`<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><fileSharing readOnlyRecommended="1" userName="Excel" reservationPassword="83AF"/><sheets><sheet name="Sheet1" sheetId="1" r:id="rId1" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"/></sheets></workbook>`))
+	opts, err = f.GetFileSharing()
+	assert.NoError(t, err)
+	assert.Equal(t, FileSharingOptions{AlgorithmName: "XOR", ReadOnlyRecommended: true, UserName: "Excel"}, opts)
+	// Test set and get the file sharing settings with unsupported charset workbook
+	f.WorkBook = nil
+	f.Pkg.Store(defaultXMLPathWorkbook, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.SetFileSharing(FileSharingOptions{}), "XML syntax error on line 1: invalid UTF-8")
+	f.WorkBook = nil
+	f.Pkg.Store(defaultXMLPathWorkbook, MacintoshCyrillicCharset)
+	_, err = f.GetFileSharing()
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+}
+
 func TestDeleteWorkbookRels(t *testing.T) {
 	f := NewFile()
 	// Test delete pivot table without worksheet relationships
@@ -80,3 +144,99 @@ func TestDeleteWorkbookRels(t *testing.T) {
 	assert.Empty(t, rID)
 	assert.NoError(t, err)
 }
+
+func TestGetExternalDependencies(t *testing.T) {
+	f := NewFile()
+	deps, err := f.GetExternalDependencies()
+	assert.NoError(t, err)
+	assert.Empty(t, deps)
+	assert.NoError(t, f.SetCellHyperLink("Sheet1", "A1", "https://github.com/xuri/excelize", "External"))
+	assert.NoError(t, f.SetCellHyperLink("Sheet1", "B2", "Sheet1!A1", "Location"))
+	assert.NoError(t, f.AddPicture("Sheet1", "C3", filepath.Join("test", "images", "excel.png"), nil))
+	f.addRels("xl/drawings/_rels/drawing1.xml.rels", SourceRelationshipImage, "https://github.com/xuri/excelize/logo.png", "External")
+	f.addRels("xl/worksheets/_rels/sheet1.xml.rels", SourceRelationshipOLEObject, "file:///C:\\Docs\\Report.docx", "External")
+	f.Pkg.Store("xl/externalLinks/externalLink1.xml", []byte(`<externalLink xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"><externalBook r:id="rId1"><sheetNames><sheetName val="Sheet1"/></sheetNames></externalBook></externalLink>`))
+	f.addRels("xl/externalLinks/_rels/externalLink1.xml.rels", SourceRelationshipExternalLinkPath, "Budget.xlsx", "External")
+	f.Pkg.Store(defaultXMLPathConnections, []byte(`<connections xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><connection id="1" sourceFile="C:\Data\Sales.accdb" name="Sales" type="1" refreshedVersion="8"><dbPr connection="DSN=Sales" command="SELECT * FROM Sales"/></connection></connections>`))
+	f.addRels(f.getWorkbookRelsPath(), SourceRelationshipConnections, "connections.xml", "")
+	expected := []Dependency{
+		{Type: DependencyLinkedPicture, Target: "https://github.com/xuri/excelize/logo.png", Sheet: "Sheet1", Part: "xl/drawings/drawing1.xml"},
+		{Type: DependencyExternalLink, Target: "Budget.xlsx", Part: "xl/externalLinks/externalLink1.xml"},
+		{Type: DependencyHyperLink, Target: "https://github.com/xuri/excelize", Sheet: "Sheet1", Cell: "A1", Part: "xl/worksheets/sheet1.xml"},
+		{Type: DependencyOLEObject, Target: "file:///C:\\Docs\\Report.docx", Sheet: "Sheet1", Part: "xl/worksheets/sheet1.xml"},
+		{Type: DependencyConnection, Target: "C:\\Data\\Sales.accdb", Part: defaultXMLPathConnections},
+		{Type: DependencyConnection, Target: "DSN=Sales", Part: defaultXMLPathConnections},
+	}
+	deps, err = f.GetExternalDependencies()
+	assert.NoError(t, err)
+	assert.Equal(t, expected, deps)
+	// Test get external dependencies after save and reopen the workbook
+	path := filepath.Join("test", "TestGetExternalDependencies.xlsx")
+	assert.NoError(t, f.SaveAs(path))
+	assert.NoError(t, f.Close())
+	f, err = OpenFile(path)
+	assert.NoError(t, err)
+	deps, err = f.GetExternalDependencies()
+	assert.NoError(t, err)
+	assert.Equal(t, expected, deps)
+	assert.NoError(t, f.Close())
+	// Test get external dependencies with DDE link, OLE link and web query
+	f = NewFile()
+	wb, err := f.workbookReader()
+	assert.NoError(t, err)
+	wb.ExternalReferences = &xlsxExternalReferences{}
+	for i, link := range []string{
+		`<ddeLink ddeService="WINWORD" ddeTopic="C:\Docs\Report.docx"><ddeItems><ddeItem name="Summary" advise="1"/></ddeItems></ddeLink>`,
+		`<oleLink r:id="rId1" progId="Word.Document.12"><oleItems><oleItem name="'" advise="1" preferPic="1"/></oleItems></oleLink>`,
+	} {
+		linkXML := fmt.Sprintf("xl/externalLinks/externalLink%d.xml", i+1)
+		f.Pkg.Store(linkXML, []byte(`<externalLink xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">`+link+`</externalLink>`))
+		f.ContentTypes.Overrides = append(f.ContentTypes.Overrides, xlsxOverride{PartName: "/" + linkXML, ContentType: "application/vnd.openxmlformats-officedocument.spreadsheetml.externalLink+xml"})
+		rID := f.addRels(f.getWorkbookRelsPath(), SourceRelationshipExternalLink, strings.TrimPrefix(linkXML, "xl/"), "")
+		wb.ExternalReferences.ExternalReference = append(wb.ExternalReferences.ExternalReference, xlsxExternalReference{RID: "rId" + strconv.Itoa(rID)})
+	}
+	f.addRels("xl/externalLinks/_rels/externalLink2.xml.rels", SourceRelationshipOLEObject, "file:///C:\\Docs\\Report.docx", "External")
+	f.Pkg.Store(defaultXMLPathConnections, []byte(`<connections xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><connection id="1" name="Rates" type="4" refreshedVersion="8" background="1" saveData="1"><webPr sourceData="1" parsePre="1" consecutive="1" xl2000="1" url="https://example.com/rates.html" htmlTables="1"><tables count="1"><x v="1"/></tables></webPr></connection></connections>`))
+	f.addRels(f.getWorkbookRelsPath(), SourceRelationshipConnections, "connections.xml", "")
+	expected = []Dependency{
+		{Type: DependencyOLEObject, Target: "file:///C:\\Docs\\Report.docx", Part: "xl/externalLinks/externalLink2.xml"},
+		{Type: DependencyDDELink, Target: "WINWORD|C:\\Docs\\Report.docx", Part: "xl/externalLinks/externalLink1.xml"},
+		{Type: DependencyConnection, Target: "https://example.com/rates.html", Part: defaultXMLPathConnections},
+	}
+	deps, err = f.GetExternalDependencies()
+	assert.NoError(t, err)
+	assert.Equal(t, expected, deps)
+	path = filepath.Join("test", "TestGetExternalDependencies2.xlsx")
+	assert.NoError(t, f.SaveAs(path))
+	assert.NoError(t, f.Close())
+	f, err = OpenFile(path)
+	assert.NoError(t, err)
+	deps, err = f.GetExternalDependencies()
+	assert.NoError(t, err)
+	assert.Equal(t, expected, deps)
+	assert.NoError(t, f.Close())
+	// Test get external dependencies with unsupported charset external link
+	f = NewFile()
+	f.addRels(f.getWorkbookRelsPath(), SourceRelationshipExternalLink, "externalLinks/externalLink1.xml", "")
+	f.Pkg.Store("xl/externalLinks/externalLink1.xml", MacintoshCyrillicCharset)
+	_, err = f.GetExternalDependencies()
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	// Test get external dependencies with unsupported charset relationships
+	f = NewFile()
+	f.Pkg.Store("xl/drawings/_rels/drawing1.xml.rels", MacintoshCyrillicCharset)
+	_, err = f.GetExternalDependencies()
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	// Test get external dependencies with unsupported charset worksheet
+	f = NewFile()
+	assert.NoError(t, f.SetCellHyperLink("Sheet1", "A1", "https://github.com/xuri/excelize", "External"))
+	f.Sheet.Delete("xl/worksheets/sheet1.xml")
+	f.Pkg.Store("xl/worksheets/sheet1.xml", MacintoshCyrillicCharset)
+	f.checked = sync.Map{}
+	_, err = f.GetExternalDependencies()
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	// Test get external dependencies with unsupported charset connections
+	f = NewFile()
+	f.Pkg.Store(defaultXMLPathConnections, MacintoshCyrillicCharset)
+	_, err = f.GetExternalDependencies()
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+}
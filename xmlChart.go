@@ -412,10 +412,10 @@ type cSer struct {
 	Order            *attrValInt  `xml:"order"`
 	Tx               *cTx         `xml:"tx"`
 	SpPr             *cSpPr       `xml:"spPr"`
-	DPt              []*cDPt      `xml:"dPt"`
-	DLbls            *cDLbls      `xml:"dLbls"`
 	Marker           *cMarker     `xml:"marker"`
 	InvertIfNegative *attrValBool `xml:"invertIfNegative"`
+	DPt              []*cDPt      `xml:"dPt"`
+	DLbls            *cDLbls      `xml:"dLbls"`
 	Cat              *cCat        `xml:"cat"`
 	Val              *cVal        `xml:"val"`
 	XVal             *cCat        `xml:"xVal"`
@@ -633,6 +633,14 @@ type ChartDataLabel struct {
 	Fill      Fill
 }
 
+// ChartDataPoint directly maps the format settings of the single data point
+// in the chart series.
+type ChartDataPoint struct {
+	Index  int
+	Fill   Fill
+	Border ChartLine
+}
+
 // ChartSeries directly maps the format settings of the chart series.
 type ChartSeries struct {
 	Name              string
@@ -644,4 +652,5 @@ type ChartSeries struct {
 	Marker            ChartMarker
 	DataLabel         ChartDataLabel
 	DataLabelPosition ChartDataLabelPositionType
+	DataPoints        []ChartDataPoint
 }
@@ -72,6 +72,24 @@ type xlsxPhoneticRun struct {
 	T  string `xml:"t"`
 }
 
+// xlsxPersonList directly maps the personList element. This element is the
+// root element of the persons part, which specifies the authors of the
+// threaded comments in the workbook.
+type xlsxPersonList struct {
+	XMLName xml.Name     `xml:"personList"`
+	Person  []xlsxPerson `xml:"person"`
+}
+
+// xlsxPerson directly maps the person element. This element specifies an
+// author of the threaded comments, the display name and the identity of the
+// user in the identity provider.
+type xlsxPerson struct {
+	DisplayName string `xml:"displayName,attr"`
+	ID          string `xml:"id,attr"`
+	UserID      string `xml:"userId,attr,omitempty"`
+	ProviderID  string `xml:"providerId,attr,omitempty"`
+}
+
 // Comment directly maps the comment information.
 type Comment struct {
 	Author    string
@@ -0,0 +1,98 @@
+// Copyright 2016 - 2025 The excelize Authors. All rights reserved. Use of
+// this source code is governed by a BSD-style license that can be found in
+// the LICENSE file.
+//
+// Package excelize providing a set of functions that allow you to write to and
+// read from XLAM / XLSM / XLSX / XLTM / XLTX files. Supports reading and
+// writing spreadsheet documents generated by Microsoft Excel™ 2007 and later.
+// Supports complex components by high compatibility, and provided streaming
+// API for generating or reading data from a worksheet with huge amounts of
+// data. This library needs Go version 1.23 or later.
+
+package excelize
+
+import "encoding/xml"
+
+// xlsxConnections directly maps the connections element. This element
+// specifies a list of all external data connections used in the workbook,
+// such as the OLE DB connections created by Power Query.
+type xlsxConnections struct {
+	XMLName    xml.Name         `xml:"connections"`
+	XMLNS      string           `xml:"xmlns,attr"`
+	Connection []xlsxConnection `xml:"connection"`
+}
+
+// xlsxConnection directly maps the connection element. This element specifies
+// the properties for an external data connection used to refresh the data in
+// the workbook.
+type xlsxConnection struct {
+	ID                    int           `xml:"id,attr"`
+	SourceFile            string        `xml:"sourceFile,attr,omitempty"`
+	OdcFile               string        `xml:"odcFile,attr,omitempty"`
+	KeepAlive             bool          `xml:"keepAlive,attr,omitempty"`
+	Interval              int           `xml:"interval,attr,omitempty"`
+	Name                  string        `xml:"name,attr,omitempty"`
+	Description           string        `xml:"description,attr,omitempty"`
+	Type                  int           `xml:"type,attr,omitempty"`
+	ReconnectionMethod    int           `xml:"reconnectionMethod,attr,omitempty"`
+	RefreshedVersion      int           `xml:"refreshedVersion,attr"`
+	MinRefreshableVersion int           `xml:"minRefreshableVersion,attr,omitempty"`
+	SavePassword          bool          `xml:"savePassword,attr,omitempty"`
+	New                   bool          `xml:"new,attr,omitempty"`
+	Deleted               bool          `xml:"deleted,attr,omitempty"`
+	OnlyUseConnectionFile bool          `xml:"onlyUseConnectionFile,attr,omitempty"`
+	Background            bool          `xml:"background,attr,omitempty"`
+	RefreshOnLoad         bool          `xml:"refreshOnLoad,attr,omitempty"`
+	SaveData              bool          `xml:"saveData,attr,omitempty"`
+	Credentials           string        `xml:"credentials,attr,omitempty"`
+	SingleSignOnID        string        `xml:"singleSignOnId,attr,omitempty"`
+	DbPr                  *xlsxDbPr     `xml:"dbPr"`
+	OlapPr                *xlsxInnerXML `xml:"olapPr"`
+	WebPr                 *xlsxWebPr    `xml:"webPr"`
+	TextPr                *xlsxInnerXML `xml:"textPr"`
+	Parameters            *xlsxInnerXML `xml:"parameters"`
+	ExtLst                *xlsxExtLst   `xml:"extLst"`
+}
+
+// xlsxDbPr directly maps the dbPr element. This element specifies all
+// properties relating to an ODBC or OLE DB external data connection.
+type xlsxDbPr struct {
+	Connection    string `xml:"connection,attr"`
+	Command       string `xml:"command,attr,omitempty"`
+	ServerCommand string `xml:"serverCommand,attr,omitempty"`
+	CommandType   int    `xml:"commandType,attr,omitempty"`
+}
+
+// xlsxWebPr directly maps the webPr element. This element specifies the
+// properties for a web query connection, only the URL of the web page is
+// mapped.
+type xlsxWebPr struct {
+	URL     string `xml:"url,attr,omitempty"`
+	Content string `xml:",innerxml"`
+}
+
+// xlsxQueryTable directly maps the queryTable element. This element is the
+// root element of a query table part, which describes how a table is
+// connected to an external data source. Only the attributes required to
+// resolve the connection are mapped.
+type xlsxQueryTable struct {
+	XMLName      xml.Name `xml:"queryTable"`
+	Name         string   `xml:"name,attr"`
+	ConnectionID int      `xml:"connectionId,attr"`
+}
+
+// ConnectionInfo directly maps the settings of the workbook external data
+// connection.
+type ConnectionInfo struct {
+	ID                int
+	Name              string
+	Description       string
+	Type              string
+	Connection        string
+	Command           string
+	RefreshOnLoad     bool
+	BackgroundRefresh bool
+	SaveData          bool
+	RefreshInterval   int
+	QueryTables       []string
+}
@@ -46,6 +46,18 @@ type decodeCellAnchorPos struct {
 	ClientData       *xlsxInnerXML           `xml:"clientData"`
 }
 
+// decodeDrawingObject defines the structure used to deserialize the
+// non-visual drawing properties of the object in the cell anchor.
+type decodeDrawingObject struct {
+	From         *decodeFrom  `xml:"from"`
+	Sp           *decodeCNvPr `xml:"sp>nvSpPr>cNvPr"`
+	GrpSp        *decodeCNvPr `xml:"grpSp>nvGrpSpPr>cNvPr"`
+	GraphicFrame *decodeCNvPr `xml:"graphicFrame>nvGraphicFramePr>cNvPr"`
+	CxnSp        *decodeCNvPr `xml:"cxnSp>nvCxnSpPr>cNvPr"`
+	Pic          *decodeCNvPr `xml:"pic>nvPicPr>cNvPr"`
+	Slicer       *decodeCNvPr `xml:"AlternateContent>Choice>graphicFrame>nvGraphicFramePr>cNvPr"`
+}
+
 // decodeChoice defines the structure used to deserialize the mc:Choice element.
 type decodeChoice struct {
 	XMLName      xml.Name           `xml:"Choice"`
@@ -117,11 +129,37 @@ type decodeWsDr struct {
 // information that does not affect the appearance of the picture to be
 // stored.
 type decodeCNvPr struct {
-	XMLName xml.Name `xml:"cNvPr"`
-	ID      int      `xml:"id,attr"`
-	Name    string   `xml:"name,attr"`
-	Descr   string   `xml:"descr,attr"`
-	Title   string   `xml:"title,attr,omitempty"`
+	XMLName    xml.Name           `xml:"cNvPr"`
+	ID         int                `xml:"id,attr"`
+	Name       string             `xml:"name,attr"`
+	Descr      string             `xml:"descr,attr"`
+	Title      string             `xml:"title,attr,omitempty"`
+	HlinkClick *decodeHlinkClick  `xml:"hlinkClick"`
+	ExtLst     *decodeCNvPrExtLst `xml:"extLst"`
+}
+
+// decodeHlinkClick directly maps the hlinkClick (Click Hyperlink) element.
+type decodeHlinkClick struct {
+	RID string `xml:"http://schemas.openxmlformats.org/officeDocument/2006/relationships id,attr"`
+}
+
+// decodeCNvPrExtLst directly maps the extLst element of the non-visual
+// drawing properties.
+type decodeCNvPrExtLst struct {
+	Ext []decodeCNvPrExt `xml:"ext"`
+}
+
+// decodeCNvPrExt directly maps the ext element of the non-visual drawing
+// properties extension list.
+type decodeCNvPrExt struct {
+	URI        string            `xml:"uri,attr"`
+	Decorative *decodeDecorative `xml:"decorative"`
+}
+
+// decodeDecorative directly maps the decorative element of the drawing
+// object.
+type decodeDecorative struct {
+	Val string `xml:"val,attr"`
 }
 
 // decodePicLocks directly maps the picLocks (Picture Locks). This element
@@ -20,11 +20,34 @@ import (
 // element specifies non-visual canvas properties. This allows for additional
 // information that does not affect the appearance of the picture to be stored.
 type xlsxCNvPr struct {
-	ID         int             `xml:"id,attr"`
-	Name       string          `xml:"name,attr"`
-	Descr      string          `xml:"descr,attr"`
-	Title      string          `xml:"title,attr,omitempty"`
-	HlinkClick *xlsxHlinkClick `xml:"a:hlinkClick"`
+	ID         int              `xml:"id,attr"`
+	Name       string           `xml:"name,attr"`
+	Descr      string           `xml:"descr,attr"`
+	Title      string           `xml:"title,attr,omitempty"`
+	HlinkClick *xlsxHlinkClick  `xml:"a:hlinkClick"`
+	ExtLst     *xlsxCNvPrExtLst `xml:"a:extLst"`
+}
+
+// xlsxCNvPrExtLst directly maps the extLst element of the non-visual drawing
+// properties, which used to store the accessibility extensions of the drawing
+// object.
+type xlsxCNvPrExtLst struct {
+	Ext []xlsxCNvPrExt `xml:"a:ext"`
+}
+
+// xlsxCNvPrExt directly maps the ext element of the non-visual drawing
+// properties extension list.
+type xlsxCNvPrExt struct {
+	URI        string          `xml:"uri,attr"`
+	Decorative *xlsxDecorative `xml:"adec:decorative"`
+}
+
+// xlsxDecorative directly maps the decorative element. This element specifies
+// the drawing object is decorative, which means the object adds visual
+// interest but isn't informative and the screen readers skip it.
+type xlsxDecorative struct {
+	XMLNSAdec string `xml:"xmlns:adec,attr"`
+	Val       bool   `xml:"val,attr"`
 }
 
 // xlsxHlinkClick (Click Hyperlink) Specifies the on-click hyperlink
@@ -274,6 +297,7 @@ type xlsxPoint2D struct {
 // wsDr.
 type xlsxWsDr struct {
 	mu               sync.Mutex
+	objectNames      map[string]bool
 	XMLName          xml.Name                `xml:"xdr:wsDr"`
 	NS               string                  `xml:"xmlns,attr,omitempty"`
 	A                string                  `xml:"xmlns:a,attr,omitempty"`
@@ -433,6 +457,8 @@ type Picture struct {
 // GraphicOptions directly maps the format settings of the picture.
 type GraphicOptions struct {
 	AltText             string
+	Title               string
+	Decorative          bool
 	PrintObject         *bool
 	Locked              *bool
 	LockAspectRatio     bool
@@ -447,6 +473,19 @@ type GraphicOptions struct {
 	Positioning         string
 }
 
+// DrawingObject directly maps the drawing object in the worksheet. The Anchor
+// is one of "absoluteAnchor", "oneCellAnchor" and "twoCellAnchor", the Type is
+// one of "Chart", "Connector", "Group", "Picture", "Shape" and "Slicer", the
+// Cell is the top-left cell of the object, and the ZIndex is the stacking
+// order of the object in the drawing part, the larger one is on the top.
+type DrawingObject struct {
+	Name   string
+	Type   string
+	Anchor string
+	Cell   string
+	ZIndex int
+}
+
 // Shape directly maps the format settings of the shape.
 type Shape struct {
 	Cell      string
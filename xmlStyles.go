@@ -337,6 +337,18 @@ type Border struct {
 	Style int
 }
 
+// Color directly maps the color settings of the spreadsheet elements. The RGB
+// specifies the Alpha Red Green Blue or Red Green Blue color value, the
+// Indexed specifies the index into the indexed color palette, the Theme
+// specifies the zero-based index into the theme color scheme, and the Tint
+// specifies the tint value applied to the color, between -1.0 and 1.0.
+type Color struct {
+	RGB     string
+	Indexed *int
+	Theme   *int
+	Tint    float64
+}
+
 // Font directly maps the font settings of the fonts.
 type Font struct {
 	Bold         bool
@@ -352,6 +364,32 @@ type Font struct {
 	VertAlign    string
 }
 
+// FontMetrics directly maps the metrics of a font for measuring the width of
+// the text. The MaxDigitWidth specifies the width of the widest digit in
+// pixels, which will be used for converting the column width between the
+// number of characters and pixels if it is the metrics of the default font.
+// The CharWidths specifies the width of the characters in pixels, and the
+// DefaultCharWidth specifies the width in pixels of the characters not in the
+// CharWidths, the MaxDigitWidth will be used if it is zero. All widths are
+// measured at the font size of 11 points.
+type FontMetrics struct {
+	MaxDigitWidth    float64
+	DefaultCharWidth float64
+	CharWidths       map[rune]float64
+}
+
+// EmbeddedFont directly maps the font embedded in the workbook. The Name is
+// the font family name read from the font data, or the file name of the font
+// part if the font data can't be parsed. The name of the obfuscated font will
+// be read after restoring the font data by the GUID in the file name of the
+// font part. The Path is the path of the font part in the workbook, and the
+// Data is the raw bytes of the font part as stored in the workbook.
+type EmbeddedFont struct {
+	Name string
+	Path string
+	Data []byte
+}
+
 // Fill directly maps the fill settings of the cells.
 type Fill struct {
 	Type    string
@@ -39,7 +39,7 @@ type xlsxWorkbook struct {
 	XMLName                xml.Name                 `xml:"http://schemas.openxmlformats.org/spreadsheetml/2006/main workbook"`
 	Conformance            string                   `xml:"conformance,attr,omitempty"`
 	FileVersion            *xlsxFileVersion         `xml:"fileVersion"`
-	FileSharing            *xlsxExtLst              `xml:"fileSharing"`
+	FileSharing            *xlsxFileSharing         `xml:"fileSharing"`
 	WorkbookPr             *xlsxWorkbookPr          `xml:"workbookPr"`
 	AlternateContent       *xlsxAlternateContent    `xml:"mc:AlternateContent"`
 	DecodeAlternateContent *xlsxInnerXML            `xml:"http://schemas.openxmlformats.org/markup-compatibility/2006 AlternateContent"`
@@ -95,6 +95,20 @@ type xlsxWorkbookProtection struct {
 	WorkbookSpinCount      int    `xml:"workbookSpinCount,attr,omitempty"`
 }
 
+// xlsxFileSharing directly maps the fileSharing element. This element
+// specifies the file sharing settings of the workbook, such as whether the
+// workbook is recommended to be opened as read-only, and the password to
+// modify the workbook.
+type xlsxFileSharing struct {
+	ReadOnlyRecommended bool   `xml:"readOnlyRecommended,attr,omitempty"`
+	UserName            string `xml:"userName,attr,omitempty"`
+	ReservationPassword string `xml:"reservationPassword,attr,omitempty"`
+	AlgorithmName       string `xml:"algorithmName,attr,omitempty"`
+	HashValue           string `xml:"hashValue,attr,omitempty"`
+	SaltValue           string `xml:"saltValue,attr,omitempty"`
+	SpinCount           int    `xml:"spinCount,attr,omitempty"`
+}
+
 // xlsxFileVersion directly maps the fileVersion element. This element defines
 // properties that track which version of the application accessed the data and
 // source code contained in the file.
@@ -416,3 +430,97 @@ type WorkbookProtectionOptions struct {
 	LockStructure bool
 	LockWindows   bool
 }
+
+// FileSharingOptions directly maps the settings of the workbook file sharing.
+// The Password is the password to modify the workbook, which can't be read
+// back from the workbook.
+type FileSharingOptions struct {
+	AlgorithmName       string
+	Password            string
+	ReadOnlyRecommended bool
+	UserName            string
+}
+
+// DependencyType is the type of workbook external dependency.
+type DependencyType byte
+
+// This section defines the currently supported external dependency types
+// enumeration.
+const (
+	DependencyExternalLink DependencyType = iota
+	DependencyHyperLink
+	DependencyOLEObject
+	DependencyConnection
+	DependencyLinkedPicture
+	DependencyDDELink
+	DependencyOther
+)
+
+// xlsxExternalLink directly maps the externalLink element. This element is
+// the root element of an external link part, only the DDE link is mapped,
+// the external workbook and OLE link sources are referenced by the
+// relationships of the part.
+type xlsxExternalLink struct {
+	XMLName xml.Name     `xml:"externalLink"`
+	DdeLink *xlsxDdeLink `xml:"ddeLink"`
+}
+
+// xlsxDdeLink directly maps the ddeLink element. This element specifies a
+// DDE (Dynamic Data Exchange) link by given service name and topic.
+type xlsxDdeLink struct {
+	DdeService string `xml:"ddeService,attr"`
+	DdeTopic   string `xml:"ddeTopic,attr"`
+}
+
+// Dependency directly maps the external resource which the workbook might
+// reach for. The Target field is the location of the resource, such as a
+// file path, URL or connection string. The Part field is the path of the
+// package part which references the resource, and the Sheet and Cell fields
+// specify the worksheet and cell reference where the resource referenced if
+// they can be resolved.
+type Dependency struct {
+	Type   DependencyType
+	Target string
+	Sheet  string
+	Cell   string
+	Part   string
+}
+
+// InspectionItem directly maps an item of the personal information or hidden
+// content found by the document inspector. The Part field is the path of the
+// package part which contains the item, the Sheet and Ref fields specify the
+// worksheet name and the property name, cell range or defined name of the
+// item if applicable, and the Value field is the content of the item.
+type InspectionItem struct {
+	Part  string
+	Sheet string
+	Ref   string
+	Value string
+}
+
+// InspectionReport directly maps the result of the document inspector.
+type InspectionReport struct {
+	DocProps               []InspectionItem
+	CommentAuthors         []InspectionItem
+	ThreadedCommentPersons []InspectionItem
+	PrinterSettings        []InspectionItem
+	AbsolutePath           []InspectionItem
+	HiddenSheets           []InspectionItem
+	HiddenRows             []InspectionItem
+	HiddenCols             []InspectionItem
+	HiddenNames            []InspectionItem
+	ExternalDependencies   []Dependency
+}
+
+// StripOptions directly maps the categories of the personal information and
+// hidden content to be removed by the document inspector.
+type StripOptions struct {
+	DocProps        bool
+	Comments        bool
+	PrinterSettings bool
+	AbsolutePath    bool
+	HiddenSheets    bool
+	HiddenRows      bool
+	HiddenCols      bool
+	HiddenNames     bool
+}
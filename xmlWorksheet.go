@@ -14,6 +14,7 @@ package excelize
 import (
 	"encoding/xml"
 	"sync"
+	"sync/atomic"
 )
 
 // xlsxWorksheet directly maps the worksheet element in the namespace
@@ -63,6 +64,8 @@ type xlsxWorksheet struct {
 	TableParts             *xlsxTableParts              `xml:"tableParts"`
 	ExtLst                 *xlsxExtLst                  `xml:"extLst"`
 	DecodeAlternateContent *xlsxInnerXML                `xml:"http://schemas.openxmlformats.org/markup-compatibility/2006 AlternateContent"`
+	generation             atomic.Uint64
+	flushedGeneration      uint64
 }
 
 // xlsxDrawing change r:id to rid in the namespace.
@@ -886,6 +889,13 @@ type DataValidation struct {
 	Formula2         string
 }
 
+// ColValidationOptions directly maps the settings of the column data
+// validation. The HeaderRows specifies the number of rows at the top of the
+// column which will not be validated.
+type ColValidationOptions struct {
+	HeaderRows int
+}
+
 // SparklineOptions directly maps the settings of the sparkline.
 type SparklineOptions struct {
 	Location      []string
@@ -935,6 +945,32 @@ type Panes struct {
 	Selection   []Selection
 }
 
+// SplitPaneOptions directly maps the settings of the adjustable split panes.
+// The split position could be given by the Cell, or by the XSplit and YSplit
+// in 1/20th of a point.
+type SplitPaneOptions struct {
+	Cell        string
+	XSplit      float64
+	YSplit      float64
+	TopLeftCell string
+	ActivePane  string
+	Selection   []Selection
+}
+
+// ZebraOptions directly maps the settings of the alternating row fills. The
+// Color specifies the fill color of the striped bands, the AlternateColor
+// specifies the fill color of the bands between the striped bands which will
+// be left unfilled by default, the BandSize specifies the number of rows in
+// each band, and the header row of the range will be filled with the
+// HeaderColor in bold font and excluded from the bands if the Header is true.
+type ZebraOptions struct {
+	Color          string
+	AlternateColor string
+	BandSize       int
+	Header         bool
+	HeaderColor    string
+}
+
 // ConditionalFormatOptions directly maps the conditional format settings of the cells.
 type ConditionalFormatOptions struct {
 	Type           string
@@ -964,6 +1000,13 @@ type ConditionalFormatOptions struct {
 }
 
 // SheetProtectionOptions directly maps the settings of worksheet protection.
+// Each boolean field grants users the corresponding action on the protected
+// worksheet, it will be written as the inverted attribute of the
+// sheetProtection element, for example, setting FormatRows to true writes
+// formatRows="0". The EditObjects and EditScenarios fields map to the objects
+// and scenarios attributes. Expanding or collapsing outline groups hides and
+// unhides rows and columns, so both FormatRows and FormatColumns need to be
+// allowed for users to operate the outline symbols of a protected worksheet.
 type SheetProtectionOptions struct {
 	AlgorithmName       string
 	AutoFilter          bool
@@ -1034,6 +1077,49 @@ type PageLayoutOptions struct {
 	PageOrder *string
 }
 
+// PrintLayout directly maps the settings which affect the pagination of a
+// worksheet when it is printed. The column widths are in characters, the same
+// unit as the GetColWidth function, and the row heights are in points. The
+// RowBreaks and ColBreaks are the 1-based row and column numbers after which
+// a manual page break is placed.
+type PrintLayout struct {
+	PageLayout         PageLayoutOptions
+	PageMargins        PageLayoutMarginsOptions
+	HeaderFooter       *HeaderFooterOptions
+	HeaderFooterImages []HeaderFooterImageOptions
+	PrintArea          string
+	PrintTitles        string
+	RowBreaks          []int
+	ColBreaks          []int
+	HiddenRows         []int
+	HiddenCols         []string
+	DefaultColWidth    float64
+	DefaultRowHeight   float64
+	ColWidths          map[string]float64
+	RowHeights         map[int]float64
+}
+
+// AutoFitOptions directly maps the settings of auto fit column width. The
+// MinWidth and MaxWidth specify the lower and upper bound of the column width
+// in characters, the MaxWidth will be capped at 255 characters. The
+// ConsiderBold specifies if measure the cells with bold font style, such as
+// the header rows, wider than the regular cells.
+type AutoFitOptions struct {
+	MinWidth     float64
+	MaxWidth     float64
+	ConsiderBold bool
+}
+
+// GroupOptions directly maps the settings of the columns group. The
+// SummaryLeft specifies if the summary column is on the left of the group,
+// the summary column is on the right of the group by default. The Collapsed
+// specifies if the group starts collapsed, which hides the columns of the
+// group and marks the summary column as collapsed.
+type GroupOptions struct {
+	SummaryLeft bool
+	Collapsed   bool
+}
+
 // ViewOptions directly maps the settings of sheet view.
 type ViewOptions struct {
 	// DefaultGridColor indicating that the consuming application should use
@@ -1072,6 +1158,26 @@ type ViewOptions struct {
 	ZoomScale *float64
 }
 
+// SheetViewDefaults directly maps the settings of the sheet views applied to
+// the worksheets by the ApplySheetViewDefaults function.
+type SheetViewDefaults struct {
+	// View specifies the view options, such as the zoom scale and the grid
+	// lines visibility.
+	View ViewOptions
+	// FreezeCols specifies the number of the leading columns to be frozen, the
+	// panes will not be changed if both FreezeCols and FreezeRows are 0.
+	FreezeCols int
+	// FreezeRows specifies the number of the leading rows to be frozen, such as
+	// 1 for freezing the header row.
+	FreezeRows int
+	// Selection specifies the space-separated selected ranges, and the
+	// ActiveCell specifies the active cell which must be in one of the
+	// selected ranges. The selection will not be changed if the ActiveCell is
+	// empty.
+	Selection  string
+	ActiveCell string
+}
+
 // SheetPropsOptions provides a function to set worksheet properties. There 4
 // kinds of presets "Custom Scaling Options" in the spreadsheet applications, if
 // you need to set those kind of scaling options, please using the
@@ -1181,3 +1287,61 @@ type SheetPropsOptions struct {
 	// ThickBottom specifies if rows have a thick bottom border by default.
 	ThickBottom *bool
 }
+
+// SheetFormatPrOptions directly maps the settings of the sheet formatting
+// properties, which specifies the default column width and row height of the
+// worksheet.
+type SheetFormatPrOptions struct {
+	// DefaultColWidth specifies the default column width measured as the
+	// number of characters of the maximum digit width of the normal style's
+	// font, which applies to the columns without explicit width.
+	DefaultColWidth *float64
+	// DefaultRowHeight specifies the default row height measured in point
+	// size, which applies to the rows without explicit height when the
+	// CustomHeight is true.
+	DefaultRowHeight *float64
+	// CustomHeight specifies if the default row height has been manually set.
+	CustomHeight *bool
+	// ZeroHeight specifies if rows are hidden by default.
+	ZeroHeight *bool
+	// BaseColWidth specifies the number of characters of the maximum digit
+	// width of the normal style's font, which is used when the DefaultColWidth
+	// is not specified.
+	BaseColWidth *uint8
+	// OutlineLevelCol specifies the maximum outline level of the columns.
+	OutlineLevelCol *uint8
+	// OutlineLevelRow specifies the maximum outline level of the rows.
+	OutlineLevelRow *uint8
+}
+
+// TOCOptions directly maps the settings of the table of contents worksheet
+// generated by the AddTableOfContents function.
+type TOCOptions struct {
+	// Sheet specifies the name of the table of contents worksheet, the
+	// default value is "Contents".
+	Sheet string
+	// Title specifies the title written in the first row of the table of
+	// contents, the entries start from the first row if it is empty.
+	Title string
+	// TitleStyle specifies the style ID of the title cell.
+	TitleStyle int
+	// LinkStyle specifies the style ID of the hyperlink cells, a blue and
+	// underlined font style will be used if it is 0.
+	LinkStyle int
+	// DescriptionStyle specifies the style ID of the description cells.
+	DescriptionStyle int
+	// Descriptions specifies the description of the worksheets by the
+	// worksheet names, which will be written next to the hyperlinks.
+	Descriptions map[string]string
+	// DescriptionFromFirstCell specifies if use the value of the cell A1 of
+	// the worksheet as the description when the description of the worksheet
+	// isn't specified by the Descriptions.
+	DescriptionFromFirstCell bool
+	// BackLinkCell specifies the cell reference of each worksheet to set a
+	// hyperlink back to the table of contents, no back links will be set if
+	// it is empty.
+	BackLinkCell string
+	// BackLinkText specifies the value of the back link cells, the default
+	// value is "Back to contents".
+	BackLinkText string
+}